@@ -0,0 +1,233 @@
+// Package cli 서버 대신 실행하는 운영 하위 명령 (mc-iam-manager <command>)
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const cliUsage = `Usage: mc-iam-manager [command]
+
+Without a command the API server is started.
+
+Commands:
+  audit verify [-chain name] [-json]   Verify the audit hash chains and signed checkpoints
+  audit checkpoint                     Sign a checkpoint for chains with new records
+  roles bulk-assign -file path [-format csv|yaml] [-dry-run] [-json]
+                                       Assign roles and group memberships from CSV or YAML rows
+`
+
+// Run 하위 명령 실행 후 종료 코드 반환 (0 성공, 1 검증 실패, 2 사용법/실행 오류)
+func Run(args []string) int {
+	if len(args) >= 2 && args[0] == "audit" {
+		switch args[1] {
+		case "verify":
+			return runAuditVerify(args[2:], os.Stdout)
+		case "checkpoint":
+			return runAuditCheckpoint(os.Stdout)
+		}
+	}
+	if len(args) >= 2 && args[0] == "roles" && args[1] == "bulk-assign" {
+		return runRolesBulkAssign(args[2:], os.Stdout)
+	}
+	fmt.Fprint(os.Stderr, cliUsage)
+	return 2
+}
+
+// openCommandDB 서버와 같은 설정으로 DB 연결 (마이그레이션은 서버 기동 시 수행)
+func openCommandDB() (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(config.NewDatabaseConfig().GetDSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Error),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+	return db, nil
+}
+
+// runAuditVerify 해시 체인 검증 결과 출력 (끊어진 연결이나 잘못된 체크포인트가 있으면 1)
+func runAuditVerify(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("audit verify", flag.ContinueOnError)
+	chain := fs.String("chain", "", "audit_events, credential_issuances or break_glass_activities (default: all chains)")
+	asJSON := fs.Bool("json", false, "print the verification result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	db, err := openCommandDB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	resp, err := service.NewAuditChainService(db).Verify(context.Background(), *chain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit verify failed: %v\n", err)
+		return 2
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(resp)
+	} else {
+		printAuditVerification(out, resp)
+	}
+	if !resp.Valid {
+		return 1
+	}
+	return 0
+}
+
+func printAuditVerification(out io.Writer, resp *model.AuditVerifyResponse) {
+	for _, c := range resp.Chains {
+		status := "OK"
+		if !c.Valid {
+			status = "FAILED"
+		}
+		fmt.Fprintf(out, "[%s] %s: %d records verified", status, c.Chain, c.Checked)
+		if c.Unchained > 0 {
+			fmt.Fprintf(out, ", %d records predate the chain", c.Unchained)
+		}
+		fmt.Fprintln(out)
+		if c.BrokenLink != nil {
+			fmt.Fprintf(out, "  first broken link: record %d: %s\n", c.BrokenLink.RecordID, c.BrokenLink.Reason)
+			if c.BrokenLink.Expected != "" || c.BrokenLink.Actual != "" {
+				fmt.Fprintf(out, "    expected %q, got %q\n", c.BrokenLink.Expected, c.BrokenLink.Actual)
+			}
+		}
+		for _, cp := range c.Checkpoints {
+			if !cp.Valid || cp.Reason != "" {
+				fmt.Fprintf(out, "  checkpoint %d (record %d): valid=%t %s\n", cp.CheckpointID, cp.LastRecordID, cp.Valid, cp.Reason)
+			}
+		}
+	}
+}
+
+// runAuditCheckpoint 서명 체크포인트 생성 결과 출력
+func runAuditCheckpoint(out io.Writer) int {
+	db, err := openCommandDB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	created, err := service.NewAuditChainService(db).CreateCheckpoints()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit checkpoint failed: %v\n", err)
+		return 1
+	}
+	if len(created) == 0 {
+		fmt.Fprintln(out, "no new records since the last checkpoints")
+	}
+	for _, cp := range created {
+		fmt.Fprintf(out, "checkpoint %d: chain=%s lastRecordId=%d records=%d keyId=%s\n", cp.ID, cp.Chain, cp.LastRecordID, cp.RecordCount, cp.KeyID)
+	}
+	return 0
+}
+
+// runRolesBulkAssign CSV/YAML 일괄 할당 계획 또는 적용 결과 출력 (잘못된 행이 있거나 적용이 롤백되면 1)
+func runRolesBulkAssign(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("roles bulk-assign", flag.ContinueOnError)
+	file := fs.String("file", "", "CSV or YAML file with user, workspace, role, expiresAt and group columns")
+	format := fs.String("format", "", "csv or yaml (default: from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate and print the plan without applying it")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "roles bulk-assign: -file is required")
+		return 2
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	rows, err := service.ParseBulkAssignments(*format, data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	db, err := openCommandDB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	// 이메일 조회와 realm role/그룹 반영에 Keycloak 이 필요
+	if err := config.InitKeycloak(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize keycloak: %v\n", err)
+		return 2
+	}
+	svc := service.NewBulkAssignmentService(db)
+	var report *model.BulkAssignmentReport
+	if *dryRun {
+		report = svc.Plan(context.Background(), rows)
+	} else {
+		report, err = svc.Apply(context.Background(), rows)
+		if err != nil && !errors.Is(err, service.ErrBulkAssignmentInvalid) && !errors.Is(err, service.ErrBulkAssignmentFailed) {
+			fmt.Fprintf(os.Stderr, "roles bulk-assign failed: %v\n", err)
+			return 2
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		printBulkAssignmentReport(out, report)
+	}
+	if report.Invalid > 0 || report.Error != "" {
+		return 1
+	}
+	return 0
+}
+
+func printBulkAssignmentReport(out io.Writer, report *model.BulkAssignmentReport) {
+	for _, row := range report.Rows {
+		fmt.Fprintf(out, "line %d [%s] %s", row.Line, row.Status, row.User)
+		for _, step := range row.Steps {
+			target := step.Target
+			if step.Workspace != "" {
+				target = step.Workspace + "/" + target
+			}
+			fmt.Fprintf(out, " %s=%s", step.Kind, target)
+			if step.Exists {
+				fmt.Fprint(out, "(exists)")
+			}
+		}
+		fmt.Fprintln(out)
+		for _, msg := range row.Errors {
+			fmt.Fprintf(out, "  error: %s\n", msg)
+		}
+	}
+	mode := "applied"
+	switch {
+	case report.DryRun:
+		mode = "dry-run"
+	case !report.Applied:
+		mode = "not applied"
+	}
+	fmt.Fprintf(out, "%s: %d rows, %d valid, %d invalid\n", mode, report.Total, report.Valid, report.Invalid)
+	if report.Error != "" {
+		fmt.Fprintf(out, "rolled back: %s\n", report.Error)
+	}
+}
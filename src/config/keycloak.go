@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +33,9 @@ type KeycloakConfig struct {
 	OIDCClientName   string
 	OIDCClientID     string
 	OIDCClientSecret string
+
+	// 토큰 aud/azp 검증 시 추가로 허용할 클라이언트 (MC_IAM_MANAGER_KEYCLOAK_TRUSTED_CLIENTS)
+	TrustedClients []string
 }
 
 var KC *KeycloakConfig
@@ -120,6 +124,14 @@ func InitKeycloak() error {
 	keycloakAdmin := os.Getenv("MC_IAM_MANAGER_KEYCLOAK_ADMIN")
 	fmt.Printf("MC_IAM_MANAGER_KEYCLOAK_ADMIN: %s\n", keycloakAdmin)
 
+	var trustedClients []string
+	for _, name := range strings.Split(os.Getenv("MC_IAM_MANAGER_KEYCLOAK_TRUSTED_CLIENTS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			trustedClients = append(trustedClients, name)
+		}
+	}
+	fmt.Printf("MC_IAM_MANAGER_KEYCLOAK_TRUSTED_CLIENTS: %v\n", trustedClients)
+
 	client := gocloak.NewClient(host)
 
 	KC = &KeycloakConfig{
@@ -133,6 +145,7 @@ func InitKeycloak() error {
 		OIDCClientID:     oidcClientID,
 		OIDCClientName:   oidcClientName,
 		OIDCClientSecret: oidcClientSecret,
+		TrustedClients:   trustedClients,
 	}
 
 	// Test connection and get certs
@@ -220,6 +233,29 @@ func (kc *KeycloakConfig) LoginAdmin(ctx context.Context) (*gocloak.JWT, error)
 	return token, nil
 }
 
+// CertsURL realm JWKS(서명 공개키 목록) 엔드포인트 주소
+func (kc *KeycloakConfig) CertsURL() string {
+	return strings.TrimRight(kc.Host, "/") + "/realms/" + kc.Realm + "/protocol/openid-connect/certs"
+}
+
+// Issuers 토큰 iss 로 허용하는 realm 주소 목록 (내부 Host, 외부 URL)
+func (kc *KeycloakConfig) Issuers() []string {
+	issuers := []string{strings.TrimRight(kc.Host, "/") + "/realms/" + kc.Realm}
+	if external := strings.TrimRight(kc.ExternalURL, "/") + "/realms/" + kc.Realm; kc.ExternalURL != "" && external != issuers[0] {
+		issuers = append(issuers, external)
+	}
+	return issuers
+}
+
+// Audiences 토큰 aud/azp 로 허용하는 클라이언트 목록
+func (kc *KeycloakConfig) Audiences() []string {
+	audiences := []string{kc.ClientName}
+	if kc.OIDCClientName != "" && kc.OIDCClientName != kc.ClientName {
+		audiences = append(audiences, kc.OIDCClientName)
+	}
+	return append(audiences, kc.TrustedClients...)
+}
+
 // GetPublicKey는 Keycloak의 공개키를 가져옵니다.
 func (kc *KeycloakConfig) GetPublicKey() (interface{}, error) {
 	ctx := context.Background()
@@ -0,0 +1,119 @@
+package eventsink
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// CEF 헤더 기본값
+const (
+	defaultCEFVendor  = "m-cmp"
+	defaultCEFProduct = "mc-iam-manager"
+	defaultCEFVersion = "1.0"
+)
+
+// CEFFormatter ArcSight Common Event Format(CEF:0) 변환
+// 헤더의 Signature ID 는 이벤트 종류, Name 은 메시지(없으면 종류)를 사용한다.
+type CEFFormatter struct {
+	Vendor  string
+	Product string
+	Version string
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\r", `\r`, "\n", `\n`)
+)
+
+// Format CEF 한 줄로 변환
+func (f CEFFormatter) Format(event *model.SecurityEvent) ([]byte, error) {
+	vendor, product, version := f.Vendor, f.Product, f.Version
+	if vendor == "" {
+		vendor = defaultCEFVendor
+	}
+	if product == "" {
+		product = defaultCEFProduct
+	}
+	if version == "" {
+		version = defaultCEFVersion
+	}
+	name := event.Message
+	if name == "" {
+		name = event.Type
+	}
+	severity := event.Severity
+	if severity < 0 {
+		severity = 0
+	}
+	if severity > 10 {
+		severity = 10
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeaderEscaper.Replace(vendor), cefHeaderEscaper.Replace(product), cefHeaderEscaper.Replace(version),
+		cefHeaderEscaper.Replace(event.Type), cefHeaderEscaper.Replace(name), severity)
+
+	ext := make([]string, 0, 24)
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtensionEscaper.Replace(value))
+		}
+	}
+	if !event.Time.IsZero() {
+		add("rt", strconv.FormatInt(event.Time.UnixMilli(), 10))
+	}
+	add("externalId", event.ID)
+	add("cat", event.Category)
+	add("act", event.Type)
+	add("outcome", event.Outcome)
+	add("suid", event.ActorKcID)
+	add("suser", event.ActorUsername)
+	// src 는 IP 주소 형식만 허용
+	if net.ParseIP(event.SourceIP) != nil {
+		add("src", event.SourceIP)
+	}
+	add("requestClientApplication", event.UserAgent)
+	add("reason", event.Reason)
+	add("msg", event.Message)
+	if event.TargetType != "" {
+		add("cs1Label", "targetType")
+		add("cs1", event.TargetType)
+	}
+	if event.TargetID != "" {
+		add("cs2Label", "targetId")
+		add("cs2", event.TargetID)
+	}
+	if event.WorkspaceID != nil {
+		add("cn1Label", "workspaceId")
+		add("cn1", strconv.FormatUint(uint64(*event.WorkspaceID), 10))
+	}
+	if event.RecordID != 0 {
+		add("cn2Label", "recordId")
+		add("cn2", strconv.FormatUint(uint64(event.RecordID), 10))
+	}
+	if event.RecordHash != "" {
+		add("cs3Label", "recordHash")
+		add("cs3", event.RecordHash)
+	}
+	if len(event.Attributes) > 0 {
+		keys := make([]string, 0, len(event.Attributes))
+		for k := range event.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+":"+event.Attributes[k])
+		}
+		add("cs4Label", "attributes")
+		add("cs4", strings.Join(pairs, ";"))
+	}
+	b.WriteString(strings.Join(ext, " "))
+	return []byte(b.String()), nil
+}
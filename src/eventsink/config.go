@@ -0,0 +1,147 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+const (
+	defaultJSONLPath      = "./log/security-events.jsonl"
+	defaultJSONLMaxSizeMB = 100
+	defaultJSONLBackups   = 5
+)
+
+var (
+	defaultDispatcher     *Dispatcher
+	defaultDispatcherOnce sync.Once
+)
+
+// Default 서비스들이 공유하는 디스패처 (.env 로드 후 첫 호출 시 환경 변수로 생성)
+// MC_IAM_MANAGER_EVENT_SINKS 가 비어 있으면 싱크 없이 동작한다.
+func Default() *Dispatcher {
+	defaultDispatcherOnce.Do(func() {
+		sinks, err := SinksFromEnv()
+		if err != nil {
+			log.Printf("[EVENT_SINK] %v", err)
+		}
+		opts := DispatcherOptions{
+			BufferSize: envInt("MC_IAM_MANAGER_EVENT_SINK_BUFFER", 0),
+			MaxRetries: envInt("MC_IAM_MANAGER_EVENT_SINK_MAX_RETRIES", 0),
+		}
+		defaultDispatcher = NewDispatcher(sinks, opts)
+		for _, sink := range sinks {
+			log.Printf("[EVENT_SINK] forwarding security events to %s", sink.Name())
+		}
+	})
+	return defaultDispatcher
+}
+
+// Publish 공용 디스패처로 이벤트 전달
+func Publish(event *model.SecurityEvent) {
+	Default().Publish(event)
+}
+
+// Shutdown 공용 디스패처의 남은 이벤트를 ctx 시한까지 전달하고 닫는다
+func Shutdown(ctx context.Context) error {
+	return Default().Close(ctx)
+}
+
+// SinksFromEnv 환경 변수로 싱크 생성 (MC_IAM_MANAGER_EVENT_SINKS=jsonl,syslog)
+// 설정이 잘못된 싱크는 건너뛰고 오류를 모아 반환한다.
+func SinksFromEnv() ([]Sink, error) {
+	var (
+		sinks []Sink
+		errs  []string
+	)
+	for _, name := range strings.Split(os.Getenv("MC_IAM_MANAGER_EVENT_SINKS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		var (
+			sink Sink
+			err  error
+		)
+		switch name {
+		case "":
+			continue
+		case "jsonl":
+			sink, err = jsonlSinkFromEnv()
+		case "syslog":
+			sink, err = syslogSinkFromEnv()
+		default:
+			err = fmt.Errorf("unknown event sink %q (jsonl, syslog)", name)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(errs) > 0 {
+		return sinks, fmt.Errorf("event sink configuration: %s", strings.Join(errs, "; "))
+	}
+	return sinks, nil
+}
+
+func jsonlSinkFromEnv() (Sink, error) {
+	path := os.Getenv("MC_IAM_MANAGER_EVENT_JSONL_PATH")
+	if path == "" {
+		path = defaultJSONLPath
+	}
+	maxSizeMB := envInt("MC_IAM_MANAGER_EVENT_JSONL_MAX_SIZE_MB", defaultJSONLMaxSizeMB)
+	backups := envInt("MC_IAM_MANAGER_EVENT_JSONL_MAX_BACKUPS", defaultJSONLBackups)
+	return NewJSONLFileSink(path, int64(maxSizeMB)*1024*1024, backups)
+}
+
+func syslogSinkFromEnv() (Sink, error) {
+	cfg := SyslogConfig{
+		Network:  strings.ToLower(os.Getenv("MC_IAM_MANAGER_EVENT_SYSLOG_NETWORK")),
+		Address:  os.Getenv("MC_IAM_MANAGER_EVENT_SYSLOG_ADDRESS"),
+		Facility: envInt("MC_IAM_MANAGER_EVENT_SYSLOG_FACILITY", defaultSyslogFacility),
+	}
+	switch format := strings.ToLower(os.Getenv("MC_IAM_MANAGER_EVENT_SYSLOG_FORMAT")); format {
+	case "", "cef":
+		cfg.Formatter = CEFFormatter{}
+	case "json":
+		cfg.Formatter = JSONFormatter{}
+	default:
+		return nil, fmt.Errorf("syslog sink: unsupported format %q (cef, json)", format)
+	}
+	if cfg.Network == SyslogTLS {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if caFile := os.Getenv("MC_IAM_MANAGER_EVENT_SYSLOG_TLS_CA_FILE"); caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("syslog sink: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("syslog sink: no certificates in %s", caFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		tlsConfig.InsecureSkipVerify = os.Getenv("MC_IAM_MANAGER_EVENT_SYSLOG_TLS_INSECURE_SKIP_VERIFY") == "true"
+		cfg.TLSConfig = tlsConfig
+	}
+	return NewSyslogSink(cfg)
+}
+
+func envInt(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("[EVENT_SINK] invalid %s %q, using %d", key, raw, fallback)
+		return fallback
+	}
+	return v
+}
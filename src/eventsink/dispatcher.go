@@ -0,0 +1,217 @@
+package eventsink
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// 디스패처 기본값
+const (
+	defaultBufferSize   = 1000
+	defaultMaxRetries   = 5
+	defaultRetryBackoff = 500 * time.Millisecond
+	defaultMaxBackoff   = 30 * time.Second
+)
+
+// DispatcherOptions 싱크별 대기열/재시도 설정 (0 이면 기본값)
+type DispatcherOptions struct {
+	BufferSize   int           // 싱크별 대기열 크기
+	MaxRetries   int           // 첫 전송 실패 후 재시도 횟수 (음수면 재시도 안 함)
+	RetryBackoff time.Duration // 첫 재시도 대기 시간 (재시도마다 두 배)
+	MaxBackoff   time.Duration
+}
+
+// Dispatcher 보안 이벤트를 싱크별 대기열에 넣고 백그라운드에서 전달
+// Publish 는 막히지 않으며, 싱크 장애로 대기열이 가득 차면 새 이벤트를 버리고 건수를 센다.
+// 한 싱크의 장애는 다른 싱크 전달에 영향을 주지 않는다. nil 디스패처의 Publish 는 아무것도 하지 않는다.
+type Dispatcher struct {
+	opts    DispatcherOptions
+	workers []*sinkWorker
+
+	mu     sync.RWMutex // Publish 와 Close 간 대기열 닫기 보호
+	closed bool
+	stop   chan struct{} // 재시도 대기 중단 (Close 시한 초과)
+	wg     sync.WaitGroup
+}
+
+type sinkWorker struct {
+	sink  Sink
+	queue chan *model.SecurityEvent
+
+	delivered atomic.Uint64
+	retried   atomic.Uint64
+	dropped   atomic.Uint64
+
+	errMu     sync.Mutex
+	lastError string
+	lastErrAt *time.Time
+}
+
+// NewDispatcher 싱크마다 대기열과 전달 고루틴을 만들고 시작
+func NewDispatcher(sinks []Sink, opts DispatcherOptions) *Dispatcher {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaultMaxRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultRetryBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	d := &Dispatcher{opts: opts, stop: make(chan struct{})}
+	for _, sink := range sinks {
+		w := &sinkWorker{sink: sink, queue: make(chan *model.SecurityEvent, opts.BufferSize)}
+		d.workers = append(d.workers, w)
+		d.wg.Add(1)
+		go d.run(w)
+	}
+	return d
+}
+
+// Enabled 싱크가 하나 이상 있는지 여부
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && len(d.workers) > 0
+}
+
+// Publish 이벤트를 모든 싱크 대기열에 넣는다 (호출 후 event 를 수정하지 않아야 함)
+func (d *Dispatcher) Publish(event *model.SecurityEvent) {
+	if !d.Enabled() || event == nil {
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return
+	}
+	for _, w := range d.workers {
+		select {
+		case w.queue <- event:
+		default:
+			if n := w.dropped.Add(1); n == 1 || n%1000 == 0 {
+				log.Printf("[EVENT_SINK] %s queue full, dropped %d event(s) so far", w.sink.Name(), n)
+			}
+		}
+	}
+}
+
+// Status 싱크별 전달 현황
+func (d *Dispatcher) Status() []model.EventSinkStatus {
+	statuses := []model.EventSinkStatus{}
+	if d == nil {
+		return statuses
+	}
+	for _, w := range d.workers {
+		w.errMu.Lock()
+		status := model.EventSinkStatus{
+			Name:      w.sink.Name(),
+			Queued:    len(w.queue),
+			Capacity:  cap(w.queue),
+			Delivered: w.delivered.Load(),
+			Retried:   w.retried.Load(),
+			Dropped:   w.dropped.Load(),
+			LastError: w.lastError,
+			LastErrAt: w.lastErrAt,
+		}
+		w.errMu.Unlock()
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Close 새 이벤트를 받지 않고 대기열에 남은 이벤트를 ctx 시한까지 전달한 뒤 싱크를 닫는다
+func (d *Dispatcher) Close(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	for _, w := range d.workers {
+		close(w.queue)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// 재시도 대기를 끊고 남은 이벤트는 버린다
+		close(d.stop)
+		<-done
+		err = ctx.Err()
+	}
+	for _, w := range d.workers {
+		if closeErr := w.sink.Close(); closeErr != nil {
+			log.Printf("[EVENT_SINK] failed to close %s: %v", w.sink.Name(), closeErr)
+		}
+	}
+	return err
+}
+
+func (d *Dispatcher) run(w *sinkWorker) {
+	defer d.wg.Done()
+	for event := range w.queue {
+		select {
+		case <-d.stop:
+			w.dropped.Add(1)
+			continue
+		default:
+		}
+		d.deliver(w, event)
+	}
+}
+
+// deliver 실패 시 대기 시간을 두 배씩 늘려 MaxRetries 번까지 재시도, 모두 실패하면 버린다
+func (d *Dispatcher) deliver(w *sinkWorker, event *model.SecurityEvent) {
+	backoff := d.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := w.sink.Send(event)
+		if err == nil {
+			w.delivered.Add(1)
+			return
+		}
+		w.recordError(err)
+		if d.opts.MaxRetries < 0 || attempt >= d.opts.MaxRetries {
+			w.dropped.Add(1)
+			log.Printf("[EVENT_SINK] %s: giving up on event %s (%s) after %d attempt(s): %v", w.sink.Name(), event.ID, event.Type, attempt+1, err)
+			return
+		}
+		w.retried.Add(1)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-d.stop:
+			timer.Stop()
+			w.dropped.Add(1)
+			return
+		}
+		backoff *= 2
+		if backoff > d.opts.MaxBackoff {
+			backoff = d.opts.MaxBackoff
+		}
+	}
+}
+
+func (w *sinkWorker) recordError(err error) {
+	now := time.Now()
+	w.errMu.Lock()
+	w.lastError = err.Error()
+	w.lastErrAt = &now
+	w.errMu.Unlock()
+}
@@ -0,0 +1,317 @@
+package eventsink
+
+// eventsink_test.go
+//
+// 보안 이벤트 싱크 테스트
+// CEF 변환/이스케이프, RFC 5424 syslog(UDP/TCP 옥텟 카운팅), JSONL 파일 회전,
+// 디스패처 재시도와 싱크 장애 시 Publish 가 막히지 않는지 검증한다.
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSecurityEvent() *model.SecurityEvent {
+	workspaceID := uint(3)
+	return &model.SecurityEvent{
+		ID:            "audit:42",
+		Time:          time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.UTC),
+		Type:          model.AuditActionWorkspaceRoleAssign,
+		Category:      model.SecurityCategoryAuthorization,
+		Severity:      7,
+		Outcome:       model.AuditResultSuccess,
+		ActorKcID:     "kc-admin",
+		ActorUsername: "admin",
+		SourceIP:      "10.0.0.5",
+		TargetType:    model.AuditEntityUser,
+		TargetID:      "7",
+		WorkspaceID:   &workspaceID,
+		Message:       "POST /api/roles|assign",
+		Reason:        "a=b\nc\\d",
+		RecordID:      42,
+		RecordHash:    "abc123",
+		Attributes:    map[string]string{"statusCode": "200", "cspType": "aws"},
+	}
+}
+
+// TC-EVENTSINK-CEF-01: CEF 헤더/확장 필드 구성과 이스케이프
+func TestCEFFormatter_Format(t *testing.T) {
+	out, err := CEFFormatter{}.Format(testSecurityEvent())
+	require.NoError(t, err)
+	line := string(out)
+
+	assert.True(t, strings.HasPrefix(line, `CEF:0|m-cmp|mc-iam-manager|1.0|role.workspace.assign|POST /api/roles\|assign|7|`), line)
+	assert.Contains(t, line, "rt=1772357400123 ")
+	assert.Contains(t, line, "externalId=audit:42 ")
+	assert.Contains(t, line, "suser=admin ")
+	assert.Contains(t, line, "src=10.0.0.5 ")
+	assert.Contains(t, line, `reason=a\=b\nc\\d `)
+	assert.Contains(t, line, "cn1Label=workspaceId cn1=3 ")
+	assert.Contains(t, line, "cs3Label=recordHash cs3=abc123 ")
+	assert.True(t, strings.HasSuffix(line, "cs4Label=attributes cs4=cspType:aws;statusCode:200"), line)
+	assert.NotContains(t, line, "\n")
+
+	// IP 가 아닌 src 는 생략, 심각도는 0-10 으로 제한
+	event := testSecurityEvent()
+	event.SourceIP = "unknown"
+	event.Severity = 15
+	out, err = CEFFormatter{Vendor: "acme"}.Format(event)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "CEF:0|acme|")
+	assert.Contains(t, string(out), "|10|")
+	assert.NotContains(t, string(out), "src=")
+}
+
+// TC-EVENTSINK-SYSLOG-01: UDP 는 데이터그램 하나에 RFC 5424 메시지 하나
+func TestSyslogSink_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: SyslogUDP, Address: pc.LocalAddr().String(), Hostname: "iam-1"})
+	require.NoError(t, err)
+	defer sink.Close()
+	require.NoError(t, sink.Send(testSecurityEvent()))
+
+	buf := make([]byte, 4096)
+	pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+
+	// facility 10(authpriv) * 8 + 심각도 7 → syslog error(3)
+	prefix := "<83>1 2026-03-01T09:30:00.123456Z iam-1 mc-iam-manager " + strconv.Itoa(os.Getpid()) + " role.workspace.assign - CEF:0|"
+	assert.True(t, strings.HasPrefix(msg, prefix), msg)
+}
+
+// TC-EVENTSINK-SYSLOG-02: TCP 는 옥텟 카운팅 프레이밍, 연결이 끊기면 다음 전송에서 재연결
+func TestSyslogSink_TCPOctetCountingAndReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	frames := make(chan string, 4)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					lenStr, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+					size, _ := strconv.Atoi(strings.TrimSpace(lenStr))
+					body := make([]byte, size)
+					if _, err := io.ReadFull(r, body); err != nil {
+						return
+					}
+					frames <- string(body)
+					// 첫 메시지 후 연결을 끊어 재연결 유도
+					return
+				}
+			}(conn)
+		}
+	}()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: SyslogTCP, Address: ln.Addr().String(), Formatter: JSONFormatter{}})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(testSecurityEvent()))
+	first := <-frames
+	idx := strings.Index(first, " - {")
+	require.Greater(t, idx, 0, first)
+	var decoded model.SecurityEvent
+	require.NoError(t, json.Unmarshal([]byte(first[idx+3:]), &decoded))
+	assert.Equal(t, "audit:42", decoded.ID)
+
+	// 서버가 끊은 연결로 쓰기는 실패할 수 있으나 재시도 시 새 연결로 전달되어야 함
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if err := sink.Send(testSecurityEvent()); err == nil {
+			select {
+			case <-frames:
+				return
+			case <-time.After(200 * time.Millisecond):
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("second message was not delivered after reconnect")
+		}
+	}
+}
+
+// TC-EVENTSINK-SYSLOG-03: 잘못된 설정 거부
+func TestSyslogSink_InvalidConfig(t *testing.T) {
+	_, err := NewSyslogSink(SyslogConfig{Network: "http", Address: "x:1"})
+	assert.Error(t, err)
+	_, err = NewSyslogSink(SyslogConfig{Network: SyslogTCP})
+	assert.Error(t, err)
+	_, err = NewSyslogSink(SyslogConfig{Address: "x:1", Facility: 24})
+	assert.Error(t, err)
+}
+
+// TC-EVENTSINK-JSONL-01: 한 줄에 하나씩 기록, 크기 초과 시 회전하고 maxBackups 개만 보관
+func TestJSONLFileSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "security.jsonl")
+	line, _ := json.Marshal(testSecurityEvent())
+	lineSize := int64(len(line) + 1)
+
+	sink, err := NewJSONLFileSink(path, lineSize*2, 2)
+	require.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.Send(testSecurityEvent()))
+	}
+	require.NoError(t, sink.Close())
+
+	countLines := func(p string) int {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return strings.Count(string(data), "\n")
+	}
+	assert.Equal(t, 1, countLines(path))
+	assert.Equal(t, 2, countLines(path+".1"))
+	assert.Equal(t, 2, countLines(path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// 다시 열면 이어서 기록
+	sink, err = NewJSONLFileSink(path, lineSize*2, 2)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(testSecurityEvent()))
+	require.NoError(t, sink.Close())
+	assert.Equal(t, 2, countLines(path))
+}
+
+// flakySink 처음 failures 번은 실패하는 테스트 싱크
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	block    chan struct{} // nil 이 아니면 닫힐 때까지 Send 대기
+	received []string
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+func (s *flakySink) Close() error { return nil }
+func (s *flakySink) Send(event *model.SecurityEvent) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.received = append(s.received, event.ID)
+	return nil
+}
+
+func (s *flakySink) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+// TC-EVENTSINK-DISPATCH-01: 전송 실패는 재시도 후 전달, 재시도를 모두 실패하면 버림
+func TestDispatcher_Retry(t *testing.T) {
+	sink := &flakySink{failures: 2}
+	d := NewDispatcher([]Sink{sink}, DispatcherOptions{MaxRetries: 2, RetryBackoff: time.Millisecond})
+	d.Publish(&model.SecurityEvent{ID: "e1"})
+	require.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, []string{"e1"}, sink.ids())
+	status := d.Status()[0]
+	assert.Equal(t, uint64(1), status.Delivered)
+	assert.Equal(t, uint64(2), status.Retried)
+	assert.Equal(t, "sink unavailable", status.LastError)
+
+	sink = &flakySink{failures: 3}
+	d = NewDispatcher([]Sink{sink}, DispatcherOptions{MaxRetries: 2, RetryBackoff: time.Millisecond})
+	d.Publish(&model.SecurityEvent{ID: "e1"})
+	d.Publish(&model.SecurityEvent{ID: "e2"})
+	require.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, []string{"e2"}, sink.ids())
+	assert.Equal(t, uint64(1), d.Status()[0].Dropped)
+
+	// 닫힌 디스패처/nil 디스패처의 Publish 는 무시
+	d.Publish(&model.SecurityEvent{ID: "late"})
+	var nilDispatcher *Dispatcher
+	nilDispatcher.Publish(&model.SecurityEvent{ID: "x"})
+	assert.Empty(t, nilDispatcher.Status())
+}
+
+// TC-EVENTSINK-DISPATCH-02: 싱크가 멈춰도 Publish 는 막히지 않고, 대기열 초과분은 버리며 다른 싱크는 정상 전달
+func TestDispatcher_SinkOutageDoesNotBlock(t *testing.T) {
+	stuck := &flakySink{block: make(chan struct{})}
+	healthy := &flakySink{}
+	d := NewDispatcher([]Sink{stuck, healthy}, DispatcherOptions{BufferSize: 2, RetryBackoff: time.Millisecond})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			d.Publish(&model.SecurityEvent{ID: strconv.Itoa(i)})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a stuck sink")
+	}
+
+	require.Eventually(t, func() bool { return len(healthy.ids()) >= 2 }, time.Second, 10*time.Millisecond)
+	assert.Greater(t, d.Status()[0].Dropped, uint64(0))
+
+	// 시한 안에 전달되지 않으면 Close 는 시한 오류를 반환하고 남은 이벤트를 버림
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(stuck.block)
+	}()
+	assert.ErrorIs(t, d.Close(ctx), context.DeadlineExceeded)
+}
+
+// TC-EVENTSINK-CONFIG-01: 환경 변수로 싱크 구성, 잘못된 싱크는 건너뜀
+func TestSinksFromEnv(t *testing.T) {
+	t.Setenv("MC_IAM_MANAGER_EVENT_SINKS", "jsonl, syslog, kafka")
+	t.Setenv("MC_IAM_MANAGER_EVENT_JSONL_PATH", filepath.Join(t.TempDir(), "events.jsonl"))
+	t.Setenv("MC_IAM_MANAGER_EVENT_SYSLOG_NETWORK", "tcp")
+	t.Setenv("MC_IAM_MANAGER_EVENT_SYSLOG_ADDRESS", "127.0.0.1:6514")
+
+	sinks, err := SinksFromEnv()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka")
+	require.Len(t, sinks, 2)
+	assert.True(t, strings.HasPrefix(sinks[0].Name(), "jsonl:"))
+	assert.Equal(t, "syslog:tcp://127.0.0.1:6514", sinks[1].Name())
+	for _, s := range sinks {
+		s.Close()
+	}
+
+	t.Setenv("MC_IAM_MANAGER_EVENT_SINKS", "syslog")
+	t.Setenv("MC_IAM_MANAGER_EVENT_SYSLOG_FORMAT", "xml")
+	sinks, err = SinksFromEnv()
+	assert.Error(t, err)
+	assert.Empty(t, sinks)
+}
@@ -0,0 +1,127 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// JSONLFileSink 이벤트를 한 줄에 하나씩 JSON 으로 기록하는 파일 싱크
+// 파일이 maxBytes 를 넘으면 path.1, path.2 ... 로 밀어내고 새 파일에 쓴다 (maxBackups 개까지 보관).
+type JSONLFileSink struct {
+	path       string
+	maxBytes   int64 // 0 이면 회전하지 않음
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewJSONLFileSink JSONLFileSink 생성 (디렉터리가 없으면 만든다)
+func NewJSONLFileSink(path string, maxBytes int64, maxBackups int) (*JSONLFileSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("jsonl sink: path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("jsonl sink: %w", err)
+	}
+	s := &JSONLFileSink{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name 싱크 이름
+func (s *JSONLFileSink) Name() string {
+	return "jsonl:" + s.path
+}
+
+// Send 이벤트를 JSON 한 줄로 추가
+func (s *JSONLFileSink) Send(event *model.SecurityEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	return nil
+}
+
+// Close 파일 닫기
+func (s *JSONLFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *JSONLFileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate 현재 파일을 path.1 로 옮기고 기존 백업은 번호를 하나씩 올린다 (가장 오래된 것은 삭제)
+func (s *JSONLFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	s.file = nil
+
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("jsonl sink: %w", err)
+		}
+		return s.open()
+	}
+	oldest := fmt.Sprintf("%s.%d", s.path, s.maxBackups)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", s.path, i)
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", s.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("jsonl sink: %w", err)
+		}
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("jsonl sink: %w", err)
+	}
+	return s.open()
+}
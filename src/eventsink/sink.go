@@ -0,0 +1,33 @@
+package eventsink
+
+import (
+	"encoding/json"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// Sink 보안 이벤트를 외부로 내보내는 대상 (JSONL 파일, syslog 등)
+// Send 는 디스패처의 싱크별 작업 고루틴에서만 호출되며, 오류를 반환하면 재시도된다.
+type Sink interface {
+	// Name 상태 조회/로그에 쓰는 싱크 이름
+	Name() string
+
+	// Send 이벤트 하나 전달
+	Send(event *model.SecurityEvent) error
+
+	// Close 연결/파일 정리
+	Close() error
+}
+
+// Formatter 이벤트를 한 줄 메시지로 변환
+type Formatter interface {
+	Format(event *model.SecurityEvent) ([]byte, error)
+}
+
+// JSONFormatter 이벤트를 JSON 한 줄로 변환
+type JSONFormatter struct{}
+
+// Format JSON 으로 직렬화
+func (JSONFormatter) Format(event *model.SecurityEvent) ([]byte, error) {
+	return json.Marshal(event)
+}
@@ -0,0 +1,197 @@
+package eventsink
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// syslog 전송 방식
+const (
+	SyslogUDP = "udp"
+	SyslogTCP = "tcp"
+	SyslogTLS = "tls"
+)
+
+const (
+	defaultSyslogFacility = 10 // authpriv
+	defaultSyslogAppName  = "mc-iam-manager"
+	defaultSyslogTimeout  = 5 * time.Second
+	syslogMsgIDMaxLen     = 32
+)
+
+// SyslogConfig syslog 싱크 설정
+type SyslogConfig struct {
+	Network   string // udp, tcp, tls
+	Address   string // host:port
+	Facility  int    // 1-23, 0 이면 10(authpriv)
+	AppName   string
+	Hostname  string      // 비어 있으면 os.Hostname
+	TLSConfig *tls.Config // tls 전송 시 사용 (nil 이면 기본 설정)
+	Formatter Formatter   // MSG 부분 형식 (nil 이면 CEF)
+	Timeout   time.Duration
+}
+
+// SyslogSink RFC 5424 syslog 싱크
+// TCP/TLS 는 RFC 6587 옥텟 카운팅으로 메시지를 구분하고, 전송 실패 시 연결을 닫고 다음 전송에서 다시 연결한다.
+type SyslogSink struct {
+	cfg SyslogConfig
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink SyslogSink 생성 (연결은 첫 전송 시 맺는다)
+func NewSyslogSink(cfg SyslogConfig) (*SyslogSink, error) {
+	switch cfg.Network {
+	case SyslogUDP, SyslogTCP, SyslogTLS:
+	case "":
+		cfg.Network = SyslogUDP
+	default:
+		return nil, fmt.Errorf("syslog sink: unsupported network %q (udp, tcp, tls)", cfg.Network)
+	}
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("syslog sink: address is required")
+	}
+	if cfg.Facility == 0 {
+		cfg.Facility = defaultSyslogFacility
+	}
+	if cfg.Facility < 0 || cfg.Facility > 23 {
+		return nil, fmt.Errorf("syslog sink: facility must be 1-23: %d", cfg.Facility)
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = defaultSyslogAppName
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+	if cfg.Formatter == nil {
+		cfg.Formatter = CEFFormatter{}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSyslogTimeout
+	}
+	return &SyslogSink{cfg: cfg}, nil
+}
+
+// Name 싱크 이름
+func (s *SyslogSink) Name() string {
+	return "syslog:" + s.cfg.Network + "://" + s.cfg.Address
+}
+
+// Send 이벤트를 syslog 메시지로 전송
+func (s *SyslogSink) Send(event *model.SecurityEvent) error {
+	msg, err := s.message(event)
+	if err != nil {
+		return err
+	}
+	frame := msg
+	if s.cfg.Network != SyslogUDP {
+		frame = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		if err := s.dial(); err != nil {
+			return err
+		}
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(s.cfg.Timeout))
+	if _, err := s.conn.Write(frame); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("syslog sink: %w", err)
+	}
+	return nil
+}
+
+// Close 연결 닫기
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *SyslogSink) dial() error {
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.cfg.Network == SyslogTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.cfg.Address, s.cfg.TLSConfig)
+	} else {
+		conn, err = dialer.Dial(s.cfg.Network, s.cfg.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("syslog sink: %w", err)
+	}
+	s.conn = conn
+	return nil
+}
+
+// message RFC 5424 메시지: <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+func (s *SyslogSink) message(event *model.SecurityEvent) ([]byte, error) {
+	body, err := s.cfg.Formatter.Format(event)
+	if err != nil {
+		return nil, fmt.Errorf("syslog sink: %w", err)
+	}
+	at := event.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	pri := s.cfg.Facility*8 + syslogSeverity(event.Severity)
+	header := fmt.Sprintf("<%d>1 %s %s %s %d %s - ",
+		pri,
+		at.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(s.cfg.Hostname, 255),
+		syslogHeaderField(s.cfg.AppName, 48),
+		os.Getpid(),
+		syslogHeaderField(event.Type, syslogMsgIDMaxLen))
+	return append([]byte(header), body...), nil
+}
+
+// syslogSeverity CEF 심각도(0-10)를 syslog 심각도로 변환
+func syslogSeverity(severity int) int {
+	switch {
+	case severity >= 9:
+		return 2 // critical
+	case severity >= 7:
+		return 3 // error
+	case severity >= 5:
+		return 4 // warning
+	case severity >= 3:
+		return 5 // notice
+	default:
+		return 6 // informational
+	}
+}
+
+// syslogHeaderField 헤더 필드는 공백 없는 출력 가능 ASCII 만 허용 (비어 있으면 "-")
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AccessReviewHandler 접근 검토(재인증) 캠페인 핸들러
+type AccessReviewHandler struct {
+	reviewService *service.AccessReviewService
+}
+
+// NewAccessReviewHandler AccessReviewHandler 생성
+func NewAccessReviewHandler(db *gorm.DB) *AccessReviewHandler {
+	return &AccessReviewHandler{reviewService: service.NewAccessReviewService(db)}
+}
+
+// caller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func (h *AccessReviewHandler) caller(c echo.Context) (*model.User, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return nil, errors.New("kcUserId not found in context")
+	}
+	return h.reviewService.ResolveReviewer(c.Request().Context(), kcUserID)
+}
+
+// CreateAccessReviewCampaign 캠페인 생성
+// @Summary Create access review campaign
+// @Description Creates an access review (certification) campaign. Current user platform role, user workspace role and group workspace role assignments matching every given scope (workspaces, roles, organizations by direct membership) are captured as review items. Unreviewed items are revoked or escalated to campaign managers when the campaign closes (unreviewedAction, default escalate); a campaign with dueAt closes automatically.
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param request body model.CreateAccessReviewCampaignRequest true "Campaign"
+// @Success 201 {object} model.AccessReviewCampaign
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/access-reviews [post]
+// @Id createAccessReviewCampaign
+func (h *AccessReviewHandler) CreateAccessReviewCampaign(c echo.Context) error {
+	var req model.CreateAccessReviewCampaignRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	creator, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	campaign, err := h.reviewService.CreateCampaign(&req, creator)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAccessReviewRequest) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	audit := auditDetail(c, model.AuditActionAccessReviewCreate, model.AuditEntityAccessReview, campaign.ID)
+	audit.After = campaign
+	return c.JSON(http.StatusCreated, campaign)
+}
+
+// ListAccessReviewCampaigns 캠페인 목록
+// @Summary List access review campaigns
+// @Description Lists access review campaigns with item counts by status, newest first.
+// @Tags access-reviews
+// @Produce json
+// @Param status query string false "open or closed"
+// @Success 200 {array} model.AccessReviewCampaign
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/access-reviews [get]
+// @Id listAccessReviewCampaigns
+func (h *AccessReviewHandler) ListAccessReviewCampaigns(c echo.Context) error {
+	campaigns, err := h.reviewService.ListCampaigns(c.QueryParam("status"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, campaigns)
+}
+
+// GetAccessReviewCampaign 캠페인 조회
+// @Summary Get access review campaign
+// @Description Returns an access review campaign with item counts by status.
+// @Tags access-reviews
+// @Produce json
+// @Param campaignId path int true "Campaign ID"
+// @Success 200 {object} model.AccessReviewCampaign
+// @Failure 400 {object} map[string]string "error: Invalid campaign ID"
+// @Failure 404 {object} map[string]string "error: Campaign not found"
+// @Security BearerAuth
+// @Router /api/access-reviews/{campaignId} [get]
+// @Id getAccessReviewCampaign
+func (h *AccessReviewHandler) GetAccessReviewCampaign(c echo.Context) error {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid campaign ID"})
+	}
+	campaign, err := h.reviewService.GetCampaign(uint(campaignID))
+	if err != nil {
+		return accessReviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, campaign)
+}
+
+// ListAccessReviewItems 캠페인 항목 목록
+// @Summary List access review items
+// @Description Lists the review items of a campaign.
+// @Tags access-reviews
+// @Produce json
+// @Param campaignId path int true "Campaign ID"
+// @Param status query string false "pending, certified, revoked or escalated"
+// @Param workspaceId query int false "Workspace ID"
+// @Success 200 {array} model.AccessReviewItem
+// @Failure 400 {object} map[string]string "error: Invalid campaign ID"
+// @Failure 404 {object} map[string]string "error: Campaign not found"
+// @Security BearerAuth
+// @Router /api/access-reviews/{campaignId}/items [get]
+// @Id listAccessReviewItems
+func (h *AccessReviewHandler) ListAccessReviewItems(c echo.Context) error {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid campaign ID"})
+	}
+	var filter model.AccessReviewItemFilter
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+	items, err := h.reviewService.ListItems(uint(campaignID), filter)
+	if err != nil {
+		return accessReviewError(c, err)
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// ListMyAccessReviewItems 요청자가 검토할 항목
+// @Summary List my access review items
+// @Description Lists undecided items the caller may review: pending items of open campaigns in workspaces or organizations the caller reviews, and escalated items for campaign managers. The caller's own assignments are never included.
+// @Tags access-reviews
+// @Produce json
+// @Success 200 {array} model.AccessReviewItem
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/access-reviews/my-items [get]
+// @Id listMyAccessReviewItems
+func (h *AccessReviewHandler) ListMyAccessReviewItems(c echo.Context) error {
+	reviewer, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	items, err := h.reviewService.ListReviewerItems(c.Request().Context(), reviewer)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// DecideAccessReviewItem 항목 검토 결정
+// @Summary Decide access review item
+// @Description Certifies or revokes an access review item. Revocation removes the assignment immediately (platform roles are also removed from the Keycloak realm roles of the user).
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param itemId path int true "Item ID"
+// @Param request body model.AccessReviewDecisionRequest true "Decision"
+// @Success 200 {object} model.AccessReviewItem
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: Not a reviewer of the item"
+// @Failure 404 {object} map[string]string "error: Item not found"
+// @Failure 409 {object} map[string]string "error: Item already decided or campaign closed"
+// @Security BearerAuth
+// @Router /api/access-reviews/items/{itemId}/decision [post]
+// @Id decideAccessReviewItem
+func (h *AccessReviewHandler) DecideAccessReviewItem(c echo.Context) error {
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid item ID"})
+	}
+	var req model.AccessReviewDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	reviewer, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionAccessReviewDecide, model.AuditEntityAccessReview, uint(itemID))
+	item, err := h.reviewService.Decide(c.Request().Context(), uint(itemID), &req, reviewer)
+	if err != nil {
+		return accessReviewError(c, err)
+	}
+	audit.WorkspaceID = item.WorkspaceID
+	audit.After = item
+	return c.JSON(http.StatusOK, item)
+}
+
+// CloseAccessReviewCampaign 캠페인 종료
+// @Summary Close access review campaign
+// @Description Closes an open campaign. Pending items are revoked or escalated according to the campaign's unreviewedAction; items whose revocation fails are escalated with the failure reason.
+// @Tags access-reviews
+// @Produce json
+// @Param campaignId path int true "Campaign ID"
+// @Success 200 {object} model.AccessReviewCampaign
+// @Failure 400 {object} map[string]string "error: Invalid campaign ID"
+// @Failure 404 {object} map[string]string "error: Campaign not found"
+// @Failure 409 {object} map[string]string "error: Campaign already closed"
+// @Security BearerAuth
+// @Router /api/access-reviews/{campaignId}/close [post]
+// @Id closeAccessReviewCampaign
+func (h *AccessReviewHandler) CloseAccessReviewCampaign(c echo.Context) error {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid campaign ID"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionAccessReviewClose, model.AuditEntityAccessReview, uint(campaignID))
+	campaign, err := h.reviewService.CloseCampaign(c.Request().Context(), uint(campaignID), actor)
+	if err != nil {
+		return accessReviewError(c, err)
+	}
+	audit.After = campaign
+	return c.JSON(http.StatusOK, campaign)
+}
+
+// ExportAccessReviewCampaign 증적 내보내기
+// @Summary Export access review evidence
+// @Description Exports a campaign and every item with its decision, reviewer and time as CSV (default) or JSON. The X-Content-SHA256 header carries the hex SHA-256 of the body for evidence integrity.
+// @Tags access-reviews
+// @Produce text/csv
+// @Produce json
+// @Param campaignId path int true "Campaign ID"
+// @Param format query string false "csv (default) or json"
+// @Success 200 {object} model.AccessReviewCampaignDetail
+// @Failure 400 {object} map[string]string "error: Invalid campaign ID or format"
+// @Failure 404 {object} map[string]string "error: Campaign not found"
+// @Security BearerAuth
+// @Router /api/access-reviews/{campaignId}/export [get]
+// @Id exportAccessReviewCampaign
+func (h *AccessReviewHandler) ExportAccessReviewCampaign(c echo.Context) error {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid campaign ID"})
+	}
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "format must be csv or json"})
+	}
+
+	detail, err := h.reviewService.ExportCampaign(uint(campaignID))
+	if err != nil {
+		return accessReviewError(c, err)
+	}
+	var (
+		body        []byte
+		contentType string
+	)
+	if format == "json" {
+		body, err = json.MarshalIndent(detail, "", "  ")
+		contentType = echo.MIMEApplicationJSON
+	} else {
+		body, err = service.EncodeAccessReviewCSV(detail)
+		contentType = "text/csv"
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	sum := sha256.Sum256(body)
+	c.Response().Header().Set("X-Content-SHA256", hex.EncodeToString(sum[:]))
+	c.Response().Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="access-review-%d.%s"`, campaignID, format),
+	)
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// accessReviewError 서비스 오류를 HTTP 응답으로 변환
+func accessReviewError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrAccessReviewCampaignNotFound), errors.Is(err, repository.ErrAccessReviewItemNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidAccessReviewRequest):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionDenied), errors.Is(err, service.ErrAccessReviewSelfReview):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrAccessReviewCampaignClosed), errors.Is(err, service.ErrAccessReviewItemDecided):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -459,6 +459,9 @@ func (h *AdminHandler) RestoreRolePermissions(c echo.Context) error {
 		}
 	}
 
+	audit := auditDetail(c, model.AuditActionRolePermissionsRestore, model.AuditEntityRolePermissions, 0)
+	audit.Before = h.rolePermissionSnapshot(backup, sections)
+
 	result, err := h.menuService.RestoreRolePermissions(backup, mode, sections)
 	if err != nil {
 		log.Printf("[ERROR] RestoreRolePermissions failed: %v", err)
@@ -472,9 +475,27 @@ func (h *AdminHandler) RestoreRolePermissions(c echo.Context) error {
 		"[INFO] Role permissions restored: mode=%s roles=%d added=%d removed=%d",
 		result.Mode, result.RolesProcessed, result.MenusAdded, result.MenusRemoved,
 	)
+	audit.After = map[string]interface{}{"result": result, "permissions": h.rolePermissionSnapshot(backup, sections)}
 	return c.JSON(http.StatusOK, result)
 }
 
+// rolePermissionSnapshot 감사 스냅샷용 복구 대상 역할의 현재 권한 (조회 실패 시 nil)
+func (h *AdminHandler) rolePermissionSnapshot(backup *model.RolePermissionBackup, sections []string) []model.RolePermissionEntry {
+	roleNames := make([]string, 0, len(backup.Permissions))
+	for _, entry := range backup.Permissions {
+		roleNames = append(roleNames, entry.Role)
+	}
+	if len(roleNames) == 0 {
+		return nil
+	}
+	current, err := h.menuService.BackupRolePermissions(roleNames, sections)
+	if err != nil {
+		log.Printf("[WARN] failed to snapshot role permissions for audit: %v", err)
+		return nil
+	}
+	return current.Permissions
+}
+
 func splitCSVQuery(raw string) []string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
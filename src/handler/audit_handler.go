@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/eventsink"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AuditHandler 감사 이벤트 조회 핸들러
+type AuditHandler struct {
+	auditService *service.AuditService
+	chainService *service.AuditChainService
+}
+
+// NewAuditHandler AuditHandler 생성
+func NewAuditHandler(db *gorm.DB) *AuditHandler {
+	return &AuditHandler{
+		auditService: service.NewAuditService(db),
+		chainService: service.NewAuditChainService(db),
+	}
+}
+
+// ListAuditEvents 감사 이벤트 목록 조회
+// @Summary List audit events
+// @Description Lists audit events of mutating IAM requests, newest first. Filter by actor (kcUserId or username), entity type/id, action, result, workspace and time range (RFC3339, from inclusive, to exclusive).
+// @Tags audit
+// @Produce json
+// @Param actor query string false "Actor kcUserId or username"
+// @Param entityType query string false "Entity type (user, group, workspace, role-permissions, csp-idp-config)"
+// @Param entityId query string false "Entity ID"
+// @Param action query string false "Action (e.g. role.workspace.assign)"
+// @Param result query string false "success or failure"
+// @Param workspaceId query int false "Workspace ID"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} model.AuditEventListResponse
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/audit/events [get]
+// @Id listAuditEvents
+func (h *AuditHandler) ListAuditEvents(c echo.Context) error {
+	var filter model.AuditEventFilter
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+
+	resp, err := h.auditService.ListEvents(&filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditFilter) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetAuditEvent 감사 이벤트 단건 조회
+// @Summary Get audit event
+// @Description Returns a single audit event including before/after snapshots.
+// @Tags audit
+// @Produce json
+// @Param eventId path int true "Audit event ID"
+// @Success 200 {object} model.AuditEvent
+// @Failure 400 {object} map[string]string "error: Invalid event ID"
+// @Failure 404 {object} map[string]string "error: Audit event not found"
+// @Security BearerAuth
+// @Router /api/audit/events/{eventId} [get]
+// @Id getAuditEvent
+func (h *AuditHandler) GetAuditEvent(c echo.Context) error {
+	eventID, err := strconv.ParseUint(c.Param("eventId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid event ID"})
+	}
+
+	event, err := h.auditService.GetEvent(uint(eventID))
+	if err != nil {
+		if errors.Is(err, repository.ErrAuditEventNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, event)
+}
+
+// VerifyAuditChain 감사 기록 해시 체인 검증
+// @Summary Verify audit log integrity
+// @Description Walks the hash chains of audit events, credential issuance records and break-glass activity records from the beginning, recomputing every hash, and reports the first broken link per chain. Signed checkpoints are checked against the chain and the configured audit signing key.
+// @Tags audit
+// @Produce json
+// @Param chain query string false "audit_events, credential_issuances or break_glass_activities (default all)"
+// @Success 200 {object} model.AuditVerifyResponse
+// @Failure 400 {object} map[string]string "error: Unknown audit chain"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/audit/verify [get]
+// @Id verifyAuditChain
+func (h *AuditHandler) VerifyAuditChain(c echo.Context) error {
+	resp, err := h.chainService.Verify(c.Request().Context(), c.QueryParam("chain"))
+	if err != nil {
+		if errors.Is(err, repository.ErrUnknownAuditChain) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListAuditCheckpoints 서명 체크포인트 목록 조회
+// @Summary List audit checkpoints
+// @Description Lists signed audit chain checkpoints, oldest first.
+// @Tags audit
+// @Produce json
+// @Param chain query string false "audit_events, credential_issuances or break_glass_activities (default all)"
+// @Success 200 {array} model.AuditCheckpoint
+// @Failure 400 {object} map[string]string "error: Unknown audit chain"
+// @Security BearerAuth
+// @Router /api/audit/checkpoints [get]
+// @Id listAuditCheckpoints
+func (h *AuditHandler) ListAuditCheckpoints(c echo.Context) error {
+	checkpoints, err := h.chainService.ListCheckpoints(c.QueryParam("chain"))
+	if err != nil {
+		if errors.Is(err, repository.ErrUnknownAuditChain) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, checkpoints)
+}
+
+// CreateAuditCheckpoints 서명 체크포인트 즉시 생성
+// @Summary Create audit checkpoints
+// @Description Signs a checkpoint for every chain that has new records since its last checkpoint. Requires MC_IAM_MANAGER_AUDIT_SIGNING_KEY_FILE.
+// @Tags audit
+// @Produce json
+// @Success 201 {array} model.AuditCheckpoint
+// @Failure 409 {object} map[string]string "error: audit signing key is not configured"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/audit/checkpoints [post]
+// @Id createAuditCheckpoints
+func (h *AuditHandler) CreateAuditCheckpoints(c echo.Context) error {
+	created, err := h.chainService.CreateCheckpoints()
+	if err != nil {
+		if errors.Is(err, service.ErrAuditSigningKeyNotConfigured) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, created)
+}
+
+// ListEventSinks 보안 이벤트 싱크 전달 현황
+// @Summary List security event sinks
+// @Description Shows delivery status (queued, delivered, retried, dropped, last error) of each configured security event sink (MC_IAM_MANAGER_EVENT_SINKS).
+// @Tags audit
+// @Produce json
+// @Success 200 {array} model.EventSinkStatus
+// @Security BearerAuth
+// @Router /api/audit/sinks [get]
+// @Id listEventSinks
+func (h *AuditHandler) ListEventSinks(c echo.Context) error {
+	return c.JSON(http.StatusOK, eventsink.Default().Status())
+}
+
+// auditDetail 요청의 감사 상세를 등록하고 반환 (핸들러가 전후 스냅샷을 채움, entityID 0 은 대상 없음)
+func auditDetail(c echo.Context, action, entityType string, entityID uint) *model.AuditDetail {
+	detail := &model.AuditDetail{Action: action, EntityType: entityType}
+	if entityID != 0 {
+		detail.EntityID = strconv.FormatUint(uint64(entityID), 10)
+	}
+	c.Set(model.AuditDetailKey, detail)
+	return detail
+}
@@ -0,0 +1,107 @@
+package handler
+
+// audit_handler_test.go
+//
+// 감사 미들웨어 + 감사 이벤트 조회 핸들러 테스트 (SQLite shared in-memory DB)
+// 변경 요청의 행위자/상세/실패 기록, 조회용 /list 제외, 필터 조회를 검증한다.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/middleware"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAuditHandlerTest(t *testing.T) (*echo.Echo, *AuditHandler) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:audit_handler_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().DropTable(&model.AuditEvent{}))
+	require.NoError(t, db.AutoMigrate(&model.AuditEvent{}))
+
+	h := NewAuditHandler(db)
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("kcUserId", "kc-admin")
+			c.Set("platformRoles", []string{"admin"})
+			return next(c)
+		}
+	})
+	e.Use(middleware.AuditMiddleware(h.auditService))
+	e.POST("/api/users/id/:userId/roles", func(c echo.Context) error {
+		audit := auditDetail(c, model.AuditActionPlatformRoleAssign, model.AuditEntityUser, 5)
+		audit.Before = map[string]interface{}{"platformRoles": []string{}}
+		audit.After = map[string]interface{}{"platformRoles": []string{"operator"}}
+		return c.JSON(http.StatusOK, map[string]string{"message": "ok"})
+	})
+	e.DELETE("/api/workspaces/id/:workspaceId", func(c echo.Context) error {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "permission denied"})
+	})
+	e.POST("/api/users/list", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []string{})
+	})
+	e.GET("/api/audit/events", h.ListAuditEvents)
+	return e, h
+}
+
+// TC-AUDIT-H-01: 변경 요청은 행위자/상세와 함께 기록, 실패 응답은 오류 메시지 포함, /list 는 제외
+func TestAuditMiddleware_RecordsMutatingRequests(t *testing.T) {
+	e, _ := setupAuditHandlerTest(t)
+	for _, r := range []struct{ method, path string }{
+		{http.MethodPost, "/api/users/id/5/roles"},
+		{http.MethodDelete, "/api/workspaces/id/9"},
+		{http.MethodPost, "/api/users/list"},
+	} {
+		req := httptest.NewRequest(r.method, r.path, nil)
+		req.Header.Set("X-Real-IP", "10.0.0.7")
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/audit/events", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp model.AuditEventListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, int64(2), resp.Total)
+
+	failed, assigned := resp.Events[0], resp.Events[1]
+	assert.Equal(t, "DELETE /api/workspaces/id/:workspaceId", failed.Action)
+	assert.Equal(t, model.AuditResultFailure, failed.Result)
+	assert.Equal(t, http.StatusForbidden, failed.StatusCode)
+	assert.Equal(t, "permission denied", failed.ErrorMessage)
+
+	assert.Equal(t, model.AuditActionPlatformRoleAssign, assigned.Action)
+	assert.Equal(t, model.AuditEntityUser, assigned.EntityType)
+	assert.Equal(t, "5", assigned.EntityID)
+	assert.Equal(t, "kc-admin", assigned.ActorKcID)
+	assert.Equal(t, []string{"admin"}, []string(assigned.ActorPlatformRoles))
+	assert.Equal(t, "10.0.0.7", assigned.SourceIP)
+	assert.JSONEq(t, `{"platformRoles":["operator"]}`, string(assigned.After))
+}
+
+// TC-AUDIT-H-02: 조회 필터 적용, 잘못된 시간 형식은 400
+func TestListAuditEvents_Filters(t *testing.T) {
+	e, _ := setupAuditHandlerTest(t)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/users/id/5/roles", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/workspaces/id/9", nil))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/audit/events?entityType=user&actor=kc-admin", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp model.AuditEventListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, int64(1), resp.Total)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/audit/events?from=yesterday", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
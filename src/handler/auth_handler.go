@@ -33,6 +33,7 @@ type AuthHandler struct {
 	userService     *service.UserService
 	keycloakService service.KeycloakService
 	roleService     *service.RoleService
+	dormancyService *service.DormancyService
 }
 
 // NewAuthHandler creates a new AuthHandler instance
@@ -44,6 +45,7 @@ func NewAuthHandler(db *gorm.DB) *AuthHandler {
 		userService:     userService,
 		keycloakService: keycloakService,
 		roleService:     roleService,
+		dormancyService: service.NewDormancyService(db),
 	}
 }
 
@@ -68,11 +70,16 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
+	// 로그인 결과를 보안 이벤트 싱크로 전달
+	publishLogin := func(kcUserID string, err error) {
+		service.PublishLoginEvent(userLogin.Id, kcUserID, c.RealIP(), c.Request().UserAgent(), err)
+	}
 
 	// 1. Login to Keycloak using a temporary KeycloakService instance
 	ks := service.NewKeycloakService()
 	token, err := ks.Login(ctx, userLogin.Id, userLogin.Password)
 	if err != nil {
+		publishLogin("", err)
 		// Differentiate between invalid credentials and other errors if possible
 		return c.JSON(http.StatusUnauthorized, map[string]string{"error": fmt.Sprintf("Authentication failed: %v", err)})
 	}
@@ -80,6 +87,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	// 2. Get User ID (sub) from Access Token using a temporary KeycloakService instance
 	userID, err := ks.GetUserIDFromToken(ctx, token)
 	if err != nil {
+		publishLogin("", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to extract user ID from token: %v", err)})
 	}
 
@@ -88,6 +96,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	// 3. Check if user is enabled in Keycloak using a temporary KeycloakService instance
 	kcUser, err := ks.GetUser(ctx, userID) // Use GetUser from KeycloakService
 	if err != nil {
+		publishLogin(userID, err)
 		// Handle not found vs other errors
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return c.JSON(http.StatusForbidden, map[string]string{"error": "Keycloak user information not found (possible account synchronization issue)"})
@@ -95,6 +104,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to retrieve Keycloak user information: %v", err)})
 	}
 	if kcUser == nil || kcUser.Enabled == nil || !*kcUser.Enabled {
+		publishLogin(userID, errors.New("account is disabled or pending approval"))
 		return c.JSON(http.StatusForbidden, map[string]string{"error": "Account is disabled or pending approval"})
 	}
 
@@ -106,7 +116,11 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		// return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Local DB synchronization failed: %v", err)})
 	}
 
-	// 5. Return Keycloak token
+	// 5. 마지막 로그인 시각 기록 (휴면 계정 정책)
+	h.dormancyService.RecordActivity(userID, model.UserActivityLogin)
+
+	// 6. Return Keycloak token
+	publishLogin(userID, nil)
 	return c.JSON(http.StatusOK, token)
 }
 
@@ -160,6 +174,11 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, map[string]string{"error": fmt.Sprintf("Token refresh failed: %v", err)})
 	}
 
+	// 마지막 토큰 갱신 시각 기록 (휴면 계정 정책)
+	if userID, err := ks.GetUserIDFromToken(ctx, newToken); err == nil {
+		h.dormancyService.RecordActivity(userID, model.UserActivityRefresh)
+	}
+
 	return c.JSON(http.StatusOK, newToken)
 }
 
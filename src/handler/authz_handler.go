@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AuthzHandler 권한 판정 핸들러
+type AuthzHandler struct {
+	authzService      *service.AuthzService
+	simulationService *service.AccessSimulationService
+}
+
+// NewAuthzHandler AuthzHandler 생성
+func NewAuthzHandler(db *gorm.DB) *AuthzHandler {
+	return &AuthzHandler{
+		authzService:      service.NewAuthzService(db),
+		simulationService: service.NewAccessSimulationService(db),
+	}
+}
+
+// CheckPermission 권한 확인
+// @Summary Check permissions
+// @Description Evaluates whether a subject (userId or kcUserId) holds the given MC-IAM permissions, optionally within a workspace. Direct and group-inherited platform/workspace roles are considered. A platformAdmin holder (platform role, or the caller's token realm role) is allowed every permission regardless of deny mappings, as on this service's own routes (platformAdmin: true). If no subject is given, the caller is evaluated.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzCheckRequest true "Permission check request"
+// @Success 200 {object} model.AuthzCheckResponse
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/authz/check [post]
+// @Id checkPermission
+func (h *AuthzHandler) CheckPermission(c echo.Context) error {
+	var req model.AuthzCheckRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	fillAuthzSubject(c, &req)
+
+	resp, err := h.authzService.Check(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(authzErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CheckPermissionBatch 권한 일괄 확인
+// @Summary Check permissions in batch
+// @Description Evaluates several permission checks at once. A failing check is reported in its own result entry without aborting the batch.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzBatchCheckRequest true "Batch permission check request"
+// @Success 200 {object} model.AuthzBatchCheckResponse
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Security BearerAuth
+// @Router /api/authz/check/batch [post]
+// @Id checkPermissionBatch
+func (h *AuthzHandler) CheckPermissionBatch(c echo.Context) error {
+	var req model.AuthzBatchCheckRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if len(req.Checks) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "checks is required"})
+	}
+	for i := range req.Checks {
+		fillAuthzSubject(c, &req.Checks[i])
+	}
+
+	return c.JSON(http.StatusOK, h.authzService.CheckBatch(c.Request().Context(), &req))
+}
+
+// ExplainAccess 접근 경로 설명
+// @Summary Explain access
+// @Description Lists every path that grants or blocks a user's access to a permission, menu or CSP role: direct role assignments, group (organization) memberships with their organization tree path, and role inheritance. Deny mappings, menu deny mappings on ancestors and shadowed CSP role mappings are reported as blocks; for a permission, platformAdmin (via platformAdmin) allows access even when blocks exist. If no subject is given, the caller is explained.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzExplainRequest true "Access explanation request (one of permission, menuId, cspRoleId)"
+// @Success 200 {object} model.AuthzExplainResponse
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 404 {object} map[string]string "error: User, menu or CSP role not found"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/authz/explain [post]
+// @Id explainAccess
+func (h *AuthzHandler) ExplainAccess(c echo.Context) error {
+	var req model.AuthzExplainRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if req.UserID == "" && req.KcUserID == "" {
+		if kcUserID, ok := c.Get("kcUserId").(string); ok {
+			req.KcUserID = kcUserID
+		}
+	}
+
+	resp, err := h.authzService.Explain(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(authzErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SimulateChanges 역할/그룹 변경 가정 시뮬레이션
+// @Summary Simulate role and group changes
+// @Description Applies the proposed changes (user/group role assignments, group membership changes, group workspace roles) in a transaction that is always rolled back, and returns per-user differences in effective platform roles, workspace roles, menu tree, MC-IAM permissions and CSP roles reachable through temporary credentials. Nothing is committed and Keycloak is not called.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.SimulationRequest true "Proposed changes"
+// @Success 200 {object} model.SimulationResponse
+// @Failure 400 {object} map[string]string "error: Invalid request or change cannot be applied"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/authz/simulate [post]
+// @Id simulateAccessChanges
+func (h *AuthzHandler) SimulateChanges(c echo.Context) error {
+	var req model.SimulationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	resp, err := h.simulationService.Simulate(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(authzErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetCacheStats 권한 캐시 지표 조회
+// @Summary Get authorization cache statistics
+// @Description Returns hit/miss counters (total and per kind: platformRoles, workspaceRoles, permissions, menuTree), entry count and invalidation counters of this instance's effective role/permission/menu tree cache.
+// @Tags authz
+// @Produce json
+// @Success 200 {object} model.AuthzCacheStats
+// @Security BearerAuth
+// @Router /api/authz/cache/stats [get]
+// @Id getAuthzCacheStats
+func (h *AuthzHandler) GetCacheStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.authzService.CacheStats())
+}
+
+// fillAuthzSubject 주체가 지정되지 않은 경우 호출자(kcUserId)로 채움
+func fillAuthzSubject(c echo.Context, req *model.AuthzCheckRequest) {
+	if req.UserID != "" || req.KcUserID != "" {
+		return
+	}
+	if kcUserID, ok := c.Get("kcUserId").(string); ok {
+		req.KcUserID = kcUserID
+	}
+}
+
+// authzErrorStatus 권한 판정 오류를 HTTP 상태 코드로 변환
+func authzErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, repository.ErrMenuNotFound),
+		errors.Is(err, service.ErrCspRoleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAuthzSubjectRequired),
+		errors.Is(err, service.ErrInvalidPermissionID),
+		errors.Is(err, service.ErrInvalidWorkspaceID),
+		errors.Is(err, service.ErrInvalidUserID),
+		errors.Is(err, service.ErrInvalidConditionInput),
+		errors.Is(err, service.ErrInvalidExplainTarget),
+		errors.Is(err, service.ErrInvalidSimulationChange):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrSodViolation):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// BreakGlassHandler 비상 접근(break-glass) 계정/세션 핸들러
+type BreakGlassHandler struct {
+	breakGlassService *service.BreakGlassService
+}
+
+// NewBreakGlassHandler BreakGlassHandler 생성
+func NewBreakGlassHandler(db *gorm.DB) *BreakGlassHandler {
+	return &BreakGlassHandler{breakGlassService: service.NewBreakGlassService(db)}
+}
+
+// caller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func (h *BreakGlassHandler) caller(c echo.Context) (*model.User, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return nil, errors.New("kcUserId not found in context")
+	}
+	return h.breakGlassService.ResolveUser(c.Request().Context(), kcUserID)
+}
+
+// ActivateMyBreakGlass 비상 접근 활성화
+// @Summary Activate break-glass access
+// @Description Grants platformAdmin to a pre-registered break-glass account for a short window. A reason is mandatory; durationMinutes defaults to the break-glass policy and may not exceed its maximum. All other platform admins are notified with high priority, every request made during the window is recorded in the break-glass activity chain, and the grant is revoked automatically when it elapses. The current token gains platformAdmin immediately; Keycloak tokens issued afterwards carry the realm role until the session ends.
+// @Tags break-glass
+// @Accept json
+// @Produce json
+// @Param request body model.ActivateBreakGlassRequest true "Reason and duration"
+// @Success 201 {object} model.BreakGlassSession
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: Not registered for break-glass access"
+// @Failure 409 {object} map[string]string "error: Already active or already platformAdmin"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/break-glass [post]
+// @Id activateMyBreakGlass
+func (h *BreakGlassHandler) ActivateMyBreakGlass(c echo.Context) error {
+	var req model.ActivateBreakGlassRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	user, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	session, err := h.breakGlassService.Activate(c.Request().Context(), &req, user, c.RealIP())
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionBreakGlassActivate, model.AuditEntityBreakGlass, session.ID)
+	audit.After = session
+	return c.JSON(http.StatusCreated, session)
+}
+
+// GetMyBreakGlass 내 유효한 비상 접근 조회
+// @Summary Get my active break-glass session
+// @Description Returns the caller's active break-glass session.
+// @Tags break-glass
+// @Produce json
+// @Success 200 {object} model.BreakGlassSession
+// @Failure 404 {object} map[string]string "error: No active break-glass session"
+// @Security BearerAuth
+// @Router /api/users/me/break-glass [get]
+// @Id getMyBreakGlass
+func (h *BreakGlassHandler) GetMyBreakGlass(c echo.Context) error {
+	user, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	session, err := h.breakGlassService.GetMine(user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if session == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": service.ErrBreakGlassNotActive.Error()})
+	}
+	return c.JSON(http.StatusOK, session)
+}
+
+// EndMyBreakGlass 내 비상 접근 반납
+// @Summary End my break-glass session
+// @Description Ends the caller's active break-glass session before it expires and revokes platformAdmin immediately.
+// @Tags break-glass
+// @Accept json
+// @Produce json
+// @Param request body model.EndBreakGlassRequest false "Reason"
+// @Success 200 {object} model.BreakGlassSession
+// @Failure 409 {object} map[string]string "error: No active break-glass session"
+// @Security BearerAuth
+// @Router /api/users/me/break-glass/end [post]
+// @Id endMyBreakGlass
+func (h *BreakGlassHandler) EndMyBreakGlass(c echo.Context) error {
+	var req model.EndBreakGlassRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	user, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	session, err := h.breakGlassService.EndMine(c.Request().Context(), &req, user)
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionBreakGlassEnd, model.AuditEntityBreakGlass, session.ID)
+	audit.After = session
+	return c.JSON(http.StatusOK, session)
+}
+
+// GetBreakGlassPolicy 비상 접근 기간 정책 조회
+// @Summary Get break-glass policy
+// @Description Returns the default and maximum break-glass duration in minutes (MC_IAM_MANAGER_BREAK_GLASS_DEFAULT_MINUTES, MC_IAM_MANAGER_BREAK_GLASS_MAX_MINUTES).
+// @Tags break-glass
+// @Produce json
+// @Success 200 {object} model.BreakGlassPolicy
+// @Security BearerAuth
+// @Router /api/break-glass/policy [get]
+// @Id getBreakGlassPolicy
+func (h *BreakGlassHandler) GetBreakGlassPolicy(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.breakGlassService.Policy())
+}
+
+// ListBreakGlassAccounts 비상 접근 계정 목록
+// @Summary List break-glass accounts
+// @Description Lists the accounts registered for break-glass access.
+// @Tags break-glass
+// @Produce json
+// @Success 200 {array} model.BreakGlassAccount
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/break-glass/accounts [get]
+// @Id listBreakGlassAccounts
+func (h *BreakGlassHandler) ListBreakGlassAccounts(c echo.Context) error {
+	accounts, err := h.breakGlassService.ListAccounts()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, accounts)
+}
+
+// RegisterBreakGlassAccount 비상 접근 계정 등록
+// @Summary Register break-glass account
+// @Description Registers a user for break-glass access. kind emergency marks a dedicated emergency account (also exempted from the dormancy policy as BREAK_GLASS); kind elevation (default) lets an existing account elevate itself to platformAdmin in an emergency.
+// @Tags break-glass
+// @Accept json
+// @Produce json
+// @Param request body model.RegisterBreakGlassAccountRequest true "Account"
+// @Success 201 {object} model.BreakGlassAccount
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 409 {object} map[string]string "error: Already registered"
+// @Security BearerAuth
+// @Router /api/break-glass/accounts [post]
+// @Id registerBreakGlassAccount
+func (h *BreakGlassHandler) RegisterBreakGlassAccount(c echo.Context) error {
+	var req model.RegisterBreakGlassAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	account, err := h.breakGlassService.RegisterAccount(&req, actor)
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionBreakGlassRegister, model.AuditEntityBreakGlassAccount, account.UserID)
+	audit.After = account
+	return c.JSON(http.StatusCreated, account)
+}
+
+// RemoveBreakGlassAccount 비상 접근 계정 등록 해제
+// @Summary Remove break-glass account
+// @Description Removes a user's break-glass registration. An active session must be ended first.
+// @Tags break-glass
+// @Produce json
+// @Param userId path int true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "error: Invalid user ID"
+// @Failure 404 {object} map[string]string "error: Break-glass account not found"
+// @Failure 409 {object} map[string]string "error: Break-glass session active"
+// @Security BearerAuth
+// @Router /api/break-glass/accounts/{userId} [delete]
+// @Id removeBreakGlassAccount
+func (h *BreakGlassHandler) RemoveBreakGlassAccount(c echo.Context) error {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
+	}
+
+	audit := auditDetail(c, model.AuditActionBreakGlassUnregister, model.AuditEntityBreakGlassAccount, uint(userID))
+	account, err := h.breakGlassService.RemoveAccount(uint(userID))
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	audit.Before = account
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListBreakGlassSessions 비상 접근 세션 목록
+// @Summary List break-glass sessions
+// @Description Lists break-glass sessions of all accounts, newest first.
+// @Tags break-glass
+// @Produce json
+// @Param status query string false "active, ended or expired"
+// @Param userId query int false "User ID"
+// @Success 200 {array} model.BreakGlassSession
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/break-glass/sessions [get]
+// @Id listBreakGlassSessions
+func (h *BreakGlassHandler) ListBreakGlassSessions(c echo.Context) error {
+	var filter model.BreakGlassSessionFilter
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+	sessions, err := h.breakGlassService.ListSessions(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// GetBreakGlassSession 비상 접근 세션 조회
+// @Summary Get break-glass session
+// @Description Returns a break-glass session with its reason, window and notified admins.
+// @Tags break-glass
+// @Produce json
+// @Param sessionId path int true "Session ID"
+// @Success 200 {object} model.BreakGlassSession
+// @Failure 400 {object} map[string]string "error: Invalid session ID"
+// @Failure 404 {object} map[string]string "error: Break-glass session not found"
+// @Security BearerAuth
+// @Router /api/break-glass/sessions/{sessionId} [get]
+// @Id getBreakGlassSession
+func (h *BreakGlassHandler) GetBreakGlassSession(c echo.Context) error {
+	sessionID, err := strconv.ParseUint(c.Param("sessionId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid session ID"})
+	}
+	session, err := h.breakGlassService.GetSession(uint(sessionID))
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	return c.JSON(http.StatusOK, session)
+}
+
+// ListBreakGlassActivities 비상 접근 중 요청 기록
+// @Summary List break-glass session activity
+// @Description Lists every request made with the session's break-glass grant, oldest first. Records are hash-chained (chain break_glass_activities) and can be verified with GET /api/audit/verify.
+// @Tags break-glass
+// @Produce json
+// @Param sessionId path int true "Session ID"
+// @Success 200 {array} model.BreakGlassActivity
+// @Failure 400 {object} map[string]string "error: Invalid session ID"
+// @Failure 404 {object} map[string]string "error: Break-glass session not found"
+// @Security BearerAuth
+// @Router /api/break-glass/sessions/{sessionId}/activities [get]
+// @Id listBreakGlassActivities
+func (h *BreakGlassHandler) ListBreakGlassActivities(c echo.Context) error {
+	sessionID, err := strconv.ParseUint(c.Param("sessionId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid session ID"})
+	}
+	activities, err := h.breakGlassService.ListActivities(uint(sessionID))
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	return c.JSON(http.StatusOK, activities)
+}
+
+// EndBreakGlassSession 비상 접근 종료 (관리자)
+// @Summary End break-glass session
+// @Description Ends an active break-glass session before it expires and revokes platformAdmin immediately.
+// @Tags break-glass
+// @Accept json
+// @Produce json
+// @Param sessionId path int true "Session ID"
+// @Param request body model.EndBreakGlassRequest false "Reason"
+// @Success 200 {object} model.BreakGlassSession
+// @Failure 400 {object} map[string]string "error: Invalid session ID"
+// @Failure 404 {object} map[string]string "error: Break-glass session not found"
+// @Failure 409 {object} map[string]string "error: Session not active"
+// @Security BearerAuth
+// @Router /api/break-glass/sessions/{sessionId}/end [post]
+// @Id endBreakGlassSession
+func (h *BreakGlassHandler) EndBreakGlassSession(c echo.Context) error {
+	sessionID, err := strconv.ParseUint(c.Param("sessionId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid session ID"})
+	}
+	var req model.EndBreakGlassRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionBreakGlassEnd, model.AuditEntityBreakGlass, uint(sessionID))
+	session, err := h.breakGlassService.End(c.Request().Context(), uint(sessionID), &req, actor)
+	if err != nil {
+		return breakGlassError(c, err)
+	}
+	audit.After = session
+	return c.JSON(http.StatusOK, session)
+}
+
+// breakGlassError 서비스 오류를 HTTP 응답으로 변환
+func breakGlassError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrBreakGlassAccountNotFound), errors.Is(err, repository.ErrBreakGlassSessionNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidBreakGlassRequest):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrBreakGlassNotEligible):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrBreakGlassAccountExists), errors.Is(err, service.ErrBreakGlassAlreadyActive),
+		errors.Is(err, service.ErrBreakGlassAlreadyAdmin), errors.Is(err, service.ErrBreakGlassNotActive):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
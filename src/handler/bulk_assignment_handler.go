@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// BulkAssignmentHandler 역할·그룹 일괄 할당 핸들러
+type BulkAssignmentHandler struct {
+	bulkAssignmentService *service.BulkAssignmentService
+	workflowService       *service.WorkflowService
+}
+
+// NewBulkAssignmentHandler BulkAssignmentHandler 생성
+func NewBulkAssignmentHandler(db *gorm.DB) *BulkAssignmentHandler {
+	return &BulkAssignmentHandler{
+		bulkAssignmentService: service.NewBulkAssignmentService(db),
+		workflowService:       service.NewWorkflowService(db),
+	}
+}
+
+// bulkAssignmentFormat format 쿼리 파라미터, 없으면 Content-Type 으로 입력 형식 결정
+func bulkAssignmentFormat(c echo.Context) string {
+	if format := c.QueryParam("format"); format != "" {
+		return format
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return model.BulkAssignmentFormatCSV
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return model.BulkAssignmentFormatYAML
+	}
+	return ""
+}
+
+// BulkAssignRoles 역할·그룹 일괄 할당
+// @Summary Bulk assign roles and group memberships
+// @Description Assigns workspace roles, platform roles and group memberships from CSV or YAML rows of (user, workspace, role, expiresAt, group). `user` is a username or an email; `workspace` is a workspace name or ID and, when blank, `role` is a platform role; `group` is an organization code or name; `expiresAt` is RFC3339 or YYYY-MM-DD. CSV needs a header row; YAML is a list under `assignments:`. Every row is validated first (existence, duplicates, current assignments, segregation of duties). With dryRun=true the per-row plan is returned without changes. Otherwise nothing is applied if any row is invalid; valid input is applied in one database transaction, and Keycloak realm-role and group changes already made are reverted if a later step fails. Rows with a group also require mc-iam-manager:organization:write. The response is a per-row report.
+// @Tags roles
+// @Accept plain
+// @Produce json
+// @Param format query string false "csv or yaml (default: from Content-Type)"
+// @Param dryRun query bool false "Validate and return the plan only"
+// @Param body body string true "CSV or YAML rows"
+// @Success 200 {object} model.BulkAssignmentReport
+// @Failure 400 {object} model.BulkAssignmentReport "Invalid input or invalid rows"
+// @Failure 403 {object} map[string]string "error: organization:write is required for rows with a group"
+// @Failure 409 {object} map[string]string "error: Role assignments require approval"
+// @Failure 500 {object} model.BulkAssignmentReport "Applying failed and was rolled back"
+// @Security BearerAuth
+// @Router /api/roles/assign/bulk [post]
+// @Id bulkAssignRoles
+func (h *BulkAssignmentHandler) BulkAssignRoles(c echo.Context) error {
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dryRun"))
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "failed to read request body"})
+	}
+	rows, err := service.ParseBulkAssignments(bulkAssignmentFormat(c), body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if dryRun {
+		return c.JSON(http.StatusOK, h.bulkAssignmentService.Plan(c.Request().Context(), rows))
+	}
+
+	// 역할 할당 승인 워크플로가 켜져 있으면 일괄 적용 불가 (dry-run 은 허용)
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "role assignments require approval; submit them individually"})
+	}
+
+	kcUserID, _ := c.Get("kcUserId").(string)
+	actor, err := h.bulkAssignmentService.ResolveUser(c.Request().Context(), kcUserID)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionBulkAssign, model.AuditEntityBulkAssignment, 0)
+	report, err := h.bulkAssignmentService.ApplyAs(c.Request().Context(), actor.ID, rows)
+	audit.After = report
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrBulkAssignmentInvalid):
+		return c.JSON(http.StatusBadRequest, report)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
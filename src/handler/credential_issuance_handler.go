@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// CredentialIssuanceHandler CSP 임시 자격 증명 발급 원장 조회 핸들러
+type CredentialIssuanceHandler struct {
+	issuanceService *service.CredentialIssuanceService
+}
+
+// NewCredentialIssuanceHandler CredentialIssuanceHandler 생성
+func NewCredentialIssuanceHandler(db *gorm.DB) *CredentialIssuanceHandler {
+	return &CredentialIssuanceHandler{issuanceService: service.NewCredentialIssuanceService(db)}
+}
+
+// ListCredentialIssuances 발급 원장 조회
+// @Summary List credential issuances
+// @Description Lists temporary CSP credential issuance attempts (successful and failed), newest first. No secrets are stored. Filter by user (kcUserId or username), workspace, CSP type, CSP role (name/identifier substring), auth method, result, source IP and time range (RFC3339, from inclusive, to exclusive).
+// @Tags audit
+// @Produce json
+// @Param user query string false "kcUserId or username"
+// @Param workspaceId query int false "Workspace ID"
+// @Param cspType query string false "CSP type (aws, gcp, ...)"
+// @Param cspRole query string false "CSP role name or identifier (substring)"
+// @Param authMethod query string false "OIDC, SAML or SECRET_KEY"
+// @Param result query string false "success or failure"
+// @Param sourceIp query string false "Source IP"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} model.CredentialIssuanceListResponse
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/audit/credential-issuances [get]
+// @Id listCredentialIssuances
+func (h *CredentialIssuanceHandler) ListCredentialIssuances(c echo.Context) error {
+	var filter model.CredentialIssuanceFilter
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+
+	resp, err := h.issuanceService.ListIssuances(&filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentialIssuanceFilter) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetCredentialIssuance 발급 기록 단건 조회
+// @Summary Get credential issuance
+// @Description Returns a single temporary CSP credential issuance record.
+// @Tags audit
+// @Produce json
+// @Param issuanceId path int true "Credential issuance ID"
+// @Success 200 {object} model.CredentialIssuance
+// @Failure 400 {object} map[string]string "error: Invalid issuance ID"
+// @Failure 404 {object} map[string]string "error: Credential issuance not found"
+// @Security BearerAuth
+// @Router /api/audit/credential-issuances/{issuanceId} [get]
+// @Id getCredentialIssuance
+func (h *CredentialIssuanceHandler) GetCredentialIssuance(c echo.Context) error {
+	issuanceID, err := strconv.ParseUint(c.Param("issuanceId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid issuance ID"})
+	}
+
+	issuance, err := h.issuanceService.GetIssuance(uint(issuanceID))
+	if err != nil {
+		if errors.Is(err, repository.ErrCredentialIssuanceNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, issuance)
+}
+
+// AggregateCredentialIssuances 발급 원장 집계
+// @Summary Aggregate credential issuances
+// @Description Aggregates credential issuance attempts matching the filter, grouped by workspace and CSP type by default. groupBy accepts a comma separated combination of workspace, csp, cspRole and user.
+// @Tags audit
+// @Produce json
+// @Param groupBy query string false "Group by (default workspace,csp)"
+// @Param user query string false "kcUserId or username"
+// @Param workspaceId query int false "Workspace ID"
+// @Param cspType query string false "CSP type (aws, gcp, ...)"
+// @Param cspRole query string false "CSP role name or identifier (substring)"
+// @Param result query string false "success or failure"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Success 200 {array} model.CredentialIssuanceAggregate
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/audit/credential-issuances/aggregates [get]
+// @Id aggregateCredentialIssuances
+func (h *CredentialIssuanceHandler) AggregateCredentialIssuances(c echo.Context) error {
+	var filter model.CredentialIssuanceFilter
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+
+	aggregates, err := h.issuanceService.AggregateIssuances(&filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentialIssuanceFilter) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, aggregates)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -18,6 +19,8 @@ type CspCredentialHandler struct {
 	credService     *service.CspCredentialService
 	keycloakService service.KeycloakService // To get user ID from token
 	userService     *service.UserService
+	// 유효한 CSP 역할 승격이 있으면 승격 역할로 발급 (nil 이면 승격을 확인하지 않음)
+	elevationService *service.ElevationService
 }
 
 // NewCspCredentialHandler 새 CspCredentialHandler 인스턴스 생성
@@ -26,9 +29,10 @@ func NewCspCredentialHandler(db *gorm.DB) *CspCredentialHandler {
 	keycloakService := service.NewKeycloakService() // Stateless
 	userService := service.NewUserService(db)
 	return &CspCredentialHandler{
-		credService:     credService,
-		keycloakService: keycloakService,
-		userService:     userService,
+		credService:      credService,
+		keycloakService:  keycloakService,
+		userService:      userService,
+		elevationService: service.NewElevationService(db),
 	}
 }
 
@@ -81,7 +85,20 @@ func (h *CspCredentialHandler) GetTemporaryCredentials(c echo.Context) error {
 	userID := user.ID
 
 	// 2. Call the CspCredentialService with values from context
-	credentials, err := h.credService.GetTemporaryCredentials(c.Request().Context(), userID, kcUserId, &req)
+	// WorkspaceRoleMiddleware 가 확인한 워크스페이스 역할(그룹 상속 포함)이 있으면 재조회하지 않음
+	// 해당 CSP 타입으로 승인된 CSP 역할 승격이 유효하면 승격된 워크스페이스 역할의 매핑으로 발급
+	var credentials *model.CspCredentialResponse
+	elevatedRoleID, err := h.elevatedRoleID(userID, req.WorkspaceID, req.CspType)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to check elevations: %v", err)})
+	}
+	if elevatedRoleID != 0 {
+		credentials, err = h.credService.GetTemporaryCredentialsForRole(c.Request().Context(), kcUserId, elevatedRoleID, &req)
+	} else if role, ok := c.Get("workspace_role").(*model.AuthzRoleGrant); ok && role != nil {
+		credentials, err = h.credService.GetTemporaryCredentialsForRole(c.Request().Context(), kcUserId, role.RoleID, &req)
+	} else {
+		credentials, err = h.credService.GetTemporaryCredentials(c.Request().Context(), userID, kcUserId, &req)
+	}
 	if err != nil {
 		log.Printf("Error: %v", err)
 		// Handle specific errors from the service
@@ -91,6 +108,9 @@ func (h *CspCredentialHandler) GetTemporaryCredentials(c echo.Context) error {
 		if errors.Is(err, service.ErrNoCspRoleMappingFound) || strings.Contains(err.Error(), "user has no roles") {
 			return c.JSON(http.StatusForbidden, map[string]string{"error": "No suitable CSP role mapping found for user in this workspace: " + err.Error()})
 		}
+		if errors.Is(err, service.ErrGrantConditionsNotMet) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		if errors.Is(err, service.ErrUnsupportedCspType) {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
@@ -107,6 +127,18 @@ func (h *CspCredentialHandler) GetTemporaryCredentials(c echo.Context) error {
 	return c.JSON(http.StatusOK, credentials)
 }
 
+// elevatedRoleID 사용자가 워크스페이스에서 cspType 에 대해 유효한 CSP 역할 승격의 역할 ID (없으면 0)
+func (h *CspCredentialHandler) elevatedRoleID(userID uint, workspaceID, cspType string) (uint, error) {
+	if h.elevationService == nil {
+		return 0, nil
+	}
+	wsID, err := strconv.ParseUint(workspaceID, 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+	return h.elevationService.ActiveCspElevationRoleID(userID, uint(wsID), cspType)
+}
+
 // Removed placeholder ValidateTokenAndGetClaims function from handler
 
 // ListCredentials godoc
@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -60,10 +61,13 @@ func (h *CspIdpConfigHandler) CreateCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Config is required"})
 	}
 
+	audit := auditDetail(c, model.AuditActionCspIdpConfigCreate, model.AuditEntityCspIdpConfig, 0)
 	idpConfig, err := h.cspIdpConfigService.CreateCspIdpConfig(&req)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to create IDP config: %v", err)})
 	}
+	audit.EntityID = strconv.FormatUint(uint64(idpConfig.ID), 10)
+	audit.After = idpConfig
 
 	return c.JSON(http.StatusCreated, idpConfig)
 }
@@ -155,6 +159,7 @@ func (h *CspIdpConfigHandler) UpdateCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request format"})
 	}
 
+	audit := h.auditIdpConfig(c, model.AuditActionCspIdpConfigUpdate, configID)
 	idpConfig, err := h.cspIdpConfigService.UpdateCspIdpConfig(configID, &req)
 	if err != nil {
 		if err.Error() == fmt.Sprintf("IDP config not found with ID: %d", configID) {
@@ -163,6 +168,7 @@ func (h *CspIdpConfigHandler) UpdateCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to update IDP config: %v", err)})
 	}
 
+	audit.After = idpConfig
 	return c.JSON(http.StatusOK, idpConfig)
 }
 
@@ -186,6 +192,7 @@ func (h *CspIdpConfigHandler) DeleteCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid config ID"})
 	}
 
+	h.auditIdpConfig(c, model.AuditActionCspIdpConfigDelete, configID)
 	if err := h.cspIdpConfigService.DeleteCspIdpConfig(configID); err != nil {
 		if err.Error() == fmt.Sprintf("IDP config not found with ID: %d", configID) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -246,9 +253,13 @@ func (h *CspIdpConfigHandler) ActivateCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid config ID"})
 	}
 
+	audit := h.auditIdpConfig(c, model.AuditActionCspIdpConfigActivate, configID)
 	if err := h.cspIdpConfigService.ActivateIdpConfig(configID); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to activate IDP config: %v", err)})
 	}
+	if after, err := h.cspIdpConfigService.GetCspIdpConfigByID(configID); err == nil {
+		audit.After = after
+	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "IDP config activated successfully"})
 }
@@ -273,9 +284,13 @@ func (h *CspIdpConfigHandler) DeactivateCspIdpConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid config ID"})
 	}
 
+	audit := h.auditIdpConfig(c, model.AuditActionCspIdpConfigDeactivate, configID)
 	if err := h.cspIdpConfigService.DeactivateIdpConfig(configID); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to deactivate IDP config: %v", err)})
 	}
+	if after, err := h.cspIdpConfigService.GetCspIdpConfigByID(configID); err == nil {
+		audit.After = after
+	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "IDP config deactivated successfully"})
 }
@@ -328,3 +343,12 @@ func (h *CspIdpConfigHandler) BulkHealthCheck(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, result)
 }
+
+// auditIdpConfig 감사 상세 등록, 변경 전 설정을 스냅샷으로 남김 (Config 의 비밀값은 감사 저장 시 가려짐)
+func (h *CspIdpConfigHandler) auditIdpConfig(c echo.Context, action string, configID uint) *model.AuditDetail {
+	audit := auditDetail(c, action, model.AuditEntityCspIdpConfig, configID)
+	if before, err := h.cspIdpConfigService.GetCspIdpConfigByID(configID); err == nil {
+		audit.Before = before
+	}
+	return audit
+}
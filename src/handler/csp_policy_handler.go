@@ -25,6 +25,17 @@ func NewCspPolicyHandler(db *gorm.DB) *CspPolicyHandler {
 	}
 }
 
+// rolePolicySnapshot 감사 스냅샷용 CSP 역할에 연결된 정책 목록
+func (h *CspPolicyHandler) rolePolicySnapshot(cspRoleID uint) map[string]interface{} {
+	policies := []map[string]interface{}{}
+	if attached, err := h.cspPolicyService.GetPoliciesByRoleID(cspRoleID); err == nil {
+		for _, policy := range attached {
+			policies = append(policies, map[string]interface{}{"id": policy.ID, "name": policy.Name})
+		}
+	}
+	return map[string]interface{}{"cspRoleId": cspRoleID, "policies": policies}
+}
+
 // CreateCspPolicy godoc
 // @Summary Create CSP policy
 // @Description Create a new CSP policy
@@ -252,9 +263,12 @@ func (h *CspPolicyHandler) AttachPolicyToRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "CSP Policy ID is required"})
 	}
 
+	audit := auditDetail(c, model.AuditActionCspPolicyAttach, model.AuditEntityCspRole, req.CspRoleID)
+	audit.Before = h.rolePolicySnapshot(req.CspRoleID)
 	if err := h.cspPolicyService.AttachPolicyToRole(req.CspRoleID, req.CspPolicyID); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to attach policy: %v", err)})
 	}
+	audit.After = h.rolePolicySnapshot(req.CspRoleID)
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Policy attached successfully"})
 }
@@ -286,9 +300,12 @@ func (h *CspPolicyHandler) DetachPolicyFromRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "CSP Policy ID is required"})
 	}
 
+	audit := auditDetail(c, model.AuditActionCspPolicyDetach, model.AuditEntityCspRole, req.CspRoleID)
+	audit.Before = h.rolePolicySnapshot(req.CspRoleID)
 	if err := h.cspPolicyService.DetachPolicyFromRole(req.CspRoleID, req.CspPolicyID); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to detach policy: %v", err)})
 	}
+	audit.After = h.rolePolicySnapshot(req.CspRoleID)
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Policy detached successfully"})
 }
@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// ElevationHandler 기한부 권한 승격(JIT) 요청/승인 핸들러
+type ElevationHandler struct {
+	elevationService *service.ElevationService
+}
+
+// NewElevationHandler ElevationHandler 생성
+func NewElevationHandler(db *gorm.DB) *ElevationHandler {
+	return &ElevationHandler{elevationService: service.NewElevationService(db)}
+}
+
+// caller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func (h *ElevationHandler) caller(c echo.Context) (*model.User, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return nil, errors.New("kcUserId not found in context")
+	}
+	return h.elevationService.ResolveUser(c.Request().Context(), kcUserID)
+}
+
+// CreateMyElevation 권한 승격 요청
+// @Summary Request elevation
+// @Description Requests a workspace role, or the workspace role mapped to a CSP role (type csp-role with cspRoleId), for a bounded duration with a justification. durationMinutes defaults to the elevation policy and may not exceed its maximum. The request waits for an approver; on approval the role is assigned with an expiry and revoked automatically when it elapses.
+// @Tags elevations
+// @Accept json
+// @Produce json
+// @Param request body model.CreateElevationRequest true "Elevation request"
+// @Success 201 {object} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 409 {object} map[string]string "error: Role already held or request already open"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/elevations [post]
+// @Id createMyElevation
+func (h *ElevationHandler) CreateMyElevation(c echo.Context) error {
+	var req model.CreateElevationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	elevation, err := h.elevationService.Create(&req, requester)
+	if err != nil {
+		return elevationError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionElevationRequest, model.AuditEntityElevation, elevation.ID)
+	audit.WorkspaceID = &elevation.WorkspaceID
+	audit.After = elevation
+	return c.JSON(http.StatusCreated, elevation)
+}
+
+// ListMyElevations 내 권한 승격 요청 목록
+// @Summary List my elevations
+// @Description Lists the caller's elevation requests with their approval and revocation state, newest first.
+// @Tags elevations
+// @Produce json
+// @Param status query string false "pending, approved, denied, cancelled, revoked or expired"
+// @Success 200 {array} model.ElevationRequest
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/elevations [get]
+// @Id listMyElevations
+func (h *ElevationHandler) ListMyElevations(c echo.Context) error {
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	elevations, err := h.elevationService.ListMine(requester, c.QueryParam("status"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, elevations)
+}
+
+// GetMyElevation 내 권한 승격 요청 조회
+// @Summary Get my elevation
+// @Description Returns one of the caller's elevation requests.
+// @Tags elevations
+// @Produce json
+// @Param elevationId path int true "Elevation request ID"
+// @Success 200 {object} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid elevation request ID"
+// @Failure 404 {object} map[string]string "error: Elevation request not found"
+// @Security BearerAuth
+// @Router /api/users/me/elevations/{elevationId} [get]
+// @Id getMyElevation
+func (h *ElevationHandler) GetMyElevation(c echo.Context) error {
+	elevationID, err := strconv.ParseUint(c.Param("elevationId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid elevation request ID"})
+	}
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	elevation, err := h.elevationService.GetMine(uint(elevationID), requester)
+	if err != nil {
+		return elevationError(c, err)
+	}
+	return c.JSON(http.StatusOK, elevation)
+}
+
+// CancelMyElevation 내 권한 승격 취소/반납
+// @Summary Cancel my elevation
+// @Description Cancels a pending elevation request, or relinquishes an approved elevation before it expires (the elevated role assignment is removed immediately).
+// @Tags elevations
+// @Produce json
+// @Param elevationId path int true "Elevation request ID"
+// @Success 200 {object} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid elevation request ID"
+// @Failure 404 {object} map[string]string "error: Elevation request not found"
+// @Failure 409 {object} map[string]string "error: Elevation already ended"
+// @Security BearerAuth
+// @Router /api/users/me/elevations/{elevationId}/cancel [post]
+// @Id cancelMyElevation
+func (h *ElevationHandler) CancelMyElevation(c echo.Context) error {
+	elevationID, err := strconv.ParseUint(c.Param("elevationId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid elevation request ID"})
+	}
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionElevationCancel, model.AuditEntityElevation, uint(elevationID))
+	elevation, err := h.elevationService.Cancel(uint(elevationID), requester)
+	if err != nil {
+		return elevationError(c, err)
+	}
+	audit.WorkspaceID = &elevation.WorkspaceID
+	audit.After = elevation
+	return c.JSON(http.StatusOK, elevation)
+}
+
+// ListMyElevationApprovals 내가 결정할 승격 요청
+// @Summary List elevation requests awaiting my decision
+// @Description Lists pending elevation requests the caller may approve or deny: every request for elevation managers, otherwise requests in workspaces where the caller holds the elevation approve permission. The caller's own requests are never included.
+// @Tags elevations
+// @Produce json
+// @Success 200 {array} model.ElevationRequest
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/elevation-approvals [get]
+// @Id listMyElevationApprovals
+func (h *ElevationHandler) ListMyElevationApprovals(c echo.Context) error {
+	approver, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	elevations, err := h.elevationService.ListApprovable(c.Request().Context(), approver)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, elevations)
+}
+
+// GetElevationPolicy 승격 기간 정책 조회
+// @Summary Get elevation policy
+// @Description Returns the default and maximum elevation duration in minutes (MC_IAM_MANAGER_ELEVATION_DEFAULT_MINUTES, MC_IAM_MANAGER_ELEVATION_MAX_MINUTES).
+// @Tags elevations
+// @Produce json
+// @Success 200 {object} model.ElevationPolicy
+// @Security BearerAuth
+// @Router /api/elevations/policy [get]
+// @Id getElevationPolicy
+func (h *ElevationHandler) GetElevationPolicy(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.elevationService.Policy())
+}
+
+// ListElevations 권한 승격 요청 목록 (관리자)
+// @Summary List elevations
+// @Description Lists elevation requests of all users, newest first.
+// @Tags elevations
+// @Produce json
+// @Param status query string false "pending, approved, denied, cancelled, revoked or expired"
+// @Param workspaceId query int false "Workspace ID"
+// @Param requesterId query int false "Requester user ID"
+// @Success 200 {array} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/elevations [get]
+// @Id listElevations
+func (h *ElevationHandler) ListElevations(c echo.Context) error {
+	var filter model.ElevationFilter
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+	elevations, err := h.elevationService.List(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, elevations)
+}
+
+// DecideElevation 권한 승격 승인/거절
+// @Summary Decide elevation request
+// @Description Approves or denies a pending elevation request. Approval assigns the workspace role to the requester from now until now plus the requested duration, subject to segregation-of-duties constraints. Requesters cannot decide on their own requests.
+// @Tags elevations
+// @Accept json
+// @Produce json
+// @Param elevationId path int true "Elevation request ID"
+// @Param request body model.ElevationDecisionRequest true "Decision"
+// @Success 200 {object} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: Not an approver of the request"
+// @Failure 404 {object} map[string]string "error: Elevation request not found"
+// @Failure 409 {object} map[string]string "error: Request already decided, role already held or SoD violation"
+// @Security BearerAuth
+// @Router /api/elevations/{elevationId}/decision [post]
+// @Id decideElevation
+func (h *ElevationHandler) DecideElevation(c echo.Context) error {
+	elevationID, err := strconv.ParseUint(c.Param("elevationId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid elevation request ID"})
+	}
+	var req model.ElevationDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	approver, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	action := model.AuditActionElevationApprove
+	if req.Decision == model.ElevationDecisionDeny {
+		action = model.AuditActionElevationDeny
+	}
+	audit := auditDetail(c, action, model.AuditEntityElevation, uint(elevationID))
+	elevation, err := h.elevationService.Decide(c.Request().Context(), uint(elevationID), &req, approver)
+	if err != nil {
+		return elevationError(c, err)
+	}
+	audit.WorkspaceID = &elevation.WorkspaceID
+	audit.After = elevation
+	return c.JSON(http.StatusOK, elevation)
+}
+
+// RevokeElevation 권한 승격 회수 (관리자)
+// @Summary Revoke elevation
+// @Description Ends an approved elevation before it expires and removes the elevated role assignment.
+// @Tags elevations
+// @Accept json
+// @Produce json
+// @Param elevationId path int true "Elevation request ID"
+// @Param request body model.ElevationRevokeRequest false "Reason"
+// @Success 200 {object} model.ElevationRequest
+// @Failure 400 {object} map[string]string "error: Invalid elevation request ID"
+// @Failure 404 {object} map[string]string "error: Elevation request not found"
+// @Failure 409 {object} map[string]string "error: Elevation not active"
+// @Security BearerAuth
+// @Router /api/elevations/{elevationId}/revoke [post]
+// @Id revokeElevation
+func (h *ElevationHandler) RevokeElevation(c echo.Context) error {
+	elevationID, err := strconv.ParseUint(c.Param("elevationId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid elevation request ID"})
+	}
+	var req model.ElevationRevokeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionElevationRevoke, model.AuditEntityElevation, uint(elevationID))
+	elevation, err := h.elevationService.Revoke(uint(elevationID), &req, actor)
+	if err != nil {
+		return elevationError(c, err)
+	}
+	audit.WorkspaceID = &elevation.WorkspaceID
+	audit.After = elevation
+	return c.JSON(http.StatusOK, elevation)
+}
+
+// elevationError 서비스 오류를 HTTP 응답으로 변환
+func elevationError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrElevationRequestNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidElevationRequest):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionDenied), errors.Is(err, service.ErrElevationSelfApproval):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrElevationNotPending), errors.Is(err, service.ErrElevationNotActive),
+		errors.Is(err, service.ErrElevationAlreadyOpen), errors.Is(err, service.ErrElevationRoleAlreadyHeld),
+		errors.Is(err, service.ErrSodViolation):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
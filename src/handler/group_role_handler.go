@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -15,6 +16,8 @@ import (
 // GroupRoleHandler 그룹 역할 관리 핸들러
 type GroupRoleHandler struct {
 	groupRoleService *service.GroupRoleService
+	workspaceAdmin   *service.WorkspaceAdminService
+	workflowService  *service.WorkflowService // nil 이면 역할 할당 승인 워크플로를 사용하지 않음
 	db               *gorm.DB
 }
 
@@ -22,6 +25,8 @@ type GroupRoleHandler struct {
 func NewGroupRoleHandler(db *gorm.DB) *GroupRoleHandler {
 	return &GroupRoleHandler{
 		groupRoleService: service.NewGroupRoleService(db),
+		workspaceAdmin:   service.NewWorkspaceAdminService(db),
+		workflowService:  service.NewWorkflowService(db),
 		db:               db,
 	}
 }
@@ -35,15 +40,89 @@ func (h *GroupRoleHandler) getUserKcID(userID uint) string {
 	return user.KcId
 }
 
+// groupPlatformRoleSnapshot 감사 스냅샷용 그룹 플랫폼 역할 이름 목록
+func (h *GroupRoleHandler) groupPlatformRoleSnapshot(groupID uint) map[string]interface{} {
+	names := []string{}
+	if roles, err := h.groupRoleService.GetGroupPlatformRoles(groupID); err == nil {
+		for _, role := range roles {
+			names = append(names, role.RoleName)
+		}
+	}
+	return map[string]interface{}{"groupId": groupID, "platformRoles": names}
+}
+
+// groupWorkspaceSnapshot 감사 스냅샷용 그룹-워크스페이스 매핑 역할 목록
+func (h *GroupRoleHandler) groupWorkspaceSnapshot(groupID uint) map[string]interface{} {
+	bindings := []map[string]interface{}{}
+	if workspaces, err := h.groupRoleService.GetGroupWorkspaces(groupID); err == nil {
+		for _, w := range workspaces {
+			bindings = append(bindings, map[string]interface{}{"workspaceId": w.WorkspaceID, "roleId": w.RoleID, "roleName": w.RoleName})
+		}
+	}
+	return map[string]interface{}{"groupId": groupID, "workspaces": bindings}
+}
+
+// groupMemberSnapshot 감사 스냅샷용 그룹 구성원 사용자 ID 목록
+func (h *GroupRoleHandler) groupMemberSnapshot(groupID uint) map[string]interface{} {
+	userIDs := []uint{}
+	h.db.Model(&model.UserOrganization{}).Where("organization_id = ?", groupID).Order("user_id").Pluck("user_id", &userIDs)
+	return map[string]interface{}{"groupId": groupID, "userIds": userIDs}
+}
+
+// userGroupSnapshot 감사 스냅샷용 사용자 소속 그룹 ID 목록
+func (h *GroupRoleHandler) userGroupSnapshot(userID uint) map[string]interface{} {
+	groupIDs := []uint{}
+	h.db.Model(&model.UserOrganization{}).Where("user_id = ?", userID).Order("organization_id").Pluck("organization_id", &groupIDs)
+	return map[string]interface{}{"userId": userID, "groupIds": groupIDs}
+}
+
+// submitGroupRoleAssignment 그룹 역할 할당/그룹 구성원 추가 승인 요청 생성 (202)
+func (h *GroupRoleHandler) submitGroupRoleAssignment(c echo.Context, payload *model.WorkflowRoleAssignmentPayload) error {
+	var summary string
+	switch payload.RoleType {
+	case model.WorkflowRoleGroupPlatform:
+		summary = fmt.Sprintf("assign platform role %d to group %d", payload.RoleID, payload.GroupID)
+	case model.WorkflowRoleGroupWorkspace:
+		summary = fmt.Sprintf("assign workspace role %d to group %d in workspace %d", payload.RoleID, payload.GroupID, payload.WorkspaceID)
+	case model.WorkflowRoleGroupMember:
+		if len(payload.GroupIDs) > 0 {
+			summary = fmt.Sprintf("add user %d to groups %v", payload.UserID, payload.GroupIDs)
+		} else {
+			summary = fmt.Sprintf("add users %v to group %d", payload.UserIDs, payload.GroupID)
+		}
+	}
+	var username string
+	if payload.UserID != 0 {
+		var user model.User
+		if err := h.db.First(&user, payload.UserID).Error; err == nil {
+			username = user.Username
+		}
+	}
+	request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+		Type:            model.WorkflowTypeRoleAssignment,
+		Key:             fmt.Sprintf("%s:%d:%d:%d:%d:%v:%v", payload.RoleType, payload.GroupID, payload.UserID, payload.WorkspaceID, payload.RoleID, payload.GroupIDs, payload.UserIDs),
+		Summary:         summary,
+		Payload:         payload,
+		SubjectUserID:   payload.UserID,
+		SubjectUsername: username,
+		WorkspaceID:     payload.WorkspaceID,
+	})
+	if err != nil {
+		return workflowError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, request)
+}
+
 // AssignGroupPlatformRole godoc
 // @Summary 그룹에 Platform Role 할당
-// @Description 그룹에 플랫폼 역할을 할당합니다. DB + Keycloak 이중 관리.
+// @Description 그룹에 플랫폼 역할을 할당합니다. DB + Keycloak 이중 관리. valid_from/expires_at 을 지정하면 해당 기간에만 유효합니다.
 // @Tags groups
 // @Accept json
 // @Produce json
 // @Param groupId path int true "그룹 ID"
 // @Param body body model.AssignGroupPlatformRoleRequest true "역할 할당 요청"
 // @Success 201 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 409 {object} map[string]string
@@ -64,8 +143,31 @@ func (h *GroupRoleHandler) AssignGroupPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if err := h.groupRoleService.AssignGroupPlatformRole(c.Request().Context(), uint(groupID), req.RoleID); err != nil {
+	// 역할 할당 승인 워크플로가 켜져 있으면 승인 후 할당
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitGroupRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType:  model.WorkflowRoleGroupPlatform,
+			GroupID:   uint(groupID),
+			RoleID:    req.RoleID,
+			ValidFrom: req.ValidFrom,
+			ExpiresAt: req.ExpiresAt,
+		})
+	}
+
+	audit := auditDetail(c, model.AuditActionGroupPlatformRoleAssign, model.AuditEntityGroup, uint(groupID))
+	audit.Before = h.groupPlatformRoleSnapshot(uint(groupID))
+
+	validity := model.AssignmentValidity{ValidFrom: req.ValidFrom, ExpiresAt: req.ExpiresAt}
+	if err := h.groupRoleService.AssignGroupPlatformRoleWithValidity(c.Request().Context(), uint(groupID), req.RoleID, validity); err != nil {
 		switch {
+		case errors.Is(err, service.ErrInvalidAssignmentValidity):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrSodViolation):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrOrganizationNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "그룹을 찾을 수 없습니다"})
 		case errors.Is(err, repository.ErrRoleMasterNotFound):
@@ -77,6 +179,7 @@ func (h *GroupRoleHandler) AssignGroupPlatformRole(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupPlatformRoleSnapshot(uint(groupID))
 	return c.JSON(http.StatusCreated, map[string]string{"message": "그룹에 플랫폼 역할이 할당되었습니다."})
 }
 
@@ -223,6 +326,9 @@ func (h *GroupRoleHandler) RemoveGroupPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role ID"})
 	}
 
+	audit := auditDetail(c, model.AuditActionGroupPlatformRoleRemove, model.AuditEntityGroup, uint(groupID))
+	audit.Before = h.groupPlatformRoleSnapshot(uint(groupID))
+
 	if err := h.groupRoleService.RemoveGroupPlatformRole(c.Request().Context(), uint(groupID), uint(roleID)); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -236,19 +342,22 @@ func (h *GroupRoleHandler) RemoveGroupPlatformRole(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupPlatformRoleSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹의 플랫폼 역할이 해제되었습니다."})
 }
 
 // AssignGroupWorkspace godoc
 // @Summary 그룹-워크스페이스 매핑
-// @Description 그룹을 워크스페이스에 매핑하고 역할을 지정합니다. DB 전용 관리.
+// @Description 그룹을 워크스페이스에 매핑하고 역할을 지정합니다. DB 전용 관리. valid_from/expires_at 을 지정하면 해당 기간에만 유효합니다. 플랫폼 조직 쓰기 권한이 없으면 해당 워크스페이스의 관리자가 위임 가능(delegable) 역할로만 매핑할 수 있습니다.
 // @Tags groups
 // @Accept json
 // @Produce json
 // @Param groupId path int true "그룹 ID"
 // @Param body body model.AssignGroupWorkspaceRequest true "워크스페이스 매핑 요청"
 // @Success 201 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 409 {object} map[string]string
 // @Security BearerAuth
@@ -268,8 +377,46 @@ func (h *GroupRoleHandler) AssignGroupWorkspace(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if err := h.groupRoleService.AssignGroupWorkspace(uint(groupID), req.WorkspaceID, req.RoleID); err != nil {
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	// 역할 할당 승인 워크플로가 켜져 있으면 매핑 권한 확인 후 승인 요청
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		if err := h.workspaceAdmin.AuthorizeGroupBinding(c.Request().Context(), actorID, req.WorkspaceID, req.RoleID); err != nil {
+			if isWorkspaceAdminDenied(err) {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+			}
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		return h.submitGroupRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType:    model.WorkflowRoleGroupWorkspace,
+			GroupID:     uint(groupID),
+			RoleID:      req.RoleID,
+			WorkspaceID: req.WorkspaceID,
+			ValidFrom:   req.ValidFrom,
+			ExpiresAt:   req.ExpiresAt,
+		})
+	}
+
+	audit := auditDetail(c, model.AuditActionGroupWorkspaceAssign, model.AuditEntityGroup, uint(groupID))
+	audit.WorkspaceID = &req.WorkspaceID
+	audit.Before = h.groupWorkspaceSnapshot(uint(groupID))
+
+	validity := model.AssignmentValidity{ValidFrom: req.ValidFrom, ExpiresAt: req.ExpiresAt}
+	if err := h.workspaceAdmin.BindGroup(c.Request().Context(), actorID, uint(groupID), req.WorkspaceID, req.RoleID, validity); err != nil {
 		switch {
+		case isWorkspaceAdminDenied(err):
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidAssignmentValidity):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrSodViolation):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrWorkspaceNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "워크스페이스를 찾을 수 없습니다"})
 		case errors.Is(err, repository.ErrRoleMasterNotFound):
@@ -281,6 +428,7 @@ func (h *GroupRoleHandler) AssignGroupWorkspace(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupWorkspaceSnapshot(uint(groupID))
 	return c.JSON(http.StatusCreated, map[string]string{"message": "그룹이 워크스페이스에 매핑되었습니다."})
 }
 
@@ -310,7 +458,7 @@ func (h *GroupRoleHandler) GetGroupWorkspaces(c echo.Context) error {
 
 // UpdateGroupWorkspaceRole godoc
 // @Summary 그룹 워크스페이스 역할 변경
-// @Description 그룹-워크스페이스 매핑의 역할을 변경합니다.
+// @Description 그룹-워크스페이스 매핑의 역할을 변경합니다. 워크스페이스 관리자는 현재 역할과 변경할 역할이 모두 위임 가능(delegable)일 때만 변경할 수 있습니다.
 // @Tags groups
 // @Accept json
 // @Produce json
@@ -319,6 +467,7 @@ func (h *GroupRoleHandler) GetGroupWorkspaces(c echo.Context) error {
 // @Param body body model.UpdateGroupWorkspaceRoleRequest true "역할 변경 요청"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/groups/id/{groupId}/workspaces/{workspaceId} [put]
@@ -341,8 +490,21 @@ func (h *GroupRoleHandler) UpdateGroupWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if err := h.groupRoleService.UpdateGroupWorkspaceRole(uint(groupID), uint(workspaceID), req.RoleID); err != nil {
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	wsID := uint(workspaceID)
+	audit := auditDetail(c, model.AuditActionGroupWorkspaceUpdate, model.AuditEntityGroup, uint(groupID))
+	audit.WorkspaceID = &wsID
+	audit.Before = h.groupWorkspaceSnapshot(uint(groupID))
+	if err := h.workspaceAdmin.UpdateGroupRole(c.Request().Context(), actorID, uint(groupID), uint(workspaceID), req.RoleID); err != nil {
 		switch {
+		case isWorkspaceAdminDenied(err):
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrSodViolation):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrGroupWorkspaceRoleNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "매핑을 찾을 수 없습니다"})
 		case errors.Is(err, repository.ErrRoleMasterNotFound):
@@ -352,18 +514,20 @@ func (h *GroupRoleHandler) UpdateGroupWorkspaceRole(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupWorkspaceSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹 워크스페이스 역할이 변경되었습니다."})
 }
 
 // RemoveGroupWorkspaceRole godoc
 // @Summary 그룹-워크스페이스 매핑 제거
-// @Description 그룹-워크스페이스 매핑을 제거합니다.
+// @Description 그룹-워크스페이스 매핑을 제거합니다. 워크스페이스 관리자는 위임 가능(delegable) 역할의 매핑만 제거할 수 있습니다.
 // @Tags groups
 // @Produce json
 // @Param groupId path int true "그룹 ID"
 // @Param workspaceId path int true "워크스페이스 ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/groups/id/{groupId}/workspaces/{workspaceId} [delete]
@@ -378,13 +542,26 @@ func (h *GroupRoleHandler) RemoveGroupWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
 	}
 
-	if err := h.groupRoleService.RemoveGroupWorkspaceRole(uint(groupID), uint(workspaceID)); err != nil {
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	wsID := uint(workspaceID)
+	audit := auditDetail(c, model.AuditActionGroupWorkspaceRemove, model.AuditEntityGroup, uint(groupID))
+	audit.WorkspaceID = &wsID
+	audit.Before = h.groupWorkspaceSnapshot(uint(groupID))
+	if err := h.workspaceAdmin.UnbindGroup(c.Request().Context(), actorID, uint(groupID), uint(workspaceID)); err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		if errors.Is(err, repository.ErrGroupWorkspaceRoleNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "매핑을 찾을 수 없습니다"})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	audit.After = h.groupWorkspaceSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹-워크스페이스 매핑이 제거되었습니다."})
 }
 
@@ -397,6 +574,7 @@ func (h *GroupRoleHandler) RemoveGroupWorkspaceRole(c echo.Context) error {
 // @Param groupId path int true "그룹 ID"
 // @Param body body model.AssignGroupUsersRequest true "사용자 일괄 할당 요청"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Security BearerAuth
@@ -416,8 +594,25 @@ func (h *GroupRoleHandler) AssignGroupUsers(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	// 그룹 구성원은 그룹 역할을 상속하므로 역할 할당 승인 워크플로가 켜져 있으면 승인 후 추가
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitGroupRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType: model.WorkflowRoleGroupMember,
+			GroupID:  uint(groupID),
+			UserIDs:  req.UserIDs,
+		})
+	}
+
+	audit := auditDetail(c, model.AuditActionGroupMemberAdd, model.AuditEntityGroup, uint(groupID))
+	audit.Before = h.groupMemberSnapshot(uint(groupID))
 	if err := h.groupRoleService.AssignUsersToGroup(c.Request().Context(), uint(groupID), req.UserIDs); err != nil {
 		switch {
+		case errors.Is(err, service.ErrSodViolation):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrOrganizationNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "그룹을 찾을 수 없습니다"})
 		default:
@@ -425,6 +620,7 @@ func (h *GroupRoleHandler) AssignGroupUsers(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupMemberSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 그룹에 할당되었습니다."})
 }
 
@@ -453,6 +649,8 @@ func (h *GroupRoleHandler) RemoveGroupUser(c echo.Context) error {
 
 	kcUserID := h.getUserKcID(uint(userID))
 
+	audit := auditDetail(c, model.AuditActionGroupMemberRemove, model.AuditEntityGroup, uint(groupID))
+	audit.Before = h.groupMemberSnapshot(uint(groupID))
 	if err := h.groupRoleService.RemoveUserFromGroup(c.Request().Context(), uint(userID), uint(groupID), kcUserID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -464,6 +662,7 @@ func (h *GroupRoleHandler) RemoveGroupUser(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupMemberSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 그룹에서 제거되었습니다."})
 }
 
@@ -495,6 +694,8 @@ func (h *GroupRoleHandler) RemoveGroupUsers(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	audit := auditDetail(c, model.AuditActionGroupMemberRemove, model.AuditEntityGroup, uint(groupID))
+	audit.Before = h.groupMemberSnapshot(uint(groupID))
 	if err := h.groupRoleService.RemoveUsersFromGroup(c.Request().Context(), uint(groupID), req.UserIDs); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -504,6 +705,7 @@ func (h *GroupRoleHandler) RemoveGroupUsers(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.groupMemberSnapshot(uint(groupID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 그룹에서 일괄 제거되었습니다."})
 }
 
@@ -516,6 +718,7 @@ func (h *GroupRoleHandler) RemoveGroupUsers(c echo.Context) error {
 // @Param userId path int true "사용자 ID"
 // @Param body body model.AssignUserGroupsRequest true "그룹 할당 요청"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/users/id/{userId}/groups [post]
@@ -534,10 +737,27 @@ func (h *GroupRoleHandler) AssignUserGroups(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	// 그룹 구성원은 그룹 역할을 상속하므로 역할 할당 승인 워크플로가 켜져 있으면 승인 후 추가
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitGroupRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType: model.WorkflowRoleGroupMember,
+			UserID:   uint(userID),
+			GroupIDs: req.GroupIDs,
+		})
+	}
+
 	kcUserID := h.getUserKcID(uint(userID))
 
+	audit := auditDetail(c, model.AuditActionUserGroupAssign, model.AuditEntityUser, uint(userID))
+	audit.Before = h.userGroupSnapshot(uint(userID))
 	if err := h.groupRoleService.AssignUserToGroups(c.Request().Context(), uint(userID), req.GroupIDs, kcUserID); err != nil {
 		switch {
+		case errors.Is(err, service.ErrSodViolation):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrOrganizationNotFound):
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		default:
@@ -545,6 +765,7 @@ func (h *GroupRoleHandler) AssignUserGroups(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.userGroupSnapshot(uint(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 그룹에 할당되었습니다."})
 }
 
@@ -572,10 +793,13 @@ func (h *GroupRoleHandler) RemoveUserFromGroup(c echo.Context) error {
 
 	kcUserID := h.getUserKcID(uint(userID))
 
+	audit := auditDetail(c, model.AuditActionUserGroupRemove, model.AuditEntityUser, uint(userID))
+	audit.Before = h.userGroupSnapshot(uint(userID))
 	if err := h.groupRoleService.RemoveUserFromGroup(c.Request().Context(), uint(userID), uint(groupID), kcUserID); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	audit.After = h.userGroupSnapshot(uint(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 그룹에서 제거되었습니다."})
 }
 
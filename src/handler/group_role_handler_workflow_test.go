@@ -0,0 +1,138 @@
+package handler
+
+// group_role_handler_workflow_test.go
+//
+// 그룹 역할 할당/그룹 구성원 추가의 역할 할당 승인 워크플로 적용 확인 (SQLite shared in-memory DB)
+// 워크플로가 켜져 있으면 바로 반영하지 않고 승인 요청(202)을 만들어야 한다.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type groupWorkflowFixture struct {
+	e         *echo.Echo
+	db        *gorm.DB
+	group     *model.Organization
+	member    *model.User
+	role      *model.RoleMaster
+	workspace *model.Workspace
+}
+
+func setupGroupWorkflowTest(t *testing.T) *groupWorkflowFixture {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:group_role_workflow_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	tables := []interface{}{
+		&model.Organization{}, &model.UserOrganization{}, &model.User{}, &model.RoleMaster{}, &model.RoleSub{}, &model.Workspace{},
+		&model.UserPlatformRole{}, &model.UserWorkspaceRole{}, &model.GroupPlatformRole{}, &model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&model.WorkflowPolicy{}, &model.WorkflowRequest{}, &model.WorkflowDecision{}, &model.WorkflowComment{},
+	}
+	require.NoError(t, db.Migrator().DropTable(tables...))
+	require.NoError(t, db.AutoMigrate(tables...))
+	require.NoError(t, service.DefaultAuthzCache().RegisterInvalidation(db))
+
+	f := &groupWorkflowFixture{db: db}
+	requester := &model.User{Username: "gwf-admin", KcId: "kc-gwf-admin"}
+	require.NoError(t, db.Create(requester).Error)
+	f.member = &model.User{Username: "gwf-alice", KcId: "kc-gwf-alice"}
+	require.NoError(t, db.Create(f.member).Error)
+	f.group = &model.Organization{Name: "gwf-group", OrganizationCode: "GWF"}
+	require.NoError(t, db.Create(f.group).Error)
+	f.workspace = &model.Workspace{Name: "gwf-ws"}
+	require.NoError(t, db.Create(f.workspace).Error)
+	f.role = &model.RoleMaster{Name: "gwf-operator"}
+	require.NoError(t, db.Create(f.role).Error)
+	require.NoError(t, db.Create(&model.RoleSub{RoleID: f.role.ID, RoleType: constants.RoleTypePlatform}).Error)
+	require.NoError(t, db.Create(&model.RoleSub{RoleID: f.role.ID, RoleType: constants.RoleTypeWorkspace}).Error)
+
+	// 요청자: 조직 쓰기 권한 (그룹-워크스페이스 매핑 허용)
+	adminRole := &model.RoleMaster{Name: "gwf-org-admin"}
+	require.NoError(t, db.Create(adminRole).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: requester.ID, RoleID: adminRole.ID}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypePlatform, RoleID: adminRole.ID,
+		PermissionID: "mc-iam-manager:organization:write", Effect: model.PermissionEffectAllow,
+	}).Error)
+
+	workflowService := service.NewWorkflowService(db)
+	_, err = workflowService.UpdatePolicy(model.WorkflowTypeRoleAssignment, &model.WorkflowPolicy{
+		Enabled:       true,
+		ApproverRules: []model.WorkflowApproverRule{{Kind: model.WorkflowApproverPlatformRole, RoleName: "gwf-reviewer"}},
+	}, nil)
+	require.NoError(t, err)
+
+	h := &GroupRoleHandler{
+		groupRoleService: service.NewGroupRoleService(db),
+		workspaceAdmin:   service.NewWorkspaceAdminService(db),
+		workflowService:  workflowService,
+		db:               db,
+	}
+	f.e = newTestValidatorEcho()
+	f.e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("kcUserId", c.Request().Header.Get("X-Test-User"))
+			return next(c)
+		}
+	})
+	f.e.POST("/api/groups/id/:groupId/platform-roles", h.AssignGroupPlatformRole)
+	f.e.POST("/api/groups/id/:groupId/workspaces", h.AssignGroupWorkspace)
+	f.e.POST("/api/groups/id/:groupId/users", h.AssignGroupUsers)
+	f.e.POST("/api/users/id/:userId/groups", h.AssignUserGroups)
+	return f
+}
+
+func (f *groupWorkflowFixture) post(user, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", user)
+	rec := httptest.NewRecorder()
+	f.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func (f *groupWorkflowFixture) count(t *testing.T, value interface{}) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, f.db.Model(value).Count(&n).Error)
+	return n
+}
+
+// TC-WF-GROUP-H-01: 역할 할당 워크플로가 켜져 있으면 그룹 역할 할당·구성원 추가는 승인 요청(202)만 생성
+func TestGroupRoleAssignment_RequiresWorkflowApproval(t *testing.T) {
+	f := setupGroupWorkflowTest(t)
+	groupPath := "/api/groups/id/" + uintToStr(f.group.ID)
+
+	rec := f.post("kc-gwf-admin", groupPath+"/platform-roles", `{"role_id":`+uintToStr(f.role.ID)+`}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	rec = f.post("kc-gwf-admin", groupPath+"/workspaces", `{"workspace_id":`+uintToStr(f.workspace.ID)+`,"role_id":`+uintToStr(f.role.ID)+`}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	rec = f.post("kc-gwf-admin", groupPath+"/users", `{"user_ids":[`+uintToStr(f.member.ID)+`]}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	rec = f.post("kc-gwf-admin", "/api/users/id/"+uintToStr(f.member.ID)+"/groups", `{"group_ids":[`+uintToStr(f.group.ID)+`]}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	assert.Zero(t, f.count(t, &model.GroupPlatformRole{}), "승인 전 그룹 플랫폼 역할 미할당")
+	assert.Zero(t, f.count(t, &model.GroupWorkspaceRole{}), "승인 전 그룹-워크스페이스 미매핑")
+	assert.Zero(t, f.count(t, &model.UserOrganization{}), "승인 전 그룹 구성원 미추가")
+
+	var requests []model.WorkflowRequest
+	require.NoError(t, f.db.Where("type = ?", model.WorkflowTypeRoleAssignment).Find(&requests).Error)
+	assert.Len(t, requests, 4)
+
+	// 그룹-워크스페이스 매핑은 승인 요청 전에 요청자의 매핑 권한을 확인
+	rec = f.post("kc-gwf-alice", groupPath+"/workspaces", `{"workspace_id":`+uintToStr(f.workspace.ID)+`,"role_id":`+uintToStr(f.role.ID)+`}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
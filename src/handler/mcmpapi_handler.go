@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings" // Import strings
 
 	"github.com/labstack/echo/v4"
@@ -19,7 +20,8 @@ const apiYamlEnvVar = "MC_ADMIN_CLI_APIYAML"
 
 // McmpApiHandler handles requests related to mcmp API definitions. (Renamed)
 type McmpApiHandler struct {
-	service service.McmpApiService // Use renamed service interface
+	service      service.McmpApiService // Use renamed service interface
+	authzService *service.AuthzService  // 거부(deny) 매핑 확인
 	// db *gorm.DB // Not needed directly in handler
 }
 
@@ -27,7 +29,7 @@ type McmpApiHandler struct {
 func NewMcmpApiHandler(db *gorm.DB) *McmpApiHandler { // Accept db, remove service param
 	// Initialize service internally
 	mcmpApiService := service.NewMcmpApiService(db)
-	return &McmpApiHandler{service: mcmpApiService} // Renamed struct type
+	return &McmpApiHandler{service: mcmpApiService, authzService: service.NewAuthzService(db)} // Renamed struct type
 }
 
 // SyncMcmpAPIs godoc
@@ -139,12 +141,14 @@ func (h *McmpApiHandler) SetActiveVersion(c echo.Context) error {
 // McmpApiCall godoc
 // @Summary Call an external MCMP API action (Structured Request)
 // @Description Executes a defined MCMP API action with parameters structured in McmpApiCallRequest.
+// @Description Even when the RPT grants the action, the call is rejected if a permission mapped to the action is denied to the caller by an MC-IAM role (platform roles, plus workspace roles of workspaceId, or of every workspace the caller holds a role in when workspaceId is omitted or the caller has no role there).
 // @Tags McmpAPI
 // @Accept json
 // @Produce json
 // @Param callRequest body model.McmpApiCallRequest true "API Call Request"
 // @Success 200 {object} object "External API Response (structure depends on the called API)"
 // @Failure 400 {object} map[string]string "error: Invalid request body or parameters"
+// @Failure 403 {object} map[string]string "error: Permission denied"
 // @Failure 404 {object} map[string]string "error: Service or action not found"
 // @Failure 500 {object} map[string]string "error: Internal server error or failed to call external API"
 // @Failure 503 {object} map[string]string "error: External API unavailable"
@@ -229,6 +233,11 @@ func (h *McmpApiHandler) McmpApiCall(c echo.Context) error { // Renamed function
 	}
 	// --- RPT Validation and Permission Check END ---
 
+	// 거부(deny) 매핑 확인: RPT 에서 허용되더라도 액션에 매핑된 권한이 역할에 의해 거부되면 호출하지 않는다.
+	if rejected, err := h.rejectDeniedMcmpApiAction(c, requiredPermission, &req); rejected {
+		return err
+	}
+
 	// If permission check passed, proceed to call the service
 	statusCode, respBody, serviceVersion, calledURL, err := h.service.McmpApiCall(c.Request().Context(), &req) // Get new return values
 	if err != nil {
@@ -262,6 +271,51 @@ func (h *McmpApiHandler) McmpApiCall(c echo.Context) error { // Renamed function
 	return nil // Response already written
 }
 
+// rejectDeniedMcmpApiAction 호출자에게 액션에 매핑된 권한이 거부되었으면 오류 응답을 쓰고 true 를 반환
+func (h *McmpApiHandler) rejectDeniedMcmpApiAction(c echo.Context, requiredPermission string, req *model.McmpApiCallRequest) (bool, error) {
+	kcUserId, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserId == "" {
+		return false, nil
+	}
+	denials, err := h.mcmpApiActionDenials(c, kcUserId, req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidWorkspaceID) {
+			return true, c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		log.Printf("거부 매핑 확인 실패 (McmpApiCall): %v", err)
+		return true, c.JSON(http.StatusInternalServerError, map[string]string{"error": "권한 확인에 실패했습니다"})
+	}
+	if len(denials) > 0 {
+		log.Printf("권한 거부 (McmpApiCall): '%s' 는 '%s' 거부 매핑에 의해 차단됨", requiredPermission, denials[0].Permission)
+		return true, c.JSON(http.StatusForbidden, map[string]string{"error": fmt.Sprintf("권한 거부: '%s' 권한이 역할에 의해 거부되었습니다.", denials[0].Permission)})
+	}
+	return false, nil
+}
+
+// mcmpApiActionDenials 호출자에게 거부(deny)된, 액션에 매핑된 권한 조회
+// 등록되지 않은 사용자는 MC-IAM 역할이 없으므로 거부 매핑도 없다.
+// workspaceId 를 생략하면 사용자가 역할을 가진 모든 워크스페이스의 거부 매핑을 평가한다.
+func (h *McmpApiHandler) mcmpApiActionDenials(c echo.Context, kcUserId string, req *model.McmpApiCallRequest) ([]model.AuthzPermissionDecision, error) {
+	var workspaceID uint
+	if req.WorkspaceID != "" {
+		id, err := strconv.ParseUint(req.WorkspaceID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", req.WorkspaceID, service.ErrInvalidWorkspaceID)
+		}
+		workspaceID = uint(id)
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.authzService.ResolveSubject(ctx, "", kcUserId)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return h.authzService.GetMcmpApiActionDenials(ctx, user.ID, workspaceID, req.ServiceName, req.ActionName)
+}
+
 // GetAllAPIDefinitions godoc
 // @Summary Get All Stored MCMP API Definitions
 // @Description Retrieves all MCMP API service and action definitions currently stored in the database.
@@ -0,0 +1,90 @@
+package handler
+
+// mcmpapi_handler_deny_test.go
+//
+// MCMP API 호출 거부(deny) 매핑 확인 테스트 (SQLite shared in-memory DB)
+// RPT 검증 이후 단계만 검증한다: workspaceId 를 생략해도 워크스페이스 역할의 거부 매핑이 적용되어야 한다.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupMcmpApiDenyTest(t *testing.T) *echo.Echo {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:mcmpapi_deny_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	tables := []interface{}{
+		&model.Organization{}, &model.UserOrganization{}, &model.User{}, &model.RoleMaster{}, &model.Workspace{},
+		&model.UserPlatformRole{}, &model.UserWorkspaceRole{}, &model.GroupPlatformRole{}, &model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{}, &mcmpapi.McmpApiAction{}, &mcmpapi.McmpApiPermissionActionMapping{},
+	}
+	require.NoError(t, db.Migrator().DropTable(tables...))
+	require.NoError(t, db.AutoMigrate(tables...))
+	require.NoError(t, service.DefaultAuthzCache().RegisterInvalidation(db))
+
+	user := &model.User{Username: "deny-operator", KcId: "kc-deny-operator"}
+	require.NoError(t, db.Create(user).Error)
+	role := &model.RoleMaster{Name: "workspace-operator"}
+	require.NoError(t, db.Create(role).Error)
+	ws := &model.Workspace{Name: "deny-ws"}
+	require.NoError(t, db.Create(ws).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: role.ID,
+		PermissionID: "mc-infra-manager:vm:delete", Effect: model.PermissionEffectDeny,
+	}).Error)
+	action := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "DelMciVm", Method: "DELETE"}
+	require.NoError(t, db.Create(action).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{
+		PermissionID: "mc-infra-manager:vm:delete", ActionID: action.ID, ActionName: action.ActionName,
+	}).Error)
+
+	h := &McmpApiHandler{authzService: service.NewAuthzService(db)}
+	e := echo.New()
+	e.POST("/api/mcmp-apis/mcmpApiCall", func(c echo.Context) error {
+		c.Set("kcUserId", c.Request().Header.Get("X-Test-User"))
+		var req model.McmpApiCallRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		if rejected, err := h.rejectDeniedMcmpApiAction(c, req.ServiceName+"#"+req.ActionName, &req); rejected {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	return e
+}
+
+// TC-DENY-H-01: workspaceId 를 생략해도 워크스페이스 역할의 거부 매핑으로 403, 잘못된 workspaceId 는 400
+func TestMcmpApiCall_DenyWithoutWorkspaceID(t *testing.T) {
+	e := setupMcmpApiDenyTest(t)
+	for _, tc := range []struct {
+		name, user, body string
+		want             int
+	}{
+		{"omitted workspace", "kc-deny-operator", `{"serviceName":"mc-infra-manager","actionName":"DelMciVm"}`, http.StatusForbidden},
+		{"invalid workspace", "kc-deny-operator", `{"serviceName":"mc-infra-manager","actionName":"DelMciVm","workspaceId":"abc"}`, http.StatusBadRequest},
+		{"unmapped action", "kc-deny-operator", `{"serviceName":"mc-infra-manager","actionName":"GetMciVm"}`, http.StatusOK},
+		{"unregistered user", "kc-unknown", `{"serviceName":"mc-infra-manager","actionName":"DelMciVm"}`, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/mcmp-apis/mcmpApiCall", strings.NewReader(tc.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("X-Test-User", tc.user)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, tc.name)
+	}
+}
@@ -38,6 +38,12 @@ func NewMenuHandler(db *gorm.DB) *MenuHandler {
 
 // Helper function moved to util package
 
+// menuUserID 메뉴를 요청한 사용자의 DB ID (조건부 거부 매핑 평가용)
+func (h *MenuHandler) menuUserID(c echo.Context) (uint, error) {
+	kcUserID, _ := c.Get("kcUserId").(string)
+	return h.menuService.MenuUserID(kcUserID)
+}
+
 // ListUserMenuTree godoc
 // @Summary Get current user's menu tree
 // @Description Get the menu tree accessible to the current user's platform role.
@@ -75,8 +81,13 @@ func (h *MenuHandler) ListUserMenuTree(c echo.Context) error {
 		platformRoleNames = append(platformRoleNames, role.ID)
 	}
 
+	userID, err := h.menuUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("failed to find user: %v", err)})
+	}
+
 	// Call the service method with platform role IDs
-	menuTree, err := h.menuService.BuildUserMenuTree(c.Request().Context(), platformRoleNames)
+	menuTree, err := h.menuService.BuildUserMenuTree(c.Request().Context(), userID, platformRoleNames)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": fmt.Sprintf("Failed to retrieve menu tree: %v", err),
@@ -155,7 +166,12 @@ func (h *MenuHandler) ListUserMenu(c echo.Context) error {
 	}
 	req.RoleIDs = platformRoleIDs
 
-	menuList, err := h.menuService.MenuList(req)
+	userID, err := h.menuUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("failed to find user: %v", err)})
+	}
+
+	menuList, err := h.menuService.MenuList(c.Request().Context(), userID, req)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": fmt.Sprintf("Failed to retrieve user menu: %v", err),
@@ -380,6 +396,8 @@ func (h *MenuHandler) CreateMenu(c echo.Context) error {
 	}
 
 	// 메뉴 생성 + 역할 매핑 (트랜잭션)
+	audit := auditDetail(c, model.AuditActionMenuCreate, model.AuditEntityMenu, 0)
+	audit.EntityID = req.ID
 	resp, err := h.menuService.CreateWithRoleMappings(req)
 	if err != nil {
 		c.Logger().Debugf("CreateMenu err %v", err)
@@ -396,6 +414,7 @@ func (h *MenuHandler) CreateMenu(c echo.Context) error {
 			"error": fmt.Sprintf("메뉴 생성에 실패했습니다: %v", err),
 		})
 	}
+	audit.After = resp
 
 	return c.JSON(http.StatusCreated, resp)
 }
@@ -651,13 +670,13 @@ func (h *MenuHandler) CreateMenusRolesMapping(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
 	}
 
+	roleIDInt, err := util.StringToUint(req.RoleID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role ID"})
+	}
+
 	var mappings []*model.RoleMenuMapping
 	for _, menuID := range req.MenuIDs {
-		roleIDInt, err := util.StringToUint(req.RoleID)
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role ID"})
-		}
-
 		mapping := &model.RoleMenuMapping{
 			RoleID:    roleIDInt,
 			MenuID:    menuID,
@@ -665,9 +684,13 @@ func (h *MenuHandler) CreateMenusRolesMapping(c echo.Context) error {
 		}
 		mappings = append(mappings, mapping)
 	}
+
+	audit := auditDetail(c, model.AuditActionRoleMenuMap, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	if err := h.menuService.CreateRoleMenuMappings(mappings); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("메뉴 매핑 생성 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 	return c.JSON(http.StatusCreated, map[string]string{"message": "Menu mapping created successfully"})
 }
 
@@ -697,10 +720,13 @@ func (h *MenuHandler) DeleteMenusRolesMapping(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "menuId is required"})
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleMenuUnmap, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	err = h.menuService.DeleteRoleMenuMappingByRoleAndMenu(roleIDInt, menuID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Menu mapping deleted successfully"})
 }
@@ -735,7 +761,12 @@ func (h *MenuHandler) GetUserMenuTree(c echo.Context) error {
 		platformRoleIDs = append(platformRoleIDs, role.ID)
 	}
 
-	menuTree, err := h.menuService.BuildUserMenuTree(c.Request().Context(), platformRoleIDs)
+	userID, err := h.menuUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	menuTree, err := h.menuService.BuildUserMenuTree(c.Request().Context(), userID, platformRoleIDs)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
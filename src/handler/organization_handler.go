@@ -25,6 +25,28 @@ func NewOrganizationHandler(db *gorm.DB) *OrganizationHandler {
 	}
 }
 
+// userOrganizationSnapshot 감사 스냅샷용 사용자 소속 조직(그룹) 목록
+func (h *OrganizationHandler) userOrganizationSnapshot(userID uint) map[string]interface{} {
+	groups := []map[string]interface{}{}
+	if orgs, err := h.orgService.GetUserOrganizations(userID); err == nil {
+		for _, org := range orgs {
+			groups = append(groups, map[string]interface{}{"id": org.ID, "name": org.Name})
+		}
+	}
+	return map[string]interface{}{"userId": userID, "groups": groups}
+}
+
+// organizationUserSnapshot 감사 스냅샷용 조직(그룹) 소속 사용자 목록
+func (h *OrganizationHandler) organizationUserSnapshot(orgID uint) map[string]interface{} {
+	users := []map[string]interface{}{}
+	if members, err := h.orgService.GetOrganizationUsers(orgID); err == nil {
+		for _, user := range members {
+			users = append(users, map[string]interface{}{"id": user.ID, "username": user.Username})
+		}
+	}
+	return map[string]interface{}{"groupId": orgID, "users": users}
+}
+
 // SetupInitialOrganizations godoc
 // @Summary 기본 조직 초기화
 // @Description YAML 시드 파일에서 기본 조직 구조(MZC + 8개 프레임워크)를 로드하여 등록합니다. 멱등성 보장.
@@ -239,6 +261,12 @@ func (h *OrganizationHandler) DeleteOrganization(c echo.Context) error {
 
 	cascade := c.QueryParam("cascade") == "true"
 
+	// 삭제된 그룹의 구성원은 그룹 역할 상속을 잃으므로 삭제 전 구성원을 남긴다
+	audit := auditDetail(c, model.AuditActionGroupDelete, model.AuditEntityGroup, uint(id))
+	before := h.organizationUserSnapshot(uint(id))
+	before["cascade"] = cascade
+	audit.Before = before
+
 	if cascade {
 		if err := h.orgService.DeleteOrganizationCascade(c.Request().Context(), uint(id)); err != nil {
 			if errors.Is(err, repository.ErrOrganizationNotFound) {
@@ -407,12 +435,18 @@ func (h *OrganizationHandler) AssignUserOrganizations(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	audit := auditDetail(c, model.AuditActionUserGroupAssign, model.AuditEntityUser, uint(userID))
+	audit.Before = h.userOrganizationSnapshot(uint(userID))
 	if err := h.orgService.AssignUserToOrganizations(uint(userID), req.OrganizationIDs); err != nil {
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		if errors.Is(err, repository.ErrOrganizationNotFound) {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	audit.After = h.userOrganizationSnapshot(uint(userID))
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 조직에 할당되었습니다."})
 }
@@ -477,9 +511,15 @@ func (h *OrganizationHandler) ReplaceUserGroups(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	audit := auditDetail(c, model.AuditActionUserGroupReplace, model.AuditEntityUser, uint(userID))
+	audit.Before = h.userOrganizationSnapshot(uint(userID))
 	if err := h.orgService.ReplaceUserGroups(uint(userID), req.GroupIDs); err != nil {
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
+	audit.After = h.userOrganizationSnapshot(uint(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자 그룹 멤버십이 교체되었습니다."})
 }
 
@@ -505,12 +545,15 @@ func (h *OrganizationHandler) RemoveUserOrganization(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid organization ID"})
 	}
 
+	audit := auditDetail(c, model.AuditActionUserGroupRemove, model.AuditEntityUser, uint(userID))
+	audit.Before = h.userOrganizationSnapshot(uint(userID))
 	if err := h.orgService.RemoveUserFromOrganization(uint(userID), uint(orgID)); err != nil {
 		if errors.Is(err, repository.ErrUserOrganizationNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "사용자가 해당 조직에 소속되어 있지 않습니다"})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	audit.After = h.userOrganizationSnapshot(uint(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "사용자가 조직에서 제거되었습니다."})
 }
 
@@ -199,13 +199,15 @@ func (h *MciamPermissionHandler) DeleteMciamPermission(c echo.Context) error { /
 
 // AssignRolePermission assign permission to role
 // @Summary 역할에 MC-IAM 권한 할당 - Renamed
-// @Description 역할에 MC-IAM 권한을 할당합니다.
+// @Description 역할에 MC-IAM 권한을 할당합니다. effect=deny 로 할당하면 거부 매핑이 되며, 다른 역할/그룹 경로의 허용보다 우선합니다.
 // @Tags roles, mciam-permissions
 // @Accept json
 // @Produce json
 // @Param roleType path string true "역할 타입 ('platform' or 'workspace')"
 // @Param roleId path int true "역할 ID"
 // @Param permissionId path string true "MC-IAM 권한 ID"
+// @Param effect query string false "매핑 효과 ('allow' or 'deny', 기본값 allow)"
+// @Param request body model.AssignRolePermissionRequest false "적용 조건 (IP 대역, 시간대, 조직, 워크스페이스/프로젝트 라벨)"
 // @Success 204 "No Content"
 // @Router /api/roles/{roleType}/{roleId}/mciam-permissions/{permissionId} [post] // Updated route
 // @Id assignMciamPermissionToRole
@@ -223,16 +225,32 @@ func (h *MciamPermissionHandler) AssignMciamPermissionToRole(c echo.Context) err
 
 	permissionID := c.Param("permissionId")
 
-	if err := h.permissionService.AssignMciamPermissionToRole(c.Request().Context(), roleType, uint(roleID), permissionID); err != nil { // Use renamed service method
+	effect, err := service.ParsePermissionEffect(c.QueryParam("effect"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	var req model.AssignRolePermissionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+
+	audit := auditDetail(c, model.AuditActionRolePermissionAssign, model.AuditEntityRole, uint(roleID))
+	audit.Before = h.rolePermissionMappingSnapshot(c, roleType, uint(roleID))
+	if err := h.permissionService.AssignMciamPermissionToRole(c.Request().Context(), roleType, uint(roleID), permissionID, effect, req.Conditions); err != nil { // Use renamed service method
 		// Handle specific errors like permission not found or role not found
 		if errors.Is(err, repository.ErrPermissionNotFound) { // Check for specific error
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, service.ErrInvalidGrantConditions) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		// TODO: Add check for role not found error if service implements it
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "권한 할당에 실패했습니다",
 		})
 	}
+	audit.After = h.rolePermissionMappingSnapshot(c, roleType, uint(roleID))
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -262,6 +280,8 @@ func (h *MciamPermissionHandler) RemoveMciamPermissionFromRole(c echo.Context) e
 
 	permissionID := c.Param("permissionId")
 
+	audit := auditDetail(c, model.AuditActionRolePermissionRemove, model.AuditEntityRole, uint(roleID))
+	audit.Before = h.rolePermissionMappingSnapshot(c, roleType, uint(roleID))
 	if err := h.permissionService.RemoveMciamPermissionFromRole(c.Request().Context(), roleType, uint(roleID), permissionID); err != nil { // Use renamed service method
 		// Handle specific error like mapping not found
 		if err.Error() == "role mciam permission mapping not found" { // Check specific error text from repo
@@ -271,17 +291,19 @@ func (h *MciamPermissionHandler) RemoveMciamPermissionFromRole(c echo.Context) e
 			"error": "권한 제거에 실패했습니다",
 		})
 	}
+	audit.After = h.rolePermissionMappingSnapshot(c, roleType, uint(roleID))
 	return c.NoContent(http.StatusNoContent)
 }
 
 // GetRolePermissions 역할의 권한 목록 조회
 // @Summary 역할의 MC-IAM 권한 목록 조회 - Renamed
-// @Description 특정 역할의 MC-IAM 권한 ID 목록을 조회합니다.
+// @Description 특정 역할의 MC-IAM 권한 ID 목록을 조회합니다. effect=deny 로 거부 매핑 목록을 조회합니다.
 // @Tags roles, mciam-permissions
 // @Accept json
 // @Produce json
 // @Param roleType path string true "역할 타입 ('platform' or 'workspace')"
 // @Param roleId path int true "역할 ID"
+// @Param effect query string false "매핑 효과 ('allow' or 'deny', 기본값 allow)"
 // @Success 200 {array} string "권한 ID 목록"
 // @Router /api/roles/{roleType}/{roleId}/mciam-permissions [get]
 // @Id getRoleMciamPermissions
@@ -297,7 +319,12 @@ func (h *MciamPermissionHandler) GetRoleMciamPermissions(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 역할 ID입니다"})
 	}
 
-	permissionIDs, err := h.permissionService.GetRoleMciamPermissions(c.Request().Context(), roleType, uint(roleID)) // Use renamed service method
+	effect, err := service.ParsePermissionEffect(c.QueryParam("effect"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	permissionIDs, err := h.permissionService.GetRoleMciamPermissions(c.Request().Context(), roleType, uint(roleID), effect) // Use renamed service method
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "권한 목록을 가져오는데 실패했습니다",
@@ -305,3 +332,16 @@ func (h *MciamPermissionHandler) GetRoleMciamPermissions(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, permissionIDs) // Return permissionIDs variable
 }
+
+// rolePermissionMappingSnapshot 감사 스냅샷용 역할의 MC-IAM 권한 매핑 (허용/거부)
+func (h *MciamPermissionHandler) rolePermissionMappingSnapshot(c echo.Context, roleType constants.IAMRoleType, roleID uint) map[string]interface{} {
+	snapshot := map[string]interface{}{"roleId": roleID, "roleType": roleType}
+	for _, effect := range []model.PermissionEffect{model.PermissionEffectAllow, model.PermissionEffectDeny} {
+		permissionIDs, err := h.permissionService.GetRoleMciamPermissions(c.Request().Context(), roleType, roleID, effect)
+		if err != nil || permissionIDs == nil {
+			permissionIDs = []string{}
+		}
+		snapshot[string(effect)] = permissionIDs
+	}
+	return snapshot
+}
@@ -201,6 +201,9 @@ func (h *ProjectHandler) UpdateProject(c echo.Context) error {
 		"name":        project.Name,
 		"description": project.Description,
 	}
+	if project.Labels != nil {
+		updates["labels"] = project.Labels // 조건부 권한 매핑의 projectLabels 평가 대상
+	}
 
 	if err := h.projectService.UpdateProject(projectIDInt, updates); err != nil {
 		if err.Error() == "project not found" {
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/report"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// ReportHandler 규정 준수 보고서 다운로드 핸들러
+type ReportHandler struct {
+	reportService *service.ReportService
+}
+
+// NewReportHandler ReportHandler 생성
+func NewReportHandler(db *gorm.DB) *ReportHandler {
+	return &ReportHandler{reportService: service.NewReportService(db)}
+}
+
+// ListReports 보고서 목록
+// @Summary List compliance reports
+// @Description Lists the compliance reports that can be downloaded from /api/reports/{reportName}.
+// @Tags reports
+// @Produce json
+// @Success 200 {array} model.ReportDefinition
+// @Security BearerAuth
+// @Router /api/reports [get]
+// @Id listReports
+func (h *ReportHandler) ListReports(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.reportService.ListReports())
+}
+
+// DownloadReport 보고서 다운로드
+// @Summary Download compliance report
+// @Description Generates a compliance report as CSV or XLSX. privileged-users lists users holding platformAdmin directly or through a group; dormant-accounts lists active users with no login, token refresh or token use for the given number of days (Keycloak LOGIN events are also considered when event storage is enabled); unmapped-csp-roles lists CSP roles without a role mapping; empty-workspace-roles lists workspace roles with no user or group; failed-idp-configs lists CSP IDP configs whose last health check failed.
+// @Tags reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param reportName path string true "privileged-users, dormant-accounts, unmapped-csp-roles, empty-workspace-roles or failed-idp-configs"
+// @Param format query string false "csv (default) or xlsx"
+// @Param days query int false "dormant-accounts: days without activity (default MC_IAM_MANAGER_REPORT_DORMANT_DAYS)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string "error: Invalid format or option"
+// @Failure 404 {object} map[string]string "error: Report not found"
+// @Security BearerAuth
+// @Router /api/reports/{reportName} [get]
+// @Id downloadReport
+func (h *ReportHandler) DownloadReport(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = report.FormatCSV
+	}
+	if !report.ValidFormat(format) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "format must be csv or xlsx"})
+	}
+	var opts model.ReportOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &opts); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid report option"})
+	}
+
+	name := c.Param("reportName")
+	table, err := h.reportService.Generate(c.Request().Context(), name, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReportNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidReportOption):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	var buf bytes.Buffer
+	if err := table.Write(&buf, format); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	c.Response().Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().UTC().Format("20060102T150405Z"), format),
+	)
+	return c.Blob(http.StatusOK, report.ContentType(format), buf.Bytes())
+}
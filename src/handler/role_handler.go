@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
 	"github.com/m-cmp/mc-iam-manager/service"
 	"github.com/m-cmp/mc-iam-manager/util"
 	"gorm.io/gorm"
@@ -23,6 +25,8 @@ type RoleHandler struct {
 	keycloakService service.KeycloakService
 	menuService     *service.MenuService
 	cspRoleService  *service.CspRoleService
+	workflowService *service.WorkflowService // nil 이면 역할 할당/CSP 매핑 승인 워크플로를 사용하지 않음
+	workspaceAdmin  *service.WorkspaceAdminService
 }
 
 // NewRoleHandler create new RoleHandler instance
@@ -38,6 +42,8 @@ func NewRoleHandler(db *gorm.DB) *RoleHandler {
 		keycloakService: keycloakService,
 		menuService:     menuService,
 		cspRoleService:  cspRoleService,
+		workflowService: service.NewWorkflowService(db),
+		workspaceAdmin:  service.NewWorkspaceAdminService(db),
 	}
 }
 
@@ -98,6 +104,7 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		ParentID:    req.ParentID,
+		Delegable:   req.Delegable,
 	}
 
 	roleSubs := make([]model.RoleSub, 0)
@@ -107,6 +114,8 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 		})
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleCreate, model.AuditEntityRole, 0)
+
 	// 1. Create CSP roles first (external API calls required, so handle outside transaction)
 	createdCspRoles := make([]model.CreateCspRoleRequest, 0)
 	if len(req.CspRoles) > 0 {
@@ -154,8 +163,10 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 	// 2. Create role and all dependencies together in transaction
 	createdRole, err := h.roleService.CreateRoleWithAllDependencies(role, roleSubs, req.MenuIDs, createdCspRoles, req.Description)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": err.Error()})
 	}
+	audit.EntityID = util.UintToString(createdRole.ID)
+	audit.After = roleSnapshot(h.roleService, createdRole.ID)
 
 	return c.JSON(http.StatusCreated, createdRole)
 }
@@ -200,6 +211,37 @@ func (h *RoleHandler) GetRoleByRoleID(c echo.Context) error {
 	return c.JSON(http.StatusOK, role)
 }
 
+// @Summary Get resolved role permissions
+// @Description Resolve a role's permissions, menus and CSP role mappings along its parent hierarchy. Each entry reports the role it comes from and whether it is inherited.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.ResolvedRolePermissions
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/roles/id/{roleId}/resolved-permissions [get]
+// @Id getResolvedRolePermissions
+func (h *RoleHandler) GetResolvedRolePermissions(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("roleId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid roleId ID format"})
+	}
+
+	resolved, err := h.roleService.GetResolvedRolePermissions(uint(id))
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleMasterNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Role with the specified ID not found"})
+		}
+		log.Printf("Failed to resolve role permissions - ID: %d, error: %v", id, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to resolve role permissions: %v", err)})
+	}
+
+	return c.JSON(http.StatusOK, resolved)
+}
+
 // @Summary Get role by Name
 // @Description Retrieve role details by role name.
 // @Tags roles
@@ -275,12 +317,18 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		ParentID:    req.ParentID,
+		Delegable:   req.Delegable,
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleUpdate, model.AuditEntityRole, roleIdInt)
+	audit.Before = roleSnapshot(h.roleService, roleIdInt)
+	// 메뉴/CSP 매핑 교체 중 실패해도 그때까지 반영된 상태를 남긴다
+	defer func() { audit.After = roleSnapshot(h.roleService, roleIdInt) }()
+
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
 		log.Printf("Failed to update role - ID: %d, error: %v", roleIdInt, err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to update role: %v", err)})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": fmt.Sprintf("Failed to update role: %v", err)})
 	}
 
 	if len(req.MenuIDs) > 0 {
@@ -417,6 +465,11 @@ func (h *RoleHandler) DeleteRole(c echo.Context) error {
 	// Check if users are assigned to this role -> if yes, cannot delete
 	// TODO : Implement this.
 
+	audit := auditDetail(c, model.AuditActionRoleDelete, model.AuditEntityRole, roleIdInt)
+	audit.Before = roleSnapshot(h.roleService, roleIdInt)
+	// 매핑 삭제 후 역할 삭제가 실패해도 그때까지 반영된 상태를 남긴다 (삭제 성공 시 nil)
+	defer func() { audit.After = roleSnapshot(h.roleService, roleIdInt) }()
+
 	roleSubs := role.RoleSubs
 	for _, roleSub := range roleSubs {
 		// Delete role-platform mapping
@@ -559,21 +612,41 @@ func (h *RoleHandler) AssignRole(c echo.Context) error {
 	}
 
 	// Assign role
+	validity := model.AssignmentValidity{ValidFrom: req.ValidFrom, ExpiresAt: req.ExpiresAt}
 	if reqRoleType == constants.RoleTypePlatform {
-		if err := h.roleService.AssignPlatformRole(userID, roleID); err != nil {
+		audit := auditDetail(c, model.AuditActionPlatformRoleAssign, model.AuditEntityUser, userID)
+		audit.Before = h.platformRoleSnapshot(userID)
+		if err := h.roleService.AssignPlatformRoleWithValidity(userID, roleID, validity); err != nil {
 			log.Printf("Failed to assign platform role - userID: %d, roleID: %s, error: %v", userID, req.RoleID, err)
+			if errors.Is(err, service.ErrInvalidAssignmentValidity) {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+			if errors.Is(err, service.ErrSodViolation) {
+				return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+			}
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to assign platform role: %v", err)})
 		}
+		audit.After = h.platformRoleSnapshot(userID)
 	} else if reqRoleType == constants.RoleTypeWorkspace {
 		if workspaceID == 0 {
 			log.Printf("Workspace ID missing - userID: %d, roleID: %s", userID, req.RoleID)
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Workspace ID is required"})
 		}
-		if err := h.roleService.AssignWorkspaceRole(userID, workspaceID, roleID); err != nil {
+		audit := auditDetail(c, model.AuditActionWorkspaceRoleAssign, model.AuditEntityUser, userID)
+		audit.WorkspaceID = &workspaceID
+		audit.Before = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
+		if err := h.roleService.AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID, validity); err != nil {
 			log.Printf("Failed to assign workspace role - userID: %d, workspaceID: %d, roleID: %s, error: %v",
 				userID, workspaceID, req.RoleID, err)
+			if errors.Is(err, service.ErrInvalidAssignmentValidity) {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+			if errors.Is(err, service.ErrSodViolation) {
+				return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+			}
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to assign workspace role: %v", err)})
 		}
+		audit.After = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 	}
 
 	log.Printf("Successfully assigned role - userID: %d, roleID: %s, roleType: %s", userID, req.RoleID, reqRoleType)
@@ -654,9 +727,12 @@ func (h *RoleHandler) RemoveRole(c echo.Context) error {
 
 	// 역할 제거
 	if reqRoleType == constants.RoleTypePlatform {
+		audit := auditDetail(c, model.AuditActionPlatformRoleRemove, model.AuditEntityUser, userID)
+		audit.Before = h.platformRoleSnapshot(userID)
 		if err := h.roleService.RemovePlatformRole(userID, roleID); err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 제거 실패: %v", err)})
 		}
+		audit.After = h.platformRoleSnapshot(userID)
 	} else if reqRoleType == constants.RoleTypeWorkspace {
 		var workspaceID uint
 		if req.WorkspaceID == "" {
@@ -669,9 +745,13 @@ func (h *RoleHandler) RemoveRole(c echo.Context) error {
 		}
 		workspaceID = workspaceIDInt
 
+		audit := auditDetail(c, model.AuditActionWorkspaceRoleRemove, model.AuditEntityUser, userID)
+		audit.WorkspaceID = &workspaceID
+		audit.Before = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 		if err := h.roleService.RemoveWorkspaceRole(userID, workspaceID, roleID); err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("워크스페이스 역할 제거 실패: %v", err)})
 		}
+		audit.After = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "지원하지 않는 역할 타입입니다"})
 	}
@@ -810,10 +890,13 @@ func (h *RoleHandler) CreatePlatformRole(c echo.Context) error {
 	}
 
 	// Create role and subtypes
+	audit := auditDetail(c, model.AuditActionRoleCreate, model.AuditEntityRole, 0)
 	createdRole, err := h.roleService.CreateRoleWithSubs(role, roleSubs)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": err.Error()})
 	}
+	audit.EntityID = util.UintToString(createdRole.ID)
+	audit.After = roleSnapshot(h.roleService, createdRole.ID)
 
 	return c.JSON(http.StatusCreated, createdRole)
 }
@@ -851,6 +934,7 @@ func (h *RoleHandler) CreateWorkspaceRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		Predefined:  false,
+		Delegable:   req.Delegable,
 	}
 
 	// Create RoleSubs
@@ -864,10 +948,13 @@ func (h *RoleHandler) CreateWorkspaceRole(c echo.Context) error {
 	}
 
 	// Create role and subtypes
+	audit := auditDetail(c, model.AuditActionRoleCreate, model.AuditEntityRole, 0)
 	createdRole, err := h.roleService.CreateRoleWithSubs(role, roleSubs)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": err.Error()})
 	}
+	audit.EntityID = util.UintToString(createdRole.ID)
+	audit.After = roleSnapshot(h.roleService, createdRole.ID)
 
 	log.Printf("Role creation successful - ID: %d", createdRole.ID)
 	return c.JSON(http.StatusCreated, createdRole)
@@ -1210,11 +1297,14 @@ func (h *RoleHandler) UpdatePlatformRole(c echo.Context) error {
 		ParentID:    req.ParentID,
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleUpdate, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
 		log.Printf("platform 역할 수정 실패 - ID: %d, 에러: %v", roleIDInt, err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("platform 역할 수정 성공 - ID: %d", roleIDInt)
 	return c.JSON(http.StatusOK, updatedRole)
@@ -1265,13 +1355,17 @@ func (h *RoleHandler) UpdateWorkspaceRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		ParentID:    req.ParentID,
+		Delegable:   req.Delegable,
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleUpdate, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
 		log.Printf("workspace 역할 수정 실패 - ID: %d, 에러: %v", roleIDInt, err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("workspace 역할 수정 성공 - ID: %d", roleIDInt)
 	return c.JSON(http.StatusOK, updatedRole)
@@ -1325,11 +1419,14 @@ func (h *RoleHandler) UpdateCspRoleMaster(c echo.Context) error {
 		ParentID:    req.ParentID,
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleUpdate, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
 		log.Printf("csp 역할 수정 실패 - ID: %d, 에러: %v", roleIDInt, err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("csp 역할 수정 성공 - ID: %d", roleIDInt)
 	return c.JSON(http.StatusOK, updatedRole)
@@ -1382,10 +1479,13 @@ func (h *RoleHandler) DeletePlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, map[string]string{"error": "미리 정의된 역할은 삭제할 수 없습니다"})
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleDelete, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	if err := h.roleService.DeleteRoleSubs(roleIDInt, []constants.IAMRoleType{constants.RoleTypePlatform}); err != nil {
 		log.Printf("platform 역할 삭제 실패 - ID: %d, 에러: %v", roleIDInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 삭제 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("platform 역할 삭제 성공 - ID: %d", roleIDInt)
 	return c.NoContent(http.StatusNoContent)
@@ -1438,10 +1538,13 @@ func (h *RoleHandler) DeleteWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, map[string]string{"error": "미리 정의된 역할은 삭제할 수 없습니다"})
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleDelete, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	if err := h.roleService.DeleteRoleSubs(roleIDInt, []constants.IAMRoleType{constants.RoleTypeWorkspace}); err != nil {
 		log.Printf("workspace 역할 삭제 실패 - ID: %d, 에러: %v", roleIDInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 삭제 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("workspace 역할 삭제 성공 - ID: %d", roleIDInt)
 	return c.JSON(http.StatusOK, map[string]string{"message": "workspace 역할 삭제 성공"})
@@ -1485,6 +1588,9 @@ func (h *RoleHandler) DeleteCspRole(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "해당 ID의 CSP 역할을 찾을 수 없습니다"})
 	}
 
+	// CSP 역할 레코드 삭제는 이를 참조하는 역할 매핑도 제거하므로 삭제 전 레코드를 남긴다
+	audit := auditDetail(c, model.AuditActionCspRoleDelete, model.AuditEntityCspRole, cspRoleIDInt)
+	audit.Before = existing
 	if err := h.roleService.DeleteRoleCspRoleMappingsByCspRoleID(cspRoleIDInt); err != nil {
 		log.Printf("csp 역할 매핑 삭제 실패 - ID: %d, 에러: %v", cspRoleIDInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 매핑 삭제 실패: %v", err)})
@@ -1541,10 +1647,13 @@ func (h *RoleHandler) CreateCspRoleMaster(c echo.Context) error {
 		}
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleCreate, model.AuditEntityRole, 0)
 	createdRole, err := h.roleService.CreateRoleWithSubs(role, roleSubs)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(roleHierarchyErrorStatus(err), map[string]string{"error": err.Error()})
 	}
+	audit.EntityID = util.UintToString(createdRole.ID)
+	audit.After = roleSnapshot(h.roleService, createdRole.ID)
 
 	log.Printf("Role creation successful - ID: %d", createdRole.ID)
 	return c.JSON(http.StatusCreated, createdRole)
@@ -1611,10 +1720,13 @@ func (h *RoleHandler) DeleteCspRoleMaster(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, map[string]string{"error": "csp 역할 매핑이 있어 삭제 불가"})
 	}
 
+	audit := auditDetail(c, model.AuditActionRoleDelete, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
 	if err := h.roleService.DeleteRoleSubs(roleIDInt, []constants.IAMRoleType{constants.RoleTypeCSP}); err != nil {
 		log.Printf("csp 역할(master) 삭제 실패 - ID: %d, 에러: %v", roleIDInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 삭제 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("csp 역할(master) 삭제 성공 - ID: %d", roleIDInt)
 	return c.NoContent(http.StatusNoContent)
@@ -1661,6 +1773,7 @@ func (h *RoleHandler) GetUserWorkspaceRoles(c echo.Context) error {
 // @Produce json
 // @Param request body model.AssignRoleRequest true "Platform Role Assignment Info"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -1693,6 +1806,9 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 		userID = uint(uid)
 	}
+	validity := model.AssignmentValidity{ValidFrom: req.ValidFrom, ExpiresAt: req.ExpiresAt}
+	audit := auditDetail(c, model.AuditActionPlatformRoleAssign, model.AuditEntityUser, userID)
+	audit.Before = h.platformRoleSnapshot(userID)
 
 	// RoleName이 없으면 DB에서 roleID로 조회
 	if req.RoleName == "" {
@@ -1713,12 +1829,46 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "해당 사용자를 찾을 수 없습니다"})
 	}
 
+	// 역할 할당 승인 워크플로가 켜져 있으면 승인 후 할당
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType:  model.WorkflowRolePlatform,
+			UserID:    userID,
+			RoleID:    roleID,
+			RoleName:  req.RoleName,
+			ValidFrom: req.ValidFrom,
+			ExpiresAt: req.ExpiresAt,
+		}, user.Username)
+	}
+
 	// 이미 할당 되어있는지 확인.
 	isAssignedPlatformRole, err := h.roleService.IsAssignedPlatformRole(userID, roleID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 확인 실패: %v", err)})
 	}
 	if isAssignedPlatformRole {
+		// 만료 시각이 지정되면 기존 할당의 만료 시각만 변경 (시작 시각은 유지)
+		if req.ExpiresAt != nil {
+			if err := h.roleService.UpdatePlatformRoleExpiry(userID, roleID, req.ExpiresAt); err != nil {
+				if errors.Is(err, service.ErrInvalidAssignmentValidity) {
+					return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+				}
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 만료 시각 변경 실패: %v", err)})
+			}
+		}
+		// 시작 전 할당은 Keycloak 에 아직 부여하지 않음 (만료 처리기가 시작 시각에 부여)
+		pending, err := h.roleService.IsPlatformRoleActivationPending(userID, roleID)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 확인 실패: %v", err)})
+		}
+		if pending {
+			audit.After = h.platformRoleSnapshot(userID)
+			return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 할당되었습니다"})
+		}
 		// DB에는 이미 있음 — Keycloak 동기화 상태 확인 (idempotent 처리)
 		isKcAssigned, err := h.keycloakService.IsRealmRoleAssignedToUser(c.Request().Context(), user.KcId, req.RoleName)
 		if err != nil {
@@ -1726,6 +1876,7 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 		if isKcAssigned {
 			// DB + Keycloak 모두 할당됨 — idempotent 성공 반환
+			audit.After = h.platformRoleSnapshot(userID)
 			return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 할당되었습니다"})
 		}
 		// DB에는 있지만 Keycloak에 없음 — Keycloak 동기화
@@ -1744,10 +1895,22 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 	} else {
 		// DB에 역할 할당
-		if err := h.roleService.AssignPlatformRole(userID, roleID); err != nil {
+		if err := h.roleService.AssignPlatformRoleWithValidity(userID, roleID, validity); err != nil {
+			if errors.Is(err, service.ErrInvalidAssignmentValidity) {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+			if errors.Is(err, service.ErrSodViolation) {
+				return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+			}
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 할당 실패: %v", err)})
 		}
 
+		// 시작 전이면 Keycloak 부여는 만료 처리기가 시작 시각에 수행
+		if validity.PendingAt(time.Now()) {
+			audit.After = h.platformRoleSnapshot(userID)
+			return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 할당되었습니다"})
+		}
+
 		// Keycloak role 존재 여부 확인 및 생성
 		roleExists, err := h.keycloakService.CheckRealmRoleExists(c.Request().Context(), req.RoleName)
 		if err != nil {
@@ -1782,6 +1945,7 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.platformRoleSnapshot(userID)
 	return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 할당되었습니다"})
 }
 
@@ -1850,6 +2014,9 @@ func (h *RoleHandler) RemovePlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "역할 ID 또는 역할명이 필요합니다"})
 	}
 
+	audit := auditDetail(c, model.AuditActionPlatformRoleRemove, model.AuditEntityUser, userID)
+	audit.Before = h.platformRoleSnapshot(userID)
+
 	// 사용자 정보 조회 (Keycloak ID 필요)
 	user, err := h.userService.GetUserByID(c.Request().Context(), userID)
 	if err != nil {
@@ -1887,17 +2054,20 @@ func (h *RoleHandler) RemovePlatformRole(c echo.Context) error {
 		}
 	}
 
+	audit.After = h.platformRoleSnapshot(userID)
 	return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 제거되었습니다"})
 }
 
 // @Summary Assign workspace role
-// @Description Assign a workspace role to a user
+// @Description Assign a workspace role to a user. Allowed with the platform role write permission, or for workspace admins of that workspace when the role is delegable.
 // @Tags roles
 // @Accept json
 // @Produce json
 // @Param request body model.AssignWorkspaceRoleRequest true "Workspace Role Assignment Info"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/roles/assign/workspace-role [post]
@@ -1971,23 +2141,68 @@ func (h *RoleHandler) AssignWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "워크스페이스 ID가 필요합니다"})
 	}
 
+	// 플랫폼 권한 또는 해당 워크스페이스의 위임 관리자(delegable 역할만)
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if err := h.workspaceAdmin.AuthorizeRoleAssignment(c.Request().Context(), actorID, workspaceID, roleID); err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	// 역할 할당 승인 워크플로가 켜져 있으면 승인 후 할당
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeRoleAssignment)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitRoleAssignment(c, &model.WorkflowRoleAssignmentPayload{
+			RoleType:    model.WorkflowRoleWorkspace,
+			UserID:      userID,
+			RoleID:      roleID,
+			RoleName:    req.RoleName,
+			WorkspaceID: workspaceID,
+			ValidFrom:   req.ValidFrom,
+			ExpiresAt:   req.ExpiresAt,
+		}, req.Username)
+	}
+
+	audit := auditDetail(c, model.AuditActionWorkspaceRoleAssign, model.AuditEntityUser, userID)
+	audit.WorkspaceID = &workspaceID
+	audit.Before = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
+
 	// 역할 할당
-	err := h.roleService.AssignWorkspaceRole(userID, workspaceID, roleID)
+	validity := model.AssignmentValidity{ValidFrom: req.ValidFrom, ExpiresAt: req.ExpiresAt}
+	err = h.workspaceAdmin.AssignRole(c.Request().Context(), actorID, userID, workspaceID, roleID, validity)
 	if err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, service.ErrInvalidAssignmentValidity) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 실패: %v", err)})
 	}
 
+	audit.After = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 	return c.JSON(http.StatusOK, map[string]string{"message": "역할이 성공적으로 할당되었습니다"})
 }
 
 // @Summary Remove workspace role
-// @Description Remove a workspace role from a user
+// @Description Remove a workspace role from a user. Workspace admins may remove only delegable roles in their own workspace.
 // @Tags roles
 // @Accept json
 // @Produce json
 // @Param request body model.AssignRoleRequest true "Workspace Role Removal Info"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/roles/unassign/workspace-role [delete]
@@ -2061,12 +2276,25 @@ func (h *RoleHandler) RemoveWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "워크스페이스 ID가 필요합니다"})
 	}
 
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionWorkspaceRoleRemove, model.AuditEntityUser, userID)
+	audit.WorkspaceID = &workspaceID
+	audit.Before = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
+
 	// 역할 제거
-	err := h.roleService.RemoveWorkspaceRole(userID, workspaceID, roleID)
+	err = h.workspaceAdmin.RemoveRole(c.Request().Context(), actorID, userID, workspaceID, roleID)
 	if err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 제거 실패: %v", err)})
 	}
 
+	audit.After = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 	return c.JSON(http.StatusOK, map[string]string{"message": "역할이 성공적으로 제거되었습니다"})
 }
 
@@ -2077,6 +2305,7 @@ func (h *RoleHandler) RemoveWorkspaceRole(c echo.Context) error {
 // @Produce json
 // @Param mapping body model.RoleMasterCspRoleMappingRequest true "Mapping Info"
 // @Success 201 {object} model.RoleMasterCspRoleMappingRequest
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -2120,6 +2349,18 @@ func (h *RoleHandler) AddCspRoleMappings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "해당 역할이 CSP 역할에 정의 되어 있지 않습니다"})
 	}
 
+	// CSP 역할 매핑 승인 워크플로가 켜져 있으면 승인 후 매핑
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeCspRoleMapping)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitCspRoleMapping(c, model.WorkflowCspMappingAdd, req)
+	}
+
+	audit := auditDetail(c, model.AuditActionRoleCspMappingAdd, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
+
 	// 해당 역할이 할당 되어 있는지 확인
 	isAssigned, err := h.roleService.IsAssignedRole(0, roleIDInt, constants.RoleTypeCSP)
 	if err != nil {
@@ -2151,8 +2392,12 @@ func (h *RoleHandler) AddCspRoleMappings(c echo.Context) error {
 	// mapping 관계만 추가
 	err = h.roleService.AddCspRolesMapping(&req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidGrantConditions) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("Master 역할-CSP 역할 매핑 생성 성공 - ID: %d", roleIDInt)
 	return c.JSON(http.StatusCreated, map[string]string{"message": "Master 역할-CSP 역할 매핑 생성 성공"})
@@ -2165,6 +2410,7 @@ func (h *RoleHandler) AddCspRoleMappings(c echo.Context) error {
 // @Produce json
 // @Param mapping body model.RoleMasterCspRoleMappingRequest true "Mapping Info"
 // @Success 204 "No Content"
+// @Success 202 {object} model.WorkflowRequest "Approval workflow enabled: request awaiting approval"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -2193,12 +2439,30 @@ func (h *RoleHandler) RemoveCspRoleMappings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 	}
 
+	// CSP 역할 매핑 승인 워크플로가 켜져 있으면 승인 후 삭제
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeCspRoleMapping)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.submitCspRoleMapping(c, model.WorkflowCspMappingRemove, model.CreateRoleMasterCspRoleMappingRequest{
+			RoleID:     req.RoleID,
+			CspType:    req.CspType,
+			CspRoleID:  req.CspRoleID,
+			AuthMethod: req.AuthMethod,
+		})
+	}
+
+	audit := auditDetail(c, model.AuditActionRoleCspMappingRemove, model.AuditEntityRole, roleIDInt)
+	audit.Before = roleSnapshot(h.roleService, roleIDInt)
+
 	// 매핑 삭제
 	err = h.roleService.DeleteRoleCspRoleMapping(roleIDInt, cspRoleIDInt, reqAuthMethod)
 	if err != nil {
 		log.Printf("Master 역할-CSP 역할 매핑 삭제 실패: %v", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("매핑 삭제 실패: %v", err)})
 	}
+	audit.After = roleSnapshot(h.roleService, roleIDInt)
 
 	log.Printf("Master 역할-CSP 역할 매핑 삭제 성공 - ID: %d", roleIDInt)
 	return c.NoContent(http.StatusNoContent)
@@ -2492,3 +2756,99 @@ func (h *RoleHandler) GetRoleMasterMappings(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, mappings)
 }
+
+// roleHierarchyErrorStatus 역할 생성/수정 오류의 HTTP 상태 코드 (상위 역할 검증 실패는 400)
+func roleHierarchyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrCircularReference),
+		errors.Is(err, repository.ErrRoleMasterNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// platformRoleSnapshot 감사 스냅샷용 사용자 직접 할당 플랫폼 역할 이름 목록
+func (h *RoleHandler) platformRoleSnapshot(userID uint) map[string]interface{} {
+	names := []string{}
+	if roles, err := h.roleService.GetUserPlatformRoles(userID); err == nil {
+		for _, role := range roles {
+			names = append(names, role.Name)
+		}
+	}
+	return map[string]interface{}{"userId": userID, "platformRoles": names}
+}
+
+// workspaceRoleSnapshot 감사 스냅샷용 사용자 직접 할당 워크스페이스 역할 이름 목록
+func workspaceRoleSnapshot(roleService *service.RoleService, userID, workspaceID uint) map[string]interface{} {
+	names := []string{}
+	if roles, err := roleService.GetUserWorkspaceRoles(userID, workspaceID); err == nil {
+		for _, role := range roles {
+			names = append(names, role.RoleName)
+		}
+	}
+	return map[string]interface{}{"userId": userID, "workspaceId": workspaceID, "workspaceRoles": names}
+}
+
+// roleSnapshot 감사 스냅샷용 역할 정의와 상위 역할 상속을 포함한 MC-IAM 권한·메뉴·CSP 매핑 (역할이 없으면 nil)
+func roleSnapshot(roleService *service.RoleService, roleID uint) map[string]interface{} {
+	role, err := roleService.GetRoleByID(roleID, "")
+	if err != nil || role == nil {
+		return nil
+	}
+	roleTypes := []constants.IAMRoleType{}
+	for _, sub := range role.RoleSubs {
+		roleTypes = append(roleTypes, sub.RoleType)
+	}
+	snapshot := map[string]interface{}{
+		"roleId":      role.ID,
+		"name":        role.Name,
+		"description": role.Description,
+		"parentId":    role.ParentID,
+		"predefined":  role.Predefined,
+		"delegable":   role.Delegable,
+		"roleTypes":   roleTypes,
+	}
+	if resolved, err := roleService.GetResolvedRolePermissions(roleID); err == nil {
+		snapshot["permissions"] = resolved.Permissions
+		snapshot["menus"] = resolved.Menus
+		snapshot["cspRoleMappings"] = resolved.CspRoleMappings
+	}
+	return snapshot
+}
+
+// submitRoleAssignment 역할 할당 승인 요청 생성 (202)
+func (h *RoleHandler) submitRoleAssignment(c echo.Context, payload *model.WorkflowRoleAssignmentPayload, username string) error {
+	key := fmt.Sprintf("%s:%d:%d:%d", payload.RoleType, payload.UserID, payload.WorkspaceID, payload.RoleID)
+	summary := fmt.Sprintf("assign %s role %d to user %d", payload.RoleType, payload.RoleID, payload.UserID)
+	if payload.RoleType == model.WorkflowRoleWorkspace {
+		summary += fmt.Sprintf(" in workspace %d", payload.WorkspaceID)
+	}
+	request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+		Type:            model.WorkflowTypeRoleAssignment,
+		Key:             key,
+		Summary:         summary,
+		Payload:         payload,
+		SubjectUserID:   payload.UserID,
+		SubjectUsername: username,
+		WorkspaceID:     payload.WorkspaceID,
+	})
+	if err != nil {
+		return workflowError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, request)
+}
+
+// submitCspRoleMapping 역할-CSP 역할 매핑 추가/삭제 승인 요청 생성 (202)
+func (h *RoleHandler) submitCspRoleMapping(c echo.Context, operation string, mapping model.CreateRoleMasterCspRoleMappingRequest) error {
+	request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+		Type:    model.WorkflowTypeCspRoleMapping,
+		Key:     fmt.Sprintf("%s:%s:%s:%s", operation, mapping.RoleID, mapping.CspRoleID, mapping.AuthMethod),
+		Summary: fmt.Sprintf("%s mapping of role %s to csp role %s", operation, mapping.RoleID, mapping.CspRoleID),
+		Payload: model.WorkflowCspRoleMappingPayload{Operation: operation, Mapping: mapping},
+	})
+	if err != nil {
+		return workflowError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, request)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// RoleTemplateHandler 역할 복제·역할 템플릿 핸들러
+type RoleTemplateHandler struct {
+	roleTemplateService *service.RoleTemplateService
+}
+
+// NewRoleTemplateHandler RoleTemplateHandler 생성
+func NewRoleTemplateHandler(db *gorm.DB) *RoleTemplateHandler {
+	return &RoleTemplateHandler{
+		roleTemplateService: service.NewRoleTemplateService(db),
+	}
+}
+
+// roleTemplateActor 요청자 DB 사용자 ID
+func (h *RoleTemplateHandler) roleTemplateActor(c echo.Context) (uint, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return 0, errors.New("kcUserId not found in context")
+	}
+	user, err := h.roleTemplateService.ResolveUser(c.Request().Context(), kcUserID)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// roleTemplateErrorStatus 역할 복제·템플릿 오류의 HTTP 상태 코드
+func roleTemplateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrRoleMasterNotFound),
+		errors.Is(err, service.ErrRoleTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrRoleNameTaken),
+		errors.Is(err, service.ErrCspMappingApprovalRequired):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidRoleClone),
+		errors.Is(err, service.ErrInvalidRoleTemplate),
+		errors.Is(err, repository.ErrCircularReference):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CloneRole 역할 복제
+// @Summary Clone role
+// @Description Creates a new role from an existing one with its role types, menu mappings, MC-IAM permissions (allow/deny with conditions) and CSP role mappings. `sections` selects what is copied (roleTypes, menus, operations, csps; default: all). Copying csps requires mc-iam-manager:role:manage and is rejected while the csp-role-mapping approval workflow is enabled (exclude csps and request the mappings through /api/roles/csp-roles). The clone keeps the parent role, is never predefined or delegable, and is created in one transaction.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "Source role ID"
+// @Param request body model.CloneRoleRequest true "Clone request"
+// @Success 201 {object} model.RoleMaster
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: role:manage required for csps"
+// @Failure 404 {object} map[string]string "error: Role not found"
+// @Failure 409 {object} map[string]string "error: Role name already exists, or csps require approval"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/roles/id/{roleId}/clone [post]
+// @Id cloneRole
+func (h *RoleTemplateHandler) CloneRole(c echo.Context) error {
+	roleID, err := strconv.ParseUint(c.Param("roleId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 역할 ID"})
+	}
+	var req model.CloneRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	actorID, err := h.roleTemplateActor(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionRoleClone, model.AuditEntityRole, 0)
+	audit.Before = map[string]interface{}{"sourceRoleId": roleID, "sections": req.Sections}
+	role, err := h.roleTemplateService.CloneRole(c.Request().Context(), actorID, uint(roleID), &req)
+	if err != nil {
+		return c.JSON(roleTemplateErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	audit.EntityID = strconv.FormatUint(uint64(role.ID), 10)
+	audit.After = map[string]interface{}{"roleId": role.ID, "name": role.Name}
+	return c.JSON(http.StatusCreated, role)
+}
+
+// ListRoleTemplates 역할 템플릿 목록 조회
+// @Summary List role templates
+// @Description Lists the named role templates defined in asset/role-templates/*.yaml
+// @Tags roles
+// @Produce json
+// @Success 200 {array} model.RoleTemplate
+// @Failure 500 {object} map[string]string "error: Invalid template files"
+// @Security BearerAuth
+// @Router /api/roles/templates [get]
+// @Id listRoleTemplates
+func (h *RoleTemplateHandler) ListRoleTemplates(c echo.Context) error {
+	templates, err := h.roleTemplateService.ListTemplates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, templates)
+}
+
+// GetRoleTemplate 역할 템플릿 조회
+// @Summary Get role template
+// @Description Returns a role template by name
+// @Tags roles
+// @Produce json
+// @Param templateName path string true "Template name"
+// @Success 200 {object} model.RoleTemplate
+// @Failure 404 {object} map[string]string "error: Template not found"
+// @Failure 500 {object} map[string]string "error: Invalid template files"
+// @Security BearerAuth
+// @Router /api/roles/templates/{templateName} [get]
+// @Id getRoleTemplate
+func (h *RoleTemplateHandler) GetRoleTemplate(c echo.Context) error {
+	template, err := h.roleTemplateService.GetTemplate(c.Param("templateName"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrRoleTemplateNotFound) {
+			status = http.StatusNotFound
+		}
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, template)
+}
+
+// CreateRoleFromTemplate 역할 템플릿으로 역할 생성
+// @Summary Create role from template
+// @Description Creates a new role from a named template with its role types, menu mappings, MC-IAM permissions and CSP role mappings in one transaction. Menus and CSP roles referenced by the template must already exist; templates with csps require mc-iam-manager:role:manage and are rejected while the csp-role-mapping approval workflow is enabled.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param templateName path string true "Template name"
+// @Param request body model.InstantiateRoleTemplateRequest true "New role"
+// @Success 201 {object} model.RoleMaster
+// @Failure 400 {object} map[string]string "error: Invalid request or template"
+// @Failure 403 {object} map[string]string "error: role:manage required for csps"
+// @Failure 404 {object} map[string]string "error: Template not found"
+// @Failure 409 {object} map[string]string "error: Role name already exists, or csps require approval"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/roles/templates/{templateName}/instantiate [post]
+// @Id createRoleFromTemplate
+func (h *RoleTemplateHandler) CreateRoleFromTemplate(c echo.Context) error {
+	templateName := c.Param("templateName")
+	var req model.InstantiateRoleTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	actorID, err := h.roleTemplateActor(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionRoleTemplateInstantiate, model.AuditEntityRole, 0)
+	audit.Before = map[string]interface{}{"template": templateName}
+	role, err := h.roleTemplateService.InstantiateTemplate(c.Request().Context(), actorID, templateName, &req)
+	if err != nil {
+		return c.JSON(roleTemplateErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	audit.EntityID = strconv.FormatUint(uint64(role.ID), 10)
+	audit.After = map[string]interface{}{"roleId": role.ID, "name": role.Name}
+	return c.JSON(http.StatusCreated, role)
+}
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// SodHandler 직무 분리(SoD) 제약/예외/위반 보고서 핸들러
+type SodHandler struct {
+	sodService   *service.SodService
+	authzService *service.AuthzService
+}
+
+// NewSodHandler SodHandler 생성
+func NewSodHandler(db *gorm.DB) *SodHandler {
+	return &SodHandler{
+		sodService:   service.NewSodService(db),
+		authzService: service.NewAuthzService(db),
+	}
+}
+
+// caller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func (h *SodHandler) caller(c echo.Context) (*model.User, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return nil, errors.New("kcUserId not found in context")
+	}
+	return h.authzService.ResolveSubject(c.Request().Context(), "", kcUserID)
+}
+
+// ListSodConstraints 제약 목록
+// @Summary List segregation of duties constraints
+// @Description Lists mutually exclusive role sets. A user may not hold two or more roles of an enabled constraint together, either among platform roles or among platform roles and the roles of one workspace, whether assigned directly or inherited from groups.
+// @Tags sod
+// @Produce json
+// @Success 200 {array} model.SodConstraint
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/sod/constraints [get]
+// @Id listSodConstraints
+func (h *SodHandler) ListSodConstraints(c echo.Context) error {
+	constraints, err := h.sodService.ListConstraints()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, constraints)
+}
+
+// GetSodConstraint 제약 조회
+// @Summary Get segregation of duties constraint
+// @Description Returns a constraint with its role names.
+// @Tags sod
+// @Produce json
+// @Param constraintId path int true "Constraint ID"
+// @Success 200 {object} model.SodConstraint
+// @Failure 400 {object} map[string]string "error: Invalid constraint ID"
+// @Failure 404 {object} map[string]string "error: Constraint not found"
+// @Security BearerAuth
+// @Router /api/sod/constraints/{constraintId} [get]
+// @Id getSodConstraint
+func (h *SodHandler) GetSodConstraint(c echo.Context) error {
+	constraintID, err := strconv.ParseUint(c.Param("constraintId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid constraint ID"})
+	}
+	constraint, err := h.sodService.GetConstraint(uint(constraintID))
+	if err != nil {
+		return sodError(c, err)
+	}
+	return c.JSON(http.StatusOK, constraint)
+}
+
+// CreateSodConstraint 제약 생성
+// @Summary Create segregation of duties constraint
+// @Description Creates a mutually exclusive role set from role IDs and/or role names (at least two distinct roles). Existing assignments are not changed; use the violations report to find them.
+// @Tags sod
+// @Accept json
+// @Produce json
+// @Param request body model.SodConstraintRequest true "Constraint"
+// @Success 201 {object} model.SodConstraint
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/sod/constraints [post]
+// @Id createSodConstraint
+func (h *SodHandler) CreateSodConstraint(c echo.Context) error {
+	var req model.SodConstraintRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	constraint, err := h.sodService.CreateConstraint(&req)
+	if err != nil {
+		return sodError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionSodConstraintCreate, model.AuditEntitySodConstraint, constraint.ID)
+	audit.After = constraint
+	return c.JSON(http.StatusCreated, constraint)
+}
+
+// UpdateSodConstraint 제약 수정
+// @Summary Update segregation of duties constraint
+// @Description Replaces the name, description, role set and enabled flag of a constraint.
+// @Tags sod
+// @Accept json
+// @Produce json
+// @Param constraintId path int true "Constraint ID"
+// @Param request body model.SodConstraintRequest true "Constraint"
+// @Success 200 {object} model.SodConstraint
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 404 {object} map[string]string "error: Constraint not found"
+// @Security BearerAuth
+// @Router /api/sod/constraints/{constraintId} [put]
+// @Id updateSodConstraint
+func (h *SodHandler) UpdateSodConstraint(c echo.Context) error {
+	constraintID, err := strconv.ParseUint(c.Param("constraintId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid constraint ID"})
+	}
+	var req model.SodConstraintRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	before, err := h.sodService.GetConstraint(uint(constraintID))
+	if err != nil {
+		return sodError(c, err)
+	}
+	constraint, err := h.sodService.UpdateConstraint(uint(constraintID), &req)
+	if err != nil {
+		return sodError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionSodConstraintUpdate, model.AuditEntitySodConstraint, constraint.ID)
+	audit.Before = before
+	audit.After = constraint
+	return c.JSON(http.StatusOK, constraint)
+}
+
+// DeleteSodConstraint 제약 삭제
+// @Summary Delete segregation of duties constraint
+// @Description Deletes a constraint together with its overrides.
+// @Tags sod
+// @Produce json
+// @Param constraintId path int true "Constraint ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "error: Invalid constraint ID"
+// @Failure 404 {object} map[string]string "error: Constraint not found"
+// @Security BearerAuth
+// @Router /api/sod/constraints/{constraintId} [delete]
+// @Id deleteSodConstraint
+func (h *SodHandler) DeleteSodConstraint(c echo.Context) error {
+	constraintID, err := strconv.ParseUint(c.Param("constraintId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid constraint ID"})
+	}
+	before, err := h.sodService.GetConstraint(uint(constraintID))
+	if err != nil {
+		return sodError(c, err)
+	}
+	if err := h.sodService.DeleteConstraint(uint(constraintID)); err != nil {
+		return sodError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionSodConstraintDelete, model.AuditEntitySodConstraint, uint(constraintID))
+	audit.Before = before
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListSodViolations 위반 보고서
+// @Summary List segregation of duties violations
+// @Description Reports current assignments that violate enabled constraints (or the given constraint), including the assignment path (direct or group) of each conflicting role. Violations allowed by an active override carry its overrideId.
+// @Tags sod
+// @Produce json
+// @Param constraintId query int false "Constraint ID"
+// @Success 200 {array} model.SodViolation
+// @Failure 400 {object} map[string]string "error: Invalid constraint ID"
+// @Failure 404 {object} map[string]string "error: Constraint not found"
+// @Security BearerAuth
+// @Router /api/sod/violations [get]
+// @Id listSodViolations
+func (h *SodHandler) ListSodViolations(c echo.Context) error {
+	var constraintID uint64
+	if v := c.QueryParam("constraintId"); v != "" {
+		var err error
+		if constraintID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid constraint ID"})
+		}
+	}
+	violations, err := h.sodService.FindViolations(uint(constraintID))
+	if err != nil {
+		return sodError(c, err)
+	}
+	return c.JSON(http.StatusOK, violations)
+}
+
+// ListSodOverrides 예외 목록
+// @Summary List segregation of duties overrides
+// @Description Lists overrides, including revoked and expired ones, newest first.
+// @Tags sod
+// @Produce json
+// @Param constraintId query int false "Constraint ID"
+// @Param userId query int false "User ID"
+// @Success 200 {array} model.SodOverride
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Security BearerAuth
+// @Router /api/sod/overrides [get]
+// @Id listSodOverrides
+func (h *SodHandler) ListSodOverrides(c echo.Context) error {
+	var filter struct {
+		ConstraintID uint `query:"constraintId"`
+		UserID       uint `query:"userId"`
+	}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+	overrides, err := h.sodService.ListOverrides(filter.ConstraintID, filter.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, overrides)
+}
+
+// CreateSodOverride 예외 승인
+// @Summary Create segregation of duties override
+// @Description Allows a user to hold conflicting roles of a constraint. A justification is required; the approver is recorded. Without expiresAt the override stays active until revoked.
+// @Tags sod
+// @Accept json
+// @Produce json
+// @Param request body model.CreateSodOverrideRequest true "Override"
+// @Success 201 {object} model.SodOverride
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 404 {object} map[string]string "error: Constraint or user not found"
+// @Security BearerAuth
+// @Router /api/sod/overrides [post]
+// @Id createSodOverride
+func (h *SodHandler) CreateSodOverride(c echo.Context) error {
+	var req model.CreateSodOverrideRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	approver, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	override, err := h.sodService.CreateOverride(&req, approver)
+	if err != nil {
+		return sodError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionSodOverrideCreate, model.AuditEntitySodOverride, override.ID)
+	audit.After = override
+	return c.JSON(http.StatusCreated, override)
+}
+
+// RevokeSodOverride 예외 철회
+// @Summary Revoke segregation of duties override
+// @Description Revokes an override. Conflicting roles the user still holds show up in the violations report again.
+// @Tags sod
+// @Produce json
+// @Param overrideId path int true "Override ID"
+// @Success 200 {object} model.SodOverride
+// @Failure 400 {object} map[string]string "error: Invalid override ID"
+// @Failure 404 {object} map[string]string "error: Override not found"
+// @Failure 409 {object} map[string]string "error: Override already revoked"
+// @Security BearerAuth
+// @Router /api/sod/overrides/{overrideId} [delete]
+// @Id revokeSodOverride
+func (h *SodHandler) RevokeSodOverride(c echo.Context) error {
+	overrideID, err := strconv.ParseUint(c.Param("overrideId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid override ID"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	override, err := h.sodService.RevokeOverride(uint(overrideID), actor)
+	if err != nil {
+		return sodError(c, err)
+	}
+	audit := auditDetail(c, model.AuditActionSodOverrideRevoke, model.AuditEntitySodOverride, override.ID)
+	audit.After = override
+	return c.JSON(http.StatusOK, override)
+}
+
+// sodError 서비스 오류를 HTTP 응답으로 변환
+func sodError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrSodConstraintNotFound), errors.Is(err, repository.ErrSodOverrideNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidSodRequest):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrSodOverrideInactive):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	// "github.com/m-cmp/mc-iam-manager/config" // Removed unused import
 	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
 	"github.com/m-cmp/mc-iam-manager/service"
 	"github.com/m-cmp/mc-iam-manager/util"
 	"github.com/m-cmp/mc-iam-manager/utils"
@@ -49,6 +51,8 @@ type UserHandler struct {
 	userService      *service.UserService
 	roleService      *service.RoleService
 	workspaceService *service.WorkspaceService
+	dormancyService  *service.DormancyService
+	workflowService  *service.WorkflowService // nil 이면 가입/탈퇴 승인 워크플로를 사용하지 않음
 	// db *gorm.DB // Not needed directly
 	// keycloakConfig *config.KeycloakConfig // Not needed directly
 	// keycloakClient *gocloak.GoCloak // Not needed directly
@@ -62,6 +66,8 @@ func NewUserHandler(db *gorm.DB) *UserHandler {
 		userService:      userService,
 		roleService:      roleService,
 		workspaceService: workspaceService,
+		dormancyService:  service.NewDormancyService(db),
+		workflowService:  service.NewWorkflowService(db),
 	}
 }
 
@@ -598,6 +604,9 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
 	}
 
+	detail := auditDetail(c, model.AuditActionUserDelete, model.AuditEntityUser, userIDInt)
+	detail.Before = h.userStatusSnapshot(c, userIDInt)
+
 	// Call service method with DB ID
 	err = h.userService.DeleteUser(c.Request().Context(), userIDInt) // Pass uint ID
 	if err != nil {
@@ -611,13 +620,14 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 
 // UpdateUserStatus godoc
 // @Summary Update user status
-// @Description Update user status (active/inactive)
+// @Description Update user status (active/inactive). When the user-signup approval workflow is enabled, "approved" creates an approval request (202) instead of enabling the user immediately.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
 // @Param status body model.UserStatusRequest true "User Status"
 // @Success 200 {object} model.User
+// @Success 202 {object} model.WorkflowRequest
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -654,13 +664,34 @@ func (h *UserHandler) UpdateUserStatus(c echo.Context) error {
 	}
 
 	if updateUser.Status == "approved" {
+		required, err := workflowRequired(h.workflowService, model.WorkflowTypeUserSignup)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		if required {
+			request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+				Type:            model.WorkflowTypeUserSignup,
+				Key:             fmt.Sprintf("user:%d", user.ID),
+				Summary:         fmt.Sprintf("approve signup of %s", user.Username),
+				Payload:         model.WorkflowUserPayload{UserID: user.ID, KcUserID: user.KcId},
+				SubjectUserID:   user.ID,
+				SubjectUsername: user.Username,
+			})
+			if err != nil {
+				return workflowError(c, err)
+			}
+			return c.JSON(http.StatusAccepted, request)
+		}
 
-		err := h.userService.ApproveUser(c.Request().Context(), user.KcId) // Assign error to a new variable 'err'
+		detail := auditDetail(c, model.AuditActionUserStatusUpdate, model.AuditEntityUser, user.ID)
+		detail.Before = h.userStatusSnapshot(c, user.ID)
+		err = h.userService.ApproveUser(c.Request().Context(), user.KcId) // Assign error to a new variable 'err'
 		if err != nil {
 			fmt.Printf("[ERROR] ApproveUser: Error from userService.ApproveUser: %v\n", err)
 			// Handle specific errors from service if needed (e.g., user not found in Keycloak)
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to approve user: %v", err)})
 		}
+		detail.After = h.userStatusSnapshot(c, user.ID)
 	}
 
 	// TODO : Add user activation and deactivation functionality
@@ -687,6 +718,15 @@ func (h *UserHandler) UpdateUserStatus(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// userStatusSnapshot 감사 스냅샷용 사용자 계정 상태 (DB 상태 + Keycloak 활성화 여부, 사용자가 없으면 nil)
+func (h *UserHandler) userStatusSnapshot(c echo.Context, userID uint) map[string]interface{} {
+	user, err := h.userService.GetUserByID(c.Request().Context(), userID)
+	if err != nil || user == nil {
+		return nil
+	}
+	return map[string]interface{}{"userId": user.ID, "status": user.Status, "enabled": user.Enabled}
+}
+
 
 // ListUserWorkspaceAndWorkspaceRoles godoc
 // @Summary List user workspace and roles
@@ -882,46 +922,7 @@ func (h *UserHandler) ListUserProjectsByWorkspace(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID format"})
 	}
 
-	// 1. Get Keycloak User ID
-	kcUserIdVal := c.Get("kcUserId")
-	if kcUserIdVal == nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get user at GetUserWorkspaceAndWorkspaceRoles"})
-	}
-	kcUserID, ok := kcUserIdVal.(string)
-	if !ok || kcUserID == "" {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "invalid kcUserId in context at GetUserWorkspaceAndWorkspaceRoles"})
-	}
-
-	// 2. Get local DB User ID (db_id) using the service method
-	//    This method handles syncing if the user isn't in the local DB yet.
-	localUserID, err := h.userService.GetUserIDByKcID(c.Request().Context(), kcUserID) // Correct function name
-	if err != nil {
-		fmt.Printf("[ERROR] GetUserWorkspaceAndWorkspaceRoles: Failed to get local DB ID for user (kcID: %s): %v\n", kcUserID, err) // Updated log prefix
-		// Handle potential errors like user not found in Keycloak (if GetUserIDByKcID propagates it)
-		// or DB errors during sync.
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve user database ID"})
-	}
-	if localUserID == 0 {
-		// Should not happen if GetUserIDByKcID works correctly, but safeguard.
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Retrieved invalid local user database ID (0)"})
-	}
-
-	// 1-0. platformAdmin이면 소속 검증을 건너뛴다 (ListUserWorkspaces와 동일한 정책)
-	if !checkRoleFromContext(c, []string{"platformAdmin"}) {
-		// 1. 사용자가 실제로 해당 workspace에 소속되어 있는지 먼저 검증한다.
-		//    (WorkspaceID + UserID 조건을 함께 걸어야 하므로 project 정보가 없는 ListWorkspaces를 사용한다.
-		//     FindWorkspacesProjects는 WorkspaceID가 있으면 UserID를 확인하기 전에 바로 return해버려서 사용할 수 없다.)
-		memberWorkspaces, err := h.workspaceService.ListWorkspaces(&model.WorkspaceFilterRequest{
-			WorkspaceID: fmt.Sprintf("%d", workspaceIdInt),
-			UserID:      fmt.Sprintf("%d", localUserID),
-		})
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to verify workspace membership: %v", err)})
-		}
-		if len(memberWorkspaces) == 0 {
-			return c.JSON(http.StatusForbidden, map[string]string{"error": "user does not belong to the requested workspace"})
-		}
-	}
+	// 1. 사용자의 workspace 소속 검증은 WorkspaceRoleMiddleware 에서 수행한다 (그룹 상속 역할 포함, platformAdmin 은 통과).
 
 	// 2. 소속이 확인된 workspace에 한해 project 목록(NsId 포함)을 조회한다.
 	workspaces, err := h.workspaceService.ListWorkspacesProjects(&model.WorkspaceFilterRequest{
@@ -1080,6 +1081,8 @@ func (h *UserHandler) DeactivateUser(c echo.Context) error {
 	}
 	requestorKcIDVal := c.Get("kcUserId")
 	requestorKcID, _ := requestorKcIDVal.(string)
+	detail := auditDetail(c, model.AuditActionUserDeactivate, model.AuditEntityUser, userIDInt)
+	detail.Before = h.userStatusSnapshot(c, userIDInt)
 	if err := h.userService.DeactivateUser(c.Request().Context(), userIDInt, requestorKcID); err != nil {
 		switch err.Error() {
 		case "cannot deactivate yourself":
@@ -1093,6 +1096,7 @@ func (h *UserHandler) DeactivateUser(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to deactivate user"})
 		}
 	}
+	detail.After = h.userStatusSnapshot(c, userIDInt)
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -1117,6 +1121,8 @@ func (h *UserHandler) ActivateUser(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
 	}
+	detail := auditDetail(c, model.AuditActionUserActivate, model.AuditEntityUser, userIDInt)
+	detail.Before = h.userStatusSnapshot(c, userIDInt)
 	if err := h.userService.ActivateUser(c.Request().Context(), userIDInt); err != nil {
 		switch err.Error() {
 		case "user is not inactive":
@@ -1128,14 +1134,69 @@ func (h *UserHandler) ActivateUser(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to activate user"})
 		}
 	}
+	detail.After = h.userStatusSnapshot(c, userIDInt)
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetDormancyPolicy godoc
+// @Summary Get dormant account policy
+// @Description Returns the dormant account policy: days of inactivity (no login, token refresh or token use) before a warning notification and before the account is deactivated. 0 disables the step.
+// @Tags users
+// @Produce json
+// @Success 200 {object} model.DormancyPolicy
+// @Security BearerAuth
+// @Router /api/users/dormancy-policy [get]
+// @Id getDormancyPolicy
+func (h *UserHandler) GetDormancyPolicy(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.dormancyService.Policy())
+}
+
+// SetDormancyExemption godoc
+// @Summary Set dormant account policy exemption
+// @Description Exempts a user from the dormant account policy (SERVICE_ACCOUNT or BREAK_GLASS). An empty exemption removes it.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User DB ID"
+// @Param request body model.SetDormancyExemptionRequest true "Exemption"
+// @Success 200 {object} model.User
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/id/{userId}/dormancy-exemption [put]
+// @Id setDormancyExemption
+func (h *UserHandler) SetDormancyExemption(c echo.Context) error {
+	userIDInt, err := util.StringToUint(c.Param("userId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
+	}
+	var req model.SetDormancyExemptionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	detail := auditDetail(c, model.AuditActionDormancyExemption, model.AuditEntityUser, userIDInt)
+	user, previous, err := h.dormancyService.SetExemption(userIDInt, req.Exemption)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDormancyExemption):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, repository.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update dormancy exemption"})
+	}
+	detail.Before = map[string]string{"dormancyExemption": previous}
+	detail.After = map[string]string{"dormancyExemption": user.DormancyExemption}
+	return c.JSON(http.StatusOK, user)
+}
+
 // RequestWithdrawal godoc
 // @Summary Request user withdrawal
-// @Description Current user requests account withdrawal (ACTIVE → WITHDRAWAL_REQUESTED).
+// @Description Current user requests account withdrawal (ACTIVE → WITHDRAWAL_REQUESTED). When the user-withdrawal approval workflow is enabled an approval request is returned with 202 and the withdrawal is processed once it is approved.
 // @Tags users
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -1148,6 +1209,13 @@ func (h *UserHandler) RequestWithdrawal(c echo.Context) error {
 	if !ok || kcUserID == "" {
 		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
 	}
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeUserWithdrawal)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	detail := auditDetail(c, model.AuditActionWithdrawalRequest, model.AuditEntityUser, 0)
+	detail.EntityID = kcUserID
+	detail.After = map[string]interface{}{"status": model.UserStatusWithdrawalRequested}
 	if err := h.userService.RequestWithdrawal(c.Request().Context(), kcUserID); err != nil {
 		switch err.Error() {
 		case "only active users can request withdrawal":
@@ -1156,6 +1224,24 @@ func (h *UserHandler) RequestWithdrawal(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to request withdrawal"})
 		}
 	}
+	// 탈퇴 승인 워크플로가 켜져 있으면 승인되는 대로 탈퇴 처리 (거절/취소되면 ACTIVE 로 복구)
+	if required {
+		user, err := workflowCaller(c, h.workflowService)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+			Type:          model.WorkflowTypeUserWithdrawal,
+			Key:           fmt.Sprintf("user:%d", user.ID),
+			Summary:       fmt.Sprintf("withdraw account of %s", user.Username),
+			Payload:       model.WorkflowUserPayload{UserID: user.ID, KcUserID: user.KcId},
+			SubjectUserID: user.ID,
+		})
+		if err != nil {
+			return workflowError(c, err)
+		}
+		return c.JSON(http.StatusAccepted, request)
+	}
 	return c.JSON(http.StatusOK, map[string]string{"message": "탈퇴 신청이 완료되었습니다. 관리자 승인 후 처리됩니다."})
 }
 
@@ -1180,6 +1266,8 @@ func (h *UserHandler) ProcessWithdrawal(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
 	}
+	detail := auditDetail(c, model.AuditActionWithdrawalProcess, model.AuditEntityUser, userIDInt)
+	detail.After = map[string]interface{}{"status": model.UserStatusWithdrawn}
 	if err := h.userService.ProcessWithdrawal(c.Request().Context(), userIDInt); err != nil {
 		switch err.Error() {
 		case "user has not requested withdrawal":
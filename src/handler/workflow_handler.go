@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// WorkflowHandler 승인 워크플로 요청/결정/정책 핸들러
+type WorkflowHandler struct {
+	workflowService *service.WorkflowService
+}
+
+// NewWorkflowHandler WorkflowHandler 생성
+func NewWorkflowHandler(db *gorm.DB) *WorkflowHandler {
+	return &WorkflowHandler{workflowService: service.NewWorkflowService(db)}
+}
+
+// caller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func (h *WorkflowHandler) caller(c echo.Context) (*model.User, error) {
+	return workflowCaller(c, h.workflowService)
+}
+
+// ListWorkflowPolicies 요청 유형별 승인 정책 목록
+// @Summary List workflow policies
+// @Description Lists the approval policy of every workflow request type (workspace-invitation, user-signup, user-withdrawal, role-assignment, csp-role-mapping). Types without a stored policy are reported disabled; disabled types are applied immediately without approval.
+// @Tags workflows
+// @Produce json
+// @Success 200 {array} model.WorkflowPolicy
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/workflows/policies [get]
+// @Id listWorkflowPolicies
+func (h *WorkflowHandler) ListWorkflowPolicies(c echo.Context) error {
+	policies, err := h.workflowService.ListPolicies()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, policies)
+}
+
+// UpdateWorkflowPolicy 요청 유형의 승인 정책 저장
+// @Summary Update workflow policy
+// @Description Enables or disables approval for a request type and sets its approver rules (platform-role with roleName, workspace-admin, org-manager), the number of approvals required (N of the eligible approvers), the approval timeout in minutes and the escalation approvers added when the timeout elapses. Without escalation rules a timed-out request expires. Pending requests keep the approval count and due time they were created with.
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Param type path string true "Request type"
+// @Param policy body model.WorkflowPolicy true "Policy"
+// @Success 200 {object} model.WorkflowPolicy
+// @Failure 400 {object} map[string]string "error: Invalid policy"
+// @Failure 404 {object} map[string]string "error: Unsupported request type"
+// @Security BearerAuth
+// @Router /api/workflows/policies/{type} [put]
+// @Id updateWorkflowPolicy
+func (h *WorkflowHandler) UpdateWorkflowPolicy(c echo.Context) error {
+	var req model.WorkflowPolicy
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	requestType := c.Param("type")
+	audit := auditDetail(c, model.AuditActionWorkflowPolicyUpdate, model.AuditEntityWorkflowPolicy, 0)
+	audit.EntityID = requestType
+	if before, err := h.workflowService.Policy(requestType); err == nil {
+		audit.Before = before
+	}
+	policy, err := h.workflowService.UpdatePolicy(requestType, &req, actor)
+	if err != nil {
+		return workflowError(c, err)
+	}
+	audit.After = policy
+	return c.JSON(http.StatusOK, policy)
+}
+
+// ListWorkflows 승인 요청 목록 (관리자)
+// @Summary List workflow requests
+// @Description Lists approval requests of all users, newest first.
+// @Tags workflows
+// @Produce json
+// @Param type query string false "Request type"
+// @Param status query string false "pending, approved, rejected, cancelled, expired or failed"
+// @Param requesterId query int false "Requester user ID"
+// @Param workspaceId query int false "Workspace ID"
+// @Success 200 {array} model.WorkflowRequest
+// @Failure 400 {object} map[string]string "error: Invalid filter"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/workflows [get]
+// @Id listWorkflows
+func (h *WorkflowHandler) ListWorkflows(c echo.Context) error {
+	var filter model.WorkflowFilter
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid filter"})
+	}
+	requests, err := h.workflowService.List(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// GetWorkflow 승인 요청 조회
+// @Summary Get workflow request
+// @Description Returns an approval request with its decisions and comments. Visible to the requester, the subject user, eligible approvers and workflow managers.
+// @Tags workflows
+// @Produce json
+// @Param requestId path int true "Workflow request ID"
+// @Success 200 {object} model.WorkflowRequest
+// @Failure 400 {object} map[string]string "error: Invalid workflow request ID"
+// @Failure 403 {object} map[string]string "error: Not allowed to view the request"
+// @Failure 404 {object} map[string]string "error: Workflow request not found"
+// @Security BearerAuth
+// @Router /api/workflows/{requestId} [get]
+// @Id getWorkflow
+func (h *WorkflowHandler) GetWorkflow(c echo.Context) error {
+	requestID, err := strconv.ParseUint(c.Param("requestId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workflow request ID"})
+	}
+	viewer, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	request, err := h.workflowService.Get(c.Request().Context(), uint(requestID), viewer)
+	if err != nil {
+		return workflowError(c, err)
+	}
+	return c.JSON(http.StatusOK, request)
+}
+
+// DecideWorkflow 승인 요청 승인/거절
+// @Summary Decide workflow request
+// @Description Approves or rejects a pending request. A rejection closes the request and undoes its pending state (for example the invitation is rejected or the withdrawal request is withdrawn). Once the required number of approvals is reached the underlying action runs; if it fails the request ends as failed with resultError. Requesters and subject users cannot decide.
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Workflow request ID"
+// @Param request body model.WorkflowDecisionRequest true "Decision"
+// @Success 200 {object} model.WorkflowRequest
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: Not an approver of the request"
+// @Failure 404 {object} map[string]string "error: Workflow request not found"
+// @Failure 409 {object} map[string]string "error: Request already closed or already decided by the caller"
+// @Security BearerAuth
+// @Router /api/workflows/{requestId}/decision [post]
+// @Id decideWorkflow
+func (h *WorkflowHandler) DecideWorkflow(c echo.Context) error {
+	requestID, err := strconv.ParseUint(c.Param("requestId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workflow request ID"})
+	}
+	var req model.WorkflowDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	approver, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	action := model.AuditActionWorkflowApprove
+	if req.Decision == model.WorkflowDecisionReject {
+		action = model.AuditActionWorkflowReject
+	}
+	audit := auditDetail(c, action, model.AuditEntityWorkflow, uint(requestID))
+	request, err := h.workflowService.Decide(c.Request().Context(), uint(requestID), &req, approver)
+	if err != nil {
+		return workflowError(c, err)
+	}
+	audit.WorkspaceID = request.WorkspaceID
+	audit.After = request
+	return c.JSON(http.StatusOK, request)
+}
+
+// AddWorkflowComment 승인 요청에 의견 작성
+// @Summary Comment on workflow request
+// @Description Adds a comment to an approval request. Anyone who can view the request may comment.
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Workflow request ID"
+// @Param request body model.WorkflowCommentRequest true "Comment"
+// @Success 201 {object} model.WorkflowRequest
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 403 {object} map[string]string "error: Not allowed to view the request"
+// @Failure 404 {object} map[string]string "error: Workflow request not found"
+// @Security BearerAuth
+// @Router /api/workflows/{requestId}/comments [post]
+// @Id addWorkflowComment
+func (h *WorkflowHandler) AddWorkflowComment(c echo.Context) error {
+	requestID, err := strconv.ParseUint(c.Param("requestId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workflow request ID"})
+	}
+	var req model.WorkflowCommentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	author, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionWorkflowComment, model.AuditEntityWorkflow, uint(requestID))
+	request, err := h.workflowService.AddComment(c.Request().Context(), uint(requestID), &req, author)
+	if err != nil {
+		return workflowError(c, err)
+	}
+	audit.WorkspaceID = request.WorkspaceID
+	audit.After = map[string]string{"comment": req.Body}
+	return c.JSON(http.StatusCreated, request)
+}
+
+// ListMyWorkflows 내 승인 요청 목록
+// @Summary List my workflow requests
+// @Description Lists approval requests the caller submitted (including withdrawal requests and invitation acceptances awaiting approval), newest first.
+// @Tags workflows
+// @Produce json
+// @Param status query string false "pending, approved, rejected, cancelled, expired or failed"
+// @Success 200 {array} model.WorkflowRequest
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/workflows [get]
+// @Id listMyWorkflows
+func (h *WorkflowHandler) ListMyWorkflows(c echo.Context) error {
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	requests, err := h.workflowService.ListMine(requester, c.QueryParam("status"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// CancelMyWorkflow 내 승인 요청 취소
+// @Summary Cancel my workflow request
+// @Description Cancels one of the caller's pending approval requests and undoes its pending state.
+// @Tags workflows
+// @Produce json
+// @Param requestId path int true "Workflow request ID"
+// @Success 200 {object} model.WorkflowRequest
+// @Failure 400 {object} map[string]string "error: Invalid workflow request ID"
+// @Failure 404 {object} map[string]string "error: Workflow request not found"
+// @Failure 409 {object} map[string]string "error: Request already closed"
+// @Security BearerAuth
+// @Router /api/users/me/workflows/{requestId}/cancel [post]
+// @Id cancelMyWorkflow
+func (h *WorkflowHandler) CancelMyWorkflow(c echo.Context) error {
+	requestID, err := strconv.ParseUint(c.Param("requestId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workflow request ID"})
+	}
+	requester, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	audit := auditDetail(c, model.AuditActionWorkflowCancel, model.AuditEntityWorkflow, uint(requestID))
+	request, err := h.workflowService.Cancel(c.Request().Context(), uint(requestID), requester)
+	if err != nil {
+		return workflowError(c, err)
+	}
+	audit.WorkspaceID = request.WorkspaceID
+	audit.After = request
+	return c.JSON(http.StatusOK, request)
+}
+
+// ListMyWorkflowApprovals 내가 결정할 승인 요청
+// @Summary List workflow requests awaiting my decision
+// @Description Lists pending approval requests the caller may decide on and has not decided yet. Requests the caller submitted or that concern the caller are never included.
+// @Tags workflows
+// @Produce json
+// @Success 200 {array} model.WorkflowRequest
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /api/users/me/workflow-approvals [get]
+// @Id listMyWorkflowApprovals
+func (h *WorkflowHandler) ListMyWorkflowApprovals(c echo.Context) error {
+	approver, err := h.caller(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	requests, err := h.workflowService.ListApprovable(c.Request().Context(), approver)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// workflowCaller JWT 컨텍스트의 요청자를 DB 사용자로 조회
+func workflowCaller(c echo.Context, workflowService *service.WorkflowService) (*model.User, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return nil, errors.New("kcUserId not found in context")
+	}
+	return workflowService.ResolveUser(c.Request().Context(), kcUserID)
+}
+
+// workflowRequired 요청 유형에 승인 절차가 켜져 있는지 (workflowService 가 nil 이면 사용하지 않음)
+func workflowRequired(workflowService *service.WorkflowService, requestType string) (bool, error) {
+	if workflowService == nil {
+		return false, nil
+	}
+	return workflowService.Required(requestType)
+}
+
+// submitWorkflow 작업을 바로 실행하지 않고 호출자 이름으로 승인 요청을 만들어 감사 기록 (응답은 호출한 핸들러가 202 로)
+// 대상 사용자가 호출자 본인이면 대상 이름도 채운다.
+func submitWorkflow(c echo.Context, workflowService *service.WorkflowService, sub *service.WorkflowSubmission) (*model.WorkflowRequest, error) {
+	requester, err := workflowCaller(c, workflowService)
+	if err != nil {
+		return nil, err
+	}
+	sub.Requester = requester
+	if sub.SubjectUserID == requester.ID && sub.SubjectUsername == "" {
+		sub.SubjectUsername = requester.Username
+	}
+	request, err := workflowService.Submit(sub)
+	if err != nil {
+		return nil, err
+	}
+	audit := auditDetail(c, model.AuditActionWorkflowSubmit, model.AuditEntityWorkflow, request.ID)
+	audit.WorkspaceID = request.WorkspaceID
+	audit.After = request
+	return request, nil
+}
+
+// workflowError 서비스 오류를 HTTP 응답으로 변환
+func workflowError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrWorkflowRequestNotFound), errors.Is(err, service.ErrWorkflowUnsupportedType):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidWorkflowRequest), errors.Is(err, service.ErrInvalidWorkflowPolicy):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionDenied), errors.Is(err, service.ErrWorkflowSelfApproval):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrWorkflowNotPending), errors.Is(err, service.ErrWorkflowAlreadyOpen),
+		errors.Is(err, repository.ErrWorkflowDecisionExists):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
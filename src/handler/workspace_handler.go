@@ -27,6 +27,7 @@ type WorkspaceHandler struct {
 	roleService       *service.RoleService
 	workspaceRoleRepo *repository.WorkspaceRoleRepository
 	roleRepo          *repository.RoleRepository
+	workspaceAdmin    *service.WorkspaceAdminService
 }
 
 // NewWorkspaceHandler create new WorkspaceHandler instance
@@ -44,6 +45,7 @@ func NewWorkspaceHandler(db *gorm.DB) *WorkspaceHandler {
 		roleService:       roleService,
 		workspaceRoleRepo: workspaceRoleRepo,
 		roleRepo:          roleRepo,
+		workspaceAdmin:    service.NewWorkspaceAdminService(db),
 	}
 }
 
@@ -231,6 +233,13 @@ func (h *WorkspaceHandler) DeleteWorkspace(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
 	}
 
+	audit := auditDetail(c, model.AuditActionWorkspaceDelete, model.AuditEntityWorkspace, uint(id))
+	wsID := uint(id)
+	audit.WorkspaceID = &wsID
+	if ws, err := h.workspaceService.GetWorkspaceByID(wsID); err == nil {
+		audit.Before = ws
+	}
+
 	if err := h.workspaceService.DeleteWorkspace(uint(id)); err != nil {
 		if err.Error() == "workspace not found" {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -584,7 +593,7 @@ func (h *WorkspaceHandler) ListWorkspaceRoles(c echo.Context) error {
 
 // AddUserToWorkspace godoc
 // @Summary Add user to workspace
-// @Description Add a user to a workspace
+// @Description Add a user to a workspace with the default workspace role. Allowed with the platform workspace write permission, or for workspace admins of that workspace when the default role is delegable.
 // @Tags workspaces
 // @Accept json
 // @Produce json
@@ -592,7 +601,9 @@ func (h *WorkspaceHandler) ListWorkspaceRoles(c echo.Context) error {
 // @Param request body model.AssignRoleRequest true "User Info"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "error: separation-of-duties constraint violated"
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/workspaces/{id}/users [post]
@@ -623,18 +634,32 @@ func (h *WorkspaceHandler) AddUserToWorkspace(c echo.Context) error {
 		}
 	}
 
-	if err := h.workspaceService.AddUserToWorkspace(workspaceID, userID); err != nil {
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	audit := auditDetail(c, model.AuditActionWorkspaceMemberAdd, model.AuditEntityUser, userID)
+	audit.WorkspaceID = &workspaceID
+	audit.Before = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
+	if err := h.workspaceAdmin.AddMember(c.Request().Context(), actorID, workspaceID, userID); err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		if err.Error() == "workspace not found" || err.Error() == "user not found" {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to add user: %v", err)})
 	}
+	audit.After = workspaceRoleSnapshot(h.roleService, userID, workspaceID)
 	return c.NoContent(http.StatusNoContent)
 }
 
 // RemoveUserFromWorkspace godoc
 // @Summary Remove user from workspace
-// @Description Remove a user from a workspace
+// @Description Remove a user from a workspace. Workspace admins may remove only members whose roles in the workspace are all delegable.
 // @Tags workspaces
 // @Accept json
 // @Produce json
@@ -642,6 +667,7 @@ func (h *WorkspaceHandler) AddUserToWorkspace(c echo.Context) error {
 // @Param userId path string true "User ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -658,13 +684,43 @@ func (h *WorkspaceHandler) RemoveUserFromWorkspace(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
 	}
 
-	if err := h.workspaceService.RemoveUserFromWorkspace(uint(workspaceID), uint(userID)); err != nil {
+	actorID, err := workspaceAdminActor(c, h.workspaceAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	wsID := uint(workspaceID)
+	audit := auditDetail(c, model.AuditActionWorkspaceMemberRemove, model.AuditEntityUser, uint(userID))
+	audit.WorkspaceID = &wsID
+	audit.Before = workspaceRoleSnapshot(h.roleService, uint(userID), wsID)
+	if err := h.workspaceAdmin.RemoveMember(c.Request().Context(), actorID, wsID, uint(userID)); err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	audit.After = workspaceRoleSnapshot(h.roleService, uint(userID), wsID)
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// workspaceAdminActor 요청자의 DB 사용자 ID (워크스페이스 위임 관리 권한 확인용)
+func workspaceAdminActor(c echo.Context, workspaceAdmin *service.WorkspaceAdminService) (uint, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return 0, errors.New("kcUserId not found in context")
+	}
+	user, err := workspaceAdmin.ResolveUser(c.Request().Context(), kcUserID)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// isWorkspaceAdminDenied 관리 권한이 없거나 위임 불가 역할을 다루려 한 경우 (403)
+func isWorkspaceAdminDenied(err error) bool {
+	return errors.Is(err, service.ErrPermissionDenied) || errors.Is(err, service.ErrRoleNotDelegable)
+}
+
 // Helper function to get user DB ID and Platform Roles from context
 // TODO: Move this to a shared location or middleware
 func getUserDbIdAndPlatformRoles(ctx context.Context, c echo.Context, userService *service.UserService) (uint, []*model.RoleMaster, error) {
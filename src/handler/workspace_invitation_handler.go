@@ -2,6 +2,8 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 
@@ -15,6 +17,8 @@ import (
 type WorkspaceInvitationHandler struct {
 	invitationService *service.WorkspaceInvitationService
 	userService       *service.UserService
+	workflowService   *service.WorkflowService // nil 이면 가입 승인 워크플로를 사용하지 않음
+	workspaceAdmin    *service.WorkspaceAdminService
 }
 
 // NewWorkspaceInvitationHandler 새 WorkspaceInvitationHandler 인스턴스 생성
@@ -22,6 +26,8 @@ func NewWorkspaceInvitationHandler(db *gorm.DB) *WorkspaceInvitationHandler {
 	return &WorkspaceInvitationHandler{
 		invitationService: service.NewWorkspaceInvitationService(db),
 		userService:       service.NewUserService(db),
+		workflowService:   service.NewWorkflowService(db),
+		workspaceAdmin:    service.NewWorkspaceAdminService(db),
 	}
 }
 
@@ -44,7 +50,7 @@ func (h *WorkspaceInvitationHandler) getCallerUserID(c echo.Context) (uint, erro
 
 // SendInvitation godoc
 // @Summary Send workspace invitation
-// @Description Send an invitation to a platform user to join the workspace
+// @Description Send an invitation to a platform user to join the workspace. Allowed with the platform invitation write permission, or for workspace admins of that workspace when the invitation role is delegable.
 // @Tags workspaces
 // @Accept json
 // @Produce json
@@ -52,6 +58,7 @@ func (h *WorkspaceInvitationHandler) getCallerUserID(c echo.Context) (uint, erro
 // @Param body body model.SendInvitationRequest true "Invitation request"
 // @Success 201 {object} model.WorkspaceInvitation
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/workspaces/id/{wsId}/invitations [post]
@@ -75,8 +82,11 @@ func (h *WorkspaceInvitationHandler) SendInvitation(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "inviteeUserId is required"})
 	}
 
-	invitation, err := h.invitationService.SendInvitation(uint(wsID), callerID, req.InviteeUserID, req.RoleID)
+	invitation, err := h.workspaceAdmin.SendInvitation(c.Request().Context(), callerID, uint(wsID), req.InviteeUserID, req.RoleID)
 	if err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		if err.Error() == "user is already a member of this workspace" ||
 			err.Error() == "pending invitation already exists for this user" {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
@@ -88,13 +98,14 @@ func (h *WorkspaceInvitationHandler) SendInvitation(c echo.Context) error {
 
 // ListWorkspaceInvitations godoc
 // @Summary List workspace invitations
-// @Description List invitations for a specific workspace
+// @Description List invitations for a specific workspace. Allowed with the platform invitation read permission or for workspace admins of that workspace.
 // @Tags workspaces
 // @Accept json
 // @Produce json
 // @Param wsId path int true "Workspace ID"
 // @Param status query string false "Filter by status (PENDING/ACCEPTED/REJECTED)"
 // @Success 200 {array} model.WorkspaceInvitation
+// @Failure 403 {object} map[string]string
 // @Security BearerAuth
 // @Router /api/workspaces/id/{wsId}/invitations [get]
 // @Id listWorkspaceInvitations
@@ -105,10 +116,18 @@ func (h *WorkspaceInvitationHandler) ListWorkspaceInvitations(c echo.Context) er
 	}
 	status := c.QueryParam("status")
 
-	invitations, err := h.invitationService.ListWorkspaceInvitations(uint(wsID), status)
+	callerID, err := h.getCallerUserID(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+
+	invitations, err := h.workspaceAdmin.ListInvitations(c.Request().Context(), callerID, uint(wsID), status)
+	if err != nil {
+		if isWorkspaceAdminDenied(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
 	return c.JSON(http.StatusOK, invitations)
 }
 
@@ -137,14 +156,16 @@ func (h *WorkspaceInvitationHandler) ListMyInvitations(c echo.Context) error {
 
 // AcceptInvitation godoc
 // @Summary Accept workspace invitation
-// @Description Accept a workspace invitation and join as member
+// @Description Accept a workspace invitation and join as member. When the workspace-invitation approval workflow is enabled the invitation moves to PENDING_APPROVAL and an approval request is returned with 202; the caller joins once it is approved.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param invitationId path int true "Invitation ID"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} model.WorkflowRequest
 // @Failure 400 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string "error: separation-of-duties constraint violated"
 // @Security BearerAuth
 // @Router /api/users/me/invitations/{invitationId}/accept [put]
 // @Id acceptInvitation
@@ -159,15 +180,52 @@ func (h *WorkspaceInvitationHandler) AcceptInvitation(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	required, err := workflowRequired(h.workflowService, model.WorkflowTypeWorkspaceInvitation)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if required {
+		return h.requestInvitationApproval(c, uint(invitationID), callerID)
+	}
+
 	if err := h.invitationService.AcceptInvitation(uint(invitationID), callerID); err != nil {
 		if err.Error() == "forbidden: not your invitation" {
 			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 	return c.JSON(http.StatusOK, map[string]string{"message": "invitation accepted"})
 }
 
+// requestInvitationApproval 가입 승인 워크플로가 켜져 있으면 초대를 승인 대기로 두고 승인 요청 생성
+func (h *WorkspaceInvitationHandler) requestInvitationApproval(c echo.Context, invitationID, callerID uint) error {
+	invitation, err := h.invitationService.RequestApproval(invitationID, callerID)
+	if err != nil {
+		if err.Error() == "forbidden: not your invitation" {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	request, err := submitWorkflow(c, h.workflowService, &service.WorkflowSubmission{
+		Type:          model.WorkflowTypeWorkspaceInvitation,
+		Key:           fmt.Sprintf("invitation:%d", invitationID),
+		Summary:       fmt.Sprintf("join workspace %d through invitation %d", invitation.WorkspaceID, invitationID),
+		Payload:       model.WorkflowInvitationPayload{InvitationID: invitationID},
+		SubjectUserID: callerID,
+		WorkspaceID:   invitation.WorkspaceID,
+	})
+	if err != nil {
+		if reopenErr := h.invitationService.ReopenInvitation(invitationID); reopenErr != nil {
+			log.Printf("failed to reopen invitation %d: %v", invitationID, reopenErr)
+		}
+		return workflowError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, request)
+}
+
 // RejectInvitation godoc
 // @Summary Reject workspace invitation
 // @Description Reject a workspace invitation
@@ -230,6 +288,7 @@ func (h *WorkspaceInvitationHandler) ListAllInvitations(c echo.Context) error {
 // @Param invitationId path int true "Invitation ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "error: separation-of-duties constraint violated"
 // @Security BearerAuth
 // @Router /api/invitations/{invitationId}/approve [put]
 // @Id approveInvitation
@@ -239,7 +298,12 @@ func (h *WorkspaceInvitationHandler) ApproveInvitation(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid invitation ID"})
 	}
 
+	detail := auditDetail(c, model.AuditActionInvitationApprove, model.AuditEntityInvitation, uint(invitationID))
+	detail.After = map[string]interface{}{"status": model.InvitationStatusAccepted}
 	if err := h.invitationService.ApproveInvitation(uint(invitationID)); err != nil {
+		if errors.Is(err, service.ErrSodViolation) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 	return c.JSON(http.StatusOK, map[string]string{"message": "invitation approved"})
@@ -15,9 +15,12 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/m-cmp/mc-iam-manager/cli"
 	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/eventsink"
 	"github.com/m-cmp/mc-iam-manager/handler"
 	"github.com/m-cmp/mc-iam-manager/middleware"
+	"github.com/m-cmp/mc-iam-manager/service"
 	"github.com/m-cmp/mc-iam-manager/util"
 
 	// "github.com/m-cmp/mc-iam-manager/repository" // Removed unused import
@@ -59,6 +62,11 @@ func main() {
 	// .env 파일 로드 (프로젝트 루트에서 찾도록 수정)
 	util.LoadEnvFiles()
 
+	// 하위 명령(예: audit verify)이 있으면 서버 대신 실행
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	// 시작 로그 추가
 	log.Printf("=== Application Starting ===")
 
@@ -94,20 +102,108 @@ func main() {
 		&mcmpapi.McmpApiServiceMeta{},
 		&model.MciamPermission{},
 		&model.MciamRoleMciamPermission{},
+		&model.RoleOperationSeed{},
 		&model.Organization{},
 		&model.UserOrganization{},
 		&model.GroupPlatformRole{},
 		&model.GroupWorkspaceRole{},
 		&model.WorkspaceInvitation{},
 		&model.Company{},
+		&model.AuditEvent{},
+		&model.CredentialIssuance{},
+		&model.AuditCheckpoint{},
+		&model.AccessReviewCampaign{},
+		&model.AccessReviewItem{},
+		&model.SodConstraint{},
+		&model.SodOverride{},
+		&model.ElevationRequest{},
+		&model.WorkflowPolicy{},
+		&model.WorkflowRequest{},
+		&model.WorkflowDecision{},
+		&model.WorkflowComment{},
+		&model.BreakGlassAccount{},
+		&model.BreakGlassSession{},
+		&model.BreakGlassActivity{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	// 유효 역할/권한/메뉴 트리 캐시 무효화 (쓰기 감지 + 인스턴스 간 LISTEN/NOTIFY 전파)
+	authzCache := service.DefaultAuthzCache()
+	if err := authzCache.RegisterInvalidation(db); err != nil {
+		log.Fatalf("Failed to register authz cache invalidation: %v", err)
+	}
+	cacheListenCtx, stopCacheListen := context.WithCancel(context.Background())
+	defer stopCacheListen()
+	if err := authzCache.Listen(cacheListenCtx, dbConfig.GetDSN()); err != nil {
+		log.Printf("Authz cache listener disabled, other instances rely on cache TTL: %v", err)
+	}
+
+	// permission.yaml 의 역할별 operations/denies 중 아직 반영하지 않은 항목 추가 (기존 배포에 새 라우트 권한 반영, 반영 이력은 DB 에 기록)
+	if os.Getenv("MC_IAM_MANAGER_SYNC_ROLE_OPERATIONS") != "false" {
+		if added, err := service.NewMenuService(db).SyncRoleOperationPermissionsFromYAML(""); err != nil {
+			log.Printf("Failed to sync role operation permissions from permission.yaml: %v", err)
+		} else if added > 0 {
+			log.Printf("Synced %d role operation permissions from permission.yaml", added)
+		}
+	}
+
 	// Keycloak 초기화
 	if err := config.InitKeycloak(); err != nil {
 		log.Fatalf("Failed to initialize Keycloak: %v", err)
 	}
+	jwksCtx, stopJWKSRefresh := context.WithCancel(context.Background())
+	defer stopJWKSRefresh()
+	if err := util.DefaultJWKSCache().Start(jwksCtx); err != nil {
+		log.Printf("Initial JWKS fetch failed, retrying on first token: %v", err)
+	}
+
+	// 감사 해시 체인 서명 체크포인트 (서명 키가 설정된 경우)
+	checkpointCtx, stopCheckpointing := context.WithCancel(context.Background())
+	defer stopCheckpointing()
+	if err := service.NewAuditChainService(db).StartCheckpointing(checkpointCtx); err != nil {
+		log.Printf("Audit checkpoints disabled: %v", err)
+	}
+
+	// 보안 이벤트 싱크 (JSONL 파일, syslog) 전달 시작
+	eventsink.Default()
+
+	// 마감된 접근 검토 캠페인 자동 종료
+	accessReviewCtx, stopAccessReviews := context.WithCancel(context.Background())
+	defer stopAccessReviews()
+	service.NewAccessReviewService(db).StartAutoClose(accessReviewCtx)
+
+	// 규정 준수 보고서 정기 생성 (MC_IAM_MANAGER_REPORT_DIR 설정 시)
+	reportCtx, stopReports := context.WithCancel(context.Background())
+	defer stopReports()
+	service.NewReportService(db).StartScheduledReports(reportCtx)
+
+	// 휴면 계정 경고/자동 비활성화 (MC_IAM_MANAGER_DORMANT_*_DAYS 설정 시)
+	dormancyService := service.NewDormancyService(db)
+	dormancyCtx, stopDormancy := context.WithCancel(context.Background())
+	defer stopDormancy()
+	dormancyService.StartDormancyCheck(dormancyCtx)
+
+	// 기한부 역할 할당 시작/만료 처리 (valid_from/expires_at)
+	roleExpiryCtx, stopRoleExpiry := context.WithCancel(context.Background())
+	defer stopRoleExpiry()
+	service.NewRoleAssignmentService(db).StartExpiryReaper(roleExpiryCtx)
+
+	// 기한부 권한 승격(JIT) 만료 시 자동 회수
+	elevationCtx, stopElevations := context.WithCancel(context.Background())
+	defer stopElevations()
+	service.NewElevationService(db).StartExpiry(elevationCtx)
+
+	// 승인 워크플로 기한 경과 시 상향/만료 처리
+	workflowCtx, stopWorkflows := context.WithCancel(context.Background())
+	defer stopWorkflows()
+	service.NewWorkflowService(db).StartTimeouts(workflowCtx)
+
+	// 비상 접근(break-glass) 만료 시 자동 회수
+	breakGlassService := service.NewBreakGlassService(db)
+	breakGlassCtx, stopBreakGlass := context.WithCancel(context.Background())
+	defer stopBreakGlass()
+	breakGlassService.StartExpiry(breakGlassCtx)
 
 	// 핸들러 초기화
 	authHandler := handler.NewAuthHandler(db)
@@ -140,6 +236,20 @@ func main() {
 	groupRoleHandler := handler.NewGroupRoleHandler(db)
 	// 회사 정보 핸들러 초기화
 	companyHandler := handler.NewCompanyHandler(db)
+	// 권한 판정 핸들러 초기화
+	authzHandler := handler.NewAuthzHandler(db)
+	// 감사 이벤트 핸들러 초기화
+	auditHandler := handler.NewAuditHandler(db)
+	credentialIssuanceHandler := handler.NewCredentialIssuanceHandler(db)
+	// 접근 검토 캠페인 핸들러 초기화
+	accessReviewHandler := handler.NewAccessReviewHandler(db)
+	sodHandler := handler.NewSodHandler(db)
+	reportHandler := handler.NewReportHandler(db)
+	elevationHandler := handler.NewElevationHandler(db)
+	breakGlassHandler := handler.NewBreakGlassHandler(db)
+	workflowHandler := handler.NewWorkflowHandler(db)
+	bulkAssignmentHandler := handler.NewBulkAssignmentHandler(db)
+	roleTemplateHandler := handler.NewRoleTemplateHandler(db)
 
 	// Echo 인스턴스 생성
 	e := echo.New()
@@ -147,6 +257,13 @@ func main() {
 	// Validator 설정
 	e.Validator = &CustomValidator{validator: validator.New()}
 
+	// 요청 IP 추출 (신뢰하는 프록시가 없으면 클라이언트가 보낸 X-Forwarded-For 를 무시)
+	ipExtractor, err := middleware.NewIPExtractor(os.Getenv("MC_IAM_MANAGER_TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("invalid MC_IAM_MANAGER_TRUSTED_PROXIES: %v", err)
+	}
+	e.IPExtractor = ipExtractor
+
 	// 로그 레벨 설정
 	e.Debug = true
 
@@ -192,34 +309,50 @@ func main() {
 		}
 	})
 
+	// 토큰 사용 시각 기록 (휴면 계정 정책)
+	e.Use(middleware.ActivityMiddleware(dormancyService))
+
+	// 비상 접근 중 platformAdmin 반영과 활동 기록 (종료 후에는 토큰에 남은 platformAdmin 제거)
+	e.Use(middleware.BreakGlassMiddleware(breakGlassService))
+
+	// 조건부 권한 매핑 평가용 요청 속성 (IP, 시각, 프로젝트)
+	e.Use(middleware.AuthzConditionMiddleware)
+
+	// 변경 요청 감사 기록 (행위자, 라우트, 대상, 전후 스냅샷, 결과, 요청 IP)
+	e.Use(middleware.AuditMiddleware(service.NewAuditService(db)))
+
 	// 라우트 설정
 	e.GET("/readyz", healthHandler.CheckHealth)
 
 	api := e.Group(basePath)
 
+	// 라우트별 MC-IAM 권한 검사기 (호출자의 유효 권한을 DB에서 조회)
+	perm := middleware.NewPermissionAuthorizer(db)
+	wsMember := middleware.WorkspaceRoleMiddleware(db) // 워크스페이스 구성원 확인 (직접 할당 + 그룹 상속)
+
 	// 인증 라우트
 	auth := api.Group("/auth")
 	{
-		auth.POST("/login", authHandler.Login)
-		auth.POST("/logout", authHandler.Logout)
-		auth.POST("/refresh", authHandler.RefreshToken)
-		auth.GET("/certs", authHandler.AuthCerts)
-		auth.GET("/temp-credential-csps", authHandler.GetTempCredentialProviders)
-		auth.POST("/validate", authHandler.Validate)
-		auth.POST("/signup", userHandler.SignupUser) // Public signup
+		auth.POST("/login", authHandler.Login, perm.Exempt)
+		auth.POST("/logout", authHandler.Logout, perm.Exempt)
+		auth.POST("/refresh", authHandler.RefreshToken, perm.Exempt)
+		auth.GET("/certs", authHandler.AuthCerts, perm.Exempt)
+		auth.GET("/temp-credential-csps", authHandler.GetTempCredentialProviders, perm.Exempt)
+		auth.POST("/validate", authHandler.Validate, perm.Exempt)
+		auth.POST("/signup", userHandler.SignupUser, perm.Exempt) // Public signup
 	}
 
 	// platform admin 생성. 권한체크 필요한데...
-	api.POST("/initial-admin", adminHandler.SetupInitialAdmin) // TODO : 초기 설정에서 직접 keycloak 호출하는 것으로 바꿔야 할 듯.
+	api.POST("/initial-admin", adminHandler.SetupInitialAdmin, perm.Exempt) // TODO : 초기 설정에서 직접 keycloak 호출하는 것으로 바꿔야 할 듯.
 
 	// 회사 정보 라우트 (싱글톤 — URL에 ID 없음)
 	company := api.Group("/company")
 	{
-		company.POST("", companyHandler.CreateCompany, middleware.PlatformAdminMiddleware)
-		company.GET("", companyHandler.GetCompany, middleware.PlatformRoleMiddleware(middleware.Read))
-		company.PUT("", companyHandler.UpdateCompany, middleware.PlatformAdminMiddleware)
-		company.DELETE("", companyHandler.DeactivateCompany, middleware.PlatformAdminMiddleware)
-		company.POST("/activate", companyHandler.ActivateCompany, middleware.PlatformAdminMiddleware)
+		company.POST("", companyHandler.CreateCompany, perm.Require("mc-iam-manager:company:manage"))
+		company.GET("", companyHandler.GetCompany, perm.Require("mc-iam-manager:company:read"))
+		company.PUT("", companyHandler.UpdateCompany, perm.Require("mc-iam-manager:company:manage"))
+		company.DELETE("", companyHandler.DeactivateCompany, perm.Require("mc-iam-manager:company:manage"))
+		company.POST("/activate", companyHandler.ActivateCompany, perm.Require("mc-iam-manager:company:manage"))
 	}
 
 	// 관리자 setup 라우트
@@ -240,192 +373,223 @@ func main() {
 		setup.POST("/initial-organizations", organizationHandler.SetupInitialOrganizations, middleware.PlatformAdminMiddleware)
 	}
 
-	// 워크스페이스 라우트
+	// 워크스페이스 라우트 (구성원·초대 관리는 서비스에서 플랫폼 권한 또는 워크스페이스 관리자 범위를 확인)
 	workspaces := api.Group("/workspaces")
+	perm.Declare(service.WorkspaceAdminPermission)
 	{
-		workspaces.POST("/list", workspaceHandler.ListWorkspaces, middleware.PlatformRoleMiddleware(middleware.Write)) // workspace 목록만 조회. 전체조회 권한이 있으면 모든 workspaces, 그 외에는 세션의 유저에 해당하는 workspaces 조회
-		workspaces.POST("", workspaceHandler.CreateWorkspace)
-		workspaces.GET("/id/:workspaceId", workspaceHandler.GetWorkspaceByID)
-		workspaces.GET("/name/:workspaceName", workspaceHandler.GetWorkspaceByName)
-		workspaces.PUT("/id/:workspaceId", workspaceHandler.UpdateWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.DELETE("/id/:workspaceId", workspaceHandler.DeleteWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))
-
-		workspaces.POST("/workspace-ticket", authHandler.WorkspaceTicket) // 1개 워크스페이스에 대한 티켓 설정
-		workspaces.POST("/temporary-credentials", cspCredentialHandler.GetTemporaryCredentials)
-		workspaces.POST("/credentials/validate", cspValidationHandler.ValidateCredentials)
-
-		workspaces.POST("/users/list", workspaceHandler.ListWorkspaceUsers, middleware.PlatformRoleMiddleware(middleware.Write))               // workspace의 사용자 목록 조회
-		workspaces.POST("/users-roles/list", workspaceHandler.ListWorkspaceUsersAndRoles, middleware.PlatformRoleMiddleware(middleware.Write)) // workspace와 사용자 및 role 조회
-		workspaces.POST("/roles/list", workspaceHandler.ListWorkspaceRoles, middleware.PlatformRoleMiddleware(middleware.Write))               // workspace 역할 목록 조회
-
-		workspaces.POST("/projects/list", workspaceHandler.ListWorkspaceProjects, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.GET("/id/:workspaceId/projects/list", workspaceHandler.GetWorkspaceProjectsByWorkspaceId, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.POST("/id/:workspaceId/users/list", workspaceHandler.ListUsersAndRolesByWorkspaces)                                              // TODO ListAllWorkspaceUsersAndRoles으로 대체 또는 통합 가능하지 않나?
-		workspaces.GET("/id/:workspaceId/users/id/:userId", roleHandler.GetUserWorkspaceRoles, middleware.PlatformRoleMiddleware(middleware.Write)) // 특정 사용자에게 할당된 워크스페이스 역할 조회 ( 관리자가 사용자의 workspace role 조회) --> get을 post로 바꿀까?
-
-		workspaces.POST("/id/:id/users", workspaceHandler.AddUserToWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))                // workspace에 사용자 추가
-		workspaces.DELETE("/id/:id/users/:userId", workspaceHandler.RemoveUserFromWorkspace, middleware.PlatformRoleMiddleware(middleware.Write)) // workspace에서 사용자 제거
-		workspaces.POST("/assign/projects", workspaceHandler.AddProjectToWorkspace, middleware.PlatformAdminMiddleware)
-		workspaces.DELETE("/unassign/projects", workspaceHandler.RemoveProjectFromWorkspace, middleware.PlatformAdminMiddleware)
+		workspaces.POST("/list", workspaceHandler.ListWorkspaces, perm.Require("mc-iam-manager:workspace:read")) // workspace 목록만 조회. 전체조회 권한이 있으면 모든 workspaces, 그 외에는 세션의 유저에 해당하는 workspaces 조회
+		workspaces.POST("", workspaceHandler.CreateWorkspace, perm.Require("mc-iam-manager:workspace:write"))
+		workspaces.GET("/id/:workspaceId", workspaceHandler.GetWorkspaceByID, wsMember, perm.Exempt)
+		workspaces.GET("/name/:workspaceName", workspaceHandler.GetWorkspaceByName, wsMember, perm.Exempt)
+		workspaces.PUT("/id/:workspaceId", workspaceHandler.UpdateWorkspace, wsMember, perm.Require("mc-iam-manager:workspace:write"))
+		workspaces.DELETE("/id/:workspaceId", workspaceHandler.DeleteWorkspace, wsMember, perm.Require("mc-iam-manager:workspace:write"))
+
+		workspaces.POST("/workspace-ticket", authHandler.WorkspaceTicket, wsMember, perm.Exempt) // 1개 워크스페이스에 대한 티켓 설정
+		workspaces.POST("/temporary-credentials", cspCredentialHandler.GetTemporaryCredentials, wsMember, perm.Exempt)
+		workspaces.POST("/credentials/validate", cspValidationHandler.ValidateCredentials, wsMember, perm.Exempt)
+
+		workspaces.POST("/users/list", workspaceHandler.ListWorkspaceUsers, perm.Require("mc-iam-manager:workspace:read"))               // workspace의 사용자 목록 조회
+		workspaces.POST("/users-roles/list", workspaceHandler.ListWorkspaceUsersAndRoles, perm.Require("mc-iam-manager:workspace:read")) // workspace와 사용자 및 role 조회
+		workspaces.POST("/roles/list", workspaceHandler.ListWorkspaceRoles, perm.Require("mc-iam-manager:workspace:read"))               // workspace 역할 목록 조회
+
+		workspaces.POST("/projects/list", workspaceHandler.ListWorkspaceProjects, perm.Require("mc-iam-manager:workspace:read"))
+		workspaces.GET("/id/:workspaceId/projects/list", workspaceHandler.GetWorkspaceProjectsByWorkspaceId, wsMember, perm.Require("mc-iam-manager:workspace:read"))
+		workspaces.POST("/id/:workspaceId/users/list", workspaceHandler.ListUsersAndRolesByWorkspaces, wsMember, perm.Exempt)                           // TODO ListAllWorkspaceUsersAndRoles으로 대체 또는 통합 가능하지 않나?
+		workspaces.GET("/id/:workspaceId/users/id/:userId", roleHandler.GetUserWorkspaceRoles, wsMember, perm.Require("mc-iam-manager:workspace:read")) // 특정 사용자에게 할당된 워크스페이스 역할 조회 ( 관리자가 사용자의 workspace role 조회) --> get을 post로 바꿀까?
+
+		workspaces.POST("/id/:id/users", workspaceHandler.AddUserToWorkspace, wsMember, perm.Exempt)                // workspace에 사용자 추가
+		workspaces.DELETE("/id/:id/users/:userId", workspaceHandler.RemoveUserFromWorkspace, wsMember, perm.Exempt) // workspace에서 사용자 제거
+		workspaces.POST("/assign/projects", workspaceHandler.AddProjectToWorkspace, perm.Require("mc-iam-manager:workspace:manage"))
+		workspaces.DELETE("/unassign/projects", workspaceHandler.RemoveProjectFromWorkspace, perm.Require("mc-iam-manager:workspace:manage"))
 
 		// 워크스페이스 초대 (RQ-M6-WS-036)
-		workspaces.POST("/id/:wsId/invitations", workspaceInvitationHandler.SendInvitation)
-		workspaces.GET("/id/:wsId/invitations", workspaceInvitationHandler.ListWorkspaceInvitations)
+		workspaces.POST("/id/:wsId/invitations", workspaceInvitationHandler.SendInvitation, wsMember, perm.Exempt)
+		workspaces.GET("/id/:wsId/invitations", workspaceInvitationHandler.ListWorkspaceInvitations, wsMember, perm.Exempt)
 
 	}
 
 	// 프로젝트 라우트 : workspace ticket과 workspaceId가 있으면 됨.
 	projects := api.Group("/projects")
 	{
-		projects.POST("/list", projectHandler.ListProjects, middleware.PlatformRoleMiddleware(middleware.Write))
-		projects.POST("", projectHandler.CreateProject, middleware.PlatformRoleMiddleware(middleware.Manage)) // platformRole에서 관리자
-		projects.GET("/id/:projectId", projectHandler.GetProjectByID, middleware.PlatformRoleMiddleware(middleware.Write))
-		projects.GET("/name/:projectName", projectHandler.GetProjectByName)
-		projects.PUT("/id/:projectId", projectHandler.UpdateProject, middleware.PlatformRoleMiddleware(middleware.Manage))
-		projects.DELETE("/id/:projectId", projectHandler.DeleteProject, middleware.PlatformRoleMiddleware(middleware.Manage))
+		projects.POST("/list", projectHandler.ListProjects, perm.Require("mc-iam-manager:project:read"))
+		projects.POST("", projectHandler.CreateProject, perm.Require("mc-iam-manager:project:manage")) // platformRole에서 관리자
+		projects.GET("/id/:projectId", projectHandler.GetProjectByID, perm.Require("mc-iam-manager:project:read"))
+		projects.GET("/name/:projectName", projectHandler.GetProjectByName, perm.Exempt)
+		projects.PUT("/id/:projectId", projectHandler.UpdateProject, perm.Require("mc-iam-manager:project:manage"))
+		projects.DELETE("/id/:projectId", projectHandler.DeleteProject, perm.Require("mc-iam-manager:project:manage"))
 
-		projects.GET("/id/:projectId/workspaces", projectHandler.GetProjectWorkspaces, middleware.PlatformRoleMiddleware(middleware.Write)) // Get workspaces assigned to project
+		projects.GET("/id/:projectId/workspaces", projectHandler.GetProjectWorkspaces, perm.Require("mc-iam-manager:project:read")) // Get workspaces assigned to project
 
-		projects.POST("/assign/workspaces", projectHandler.AddWorkspaceToProject, middleware.PlatformAdminMiddleware)
-		projects.DELETE("/unassign/workspaces", projectHandler.RemoveWorkspaceFromProject, middleware.PlatformAdminMiddleware)
+		projects.POST("/assign/workspaces", projectHandler.AddWorkspaceToProject, perm.Require("mc-iam-manager:project:manage"))
+		projects.DELETE("/unassign/workspaces", projectHandler.RemoveWorkspaceFromProject, perm.Require("mc-iam-manager:project:manage"))
 	}
 
 	// 역할 관리 라우트
 	roles := api.Group("/roles")
 	{
-		roles.POST("/list", roleHandler.ListRoles)
-		roles.POST("", roleHandler.CreateRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.GET("/id/:roleId", roleHandler.GetRoleByRoleID)
-		roles.GET("/name/:roleName", roleHandler.GetRoleByRoleName)
-		roles.PUT("/id/:roleId", roleHandler.UpdateRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.DELETE("/id/:roleId", roleHandler.DeleteRole, middleware.PlatformRoleMiddleware(middleware.Write))
-
-		roles.POST("/id/:roleId/assign", roleHandler.AssignRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.DELETE("/id/:roleId/unassign", roleHandler.RemoveRole, middleware.PlatformRoleMiddleware(middleware.Write))
+		roles.POST("/list", roleHandler.ListRoles, perm.Exempt)
+		roles.POST("", roleHandler.CreateRole, perm.Require("mc-iam-manager:role:write"))
+		roles.GET("/id/:roleId", roleHandler.GetRoleByRoleID, perm.Exempt)
+		roles.GET("/id/:roleId/resolved-permissions", roleHandler.GetResolvedRolePermissions, perm.Require("mc-iam-manager:role:read"))
+		roles.GET("/name/:roleName", roleHandler.GetRoleByRoleName, perm.Exempt)
+		roles.PUT("/id/:roleId", roleHandler.UpdateRole, perm.Require("mc-iam-manager:role:write"))
+		roles.DELETE("/id/:roleId", roleHandler.DeleteRole, perm.Require("mc-iam-manager:role:write"))
+		roles.POST("/id/:roleId/clone", roleTemplateHandler.CloneRole, perm.Require("mc-iam-manager:role:write"), perm.Require("mc-iam-manager:mciam-permission:write"))
+
+		// 역할 템플릿 (asset/role-templates/*.yaml)
+		roles.GET("/templates", roleTemplateHandler.ListRoleTemplates, perm.Require("mc-iam-manager:role:read"))
+		roles.GET("/templates/:templateName", roleTemplateHandler.GetRoleTemplate, perm.Require("mc-iam-manager:role:read"))
+		roles.POST("/templates/:templateName/instantiate", roleTemplateHandler.CreateRoleFromTemplate, perm.Require("mc-iam-manager:role:write"), perm.Require("mc-iam-manager:mciam-permission:write"))
+
+		roles.POST("/id/:roleId/assign", roleHandler.AssignRole, perm.Require("mc-iam-manager:role:write"))
+		roles.DELETE("/id/:roleId/unassign", roleHandler.RemoveRole, perm.Require("mc-iam-manager:role:write"))
 		//roles.PUT("/id/:roleId/platform-roles/menus", roleHandler.UpdateRoleMenuMappings, middleware.PlatformRoleMiddleware(middleware.Write))
 		//------ 기본은 roles 관리로 되나. role관련은 특정 업무에 맞게 추가 ------//
 
 		// 사용자에게 플랫폼 역할 할당
-		roles.POST("/assign/platform-role", roleHandler.AssignPlatformRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.DELETE("/unassign/platform-role", roleHandler.RemovePlatformRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		// 사용자에게 워크스페이스 역할 할당
-		roles.POST("/assign/workspace-role", roleHandler.AssignWorkspaceRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.DELETE("/unassign/workspace-role", roleHandler.RemoveWorkspaceRole, middleware.PlatformRoleMiddleware(middleware.Write))
+		roles.POST("/assign/platform-role", roleHandler.AssignPlatformRole, perm.Require("mc-iam-manager:role:write"))
+		roles.DELETE("/unassign/platform-role", roleHandler.RemovePlatformRole, perm.Require("mc-iam-manager:role:write"))
+		// 사용자에게 워크스페이스 역할 할당 (서비스에서 role:write 또는 워크스페이스 관리자의 delegable 역할만 허용)
+		roles.POST("/assign/workspace-role", roleHandler.AssignWorkspaceRole, perm.Exempt)
+		roles.DELETE("/unassign/workspace-role", roleHandler.RemoveWorkspaceRole, perm.Exempt)
+		// CSV/YAML 일괄 역할·그룹 할당 (dryRun=true 이면 계획만, 그룹 열이 있으면 서비스에서 organization:write 확인)
+		roles.POST("/assign/bulk", bulkAssignmentHandler.BulkAssignRoles, perm.Require("mc-iam-manager:role:write"))
 
 		// csp role 매핑 관리
-		roles.POST("/csp-roles", roleHandler.AddCspRoleMappings, middleware.PlatformRoleMiddleware(middleware.Manage))
-		roles.DELETE("/csp-roles", roleHandler.RemoveCspRoleMappings, middleware.PlatformRoleMiddleware(middleware.Manage))
+		roles.POST("/csp-roles", roleHandler.AddCspRoleMappings, perm.Require("mc-iam-manager:role:manage"))
+		roles.DELETE("/csp-roles", roleHandler.RemoveCspRoleMappings, perm.Require("mc-iam-manager:role:manage"))
 
 		//roles.GET("/id/:workspaceRoleId/csp-roles", roleHandler.ListCspRoleMappings, middleware.PlatformRoleMiddleware(middleware.Write))
 
-		roles.POST("/platform-roles/list", roleHandler.ListPlatformRoles)
-		roles.POST("/platform-roles", roleHandler.CreatePlatformRole)
+		roles.POST("/platform-roles/list", roleHandler.ListPlatformRoles, perm.Exempt)
+		roles.POST("/platform-roles", roleHandler.CreatePlatformRole, perm.Require("mc-iam-manager:role:write"))
 		//roles.DELETE("/platform-roles", roleHandler.DeletePlatformRole)
-		roles.DELETE("/platform-roles/id/:roleId", roleHandler.DeletePlatformRole) //단건삭제
-		roles.GET("/platform-roles/id/:roleId", roleHandler.GetPlatformRoleByID)
-		roles.GET("/platform-roles/name/:roleName", roleHandler.GetPlatformRoleByName)
-		roles.PUT("/platform-roles/id/:roleId", roleHandler.UpdatePlatformRole)
+		roles.DELETE("/platform-roles/id/:roleId", roleHandler.DeletePlatformRole, perm.Require("mc-iam-manager:role:write")) //단건삭제
+		roles.GET("/platform-roles/id/:roleId", roleHandler.GetPlatformRoleByID, perm.Exempt)
+		roles.GET("/platform-roles/name/:roleName", roleHandler.GetPlatformRoleByName, perm.Exempt)
+		roles.PUT("/platform-roles/id/:roleId", roleHandler.UpdatePlatformRole, perm.Require("mc-iam-manager:role:write"))
 
-		roles.POST("/workspace-roles/list", roleHandler.ListWorkspaceRoles)
-		roles.POST("/workspace-roles", roleHandler.CreateWorkspaceRole)
+		roles.POST("/workspace-roles/list", roleHandler.ListWorkspaceRoles, perm.Exempt)
+		roles.POST("/workspace-roles", roleHandler.CreateWorkspaceRole, perm.Require("mc-iam-manager:role:write"))
 		//roles.DELETE("/workspace-roles", roleHandler.DeleteWorkspaceRole)
-		roles.DELETE("/workspace-roles/id/:roleId", roleHandler.DeleteWorkspaceRole) //단건삭제
-		roles.GET("/workspace-roles/id/:roleId", roleHandler.GetWorkspaceRoleByID)
-		roles.GET("/workspace-roles/name/:roleName", roleHandler.GetWorkspaceRoleByName)
-		roles.PUT("/workspace-roles/id/:roleId", roleHandler.UpdateWorkspaceRole)
-
-		roles.POST("/csp-roles/list", roleHandler.ListCspRoleMappings)
-		roles.GET("/csp-roles/id/:roleId", roleHandler.GetCspRoleMappings)
-		roles.POST("/csp-roles/master", roleHandler.CreateCspRoleMaster)
-		roles.PUT("/csp-roles/master/id/:roleId", roleHandler.UpdateCspRoleMaster)
-		roles.DELETE("/csp-roles/master/id/:roleId", roleHandler.DeleteCspRoleMaster)
-		roles.POST("/csp/list", roleHandler.ListCSPRoles)
-		roles.POST("/csp", roleHandler.CreateCspRole)
-		roles.POST("/csp/batch", roleHandler.CreateCspRoles)
+		roles.DELETE("/workspace-roles/id/:roleId", roleHandler.DeleteWorkspaceRole, perm.Require("mc-iam-manager:role:write")) //단건삭제
+		roles.GET("/workspace-roles/id/:roleId", roleHandler.GetWorkspaceRoleByID, perm.Exempt)
+		roles.GET("/workspace-roles/name/:roleName", roleHandler.GetWorkspaceRoleByName, perm.Exempt)
+		roles.PUT("/workspace-roles/id/:roleId", roleHandler.UpdateWorkspaceRole, perm.Require("mc-iam-manager:role:write"))
+
+		roles.POST("/csp-roles/list", roleHandler.ListCspRoleMappings, perm.Exempt)
+		roles.GET("/csp-roles/id/:roleId", roleHandler.GetCspRoleMappings, perm.Exempt)
+		roles.POST("/csp-roles/master", roleHandler.CreateCspRoleMaster, perm.Require("mc-iam-manager:role:write"))
+		roles.PUT("/csp-roles/master/id/:roleId", roleHandler.UpdateCspRoleMaster, perm.Require("mc-iam-manager:role:write"))
+		roles.DELETE("/csp-roles/master/id/:roleId", roleHandler.DeleteCspRoleMaster, perm.Require("mc-iam-manager:role:write"))
+		roles.POST("/csp/list", roleHandler.ListCSPRoles, perm.Exempt)
+		roles.POST("/csp", roleHandler.CreateCspRole, perm.Require("mc-iam-manager:role:write"))
+		roles.POST("/csp/batch", roleHandler.CreateCspRoles, perm.Require("mc-iam-manager:role:write"))
 		//roles.DELETE("/csp", roleHandler.DeleteCspRole)
-		roles.DELETE("/csp/id/:roleId", roleHandler.DeleteCspRole) //단건삭제
-		roles.PUT("/csp/id/:roleId", roleHandler.UpdateCspRole)
-		roles.GET("/csp/id/:roleId", roleHandler.GetCspRoleByID)
-		roles.GET("/csp/name/:roleName", roleHandler.GetCspRoleByName)
-
-		roles.POST("/mappings/list", roleHandler.ListRoleMasterMappings)
-		roles.GET("/mappings/role/id/:roleId", roleHandler.GetRoleMasterMappings)
-		roles.POST("/mappings/platform-roles/users/list", roleHandler.ListUsersByPlatformRole)
-		roles.GET("/mappings/platform-roles/id/:roleId/groups", groupRoleHandler.ListGroupsByPlatformRole, middleware.PlatformRoleMiddleware(middleware.Write))
-
-		roles.POST("/mappings/workspace-roles/users/list", roleHandler.ListUsersByWorkspaceRole)
-		roles.GET("/mappings/workspace-roles/id/:roleId/groups", groupRoleHandler.ListGroupsByWorkspaceRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.POST("/mappings/csp-roles/list", roleHandler.ListRoleMasterMappingsByCspRole)
+		roles.DELETE("/csp/id/:roleId", roleHandler.DeleteCspRole, perm.Require("mc-iam-manager:role:write")) //단건삭제
+		roles.PUT("/csp/id/:roleId", roleHandler.UpdateCspRole, perm.Require("mc-iam-manager:role:write"))
+		roles.GET("/csp/id/:roleId", roleHandler.GetCspRoleByID, perm.Exempt)
+		roles.GET("/csp/name/:roleName", roleHandler.GetCspRoleByName, perm.Exempt)
+
+		roles.POST("/mappings/list", roleHandler.ListRoleMasterMappings, perm.Exempt)
+		roles.GET("/mappings/role/id/:roleId", roleHandler.GetRoleMasterMappings, perm.Exempt)
+		roles.POST("/mappings/platform-roles/users/list", roleHandler.ListUsersByPlatformRole, perm.Exempt)
+		roles.GET("/mappings/platform-roles/id/:roleId/groups", groupRoleHandler.ListGroupsByPlatformRole, perm.Require("mc-iam-manager:role:read"))
+
+		roles.POST("/mappings/workspace-roles/users/list", roleHandler.ListUsersByWorkspaceRole, perm.Exempt)
+		roles.GET("/mappings/workspace-roles/id/:roleId/groups", groupRoleHandler.ListGroupsByWorkspaceRole, perm.Require("mc-iam-manager:role:read"))
+		roles.POST("/mappings/csp-roles/list", roleHandler.ListRoleMasterMappingsByCspRole, perm.Exempt)
 	}
 
 	// 사용자 라우트
 	users := api.Group("/users")
 	{
-		users.POST("/list", userHandler.ListUsers, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.POST("", userHandler.CreateUser, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.GET("/id/:userId", userHandler.GetUserByID, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.GET("/kc/:kcUserId", userHandler.GetUserByKcID, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.GET("/name/:username", userHandler.GetUserByUsername, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.PUT("/id/:userId", userHandler.UpdateUser, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.DELETE("/id/:userId", userHandler.DeleteUser, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.POST("/id/:userId/status", userHandler.UpdateUserStatus, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.PUT("/id/:userId/password", userHandler.ResetUserPassword, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.GET("/me", userHandler.GetMyInfo)                                                              // 사용자 본인 정보 조회
-		users.PUT("/me/password", userHandler.ChangeMyPassword)                                              // 사용자 본인 패스워드 변경
-		users.GET("/me/platform-roles", userHandler.GetMyPlatformRoles)                                      // 내 유효 플랫폼 역할 목록
-		users.GET("/me/workspace-roles", userHandler.GetMyWorkspaceRoles)                                    // 내 유효 워크스페이스 역할 목록
-		users.PUT("/id/:userId/deactivate", userHandler.DeactivateUser, middleware.PlatformAdminMiddleware)  // 사용자 계정 비활성화
-		users.PUT("/id/:userId/activate", userHandler.ActivateUser, middleware.PlatformAdminMiddleware)      // 사용자 계정 재활성화
-		users.POST("/me/withdrawal", userHandler.RequestWithdrawal)                                          // 탈퇴 신청
-		users.PUT("/id/:userId/withdraw", userHandler.ProcessWithdrawal, middleware.PlatformAdminMiddleware) // 탈퇴 처리
-
-		users.POST("/menus-tree/list", menuHandler.ListUserMenuTree)
-		users.POST("/menus/list", menuHandler.ListUserMenu)
-		users.POST("/workspaces/list", userHandler.ListUserWorkspaces)
-		users.GET("/workspaces/id/:workspaceId/projects/list", userHandler.ListUserProjectsByWorkspace)
-		users.POST("/workspaces/roles/list", userHandler.ListUserWorkspaceAndWorkspaceRoles)
-
-		users.GET("/id/:userId/workspaces/list", userHandler.GetUserWorkspacesByUserID, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.GET("/id/:userId/workspaces/roles/list", userHandler.GetUserWorkspaceAndWorkspaceRolesByUserID, middleware.PlatformRoleMiddleware(middleware.Read))
-		users.GET("/id/:userId/workspaces/id/:workspaceId/roles/list", userHandler.GetUserWorkspaceAndWorkspaceRolesByUserIDAndWorkspaceID, middleware.PlatformRoleMiddleware(middleware.Read))
+		users.POST("/list", userHandler.ListUsers, perm.Require("mc-iam-manager:user:read"))
+		users.POST("", userHandler.CreateUser, perm.Require("mc-iam-manager:user:write"))
+		users.GET("/id/:userId", userHandler.GetUserByID, perm.Require("mc-iam-manager:user:read"))
+		users.GET("/kc/:kcUserId", userHandler.GetUserByKcID, perm.Require("mc-iam-manager:user:read"))
+		users.GET("/name/:username", userHandler.GetUserByUsername, perm.Require("mc-iam-manager:user:read"))
+		users.PUT("/id/:userId", userHandler.UpdateUser, perm.Require("mc-iam-manager:user:write"))
+		users.DELETE("/id/:userId", userHandler.DeleteUser, perm.Require("mc-iam-manager:user:write"))
+		users.POST("/id/:userId/status", userHandler.UpdateUserStatus, perm.Require("mc-iam-manager:user:write"))
+		users.PUT("/id/:userId/password", userHandler.ResetUserPassword, perm.Require("mc-iam-manager:user:write"))
+		users.GET("/me", userHandler.GetMyInfo, perm.Exempt)                                                         // 사용자 본인 정보 조회
+		users.PUT("/me/password", userHandler.ChangeMyPassword, perm.Exempt)                                         // 사용자 본인 패스워드 변경
+		users.GET("/me/platform-roles", userHandler.GetMyPlatformRoles, perm.Exempt)                                 // 내 유효 플랫폼 역할 목록
+		users.GET("/me/workspace-roles", userHandler.GetMyWorkspaceRoles, perm.Exempt)                               // 내 유효 워크스페이스 역할 목록
+		users.PUT("/id/:userId/deactivate", userHandler.DeactivateUser, perm.Require("mc-iam-manager:user:manage"))  // 사용자 계정 비활성화
+		users.PUT("/id/:userId/activate", userHandler.ActivateUser, perm.Require("mc-iam-manager:user:manage"))      // 사용자 계정 재활성화
+		users.POST("/me/withdrawal", userHandler.RequestWithdrawal, perm.Exempt)                                     // 탈퇴 신청
+		users.PUT("/id/:userId/withdraw", userHandler.ProcessWithdrawal, perm.Require("mc-iam-manager:user:manage")) // 탈퇴 처리
+
+		// 휴면 계정 정책 조회 / 사용자별 예외 설정 (서비스 계정, 비상 접근 계정)
+		users.GET("/dormancy-policy", userHandler.GetDormancyPolicy, perm.Require("mc-iam-manager:user:read"))
+		users.PUT("/id/:userId/dormancy-exemption", userHandler.SetDormancyExemption, perm.Require("mc-iam-manager:user:manage"))
+
+		// 기한부 권한 승격(JIT) 요청/취소, 내가 결정할 요청
+		users.POST("/me/elevations", elevationHandler.CreateMyElevation, perm.Exempt)
+		users.GET("/me/elevations", elevationHandler.ListMyElevations, perm.Exempt)
+		users.GET("/me/elevations/:elevationId", elevationHandler.GetMyElevation, perm.Exempt)
+		users.POST("/me/elevations/:elevationId/cancel", elevationHandler.CancelMyElevation, perm.Exempt)
+		users.GET("/me/elevation-approvals", elevationHandler.ListMyElevationApprovals, perm.Exempt)
+
+		// 비상 접근(break-glass) 활성화/반납 (등록된 계정만, 서비스에서 확인)
+		users.POST("/me/break-glass", breakGlassHandler.ActivateMyBreakGlass, perm.Exempt)
+		users.GET("/me/break-glass", breakGlassHandler.GetMyBreakGlass, perm.Exempt)
+		users.POST("/me/break-glass/end", breakGlassHandler.EndMyBreakGlass, perm.Exempt)
+
+		// 승인 워크플로: 내 요청/취소, 내가 결정할 요청
+		users.GET("/me/workflows", workflowHandler.ListMyWorkflows, perm.Exempt)
+		users.POST("/me/workflows/:requestId/cancel", workflowHandler.CancelMyWorkflow, perm.Exempt)
+		users.GET("/me/workflow-approvals", workflowHandler.ListMyWorkflowApprovals, perm.Exempt)
+
+		users.POST("/menus-tree/list", menuHandler.ListUserMenuTree, perm.Exempt)
+		users.POST("/menus/list", menuHandler.ListUserMenu, perm.Exempt)
+		users.POST("/workspaces/list", userHandler.ListUserWorkspaces, perm.Exempt)
+		users.GET("/workspaces/id/:workspaceId/projects/list", userHandler.ListUserProjectsByWorkspace, wsMember, perm.Exempt)
+		users.POST("/workspaces/roles/list", userHandler.ListUserWorkspaceAndWorkspaceRoles, perm.Exempt)
+
+		users.GET("/id/:userId/workspaces/list", userHandler.GetUserWorkspacesByUserID, perm.Require("mc-iam-manager:user:read"))
+		users.GET("/id/:userId/workspaces/roles/list", userHandler.GetUserWorkspaceAndWorkspaceRolesByUserID, perm.Require("mc-iam-manager:user:read"))
+		users.GET("/id/:userId/workspaces/id/:workspaceId/roles/list", userHandler.GetUserWorkspaceAndWorkspaceRolesByUserIDAndWorkspaceID, perm.Require("mc-iam-manager:user:read"))
 
 		// 내 초대 목록/수락/거절 (RQ-M6-WS-037)
-		users.GET("/me/invitations", workspaceInvitationHandler.ListMyInvitations)
-		users.PUT("/me/invitations/:invitationId/accept", workspaceInvitationHandler.AcceptInvitation)
-		users.PUT("/me/invitations/:invitationId/reject", workspaceInvitationHandler.RejectInvitation)
+		users.GET("/me/invitations", workspaceInvitationHandler.ListMyInvitations, perm.Exempt)
+		users.PUT("/me/invitations/:invitationId/accept", workspaceInvitationHandler.AcceptInvitation, perm.Exempt)
+		users.PUT("/me/invitations/:invitationId/reject", workspaceInvitationHandler.RejectInvitation, perm.Exempt)
 
 	}
 
 	// 초대 관리 라우트 (관리자) (RQ-M6-WS-038)
 	invitations := api.Group("/invitations")
 	{
-		invitations.GET("", workspaceInvitationHandler.ListAllInvitations, middleware.PlatformRoleMiddleware(middleware.Write))
-		invitations.PUT("/:invitationId/approve", workspaceInvitationHandler.ApproveInvitation, middleware.PlatformRoleMiddleware(middleware.Write))
-		invitations.PUT("/:invitationId/reject", workspaceInvitationHandler.RejectInvitationByAdmin, middleware.PlatformRoleMiddleware(middleware.Write))
+		invitations.GET("", workspaceInvitationHandler.ListAllInvitations, perm.Require("mc-iam-manager:invitation:read"))
+		invitations.PUT("/:invitationId/approve", workspaceInvitationHandler.ApproveInvitation, perm.Require("mc-iam-manager:invitation:write"))
+		invitations.PUT("/:invitationId/reject", workspaceInvitationHandler.RejectInvitationByAdmin, perm.Require("mc-iam-manager:invitation:write"))
 	}
 
 	// 메뉴 라우트
 	menusMng := api.Group("/menus")
 	{
-		menusMng.POST("/list", menuHandler.ListMenus)
-		menusMng.POST("/menus-tree/list", menuHandler.ListMenusTree)
-		menusMng.POST("", menuHandler.CreateMenu, middleware.PlatformRoleMiddleware(middleware.Write))
-		menusMng.GET("/id/:menuId", menuHandler.GetMenuByID)
-		menusMng.PUT("/id/:menuId", menuHandler.UpdateMenu, middleware.PlatformAdminMiddleware)
-		menusMng.DELETE("/id/:menuId", menuHandler.DeleteMenu, middleware.PlatformAdminMiddleware)
+		menusMng.POST("/list", menuHandler.ListMenus, perm.Exempt)
+		menusMng.POST("/menus-tree/list", menuHandler.ListMenusTree, perm.Exempt)
+		menusMng.POST("", menuHandler.CreateMenu, perm.Require("mc-iam-manager:menu:write"))
+		menusMng.GET("/id/:menuId", menuHandler.GetMenuByID, perm.Exempt)
+		menusMng.PUT("/id/:menuId", menuHandler.UpdateMenu, perm.Require("mc-iam-manager:menu:manage"))
+		menusMng.DELETE("/id/:menuId", menuHandler.DeleteMenu, perm.Require("mc-iam-manager:menu:manage"))
 
 		//menusMng.POST("/platform-roles/list", menuHandler.ListMenusRolesMapping, middleware.PlatformRoleMiddleware(middleware.Manage))
 		//menusMng.POST("/platform-roles/list", menuHandler.ListMenusRolesMapping)
-		menusMng.POST("/platform-roles/list", menuHandler.ListMenusRolesMapping, middleware.PlatformRoleMiddleware(middleware.Write))
+		menusMng.POST("/platform-roles/list", menuHandler.ListMenusRolesMapping, perm.Require("mc-iam-manager:menu:read"))
 		// menusMng.POST("/platform-roles/list", menuHandler.ListMenusRolesMapping, middleware.PlatformAdminMiddleware)
-		menusMng.POST("/platform-roles", menuHandler.CreateMenusRolesMapping, middleware.PlatformAdminMiddleware)
-		menusMng.DELETE("/platform-roles", menuHandler.DeleteMenusRolesMapping, middleware.PlatformAdminMiddleware)
+		menusMng.POST("/platform-roles", menuHandler.CreateMenusRolesMapping, perm.Require("mc-iam-manager:menu:manage"))
+		menusMng.DELETE("/platform-roles", menuHandler.DeleteMenusRolesMapping, perm.Require("mc-iam-manager:menu:manage"))
 	}
 
 	// 리소스 타입 라우트 ( platformResource=menu,api , cloudResource=vm,nlb,k8s ...)
 	resourceTypes := api.Group("/resource-types")
 	{
-		cloudResource := resourceTypes.Group("/cloud-resources", middleware.PlatformAdminMiddleware)
+		cloudResource := resourceTypes.Group("/cloud-resources", perm.Require("mc-iam-manager:resource-type:manage"))
 		cloudResource.POST("/list", resourceTypeHandler.ListCloudResourceTypes)
 		cloudResource.POST("", resourceTypeHandler.CreateCloudResourceType)
 		cloudResource.GET("/framework/:frameworkId/id/:resourceTypeId", resourceTypeHandler.GetCloudResourceTypeByID)
@@ -434,155 +598,155 @@ func main() {
 	}
 
 	// MC-IAM 권한 라우트
-	mciamPermissions := api.Group("/permissions/mciam", middleware.PlatformRoleMiddleware(middleware.Read))
+	mciamPermissions := api.Group("/permissions/mciam")
 	{
-		mciamPermissions.POST("/list", permissionHandler.ListMciamPermissions)
-		mciamPermissions.POST("", permissionHandler.CreateMciamPermission, middleware.PlatformRoleMiddleware(middleware.Write))
-		mciamPermissions.GET("/id/:permissionId", permissionHandler.GetMciamPermissionByID)
-		mciamPermissions.PUT("/:id", permissionHandler.UpdateMciamPermission, middleware.PlatformRoleMiddleware(middleware.Write))
-		mciamPermissions.DELETE("/:id", permissionHandler.DeleteMciamPermission, middleware.PlatformRoleMiddleware(middleware.Write))
+		mciamPermissions.POST("/list", permissionHandler.ListMciamPermissions, perm.Require("mc-iam-manager:mciam-permission:read"))
+		mciamPermissions.POST("", permissionHandler.CreateMciamPermission, perm.Require("mc-iam-manager:mciam-permission:write"))
+		mciamPermissions.GET("/id/:permissionId", permissionHandler.GetMciamPermissionByID, perm.Require("mc-iam-manager:mciam-permission:read"))
+		mciamPermissions.PUT("/:id", permissionHandler.UpdateMciamPermission, perm.Require("mc-iam-manager:mciam-permission:write"))
+		mciamPermissions.DELETE("/:id", permissionHandler.DeleteMciamPermission, perm.Require("mc-iam-manager:mciam-permission:write"))
 	}
 
 	// 역할별 MC-IAM 권한 관리 라우트
-	roles.POST("/:roleType/:roleId/mciam-permissions/:permissionId", permissionHandler.AssignMciamPermissionToRole, middleware.PlatformRoleMiddleware(middleware.Write))
-	roles.DELETE("/:roleType/:roleId/mciam-permissions/:permissionId", permissionHandler.RemoveMciamPermissionFromRole, middleware.PlatformRoleMiddleware(middleware.Write))
-	roles.GET("/:roleType/:roleId/mciam-permissions", permissionHandler.GetRoleMciamPermissions, middleware.PlatformRoleMiddleware(middleware.Read))
+	roles.POST("/:roleType/:roleId/mciam-permissions/:permissionId", permissionHandler.AssignMciamPermissionToRole, perm.Require("mc-iam-manager:mciam-permission:write"))
+	roles.DELETE("/:roleType/:roleId/mciam-permissions/:permissionId", permissionHandler.RemoveMciamPermissionFromRole, perm.Require("mc-iam-manager:mciam-permission:write"))
+	roles.GET("/:roleType/:roleId/mciam-permissions", permissionHandler.GetRoleMciamPermissions, perm.Require("mc-iam-manager:mciam-permission:read"))
 
 	// MCMP API 라우트
 	mcmpApis := api.Group("/mcmp-apis")
 	{
-		mcmpApis.POST("/list", mcmpApiHandler.ListServicesAndActions, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApis.PUT("/name/:serviceName/versions/:version/activate", mcmpApiHandler.SetActiveVersion, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApis.POST("/call", mcmpApiHandler.McmpApiCall, middleware.PlatformRoleMiddleware(middleware.Read))
-		mcmpApis.GET("/test/mc-infra-manager/getallns", mcmpApiHandler.TestCallGetAllNs, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApis.POST("", mcmpApiHandler.CreateFrameworkService, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApis.PUT("/name/:serviceName", mcmpApiHandler.UpdateFrameworkService, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApis.POST("/import", mcmpApiHandler.ImportAPIs, middleware.PlatformRoleMiddleware(middleware.Manage))
+		mcmpApis.POST("/list", mcmpApiHandler.ListServicesAndActions, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApis.PUT("/name/:serviceName/versions/:version/activate", mcmpApiHandler.SetActiveVersion, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApis.POST("/call", mcmpApiHandler.McmpApiCall, perm.Require("mc-iam-manager:mcmp-api:read"))
+		mcmpApis.GET("/test/mc-infra-manager/getallns", mcmpApiHandler.TestCallGetAllNs, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApis.POST("", mcmpApiHandler.CreateFrameworkService, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApis.PUT("/name/:serviceName", mcmpApiHandler.UpdateFrameworkService, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApis.POST("/import", mcmpApiHandler.ImportAPIs, perm.Require("mc-iam-manager:mcmp-api:manage"))
 	}
 
 	// MCMP API 권한-액션 매핑 라우트
 	mcmpApiPermissionActionMappings := mcmpApis.Group("/permission-action-mappings")
 	{
-		mcmpApiPermissionActionMappings.POST("/list", mcmpApiPermissionActionMappingHandler.ListPlatformActions, middleware.PlatformRoleMiddleware(middleware.Read))
-		mcmpApiPermissionActionMappings.GET("/id/:id", mcmpApiPermissionActionMappingHandler.GetPlatformActionsByPermissionID, middleware.PlatformRoleMiddleware(middleware.Read))
-		mcmpApiPermissionActionMappings.POST("", mcmpApiPermissionActionMappingHandler.CreateMcmpApiPermissionActionMapping, middleware.PlatformRoleMiddleware(middleware.Manage))
-
-		mcmpApiPermissionActionMappings.GET("/actions/list", mcmpApiPermissionActionMappingHandler.ListWorkspaceActionsByPermissionID, middleware.PlatformRoleMiddleware(middleware.Read))
-		mcmpApiPermissionActionMappings.GET("/actions/:actionId/permissions", mcmpApiPermissionActionMappingHandler.ListPermissionsByActionID, middleware.PlatformRoleMiddleware(middleware.Read))
-		mcmpApiPermissionActionMappings.PUT("/permissions/:permissionId/actions/:actionId", mcmpApiPermissionActionMappingHandler.UpdateMapping, middleware.PlatformRoleMiddleware(middleware.Manage))
-		mcmpApiPermissionActionMappings.DELETE("/permissions/:permissionId/actions/:actionId", mcmpApiPermissionActionMappingHandler.DeleteMapping, middleware.PlatformRoleMiddleware(middleware.Manage))
+		mcmpApiPermissionActionMappings.POST("/list", mcmpApiPermissionActionMappingHandler.ListPlatformActions, perm.Require("mc-iam-manager:mcmp-api:read"))
+		mcmpApiPermissionActionMappings.GET("/id/:id", mcmpApiPermissionActionMappingHandler.GetPlatformActionsByPermissionID, perm.Require("mc-iam-manager:mcmp-api:read"))
+		mcmpApiPermissionActionMappings.POST("", mcmpApiPermissionActionMappingHandler.CreateMcmpApiPermissionActionMapping, perm.Require("mc-iam-manager:mcmp-api:manage"))
+
+		mcmpApiPermissionActionMappings.GET("/actions/list", mcmpApiPermissionActionMappingHandler.ListWorkspaceActionsByPermissionID, perm.Require("mc-iam-manager:mcmp-api:read"))
+		mcmpApiPermissionActionMappings.GET("/actions/:actionId/permissions", mcmpApiPermissionActionMappingHandler.ListPermissionsByActionID, perm.Require("mc-iam-manager:mcmp-api:read"))
+		mcmpApiPermissionActionMappings.PUT("/permissions/:permissionId/actions/:actionId", mcmpApiPermissionActionMappingHandler.UpdateMapping, perm.Require("mc-iam-manager:mcmp-api:manage"))
+		mcmpApiPermissionActionMappings.DELETE("/permissions/:permissionId/actions/:actionId", mcmpApiPermissionActionMappingHandler.DeleteMapping, perm.Require("mc-iam-manager:mcmp-api:manage"))
 	}
 
 	// CSP 계정 관리 라우트
-	cspAccounts := api.Group("/csp-accounts", middleware.PlatformRoleMiddleware(middleware.Read))
+	cspAccounts := api.Group("/csp-accounts")
 	{
-		cspAccounts.POST("/list", cspAccountHandler.ListCspAccounts)
-		cspAccounts.POST("", cspAccountHandler.CreateCspAccount, middleware.PlatformAdminMiddleware)
-		cspAccounts.GET("/id/:accountId", cspAccountHandler.GetCspAccountByID)
-		cspAccounts.PUT("/id/:accountId", cspAccountHandler.UpdateCspAccount, middleware.PlatformAdminMiddleware)
-		cspAccounts.DELETE("/id/:accountId", cspAccountHandler.DeleteCspAccount, middleware.PlatformAdminMiddleware)
-		cspAccounts.POST("/id/:accountId/validate", cspAccountHandler.ValidateCspAccount, middleware.PlatformAdminMiddleware)
-		cspAccounts.POST("/id/:accountId/activate", cspAccountHandler.ActivateCspAccount, middleware.PlatformAdminMiddleware)
-		cspAccounts.POST("/id/:accountId/deactivate", cspAccountHandler.DeactivateCspAccount, middleware.PlatformAdminMiddleware)
+		cspAccounts.POST("/list", cspAccountHandler.ListCspAccounts, perm.Require("mc-iam-manager:csp-account:read"))
+		cspAccounts.POST("", cspAccountHandler.CreateCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
+		cspAccounts.GET("/id/:accountId", cspAccountHandler.GetCspAccountByID, perm.Require("mc-iam-manager:csp-account:read"))
+		cspAccounts.PUT("/id/:accountId", cspAccountHandler.UpdateCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
+		cspAccounts.DELETE("/id/:accountId", cspAccountHandler.DeleteCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
+		cspAccounts.POST("/id/:accountId/validate", cspAccountHandler.ValidateCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
+		cspAccounts.POST("/id/:accountId/activate", cspAccountHandler.ActivateCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
+		cspAccounts.POST("/id/:accountId/deactivate", cspAccountHandler.DeactivateCspAccount, perm.Require("mc-iam-manager:csp-account:manage"))
 	}
 
 	// CSP IDP 설정 관리 라우트
-	cspIdpConfigs := api.Group("/csp-idp-configs", middleware.PlatformRoleMiddleware(middleware.Read))
+	cspIdpConfigs := api.Group("/csp-idp-configs")
 	{
-		cspIdpConfigs.POST("/list", cspIdpConfigHandler.ListCspIdpConfigs)
-		cspIdpConfigs.POST("", cspIdpConfigHandler.CreateCspIdpConfig, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.GET("/id/:configId", cspIdpConfigHandler.GetCspIdpConfigByID)
-		cspIdpConfigs.PUT("/id/:configId", cspIdpConfigHandler.UpdateCspIdpConfig, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.DELETE("/id/:configId", cspIdpConfigHandler.DeleteCspIdpConfig, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.POST("/id/:configId/test", cspIdpConfigHandler.TestCspIdpConnection, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.POST("/id/:configId/activate", cspIdpConfigHandler.ActivateCspIdpConfig, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.POST("/id/:configId/deactivate", cspIdpConfigHandler.DeactivateCspIdpConfig, middleware.PlatformAdminMiddleware)
-		cspIdpConfigs.GET("/summary", cspIdpConfigHandler.GetCspIdpSummary)
-		cspIdpConfigs.POST("/health-check", cspIdpConfigHandler.BulkHealthCheck, middleware.PlatformAdminMiddleware)
+		cspIdpConfigs.POST("/list", cspIdpConfigHandler.ListCspIdpConfigs, perm.Require("mc-iam-manager:csp-idp-config:read"))
+		cspIdpConfigs.POST("", cspIdpConfigHandler.CreateCspIdpConfig, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.GET("/id/:configId", cspIdpConfigHandler.GetCspIdpConfigByID, perm.Require("mc-iam-manager:csp-idp-config:read"))
+		cspIdpConfigs.PUT("/id/:configId", cspIdpConfigHandler.UpdateCspIdpConfig, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.DELETE("/id/:configId", cspIdpConfigHandler.DeleteCspIdpConfig, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.POST("/id/:configId/test", cspIdpConfigHandler.TestCspIdpConnection, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.POST("/id/:configId/activate", cspIdpConfigHandler.ActivateCspIdpConfig, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.POST("/id/:configId/deactivate", cspIdpConfigHandler.DeactivateCspIdpConfig, perm.Require("mc-iam-manager:csp-idp-config:manage"))
+		cspIdpConfigs.GET("/summary", cspIdpConfigHandler.GetCspIdpSummary, perm.Require("mc-iam-manager:csp-idp-config:read"))
+		cspIdpConfigs.POST("/health-check", cspIdpConfigHandler.BulkHealthCheck, perm.Require("mc-iam-manager:csp-idp-config:manage"))
 	}
 
 	// 조직 관리 라우트 (admin 이상)
-	organizations := api.Group("/organizations", middleware.PlatformRoleMiddleware(middleware.Write))
+	organizations := api.Group("/organizations")
 	{
-		organizations.POST("", organizationHandler.CreateOrganization)
-		organizations.GET("", organizationHandler.GetOrganizations)
+		organizations.POST("", organizationHandler.CreateOrganization, perm.Require("mc-iam-manager:organization:write"))
+		organizations.GET("", organizationHandler.GetOrganizations, perm.Require("mc-iam-manager:organization:read"))
 		// 트리 조회 (RQ-M2-UG-034)
-		organizations.GET("/tree", organizationHandler.GetOrganizationTree)
-		organizations.GET("/id/:organizationId", organizationHandler.GetOrganizationByID)
-		organizations.GET("/code/:code", organizationHandler.GetOrganizationByCode)
-		organizations.PUT("/id/:organizationId", organizationHandler.UpdateOrganization)
-		organizations.DELETE("/id/:organizationId", organizationHandler.DeleteOrganization)
-		organizations.GET("/id/:organizationId/users", organizationHandler.GetOrganizationUsers)
+		organizations.GET("/tree", organizationHandler.GetOrganizationTree, perm.Require("mc-iam-manager:organization:read"))
+		organizations.GET("/id/:organizationId", organizationHandler.GetOrganizationByID, perm.Require("mc-iam-manager:organization:read"))
+		organizations.GET("/code/:code", organizationHandler.GetOrganizationByCode, perm.Require("mc-iam-manager:organization:read"))
+		organizations.PUT("/id/:organizationId", organizationHandler.UpdateOrganization, perm.Require("mc-iam-manager:organization:write"))
+		organizations.DELETE("/id/:organizationId", organizationHandler.DeleteOrganization, perm.Require("mc-iam-manager:organization:write"))
+		organizations.GET("/id/:organizationId/users", organizationHandler.GetOrganizationUsers, perm.Require("mc-iam-manager:organization:read"))
 		// 하위 트리 조회 (RQ-M2-UG-034)
-		organizations.GET("/id/:organizationId/subtree", organizationHandler.GetOrganizationSubtree)
+		organizations.GET("/id/:organizationId/subtree", organizationHandler.GetOrganizationSubtree, perm.Require("mc-iam-manager:organization:read"))
 		// 조직 이동 (RQ-M2-UG-035)
-		organizations.PUT("/id/:organizationId/move", organizationHandler.MoveOrganization)
+		organizations.PUT("/id/:organizationId/move", organizationHandler.MoveOrganization, perm.Require("mc-iam-manager:organization:write"))
 		// 삭제 가능 여부 확인 (RQ-M2-UG-036)
-		organizations.GET("/id/:organizationId/deletable", organizationHandler.GetOrganizationDeletable)
+		organizations.GET("/id/:organizationId/deletable", organizationHandler.GetOrganizationDeletable, perm.Require("mc-iam-manager:organization:read"))
 	}
 
 	// 사용자-조직 라우트 (admin 이상)
-	users.POST("/id/:userId/organizations", organizationHandler.AssignUserOrganizations, middleware.PlatformRoleMiddleware(middleware.Write))
-	users.GET("/id/:userId/organizations", organizationHandler.GetUserOrganizations, middleware.PlatformRoleMiddleware(middleware.Read))
-	users.DELETE("/id/:userId/organizations/:organizationId", organizationHandler.RemoveUserOrganization, middleware.PlatformRoleMiddleware(middleware.Write))
+	users.POST("/id/:userId/organizations", organizationHandler.AssignUserOrganizations, perm.Require("mc-iam-manager:organization:write"))
+	users.GET("/id/:userId/organizations", organizationHandler.GetUserOrganizations, perm.Require("mc-iam-manager:user:read"))
+	users.DELETE("/id/:userId/organizations/:organizationId", organizationHandler.RemoveUserOrganization, perm.Require("mc-iam-manager:organization:write"))
 
 	// 그룹 관리 라우트 (/api/groups - organizations의 별칭, admin 이상)
 	// 주의: organizationId 파라미터 이름 유지 (기존 핸들러 호환)
-	groups := api.Group("/groups", middleware.PlatformRoleMiddleware(middleware.Write))
+	groups := api.Group("/groups")
 	{
-		groups.POST("", organizationHandler.CreateOrganization)
-		groups.GET("", organizationHandler.GetOrganizations)
-		groups.GET("/id/:organizationId", organizationHandler.GetOrganizationByID)
-		groups.GET("/code/:code", organizationHandler.GetOrganizationByCode)
-		groups.PUT("/id/:organizationId", organizationHandler.UpdateOrganization)
-		groups.DELETE("/id/:organizationId", organizationHandler.DeleteOrganization)
-		groups.GET("/id/:organizationId/users", organizationHandler.GetOrganizationUsers)
+		groups.POST("", organizationHandler.CreateOrganization, perm.Require("mc-iam-manager:organization:write"))
+		groups.GET("", organizationHandler.GetOrganizations, perm.Require("mc-iam-manager:organization:read"))
+		groups.GET("/id/:organizationId", organizationHandler.GetOrganizationByID, perm.Require("mc-iam-manager:organization:read"))
+		groups.GET("/code/:code", organizationHandler.GetOrganizationByCode, perm.Require("mc-iam-manager:organization:read"))
+		groups.PUT("/id/:organizationId", organizationHandler.UpdateOrganization, perm.Require("mc-iam-manager:organization:write"))
+		groups.DELETE("/id/:organizationId", organizationHandler.DeleteOrganization, perm.Require("mc-iam-manager:organization:write"))
+		groups.GET("/id/:organizationId/users", organizationHandler.GetOrganizationUsers, perm.Require("mc-iam-manager:organization:read"))
 		// 그룹 사용자 관리 (그룹 입장, Keycloak 동기화 포함)
-		groups.POST("/id/:groupId/users", groupRoleHandler.AssignGroupUsers)
-		groups.DELETE("/id/:groupId/users/:userId", groupRoleHandler.RemoveGroupUser)
-		groups.DELETE("/id/:groupId/users", groupRoleHandler.RemoveGroupUsers)
+		groups.POST("/id/:groupId/users", groupRoleHandler.AssignGroupUsers, perm.Require("mc-iam-manager:organization:write"))
+		groups.DELETE("/id/:groupId/users/:userId", groupRoleHandler.RemoveGroupUser, perm.Require("mc-iam-manager:organization:write"))
+		groups.DELETE("/id/:groupId/users", groupRoleHandler.RemoveGroupUsers, perm.Require("mc-iam-manager:organization:write"))
 
 		// 그룹 플랫폼 역할 관리 (DB + Keycloak)
-		groups.POST("/id/:groupId/platform-roles", groupRoleHandler.AssignGroupPlatformRole)
-		groups.GET("/id/:groupId/platform-roles", groupRoleHandler.GetGroupPlatformRoles)
-		groups.GET("/id/:groupId/platform-roles/available", groupRoleHandler.GetAvailableGroupPlatformRoles)
-		groups.DELETE("/id/:groupId/platform-roles/:roleId", groupRoleHandler.RemoveGroupPlatformRole)
-
-		// 그룹-워크스페이스 매핑 관리 (DB 전용)
-		groups.POST("/id/:groupId/workspaces", groupRoleHandler.AssignGroupWorkspace)
-		groups.GET("/id/:groupId/workspaces", groupRoleHandler.GetGroupWorkspaces)
-		groups.GET("/id/:groupId/workspaces/available", groupRoleHandler.GetAvailableGroupWorkspaces)
-		groups.PUT("/id/:groupId/workspaces/:workspaceId", groupRoleHandler.UpdateGroupWorkspaceRole)
-		groups.DELETE("/id/:groupId/workspaces/:workspaceId", groupRoleHandler.RemoveGroupWorkspaceRole)
+		groups.POST("/id/:groupId/platform-roles", groupRoleHandler.AssignGroupPlatformRole, perm.Require("mc-iam-manager:organization:write"))
+		groups.GET("/id/:groupId/platform-roles", groupRoleHandler.GetGroupPlatformRoles, perm.Require("mc-iam-manager:organization:read"))
+		groups.GET("/id/:groupId/platform-roles/available", groupRoleHandler.GetAvailableGroupPlatformRoles, perm.Require("mc-iam-manager:organization:read"))
+		groups.DELETE("/id/:groupId/platform-roles/:roleId", groupRoleHandler.RemoveGroupPlatformRole, perm.Require("mc-iam-manager:organization:write"))
+
+		// 그룹-워크스페이스 매핑 관리 (DB 전용, 변경은 서비스에서 organization:write 또는 워크스페이스 관리자 범위를 확인)
+		groups.POST("/id/:groupId/workspaces", groupRoleHandler.AssignGroupWorkspace, perm.Exempt)
+		groups.GET("/id/:groupId/workspaces", groupRoleHandler.GetGroupWorkspaces, perm.Require("mc-iam-manager:organization:read"))
+		groups.GET("/id/:groupId/workspaces/available", groupRoleHandler.GetAvailableGroupWorkspaces, perm.Require("mc-iam-manager:organization:read"))
+		groups.PUT("/id/:groupId/workspaces/:workspaceId", groupRoleHandler.UpdateGroupWorkspaceRole, perm.Exempt)
+		groups.DELETE("/id/:groupId/workspaces/:workspaceId", groupRoleHandler.RemoveGroupWorkspaceRole, perm.Exempt)
 	}
 
 	// 사용자-그룹 라우트 (Keycloak 동기화 포함, platformAdmin 전용)
-	users.POST("/id/:userId/groups", groupRoleHandler.AssignUserGroups, middleware.PlatformAdminMiddleware)
-	users.GET("/id/:userId/groups", organizationHandler.GetUserOrganizations, middleware.PlatformAdminMiddleware)
-	users.PUT("/id/:userId/groups", organizationHandler.ReplaceUserGroups, middleware.PlatformAdminMiddleware)
-	users.DELETE("/id/:userId/groups/:groupId", groupRoleHandler.RemoveUserFromGroup, middleware.PlatformAdminMiddleware)
+	users.POST("/id/:userId/groups", groupRoleHandler.AssignUserGroups, perm.Require("mc-iam-manager:user:manage"))
+	users.GET("/id/:userId/groups", organizationHandler.GetUserOrganizations, perm.Require("mc-iam-manager:user:manage"))
+	users.PUT("/id/:userId/groups", organizationHandler.ReplaceUserGroups, perm.Require("mc-iam-manager:user:manage"))
+	users.DELETE("/id/:userId/groups/:groupId", groupRoleHandler.RemoveUserFromGroup, perm.Require("mc-iam-manager:user:manage"))
 
 	// 사용자 유효 권한 조회 라우트 (그룹 기반 역할 상속 포함, platformAdmin 전용)
-	users.GET("/id/:userId/effective-platform-roles", groupRoleHandler.GetUserEffectivePlatformRoles, middleware.PlatformAdminMiddleware)
-	users.GET("/id/:userId/access-summary", groupRoleHandler.GetUserAccessSummary, middleware.PlatformAdminMiddleware)
+	users.GET("/id/:userId/effective-platform-roles", groupRoleHandler.GetUserEffectivePlatformRoles, perm.Require("mc-iam-manager:user:manage"))
+	users.GET("/id/:userId/access-summary", groupRoleHandler.GetUserAccessSummary, perm.Require("mc-iam-manager:user:manage"))
 
 	// CSP 정책 관리 라우트
-	cspPolicies := api.Group("/csp-policies", middleware.PlatformRoleMiddleware(middleware.Read))
+	cspPolicies := api.Group("/csp-policies")
 	{
-		cspPolicies.POST("/list", cspPolicyHandler.ListCspPolicies)
-		cspPolicies.POST("", cspPolicyHandler.CreateCspPolicy, middleware.PlatformAdminMiddleware)
-		cspPolicies.GET("/id/:policyId", cspPolicyHandler.GetCspPolicyByID)
-		cspPolicies.PUT("/id/:policyId", cspPolicyHandler.UpdateCspPolicy, middleware.PlatformAdminMiddleware)
-		cspPolicies.DELETE("/id/:policyId", cspPolicyHandler.DeleteCspPolicy, middleware.PlatformAdminMiddleware)
-		cspPolicies.GET("/id/:policyId/document", cspPolicyHandler.GetPolicyDocument)
-		cspPolicies.POST("/sync", cspPolicyHandler.SyncCspPolicies, middleware.PlatformAdminMiddleware)
-		cspPolicies.POST("/attach", cspPolicyHandler.AttachPolicyToRole, middleware.PlatformAdminMiddleware)
-		cspPolicies.POST("/detach", cspPolicyHandler.DetachPolicyFromRole, middleware.PlatformAdminMiddleware)
-		cspPolicies.GET("/role/:roleId", cspPolicyHandler.GetRolePolicies)
+		cspPolicies.POST("/list", cspPolicyHandler.ListCspPolicies, perm.Require("mc-iam-manager:csp-policy:read"))
+		cspPolicies.POST("", cspPolicyHandler.CreateCspPolicy, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.GET("/id/:policyId", cspPolicyHandler.GetCspPolicyByID, perm.Require("mc-iam-manager:csp-policy:read"))
+		cspPolicies.PUT("/id/:policyId", cspPolicyHandler.UpdateCspPolicy, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.DELETE("/id/:policyId", cspPolicyHandler.DeleteCspPolicy, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.GET("/id/:policyId/document", cspPolicyHandler.GetPolicyDocument, perm.Require("mc-iam-manager:csp-policy:read"))
+		cspPolicies.POST("/sync", cspPolicyHandler.SyncCspPolicies, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.POST("/attach", cspPolicyHandler.AttachPolicyToRole, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.POST("/detach", cspPolicyHandler.DetachPolicyFromRole, perm.Require("mc-iam-manager:csp-policy:manage"))
+		cspPolicies.GET("/role/:roleId", cspPolicyHandler.GetRolePolicies, perm.Require("mc-iam-manager:csp-policy:read"))
 	}
 
 	// CSP IAM 직접 관리 라우트 (CSP IAM Role CRUD)
-	cspIAM := api.Group("/csp/iam", middleware.PlatformAdminMiddleware)
+	cspIAM := api.Group("/csp/iam", perm.Require("mc-iam-manager:csp-iam:manage"))
 	{
 		cspIAM.POST("/roles", cspIAMHandler.CreateIAMRole)
 		cspIAM.GET("/roles/:roleName", cspIAMHandler.GetIAMRole)
@@ -590,6 +754,105 @@ func main() {
 		cspIAM.DELETE("/roles/:roleName", cspIAMHandler.DeleteIAMRole)
 	}
 
+	// 권한 판정 라우트 (MC-IAM 권한 허용/거부 확인)
+	authz := api.Group("/authz", perm.Require("mc-iam-manager:authz:read"))
+	{
+		authz.POST("/check", authzHandler.CheckPermission)
+		authz.POST("/check/batch", authzHandler.CheckPermissionBatch)
+		authz.POST("/explain", authzHandler.ExplainAccess)    // 권한/메뉴/CSP 역할 접근 경로 설명 (직접/그룹/상속, 허용/차단)
+		authz.POST("/simulate", authzHandler.SimulateChanges) // 역할/그룹 변경 가정 시뮬레이션 (롤백, 저장 안 함)
+		authz.GET("/cache/stats", authzHandler.GetCacheStats) // 유효 역할/권한/메뉴 트리 캐시 적중/미적중 지표
+	}
+
+	// 감사 이벤트 / CSP 임시 자격 증명 발급 원장 조회 라우트
+	audit := api.Group("/audit", perm.Require("mc-iam-manager:audit:read"))
+	{
+		audit.GET("/events", auditHandler.ListAuditEvents)
+		audit.GET("/events/:eventId", auditHandler.GetAuditEvent)
+		audit.GET("/credential-issuances", credentialIssuanceHandler.ListCredentialIssuances)
+		audit.GET("/credential-issuances/aggregates", credentialIssuanceHandler.AggregateCredentialIssuances)
+		audit.GET("/credential-issuances/:issuanceId", credentialIssuanceHandler.GetCredentialIssuance)
+		audit.GET("/verify", auditHandler.VerifyAuditChain, perm.Require("mc-iam-manager:audit:manage")) // 해시 체인/서명 체크포인트 검증
+		audit.GET("/checkpoints", auditHandler.ListAuditCheckpoints)
+		audit.POST("/checkpoints", auditHandler.CreateAuditCheckpoints, perm.Require("mc-iam-manager:audit:manage"))
+		audit.GET("/sinks", auditHandler.ListEventSinks)
+	}
+
+	// 접근 검토(재인증) 캠페인 라우트 (항목 결정은 서비스에서 검토자 범위를 확인)
+	accessReviews := api.Group("/access-reviews")
+	perm.Declare(service.AccessReviewReviewPermission)
+	{
+		accessReviews.POST("", accessReviewHandler.CreateAccessReviewCampaign, perm.Require("mc-iam-manager:access-review:manage"))
+		accessReviews.GET("", accessReviewHandler.ListAccessReviewCampaigns, perm.Require("mc-iam-manager:access-review:manage"))
+		accessReviews.GET("/my-items", accessReviewHandler.ListMyAccessReviewItems, perm.Exempt)
+		accessReviews.POST("/items/:itemId/decision", accessReviewHandler.DecideAccessReviewItem, perm.Exempt)
+		accessReviews.GET("/:campaignId", accessReviewHandler.GetAccessReviewCampaign, perm.Require("mc-iam-manager:access-review:manage"))
+		accessReviews.GET("/:campaignId/items", accessReviewHandler.ListAccessReviewItems, perm.Require("mc-iam-manager:access-review:manage"))
+		accessReviews.POST("/:campaignId/close", accessReviewHandler.CloseAccessReviewCampaign, perm.Require("mc-iam-manager:access-review:manage"))
+		accessReviews.GET("/:campaignId/export", accessReviewHandler.ExportAccessReviewCampaign, perm.Require("mc-iam-manager:access-review:manage"))
+	}
+
+	// 직무 분리(SoD) 제약/예외/위반 보고서 라우트 (제약은 역할/그룹 할당 서비스에서 검사)
+	sod := api.Group("/sod")
+	{
+		sod.GET("/constraints", sodHandler.ListSodConstraints, perm.Require("mc-iam-manager:sod:read"))
+		sod.POST("/constraints", sodHandler.CreateSodConstraint, perm.Require("mc-iam-manager:sod:manage"))
+		sod.GET("/constraints/:constraintId", sodHandler.GetSodConstraint, perm.Require("mc-iam-manager:sod:read"))
+		sod.PUT("/constraints/:constraintId", sodHandler.UpdateSodConstraint, perm.Require("mc-iam-manager:sod:manage"))
+		sod.DELETE("/constraints/:constraintId", sodHandler.DeleteSodConstraint, perm.Require("mc-iam-manager:sod:manage"))
+		sod.GET("/violations", sodHandler.ListSodViolations, perm.Require("mc-iam-manager:sod:read"))
+		sod.GET("/overrides", sodHandler.ListSodOverrides, perm.Require("mc-iam-manager:sod:read"))
+		sod.POST("/overrides", sodHandler.CreateSodOverride, perm.Require("mc-iam-manager:sod:override"))
+		sod.DELETE("/overrides/:overrideId", sodHandler.RevokeSodOverride, perm.Require("mc-iam-manager:sod:override"))
+	}
+
+	// 권한 승격 결정/회수 라우트 (결정은 서비스에서 승인자 범위를 확인)
+	elevations := api.Group("/elevations")
+	perm.Declare(service.ElevationApprovePermission)
+	{
+		elevations.GET("", elevationHandler.ListElevations, perm.Require("mc-iam-manager:elevation:manage"))
+		elevations.GET("/policy", elevationHandler.GetElevationPolicy, perm.Exempt)
+		elevations.POST("/:elevationId/decision", elevationHandler.DecideElevation, perm.Exempt)
+		elevations.POST("/:elevationId/revoke", elevationHandler.RevokeElevation, perm.Require("mc-iam-manager:elevation:manage"))
+	}
+
+	// 비상 접근 계정/세션 관리 라우트
+	breakGlass := api.Group("/break-glass")
+	{
+		breakGlass.GET("/policy", breakGlassHandler.GetBreakGlassPolicy, perm.Exempt)
+		breakGlass.GET("/accounts", breakGlassHandler.ListBreakGlassAccounts, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.POST("/accounts", breakGlassHandler.RegisterBreakGlassAccount, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.DELETE("/accounts/:userId", breakGlassHandler.RemoveBreakGlassAccount, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.GET("/sessions", breakGlassHandler.ListBreakGlassSessions, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.GET("/sessions/:sessionId", breakGlassHandler.GetBreakGlassSession, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.GET("/sessions/:sessionId/activities", breakGlassHandler.ListBreakGlassActivities, perm.Require("mc-iam-manager:break-glass:manage"))
+		breakGlass.POST("/sessions/:sessionId/end", breakGlassHandler.EndBreakGlassSession, perm.Require("mc-iam-manager:break-glass:manage"))
+	}
+
+	// 승인 워크플로 정책/요청 라우트 (조회·결정·의견은 서비스에서 요청자/승인자 범위를 확인)
+	workflows := api.Group("/workflows")
+	perm.Declare(service.WorkflowApprovePermission)
+	{
+		workflows.GET("", workflowHandler.ListWorkflows, perm.Require("mc-iam-manager:workflow:manage"))
+		workflows.GET("/policies", workflowHandler.ListWorkflowPolicies, perm.Require("mc-iam-manager:workflow:manage"))
+		workflows.PUT("/policies/:type", workflowHandler.UpdateWorkflowPolicy, perm.Require("mc-iam-manager:workflow:manage"))
+		workflows.GET("/:requestId", workflowHandler.GetWorkflow, perm.Exempt)
+		workflows.POST("/:requestId/decision", workflowHandler.DecideWorkflow, perm.Exempt)
+		workflows.POST("/:requestId/comments", workflowHandler.AddWorkflowComment, perm.Exempt)
+	}
+
+	// 규정 준수 보고서 다운로드 라우트 (CSV/XLSX)
+	reports := api.Group("/reports", perm.Require("mc-iam-manager:report:read"))
+	{
+		reports.GET("", reportHandler.ListReports)
+		reports.GET("/:reportName", reportHandler.DownloadReport)
+	}
+
+	// 라우트에 선언된 MC-IAM 권한 등록 (역할에 할당할 수 있도록)
+	if err := perm.RegisterPermissions(context.Background()); err != nil {
+		log.Printf("Failed to register route permissions: %v", err)
+	}
+
 	// Swagger 문서 라우트
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
@@ -614,6 +877,10 @@ func main() {
 	if err := e.Shutdown(ctx); err != nil {
 		e.Logger.Fatal(err)
 	}
+	// 대기 중인 보안 이벤트 전달
+	if err := eventsink.Shutdown(ctx); err != nil {
+		log.Printf("Security event sinks did not drain before shutdown: %v", err)
+	}
 }
 
 // CustomValidator 커스텀 validator 구조체
@@ -0,0 +1,109 @@
+package main
+
+// main_routes_test.go
+//
+// /api 하위 라우트의 권한 선언 확인 (main.go 정적 분석)
+// 라우트 또는 상위 그룹에 perm.Require(...), perm.Exempt, middleware.PlatformAdminMiddleware 중 하나가 있어야 한다.
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var routeMethods = map[string]bool{"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true, "Any": true}
+
+// routeGroup main.go 의 라우트 그룹 변수 (api 하위 여부와 그룹 단위 권한 선언)
+type routeGroup struct {
+	underAPI bool
+	declared bool
+}
+
+// declaresPermission 라우트/그룹 미들웨어 인자 중 권한 선언이 있는지 확인
+func declaresPermission(args []ast.Expr) bool {
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case *ast.CallExpr:
+			if sel, ok := v.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, "perm") && sel.Sel.Name == "Require" {
+				return true
+			}
+		case *ast.SelectorExpr:
+			if isIdent(v.X, "perm") && v.Sel.Name == "Exempt" {
+				return true
+			}
+			if isIdent(v.X, "middleware") && v.Sel.Name == "PlatformAdminMiddleware" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// TC-ROUTE-PERM-01: /api 하위 라우트는 모두 권한(또는 면제)을 선언
+func TestAPIRoutesDeclarePermission(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", nil, 0)
+	require.NoError(t, err)
+
+	groups := map[string]routeGroup{"api": {underAPI: true}}
+	var undeclared []string
+	routes := 0
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			// x := parent.Group("/path", middlewares...)
+			if len(v.Lhs) != 1 || len(v.Rhs) != 1 {
+				return true
+			}
+			lhs, ok := v.Lhs[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			call, ok := v.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+				return true
+			}
+			parent, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if pg, ok := groups[parent.Name]; ok {
+				groups[lhs.Name] = routeGroup{underAPI: pg.underAPI, declared: pg.declared || declaresPermission(call.Args[1:])}
+			}
+		case *ast.CallExpr:
+			sel, ok := v.Fun.(*ast.SelectorExpr)
+			if !ok || !routeMethods[sel.Sel.Name] || len(v.Args) < 2 {
+				return true
+			}
+			recv, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			g, ok := groups[recv.Name]
+			if !ok || !g.underAPI {
+				return true
+			}
+			routes++
+			if !g.declared && !declaresPermission(v.Args[2:]) {
+				undeclared = append(undeclared, fset.Position(v.Pos()).String())
+			}
+		}
+		return true
+	})
+
+	require.NotZero(t, routes, "no /api routes found in main.go")
+	require.Empty(t, undeclared, "routes without perm.Require / perm.Exempt")
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// ActivityMiddleware는 인증된 요청의 토큰 사용 시각을 휴면 계정 정책용으로 기록합니다.
+// 사용자별로 일정 간격에 한 번만 DB 에 기록하며, 기록 실패는 요청 결과에 영향을 주지 않습니다.
+func ActivityMiddleware(dormancyService *service.DormancyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if kcUserID, ok := c.Get("kcUserId").(string); ok && kcUserID != "" {
+				dormancyService.RecordTokenUse(kcUserID)
+			}
+			return next(c)
+		}
+	}
+}
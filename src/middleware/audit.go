@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// 감사 대상에서 제외하는 라우트 (인증/권한 판정은 변경 요청이 아님)
+var auditSkipRoutePrefixes = []string{"/api/auth/", "/api/authz/", "/api/audit/"}
+
+// 실패 응답 본문에서 오류 메시지를 찾기 위해 보관하는 최대 크기
+const auditCaptureLimit = 4096
+
+// AuditMiddleware는 변경 요청(POST/PUT/PATCH/DELETE)마다 감사 이벤트를 기록합니다.
+// 행위자(kcUserId, 플랫폼 역할), 라우트, 결과, 요청 IP 를 남기고,
+// 핸들러가 model.AuditDetailKey 로 남긴 상세(동작, 대상, 전후 스냅샷)가 있으면 함께 저장합니다.
+// 감사 기록 실패는 요청 결과에 영향을 주지 않습니다.
+func AuditMiddleware(auditService *service.AuditService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !auditableRequest(c) {
+				return next(c)
+			}
+
+			capture := &auditResponseCapture{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = capture
+			err := next(c)
+			c.Response().Writer = capture.ResponseWriter
+
+			event := &model.AuditEvent{
+				Method:    c.Request().Method,
+				Route:     c.Path(),
+				Path:      c.Request().URL.Path,
+				SourceIP:  c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			}
+			event.StatusCode, event.ErrorMessage = requestOutcome(c, err, capture)
+
+			event.ActorKcID, _ = c.Get("kcUserId").(string)
+			event.ActorPlatformRoles, _ = c.Get("platformRoles").([]string)
+			if claims, ok := c.Get("token_claims").(*jwt.MapClaims); ok && claims != nil {
+				event.ActorUsername, _ = (*claims)["preferred_username"].(string)
+			}
+			if workspaceID, ok := c.Get("workspace_id").(uint); ok && workspaceID != 0 {
+				event.WorkspaceID = &workspaceID
+			}
+
+			detail, _ := c.Get(model.AuditDetailKey).(*model.AuditDetail)
+			if recordErr := auditService.Record(event, detail); recordErr != nil {
+				log.Printf("[ERROR] failed to record audit event for %s %s: %v", event.Method, event.Route, recordErr)
+			}
+			return err
+		}
+	}
+}
+
+// auditableRequest 감사 대상 요청 여부 (변경 메서드, 조회용 /list 및 제외 라우트는 기록하지 않음)
+func auditableRequest(c echo.Context) bool {
+	switch c.Request().Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+	route := c.Path()
+	if route == "" || strings.HasSuffix(route, "/list") {
+		return false
+	}
+	for _, prefix := range auditSkipRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestOutcome 핸들러 결과의 응답 상태와 실패 시 오류 메시지
+func requestOutcome(c echo.Context, err error, capture *auditResponseCapture) (int, string) {
+	if err != nil {
+		// 오류 응답은 아직 쓰이지 않았으므로 echo 오류 처리 규칙대로 상태를 정한다
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return he.Code, fmt.Sprint(he.Message)
+		}
+		return http.StatusInternalServerError, err.Error()
+	}
+	status := c.Response().Status
+	if status >= http.StatusBadRequest {
+		return status, capture.errorMessage()
+	}
+	return status, ""
+}
+
+// auditResponseCapture 실패 응답의 오류 메시지를 얻기 위해 응답 본문 앞부분을 보관
+type auditResponseCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseCapture) Write(b []byte) (int, error) {
+	if remain := auditCaptureLimit - w.body.Len(); remain > 0 {
+		if len(b) < remain {
+			remain = len(b)
+		}
+		w.body.Write(b[:remain])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush http.Flusher 위임
+func (w *auditResponseCapture) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap http.ResponseController 가 원래 writer 에 접근할 수 있도록 반환
+func (w *auditResponseCapture) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// errorMessage 응답 본문의 error/message 필드 (JSON 이 아니면 본문 그대로)
+func (w *auditResponseCapture) errorMessage() string {
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.body.Bytes(), &body); err == nil {
+		for _, key := range []string{"error", "message"} {
+			if msg, ok := body[key].(string); ok && msg != "" {
+				return msg
+			}
+		}
+	}
+	return strings.TrimSpace(w.body.String())
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// AuthzConditionMiddleware는 조건부 권한 매핑 평가에 쓰이는 요청 속성(IP, 시각, 프로젝트)을 요청 context 에 저장합니다.
+// 토큰(또는 비상 접근 세션)에 platformAdmin 이 있으면 요청자를 함께 저장해 서비스의 권한 확인도 통과하게 합니다.
+// IP 는 c.RealIP() 로, echo 의 IPExtractor(NewIPExtractor) 설정에 따라 신뢰하는 프록시의 X-Forwarded-For 만 반영합니다.
+func AuthzConditionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cc := model.AuthzConditionContext{
+			SourceIP: c.RealIP(),
+			At:       time.Now(),
+		}
+		if id, err := strconv.ParseUint(c.Param("projectId"), 10, 32); err == nil {
+			cc.ProjectID = uint(id)
+		}
+		ctx := service.WithAuthzConditionContext(c.Request().Context(), cc)
+		if kcUserID, _ := c.Get("kcUserId").(string); kcUserID != "" && isPlatformAdmin(c) {
+			ctx = service.WithPlatformAdminSubject(ctx, kcUserID)
+		}
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+// NewIPExtractor 요청 IP 추출기 생성 (조건부 권한, 감사, 비상 접근 기록의 요청 IP)
+// trustedProxies(쉼표 구분 CIDR)가 비어 있으면 접속 주소만 사용하고 X-Forwarded-For / X-Real-IP 는 무시합니다.
+// 지정하면 그 대역에서 온 X-Forwarded-For 만 신뢰합니다 (기본 신뢰 대역인 loopback·사설망도 지정해야 신뢰).
+func NewIPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	var options []echo.TrustOption
+	for _, cidr := range strings.Split(trustedProxies, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy CIDR %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	if len(options) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options = append(options, echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false))
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
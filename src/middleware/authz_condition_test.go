@@ -0,0 +1,42 @@
+package middleware
+
+// authz_condition_test.go
+//
+// NewIPExtractor 단위 테스트
+// 신뢰하는 프록시에서 온 요청만 X-Forwarded-For 를 요청 IP 로 사용하는지 검증한다.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forwardedRequest(remoteAddr string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set("X-Real-IP", "203.0.113.8")
+	return req
+}
+
+// TC-ACM-01: 신뢰 프록시가 없으면 클라이언트가 보낸 헤더를 무시하고 접속 주소 사용
+func TestNewIPExtractor_IgnoresForwardedHeadersByDefault(t *testing.T) {
+	extract, err := NewIPExtractor("")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", extract(forwardedRequest("10.0.0.5:4000")))
+}
+
+// TC-ACM-02: 신뢰 프록시에서 온 요청만 X-Forwarded-For 를 반영, 잘못된 CIDR 은 오류
+func TestNewIPExtractor_TrustedProxies(t *testing.T) {
+	extract, err := NewIPExtractor("172.18.0.0/16, 192.0.2.10/32")
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", extract(forwardedRequest("172.18.0.3:4000")))
+	assert.Equal(t, "10.0.0.5", extract(forwardedRequest("10.0.0.5:4000")), "private network is not trusted unless configured")
+	assert.Equal(t, "127.0.0.1", extract(forwardedRequest("127.0.0.1:4000")))
+
+	_, err = NewIPExtractor("172.18.0.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// BreakGlassSessionKey 유효한 비상 접근 세션 ID 를 담는 echo 컨텍스트 키
+const BreakGlassSessionKey = "breakGlassSessionId"
+
+// BreakGlassMiddleware는 비상 접근(break-glass) 세션을 요청에 반영합니다.
+// 유효한 세션이 있으면 토큰 갱신 전이라도 platformRoles 에 platformAdmin 을 더하고,
+// 조회를 포함한 모든 요청을 일반 감사 이벤트와 별도의 해시 체인에 기록합니다.
+// 토큰 발급 후 세션이 종료되었으면 그 토큰에 실린 platformAdmin 을 제거해 만료 즉시 권한이 사라지게 합니다.
+// 활동 기록 실패는 요청 결과에 영향을 주지 않습니다.
+func BreakGlassMiddleware(breakGlassService *service.BreakGlassService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			kcUserID, ok := c.Get("kcUserId").(string)
+			if !ok || kcUserID == "" {
+				return next(c)
+			}
+			claims, _ := c.Get("token_claims").(*jwt.MapClaims)
+			if claims == nil {
+				return next(c)
+			}
+			issuedAt, err := claims.GetIssuedAt()
+			if err != nil || issuedAt == nil {
+				return next(c)
+			}
+
+			session, err := breakGlassService.SessionForToken(kcUserID, issuedAt.Time)
+			if err != nil {
+				log.Printf("[BREAK_GLASS] failed to look up session of %s: %v", kcUserID, err)
+				return next(c)
+			}
+			if session == nil {
+				return next(c)
+			}
+			if session.Status != model.BreakGlassActive || !session.ExpiresAt.After(time.Now()) {
+				c.Set("platformRoles", withoutRole(platformRoles(c), model.BreakGlassRoleName))
+				return next(c)
+			}
+
+			c.Set("platformRoles", withRole(platformRoles(c), model.BreakGlassRoleName))
+			c.Set(BreakGlassSessionKey, session.ID)
+
+			capture := &auditResponseCapture{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = capture
+			err = next(c)
+			c.Response().Writer = capture.ResponseWriter
+
+			activity := &model.BreakGlassActivity{
+				SessionID:     session.ID,
+				ActorKcID:     kcUserID,
+				ActorUsername: session.Username,
+				Method:        c.Request().Method,
+				Route:         c.Path(),
+				Path:          c.Request().URL.Path,
+				Query:         c.Request().URL.RawQuery,
+				SourceIP:      c.RealIP(),
+				UserAgent:     c.Request().UserAgent(),
+			}
+			activity.StatusCode, activity.ErrorMessage = requestOutcome(c, err, capture)
+			if recordErr := breakGlassService.RecordActivity(activity); recordErr != nil {
+				log.Printf("[BREAK_GLASS] failed to record activity of session %d for %s %s: %v",
+					session.ID, activity.Method, activity.Path, recordErr)
+			}
+			return err
+		}
+	}
+}
+
+func platformRoles(c echo.Context) []string {
+	roles, _ := c.Get("platformRoles").([]string)
+	return roles
+}
+
+func withRole(roles []string, role string) []string {
+	for _, r := range roles {
+		if r == role {
+			return roles
+		}
+	}
+	return append(append([]string{}, roles...), role)
+}
+
+func withoutRole(roles []string, role string) []string {
+	filtered := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r != role {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
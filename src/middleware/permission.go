@@ -2,13 +2,18 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
 )
 
 // PermissionLevel은 권한 레벨을 정의합니다.
@@ -40,51 +45,59 @@ func PlatformAdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-// PlatformRoleMiddleware는 플랫폼 역할 기반의 권한 체크를 수행하는 미들웨어입니다.
-func PlatformRoleMiddleware(level PermissionLevel) echo.MiddlewareFunc {
+// PermissionAuthorizer는 라우트별로 선언된 MC-IAM 권한을 검사하는 미들웨어를 생성합니다.
+// 호출자의 유효 권한(직접 할당 + 그룹 상속 플랫폼 역할)은 DB에서 조회합니다.
+type PermissionAuthorizer struct {
+	authzService      *service.AuthzService
+	permissionService *service.MciamPermissionService
+
+	mu       sync.Mutex
+	required map[string]struct{}
+}
+
+// NewPermissionAuthorizer는 PermissionAuthorizer를 생성합니다.
+func NewPermissionAuthorizer(db *gorm.DB) *PermissionAuthorizer {
+	return &PermissionAuthorizer{
+		authzService:      service.NewAuthzService(db),
+		permissionService: service.NewMciamPermissionService(db),
+		required:          make(map[string]struct{}),
+	}
+}
+
+// Require는 permissionID(<framework>:<resourceType>:<action>) 권한이 있어야 통과하는 미들웨어를 반환합니다.
+// platformAdmin 은 초기 설정용 관리자이므로 항상 통과합니다.
+func (a *PermissionAuthorizer) Require(permissionID string) echo.MiddlewareFunc {
+	a.mu.Lock()
+	a.required[permissionID] = struct{}{}
+	a.mu.Unlock()
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// 플랫폼 역할 가져오기
-			platformRoles, ok := c.Get("platformRoles").([]string)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "플랫폼 역할을 가져올 수 없습니다")
+			if isPlatformAdmin(c) {
+				return next(c)
 			}
 
-			// platformAdmin 역할 확인
-			for _, role := range platformRoles {
-				if role == "platformAdmin" {
-					return next(c)
-				}
+			kcUserId, ok := c.Get("kcUserId").(string)
+			if !ok || kcUserId == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "사용자 정보를 가져올 수 없습니다")
 			}
 
-			// 플랫폼 역할 로그 출력
-			fmt.Printf("Platform Roles: %v, Required Level: %s\n", platformRoles, level)
-
-			hasPermission := false
-
-			// read 레벨은 모든 사용자에게 허용
-			if level == Read {
-				hasPermission = true
-			} else if level == Write {
-				// write 레벨은 admin 또는 platformAdmin 역할이 필요
-				for _, role := range platformRoles {
-					if role == "admin" || role == "platformAdmin" {
-						hasPermission = true
-						break
-					}
-				}
-			} else if level == Manage {
-				// manage 레벨은 platformAdmin 역할만 가능
-				for _, role := range platformRoles {
-					if role == "platformAdmin" {
-						hasPermission = true
-						break
-					}
+			ctx := c.Request().Context()
+			user, err := a.authzService.ResolveSubject(ctx, "", kcUserId)
+			if err != nil {
+				if errors.Is(err, service.ErrUserNotFound) {
+					return echo.NewHTTPError(http.StatusForbidden, "등록되지 않은 사용자입니다")
 				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "사용자 조회에 실패했습니다")
 			}
 
-			if !hasPermission {
-				return echo.NewHTTPError(http.StatusForbidden, "권한이 부족합니다")
+			allowed, err := a.authzService.HasPermission(ctx, user.ID, 0, permissionID)
+			if err != nil {
+				log.Printf("권한 확인 실패 (user=%d, permission=%s): %v", user.ID, permissionID, err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "권한 확인에 실패했습니다")
+			}
+			if !allowed {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("권한이 부족합니다: %s", permissionID))
 			}
 
 			return next(c)
@@ -92,6 +105,41 @@ func PlatformRoleMiddleware(level PermissionLevel) echo.MiddlewareFunc {
 	}
 }
 
+// Declare는 라우트 미들웨어가 아닌 서비스에서 확인하는 권한을 등록 대상에 추가합니다.
+func (a *PermissionAuthorizer) Declare(permissionIDs ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range permissionIDs {
+		a.required[id] = struct{}{}
+	}
+}
+
+// Exempt는 라우트에 MC-IAM 권한을 요구하지 않음을 명시하는 미들웨어입니다 (검사 없이 통과).
+// 공개 라우트, 본인(/me) 라우트, 인증된 사용자 누구나 쓰는 조회 라우트, 워크스페이스 구성원 확인이나
+// 서비스에서 권한 범위를 확인하는 라우트에만 사용합니다.
+// /api 하위 라우트는 Require 또는 Exempt 중 하나를 선언해야 합니다 (main_routes_test.go 에서 확인).
+func (a *PermissionAuthorizer) Exempt(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+// RequiredPermissions는 Require/Declare로 선언된 권한 ID 목록을 반환합니다.
+func (a *PermissionAuthorizer) RequiredPermissions() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ids := make([]string, 0, len(a.required))
+	for id := range a.required {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RegisterPermissions는 라우트에 선언된 권한을 mcmp_mciam_permissions에 등록합니다 (이미 있으면 skip).
+// 역할에 권한을 할당하려면 권한이 먼저 등록되어 있어야 합니다.
+func (a *PermissionAuthorizer) RegisterPermissions(ctx context.Context) error {
+	return a.permissionService.RegisterMcIamPermissions(ctx, a.RequiredPermissions())
+}
+
 // isPlatformAdmin는 사용자가 플랫폼 관리자인지 확인합니다.
 func isPlatformAdmin(c echo.Context) bool {
 	platformRoles, ok := c.Get("platformRoles").([]string)
@@ -1,99 +1,147 @@
 package middleware
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/service"
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+// crossWorkspacePermission 구성원이 아닌 워크스페이스에 접근할 수 있는 플랫폼 권한 (플랫폼 워크스페이스 관리자)
+const crossWorkspacePermission = "mc-iam-manager:workspace:write"
+
 // WorkspaceRoleMiddleware 워크스페이스 역할 기반 접근 제어 미들웨어
+// 호출자가 대상 워크스페이스의 구성원(직접 할당 또는 그룹 상속 역할 보유)인지 확인하고,
+// 워크스페이스 역할을 context에 저장한다.
+//   - workspace_id    : uint
+//   - workspace_roles : []model.AuthzRoleGrant (구성원이 아닌 관리자 접근 시 빈 목록)
+//   - workspace_role  : *model.AuthzRoleGrant (대표 역할, 직접 할당 우선. 구성원이 아니면 nil)
+//
+// 워크스페이스 ID는 path(:workspaceId, :id, :wsId), path(:workspaceName), 요청 본문(workspaceId, workspace_id) 순으로 찾는다.
+// 구성원 확인만 수행하며 Keycloak 워크스페이스 티켓은 발행하지 않는다 (POST /api/workspaces/workspace-ticket 에서 발행).
 func WorkspaceRoleMiddleware(db *gorm.DB) echo.MiddlewareFunc {
+	authzService := service.NewAuthzService(db)
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// 1. Context에서 kcUserId를 추출
 			kcUserId, ok := c.Get("kcUserId").(string)
-			if !ok {
+			if !ok || kcUserId == "" {
 				return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 			}
 
-			// 2. Path에서 workspaceId를 추출
-			workspaceIDStr := c.Param("workspaceId")
-			workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+			// 2. 요청에서 workspaceId를 추출
+			workspaceID, err := resolveWorkspaceID(c, db)
 			if err != nil {
-				log.Printf("workspace_role_middleware: invalid workspace ID: %v", workspaceIDStr)
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return echo.NewHTTPError(http.StatusNotFound, "workspace not found")
+				}
+				log.Printf("workspace_role_middleware: %v", err)
 				return echo.NewHTTPError(http.StatusBadRequest, "workspace_role_middleware: invalid workspace ID")
 			}
 
-			// 3. DB에서 워크스페이스 티켓 정보 조회
-			var workspaceTicket model.WorkspaceTicket
-			err = db.Table("mcmp_workspace_tickets").Where("kc_user_id = ? AND workspace_id = ?", kcUserId, workspaceID).First(&workspaceTicket).Error
-			if err != nil && err != gorm.ErrRecordNotFound {
-				return echo.NewHTTPError(http.StatusInternalServerError, "failed to check workspace ticket")
+			// 3. 워크스페이스 구성원 여부 확인 (직접 할당 + 그룹 상속)
+			ctx := c.Request().Context()
+			user, err := authzService.ResolveSubject(ctx, "", kcUserId)
+			if err != nil {
+				if errors.Is(err, service.ErrUserNotFound) {
+					return echo.NewHTTPError(http.StatusForbidden, "등록되지 않은 사용자입니다")
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "사용자 조회에 실패했습니다")
 			}
 
-			// 4. 티켓 발행 또는 갱신
-			keycloakService := service.NewKeycloakService()
-			var ticket string
-			var permissions map[string]interface{}
-
-			if err == gorm.ErrRecordNotFound {
-				// 티켓이 없는 경우 새로 발행
-				ticket, permissions, err = keycloakService.IssueWorkspaceTicket(c.Request().Context(), kcUserId, uint(workspaceID))
-				if err != nil {
-					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to issue workspace ticket: %v", err))
-				}
-			} else {
-				// 티켓이 있는 경우 유효기간 체크
-				if time.Until(workspaceTicket.ExpiresAt) <= time.Minute {
-					// 1분 이내로 남은 경우 갱신
-					ticket, permissions, err = keycloakService.IssueWorkspaceTicket(c.Request().Context(), kcUserId, uint(workspaceID))
+			grants, err := authzService.GetWorkspaceRoleGrants(ctx, user.ID, workspaceID)
+			if err != nil {
+				log.Printf("workspace_role_middleware: failed to find workspace roles (user=%d, workspace=%d): %v", user.ID, workspaceID, err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "워크스페이스 역할 조회에 실패했습니다")
+			}
+			if len(grants) == 0 {
+				allowed := isPlatformAdmin(c)
+				if !allowed {
+					allowed, err = authzService.HasPermission(ctx, user.ID, 0, crossWorkspacePermission)
 					if err != nil {
-						return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to refresh workspace ticket: %v", err))
-					}
-				} else {
-					// 유효한 티켓이 있는 경우 기존 정보 사용
-					ticket = workspaceTicket.Ticket
-					if err := json.Unmarshal(workspaceTicket.Permissions, &permissions); err != nil {
-						return echo.NewHTTPError(http.StatusInternalServerError, "failed to parse permissions")
+						log.Printf("workspace_role_middleware: permission check failed (user=%d): %v", user.ID, err)
+						return echo.NewHTTPError(http.StatusInternalServerError, "권한 확인에 실패했습니다")
 					}
 				}
+				if !allowed {
+					return echo.NewHTTPError(http.StatusForbidden, "워크스페이스 구성원이 아닙니다")
+				}
 			}
 
-			// 5. 티켓 정보 저장 또는 갱신
-			expiresAt := time.Now().Add(30 * time.Minute) // 30분 유효기간
-			permissionsJSON, err := json.Marshal(permissions)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "failed to marshal permissions")
-			}
-
-			workspaceTicket = model.WorkspaceTicket{
-				KcUserID:    kcUserId,
-				WorkspaceID: uint(workspaceID),
-				Ticket:      ticket,
-				Permissions: datatypes.JSON(permissionsJSON),
-				ExpiresAt:   expiresAt,
-				LastUsedAt:  time.Now(),
+			// 4. Context에 정보 저장
+			if grants == nil {
+				grants = []model.AuthzRoleGrant{}
 			}
+			c.Set("workspace_id", workspaceID)
+			c.Set("workspace_roles", grants)
+			c.Set("workspace_role", service.PrimaryRoleGrant(grants))
 
-			if err := db.Table("mcmp_workspace_tickets").Save(&workspaceTicket).Error; err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "failed to save workspace ticket")
-			}
+			return next(c)
+		}
+	}
+}
 
-			// 6. Context에 정보 저장
-			c.Set("workspace_id", uint(workspaceID))
-			c.Set("workspace_ticket", ticket)
-			c.Set("workspace_permissions", permissions)
+// resolveWorkspaceID 요청에서 대상 워크스페이스 ID를 찾는다.
+// 본문에서 읽은 경우 핸들러가 다시 Bind 할 수 있도록 본문을 복원한다.
+func resolveWorkspaceID(c echo.Context, db *gorm.DB) (uint, error) {
+	for _, name := range []string{"workspaceId", "id", "wsId"} {
+		if v := c.Param(name); v != "" {
+			return parseWorkspaceID(v)
+		}
+	}
 
-			return next(c)
+	if name := c.Param("workspaceName"); name != "" {
+		var workspace model.Workspace
+		if err := db.Select("id").Where("name = ?", name).First(&workspace).Error; err != nil {
+			return 0, err
 		}
+		return workspace.ID, nil
+	}
+
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return 0, fmt.Errorf("workspace ID not found in request")
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		WorkspaceID      json.RawMessage `json:"workspaceId"`
+		WorkspaceIDSnake json.RawMessage `json:"workspace_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return 0, fmt.Errorf("invalid request body: %w", err)
+	}
+	raw := payload.WorkspaceID
+	if len(raw) == 0 {
+		raw = payload.WorkspaceIDSnake
+	}
+	if len(raw) == 0 {
+		return 0, fmt.Errorf("workspace ID not found in request")
+	}
+	// 문자열("1")과 숫자(1) 모두 허용
+	return parseWorkspaceID(strings.Trim(string(raw), `"`))
+}
+
+// parseWorkspaceID 워크스페이스 ID 문자열을 uint로 변환
+func parseWorkspaceID(v string) (uint, error) {
+	id, err := strconv.ParseUint(v, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid workspace ID: %q", v)
 	}
+	return uint(id), nil
 }
@@ -0,0 +1,147 @@
+package middleware
+
+// workspace_role_middleware_test.go
+//
+// WorkspaceRoleMiddleware 단위 테스트 (SQLite in-memory DB)
+// 구성원·비구성원·platformAdmin·요청 본문 workspaceId 에 대해 접근 허용 여부와 context 값을 검증한다.
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type wsMiddlewareFixture struct {
+	db        *gorm.DB
+	workspace *model.Workspace
+	member    *model.User
+	outsider  *model.User
+}
+
+func setupWorkspaceMiddlewareTest(t *testing.T) *wsMiddlewareFixture {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.Organization{},
+		&model.UserOrganization{},
+		&model.User{},
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.Workspace{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
+		&model.GroupPlatformRole{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+	))
+
+	f := &wsMiddlewareFixture{
+		db:        db,
+		workspace: &model.Workspace{Name: "wsm-ws"},
+		member:    &model.User{Username: "wsm-member", KcId: "kc-wsm-member"},
+		outsider:  &model.User{Username: "wsm-outsider", KcId: "kc-wsm-outsider"},
+	}
+	require.NoError(t, db.Create(f.workspace).Error)
+	require.NoError(t, db.Create(f.member).Error)
+	require.NoError(t, db.Create(f.outsider).Error)
+	role := &model.RoleMaster{Name: "wsm-viewer"}
+	require.NoError(t, db.Create(role).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.member.ID, WorkspaceID: f.workspace.ID, RoleID: role.ID}).Error)
+	return f
+}
+
+// serveWorkspaceRequest 인증 정보를 context 에 넣고 WorkspaceRoleMiddleware 를 거쳐 요청을 처리
+func serveWorkspaceRequest(t *testing.T, f *wsMiddlewareFixture, kcUserID string, platformRoles []string, req *http.Request, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	auth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("kcUserId", kcUserID)
+			c.Set("platformRoles", platformRoles)
+			return next(c)
+		}
+	}
+	e.GET("/workspaces/id/:workspaceId", handler, auth, WorkspaceRoleMiddleware(f.db))
+	e.POST("/workspaces/temporary-credentials", handler, auth, WorkspaceRoleMiddleware(f.db))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func workspacePath(f *wsMiddlewareFixture) string {
+	return "/workspaces/id/" + strconv.FormatUint(uint64(f.workspace.ID), 10)
+}
+
+// TC-WSM-01: 구성원은 통과하고 워크스페이스 역할이 context 에 저장됨
+func TestWorkspaceRoleMiddleware_MemberAllowed(t *testing.T) {
+	f := setupWorkspaceMiddlewareTest(t)
+	var roles []model.AuthzRoleGrant
+	rec := serveWorkspaceRequest(t, f, f.member.KcId, nil, httptest.NewRequest(http.MethodGet, workspacePath(f), nil), func(c echo.Context) error {
+		roles, _ = c.Get("workspace_roles").([]model.AuthzRoleGrant)
+		assert.Equal(t, f.workspace.ID, c.Get("workspace_id"))
+		return c.NoContent(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "wsm-viewer", roles[0].RoleName)
+}
+
+// TC-WSM-02: 구성원이 아니고 플랫폼 권한도 없으면 403
+func TestWorkspaceRoleMiddleware_NonMemberForbidden(t *testing.T) {
+	f := setupWorkspaceMiddlewareTest(t)
+	rec := serveWorkspaceRequest(t, f, f.outsider.KcId, []string{"viewer"}, httptest.NewRequest(http.MethodGet, workspacePath(f), nil), func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+// TC-WSM-03: platformAdmin 은 구성원이 아니어도 통과 (워크스페이스 역할은 빈 목록)
+func TestWorkspaceRoleMiddleware_PlatformAdminAllowed(t *testing.T) {
+	f := setupWorkspaceMiddlewareTest(t)
+	var roles []model.AuthzRoleGrant
+	rec := serveWorkspaceRequest(t, f, f.outsider.KcId, []string{"platformAdmin"}, httptest.NewRequest(http.MethodGet, workspacePath(f), nil), func(c echo.Context) error {
+		roles, _ = c.Get("workspace_roles").([]model.AuthzRoleGrant)
+		return c.NoContent(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, roles)
+	assert.Empty(t, roles)
+}
+
+// TC-WSM-04: 요청 본문의 workspaceId 로 구성원 확인, 핸들러는 본문을 다시 읽을 수 있음
+func TestWorkspaceRoleMiddleware_BodyWorkspaceID(t *testing.T) {
+	f := setupWorkspaceMiddlewareTest(t)
+	body := `{"workspaceId":"` + strconv.FormatUint(uint64(f.workspace.ID), 10) + `","cspType":"aws"}`
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/workspaces/temporary-credentials", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return req
+	}
+
+	var received string
+	rec := serveWorkspaceRequest(t, f, f.member.KcId, nil, newRequest(), func(c echo.Context) error {
+		data, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		received = string(data)
+		return c.NoContent(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, received)
+
+	rec = serveWorkspaceRequest(t, f, f.outsider.KcId, nil, newRequest(), func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// 접근 검토 캠페인 상태
+const (
+	AccessReviewCampaignOpen   = "open"
+	AccessReviewCampaignClosed = "closed"
+)
+
+// 검토되지 않은 항목의 캠페인 종료 시 처리
+const (
+	AccessReviewUnreviewedRevoke   = "revoke"   // 역할 회수
+	AccessReviewUnreviewedEscalate = "escalate" // 상위 검토자(캠페인 관리자)에게 넘김
+)
+
+// 접근 검토 대상 할당 유형
+const (
+	AccessReviewUserPlatformRole   = "user-platform-role"
+	AccessReviewUserWorkspaceRole  = "user-workspace-role"
+	AccessReviewGroupWorkspaceRole = "group-workspace-role"
+)
+
+// AccessReviewAssignmentTypes 검토할 수 있는 할당 유형
+var AccessReviewAssignmentTypes = []string{AccessReviewUserPlatformRole, AccessReviewUserWorkspaceRole, AccessReviewGroupWorkspaceRole}
+
+// 접근 검토 항목 상태
+const (
+	AccessReviewItemPending   = "pending"
+	AccessReviewItemCertified = "certified"
+	AccessReviewItemRevoked   = "revoked"
+	AccessReviewItemEscalated = "escalated" // 캠페인 종료 후 캠페인 관리자가 결정
+)
+
+// 접근 검토 결정
+const (
+	AccessReviewDecisionCertify = "certify"
+	AccessReviewDecisionRevoke  = "revoke"
+)
+
+// AccessReviewCampaign 접근 검토(재인증) 캠페인 (DB 테이블: mcmp_access_review_campaigns)
+// 범위는 워크스페이스/역할/조직(직접 소속)이며, 지정한 조건을 모두 만족하는 할당이 검토 대상이다 (비어 있으면 제한 없음).
+// 대상 할당은 캠페인 생성 시점에 항목으로 고정된다.
+type AccessReviewCampaign struct {
+	ID                uint                        `json:"id" gorm:"primaryKey;column:id"`
+	Name              string                      `json:"name" gorm:"column:name;size:255;not null"`
+	Description       string                      `json:"description,omitempty" gorm:"column:description;type:text"`
+	Status            string                      `json:"status" gorm:"column:status;size:20;not null;index"`
+	WorkspaceIDs      datatypes.JSONSlice[uint]   `json:"workspaceIds" gorm:"column:workspace_ids;type:jsonb"`
+	RoleIDs           datatypes.JSONSlice[uint]   `json:"roleIds" gorm:"column:role_ids;type:jsonb"`
+	OrganizationIDs   datatypes.JSONSlice[uint]   `json:"organizationIds" gorm:"column:organization_ids;type:jsonb"`
+	AssignmentTypes   datatypes.JSONSlice[string] `json:"assignmentTypes" gorm:"column:assignment_types;type:jsonb"`
+	UnreviewedAction  string                      `json:"unreviewedAction" gorm:"column:unreviewed_action;size:20;not null"`
+	DueAt             *time.Time                  `json:"dueAt,omitempty" gorm:"column:due_at;index"` // 지나면 자동 종료
+	CreatedByKcID     string                      `json:"createdByKcId" gorm:"column:created_by_kc_id;size:255"`
+	CreatedByUsername string                      `json:"createdByUsername,omitempty" gorm:"column:created_by_username;size:255"`
+	CreatedAt         time.Time                   `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	ClosedAt          *time.Time                  `json:"closedAt,omitempty" gorm:"column:closed_at"`
+	ClosedByKcID      string                      `json:"closedByKcId,omitempty" gorm:"column:closed_by_kc_id;size:255"` // 자동 종료면 비어 있음
+	UpdatedAt         time.Time                   `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	Summary           *AccessReviewSummary        `json:"summary,omitempty" gorm:"-"`
+}
+
+// TableName AccessReviewCampaign의 테이블 이름 지정
+func (AccessReviewCampaign) TableName() string {
+	return "mcmp_access_review_campaigns"
+}
+
+// AccessReviewItem 캠페인의 검토 항목 (DB 테이블: mcmp_access_review_items)
+// 할당 정보(이름 포함)는 캠페인 생성 시점 값으로 보관하여 종료 후에도 증적으로 남긴다.
+type AccessReviewItem struct {
+	ID                uint       `json:"id" gorm:"primaryKey;column:id"`
+	CampaignID        uint       `json:"campaignId" gorm:"column:campaign_id;not null;index"`
+	AssignmentType    string     `json:"assignmentType" gorm:"column:assignment_type;size:30;not null"`
+	UserID            *uint      `json:"userId,omitempty" gorm:"column:user_id;index"`
+	Username          string     `json:"username,omitempty" gorm:"column:username;size:255"`
+	GroupID           *uint      `json:"groupId,omitempty" gorm:"column:group_id"`
+	GroupName         string     `json:"groupName,omitempty" gorm:"column:group_name;size:255"`
+	WorkspaceID       *uint      `json:"workspaceId,omitempty" gorm:"column:workspace_id;index"`
+	WorkspaceName     string     `json:"workspaceName,omitempty" gorm:"column:workspace_name;size:255"`
+	RoleID            uint       `json:"roleId" gorm:"column:role_id;not null"`
+	RoleName          string     `json:"roleName" gorm:"column:role_name;size:255"`
+	OrganizationID    *uint      `json:"organizationId,omitempty" gorm:"column:organization_id;index"` // 조직 범위로 포함된 경우 검토 조직
+	AssignedAt        *time.Time `json:"assignedAt,omitempty" gorm:"column:assigned_at"`
+	Status            string     `json:"status" gorm:"column:status;size:20;not null;index"`
+	DecidedByKcID     string     `json:"decidedByKcId,omitempty" gorm:"column:decided_by_kc_id;size:255"` // 자동 처리면 비어 있음
+	DecidedByUsername string     `json:"decidedByUsername,omitempty" gorm:"column:decided_by_username;size:255"`
+	DecidedAt         *time.Time `json:"decidedAt,omitempty" gorm:"column:decided_at"`
+	Comment           string     `json:"comment,omitempty" gorm:"column:comment;type:text"`
+	RevocationError   string     `json:"revocationError,omitempty" gorm:"column:revocation_error;type:text"` // 회수 실패 사유 (재시도 가능)
+}
+
+// TableName AccessReviewItem의 테이블 이름 지정
+func (AccessReviewItem) TableName() string {
+	return "mcmp_access_review_items"
+}
+
+// CreateAccessReviewCampaignRequest 캠페인 생성 요청
+type CreateAccessReviewCampaignRequest struct {
+	Name             string     `json:"name" validate:"required,max=255"`
+	Description      string     `json:"description"`
+	WorkspaceIDs     []uint     `json:"workspaceIds"`
+	RoleIDs          []uint     `json:"roleIds"`
+	OrganizationIDs  []uint     `json:"organizationIds"`
+	AssignmentTypes  []string   `json:"assignmentTypes"`  // 비어 있으면 전체 유형
+	UnreviewedAction string     `json:"unreviewedAction"` // revoke, escalate (기본 escalate)
+	DueAt            *time.Time `json:"dueAt"`
+}
+
+// AccessReviewDecisionRequest 항목 검토 결정 요청
+type AccessReviewDecisionRequest struct {
+	Decision string `json:"decision" validate:"required"` // certify, revoke
+	Comment  string `json:"comment"`
+}
+
+// AccessReviewSummary 캠페인 항목 상태별 건수
+type AccessReviewSummary struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Certified int `json:"certified"`
+	Revoked   int `json:"revoked"`
+	Escalated int `json:"escalated"`
+}
+
+// AccessReviewItemFilter 검토 항목 조회 조건
+type AccessReviewItemFilter struct {
+	Status      string `query:"status"`
+	WorkspaceID uint   `query:"workspaceId"`
+}
+
+// AccessReviewCampaignDetail 캠페인과 항목 (증적 내보내기 JSON 형식)
+type AccessReviewCampaignDetail struct {
+	Campaign   AccessReviewCampaign `json:"campaign"`
+	Items      []AccessReviewItem   `json:"items"`
+	ExportedAt time.Time            `json:"exportedAt,omitempty"`
+}
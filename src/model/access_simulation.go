@@ -0,0 +1,65 @@
+package model
+
+// 가정(what-if) 시뮬레이션 변경 유형
+const (
+	SimulationAssignPlatformRole       = "assignPlatformRole"       // userId, roleId
+	SimulationRemovePlatformRole       = "removePlatformRole"       // userId, roleId
+	SimulationAssignWorkspaceRole      = "assignWorkspaceRole"      // userId, workspaceId, roleId
+	SimulationRemoveWorkspaceRole      = "removeWorkspaceRole"      // userId, workspaceId, roleId
+	SimulationAddUserToGroup           = "addUserToGroup"           // userId, groupId
+	SimulationRemoveUserFromGroup      = "removeUserFromGroup"      // userId, groupId
+	SimulationMoveUserGroup            = "moveUserGroup"            // userId, fromGroupId, groupId
+	SimulationAssignGroupPlatformRole  = "assignGroupPlatformRole"  // groupId, roleId
+	SimulationRemoveGroupPlatformRole  = "removeGroupPlatformRole"  // groupId, roleId
+	SimulationAssignGroupWorkspaceRole = "assignGroupWorkspaceRole" // groupId, workspaceId, roleId (이미 있으면 역할 변경)
+	SimulationRemoveGroupWorkspaceRole = "removeGroupWorkspaceRole" // groupId, workspaceId
+)
+
+// SimulationChange 시뮬레이션할 역할/그룹 변경 한 건
+type SimulationChange struct {
+	Action      string `json:"action" validate:"required"`
+	UserID      uint   `json:"userId,omitempty"`
+	GroupID     uint   `json:"groupId,omitempty"`     // 대상 그룹(조직), moveUserGroup 에서는 이동할 그룹
+	FromGroupID uint   `json:"fromGroupId,omitempty"` // moveUserGroup 에서 기존 그룹
+	WorkspaceID uint   `json:"workspaceId,omitempty"`
+	RoleID      uint   `json:"roleId,omitempty"`
+}
+
+// SimulationRequest 가정 시뮬레이션 요청
+// 변경은 순서대로 적용되며 결과는 저장되지 않는다.
+// 영향받는 사용자는 변경 대상 사용자와 변경되는 그룹의 구성원이며, userIds 로 추가 지정할 수 있다.
+type SimulationRequest struct {
+	UserIDs []uint             `json:"userIds,omitempty"`
+	Changes []SimulationChange `json:"changes" validate:"required,min=1"`
+}
+
+// SimulationDiff 변경 전후 차이 (정렬된 항목)
+type SimulationDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// IsEmpty 차이가 없는지 여부
+func (d SimulationDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// SimulationUserResult 사용자별 유효 접근 권한 차이
+// 워크스페이스 역할은 "역할@워크스페이스ID", 워크스페이스 권한은 "권한@워크스페이스ID",
+// CSP 역할은 "cspType:역할명@워크스페이스ID" 형식이다.
+type SimulationUserResult struct {
+	UserID         uint           `json:"userId"`
+	Username       string         `json:"username"`
+	Changed        bool           `json:"changed"`
+	PlatformRoles  SimulationDiff `json:"platformRoles"`
+	WorkspaceRoles SimulationDiff `json:"workspaceRoles"`
+	Menus          SimulationDiff `json:"menus"`
+	Permissions    SimulationDiff `json:"permissions"`
+	CspRoles       SimulationDiff `json:"cspRoles"`
+}
+
+// SimulationResponse 가정 시뮬레이션 결과
+type SimulationResponse struct {
+	Changes []SimulationChange     `json:"changes"`
+	Users   []SimulationUserResult `json:"users"`
+}
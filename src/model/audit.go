@@ -0,0 +1,190 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AuditDetailKey 핸들러가 감사 이벤트 상세(*AuditDetail)를 남기는 echo context 키
+const AuditDetailKey = "audit_detail"
+
+// 감사 이벤트 결과
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailure = "failure"
+)
+
+// 감사 대상 엔티티 유형
+const (
+	AuditEntityUser              = "user"
+	AuditEntityGroup             = "group"
+	AuditEntityWorkspace         = "workspace"
+	AuditEntityRolePermissions   = "role-permissions"
+	AuditEntityCspIdpConfig      = "csp-idp-config"
+	AuditEntityInvitation        = "workspace-invitation"
+	AuditEntityAccessReview      = "access-review"
+	AuditEntitySodConstraint     = "sod-constraint"
+	AuditEntitySodOverride       = "sod-override"
+	AuditEntityElevation         = "elevation-request"
+	AuditEntityWorkflow          = "workflow-request"
+	AuditEntityWorkflowPolicy    = "workflow-policy"
+	AuditEntityBreakGlass        = "break-glass-session"
+	AuditEntityBreakGlassAccount = "break-glass-account"
+	AuditEntityBulkAssignment    = "bulk-assignment"
+	AuditEntityRole              = "role"
+	AuditEntityCspRole           = "csp-role"
+	AuditEntityMenu              = "menu"
+)
+
+// 감사 이벤트 동작 (핸들러가 남기는 의미 단위 이벤트)
+const (
+	AuditActionPlatformRoleAssign      = "role.platform.assign"
+	AuditActionPlatformRoleRemove      = "role.platform.remove"
+	AuditActionWorkspaceRoleAssign     = "role.workspace.assign"
+	AuditActionWorkspaceRoleRemove     = "role.workspace.remove"
+	AuditActionRolePermissionsRestore  = "role.permissions.restore"
+	AuditActionRoleClone               = "role.clone"
+	AuditActionRoleTemplateInstantiate = "role.template.instantiate"
+	AuditActionGroupPlatformRoleAssign = "group.platform-role.assign"
+	AuditActionGroupPlatformRoleRemove = "group.platform-role.remove"
+	AuditActionWorkspaceDelete         = "workspace.delete"
+	AuditActionCspIdpConfigCreate      = "csp-idp-config.create"
+	AuditActionCspIdpConfigUpdate      = "csp-idp-config.update"
+	AuditActionCspIdpConfigDelete      = "csp-idp-config.delete"
+	AuditActionCspIdpConfigActivate    = "csp-idp-config.activate"
+	AuditActionCspIdpConfigDeactivate  = "csp-idp-config.deactivate"
+	AuditActionInvitationApprove       = "workspace-invitation.approve"
+	AuditActionWithdrawalRequest       = "user.withdrawal.request"
+	AuditActionWithdrawalProcess       = "user.withdrawal.process"
+	AuditActionAccessReviewCreate      = "access-review.create"
+	AuditActionAccessReviewDecide      = "access-review.decide"
+	AuditActionAccessReviewClose       = "access-review.close"
+	AuditActionSodConstraintCreate     = "sod.constraint.create"
+	AuditActionSodConstraintUpdate     = "sod.constraint.update"
+	AuditActionSodConstraintDelete     = "sod.constraint.delete"
+	AuditActionSodOverrideCreate       = "sod.override.create"
+	AuditActionSodOverrideRevoke       = "sod.override.revoke"
+	AuditActionDormancyWarn            = "user.dormancy.warn"
+	AuditActionDormancyDeactivate      = "user.dormancy.deactivate"
+	AuditActionDormancyExemption       = "user.dormancy.exemption"
+	AuditActionPlatformRoleActivate    = "role.platform.activate"
+	AuditActionPlatformRoleExpire      = "role.platform.expire"
+	AuditActionWorkspaceRoleActivate   = "role.workspace.activate"
+	AuditActionWorkspaceRoleExpire     = "role.workspace.expire"
+	AuditActionGroupPlatformActivate   = "group.platform-role.activate"
+	AuditActionGroupPlatformExpire     = "group.platform-role.expire"
+	AuditActionGroupWorkspaceActivate  = "group.workspace-role.activate"
+	AuditActionGroupWorkspaceExpire    = "group.workspace-role.expire"
+	AuditActionElevationRequest        = "elevation.request"
+	AuditActionElevationApprove        = "elevation.approve"
+	AuditActionElevationDeny           = "elevation.deny"
+	AuditActionElevationCancel         = "elevation.cancel"
+	AuditActionElevationRevoke         = "elevation.revoke"
+	AuditActionElevationExpire         = "elevation.expire"
+	AuditActionWorkflowSubmit          = "workflow.submit"
+	AuditActionWorkflowApprove         = "workflow.approve"
+	AuditActionWorkflowReject          = "workflow.reject"
+	AuditActionWorkflowCancel          = "workflow.cancel"
+	AuditActionWorkflowComment         = "workflow.comment"
+	AuditActionWorkflowEscalate        = "workflow.escalate"
+	AuditActionWorkflowExpire          = "workflow.expire"
+	AuditActionWorkflowPolicyUpdate    = "workflow.policy.update"
+	AuditActionBreakGlassRegister      = "break-glass.account.register"
+	AuditActionBreakGlassUnregister    = "break-glass.account.remove"
+	AuditActionBreakGlassActivate      = "break-glass.activate"
+	AuditActionBreakGlassEnd           = "break-glass.end"
+	AuditActionBreakGlassExpire        = "break-glass.expire"
+	AuditActionBulkAssign              = "role.bulk.assign"
+	AuditActionRoleCreate              = "role.create"
+	AuditActionRoleUpdate              = "role.update"
+	AuditActionRoleDelete              = "role.delete"
+	AuditActionRoleMenuMap             = "role.menu.map"
+	AuditActionRoleMenuUnmap           = "role.menu.unmap"
+	AuditActionRolePermissionAssign    = "role.permission.assign"
+	AuditActionRolePermissionRemove    = "role.permission.remove"
+	AuditActionRoleCspMappingAdd       = "role.csp-mapping.add"
+	AuditActionRoleCspMappingRemove    = "role.csp-mapping.remove"
+	AuditActionCspRoleDelete           = "csp-role.delete"
+	AuditActionCspPolicyAttach         = "csp-role.policy.attach"
+	AuditActionCspPolicyDetach         = "csp-role.policy.detach"
+	AuditActionGroupDelete             = "group.delete"
+	AuditActionGroupMemberAdd          = "group.member.add"
+	AuditActionGroupMemberRemove       = "group.member.remove"
+	AuditActionGroupWorkspaceAssign    = "group.workspace-role.assign"
+	AuditActionGroupWorkspaceUpdate    = "group.workspace-role.update"
+	AuditActionGroupWorkspaceRemove    = "group.workspace-role.remove"
+	AuditActionUserGroupAssign         = "user.group.assign"
+	AuditActionUserGroupReplace        = "user.group.replace"
+	AuditActionUserGroupRemove         = "user.group.remove"
+	AuditActionWorkspaceMemberAdd      = "workspace.member.add"
+	AuditActionWorkspaceMemberRemove   = "workspace.member.remove"
+	AuditActionUserStatusUpdate        = "user.status.update"
+	AuditActionUserActivate            = "user.activate"
+	AuditActionUserDeactivate          = "user.deactivate"
+	AuditActionUserDelete              = "user.delete"
+	AuditActionMenuCreate              = "menu.create"
+)
+
+// AuditEvent 변경 요청 감사 이벤트 (DB 테이블: mcmp_audit_events)
+// 핸들러가 상세를 남기지 않은 요청은 Action 이 "<METHOD> <route>" 로 기록된다.
+// Hash 는 ID/해시를 제외한 JSON 과 PrevHash 로 계산하므로, 필드를 추가할 때는 omitempty 로 두어 기존 기록의 해시가 유지되게 한다.
+type AuditEvent struct {
+	ID                 uint                        `json:"id" gorm:"primaryKey;column:id"`
+	OccurredAt         time.Time                   `json:"occurredAt" gorm:"column:occurred_at;not null;index"`
+	ActorKcID          string                      `json:"actorKcId" gorm:"column:actor_kc_id;size:255;index"`
+	ActorUsername      string                      `json:"actorUsername" gorm:"column:actor_username;size:255"`
+	ActorPlatformRoles datatypes.JSONSlice[string] `json:"actorPlatformRoles" gorm:"column:actor_platform_roles;type:jsonb"`
+	Method             string                      `json:"method" gorm:"column:method;size:10;not null"`
+	Route              string                      `json:"route" gorm:"column:route;size:255;not null"`
+	Path               string                      `json:"path" gorm:"column:path;size:1024"`
+	Action             string                      `json:"action" gorm:"column:action;size:255;not null;index"`
+	EntityType         string                      `json:"entityType,omitempty" gorm:"column:entity_type;size:100;index"`
+	EntityID           string                      `json:"entityId,omitempty" gorm:"column:entity_id;size:255"`
+	WorkspaceID        *uint                       `json:"workspaceId,omitempty" gorm:"column:workspace_id;index"`
+	Before             datatypes.JSON              `json:"before,omitempty" gorm:"column:before;type:jsonb"`
+	After              datatypes.JSON              `json:"after,omitempty" gorm:"column:after;type:jsonb"`
+	Result             string                      `json:"result" gorm:"column:result;size:20;not null"`
+	StatusCode         int                         `json:"statusCode" gorm:"column:status_code"`
+	ErrorMessage       string                      `json:"errorMessage,omitempty" gorm:"column:error_message;type:text"`
+	SourceIP           string                      `json:"sourceIp" gorm:"column:source_ip;size:64"`
+	UserAgent          string                      `json:"userAgent,omitempty" gorm:"column:user_agent;size:512"`
+	PrevHash           string                      `json:"prevHash" gorm:"column:prev_hash;size:64"` // 직전 이벤트 해시 (해시 체인)
+	Hash               string                      `json:"hash" gorm:"column:hash;size:64;index"`
+}
+
+// TableName AuditEvent의 테이블 이름 지정
+func (AuditEvent) TableName() string {
+	return "mcmp_audit_events"
+}
+
+// AuditDetail 핸들러가 남기는 의미 단위 감사 정보 (감사 미들웨어가 요청 결과와 함께 저장)
+// Before/After 는 JSON 으로 저장되며 비밀값으로 보이는 필드는 가려진다.
+type AuditDetail struct {
+	Action      string
+	EntityType  string
+	EntityID    string
+	WorkspaceID *uint
+	Before      interface{}
+	After       interface{}
+}
+
+// AuditEventFilter 감사 이벤트 조회 조건 (from/to 는 RFC3339)
+type AuditEventFilter struct {
+	Actor       string `query:"actor"` // Keycloak 사용자 ID 또는 사용자명
+	EntityType  string `query:"entityType"`
+	EntityID    string `query:"entityId"`
+	Action      string `query:"action"`
+	Result      string `query:"result"`
+	WorkspaceID uint   `query:"workspaceId"`
+	From        string `query:"from"`
+	To          string `query:"to"`
+	Limit       int    `query:"limit"`  // 기본 100, 최대 1000
+	Offset      int    `query:"offset"` // 최신순 정렬 기준
+}
+
+// AuditEventListResponse 감사 이벤트 조회 결과
+type AuditEventListResponse struct {
+	Total  int64        `json:"total"`
+	Events []AuditEvent `json:"events"`
+}
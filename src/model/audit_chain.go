@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// 해시 체인 이름 (체인마다 독립적으로 연결되고 검증됨)
+const (
+	AuditChainEvents              = "audit_events"
+	AuditChainCredentialIssuances = "credential_issuances"
+	AuditChainBreakGlass          = "break_glass_activities"
+)
+
+// AuditChains 검증/체크포인트 대상 체인 목록
+var AuditChains = []string{AuditChainEvents, AuditChainCredentialIssuances, AuditChainBreakGlass}
+
+// AuditCheckpoint 해시 체인 서명 체크포인트 (DB 테이블: mcmp_audit_checkpoints)
+// 특정 시점의 마지막 기록 ID/해시와 기록 수를 로컬 서명 키(Ed25519)로 서명해 두어,
+// 체인 전체를 다시 계산하는 변조나 마지막 기록 삭제를 검증 시 찾아낼 수 있게 한다.
+type AuditCheckpoint struct {
+	ID           uint      `json:"id" gorm:"primaryKey;column:id"`
+	Chain        string    `json:"chain" gorm:"column:chain;size:50;not null;index"`
+	LastRecordID uint      `json:"lastRecordId" gorm:"column:last_record_id;not null"`
+	LastHash     string    `json:"lastHash" gorm:"column:last_hash;size:64;not null"`
+	RecordCount  int64     `json:"recordCount" gorm:"column:record_count;not null"` // 체인에 포함된 기록 수 (LastRecordID 까지)
+	CreatedAt    time.Time `json:"createdAt" gorm:"column:created_at;not null"`
+	Algorithm    string    `json:"algorithm" gorm:"column:algorithm;size:20;not null"`
+	KeyID        string    `json:"keyId" gorm:"column:key_id;size:64;not null"` // 공개 키 SHA-256 지문 앞 16자리
+	Signature    string    `json:"signature" gorm:"column:signature;type:text;not null"`
+}
+
+// TableName AuditCheckpoint의 테이블 이름 지정
+func (AuditCheckpoint) TableName() string {
+	return "mcmp_audit_checkpoints"
+}
+
+// AuditChainBreak 체인에서 처음 발견된 끊어진 연결
+type AuditChainBreak struct {
+	RecordID uint   `json:"recordId"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// AuditCheckpointCheck 체크포인트 검증 결과
+type AuditCheckpointCheck struct {
+	CheckpointID      uint   `json:"checkpointId"`
+	LastRecordID      uint   `json:"lastRecordId"`
+	Valid             bool   `json:"valid"`
+	SignatureVerified bool   `json:"signatureVerified"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// AuditChainVerification 체인 하나의 검증 결과
+type AuditChainVerification struct {
+	Chain        string                 `json:"chain"`
+	Valid        bool                   `json:"valid"`
+	Checked      int64                  `json:"checked"`   // 해시를 검증한 기록 수
+	Unchained    int64                  `json:"unchained"` // 해시 체인 도입 전 기록 수 (검증 대상 아님)
+	LastRecordID uint                   `json:"lastRecordId,omitempty"`
+	LastHash     string                 `json:"lastHash,omitempty"`
+	BrokenLink   *AuditChainBreak       `json:"brokenLink,omitempty"`
+	Checkpoints  []AuditCheckpointCheck `json:"checkpoints"`
+}
+
+// AuditVerifyResponse 감사 기록 무결성 검증 결과
+type AuditVerifyResponse struct {
+	Valid      bool                     `json:"valid"`
+	VerifiedAt time.Time                `json:"verifiedAt"`
+	Chains     []AuditChainVerification `json:"chains"`
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+)
+
+// AuthzRoleGrant 사용자에게 부여된 유효 역할 (직접 할당 또는 그룹 상속)
+type AuthzRoleGrant struct {
+	RoleID      uint                  `json:"roleId"`
+	RoleName    string                `json:"roleName"`
+	RoleType    constants.IAMRoleType `json:"roleType"`
+	WorkspaceID uint                  `json:"workspaceId,omitempty"`
+	GroupID     uint                  `json:"groupId,omitempty"`
+	GroupName   string                `json:"groupName,omitempty"`
+	Source      string                `json:"source"` // "direct" | "group:{name}" | "token" (요청 토큰의 platformAdmin)
+
+	// 권한이 상위 역할(ParentID)에서 상속된 경우 실제 매핑을 가진 역할
+	InheritedFromRoleID   uint   `json:"inheritedFromRoleId,omitempty"`
+	InheritedFromRoleName string `json:"inheritedFromRoleName,omitempty"`
+
+	// 조건부 매핑인 경우 조건별 평가 결과
+	Conditions []AuthzConditionResult `json:"conditions,omitempty" gorm:"-"`
+}
+
+// AuthzCheckRequest 권한 확인 요청
+// 주체는 userId 또는 kcUserId 중 하나로 지정한다.
+type AuthzCheckRequest struct {
+	UserID      string   `json:"userId,omitempty"`                      // 사용자 ID (문자열로 받음)
+	KcUserID    string   `json:"kcUserId,omitempty"`                    // Keycloak 사용자 ID
+	WorkspaceID string   `json:"workspaceId,omitempty"`                 // 워크스페이스 ID (선택)
+	Permissions []string `json:"permissions" validate:"required,min=1"` // <framework>:<resourceType>:<action>
+
+	// 조건부 매핑 평가 속성 (선택, 미지정 시 호출 요청의 IP/현재 시각)
+	SourceIP  string `json:"sourceIp,omitempty"`
+	At        string `json:"at,omitempty"`        // RFC3339
+	ProjectID string `json:"projectId,omitempty"` // 프로젝트 라벨 조건 평가용
+}
+
+// AuthzPermissionDecision 권한 하나에 대한 판정 결과
+// 거부(deny) 매핑이 하나라도 있으면 허용 경로가 있어도 allowed 는 false 이다.
+type AuthzPermissionDecision struct {
+	Permission string           `json:"permission"`
+	Allowed    bool             `json:"allowed"`
+	GrantedBy  []AuthzRoleGrant `json:"grantedBy,omitempty"`
+	DeniedBy   []AuthzRoleGrant `json:"deniedBy,omitempty"`
+	// 조건을 만족하지 못해 적용되지 않은 허용/거부 매핑
+	ConditionUnmet []AuthzRoleGrant `json:"conditionUnmet,omitempty"`
+	// platformAdmin 보유로 허용 (라우트 권한 검사와 같이 역할 매핑·거부와 관계없이 모든 권한 허용)
+	PlatformAdmin bool `json:"platformAdmin,omitempty"`
+}
+
+// AuthzCheckResponse 권한 확인 응답
+// allowed 는 요청한 모든 권한이 허용된 경우에만 true 이다.
+type AuthzCheckResponse struct {
+	UserID      uint                      `json:"userId"`
+	KcUserID    string                    `json:"kcUserId"`
+	WorkspaceID string                    `json:"workspaceId,omitempty"`
+	Allowed     bool                      `json:"allowed"`
+	Decisions   []AuthzPermissionDecision `json:"decisions"`
+}
+
+// AuthzBatchCheckRequest 권한 일괄 확인 요청
+type AuthzBatchCheckRequest struct {
+	Checks []AuthzCheckRequest `json:"checks" validate:"required,min=1"`
+}
+
+// AuthzBatchCheckResult 일괄 확인의 개별 결과 (실패 시 error 에 사유 기록)
+type AuthzBatchCheckResult struct {
+	*AuthzCheckResponse
+	Error string `json:"error,omitempty"`
+}
+
+// AuthzBatchCheckResponse 권한 일괄 확인 응답
+type AuthzBatchCheckResponse struct {
+	Results []AuthzBatchCheckResult `json:"results"`
+}
+
+// AuthzExplainRequest 접근 경로 설명 요청 ("이 사용자가 왜 X 를 할 수 있는가/없는가")
+// 주체는 userId 또는 kcUserId, 대상은 permission, menuId, cspRoleId 중 하나로 지정한다.
+type AuthzExplainRequest struct {
+	UserID      string `json:"userId,omitempty"`
+	KcUserID    string `json:"kcUserId,omitempty"`
+	WorkspaceID string `json:"workspaceId,omitempty"` // 워크스페이스 ID (cspRoleId 대상이면 필수)
+	Permission  string `json:"permission,omitempty"`  // <framework>:<resourceType>:<action>
+	MenuID      string `json:"menuId,omitempty"`      // 메뉴 ID
+	CspRoleID   string `json:"cspRoleId,omitempty"`   // CSP 역할 ID
+
+	// 조건부 매핑 평가 속성 (선택, 미지정 시 호출 요청의 IP/현재 시각)
+	SourceIP  string `json:"sourceIp,omitempty"`
+	At        string `json:"at,omitempty"` // RFC3339
+	ProjectID string `json:"projectId,omitempty"`
+}
+
+// 접근 경로 설명 대상 종류
+const (
+	AuthzExplainTargetPermission = "permission"
+	AuthzExplainTargetMenu       = "menu"
+	AuthzExplainTargetCspRole    = "cspRole"
+)
+
+// AuthzAccessPath 대상에 대한 허용/차단 경로 하나
+// 역할 부여 경로(직접/그룹/상속)와 그 역할이 대상에 닿는 방식(via)을 함께 기록한다.
+type AuthzAccessPath struct {
+	AuthzRoleGrant
+	GroupPath string `json:"groupPath,omitempty"` // 그룹 경로인 경우 조직 트리 위치 (예: /본부/개발팀)
+	Via       string `json:"via,omitempty"`       // permission | platformAdmin | menu | childMenu | menuDeny | cspRoleMapping | cspRoleShadowed
+	Detail    string `json:"detail,omitempty"`
+}
+
+// AuthzExplainResponse 접근 경로 설명 응답
+// allowed 는 허용 경로가 있고 차단 경로가 없을 때 true 이다.
+type AuthzExplainResponse struct {
+	UserID         uint              `json:"userId"`
+	KcUserID       string            `json:"kcUserId"`
+	Username       string            `json:"username"`
+	WorkspaceID    string            `json:"workspaceId,omitempty"`
+	TargetType     string            `json:"targetType"` // permission | menu | cspRole
+	Target         string            `json:"target"`
+	Allowed        bool              `json:"allowed"`
+	Grants         []AuthzAccessPath `json:"grants"`
+	Blocks         []AuthzAccessPath `json:"blocks"`
+	ConditionUnmet []AuthzAccessPath `json:"conditionUnmet,omitempty"`
+	Roles          []AuthzAccessPath `json:"roles"` // 대상과 무관하게 사용자가 가진 유효 역할 전체
+}
+
+// AuthzCacheCounter 캐시 항목 종류별 적중/미적중 횟수
+type AuthzCacheCounter struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// AuthzCacheStats 유효 역할/권한/메뉴 트리 캐시 지표
+type AuthzCacheStats struct {
+	Enabled             bool                         `json:"enabled"`
+	TTLSeconds          int64                        `json:"ttlSeconds"`
+	Entries             int                          `json:"entries"`
+	Hits                uint64                       `json:"hits"`
+	Misses              uint64                       `json:"misses"`
+	HitRatio            float64                      `json:"hitRatio"`
+	ByKind              map[string]AuthzCacheCounter `json:"byKind"`              // platformRoles | workspaceRoles | permissions | menuTree
+	Invalidations       uint64                       `json:"invalidations"`       // 이 인스턴스의 쓰기로 인한 무효화
+	RemoteInvalidations uint64                       `json:"remoteInvalidations"` // LISTEN/NOTIFY 로 받은 무효화 (자기 자신의 커밋 포함)
+	ListenerConnected   bool                         `json:"listenerConnected"`
+	LastInvalidatedAt   *time.Time                   `json:"lastInvalidatedAt,omitempty"`
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// BreakGlassRoleName 비상 접근 시 부여하는 플랫폼 역할
+const BreakGlassRoleName = "platformAdmin"
+
+// 비상 접근 계정 종류
+const (
+	BreakGlassKindEmergency = "emergency" // 비상 시에만 쓰는 전용 계정 (휴면 정책 예외 BREAK_GLASS 로 지정)
+	BreakGlassKindElevation = "elevation" // 기존 사용자 계정을 비상 시 승격
+)
+
+// 비상 접근 세션 상태
+const (
+	BreakGlassActive  = "active"  // platformAdmin 이 부여되어 유효
+	BreakGlassEnded   = "ended"   // 만료 전 종료 (본인 반납 또는 관리자 종료)
+	BreakGlassExpired = "expired" // 기간 만료로 자동 회수
+)
+
+// BreakGlassAccount 비상 접근이 허용된 사전 등록 계정 (DB 테이블: mcmp_break_glass_accounts)
+type BreakGlassAccount struct {
+	ID           uint      `json:"id" gorm:"primaryKey;column:id"`
+	UserID       uint      `json:"userId" gorm:"column:user_id;not null;uniqueIndex"`
+	Username     string    `json:"username" gorm:"column:username;size:255"`
+	Kind         string    `json:"kind" gorm:"column:kind;size:20;not null"`
+	Description  string    `json:"description,omitempty" gorm:"column:description;type:text"`
+	Enabled      bool      `json:"enabled" gorm:"column:enabled;not null;default:true"`
+	RegisteredBy string    `json:"registeredBy,omitempty" gorm:"column:registered_by;size:255"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName BreakGlassAccount의 테이블 이름 지정
+func (BreakGlassAccount) TableName() string {
+	return "mcmp_break_glass_accounts"
+}
+
+// BreakGlassSession 비상 접근 활성화 기록 (DB 테이블: mcmp_break_glass_sessions)
+// 활성화하면 만료 시각이 지정된 platformAdmin 할당과 Keycloak realm role 을 부여하고, 만료되면 자동으로 회수한다.
+type BreakGlassSession struct {
+	ID                uint                        `json:"id" gorm:"primaryKey;column:id"`
+	AccountID         uint                        `json:"accountId" gorm:"column:account_id;not null;index"`
+	UserID            uint                        `json:"userId" gorm:"column:user_id;not null;index"`
+	KcUserID          string                      `json:"kcUserId" gorm:"column:kc_user_id;size:255;not null;index"`
+	Username          string                      `json:"username" gorm:"column:username;size:255"`
+	RoleID            uint                        `json:"roleId" gorm:"column:role_id;not null"` // 부여한 platformAdmin 역할
+	Reason            string                      `json:"reason" gorm:"column:reason;type:text;not null"`
+	DurationMinutes   int                         `json:"durationMinutes" gorm:"column:duration_minutes;not null"`
+	Status            string                      `json:"status" gorm:"column:status;size:20;not null;index"`
+	SourceIP          string                      `json:"sourceIp,omitempty" gorm:"column:source_ip;size:64"`
+	ActivatedAt       time.Time                   `json:"activatedAt" gorm:"column:activated_at;not null"`
+	ExpiresAt         time.Time                   `json:"expiresAt" gorm:"column:expires_at;not null;index"`
+	EndedAt           *time.Time                  `json:"endedAt,omitempty" gorm:"column:ended_at"`
+	EndedByUsername   string                      `json:"endedByUsername,omitempty" gorm:"column:ended_by_username;size:255"` // 자동 만료면 system
+	EndReason         string                      `json:"endReason,omitempty" gorm:"column:end_reason;type:text"`
+	NotifiedAdmins    datatypes.JSONSlice[string] `json:"notifiedAdmins,omitempty" gorm:"column:notified_admins;type:jsonb"` // 활성화 알림 대상 플랫폼 관리자
+	NotificationError string                      `json:"notificationError,omitempty" gorm:"column:notification_error;type:text"`
+	CreatedAt         time.Time                   `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time                   `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName BreakGlassSession의 테이블 이름 지정
+func (BreakGlassSession) TableName() string {
+	return "mcmp_break_glass_sessions"
+}
+
+// BreakGlassActivity 비상 접근 중 수행한 요청 (DB 테이블: mcmp_break_glass_activities)
+// 조회를 포함한 모든 요청을 일반 감사 이벤트와 별도의 해시 체인(break_glass_activities)에 기록한다.
+type BreakGlassActivity struct {
+	ID            uint      `json:"id" gorm:"primaryKey;column:id"`
+	SessionID     uint      `json:"sessionId" gorm:"column:session_id;not null;index"`
+	OccurredAt    time.Time `json:"occurredAt" gorm:"column:occurred_at;not null;index"`
+	ActorKcID     string    `json:"actorKcId" gorm:"column:actor_kc_id;size:255"`
+	ActorUsername string    `json:"actorUsername" gorm:"column:actor_username;size:255"`
+	Method        string    `json:"method" gorm:"column:method;size:10"`
+	Route         string    `json:"route" gorm:"column:route;size:255"`
+	Path          string    `json:"path" gorm:"column:path;size:1024"`
+	Query         string    `json:"query,omitempty" gorm:"column:query;type:text"`
+	StatusCode    int       `json:"statusCode" gorm:"column:status_code"`
+	ErrorMessage  string    `json:"errorMessage,omitempty" gorm:"column:error_message;type:text"`
+	SourceIP      string    `json:"sourceIp,omitempty" gorm:"column:source_ip;size:64"`
+	UserAgent     string    `json:"userAgent,omitempty" gorm:"column:user_agent;size:512"`
+	PrevHash      string    `json:"prevHash" gorm:"column:prev_hash;size:64"`
+	Hash          string    `json:"hash" gorm:"column:hash;size:64;index"`
+}
+
+// TableName BreakGlassActivity의 테이블 이름 지정
+func (BreakGlassActivity) TableName() string {
+	return "mcmp_break_glass_activities"
+}
+
+// RegisterBreakGlassAccountRequest 비상 접근 계정 등록
+type RegisterBreakGlassAccountRequest struct {
+	UserID      uint   `json:"userId"`
+	Kind        string `json:"kind"` // emergency, elevation (기본 elevation)
+	Description string `json:"description,omitempty"`
+}
+
+// ActivateBreakGlassRequest 비상 접근 활성화 (사유 필수)
+type ActivateBreakGlassRequest struct {
+	Reason          string `json:"reason"`
+	DurationMinutes int    `json:"durationMinutes,omitempty"`
+}
+
+// EndBreakGlassRequest 비상 접근 종료 사유
+type EndBreakGlassRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// BreakGlassSessionFilter 비상 접근 세션 목록 조회 조건
+type BreakGlassSessionFilter struct {
+	Status string `query:"status"`
+	UserID uint   `query:"userId"`
+}
+
+// BreakGlassPolicy 비상 접근 기간 정책 (분 단위)
+type BreakGlassPolicy struct {
+	DefaultMinutes int `json:"defaultMinutes"` // 요청에 기간이 없을 때
+	MaxMinutes     int `json:"maxMinutes"`
+}
+
+// BreakGlassNotice 비상 접근 활성화/종료 알림 내용 (다른 플랫폼 관리자에게 전달)
+type BreakGlassNotice struct {
+	Type        string                `json:"type"` // break-glass-activated, break-glass-ended
+	Priority    string                `json:"priority"`
+	SessionID   uint                  `json:"sessionId"`
+	Username    string                `json:"username"`
+	Reason      string                `json:"reason"`
+	SourceIP    string                `json:"sourceIp,omitempty"`
+	ActivatedAt time.Time             `json:"activatedAt"`
+	ExpiresAt   time.Time             `json:"expiresAt"`
+	EndedAt     *time.Time            `json:"endedAt,omitempty"`
+	EndReason   string                `json:"endReason,omitempty"`
+	Activities  int64                 `json:"activities,omitempty"` // 종료 시 기록된 요청 수
+	Recipients  []BreakGlassRecipient `json:"recipients"`
+}
+
+// BreakGlassRecipient 알림 대상 플랫폼 관리자
+type BreakGlassRecipient struct {
+	UserID   uint   `json:"userId"`
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+// BreakGlassNotice 값
+const (
+	BreakGlassNoticeActivated = "break-glass-activated"
+	BreakGlassNoticeEnded     = "break-glass-ended"
+	BreakGlassNoticePriority  = "high"
+)
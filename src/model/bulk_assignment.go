@@ -0,0 +1,78 @@
+package model
+
+import "time"
+
+// 일괄 할당 파일 형식
+const (
+	BulkAssignmentFormatCSV  = "csv"
+	BulkAssignmentFormatYAML = "yaml"
+)
+
+// 일괄 할당 행 처리 결과
+const (
+	BulkRowPlanned    = "planned"     // dry-run: 검증 통과, 적용 예정
+	BulkRowInvalid    = "invalid"     // 검증 실패
+	BulkRowUnchanged  = "unchanged"   // 모든 할당이 이미 존재
+	BulkRowApplied    = "applied"     // 적용 완료
+	BulkRowFailed     = "failed"      // 적용 중 실패 (전체 롤백)
+	BulkRowRolledBack = "rolled_back" // 다른 행 실패로 롤백됨
+	BulkRowNotApplied = "not_applied" // 다른 행 검증 실패로 적용하지 않음
+)
+
+// 일괄 할당 단계 종류
+const (
+	BulkStepWorkspaceRole = "workspace-role"
+	BulkStepPlatformRole  = "platform-role"
+	BulkStepGroup         = "group"
+)
+
+// BulkAssignmentRow 일괄 할당 입력 한 행
+// Workspace 가 비어 있으면 Role 은 플랫폼 역할로, 있으면 해당 워크스페이스의 워크스페이스 역할로 해석한다.
+// ExpiresAt 은 RFC3339 또는 YYYY-MM-DD(해당 일 00:00 UTC) 형식이다.
+type BulkAssignmentRow struct {
+	Line      int    `json:"line" yaml:"-"`                                  // CSV 줄 번호 또는 YAML 항목 순번
+	User      string `json:"user" yaml:"user"`                               // 사용자명 또는 이메일
+	Workspace string `json:"workspace,omitempty" yaml:"workspace,omitempty"` // 워크스페이스 이름 또는 ID
+	Role      string `json:"role,omitempty" yaml:"role,omitempty"`           // 역할 이름
+	ExpiresAt string `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	Group     string `json:"group,omitempty" yaml:"group,omitempty"` // 그룹(조직) 코드 또는 이름
+}
+
+// BulkAssignmentFile YAML 일괄 할당 파일 구조
+type BulkAssignmentFile struct {
+	Assignments []BulkAssignmentRow `yaml:"assignments"`
+}
+
+// BulkAssignmentStep 한 행에서 수행할(수행한) 개별 할당
+type BulkAssignmentStep struct {
+	Kind      string     `json:"kind"`   // workspace-role, platform-role, group
+	Target    string     `json:"target"` // 역할 또는 그룹 이름
+	Workspace string     `json:"workspace,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Exists    bool       `json:"exists,omitempty"`   // 이미 할당되어 있어 건너뜀
+	Keycloak  bool       `json:"keycloak,omitempty"` // Keycloak realm role/그룹 변경 동반
+}
+
+// BulkAssignmentRowResult 행별 계획 또는 적용 결과
+type BulkAssignmentRowResult struct {
+	Line      int                  `json:"line"`
+	User      string               `json:"user"`
+	UserID    uint                 `json:"userId,omitempty"`
+	Workspace string               `json:"workspace,omitempty"`
+	Role      string               `json:"role,omitempty"`
+	Group     string               `json:"group,omitempty"`
+	Status    string               `json:"status"`
+	Steps     []BulkAssignmentStep `json:"steps,omitempty"`
+	Errors    []string             `json:"errors,omitempty"`
+}
+
+// BulkAssignmentReport 일괄 할당 결과 보고서
+type BulkAssignmentReport struct {
+	DryRun  bool                      `json:"dryRun"`
+	Applied bool                      `json:"applied"`
+	Total   int                       `json:"total"`
+	Valid   int                       `json:"valid"`
+	Invalid int                       `json:"invalid"`
+	Error   string                    `json:"error,omitempty"` // 적용 실패 원인 (롤백됨)
+	Rows    []BulkAssignmentRowResult `json:"rows"`
+}
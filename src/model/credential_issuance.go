@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// CredentialIssuance CSP 임시 자격 증명 발급 원장 (DB 테이블: mcmp_credential_issuances)
+// 성공/실패한 모든 발급 요청을 기록하며, 발급된 키/토큰 등 비밀값은 저장하지 않는다.
+// 감사 이벤트와 같은 방식으로 해시 체인에 연결된다 (필드 추가 시 omitempty 유지).
+type CredentialIssuance struct {
+	ID                       uint       `json:"id" gorm:"primaryKey;column:id"`
+	RequestedAt              time.Time  `json:"requestedAt" gorm:"column:requested_at;not null;index"`
+	UserID                   uint       `json:"userId,omitempty" gorm:"column:user_id"`
+	KcUserID                 string     `json:"kcUserId" gorm:"column:kc_user_id;size:255;index"`
+	Username                 string     `json:"username,omitempty" gorm:"column:username;size:255"`
+	WorkspaceID              uint       `json:"workspaceId,omitempty" gorm:"column:workspace_id;index"`
+	WorkspaceRoleID          uint       `json:"workspaceRoleId,omitempty" gorm:"column:workspace_role_id"`
+	WorkspaceRoleName        string     `json:"workspaceRoleName,omitempty" gorm:"column:workspace_role_name;size:255"`
+	CspType                  string     `json:"cspType" gorm:"column:csp_type;size:50;index"`
+	AuthMethod               string     `json:"authMethod,omitempty" gorm:"column:auth_method;size:50"`
+	CspRoleID                *uint      `json:"cspRoleId,omitempty" gorm:"column:csp_role_id"`
+	CspRoleName              string     `json:"cspRoleName,omitempty" gorm:"column:csp_role_name;size:255"`
+	CspRoleIdentifier        string     `json:"cspRoleIdentifier,omitempty" gorm:"column:csp_role_identifier;size:255"` // IAM Role ARN 등
+	Region                   string     `json:"region,omitempty" gorm:"column:region;size:100"`
+	RequestedDurationSeconds int        `json:"requestedDurationSeconds,omitempty" gorm:"column:requested_duration_seconds"`
+	RequestedExpiresAt       *time.Time `json:"requestedExpiresAt,omitempty" gorm:"column:requested_expires_at"`
+	ExpiresAt                *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"` // CSP 가 반환한 실제 만료 시각
+	SourceIP                 string     `json:"sourceIp,omitempty" gorm:"column:source_ip;size:64"`
+	Result                   string     `json:"result" gorm:"column:result;size:20;not null;index"` // success | failure
+	FailureReason            string     `json:"failureReason,omitempty" gorm:"column:failure_reason;type:text"`
+	PrevHash                 string     `json:"prevHash" gorm:"column:prev_hash;size:64"` // 직전 기록 해시 (해시 체인)
+	Hash                     string     `json:"hash" gorm:"column:hash;size:64;index"`
+}
+
+// TableName CredentialIssuance의 테이블 이름 지정
+func (CredentialIssuance) TableName() string {
+	return "mcmp_credential_issuances"
+}
+
+// CredentialIssuanceFilter 발급 원장 조회 조건 (from/to 는 RFC3339)
+type CredentialIssuanceFilter struct {
+	User        string `query:"user"` // Keycloak 사용자 ID 또는 사용자명
+	WorkspaceID uint   `query:"workspaceId"`
+	CspType     string `query:"cspType"`
+	CspRole     string `query:"cspRole"` // CSP 역할 이름/식별자 부분 일치
+	AuthMethod  string `query:"authMethod"`
+	Result      string `query:"result"`
+	SourceIP    string `query:"sourceIp"`
+	From        string `query:"from"`
+	To          string `query:"to"`
+	Limit       int    `query:"limit"`   // 기본 100, 최대 1000
+	Offset      int    `query:"offset"`  // 최신순 정렬 기준
+	GroupBy     string `query:"groupBy"` // 집계 기준 (workspace, csp, cspRole, user 를 쉼표로 조합, 기본 workspace,csp)
+}
+
+// CredentialIssuanceListResponse 발급 원장 조회 결과
+type CredentialIssuanceListResponse struct {
+	Total     int64                `json:"total"`
+	Issuances []CredentialIssuance `json:"issuances"`
+}
+
+// CredentialIssuanceAggregate 발급 원장 집계 행 (GroupBy 에 포함되지 않은 기준 필드는 비어 있음)
+type CredentialIssuanceAggregate struct {
+	WorkspaceID     *uint      `json:"workspaceId,omitempty"`
+	CspType         string     `json:"cspType,omitempty"`
+	CspRoleName     string     `json:"cspRoleName,omitempty"`
+	KcUserID        string     `json:"kcUserId,omitempty"`
+	Total           int64      `json:"total"`
+	Succeeded       int64      `json:"succeeded"`
+	Failed          int64      `json:"failed"`
+	DistinctUsers   int64      `json:"distinctUsers"`
+	LastRequestedAt *time.Time `json:"lastRequestedAt,omitempty"`
+}
@@ -8,6 +8,8 @@ type CspCredentialRequest struct {
 	CspType     string `json:"cspType"`             // 대상 CSP 타입
 	Region      string `json:"region"`              // AWS 리전 (선택적)
 	AuthMethod  string `json:"authMethod,omitempty"` // 인증방식 (OIDC/SAML/SECRET_KEY), 미지정 시 매핑에서 결정
+	// 요청 세션 시간(초, 선택) — 발급 원장에 요청 만료로 기록, 실제 만료는 CSP 응답 기준
+	DurationSeconds int `json:"durationSeconds,omitempty"`
 }
 
 // CspCredentialResponse CSP 임시 자격 증명 발급 응답 모델
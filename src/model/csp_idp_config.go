@@ -26,6 +26,11 @@ type CspIdpConfig struct {
 	Description  string            `gorm:"size:500" json:"description"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
+
+	// 마지막 연결 상태 확인 결과 (확인 전이면 비어 있음)
+	LastHealthStatus    string     `gorm:"size:20" json:"last_health_status,omitempty"` // CONNECTED, FAILED, TIMEOUT
+	LastHealthError     string     `gorm:"type:text" json:"last_health_error,omitempty"`
+	LastHealthCheckedAt *time.Time `json:"last_health_checked_at,omitempty"`
 }
 
 // TableName CspIdpConfig 테이블 이름 반환
@@ -283,6 +288,13 @@ type CspIdpSummary struct {
 	MethodCounts   map[string]int `json:"method_counts"`
 }
 
+// IDP 연결 상태 확인 결과 상태값
+const (
+	HealthStatusConnected = "CONNECTED"
+	HealthStatusFailed    = "FAILED"
+	HealthStatusTimeout   = "TIMEOUT"
+)
+
 // HealthCheckResult IDP 연결 상태 확인 결과 (단건)
 type HealthCheckResult struct {
 	ConfigID   uint   `json:"config_id"`
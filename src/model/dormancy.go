@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// 휴면 계정 정책 예외 사유 (User.DormancyExemption)
+const (
+	DormancyExemptionServiceAccount = "SERVICE_ACCOUNT" // 사람이 로그인하지 않는 서비스 계정
+	DormancyExemptionBreakGlass     = "BREAK_GLASS"     // 비상 접근용 계정
+)
+
+// 사용자 활동 종류 (활동 시각 기록)
+const (
+	UserActivityLogin    = "login"
+	UserActivityRefresh  = "refresh"
+	UserActivityTokenUse = "token-use"
+)
+
+// DormancyPolicy 휴면 계정 정책 (일 단위, 0 이면 해당 단계 사용 안 함)
+type DormancyPolicy struct {
+	WarnDays             int  `json:"warnDays"`             // 마지막 활동 후 경고 알림까지
+	DeactivateDays       int  `json:"deactivateDays"`       // 마지막 활동 후 비활성화까지
+	ExemptPlatformAdmins bool `json:"exemptPlatformAdmins"` // platformAdmin 보유자(직접/그룹)는 경고·비활성화 대상에서 제외
+}
+
+// SetDormancyExemptionRequest 휴면 정책 예외 설정 요청 (빈 값이면 예외 해제)
+type SetDormancyExemptionRequest struct {
+	Exemption string `json:"exemption"`
+}
+
+// DormancyNotice 휴면 경고/비활성화 알림 내용
+type DormancyNotice struct {
+	Type           string     `json:"type"` // dormancy-warning, dormancy-deactivated
+	UserID         uint       `json:"userId"`
+	Username       string     `json:"username"`
+	Email          string     `json:"email,omitempty"`
+	LastActivityAt *time.Time `json:"lastActivityAt,omitempty"` // 활동 기록이 없으면 비어 있음 (생성 시각 기준)
+	InactiveDays   int        `json:"inactiveDays"`
+	DeactivateAt   *time.Time `json:"deactivateAt,omitempty"` // 경고 시 예정된 비활성화 시각
+}
+
+// DormancyNotice.Type 값
+const (
+	DormancyNoticeWarning     = "dormancy-warning"
+	DormancyNoticeDeactivated = "dormancy-deactivated"
+)
+
+// DormancyRunResult 휴면 정책 1회 실행 결과
+type DormancyRunResult struct {
+	Warned      []string          `json:"warned"`      // 경고한 사용자명
+	Deactivated []string          `json:"deactivated"` // 비활성화한 사용자명
+	Failed      map[string]string `json:"failed,omitempty"`
+}
@@ -0,0 +1,97 @@
+package model
+
+import "time"
+
+// 권한 승격(JIT) 요청 유형
+const (
+	ElevationTypeWorkspaceRole = "workspace-role" // 워크스페이스 역할 승격
+	ElevationTypeCspRole       = "csp-role"       // 특정 CSP 역할 매핑을 가진 워크스페이스 역할 승격
+)
+
+// 권한 승격 요청 상태
+const (
+	ElevationPending   = "pending"   // 승인 대기
+	ElevationApproved  = "approved"  // 승인되어 승격 할당이 유효
+	ElevationDenied    = "denied"    // 거절
+	ElevationCancelled = "cancelled" // 승인 전 요청자가 취소
+	ElevationRevoked   = "revoked"   // 만료 전 회수 (요청자 반납 또는 관리자 회수)
+	ElevationExpired   = "expired"   // 기간 만료로 자동 회수
+)
+
+// 권한 승격 결정
+const (
+	ElevationDecisionApprove = "approve"
+	ElevationDecisionDeny    = "deny"
+)
+
+// ElevationRequest 기한부 권한 승격 요청 (DB 테이블: mcmp_elevation_requests)
+// 승인 시 만료 시각이 지정된 워크스페이스 역할 할당을 만들고, 만료되면 자동으로 회수한다.
+// 이름 필드는 요청 시점 값으로 보관하여 회수 후에도 증적으로 남긴다.
+type ElevationRequest struct {
+	ID                uint       `json:"id" gorm:"primaryKey;column:id"`
+	Type              string     `json:"type" gorm:"column:type;size:20;not null"`
+	RequesterID       uint       `json:"requesterId" gorm:"column:requester_id;not null;index"`
+	RequesterKcID     string     `json:"requesterKcId" gorm:"column:requester_kc_id;size:255"`
+	RequesterUsername string     `json:"requesterUsername" gorm:"column:requester_username;size:255"`
+	WorkspaceID       uint       `json:"workspaceId" gorm:"column:workspace_id;not null;index"`
+	WorkspaceName     string     `json:"workspaceName,omitempty" gorm:"column:workspace_name;size:255"`
+	RoleID            uint       `json:"roleId" gorm:"column:role_id;not null"`
+	RoleName          string     `json:"roleName,omitempty" gorm:"column:role_name;size:255"`
+	CspRoleID         *uint      `json:"cspRoleId,omitempty" gorm:"column:csp_role_id"`
+	CspRoleName       string     `json:"cspRoleName,omitempty" gorm:"column:csp_role_name;size:255"`
+	CspType           string     `json:"cspType,omitempty" gorm:"column:csp_type;size:50"`
+	DurationMinutes   int        `json:"durationMinutes" gorm:"column:duration_minutes;not null"`
+	Justification     string     `json:"justification" gorm:"column:justification;type:text;not null"`
+	Status            string     `json:"status" gorm:"column:status;size:20;not null;index"`
+	DecidedByKcID     string     `json:"decidedByKcId,omitempty" gorm:"column:decided_by_kc_id;size:255"`
+	DecidedByUsername string     `json:"decidedByUsername,omitempty" gorm:"column:decided_by_username;size:255"`
+	DecisionComment   string     `json:"decisionComment,omitempty" gorm:"column:decision_comment;type:text"`
+	DecidedAt         *time.Time `json:"decidedAt,omitempty" gorm:"column:decided_at"`
+	ExpiresAt         *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at;index"` // 승인 시각 + 기간
+	EndedAt           *time.Time `json:"endedAt,omitempty" gorm:"column:ended_at"`           // 회수/만료 처리 시각
+	EndedByKcID       string     `json:"endedByKcId,omitempty" gorm:"column:ended_by_kc_id;size:255"`
+	EndedByUsername   string     `json:"endedByUsername,omitempty" gorm:"column:ended_by_username;size:255"` // 자동 만료면 system
+	EndReason         string     `json:"endReason,omitempty" gorm:"column:end_reason;type:text"`
+	CreatedAt         time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName ElevationRequest의 테이블 이름 지정
+func (ElevationRequest) TableName() string {
+	return "mcmp_elevation_requests"
+}
+
+// CreateElevationRequest 권한 승격 요청
+// csp-role 유형은 cspRoleId 필수, roleId 를 비우면 해당 CSP 역할에 매핑된 워크스페이스 역할(하나뿐일 때)을 사용한다.
+type CreateElevationRequest struct {
+	Type            string `json:"type"`
+	WorkspaceID     uint   `json:"workspaceId"`
+	RoleID          uint   `json:"roleId,omitempty"`
+	CspRoleID       *uint  `json:"cspRoleId,omitempty"`
+	DurationMinutes int    `json:"durationMinutes"`
+	Justification   string `json:"justification"`
+}
+
+// ElevationDecisionRequest 승인자 결정
+type ElevationDecisionRequest struct {
+	Decision string `json:"decision"` // approve, deny
+	Comment  string `json:"comment,omitempty"`
+}
+
+// ElevationRevokeRequest 승격 회수 사유
+type ElevationRevokeRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ElevationFilter 승격 요청 목록 조회 조건
+type ElevationFilter struct {
+	Status      string `query:"status"`
+	WorkspaceID uint   `query:"workspaceId"`
+	RequesterID uint   `query:"requesterId"`
+}
+
+// ElevationPolicy 승격 기간 정책 (분 단위)
+type ElevationPolicy struct {
+	DefaultMinutes int `json:"defaultMinutes"` // 요청에 기간이 없을 때
+	MaxMinutes     int `json:"maxMinutes"`
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Labels 워크스페이스/프로젝트 라벨 (key=value, DB 에는 JSON 으로 저장)
+type Labels map[string]string
+
+// Value driver.Valuer 구현
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scanner 구현
+func (l *Labels) Scan(value interface{}) error {
+	return scanJSONColumn(value, l)
+}
+
+// GrantConditions 역할-권한/역할-CSP 역할 매핑의 적용 조건
+// 지정된 조건을 모두 만족해야 매핑이 적용된다 (같은 조건 안의 여러 값은 하나만 만족하면 된다).
+type GrantConditions struct {
+	SourceCIDRs     []string          `json:"sourceCidrs,omitempty"`     // 요청 IP 대역 (예: 10.0.0.0/8)
+	TimeWindows     []GrantTimeWindow `json:"timeWindows,omitempty"`     // 허용 시간대
+	OrganizationIDs []uint            `json:"organizationIds,omitempty"` // 사용자가 소속된 조직(UserOrganization)
+	WorkspaceLabels Labels            `json:"workspaceLabels,omitempty"` // 대상 워크스페이스 라벨 (모두 일치)
+	ProjectLabels   Labels            `json:"projectLabels,omitempty"`   // 대상 프로젝트 라벨 (모두 일치)
+}
+
+// GrantTimeWindow 허용 시간대 (End 가 Start 보다 이르면 자정을 넘는 구간)
+type GrantTimeWindow struct {
+	Days     []string `json:"days,omitempty"`     // mon, tue, wed, thu, fri, sat, sun (비어 있으면 매일)
+	Start    string   `json:"start"`              // HH:MM
+	End      string   `json:"end"`                // HH:MM
+	Timezone string   `json:"timezone,omitempty"` // IANA 타임존 (예: Asia/Seoul, 비어 있으면 서버 로컬)
+}
+
+// IsEmpty 조건이 하나도 없는지 여부
+func (g *GrantConditions) IsEmpty() bool {
+	return g == nil || (len(g.SourceCIDRs) == 0 && len(g.TimeWindows) == 0 && len(g.OrganizationIDs) == 0 &&
+		len(g.WorkspaceLabels) == 0 && len(g.ProjectLabels) == 0)
+}
+
+// Value driver.Valuer 구현
+func (g GrantConditions) Value() (driver.Value, error) {
+	if g.IsEmpty() {
+		return nil, nil
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scanner 구현
+func (g *GrantConditions) Scan(value interface{}) error {
+	return scanJSONColumn(value, g)
+}
+
+// scanJSONColumn JSON 컬럼 값을 dest 로 변환 (NULL 은 무시)
+func scanJSONColumn(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			return nil
+		}
+		return json.Unmarshal(v, dest)
+	case string:
+		if v == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", value)
+	}
+}
+
+// AuthzConditionContext 조건 평가 시점의 요청 속성
+type AuthzConditionContext struct {
+	SourceIP  string    `json:"sourceIp,omitempty"`
+	At        time.Time `json:"at,omitempty"`
+	ProjectID uint      `json:"projectId,omitempty"`
+}
+
+// AuthzConditionResult 조건 하나의 평가 결과
+type AuthzConditionResult struct {
+	Condition string `json:"condition"` // sourceCidrs | timeWindows | organizationIds | workspaceLabels | projectLabels
+	Satisfied bool   `json:"satisfied"`
+	Detail    string `json:"detail,omitempty"`
+}
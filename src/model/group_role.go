@@ -9,6 +9,11 @@ type GroupPlatformRole struct {
 	RoleID    uint      `gorm:"primaryKey;column:role_id" json:"role_id"`
 	CreatedAt time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
 
+	// 유효 기간 (nil 이면 즉시/무기한), 시작 전이면 ActivationPending (Keycloak 그룹 realm role 은 시작 시 부여)
+	ValidFrom         *time.Time `gorm:"column:valid_from" json:"valid_from,omitempty"`
+	ExpiresAt         *time.Time `gorm:"column:expires_at;index" json:"expires_at,omitempty"`
+	ActivationPending bool       `gorm:"column:activation_pending;not null;default:false" json:"activation_pending,omitempty"`
+
 	Group *Organization `gorm:"foreignKey:GroupID" json:"group,omitempty"`
 	Role  *RoleMaster   `gorm:"foreignKey:RoleID" json:"role,omitempty"`
 }
@@ -26,6 +31,11 @@ type GroupWorkspaceRole struct {
 	CreatedAt   time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime;column:updated_at" json:"updated_at"`
 
+	// 유효 기간 (nil 이면 즉시/무기한), 시작 전이면 ActivationPending
+	ValidFrom         *time.Time `gorm:"column:valid_from" json:"valid_from,omitempty"`
+	ExpiresAt         *time.Time `gorm:"column:expires_at;index" json:"expires_at,omitempty"`
+	ActivationPending bool       `gorm:"column:activation_pending;not null;default:false" json:"activation_pending,omitempty"`
+
 	Group     *Organization `gorm:"foreignKey:GroupID" json:"group,omitempty"`
 	Workspace *Workspace    `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
 	Role      *RoleMaster   `gorm:"foreignKey:RoleID" json:"role,omitempty"`
@@ -37,13 +47,17 @@ func (GroupWorkspaceRole) TableName() string {
 
 // AssignGroupPlatformRoleRequest 그룹 플랫폼 역할 할당 요청
 type AssignGroupPlatformRoleRequest struct {
-	RoleID uint `json:"role_id" validate:"required"`
+	RoleID    uint       `json:"role_id" validate:"required"`
+	ValidFrom *time.Time `json:"valid_from,omitempty"` // 유효 시작 (비어 있으면 즉시)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // 만료 시 자동 회수 (비어 있으면 무기한)
 }
 
 // AssignGroupWorkspaceRequest 그룹-워크스페이스 매핑 요청
 type AssignGroupWorkspaceRequest struct {
-	WorkspaceID uint `json:"workspace_id" validate:"required"`
-	RoleID      uint `json:"role_id" validate:"required"`
+	WorkspaceID uint       `json:"workspace_id" validate:"required"`
+	RoleID      uint       `json:"role_id" validate:"required"`
+	ValidFrom   *time.Time `json:"valid_from,omitempty"` // 유효 시작 (비어 있으면 즉시)
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // 만료 시 자동 회수 (비어 있으면 무기한)
 }
 
 // UpdateGroupWorkspaceRoleRequest 그룹 워크스페이스 역할 변경 요청
@@ -16,6 +16,26 @@ const (
 	PermissionTypeResource PermissionType = "resource"
 )
 
+// PermissionEffect 역할-권한 매핑의 효과 (허용/거부)
+// 같은 권한에 허용과 거부가 함께 있으면 어떤 역할/그룹 경로에서 왔든 거부가 우선한다.
+type PermissionEffect string
+
+const (
+	// PermissionEffectAllow 허용
+	PermissionEffectAllow PermissionEffect = "allow"
+	// PermissionEffectDeny 거부
+	PermissionEffectDeny PermissionEffect = "deny"
+)
+
+// MenuPermissionFramework 메뉴 접근 권한 ID 의 framework
+const MenuPermissionFramework = "mc-web-console"
+
+// MenuPermissionID 메뉴 접근 권한 ID (mc-web-console:menu:<menuId>)
+// 메뉴는 역할-메뉴 매핑으로 허용되며, 이 권한에 거부 매핑이 있으면 메뉴 트리에서 제외된다.
+func MenuPermissionID(menuID string) string {
+	return MenuPermissionFramework + ":" + string(PermissionTypeMenu) + ":" + menuID
+}
+
 // MciamPermission 권한 정보 (DB 테이블: mcmp_mciam_permissions) - Renamed
 type MciamPermission struct {
 	ID             string    `json:"id" gorm:"primaryKey;column:id;type:varchar(255)"`                         // Format: <framework_id>:<resource_type_id>:<action>
@@ -39,6 +59,8 @@ type MciamRoleMciamPermission struct {
 	RoleType     constants.IAMRoleType `json:"role_type" gorm:"primaryKey;column:role_type;type:varchar(50);not null"`          // 'platform' or 'workspace'
 	RoleID       uint                  `json:"role_id" gorm:"primaryKey;column:role_id;not null"`                               // Refers to mcmp_platform_roles.id or mcmp_workspace_roles.id
 	PermissionID string                `json:"permission_id" gorm:"primaryKey;column:permission_id;type:varchar(255);not null"` // Refers to mcmp_mciam_permissions.id
+	Effect       PermissionEffect      `json:"effect" gorm:"column:effect;type:varchar(10);not null;default:'allow'"`           // 'allow' or 'deny'
+	Conditions   *GrantConditions      `json:"conditions,omitempty" gorm:"column:conditions;type:jsonb"`                        // 적용 조건 (없으면 항상 적용)
 	CreatedAt    time.Time             `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	// Relationships can be added if needed, e.g., PlatformRole, WorkspaceRole, MciamPermission
 }
@@ -47,3 +69,27 @@ type MciamRoleMciamPermission struct {
 func (MciamRoleMciamPermission) TableName() string { // Renamed receiver
 	return "mcmp_mciam_role_permissions" // Updated table name
 }
+
+// RoleOperationSeed 기동 시 동기화로 이미 반영한 permission.yaml operations/denies 항목 (DB 테이블: mcmp_role_operation_seeds)
+// 한 번 반영한 항목은 관리자가 역할에서 제거해도 다시 추가하지 않는다.
+type RoleOperationSeed struct {
+	RoleName     string           `json:"role_name" gorm:"primaryKey;column:role_name;type:varchar(255);not null"`
+	PermissionID string           `json:"permission_id" gorm:"primaryKey;column:permission_id;type:varchar(255);not null"`
+	Effect       PermissionEffect `json:"effect" gorm:"primaryKey;column:effect;type:varchar(10);not null"`
+	AppliedAt    time.Time        `json:"applied_at" gorm:"column:applied_at;autoCreateTime"`
+}
+
+// TableName 테이블 이름 지정
+func (RoleOperationSeed) TableName() string {
+	return "mcmp_role_operation_seeds"
+}
+
+// AssignRolePermissionRequest 역할-권한 매핑 등록 요청 본문 (선택)
+type AssignRolePermissionRequest struct {
+	Conditions *GrantConditions `json:"conditions,omitempty"` // 적용 조건 (없으면 항상 적용)
+}
+
+// IsDeny 거부 매핑 여부 (빈 값은 허용으로 본다)
+func (m MciamRoleMciamPermission) IsDeny() bool {
+	return m.Effect == PermissionEffectDeny
+}
@@ -61,6 +61,7 @@ type RolePermissionEntry struct {
 	Role       string   `json:"role" yaml:"role"`
 	Menus      []string `json:"menus" yaml:"menus"`
 	Operations []string `json:"operations" yaml:"operations"`
+	Denies     []string `json:"denies,omitempty" yaml:"denies,omitempty"` // 거부(deny) 매핑 MC-IAM 권한 ID
 	Csps       []string `json:"csps" yaml:"csps"`
 }
 
@@ -75,9 +76,11 @@ type RolePermissionBackup struct {
 
 // RolePermissionRestoreResult restore 결과 요약
 type RolePermissionRestoreResult struct {
-	Mode           string `json:"mode"`
-	RolesProcessed int    `json:"rolesProcessed"`
-	MenusAdded     int    `json:"menusAdded"`
-	MenusRemoved   int    `json:"menusRemoved"`
-	Message        string `json:"message"`
+	Mode              string `json:"mode"`
+	RolesProcessed    int    `json:"rolesProcessed"`
+	MenusAdded        int    `json:"menusAdded"`
+	MenusRemoved      int    `json:"menusRemoved"`
+	OperationsAdded   int    `json:"operationsAdded"`
+	OperationsRemoved int    `json:"operationsRemoved"`
+	Message           string `json:"message"`
 }
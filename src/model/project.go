@@ -8,6 +8,7 @@ type Project struct {
 	NsId        string       `json:"nsid" gorm:"column:nsid;size:255"` // Namespace ID
 	Name        string       `json:"name" gorm:"column:name;size:255;not null"`
 	Description string       `json:"description" gorm:"column:description;size:1000"`
+	Labels      Labels       `json:"labels,omitempty" gorm:"column:labels;type:jsonb"` // 조건부 권한 평가용 라벨
 	CreatedAt   time.Time    `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time    `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 	Workspaces  []*Workspace `json:"workspaces,omitempty" gorm:"many2many:mcmp_workspace_projects;"` // M:N relationship
@@ -0,0 +1,32 @@
+package model
+
+// 규정 준수 보고서 이름 (/api/reports/{name})
+const (
+	ReportPrivilegedUsers     = "privileged-users"
+	ReportDormantAccounts     = "dormant-accounts"
+	ReportUnmappedCspRoles    = "unmapped-csp-roles"
+	ReportEmptyWorkspaceRoles = "empty-workspace-roles"
+	ReportFailedIdpConfigs    = "failed-idp-configs"
+)
+
+// ReportDefinition 제공하는 보고서 설명
+type ReportDefinition struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Formats     []string `json:"formats"`
+}
+
+// ReportOptions 보고서 생성 옵션
+type ReportOptions struct {
+	DormantDays int `query:"days"` // dormant-accounts: 로그인 없는 기간(일), 0 이면 설정 기본값
+}
+
+// ReportRoleHolder 역할 보유자 한 건 (직접 할당 또는 그룹 상속)
+type ReportRoleHolder struct {
+	UserID    uint       `gorm:"column:user_id"`
+	Username  string     `gorm:"column:username"`
+	KcID      string     `gorm:"column:kc_id"`
+	Status    UserStatus `gorm:"column:status"`
+	RoleName  string     `gorm:"column:role_name"`
+	GroupName string     `gorm:"column:group_name"` // 직접 할당이면 빈 값
+}
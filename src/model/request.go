@@ -1,6 +1,10 @@
 package model
 
-import "github.com/m-cmp/mc-iam-manager/constants"
+import (
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+)
 
 // 각종 요청에 대한 구조체 정의
 // naming convention : XxxRequest
@@ -42,16 +46,19 @@ type McmpApiCallRequest struct {
 	ServiceName   string               `json:"serviceName" validate:"required"` // Target service name
 	ActionName    string               `json:"actionName" validate:"required"`  // Target action name (operationId)
 	RequestParams McmpApiRequestParams `json:"requestParams"`                   // Parameters for the external API call
+	WorkspaceID   string               `json:"workspaceId,omitempty"`           // 거부(deny) 매핑 평가에 포함할 워크스페이스 ID (선택, 생략하면 역할을 가진 모든 워크스페이스)
 }
 
 // AssignRoleRequest 역할 할당/ 해제 요청 구조체
 type AssignRoleRequest struct {
-	UserID      string `json:"userId,omitempty"`      // 사용자 ID (문자열로 받음)
-	Username    string `json:"username,omitempty"`    // 사용자명
-	RoleID      string `json:"roleId,omitempty"`      // 역할 ID (문자열로 받음)
-	RoleName    string `json:"roleName,omitempty"`    // 역할명
-	RoleType    string `json:"roleType"`              // 역할 타입 (platform/workspace)
-	WorkspaceID string `json:"workspaceId,omitempty"` // 워크스페이스 ID (문자열로 받음)
+	UserID      string     `json:"userId,omitempty"`      // 사용자 ID (문자열로 받음)
+	Username    string     `json:"username,omitempty"`    // 사용자명
+	RoleID      string     `json:"roleId,omitempty"`      // 역할 ID (문자열로 받음)
+	RoleName    string     `json:"roleName,omitempty"`    // 역할명
+	RoleType    string     `json:"roleType"`              // 역할 타입 (platform/workspace)
+	WorkspaceID string     `json:"workspaceId,omitempty"` // 워크스페이스 ID (문자열로 받음)
+	ValidFrom   *time.Time `json:"validFrom,omitempty"`   // 유효 시작 (비어 있으면 즉시)
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`   // 만료 시 자동 회수 (비어 있으면 무기한)
 }
 
 // RoleMasterSubRequest 역할 생성 요청 구조체
@@ -59,6 +66,7 @@ type CreateRoleRequest struct {
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description"`
 	ParentID    *uint  `json:"parentId"`
+	Delegable   bool   `json:"delegable"` // 워크스페이스 관리자가 부여 가능한 역할 여부
 	//RoleTypes   []constants.IAMRoleType `json:"roleTypes" validate:"required,dive,oneof=platform workspace csp"`
 	RoleTypes []constants.IAMRoleType `json:"roleTypes,omitempty"`
 
@@ -154,11 +162,13 @@ type MenuMappingFilterRequest struct {
 
 // AssignWorkspaceRoleRequest 워크스페이스 역할 할당 요청 구조체
 type AssignWorkspaceRoleRequest struct {
-	UserID      string `json:"userId,omitempty"`   // 사용자 ID (문자열로 받음)
-	Username    string `json:"username,omitempty"` // 사용자명
-	RoleID      string `json:"roleId,omitempty"`   // 역할 ID (문자열로 받음)
-	RoleName    string `json:"roleName,omitempty"` // 역할명
-	WorkspaceID string `json:"workspaceId"`        // 워크스페이스 ID (문자열로 받음)
+	UserID      string     `json:"userId,omitempty"`    // 사용자 ID (문자열로 받음)
+	Username    string     `json:"username,omitempty"`  // 사용자명
+	RoleID      string     `json:"roleId,omitempty"`    // 역할 ID (문자열로 받음)
+	RoleName    string     `json:"roleName,omitempty"`  // 역할명
+	WorkspaceID string     `json:"workspaceId"`         // 워크스페이스 ID (문자열로 받음)
+	ValidFrom   *time.Time `json:"validFrom,omitempty"` // 유효 시작 (비어 있으면 즉시)
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"` // 만료 시 자동 회수 (비어 있으면 무기한)
 }
 
 // RemoveWorkspaceRoleRequest 워크스페이스 역할 제거 요청 구조체
@@ -196,6 +206,7 @@ type CreateRoleMasterCspRoleMappingRequest struct {
 	Description string                 `json:"description,omitempty"`
 	AuthMethod  constants.AuthMethod   `json:"authMethod,omitempty"`
 	CspRoles    []CreateCspRoleRequest `json:"cspRoles,omitempty" gorm:"-"`
+	Conditions  *GrantConditions       `json:"conditions,omitempty"` // 적용 조건 (없으면 항상 적용)
 }
 
 // 조회 request 구조체
@@ -13,6 +13,7 @@ type RoleMaster struct {
 	Name            string                      `json:"name" gorm:"column:name;size:255;not null;unique"`
 	Description     string                      `json:"description" gorm:"column:description;size:1000"`
 	Predefined      bool                        `json:"predefined" gorm:"column:predefined;not null;default:false"`
+	Delegable       bool                        `json:"delegable" gorm:"column:delegable;not null;default:false"` // 워크스페이스 관리자가 위임받아 부여 가능한 역할
 	CreatedAt       time.Time                   `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time                   `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 	Parent          *RoleMaster                 `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
@@ -42,11 +43,16 @@ func (RoleSub) TableName() string {
 
 // UserPlatformRole 사용자-역할 매핑 모델 (DB 테이블: mcmp_user_platform_roles)
 type UserPlatformRole struct {
-	UserID    uint       `json:"user_id" gorm:"primaryKey;column:user_id"`
-	RoleID    uint       `json:"role_id" gorm:"primaryKey;column:role_id"`
-	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
-	User      User       `json:"-" gorm:"foreignKey:UserID"`
-	Role      RoleMaster `json:"-" gorm:"foreignKey:RoleID"`
+	UserID    uint      `json:"user_id" gorm:"primaryKey;column:user_id"`
+	RoleID    uint      `json:"role_id" gorm:"primaryKey;column:role_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+
+	// 유효 기간 (nil 이면 즉시/무기한), 시작 전이면 ActivationPending (Keycloak realm role 은 시작 시 부여)
+	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"column:valid_from"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty" gorm:"column:expires_at;index"`
+	ActivationPending bool       `json:"activation_pending,omitempty" gorm:"column:activation_pending;not null;default:false"`
+	Role              RoleMaster `json:"-" gorm:"foreignKey:RoleID"`
 
 	// 사용자 정보 (JOIN으로 가져올 필드들)
 	Username string `json:"username" gorm:"column:username"`
@@ -60,16 +66,21 @@ func (UserPlatformRole) TableName() string {
 // UserWorkspaceRole 사용자-워크스페이스-역할 매핑 모델 (DB 테이블: mcmp_user_workspace_roles)
 // 사용자 기준으로 workspace와 role 을 표시 . workspace 기준으로는 WorkspaceWithProjects 를 사용
 type UserWorkspaceRole struct {
-	UserID        uint        `json:"user_id" gorm:"primaryKey;column:user_id"`
-	WorkspaceID   uint        `json:"workspace_id" gorm:"primaryKey;column:workspace_id"`
-	RoleID        uint        `json:"role_id" gorm:"primaryKey;column:role_id"`
-	Username      string      `json:"username" gorm:"column:username"`
-	WorkspaceName string      `json:"workspace_name" gorm:"column:workspace_name"`
-	RoleName      string      `json:"role_name" gorm:"column:role_name"`
-	CreatedAt     time.Time   `json:"created_at" gorm:"column:created_at;autoCreateTime"`
-	User          *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	Workspace     *Workspace  `json:"workspace,omitempty" gorm:"foreignKey:WorkspaceID;references:ID"`
-	Role          *RoleMaster `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+	UserID        uint      `json:"user_id" gorm:"primaryKey;column:user_id"`
+	WorkspaceID   uint      `json:"workspace_id" gorm:"primaryKey;column:workspace_id"`
+	RoleID        uint      `json:"role_id" gorm:"primaryKey;column:role_id"`
+	Username      string    `json:"username" gorm:"column:username"`
+	WorkspaceName string    `json:"workspace_name" gorm:"column:workspace_name"`
+	RoleName      string    `json:"role_name" gorm:"column:role_name"`
+	CreatedAt     time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	User          *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	// 유효 기간 (nil 이면 즉시/무기한), 시작 전이면 ActivationPending
+	ValidFrom         *time.Time  `json:"valid_from,omitempty" gorm:"column:valid_from"`
+	ExpiresAt         *time.Time  `json:"expires_at,omitempty" gorm:"column:expires_at;index"`
+	ActivationPending bool        `json:"activation_pending,omitempty" gorm:"column:activation_pending;not null;default:false"`
+	Workspace         *Workspace  `json:"workspace,omitempty" gorm:"foreignKey:WorkspaceID;references:ID"`
+	Role              *RoleMaster `json:"role,omitempty" gorm:"foreignKey:RoleID"`
 }
 
 // TableName UserWorkspaceRole의 테이블 이름을 반환
@@ -85,6 +96,7 @@ type RoleMasterCspRoleMapping struct {
 	AuthMethod  constants.AuthMethod `json:"auth_method" gorm:"column:auth_method;primaryKey"`
 	CspRoleID   uint                 `json:"-" gorm:"column:csp_role_id;primaryKey;foreignKey:ID;references:mcmp_csp_roles"`
 	Description string               `json:"description" gorm:"column:description"`
+	Conditions  *GrantConditions     `json:"conditions,omitempty" gorm:"column:conditions;type:jsonb"` // 자격 증명 발급 조건 (없으면 항상 적용)
 	CreatedAt   time.Time            `json:"createdAt" gorm:"column:created_at"`
 	CspRoles    []*CspRole           `json:"cspRoles" gorm:"-"` // 서비스 레이어에서 조합
 }
@@ -110,3 +122,53 @@ type EffectiveWorkspaceRole struct {
 	RoleID        uint   `json:"role_id"`
 	RoleName      string `json:"role_name"`
 }
+
+// RoleLineageEntry 역할 계층 경로의 한 단계 (depth 0 = 조회한 역할 자신)
+type RoleLineageEntry struct {
+	RoleID   uint   `json:"roleId"`
+	RoleName string `json:"roleName"`
+	Depth    int    `json:"depth"`
+}
+
+// ResolvedRoleSource 상속 해석된 항목의 출처 역할
+type ResolvedRoleSource struct {
+	SourceRoleID   uint   `json:"sourceRoleId"`
+	SourceRoleName string `json:"sourceRoleName"`
+	Inherited      bool   `json:"inherited"` // 상위 역할에서 상속된 항목이면 true
+}
+
+// ResolvedRolePermission 상속 해석된 MC-IAM 권한
+type ResolvedRolePermission struct {
+	PermissionID string                `json:"permissionId"`
+	RoleType     constants.IAMRoleType `json:"roleType"`
+	Effect       PermissionEffect      `json:"effect"`
+	Conditions   *GrantConditions      `json:"conditions,omitempty"`
+	ResolvedRoleSource
+}
+
+// ResolvedRoleMenu 상속 해석된 메뉴
+type ResolvedRoleMenu struct {
+	MenuID string `json:"menuId"`
+	ResolvedRoleSource
+}
+
+// ResolvedRoleCspMapping 상속 해석된 CSP 역할 매핑
+// 같은 CSP 타입/인증방식에 대해서는 가장 가까운 역할의 매핑만 유효하다.
+type ResolvedRoleCspMapping struct {
+	CspRoleID   uint                 `json:"cspRoleId"`
+	CspRoleName string               `json:"cspRoleName"`
+	CspType     string               `json:"cspType"`
+	AuthMethod  constants.AuthMethod `json:"authMethod"`
+	Conditions  *GrantConditions     `json:"conditions,omitempty"`
+	ResolvedRoleSource
+}
+
+// ResolvedRolePermissions 역할 계층(ParentID)을 따라 해석한 역할의 전체 권한 집합
+type ResolvedRolePermissions struct {
+	RoleID          uint                     `json:"roleId"`
+	RoleName        string                   `json:"roleName"`
+	Lineage         []RoleLineageEntry       `json:"lineage"`
+	Permissions     []ResolvedRolePermission `json:"permissions"`
+	Menus           []ResolvedRoleMenu       `json:"menus"`
+	CspRoleMappings []ResolvedRoleCspMapping `json:"cspRoleMappings"`
+}
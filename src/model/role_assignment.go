@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// 기한부 역할 할당 유형 (만료/시작 처리 대상)
+const (
+	RoleAssignmentUserPlatform   = "user-platform-role"
+	RoleAssignmentUserWorkspace  = "user-workspace-role"
+	RoleAssignmentGroupPlatform  = "group-platform-role"
+	RoleAssignmentGroupWorkspace = "group-workspace-role"
+)
+
+// AssignmentValidity 역할 할당 유효 기간 (nil 이면 즉시 시작/무기한)
+type AssignmentValidity struct {
+	ValidFrom *time.Time
+	ExpiresAt *time.Time
+}
+
+// IsZero 유효 기간 지정 여부
+func (v AssignmentValidity) IsZero() bool {
+	return v.ValidFrom == nil && v.ExpiresAt == nil
+}
+
+// PendingAt 시각 at 에 아직 시작 전인지
+func (v AssignmentValidity) PendingAt(at time.Time) bool {
+	return v.ValidFrom != nil && v.ValidFrom.After(at)
+}
+
+// TimedRoleAssignment 시작/만료 처리할 할당 한 건 (4개 할당 테이블 공통)
+type TimedRoleAssignment struct {
+	Type        string     `json:"type" gorm:"column:assignment_type"`
+	UserID      uint       `json:"userId,omitempty" gorm:"column:user_id"`
+	Username    string     `json:"username,omitempty" gorm:"column:username"`
+	KcUserID    string     `json:"kcUserId,omitempty" gorm:"column:kc_id"`
+	GroupID     uint       `json:"groupId,omitempty" gorm:"column:group_id"`
+	GroupName   string     `json:"groupName,omitempty" gorm:"column:group_name"`
+	WorkspaceID uint       `json:"workspaceId,omitempty" gorm:"column:workspace_id"`
+	RoleID      uint       `json:"roleId" gorm:"column:role_id"`
+	RoleName    string     `json:"roleName" gorm:"column:role_name"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty" gorm:"column:valid_from"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	Pending     bool       `json:"activationPending,omitempty" gorm:"column:activation_pending"`
+}
+
+// RoleAssignmentRunResult 기한부 할당 처리 1회 결과
+type RoleAssignmentRunResult struct {
+	Activated []TimedRoleAssignment `json:"activated"`
+	Expired   []TimedRoleAssignment `json:"expired"`
+	Failed    map[string]string     `json:"failed,omitempty"` // 할당 설명 → 오류
+}
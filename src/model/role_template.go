@@ -0,0 +1,53 @@
+package model
+
+import "github.com/m-cmp/mc-iam-manager/constants"
+
+// 역할 복제 대상 항목 (비어 있으면 전체)
+const (
+	RoleCloneSectionRoleTypes  = "roleTypes"  // RoleSub 타입
+	RoleCloneSectionMenus      = "menus"      // RoleMenuMapping
+	RoleCloneSectionOperations = "operations" // MciamRoleMciamPermission (허용·거부, 조건 포함)
+	RoleCloneSectionCsps       = "csps"       // RoleMasterCspRoleMapping
+)
+
+// CloneRoleRequest 역할 복제 요청
+type CloneRoleRequest struct {
+	Name        string   `json:"name" validate:"required"`
+	Description *string  `json:"description,omitempty"` // 없으면 원본 설명 사용
+	Sections    []string `json:"sections,omitempty"`    // roleTypes, menus, operations, csps (기본: 전체)
+}
+
+// RoleTemplateCspRole 템플릿 역할에 매핑할 기존 CSP 역할 (csp_type + 이름으로 조회)
+type RoleTemplateCspRole struct {
+	CspType    string               `json:"cspType" yaml:"cspType"`
+	Name       string               `json:"name" yaml:"name"`
+	AuthMethod constants.AuthMethod `json:"authMethod,omitempty" yaml:"authMethod,omitempty"` // 기본 OIDC
+}
+
+// RoleTemplate 이름 있는 역할 템플릿 (asset/role-templates/*.yaml)
+// operations/denies 는 플랫폼 역할, workspaceOperations/workspaceDenies 는 워크스페이스 역할 권한으로 매핑한다.
+type RoleTemplate struct {
+	Name                string                  `json:"name" yaml:"name"`
+	Description         string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	RoleTypes           []constants.IAMRoleType `json:"roleTypes" yaml:"roleTypes"`
+	Delegable           bool                    `json:"delegable,omitempty" yaml:"delegable,omitempty"`
+	Menus               []string                `json:"menus,omitempty" yaml:"menus,omitempty"`
+	Operations          []string                `json:"operations,omitempty" yaml:"operations,omitempty"`
+	Denies              []string                `json:"denies,omitempty" yaml:"denies,omitempty"`
+	WorkspaceOperations []string                `json:"workspaceOperations,omitempty" yaml:"workspaceOperations,omitempty"`
+	WorkspaceDenies     []string                `json:"workspaceDenies,omitempty" yaml:"workspaceDenies,omitempty"`
+	Csps                []RoleTemplateCspRole   `json:"csps,omitempty" yaml:"csps,omitempty"`
+	Source              string                  `json:"source" yaml:"-"` // 템플릿 파일 이름
+}
+
+// RoleTemplateFile 역할 템플릿 YAML 파일 구조 (templates → name → roleTypes | menus | operations | ...)
+type RoleTemplateFile struct {
+	Templates []RoleTemplate `yaml:"templates"`
+}
+
+// InstantiateRoleTemplateRequest 템플릿으로 역할 생성 요청
+type InstantiateRoleTemplateRequest struct {
+	Name        string  `json:"name" validate:"required"`
+	Description *string `json:"description,omitempty"` // 없으면 템플릿 설명 사용
+	ParentID    *uint   `json:"parentId,omitempty"`
+}
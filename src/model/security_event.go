@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// 보안 이벤트 종류 (감사 이벤트는 감사 동작 이름을 그대로 사용)
+const (
+	SecurityEventLogin              = "auth.login"
+	SecurityEventCredentialIssue    = "credential.issue"
+	SecurityEventBreakGlassActivity = "break-glass.activity"
+)
+
+// 보안 이벤트 분류
+const (
+	SecurityCategoryAuthentication = "authentication"
+	SecurityCategoryAuthorization  = "authorization" // 역할 할당/회수
+	SecurityCategoryAccount        = "account"       // 초대 승인, 탈퇴
+	SecurityCategoryCredential     = "credential"
+	SecurityCategoryAudit          = "audit"
+)
+
+// SecurityEvent 외부 싱크(JSONL 파일, syslog 등)로 내보내는 IAM 보안 이벤트
+// 감사 이벤트/발급 기록에서 만든 이벤트는 RecordID/RecordHash 로 원본 해시 체인 기록과 대조할 수 있다.
+type SecurityEvent struct {
+	ID            string            `json:"id"` // "<출처>:<기록 ID>" 또는 임의 ID
+	Time          time.Time         `json:"time"`
+	Type          string            `json:"type"`
+	Category      string            `json:"category"`
+	Severity      int               `json:"severity"` // 0-10 (CEF 기준)
+	Outcome       string            `json:"outcome"`  // success, failure
+	ActorKcID     string            `json:"actorKcId,omitempty"`
+	ActorUsername string            `json:"actorUsername,omitempty"`
+	SourceIP      string            `json:"sourceIp,omitempty"`
+	UserAgent     string            `json:"userAgent,omitempty"`
+	TargetType    string            `json:"targetType,omitempty"`
+	TargetID      string            `json:"targetId,omitempty"`
+	WorkspaceID   *uint             `json:"workspaceId,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	Reason        string            `json:"reason,omitempty"` // 실패 사유
+	RecordID      uint              `json:"recordId,omitempty"`
+	RecordHash    string            `json:"recordHash,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// EventSinkStatus 이벤트 싱크 전달 현황
+type EventSinkStatus struct {
+	Name      string     `json:"name"`
+	Queued    int        `json:"queued"`   // 전달 대기 중인 이벤트 수
+	Capacity  int        `json:"capacity"` // 대기열 크기
+	Delivered uint64     `json:"delivered"`
+	Retried   uint64     `json:"retried"`
+	Dropped   uint64     `json:"dropped"` // 대기열이 가득 차거나 재시도를 모두 실패해 버린 이벤트 수
+	LastError string     `json:"lastError,omitempty"`
+	LastErrAt *time.Time `json:"lastErrorAt,omitempty"`
+}
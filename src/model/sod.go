@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// SodConstraint 직무 분리(SoD) 제약 (DB 테이블: mcmp_sod_constraints)
+// RoleIDs 중 두 개 이상을 같은 맥락(플랫폼 역할 + 한 워크스페이스의 역할)에서 함께 보유할 수 없다.
+// 보유 역할은 직접 할당과 그룹(조직) 상속을 모두 포함한다.
+type SodConstraint struct {
+	ID          uint                      `json:"id" gorm:"primaryKey;column:id"`
+	Name        string                    `json:"name" gorm:"column:name;size:255;not null;uniqueIndex"`
+	Description string                    `json:"description,omitempty" gorm:"column:description;type:text"`
+	RoleIDs     datatypes.JSONSlice[uint] `json:"roleIds" gorm:"column:role_ids;type:jsonb;not null"`
+	Enabled     bool                      `json:"enabled" gorm:"column:enabled;not null"`
+	CreatedAt   time.Time                 `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time                 `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	RoleNames   []string                  `json:"roleNames,omitempty" gorm:"-"`
+}
+
+// TableName SodConstraint의 테이블 이름 지정
+func (SodConstraint) TableName() string {
+	return "mcmp_sod_constraints"
+}
+
+// SodOverride 사용자별 SoD 제약 예외 승인 (DB 테이블: mcmp_sod_overrides)
+// 유효한 예외가 있으면 해당 제약을 위반하는 할당을 허용하고, 위반 보고서에는 예외로 표시한다.
+type SodOverride struct {
+	ID                 uint       `json:"id" gorm:"primaryKey;column:id"`
+	ConstraintID       uint       `json:"constraintId" gorm:"column:constraint_id;not null;index"`
+	UserID             uint       `json:"userId" gorm:"column:user_id;not null;index"`
+	Justification      string     `json:"justification" gorm:"column:justification;type:text;not null"`
+	ApprovedByKcID     string     `json:"approvedByKcId" gorm:"column:approved_by_kc_id;size:255"`
+	ApprovedByUsername string     `json:"approvedByUsername,omitempty" gorm:"column:approved_by_username;size:255"`
+	CreatedAt          time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	ExpiresAt          *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"` // 비어 있으면 만료 없음
+	RevokedAt          *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	RevokedByKcID      string     `json:"revokedByKcId,omitempty" gorm:"column:revoked_by_kc_id;size:255"`
+}
+
+// TableName SodOverride의 테이블 이름 지정
+func (SodOverride) TableName() string {
+	return "mcmp_sod_overrides"
+}
+
+// SodConstraintRequest SoD 제약 생성/수정 요청 (roleIds 와 roleNames 는 합쳐서 적용)
+type SodConstraintRequest struct {
+	Name        string   `json:"name" validate:"required,max=255"`
+	Description string   `json:"description"`
+	RoleIDs     []uint   `json:"roleIds"`
+	RoleNames   []string `json:"roleNames"`
+	Enabled     *bool    `json:"enabled"` // 비어 있으면 true
+}
+
+// CreateSodOverrideRequest SoD 예외 승인 요청
+type CreateSodOverrideRequest struct {
+	ConstraintID  uint       `json:"constraintId" validate:"required"`
+	UserID        uint       `json:"userId" validate:"required"`
+	Justification string     `json:"justification" validate:"required"`
+	ExpiresAt     *time.Time `json:"expiresAt"`
+}
+
+// SodRoleHolding 위반에 관여한 역할과 보유 경로
+type SodRoleHolding struct {
+	RoleID   uint   `json:"roleId"`
+	RoleName string `json:"roleName"`
+	Source   string `json:"source"` // direct, group:<그룹명>, proposed
+}
+
+// SodViolation SoD 제약 위반 (WorkspaceID 가 없으면 플랫폼 역할 간 위반)
+type SodViolation struct {
+	ConstraintID   uint             `json:"constraintId"`
+	ConstraintName string           `json:"constraintName"`
+	UserID         uint             `json:"userId"`
+	Username       string           `json:"username,omitempty"`
+	WorkspaceID    *uint            `json:"workspaceId,omitempty"`
+	Roles          []SodRoleHolding `json:"roles"`
+	OverrideID     *uint            `json:"overrideId,omitempty"` // 예외 승인으로 허용된 경우
+}
@@ -30,6 +30,14 @@ type User struct {
 	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 
+	// 활동 기록 (휴면 계정 정책)
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty" gorm:"column:last_login_at"`             // 마지막 로그인
+	LastRefreshAt     *time.Time `json:"last_refresh_at,omitempty" gorm:"column:last_refresh_at"`         // 마지막 토큰 갱신
+	LastTokenUseAt    *time.Time `json:"last_token_use_at,omitempty" gorm:"column:last_token_use_at"`     // 마지막 토큰 사용 (기록 간격만큼 지연될 수 있음)
+	LastActivityAt    *time.Time `json:"last_activity_at,omitempty" gorm:"column:last_activity_at;index"` // 위 세 시각 중 가장 최근
+	DormancyWarnedAt  *time.Time `json:"dormancy_warned_at,omitempty" gorm:"column:dormancy_warned_at"`   // 휴면 경고 발송 시각, 활동 시 초기화
+	DormancyExemption string     `json:"dormancy_exemption,omitempty" gorm:"column:dormancy_exemption;size:50"` // 휴면 정책 예외 사유 (빈 값이면 적용 대상)
+
 	// 관계 정의
 	PlatformRoles  []*RoleMaster `json:"platform_roles,omitempty" gorm:"many2many:mcmp_user_platform_roles;foreignKey:ID;joinForeignKey:user_id;References:ID;joinReferences:role_id;joinTable:mcmp_user_platform_roles;where:role_type='platform'"`
 	WorkspaceRoles []*RoleMaster `json:"workspace_roles,omitempty" gorm:"many2many:mcmp_user_workspace_roles;foreignKey:ID;joinForeignKey:user_id;References:ID;joinReferences:role_id;joinTable:mcmp_user_workspace_roles;where:role_type='workspace'"`
@@ -0,0 +1,201 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// 승인 워크플로 요청 유형
+const (
+	WorkflowTypeWorkspaceInvitation = "workspace-invitation" // 초대 수락 후 워크스페이스 가입
+	WorkflowTypeUserSignup          = "user-signup"          // 가입 승인 (Keycloak 사용자 활성화)
+	WorkflowTypeUserWithdrawal      = "user-withdrawal"      // 탈퇴 처리
+	WorkflowTypeRoleAssignment      = "role-assignment"      // 플랫폼/워크스페이스/그룹 역할 할당, 그룹 구성원 추가
+	WorkflowTypeCspRoleMapping      = "csp-role-mapping"     // 역할-CSP 역할 매핑 추가/삭제
+)
+
+// WorkflowTypes 워크플로를 지원하는 요청 유형
+var WorkflowTypes = []string{
+	WorkflowTypeWorkspaceInvitation,
+	WorkflowTypeUserSignup,
+	WorkflowTypeUserWithdrawal,
+	WorkflowTypeRoleAssignment,
+	WorkflowTypeCspRoleMapping,
+}
+
+// 승인 워크플로 요청 상태
+const (
+	WorkflowPending   = "pending"   // 승인 대기
+	WorkflowApproved  = "approved"  // 필요한 승인 수를 채우고 실제 작업까지 적용됨
+	WorkflowRejected  = "rejected"  // 승인자 거절
+	WorkflowCancelled = "cancelled" // 요청자 취소
+	WorkflowExpired   = "expired"   // 승인 기한 경과 (상향 후에도 결정 없음)
+	WorkflowFailed    = "failed"    // 승인되었으나 실제 작업 적용 실패 (resultError 참고)
+)
+
+// 승인 워크플로 결정
+const (
+	WorkflowDecisionApprove = "approve"
+	WorkflowDecisionReject  = "reject"
+)
+
+// 승인자 규칙 종류
+const (
+	WorkflowApproverPlatformRole   = "platform-role"   // 지정한 플랫폼 역할 보유자
+	WorkflowApproverWorkspaceAdmin = "workspace-admin" // 요청 워크스페이스에서 워크플로 승인 권한을 가진 구성원
+	WorkflowApproverOrgManager     = "org-manager"     // 대상 사용자와 같은 조직 소속이며 워크플로 승인 권한 보유
+)
+
+// 역할 할당 워크플로의 역할 종류
+const (
+	WorkflowRolePlatform       = "platform"
+	WorkflowRoleWorkspace      = "workspace"
+	WorkflowRoleGroupPlatform  = "group-platform"  // 그룹 플랫폼 역할
+	WorkflowRoleGroupWorkspace = "group-workspace" // 그룹-워크스페이스 역할 매핑
+	WorkflowRoleGroupMember    = "group-member"    // 그룹 구성원 추가 (그룹 상속 역할)
+)
+
+// CSP 역할 매핑 워크플로 작업
+const (
+	WorkflowCspMappingAdd    = "add"
+	WorkflowCspMappingRemove = "remove"
+)
+
+// WorkflowApproverRule 승인자 규칙 (규칙 중 하나라도 만족하면 승인자)
+type WorkflowApproverRule struct {
+	Kind     string `json:"kind"`               // platform-role, workspace-admin, org-manager
+	RoleName string `json:"roleName,omitempty"` // platform-role 일 때 역할 이름
+}
+
+// WorkflowPolicy 요청 유형별 승인 정책 (DB 테이블: mcmp_workflow_policies)
+// 행이 없거나 비활성화된 유형은 승인 절차 없이 기존처럼 즉시 처리된다.
+type WorkflowPolicy struct {
+	Type              string                                    `json:"type" gorm:"primaryKey;column:type;size:50"`
+	Enabled           bool                                      `json:"enabled" gorm:"column:enabled;not null;default:false"`
+	ApproverRules     datatypes.JSONSlice[WorkflowApproverRule] `json:"approverRules" gorm:"column:approver_rules;type:jsonb"`
+	RequiredApprovals int                                       `json:"requiredApprovals" gorm:"column:required_approvals;not null;default:1"` // N-of-M 의 N
+	TimeoutMinutes    int                                       `json:"timeoutMinutes" gorm:"column:timeout_minutes;not null;default:0"`       // 0 이면 기한 없음
+	EscalationRules   datatypes.JSONSlice[WorkflowApproverRule] `json:"escalationRules" gorm:"column:escalation_rules;type:jsonb"`             // 기한 경과 시 추가되는 승인자
+	UpdatedBy         string                                    `json:"updatedBy,omitempty" gorm:"column:updated_by;size:255"`
+	UpdatedAt         time.Time                                 `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName WorkflowPolicy의 테이블 이름 지정
+func (WorkflowPolicy) TableName() string {
+	return "mcmp_workflow_policies"
+}
+
+// WorkflowRequest 승인 워크플로 요청 (DB 테이블: mcmp_workflow_requests)
+// 필요한 승인 수는 요청 시점 정책 값으로 고정하고, 승인되면 유형별 작업을 실행한다.
+type WorkflowRequest struct {
+	ID                uint               `json:"id" gorm:"primaryKey;column:id"`
+	Type              string             `json:"type" gorm:"column:type;size:50;not null;index"`
+	Key               string             `json:"key" gorm:"column:request_key;size:255;not null;index"` // 같은 대상에 대한 중복 요청 판별
+	Status            string             `json:"status" gorm:"column:status;size:20;not null;index"`
+	Summary           string             `json:"summary" gorm:"column:summary;type:text"`
+	Payload           datatypes.JSON     `json:"payload" gorm:"column:payload;type:jsonb"`
+	RequesterID       uint               `json:"requesterId" gorm:"column:requester_id;not null;index"`
+	RequesterUsername string             `json:"requesterUsername" gorm:"column:requester_username;size:255"`
+	SubjectUserID     *uint              `json:"subjectUserId,omitempty" gorm:"column:subject_user_id;index"` // 요청 대상 사용자
+	SubjectUsername   string             `json:"subjectUsername,omitempty" gorm:"column:subject_username;size:255"`
+	WorkspaceID       *uint              `json:"workspaceId,omitempty" gorm:"column:workspace_id;index"`
+	RequiredApprovals int                `json:"requiredApprovals" gorm:"column:required_approvals;not null"`
+	Approvals         int                `json:"approvals" gorm:"column:approvals;not null;default:0"`
+	EscalationLevel   int                `json:"escalationLevel" gorm:"column:escalation_level;not null;default:0"`
+	DueAt             *time.Time         `json:"dueAt,omitempty" gorm:"column:due_at;index"`
+	DecidedAt         *time.Time         `json:"decidedAt,omitempty" gorm:"column:decided_at"`
+	ResultError       string             `json:"resultError,omitempty" gorm:"column:result_error;type:text"`
+	CreatedAt         time.Time          `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time          `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	Decisions         []WorkflowDecision `json:"decisions,omitempty" gorm:"foreignKey:RequestID"`
+	Comments          []WorkflowComment  `json:"comments,omitempty" gorm:"foreignKey:RequestID"`
+}
+
+// TableName WorkflowRequest의 테이블 이름 지정
+func (WorkflowRequest) TableName() string {
+	return "mcmp_workflow_requests"
+}
+
+// WorkflowDecision 승인자별 결정 (DB 테이블: mcmp_workflow_decisions, 요청당 승인자 1회)
+type WorkflowDecision struct {
+	ID               uint      `json:"id" gorm:"primaryKey;column:id"`
+	RequestID        uint      `json:"requestId" gorm:"column:request_id;not null;uniqueIndex:idx_workflow_decision_approver"`
+	ApproverID       uint      `json:"approverId" gorm:"column:approver_id;not null;uniqueIndex:idx_workflow_decision_approver"`
+	ApproverUsername string    `json:"approverUsername" gorm:"column:approver_username;size:255"`
+	Decision         string    `json:"decision" gorm:"column:decision;size:20;not null"`
+	Comment          string    `json:"comment,omitempty" gorm:"column:comment;type:text"`
+	CreatedAt        time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName WorkflowDecision의 테이블 이름 지정
+func (WorkflowDecision) TableName() string {
+	return "mcmp_workflow_decisions"
+}
+
+// WorkflowComment 요청에 남긴 의견 (DB 테이블: mcmp_workflow_comments)
+type WorkflowComment struct {
+	ID             uint      `json:"id" gorm:"primaryKey;column:id"`
+	RequestID      uint      `json:"requestId" gorm:"column:request_id;not null;index"`
+	AuthorID       uint      `json:"authorId" gorm:"column:author_id;not null"`
+	AuthorUsername string    `json:"authorUsername" gorm:"column:author_username;size:255"`
+	Body           string    `json:"body" gorm:"column:body;type:text;not null"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName WorkflowComment의 테이블 이름 지정
+func (WorkflowComment) TableName() string {
+	return "mcmp_workflow_comments"
+}
+
+// WorkflowDecisionRequest 승인자 결정
+type WorkflowDecisionRequest struct {
+	Decision string `json:"decision"` // approve, reject
+	Comment  string `json:"comment,omitempty"`
+}
+
+// WorkflowCommentRequest 의견 작성
+type WorkflowCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// WorkflowFilter 워크플로 요청 목록 조회 조건
+type WorkflowFilter struct {
+	Type        string `query:"type"`
+	Status      string `query:"status"`
+	RequesterID uint   `query:"requesterId"`
+	WorkspaceID uint   `query:"workspaceId"`
+}
+
+// 유형별 요청 내용 (WorkflowRequest.Payload)
+
+// WorkflowInvitationPayload 초대 가입 승인
+type WorkflowInvitationPayload struct {
+	InvitationID uint `json:"invitationId"`
+}
+
+// WorkflowUserPayload 가입 승인/탈퇴 처리 대상 사용자
+type WorkflowUserPayload struct {
+	UserID   uint   `json:"userId"`
+	KcUserID string `json:"kcUserId"`
+}
+
+// WorkflowRoleAssignmentPayload 역할 할당
+type WorkflowRoleAssignmentPayload struct {
+	RoleType    string     `json:"roleType"` // platform, workspace, group-platform, group-workspace, group-member
+	UserID      uint       `json:"userId"`
+	RoleID      uint       `json:"roleId"`
+	RoleName    string     `json:"roleName,omitempty"`
+	WorkspaceID uint       `json:"workspaceId,omitempty"`
+	GroupID     uint       `json:"groupId,omitempty"`
+	GroupIDs    []uint     `json:"groupIds,omitempty"` // group-member: 사용자(userId)를 여러 그룹에 추가
+	UserIDs     []uint     `json:"userIds,omitempty"`  // group-member: 그룹(groupId)에 여러 사용자를 추가
+	ValidFrom   *time.Time `json:"validFrom,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// WorkflowCspRoleMappingPayload 역할-CSP 역할 매핑 추가/삭제
+type WorkflowCspRoleMappingPayload struct {
+	Operation string                                `json:"operation"` // add, remove
+	Mapping   CreateRoleMasterCspRoleMappingRequest `json:"mapping"`
+}
@@ -9,6 +9,7 @@ type Workspace struct {
 	ID          uint       `json:"id" gorm:"primaryKey;column:id"`
 	Name        string     `json:"name" gorm:"column:name;size:255;not null"`
 	Description string     `json:"description" gorm:"column:description;size:1000"`
+	Labels      Labels     `json:"labels,omitempty" gorm:"column:labels;type:jsonb"` // 조건부 권한 평가용 라벨
 	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 	Projects    []*Project `json:"projects,omitempty" gorm:"many2many:mcmp_workspace_projects;"`
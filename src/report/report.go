@@ -0,0 +1,77 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 지원하는 출력 형식
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Table 보고서 한 장 (머리글 + 행, 모든 값은 문자열)
+type Table struct {
+	Name    string // 시트 이름/파일 이름에 사용
+	Columns []string
+	Rows    [][]string
+}
+
+// ContentType 형식별 MIME 타입
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// ValidFormat 지원하는 형식인지 여부
+func ValidFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+// Write 형식에 맞게 보고서 출력
+func (t *Table) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatCSV:
+		return t.WriteCSV(w)
+	case FormatXLSX:
+		return t.WriteXLSX(w)
+	}
+	return fmt.Errorf("unsupported report format %q", format)
+}
+
+// WriteCSV 머리글 행을 포함한 CSV 출력
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		if err := cw.Write(EscapeCSVRecord(row)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// EscapeCSVRecord 스프레드시트가 수식으로 실행하지 않도록 각 셀을 EscapeCSVCell 로 변환한 복사본
+func EscapeCSVRecord(record []string) []string {
+	escaped := make([]string, len(record))
+	for i, v := range record {
+		escaped[i] = EscapeCSVCell(v)
+	}
+	return escaped
+}
+
+// EscapeCSVCell =, +, -, @, 탭, CR 로 시작하는 값 앞에 ' 를 붙여 Excel 등에서 수식으로 해석되지 않게 함
+func EscapeCSVCell(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
@@ -0,0 +1,120 @@
+package report
+
+// report_test.go
+//
+// 보고서 출력 테스트
+// CSV 머리글/행, XLSX 패키지 구성과 셀 값(이스케이프, 열 이름, 시트 이름 규칙)을 검증한다.
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() *Table {
+	return &Table{
+		Name:    "privileged-users",
+		Columns: []string{"user_id", "username", "source"},
+		Rows: [][]string{
+			{"1", "alice", "direct"},
+			{"2", "bob <ops> & co", "group:admins"},
+		},
+	}
+}
+
+// TC-RPT-CSV-01: 머리글 + 행 순서대로 출력
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testTable().Write(&buf, FormatCSV))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"user_id", "username", "source"},
+		{"1", "alice", "direct"},
+		{"2", "bob <ops> & co", "group:admins"},
+	}, records)
+}
+
+// TC-RPT-CSV-02: =, +, -, @ 로 시작하는 셀은 ' 를 붙여 수식 실행 방지
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	table := &Table{
+		Columns: []string{"username", "group", "comment"},
+		Rows:    [][]string{{"=cmd|' /C calc'!A0", "@admins", "-1+1"}, {"+alice", "ops", "a=b"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, table.Write(&buf, FormatCSV))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"username", "group", "comment"},
+		{"'=cmd|' /C calc'!A0", "'@admins", "'-1+1"},
+		{"'+alice", "ops", "a=b"},
+	}, records)
+	assert.Equal(t, "+alice", table.Rows[1][0], "rows are not modified in place")
+}
+
+// TC-RPT-XLSX-01: 필수 파트 포함, 시트 셀 값은 원문 그대로 복원
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testTable().Write(&buf, FormatXLSX))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = body
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, string(files["xl/workbook.xml"]), `name="privileged-users"`)
+
+	var sheet struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R     string `xml:"r,attr"`
+				Value string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 3)
+	assert.Equal(t, "3", sheet.Rows[2].R)
+	assert.Equal(t, "B3", sheet.Rows[2].Cells[1].R)
+	assert.Equal(t, "bob <ops> & co", sheet.Rows[2].Cells[1].Value)
+	assert.Equal(t, "source", sheet.Rows[0].Cells[2].Value)
+}
+
+// TC-RPT-XLSX-02: 열 이름과 시트 이름 변환
+func TestXLSXNames(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+
+	assert.Equal(t, "a-b-c", sheetName("a/b:c"))
+	assert.Equal(t, "Sheet1", sheetName(""))
+	assert.Len(t, []rune(sheetName("unmapped-csp-roles-with-a-very-long-name")), 31)
+}
+
+// TC-RPT-FMT-01: 지원하지 않는 형식은 오류
+func TestWriteUnsupportedFormat(t *testing.T) {
+	assert.Error(t, testTable().Write(io.Discard, "pdf"))
+	assert.False(t, ValidFormat("pdf"))
+	assert.True(t, ValidFormat(FormatXLSX))
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAccessReviewCampaignNotFound = errors.New("access review campaign not found")
+	ErrAccessReviewItemNotFound     = errors.New("access review item not found")
+)
+
+// AccessReviewScope 검토 대상 할당 조회 조건 (비어 있는 조건은 제한 없음)
+type AccessReviewScope struct {
+	WorkspaceIDs []uint
+	RoleIDs      []uint
+	UserIDs      []uint // 사용자 할당 대상 (조직 범위의 구성원)
+	GroupIDs     []uint // 그룹 할당 대상 (조직 범위)
+}
+
+// AccessReviewRepository 접근 검토 캠페인/항목 저장과 검토 대상 할당 조회
+type AccessReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessReviewRepository AccessReviewRepository 생성
+func NewAccessReviewRepository(db *gorm.DB) *AccessReviewRepository {
+	return &AccessReviewRepository{db: db}
+}
+
+// CreateCampaign 캠페인과 항목을 함께 저장
+func (r *AccessReviewRepository) CreateCampaign(campaign *model.AccessReviewCampaign, items []model.AccessReviewItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return fmt.Errorf("error creating access review campaign: %w", err)
+		}
+		for i := range items {
+			items[i].CampaignID = campaign.ID
+		}
+		if len(items) > 0 {
+			if err := tx.CreateInBatches(items, 200).Error; err != nil {
+				return fmt.Errorf("error creating access review items: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindCampaignByID ID로 캠페인 조회
+func (r *AccessReviewRepository) FindCampaignByID(id uint) (*model.AccessReviewCampaign, error) {
+	var campaign model.AccessReviewCampaign
+	if err := r.db.First(&campaign, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccessReviewCampaignNotFound
+		}
+		return nil, fmt.Errorf("error finding access review campaign %d: %w", id, err)
+	}
+	return &campaign, nil
+}
+
+// ListCampaigns 캠페인 목록 (status 가 비어 있으면 전체, 최신순)
+func (r *AccessReviewRepository) ListCampaigns(status string) ([]model.AccessReviewCampaign, error) {
+	query := r.db.Model(&model.AccessReviewCampaign{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var campaigns []model.AccessReviewCampaign
+	if err := query.Order("id DESC").Find(&campaigns).Error; err != nil {
+		return nil, fmt.Errorf("error listing access review campaigns: %w", err)
+	}
+	return campaigns, nil
+}
+
+// ListDueCampaigns 마감 시각이 지난 진행 중 캠페인
+func (r *AccessReviewRepository) ListDueCampaigns(now time.Time) ([]model.AccessReviewCampaign, error) {
+	var campaigns []model.AccessReviewCampaign
+	err := r.db.Where("status = ? AND due_at IS NOT NULL AND due_at <= ?", model.AccessReviewCampaignOpen, now).
+		Order("id").Find(&campaigns).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing due access review campaigns: %w", err)
+	}
+	return campaigns, nil
+}
+
+// CloseCampaign 진행 중인 캠페인을 종료 상태로 변경 (이미 종료되었으면 false)
+func (r *AccessReviewRepository) CloseCampaign(id uint, closedByKcID string, closedAt time.Time) (bool, error) {
+	result := r.db.Model(&model.AccessReviewCampaign{}).
+		Where("id = ? AND status = ?", id, model.AccessReviewCampaignOpen).
+		Updates(map[string]interface{}{
+			"status":          model.AccessReviewCampaignClosed,
+			"closed_at":       closedAt,
+			"closed_by_kc_id": closedByKcID,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("error closing access review campaign %d: %w", id, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListItems 캠페인 항목 조회 (ID 순)
+func (r *AccessReviewRepository) ListItems(campaignID uint, filter model.AccessReviewItemFilter) ([]model.AccessReviewItem, error) {
+	query := r.db.Where("campaign_id = ?", campaignID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	var items []model.AccessReviewItem
+	if err := query.Order("id").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("error listing access review items: %w", err)
+	}
+	return items, nil
+}
+
+// ListOpenItems 결정이 필요한 항목 (진행 중 캠페인의 pending, 종료된 캠페인의 escalated)
+func (r *AccessReviewRepository) ListOpenItems() ([]model.AccessReviewItem, error) {
+	var items []model.AccessReviewItem
+	err := r.db.Table(model.AccessReviewItem{}.TableName()+" AS i").
+		Select("i.*").
+		Joins("JOIN "+model.AccessReviewCampaign{}.TableName()+" AS c ON c.id = i.campaign_id").
+		Where("(c.status = ? AND i.status = ?) OR i.status = ?",
+			model.AccessReviewCampaignOpen, model.AccessReviewItemPending, model.AccessReviewItemEscalated).
+		Order("i.campaign_id, i.id").
+		Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing open access review items: %w", err)
+	}
+	return items, nil
+}
+
+// FindItemByID ID로 항목 조회
+func (r *AccessReviewRepository) FindItemByID(id uint) (*model.AccessReviewItem, error) {
+	var item model.AccessReviewItem
+	if err := r.db.First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccessReviewItemNotFound
+		}
+		return nil, fmt.Errorf("error finding access review item %d: %w", id, err)
+	}
+	return &item, nil
+}
+
+// UpdateItem 항목 저장
+func (r *AccessReviewRepository) UpdateItem(item *model.AccessReviewItem) error {
+	if err := r.db.Save(item).Error; err != nil {
+		return fmt.Errorf("error updating access review item %d: %w", item.ID, err)
+	}
+	return nil
+}
+
+// CountItemsByStatus 캠페인 항목 상태별 건수
+func (r *AccessReviewRepository) CountItemsByStatus(campaignID uint) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	err := r.db.Model(&model.AccessReviewItem{}).
+		Select("status, COUNT(*) AS count").
+		Where("campaign_id = ?", campaignID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("error counting access review items: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// OrganizationMembers 조직별 직접 소속 사용자 (사용자 ID → 처음 찾은 조직 ID)
+func (r *AccessReviewRepository) OrganizationMembers(organizationIDs []uint) (map[uint]uint, error) {
+	var rows []model.UserOrganization
+	if err := r.db.Where("organization_id IN ?", organizationIDs).Order("organization_id, user_id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error finding organization members: %w", err)
+	}
+	members := make(map[uint]uint, len(rows))
+	for _, row := range rows {
+		if _, ok := members[row.UserID]; !ok {
+			members[row.UserID] = row.OrganizationID
+		}
+	}
+	return members, nil
+}
+
+// IsOrganizationMember 사용자의 조직 직접 소속 여부
+func (r *AccessReviewRepository) IsOrganizationMember(userID, organizationID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.UserOrganization{}).
+		Where("user_id = ? AND organization_id = ?", userID, organizationID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking organization membership: %w", err)
+	}
+	return count > 0, nil
+}
+
+// accessReviewAssignmentRow 검토 대상 할당 조회 결과
+type accessReviewAssignmentRow struct {
+	UserID        *uint
+	Username      string
+	GroupID       *uint
+	GroupName     string
+	WorkspaceID   *uint
+	WorkspaceName string
+	RoleID        uint
+	RoleName      string
+	CreatedAt     *time.Time
+}
+
+func (row accessReviewAssignmentRow) item(assignmentType string) model.AccessReviewItem {
+	return model.AccessReviewItem{
+		AssignmentType: assignmentType,
+		UserID:         row.UserID,
+		Username:       row.Username,
+		GroupID:        row.GroupID,
+		GroupName:      row.GroupName,
+		WorkspaceID:    row.WorkspaceID,
+		WorkspaceName:  row.WorkspaceName,
+		RoleID:         row.RoleID,
+		RoleName:       row.RoleName,
+		AssignedAt:     row.CreatedAt,
+		Status:         model.AccessReviewItemPending,
+	}
+}
+
+// FindUserPlatformRoleAssignments 범위에 해당하는 사용자 플랫폼 역할 할당 (워크스페이스 범위가 있으면 대상 아님)
+func (r *AccessReviewRepository) FindUserPlatformRoleAssignments(scope AccessReviewScope) ([]model.AccessReviewItem, error) {
+	if len(scope.WorkspaceIDs) > 0 {
+		return nil, nil
+	}
+	query := r.db.Table("mcmp_user_platform_roles AS a").
+		Select("a.user_id, u.username, a.role_id, rm.name AS role_name, a.created_at").
+		Joins("JOIN mcmp_users u ON u.id = a.user_id").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = a.role_id")
+	if len(scope.RoleIDs) > 0 {
+		query = query.Where("a.role_id IN ?", scope.RoleIDs)
+	}
+	if scope.UserIDs != nil {
+		query = query.Where("a.user_id IN ?", nonEmptyIDs(scope.UserIDs))
+	}
+	var rows []accessReviewAssignmentRow
+	if err := query.Order("a.user_id, a.role_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error finding user platform role assignments: %w", err)
+	}
+	items := make([]model.AccessReviewItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.item(model.AccessReviewUserPlatformRole))
+	}
+	return items, nil
+}
+
+// FindUserWorkspaceRoleAssignments 범위에 해당하는 사용자 워크스페이스 역할 할당
+func (r *AccessReviewRepository) FindUserWorkspaceRoleAssignments(scope AccessReviewScope) ([]model.AccessReviewItem, error) {
+	query := r.db.Table("mcmp_user_workspace_roles AS a").
+		Select("a.user_id, u.username, a.workspace_id, w.name AS workspace_name, a.role_id, rm.name AS role_name, a.created_at").
+		Joins("JOIN mcmp_users u ON u.id = a.user_id").
+		Joins("JOIN mcmp_workspaces w ON w.id = a.workspace_id").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = a.role_id")
+	if len(scope.WorkspaceIDs) > 0 {
+		query = query.Where("a.workspace_id IN ?", scope.WorkspaceIDs)
+	}
+	if len(scope.RoleIDs) > 0 {
+		query = query.Where("a.role_id IN ?", scope.RoleIDs)
+	}
+	if scope.UserIDs != nil {
+		query = query.Where("a.user_id IN ?", nonEmptyIDs(scope.UserIDs))
+	}
+	var rows []accessReviewAssignmentRow
+	if err := query.Order("a.workspace_id, a.user_id, a.role_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error finding user workspace role assignments: %w", err)
+	}
+	items := make([]model.AccessReviewItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.item(model.AccessReviewUserWorkspaceRole))
+	}
+	return items, nil
+}
+
+// FindGroupWorkspaceRoleAssignments 범위에 해당하는 그룹 워크스페이스 역할 할당
+func (r *AccessReviewRepository) FindGroupWorkspaceRoleAssignments(scope AccessReviewScope) ([]model.AccessReviewItem, error) {
+	query := r.db.Table("mcmp_group_workspace_roles AS a").
+		Select("a.group_id, g.name AS group_name, a.workspace_id, w.name AS workspace_name, a.role_id, rm.name AS role_name, a.created_at").
+		Joins("JOIN mcmp_organizations g ON g.id = a.group_id").
+		Joins("JOIN mcmp_workspaces w ON w.id = a.workspace_id").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = a.role_id")
+	if len(scope.WorkspaceIDs) > 0 {
+		query = query.Where("a.workspace_id IN ?", scope.WorkspaceIDs)
+	}
+	if len(scope.RoleIDs) > 0 {
+		query = query.Where("a.role_id IN ?", scope.RoleIDs)
+	}
+	if scope.GroupIDs != nil {
+		query = query.Where("a.group_id IN ?", nonEmptyIDs(scope.GroupIDs))
+	}
+	var rows []accessReviewAssignmentRow
+	if err := query.Order("a.workspace_id, a.group_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error finding group workspace role assignments: %w", err)
+	}
+	items := make([]model.AccessReviewItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.item(model.AccessReviewGroupWorkspaceRole))
+	}
+	return items, nil
+}
+
+// FindGroupWorkspaceRoleID 그룹-워크스페이스 매핑의 현재 역할 ID (매핑이 없으면 0)
+func (r *AccessReviewRepository) FindGroupWorkspaceRoleID(groupID, workspaceID uint) (uint, error) {
+	var rows []model.GroupWorkspaceRole
+	if err := r.db.Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).Limit(1).Find(&rows).Error; err != nil {
+		return 0, fmt.Errorf("error finding group workspace role: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].RoleID, nil
+}
+
+// nonEmptyIDs IN 조건용 (빈 목록이면 일치하는 행이 없도록 0 하나)
+func nonEmptyIDs(ids []uint) []uint {
+	if len(ids) == 0 {
+		return []uint{0}
+	}
+	return ids
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownAuditChain = errors.New("unknown audit chain")
+)
+
+// 체인별 테이블
+var auditChainTables = map[string]string{
+	model.AuditChainEvents:              model.AuditEvent{}.TableName(),
+	model.AuditChainCredentialIssuances: model.CredentialIssuance{}.TableName(),
+	model.AuditChainBreakGlass:          model.BreakGlassActivity{}.TableName(),
+}
+
+// 같은 프로세스 안의 체인 추가 직렬화 (여러 인스턴스 간에는 PostgreSQL advisory lock 사용)
+var auditChainMu sync.Mutex
+
+// AuditChainRepository 해시 체인 기록 추가와 체크포인트 저장/조회
+type AuditChainRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditChainRepository AuditChainRepository 생성
+func NewAuditChainRepository(db *gorm.DB) *AuditChainRepository {
+	return &AuditChainRepository{db: db}
+}
+
+// Append 체인을 잠그고 마지막 기록의 해시를 seal 에 넘겨 해시를 채운 뒤 record 저장
+// 체인 순서는 ID 순서와 같다 (잠금 안에서 ID 가 할당됨).
+func (r *AuditChainRepository) Append(chain string, record interface{}, seal func(prevHash string)) error {
+	table, ok := auditChainTables[chain]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAuditChain, chain)
+	}
+
+	auditChainMu.Lock()
+	defer auditChainMu.Unlock()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "mcmp_audit_chain:"+chain).Error; err != nil {
+				return fmt.Errorf("error locking audit chain %s: %w", chain, err)
+			}
+		}
+		var hashes []string
+		if err := tx.Table(table).Order("id DESC").Limit(1).Pluck("COALESCE(hash, '')", &hashes).Error; err != nil {
+			return fmt.Errorf("error finding last hash of audit chain %s: %w", chain, err)
+		}
+		prevHash := ""
+		if len(hashes) > 0 {
+			prevHash = hashes[0]
+		}
+		seal(prevHash)
+		if err := tx.Create(record).Error; err != nil {
+			return fmt.Errorf("error appending to audit chain %s: %w", chain, err)
+		}
+		return nil
+	})
+}
+
+// LastLink 체인의 마지막 기록 ID/해시 (기록이 없으면 0, "")
+func (r *AuditChainRepository) LastLink(chain string) (uint, string, error) {
+	table, ok := auditChainTables[chain]
+	if !ok {
+		return 0, "", fmt.Errorf("%w: %s", ErrUnknownAuditChain, chain)
+	}
+	var last struct {
+		ID   uint
+		Hash string
+	}
+	err := r.db.Table(table).Select("id, COALESCE(hash, '') AS hash").Order("id DESC").Limit(1).Scan(&last).Error
+	if err != nil {
+		return 0, "", fmt.Errorf("error finding last record of audit chain %s: %w", chain, err)
+	}
+	return last.ID, last.Hash, nil
+}
+
+// CountChained ID 가 upToID 이하이고 해시가 있는 기록 수
+func (r *AuditChainRepository) CountChained(chain string, upToID uint) (int64, error) {
+	table, ok := auditChainTables[chain]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownAuditChain, chain)
+	}
+	var count int64
+	if err := r.db.Table(table).Where("id <= ? AND hash IS NOT NULL AND hash <> ''", upToID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("error counting audit chain %s: %w", chain, err)
+	}
+	return count, nil
+}
+
+// CreateCheckpoint 체크포인트 저장
+func (r *AuditChainRepository) CreateCheckpoint(checkpoint *model.AuditCheckpoint) error {
+	if err := r.db.Create(checkpoint).Error; err != nil {
+		return fmt.Errorf("error creating audit checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LatestCheckpoint 체인의 마지막 체크포인트 (없으면 nil)
+func (r *AuditChainRepository) LatestCheckpoint(chain string) (*model.AuditCheckpoint, error) {
+	var checkpoints []model.AuditCheckpoint
+	if err := r.db.Where("chain = ?", chain).Order("id DESC").Limit(1).Find(&checkpoints).Error; err != nil {
+		return nil, fmt.Errorf("error finding latest audit checkpoint of %s: %w", chain, err)
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+	return &checkpoints[0], nil
+}
+
+// ListCheckpoints 체크포인트 목록 (chain 이 비어 있으면 전체, 오래된 순)
+func (r *AuditChainRepository) ListCheckpoints(chain string) ([]model.AuditCheckpoint, error) {
+	query := r.db.Model(&model.AuditCheckpoint{})
+	if chain != "" {
+		query = query.Where("chain = ?", chain)
+	}
+	var checkpoints []model.AuditCheckpoint
+	if err := query.Order("id ASC").Find(&checkpoints).Error; err != nil {
+		return nil, fmt.Errorf("error listing audit checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// AuditEventsAfter ID 가 afterID 보다 큰 감사 이벤트 (ID 순, 체인 검증용)
+func (r *AuditChainRepository) AuditEventsAfter(afterID uint, limit int) ([]model.AuditEvent, error) {
+	var events []model.AuditEvent
+	if err := r.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("error reading audit events after %d: %w", afterID, err)
+	}
+	return events, nil
+}
+
+// CredentialIssuancesAfter ID 가 afterID 보다 큰 발급 기록 (ID 순, 체인 검증용)
+func (r *AuditChainRepository) CredentialIssuancesAfter(afterID uint, limit int) ([]model.CredentialIssuance, error) {
+	var issuances []model.CredentialIssuance
+	if err := r.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&issuances).Error; err != nil {
+		return nil, fmt.Errorf("error reading credential issuances after %d: %w", afterID, err)
+	}
+	return issuances, nil
+}
+
+// BreakGlassActivitiesAfter ID 가 afterID 보다 큰 비상 접근 활동 기록 (ID 순, 체인 검증용)
+func (r *AuditChainRepository) BreakGlassActivitiesAfter(afterID uint, limit int) ([]model.BreakGlassActivity, error) {
+	var activities []model.BreakGlassActivity
+	if err := r.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&activities).Error; err != nil {
+		return nil, fmt.Errorf("error reading break-glass activities after %d: %w", afterID, err)
+	}
+	return activities, nil
+}
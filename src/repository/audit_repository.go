@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAuditEventNotFound = errors.New("audit event not found")
+)
+
+// AuditEventQuery 감사 이벤트 조회 조건 (시간 범위는 파싱된 값)
+type AuditEventQuery struct {
+	Actor       string
+	EntityType  string
+	EntityID    string
+	Action      string
+	Result      string
+	WorkspaceID uint
+	From        *time.Time
+	To          *time.Time
+	Limit       int
+	Offset      int
+}
+
+// AuditRepository 감사 이벤트 저장/조회
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository AuditRepository 생성
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// FindByID ID로 감사 이벤트 조회
+func (r *AuditRepository) FindByID(id uint) (*model.AuditEvent, error) {
+	var event model.AuditEvent
+	if err := r.db.First(&event, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAuditEventNotFound
+		}
+		return nil, fmt.Errorf("error finding audit event %d: %w", id, err)
+	}
+	return &event, nil
+}
+
+// List 조건에 맞는 감사 이벤트 조회 (최신순), 전체 건수 함께 반환
+func (r *AuditRepository) List(q AuditEventQuery) ([]model.AuditEvent, int64, error) {
+	query := r.db.Model(&model.AuditEvent{})
+	if q.Actor != "" {
+		query = query.Where("actor_kc_id = ? OR actor_username = ?", q.Actor, q.Actor)
+	}
+	if q.EntityType != "" {
+		query = query.Where("entity_type = ?", q.EntityType)
+	}
+	if q.EntityID != "" {
+		query = query.Where("entity_id = ?", q.EntityID)
+	}
+	if q.Action != "" {
+		query = query.Where("action = ?", q.Action)
+	}
+	if q.Result != "" {
+		query = query.Where("result = ?", q.Result)
+	}
+	if q.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", q.WorkspaceID)
+	}
+	if q.From != nil {
+		query = query.Where("occurred_at >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("occurred_at < ?", *q.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting audit events: %w", err)
+	}
+	var events []model.AuditEvent
+	if err := query.Order("occurred_at DESC, id DESC").Limit(q.Limit).Offset(q.Offset).Find(&events).Error; err != nil {
+		return nil, 0, fmt.Errorf("error listing audit events: %w", err)
+	}
+	return events, total, nil
+}
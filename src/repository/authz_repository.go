@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// AuthzRepository 권한 판정에 필요한 역할/권한 조회
+type AuthzRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthzRepository AuthzRepository 생성
+func NewAuthzRepository(db *gorm.DB) *AuthzRepository {
+	return &AuthzRepository{db: db}
+}
+
+// FindPlatformRoleGrants 사용자의 플랫폼 역할 목록 조회 (직접 할당 + 그룹 상속, 출처 포함)
+// 같은 역할이 여러 경로로 부여된 경우 경로별로 모두 반환한다. 유효 기간 밖(시작 전/만료)인 할당은 제외한다.
+func (r *AuthzRepository) FindPlatformRoleGrants(userID uint) ([]model.AuthzRoleGrant, error) {
+	return r.findPlatformRoleGrants(userID, false)
+}
+
+// FindAssignedPlatformRoleGrants FindPlatformRoleGrants 와 같으나 시작 전(예약) 할당도 포함 (SoD 검사용)
+func (r *AuthzRepository) FindAssignedPlatformRoleGrants(userID uint) ([]model.AuthzRoleGrant, error) {
+	return r.findPlatformRoleGrants(userID, true)
+}
+
+func (r *AuthzRepository) findPlatformRoleGrants(userID uint, includePending bool) ([]model.AuthzRoleGrant, error) {
+	var grants []model.AuthzRoleGrant
+	now := time.Now()
+	upr, uprArgs := assignmentValidityClause("upr", now, includePending)
+	gpr, gprArgs := assignmentValidityClause("gpr", now, includePending)
+	args := append(append([]interface{}{userID}, uprArgs...), userID)
+	err := r.db.Raw(`
+		SELECT rm.id AS role_id, rm.name AS role_name, 0 AS group_id, '' AS group_name, 'direct' AS source
+		FROM mcmp_user_platform_roles upr
+		JOIN mcmp_role_masters rm ON rm.id = upr.role_id
+		WHERE upr.user_id = ? AND `+upr+`
+		UNION ALL
+		SELECT rm.id AS role_id, rm.name AS role_name, o.id AS group_id, o.name AS group_name, 'group:' || o.name AS source
+		FROM mcmp_user_organizations uo
+		JOIN mcmp_group_platform_roles gpr ON gpr.group_id = uo.organization_id
+		JOIN mcmp_role_masters rm ON rm.id = gpr.role_id
+		JOIN mcmp_organizations o ON o.id = uo.organization_id
+		WHERE uo.user_id = ? AND `+gpr+`
+	`, append(args, gprArgs...)...).Scan(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding platform role grants for user %d: %w", userID, err)
+	}
+	for i := range grants {
+		grants[i].RoleType = constants.RoleTypePlatform
+	}
+	return grants, nil
+}
+
+// FindWorkspaceRoleGrants 사용자의 특정 워크스페이스 역할 목록 조회 (직접 할당 + 그룹 상속, 출처 포함, 유효 기간 내 할당만)
+func (r *AuthzRepository) FindWorkspaceRoleGrants(userID, workspaceID uint) ([]model.AuthzRoleGrant, error) {
+	var grants []model.AuthzRoleGrant
+	now := time.Now()
+	uwr, uwrArgs := assignmentValidityClause("uwr", now, false)
+	gwr, gwrArgs := assignmentValidityClause("gwr", now, false)
+	args := append(append([]interface{}{userID, workspaceID}, uwrArgs...), userID, workspaceID)
+	err := r.db.Raw(`
+		SELECT rm.id AS role_id, rm.name AS role_name, uwr.workspace_id, 0 AS group_id, '' AS group_name, 'direct' AS source
+		FROM mcmp_user_workspace_roles uwr
+		JOIN mcmp_role_masters rm ON rm.id = uwr.role_id
+		WHERE uwr.user_id = ? AND uwr.workspace_id = ? AND `+uwr+`
+		UNION ALL
+		SELECT rm.id AS role_id, rm.name AS role_name, gwr.workspace_id, o.id AS group_id, o.name AS group_name, 'group:' || o.name AS source
+		FROM mcmp_user_organizations uo
+		JOIN mcmp_group_workspace_roles gwr ON gwr.group_id = uo.organization_id
+		JOIN mcmp_role_masters rm ON rm.id = gwr.role_id
+		JOIN mcmp_organizations o ON o.id = uo.organization_id
+		WHERE uo.user_id = ? AND gwr.workspace_id = ? AND `+gwr+`
+	`, append(args, gwrArgs...)...).Scan(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding workspace role grants for user %d in workspace %d: %w", userID, workspaceID, err)
+	}
+	for i := range grants {
+		grants[i].RoleType = constants.RoleTypeWorkspace
+	}
+	return grants, nil
+}
+
+// FindAllWorkspaceRoleGrants 사용자의 전체 워크스페이스 역할 목록 조회 (직접 할당 + 그룹 상속, 출처 포함, 유효 기간 내 할당만)
+func (r *AuthzRepository) FindAllWorkspaceRoleGrants(userID uint) ([]model.AuthzRoleGrant, error) {
+	return r.findAllWorkspaceRoleGrants(userID, false)
+}
+
+// FindAssignedWorkspaceRoleGrants FindAllWorkspaceRoleGrants 와 같으나 시작 전(예약) 할당도 포함 (SoD 검사용)
+func (r *AuthzRepository) FindAssignedWorkspaceRoleGrants(userID uint) ([]model.AuthzRoleGrant, error) {
+	return r.findAllWorkspaceRoleGrants(userID, true)
+}
+
+func (r *AuthzRepository) findAllWorkspaceRoleGrants(userID uint, includePending bool) ([]model.AuthzRoleGrant, error) {
+	var grants []model.AuthzRoleGrant
+	now := time.Now()
+	uwr, uwrArgs := assignmentValidityClause("uwr", now, includePending)
+	gwr, gwrArgs := assignmentValidityClause("gwr", now, includePending)
+	args := append(append([]interface{}{userID}, uwrArgs...), userID)
+	err := r.db.Raw(`
+		SELECT rm.id AS role_id, rm.name AS role_name, uwr.workspace_id, 0 AS group_id, '' AS group_name, 'direct' AS source
+		FROM mcmp_user_workspace_roles uwr
+		JOIN mcmp_role_masters rm ON rm.id = uwr.role_id
+		WHERE uwr.user_id = ? AND `+uwr+`
+		UNION ALL
+		SELECT rm.id AS role_id, rm.name AS role_name, gwr.workspace_id, o.id AS group_id, o.name AS group_name, 'group:' || o.name AS source
+		FROM mcmp_user_organizations uo
+		JOIN mcmp_group_workspace_roles gwr ON gwr.group_id = uo.organization_id
+		JOIN mcmp_role_masters rm ON rm.id = gwr.role_id
+		JOIN mcmp_organizations o ON o.id = uo.organization_id
+		WHERE uo.user_id = ? AND `+gwr+`
+	`, append(args, gwrArgs...)...).Scan(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding workspace role grants for user %d: %w", userID, err)
+	}
+	for i := range grants {
+		grants[i].RoleType = constants.RoleTypeWorkspace
+	}
+	return grants, nil
+}
+
+// assignmentValidityClause 역할 할당 테이블(alias)의 유효 기간 조건과 인자
+// includePending 이 true 이면 만료만 제외하고 시작 전 할당은 포함한다.
+func assignmentValidityClause(alias string, now time.Time, includePending bool) (string, []interface{}) {
+	expiry := fmt.Sprintf("(%[1]s.expires_at IS NULL OR %[1]s.expires_at > ?)", alias)
+	if includePending {
+		return expiry, []interface{}{now}
+	}
+	return fmt.Sprintf("(%[1]s.valid_from IS NULL OR %[1]s.valid_from <= ?) AND %[2]s", alias, expiry), []interface{}{now, now}
+}
+
+// FindRolePermissionMappings 역할 목록에 매핑된 MC-IAM 권한 조회
+func (r *AuthzRepository) FindRolePermissionMappings(roleType constants.IAMRoleType, roleIDs []uint) ([]model.MciamRoleMciamPermission, error) {
+	var mappings []model.MciamRoleMciamPermission
+	if len(roleIDs) == 0 {
+		return mappings, nil
+	}
+	if err := r.db.Where("role_type = ? AND role_id IN ?", roleType, roleIDs).Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("error finding permission mappings for %s roles: %w", roleType, err)
+	}
+	return mappings, nil
+}
+
+// FindRoleLineages 역할별 상위 역할 경로 조회 (자기 자신부터 최상위 순)
+// 역할 테이블에 없는 ID 는 자기 자신만 포함한다.
+func (r *AuthzRepository) FindRoleLineages(roleIDs []uint) (map[uint][]model.RoleMaster, error) {
+	lineages := make(map[uint][]model.RoleMaster, len(roleIDs))
+	if len(roleIDs) == 0 {
+		return lineages, nil
+	}
+	roles, err := findRoleHierarchy(r.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, roleID := range roleIDs {
+		lineage := roleLineage(roles, roleID)
+		if len(lineage) == 0 {
+			lineage = []model.RoleMaster{{ID: roleID}}
+		}
+		lineages[roleID] = lineage
+	}
+	return lineages, nil
+}
+
+// FindUserOrganizationIDs 사용자가 소속된 조직 ID 목록 조회
+func (r *AuthzRepository) FindUserOrganizationIDs(userID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&model.UserOrganization{}).Where("user_id = ?", userID).Pluck("organization_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("error finding organizations for user %d: %w", userID, err)
+	}
+	return ids, nil
+}
+
+// FindWorkspaceLabels 워크스페이스 라벨 조회 (없는 워크스페이스는 빈 라벨)
+func (r *AuthzRepository) FindWorkspaceLabels(workspaceID uint) (model.Labels, error) {
+	var workspaces []model.Workspace
+	if err := r.db.Select("id", "labels").Where("id = ?", workspaceID).Limit(1).Find(&workspaces).Error; err != nil {
+		return nil, fmt.Errorf("error finding labels for workspace %d: %w", workspaceID, err)
+	}
+	if len(workspaces) == 0 {
+		return nil, nil
+	}
+	return workspaces[0].Labels, nil
+}
+
+// FindProjectLabels 프로젝트 라벨 조회 (없는 프로젝트는 빈 라벨)
+func (r *AuthzRepository) FindProjectLabels(projectID uint) (model.Labels, error) {
+	var projects []model.Project
+	if err := r.db.Select("id", "labels").Where("id = ?", projectID).Limit(1).Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("error finding labels for project %d: %w", projectID, err)
+	}
+	if len(projects) == 0 {
+		return nil, nil
+	}
+	return projects[0].Labels, nil
+}
+
+// FindOrganizationPaths 조직별 조직 트리 경로 조회 (예: /본부/개발팀)
+func (r *AuthzRepository) FindOrganizationPaths(orgIDs []uint) (map[uint]string, error) {
+	paths := make(map[uint]string, len(orgIDs))
+	if len(orgIDs) == 0 {
+		return paths, nil
+	}
+	var orgs []model.Organization
+	if err := r.db.Select("id", "parent_id", "name").Find(&orgs).Error; err != nil {
+		return nil, fmt.Errorf("error finding organization tree: %w", err)
+	}
+	byID := make(map[uint]model.Organization, len(orgs))
+	for _, o := range orgs {
+		byID[o.ID] = o
+	}
+	for _, id := range orgIDs {
+		path := ""
+		seen := make(map[uint]bool)
+		for cur, ok := byID[id]; ok && !seen[cur.ID]; {
+			seen[cur.ID] = true
+			path = "/" + cur.Name + path
+			if cur.ParentID == nil {
+				break
+			}
+			cur, ok = byID[*cur.ParentID]
+		}
+		paths[id] = path
+	}
+	return paths, nil
+}
+
+// FindMenuParents 메뉴 ID -> 상위 메뉴 ID 조회 (최상위 메뉴는 빈 문자열)
+func (r *AuthzRepository) FindMenuParents() (map[string]string, error) {
+	var rows []struct {
+		ID       string
+		ParentID *string
+	}
+	if err := r.db.Table("mcmp_menus").Select("id, parent_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error finding menu tree: %w", err)
+	}
+	parents := make(map[string]string, len(rows))
+	for _, row := range rows {
+		parents[row.ID] = ""
+		if row.ParentID != nil {
+			parents[row.ID] = *row.ParentID
+		}
+	}
+	return parents, nil
+}
+
+// FindRoleMenuMappings 역할 목록과 메뉴 목록이 겹치는 역할-메뉴 매핑 조회
+func (r *AuthzRepository) FindRoleMenuMappings(roleIDs []uint, menuIDs []string) ([]model.RoleMenuMapping, error) {
+	var mappings []model.RoleMenuMapping
+	if len(roleIDs) == 0 || len(menuIDs) == 0 {
+		return mappings, nil
+	}
+	if err := r.db.Where("role_id IN ? AND menu_id IN ?", roleIDs, menuIDs).Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("error finding role menu mappings: %w", err)
+	}
+	return mappings, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBreakGlassAccountNotFound = errors.New("break-glass account not found")
+	ErrBreakGlassSessionNotFound = errors.New("break-glass session not found")
+)
+
+// BreakGlassRepository 비상 접근 계정/세션/활동 기록 저장
+// 활동 기록은 해시 체인에 추가해야 하므로 AuditChainRepository.Append 로 저장한다.
+type BreakGlassRepository struct {
+	db *gorm.DB
+}
+
+// NewBreakGlassRepository BreakGlassRepository 생성
+func NewBreakGlassRepository(db *gorm.DB) *BreakGlassRepository {
+	return &BreakGlassRepository{db: db}
+}
+
+// CreateAccount 비상 접근 계정 등록
+func (r *BreakGlassRepository) CreateAccount(account *model.BreakGlassAccount) error {
+	if err := r.db.Create(account).Error; err != nil {
+		return fmt.Errorf("error creating break-glass account: %w", err)
+	}
+	return nil
+}
+
+// FindAccountByUserID 사용자의 비상 접근 계정 (등록되지 않았으면 nil)
+func (r *BreakGlassRepository) FindAccountByUserID(userID uint) (*model.BreakGlassAccount, error) {
+	var accounts []model.BreakGlassAccount
+	if err := r.db.Where("user_id = ?", userID).Limit(1).Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("error finding break-glass account of user %d: %w", userID, err)
+	}
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	return &accounts[0], nil
+}
+
+// ListAccounts 비상 접근 계정 목록
+func (r *BreakGlassRepository) ListAccounts() ([]model.BreakGlassAccount, error) {
+	var accounts []model.BreakGlassAccount
+	if err := r.db.Order("id").Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("error listing break-glass accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// DeleteAccount 사용자의 비상 접근 계정 등록 해제
+func (r *BreakGlassRepository) DeleteAccount(userID uint) error {
+	result := r.db.Where("user_id = ?", userID).Delete(&model.BreakGlassAccount{})
+	if result.Error != nil {
+		return fmt.Errorf("error deleting break-glass account of user %d: %w", userID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBreakGlassAccountNotFound
+	}
+	return nil
+}
+
+// CreateSession 세션 저장
+func (r *BreakGlassRepository) CreateSession(session *model.BreakGlassSession) error {
+	if err := r.db.Create(session).Error; err != nil {
+		return fmt.Errorf("error creating break-glass session: %w", err)
+	}
+	return nil
+}
+
+// FindSessionByID ID로 세션 조회
+func (r *BreakGlassRepository) FindSessionByID(id uint) (*model.BreakGlassSession, error) {
+	var session model.BreakGlassSession
+	if err := r.db.First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBreakGlassSessionNotFound
+		}
+		return nil, fmt.Errorf("error finding break-glass session %d: %w", id, err)
+	}
+	return &session, nil
+}
+
+// ListSessions 세션 목록 (조건이 비어 있으면 제한 없음, 최신순)
+func (r *BreakGlassRepository) ListSessions(filter model.BreakGlassSessionFilter) ([]model.BreakGlassSession, error) {
+	query := r.db.Model(&model.BreakGlassSession{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	var sessions []model.BreakGlassSession
+	if err := query.Order("id DESC").Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("error listing break-glass sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// FindActiveSession 사용자의 유효한 세션 (없으면 nil)
+func (r *BreakGlassRepository) FindActiveSession(userID uint) (*model.BreakGlassSession, error) {
+	var sessions []model.BreakGlassSession
+	err := r.db.Where("user_id = ? AND status = ?", userID, model.BreakGlassActive).
+		Order("id DESC").Limit(1).Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding active break-glass session of user %d: %w", userID, err)
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0], nil
+}
+
+// FindLatestSessionSince Keycloak 사용자의 가장 최근 세션 중 유효하거나 since 이후에 끝난 것 (없으면 nil)
+func (r *BreakGlassRepository) FindLatestSessionSince(kcUserID string, since time.Time) (*model.BreakGlassSession, error) {
+	var sessions []model.BreakGlassSession
+	err := r.db.Where("kc_user_id = ? AND (status = ? OR ended_at > ?)", kcUserID, model.BreakGlassActive, since).
+		Order("id DESC").Limit(1).Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding break-glass session of %s: %w", kcUserID, err)
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0], nil
+}
+
+// UpdateSession 세션 필드 변경 (상태 전이는 Transition 사용)
+func (r *BreakGlassRepository) UpdateSession(id uint, updates map[string]interface{}) error {
+	if err := r.db.Model(&model.BreakGlassSession{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("error updating break-glass session %d: %w", id, err)
+	}
+	return nil
+}
+
+// Transition 세션 상태를 from 에서 다른 상태로 변경 (이미 다른 상태면 false)
+func (r *BreakGlassRepository) Transition(id uint, from string, updates map[string]interface{}) (bool, error) {
+	result := r.db.Model(&model.BreakGlassSession{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("error updating break-glass session %d: %w", id, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListDueExpirations 만료 시각이 지난 유효 세션
+func (r *BreakGlassRepository) ListDueExpirations(now time.Time) ([]model.BreakGlassSession, error) {
+	var sessions []model.BreakGlassSession
+	err := r.db.Where("status = ? AND expires_at <= ?", model.BreakGlassActive, now).
+		Order("id").Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing due break-glass sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteTimedPlatformRole 비상 접근으로 만든 기한부 플랫폼 역할 할당 삭제
+// 만료 시각이 없는(상시) 할당은 건드리지 않으며, 이미 삭제되었으면 아무 것도 하지 않는다.
+func (r *BreakGlassRepository) DeleteTimedPlatformRole(userID, roleID uint) error {
+	err := r.db.Where("user_id = ? AND role_id = ? AND expires_at IS NOT NULL", userID, roleID).
+		Delete(&model.UserPlatformRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting break-glass platform role: %w", err)
+	}
+	return nil
+}
+
+// ListActivities 세션 중 기록된 요청 (오래된 순)
+func (r *BreakGlassRepository) ListActivities(sessionID uint) ([]model.BreakGlassActivity, error) {
+	var activities []model.BreakGlassActivity
+	if err := r.db.Where("session_id = ?", sessionID).Order("id").Find(&activities).Error; err != nil {
+		return nil, fmt.Errorf("error listing break-glass activities of session %d: %w", sessionID, err)
+	}
+	return activities, nil
+}
+
+// CountActivities 세션 중 기록된 요청 수
+func (r *BreakGlassRepository) CountActivities(sessionID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&model.BreakGlassActivity{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("error counting break-glass activities of session %d: %w", sessionID, err)
+	}
+	return count, nil
+}
+
+// FindPlatformRoleHolders 플랫폼 역할을 직접 할당받은 사용자 (시작 전 할당 제외, 알림 대상 조회용)
+func (r *BreakGlassRepository) FindPlatformRoleHolders(roleID uint) ([]model.User, error) {
+	var users []model.User
+	err := r.db.Joins("JOIN mcmp_user_platform_roles upr ON upr.user_id = mcmp_users.id").
+		Where("upr.role_id = ? AND upr.activation_pending = ?", roleID, false).
+		Order("mcmp_users.id").Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding holders of platform role %d: %w", roleID, err)
+	}
+	return users, nil
+}
+
+// HasPlatformRole 사용자의 플랫폼 역할 직접 할당 여부 (기한부 포함)
+func (r *BreakGlassRepository) HasPlatformRole(userID, roleID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.UserPlatformRole{}).
+		Where("user_id = ? AND role_id = ?", userID, roleID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking platform role of user %d: %w", userID, err)
+	}
+	return count > 0, nil
+}
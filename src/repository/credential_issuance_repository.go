@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCredentialIssuanceNotFound = errors.New("credential issuance not found")
+)
+
+// 집계 기준별 컬럼
+var credentialIssuanceGroupColumns = map[string]string{
+	"workspace": "workspace_id",
+	"csp":       "csp_type",
+	"cspRole":   "csp_role_name",
+	"user":      "kc_user_id",
+}
+
+// CredentialIssuanceQuery 발급 원장 조회 조건 (시간 범위는 파싱된 값)
+type CredentialIssuanceQuery struct {
+	User        string
+	WorkspaceID uint
+	CspType     string
+	CspRole     string
+	AuthMethod  string
+	Result      string
+	SourceIP    string
+	From        *time.Time
+	To          *time.Time
+	Limit       int
+	Offset      int
+	GroupBy     []string // workspace, csp, cspRole, user
+}
+
+// CredentialIssuanceRepository CSP 임시 자격 증명 발급 원장 저장/조회
+type CredentialIssuanceRepository struct {
+	db *gorm.DB
+}
+
+// NewCredentialIssuanceRepository CredentialIssuanceRepository 생성
+func NewCredentialIssuanceRepository(db *gorm.DB) *CredentialIssuanceRepository {
+	return &CredentialIssuanceRepository{db: db}
+}
+
+// FindByID ID로 발급 기록 조회
+func (r *CredentialIssuanceRepository) FindByID(id uint) (*model.CredentialIssuance, error) {
+	var issuance model.CredentialIssuance
+	if err := r.db.First(&issuance, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCredentialIssuanceNotFound
+		}
+		return nil, fmt.Errorf("error finding credential issuance %d: %w", id, err)
+	}
+	return &issuance, nil
+}
+
+// List 조건에 맞는 발급 기록 조회 (최신순), 전체 건수 함께 반환
+func (r *CredentialIssuanceRepository) List(q CredentialIssuanceQuery) ([]model.CredentialIssuance, int64, error) {
+	query := r.filtered(q)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting credential issuances: %w", err)
+	}
+	var issuances []model.CredentialIssuance
+	if err := query.Order("requested_at DESC, id DESC").Limit(q.Limit).Offset(q.Offset).Find(&issuances).Error; err != nil {
+		return nil, 0, fmt.Errorf("error listing credential issuances: %w", err)
+	}
+	return issuances, total, nil
+}
+
+// credentialIssuanceAggregateRow 집계 쿼리 결과 (최근 요청 시각은 DB 별 반환 타입이 달라 별도 조회)
+type credentialIssuanceAggregateRow struct {
+	WorkspaceID   *uint
+	CspType       string
+	CspRoleName   string
+	KcUserID      string
+	Total         int64
+	Succeeded     int64
+	DistinctUsers int64
+	LastID        uint
+}
+
+// Aggregate 조건에 맞는 발급 기록을 GroupBy 기준으로 집계 (건수 내림차순)
+func (r *CredentialIssuanceRepository) Aggregate(q CredentialIssuanceQuery) ([]model.CredentialIssuanceAggregate, error) {
+	selects := []string{
+		"COUNT(*) AS total",
+		"SUM(CASE WHEN result = ? THEN 1 ELSE 0 END) AS succeeded",
+		"COUNT(DISTINCT kc_user_id) AS distinct_users",
+		"MAX(id) AS last_id",
+	}
+	var groups []string
+	for _, g := range q.GroupBy {
+		column, ok := credentialIssuanceGroupColumns[g]
+		if !ok {
+			return nil, fmt.Errorf("unknown credential issuance group %q", g)
+		}
+		groups = append(groups, column)
+	}
+	selects = append(groups, selects...)
+
+	var rows []credentialIssuanceAggregateRow
+	err := r.filtered(q).
+		Select(strings.Join(selects, ", "), model.AuditResultSuccess).
+		Group(strings.Join(groups, ", ")).
+		Order("total DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating credential issuances: %w", err)
+	}
+
+	// 그룹별 마지막 기록(ID 최대)의 요청 시각
+	lastIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		lastIDs = append(lastIDs, row.LastID)
+	}
+	lastRequested := make(map[uint]time.Time, len(rows))
+	if len(lastIDs) > 0 {
+		var lasts []model.CredentialIssuance
+		if err := r.db.Select("id", "requested_at").Where("id IN ?", lastIDs).Find(&lasts).Error; err != nil {
+			return nil, fmt.Errorf("error finding latest credential issuances: %w", err)
+		}
+		for _, last := range lasts {
+			lastRequested[last.ID] = last.RequestedAt
+		}
+	}
+
+	aggregates := make([]model.CredentialIssuanceAggregate, 0, len(rows))
+	for _, row := range rows {
+		agg := model.CredentialIssuanceAggregate{
+			WorkspaceID:   row.WorkspaceID,
+			CspType:       row.CspType,
+			CspRoleName:   row.CspRoleName,
+			KcUserID:      row.KcUserID,
+			Total:         row.Total,
+			Succeeded:     row.Succeeded,
+			Failed:        row.Total - row.Succeeded,
+			DistinctUsers: row.DistinctUsers,
+		}
+		if at, ok := lastRequested[row.LastID]; ok {
+			agg.LastRequestedAt = &at
+		}
+		aggregates = append(aggregates, agg)
+	}
+	return aggregates, nil
+}
+
+// filtered 조회 조건을 적용한 쿼리
+func (r *CredentialIssuanceRepository) filtered(q CredentialIssuanceQuery) *gorm.DB {
+	query := r.db.Model(&model.CredentialIssuance{})
+	if q.User != "" {
+		query = query.Where("kc_user_id = ? OR username = ?", q.User, q.User)
+	}
+	if q.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", q.WorkspaceID)
+	}
+	if q.CspType != "" {
+		query = query.Where("csp_type = ?", q.CspType)
+	}
+	if q.CspRole != "" {
+		pattern := "%" + strings.ToLower(q.CspRole) + "%"
+		query = query.Where("LOWER(csp_role_name) LIKE ? OR LOWER(csp_role_identifier) LIKE ?", pattern, pattern)
+	}
+	if q.AuthMethod != "" {
+		query = query.Where("auth_method = ?", q.AuthMethod)
+	}
+	if q.Result != "" {
+		query = query.Where("result = ?", q.Result)
+	}
+	if q.SourceIP != "" {
+		query = query.Where("source_ip = ?", q.SourceIP)
+	}
+	if q.From != nil {
+		query = query.Where("requested_at >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("requested_at < ?", *q.To)
+	}
+	return query
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/model"
 	"gorm.io/gorm"
@@ -95,6 +96,19 @@ func (r *CspIdpConfigRepository) Update(config *model.CspIdpConfig) error {
 	return nil
 }
 
+// UpdateHealthCheck 마지막 연결 상태 확인 결과 저장 (updated_at 은 변경하지 않음)
+func (r *CspIdpConfigRepository) UpdateHealthCheck(id uint, status, errMsg string, checkedAt time.Time) error {
+	err := r.db.Model(&model.CspIdpConfig{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_health_status":     status,
+		"last_health_error":      errMsg,
+		"last_health_checked_at": checkedAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update CSP IDP config health check: %w", err)
+	}
+	return nil
+}
+
 // Delete CSP IDP 설정 삭제
 func (r *CspIdpConfigRepository) Delete(id uint) error {
 	result := r.db.Delete(&model.CspIdpConfig{}, id)
@@ -25,18 +25,36 @@ func NewCspMappingRepository(db *gorm.DB) *CspMappingRepository {
 
 // FindCspRoleMappingsByRoleIDAndCspType 플랫폼 역할 ID, CSP 타입, 인증방식으로 CSP 역할 매핑 조회.
 // authMethod가 비어 있으면 인증방식 무관 첫 번째 매핑을 반환한다.
+// 역할에 매핑이 없으면 상위 역할(ParentID)을 따라가며 가장 가까운 역할의 매핑을 반환한다.
 func (r *CspMappingRepository) FindCspRoleMappingsByRoleIDAndCspType(roleID uint, cspType string, authMethod string) (*model.RoleMasterCspRoleMapping, error) {
+	roles, err := findRoleHierarchy(r.db)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := []uint{roleID}
+	if lineage := roleLineage(roles, roleID); len(lineage) > 0 {
+		roleIDs = roleIDs[:0]
+		for _, role := range lineage {
+			roleIDs = append(roleIDs, role.ID)
+		}
+	}
+
 	var mappings []*model.RoleMasterCspRoleMapping
-	q := r.db.
-		Joins("JOIN mcmp_role_csp_roles ON mcmp_role_csp_roles.id = mcmp_role_csp_role_mappings.csp_role_id").
-		Where("mcmp_role_csp_role_mappings.role_id = ? AND mcmp_role_csp_roles.csp_type = ?", roleID, cspType)
+	for _, id := range roleIDs {
+		q := r.db.
+			Joins("JOIN mcmp_role_csp_roles ON mcmp_role_csp_roles.id = mcmp_role_csp_role_mappings.csp_role_id").
+			Where("mcmp_role_csp_role_mappings.role_id = ? AND mcmp_role_csp_roles.csp_type = ?", id, cspType)
 
-	if authMethod != "" {
-		q = q.Where("mcmp_role_csp_role_mappings.auth_method = ?", authMethod)
-	}
+		if authMethod != "" {
+			q = q.Where("mcmp_role_csp_role_mappings.auth_method = ?", authMethod)
+		}
 
-	if err := q.Find(&mappings).Error; err != nil {
-		return nil, err
+		if err := q.Find(&mappings).Error; err != nil {
+			return nil, err
+		}
+		if len(mappings) > 0 {
+			break
+		}
 	}
 
 	if len(mappings) == 0 {
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// ErrElevationRequestNotFound 권한 승격 요청이 없음
+var ErrElevationRequestNotFound = errors.New("elevation request not found")
+
+// ElevationRepository 기한부 권한 승격 요청 저장과 승격 대상 역할 조회
+type ElevationRepository struct {
+	db *gorm.DB
+}
+
+// NewElevationRepository ElevationRepository 생성
+func NewElevationRepository(db *gorm.DB) *ElevationRepository {
+	return &ElevationRepository{db: db}
+}
+
+// Create 요청 저장
+func (r *ElevationRepository) Create(req *model.ElevationRequest) error {
+	if err := r.db.Create(req).Error; err != nil {
+		return fmt.Errorf("error creating elevation request: %w", err)
+	}
+	return nil
+}
+
+// FindByID ID로 요청 조회
+func (r *ElevationRepository) FindByID(id uint) (*model.ElevationRequest, error) {
+	var req model.ElevationRequest
+	if err := r.db.First(&req, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrElevationRequestNotFound
+		}
+		return nil, fmt.Errorf("error finding elevation request %d: %w", id, err)
+	}
+	return &req, nil
+}
+
+// List 요청 목록 (조건이 비어 있으면 제한 없음, 최신순)
+func (r *ElevationRepository) List(filter model.ElevationFilter) ([]model.ElevationRequest, error) {
+	query := r.db.Model(&model.ElevationRequest{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.RequesterID != 0 {
+		query = query.Where("requester_id = ?", filter.RequesterID)
+	}
+	var requests []model.ElevationRequest
+	if err := query.Order("id DESC").Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("error listing elevation requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ExistsOpen 같은 사용자/워크스페이스/역할에 대기 중이거나 유효한 승격 요청이 있는지
+func (r *ElevationRepository) ExistsOpen(requesterID, workspaceID, roleID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.ElevationRequest{}).
+		Where("requester_id = ? AND workspace_id = ? AND role_id = ? AND status IN ?",
+			requesterID, workspaceID, roleID, []string{model.ElevationPending, model.ElevationApproved}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking open elevation requests: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Transition 요청 상태를 from 에서 다른 상태로 변경 (이미 다른 상태면 false)
+func (r *ElevationRepository) Transition(id uint, from string, updates map[string]interface{}) (bool, error) {
+	result := r.db.Model(&model.ElevationRequest{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("error updating elevation request %d: %w", id, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListDueExpirations 만료 시각이 지난 승인 요청
+func (r *ElevationRepository) ListDueExpirations(now time.Time) ([]model.ElevationRequest, error) {
+	var requests []model.ElevationRequest
+	err := r.db.Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", model.ElevationApproved, now).
+		Order("id").Find(&requests).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing due elevation requests: %w", err)
+	}
+	return requests, nil
+}
+
+// FindActiveCspElevation 사용자가 워크스페이스에서 유효한 CSP 역할 승격 (cspType 일치, 가장 최근 승인)
+func (r *ElevationRepository) FindActiveCspElevation(requesterID, workspaceID uint, cspType string, now time.Time) (*model.ElevationRequest, error) {
+	var requests []model.ElevationRequest
+	err := r.db.Where("requester_id = ? AND workspace_id = ? AND type = ? AND csp_type = ? AND status = ? AND expires_at > ?",
+		requesterID, workspaceID, model.ElevationTypeCspRole, cspType, model.ElevationApproved, now).
+		Order("decided_at DESC, id DESC").Limit(1).Find(&requests).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding active csp elevation: %w", err)
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	return &requests[0], nil
+}
+
+// FindRoleIDsMappedToCspRole CSP 역할에 매핑된 역할 ID 목록
+func (r *ElevationRepository) FindRoleIDsMappedToCspRole(cspRoleID uint) ([]uint, error) {
+	var roleIDs []uint
+	err := r.db.Model(&model.RoleMasterCspRoleMapping{}).
+		Where("csp_role_id = ?", cspRoleID).
+		Distinct().Order("role_id").Pluck("role_id", &roleIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding roles mapped to csp role %d: %w", cspRoleID, err)
+	}
+	return roleIDs, nil
+}
+
+// IsRoleMappedToCspRole 역할에 CSP 역할이 매핑되어 있는지
+func (r *ElevationRepository) IsRoleMappedToCspRole(roleID, cspRoleID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.RoleMasterCspRoleMapping{}).
+		Where("role_id = ? AND csp_role_id = ?", roleID, cspRoleID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking csp role mapping: %w", err)
+	}
+	return count > 0, nil
+}
+
+// DeleteTimedWorkspaceRole 승격으로 만든 기한부 워크스페이스 역할 할당 삭제
+// 만료 시각이 없는(상시) 할당은 건드리지 않으며, 이미 삭제되었으면 아무 것도 하지 않는다.
+func (r *ElevationRepository) DeleteTimedWorkspaceRole(userID, workspaceID, roleID uint) error {
+	err := r.db.Where("user_id = ? AND workspace_id = ? AND role_id = ? AND expires_at IS NOT NULL", userID, workspaceID, roleID).
+		Delete(&model.UserWorkspaceRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting elevated workspace role: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/model"
 	"gorm.io/gorm"
@@ -31,9 +32,17 @@ func NewGroupRoleRepository(db *gorm.DB) *GroupRoleRepository {
 
 // CreateGroupPlatformRole 그룹-플랫폼 역할 매핑 생성
 func (r *GroupRoleRepository) CreateGroupPlatformRole(groupID, roleID uint) error {
+	return r.CreateGroupPlatformRoleWithValidity(groupID, roleID, model.AssignmentValidity{})
+}
+
+// CreateGroupPlatformRoleWithValidity 유효 기간을 지정하여 그룹-플랫폼 역할 매핑 생성 (시작 전이면 activation_pending)
+func (r *GroupRoleRepository) CreateGroupPlatformRoleWithValidity(groupID, roleID uint, validity model.AssignmentValidity) error {
 	record := &model.GroupPlatformRole{
-		GroupID: groupID,
-		RoleID:  roleID,
+		GroupID:           groupID,
+		RoleID:            roleID,
+		ValidFrom:         validity.ValidFrom,
+		ExpiresAt:         validity.ExpiresAt,
+		ActivationPending: validity.PendingAt(time.Now()),
 	}
 	if err := r.db.Create(record).Error; err != nil {
 		if isGroupDuplicateError(err) {
@@ -120,10 +129,18 @@ func (r *GroupRoleRepository) DeleteGroupPlatformRole(groupID, roleID uint) erro
 
 // CreateGroupWorkspaceRole 그룹-워크스페이스-역할 매핑 생성
 func (r *GroupRoleRepository) CreateGroupWorkspaceRole(groupID, workspaceID, roleID uint) error {
+	return r.CreateGroupWorkspaceRoleWithValidity(groupID, workspaceID, roleID, model.AssignmentValidity{})
+}
+
+// CreateGroupWorkspaceRoleWithValidity 유효 기간을 지정하여 그룹-워크스페이스 매핑 생성 (시작 전이면 activation_pending)
+func (r *GroupRoleRepository) CreateGroupWorkspaceRoleWithValidity(groupID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
 	record := &model.GroupWorkspaceRole{
-		GroupID:     groupID,
-		WorkspaceID: workspaceID,
-		RoleID:      roleID,
+		GroupID:           groupID,
+		WorkspaceID:       workspaceID,
+		RoleID:            roleID,
+		ValidFrom:         validity.ValidFrom,
+		ExpiresAt:         validity.ExpiresAt,
+		ActivationPending: validity.PendingAt(time.Now()),
 	}
 	if err := r.db.Create(record).Error; err != nil {
 		if isGroupDuplicateError(err) {
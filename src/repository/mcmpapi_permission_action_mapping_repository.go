@@ -53,6 +53,20 @@ func (r *McmpApiPermissionActionMappingRepository) FindPermissionsByActionID(ctx
 	return permissions, nil
 }
 
+// FindPermissionIDsByServiceAction 서비스/액션 이름에 매핑된 권한 ID 목록 조회
+func (r *McmpApiPermissionActionMappingRepository) FindPermissionIDsByServiceAction(ctx context.Context, serviceName, actionName string) ([]string, error) {
+	var permissionIDs []string
+	err := r.db.Table("mcmp_api_permission_action_mappings m").
+		Joins("JOIN mcmp_api_actions a ON a.id = m.action_id").
+		Where("a.service_name = ? AND a.action_name = ?", serviceName, actionName).
+		Distinct().
+		Pluck("m.permission_id", &permissionIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions by action %s#%s: %w", serviceName, actionName, err)
+	}
+	return permissionIDs, nil
+}
+
 // CheckMappingExists 매핑 존재 여부 확인
 func (r *McmpApiPermissionActionMappingRepository) CheckMappingExists(ctx context.Context, permissionID string, actionID uint) (bool, error) {
 	var count int64
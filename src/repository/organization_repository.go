@@ -63,6 +63,18 @@ func (r *OrganizationRepository) FindByCode(code string) (*model.Organization, e
 	return &org, nil
 }
 
+// FindByName 조직 이름으로 조회 (같은 이름이 여러 개면 코드 순 첫 번째)
+func (r *OrganizationRepository) FindByName(name string) (*model.Organization, error) {
+	var org model.Organization
+	if err := r.db.Where("name = ?", name).Order("organization_code ASC").First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("error finding organization by name %s: %w", name, err)
+	}
+	return &org, nil
+}
+
 // FindAll 전체 조직 목록 조회 (평면)
 func (r *OrganizationRepository) FindAll() ([]model.Organization, error) {
 	var orgs []model.Organization
@@ -388,6 +400,17 @@ func (r *OrganizationRepository) RemoveUserFromOrganization(userID, orgID uint)
 	return nil
 }
 
+// IsUserInOrganization 사용자-조직 매핑 존재 여부
+func (r *OrganizationRepository) IsUserInOrganization(userID, orgID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.UserOrganization{}).
+		Where("user_id = ? AND organization_id = ?", userID, orgID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("error checking organization membership of user %d: %w", userID, err)
+	}
+	return count > 0, nil
+}
+
 // FindUserOrganizations 사용자가 소속된 조직 목록 조회 (계층 정보 포함)
 func (r *OrganizationRepository) FindUserOrganizations(userID uint) ([]model.OrganizationTree, error) {
 	type RawResult struct {
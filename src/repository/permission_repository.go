@@ -8,6 +8,7 @@ import (
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -101,22 +102,82 @@ func (r *MciamPermissionRepository) DeleteMcIamPermission(id string) error {
 	return nil
 }
 
+// EnsurePermissions register MC-IAM permissions and their resource types, skipping existing ones
+func (r *MciamPermissionRepository) EnsurePermissions(permissions []model.MciamPermission) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, perm := range permissions {
+			resourceType := model.ResourceType{
+				FrameworkID: perm.FrameworkID,
+				ID:          perm.ResourceTypeID,
+				Name:        perm.ResourceTypeID,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "framework_id"}, {Name: "id"}},
+				DoNothing: true,
+			}).Create(&resourceType).Error; err != nil {
+				return fmt.Errorf("failed to ensure resource type %s:%s: %w", perm.FrameworkID, perm.ResourceTypeID, err)
+			}
+
+			p := perm
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoNothing: true,
+			}).Create(&p).Error; err != nil {
+				return fmt.Errorf("failed to ensure permission %s: %w", perm.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindAppliedRoleOperationSeeds 기동 시 동기화로 이미 반영한 역할 권한 시드 항목
+func (r *MciamPermissionRepository) FindAppliedRoleOperationSeeds() ([]model.RoleOperationSeed, error) {
+	var seeds []model.RoleOperationSeed
+	if err := r.db.Find(&seeds).Error; err != nil {
+		return nil, fmt.Errorf("failed to find applied role operation seeds: %w", err)
+	}
+	return seeds, nil
+}
+
+// RecordRoleOperationSeeds 역할 권한 시드 항목을 반영한 것으로 기록 (이미 있으면 skip)
+func (r *MciamPermissionRepository) RecordRoleOperationSeeds(seeds []model.RoleOperationSeed) error {
+	if len(seeds) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_name"}, {Name: "permission_id"}, {Name: "effect"}},
+		DoNothing: true,
+	}).Create(&seeds).Error
+	if err != nil {
+		return fmt.Errorf("failed to record role operation seeds: %w", err)
+	}
+	return nil
+}
+
 // --- Role MC-IAM Permission Mappings ---
 
 // AssignMciamPermissionToRole assign MC-IAM permission to role - Renamed
-func (r *MciamPermissionRepository) AssignMciamPermissionToRole(roleType constants.IAMRoleType, roleID uint, permissionID string) error {
+// effect 가 비어 있으면 allow. 이미 매핑이 있으면 effect 만 갱신한다 (allow <-> deny 전환).
+func (r *MciamPermissionRepository) AssignMciamPermissionToRole(roleType constants.IAMRoleType, roleID uint, permissionID string, effect model.PermissionEffect, conditions *model.GrantConditions) error {
 	// Check if permission exists
 	if _, err := r.GetByID(permissionID); err != nil {
 		return err // Return ErrPermissionNotFound or other DB error
 	}
+	if effect == "" {
+		effect = model.PermissionEffectAllow
+	}
 
 	mapping := model.MciamRoleMciamPermission{ // Use new model name
 		RoleType:     roleType,
 		RoleID:       roleID,
 		PermissionID: permissionID,
+		Effect:       effect,
+		Conditions:   conditions,
 	}
-	// Use FirstOrCreate or similar to avoid duplicate errors, or handle constraint violation error
-	return r.db.Create(&mapping).Error // Simple create for now
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_type"}, {Name: "role_id"}, {Name: "permission_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"effect", "conditions"}),
+	}).Create(&mapping).Error
 }
 
 // RemoveMciamPermissionFromRole remove MC-IAM permission from role - Renamed
@@ -148,7 +209,7 @@ func (r *MciamPermissionRepository) GetPlatformRolePermissions(platformRole stri
 
 	var permissionIDs []string
 	err = r.db.Model(&model.MciamRoleMciamPermission{}).
-		Where("role_type = 'platform' AND role_id = ?", platformRoleID).
+		Where("role_type = 'platform' AND role_id = ? AND effect = ?", platformRoleID, model.PermissionEffectAllow).
 		Pluck("permission_id", &permissionIDs).Error
 	if err != nil {
 		return nil, err
@@ -156,13 +217,18 @@ func (r *MciamPermissionRepository) GetPlatformRolePermissions(platformRole stri
 	return permissionIDs, nil
 }
 
-// GetRoleMciamPermissions retrieve all MC-IAM permission IDs that workspace role has
+// GetRoleMciamPermissions retrieve all MC-IAM permission IDs that workspace role has (allow 매핑만)
 func (r *MciamPermissionRepository) GetRoleMciamPermissions(roleType constants.IAMRoleType, workspaceRoleID uint) ([]string, error) {
-	log.Printf("GetRoleMciamPermissions roleType: %s RoleID: %d", roleType, workspaceRoleID)
+	return r.GetRoleMciamPermissionsByEffect(roleType, workspaceRoleID, model.PermissionEffectAllow)
+}
+
+// GetRoleMciamPermissionsByEffect 역할에 매핑된 MC-IAM 권한 ID 목록 조회 (effect 별)
+func (r *MciamPermissionRepository) GetRoleMciamPermissionsByEffect(roleType constants.IAMRoleType, workspaceRoleID uint, effect model.PermissionEffect) ([]string, error) {
+	log.Printf("GetRoleMciamPermissions roleType: %s RoleID: %d effect: %s", roleType, workspaceRoleID, effect)
 
 	var permissionIDs []string
 	err := r.db.Model(&model.MciamRoleMciamPermission{}).
-		Where("role_type = ? AND role_id = ?", roleType, workspaceRoleID).
+		Where("role_type = ? AND role_id = ? AND effect = ?", roleType, workspaceRoleID, effect).
 		Pluck("permission_id", &permissionIDs).Error
 	if err != nil {
 		return nil, err
@@ -174,7 +240,7 @@ func (r *MciamPermissionRepository) GetRoleMciamPermissions(roleType constants.I
 func (r *MciamPermissionRepository) CheckRoleMciamPermission(roleType constants.IAMRoleType, roleID uint, permissionID string) (bool, error) {
 	var count int64
 	err := r.db.Model(&model.MciamRoleMciamPermission{}). // Use new model name
-								Where("role_type = ? AND role_id = ? AND permission_id = ?", roleType, roleID, permissionID). // Use new column name
+								Where("role_type = ? AND role_id = ? AND permission_id = ? AND effect = ?", roleType, roleID, permissionID, model.PermissionEffectAllow). // Use new column name
 								Count(&count).Error
 	if err != nil {
 		return false, err
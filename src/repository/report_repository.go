@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// ReportRepository 규정 준수 보고서 조회 저장소
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository ReportRepository 생성
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// FindPlatformRoleHolders 플랫폼 역할을 직접 또는 그룹(직접 소속)을 통해 보유한 사용자 목록
+func (r *ReportRepository) FindPlatformRoleHolders(roleName string) ([]model.ReportRoleHolder, error) {
+	var holders []model.ReportRoleHolder
+	err := r.db.Raw(`
+		SELECT u.id AS user_id, u.username, u.kc_id, u.status, rm.name AS role_name, '' AS group_name
+		FROM mcmp_user_platform_roles upr
+		JOIN mcmp_users u ON u.id = upr.user_id
+		JOIN mcmp_role_masters rm ON rm.id = upr.role_id
+		WHERE rm.name = ?
+		UNION ALL
+		SELECT u.id AS user_id, u.username, u.kc_id, u.status, rm.name AS role_name, o.name AS group_name
+		FROM mcmp_user_organizations uo
+		JOIN mcmp_group_platform_roles gpr ON gpr.group_id = uo.organization_id
+		JOIN mcmp_organizations o ON o.id = uo.organization_id
+		JOIN mcmp_users u ON u.id = uo.user_id
+		JOIN mcmp_role_masters rm ON rm.id = gpr.role_id
+		WHERE rm.name = ?
+		ORDER BY user_id, group_name
+	`, roleName, roleName).Scan(&holders).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding holders of platform role %s: %w", roleName, err)
+	}
+	return holders, nil
+}
+
+// FindActiveUsersInactiveSince 마지막 활동(기록이 없으면 생성 시각)이 기준 시각 이전인 활성 사용자 목록 (휴면 정책 예외 포함)
+func (r *ReportRepository) FindActiveUsersInactiveSince(before time.Time) ([]model.User, error) {
+	var users []model.User
+	err := r.db.Where("status = ? AND COALESCE(last_activity_at, created_at) < ?", model.UserStatusActive, before).
+		Order("id").Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding inactive users: %w", err)
+	}
+	return users, nil
+}
+
+// FindUnmappedCspRoles 어떤 역할에도 매핑되지 않은 CSP 역할 목록
+func (r *ReportRepository) FindUnmappedCspRoles() ([]model.CspRole, error) {
+	var roles []model.CspRole
+	mapped := r.db.Model(&model.RoleMasterCspRoleMapping{}).Select("1").
+		Where("mcmp_role_csp_role_mappings.csp_role_id = mcmp_role_csp_roles.id")
+	err := r.db.Preload("CspAccount").Where("NOT EXISTS (?)", mapped).Order("id").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding unmapped csp roles: %w", err)
+	}
+	return roles, nil
+}
+
+// FindEmptyWorkspaceRoles 사용자에게도 그룹에도 할당되지 않은 워크스페이스 역할 목록
+func (r *ReportRepository) FindEmptyWorkspaceRoles() ([]model.RoleMaster, error) {
+	var roles []model.RoleMaster
+	workspaceSub := r.db.Model(&model.RoleSub{}).Select("1").
+		Where("mcmp_role_subs.role_id = mcmp_role_masters.id AND mcmp_role_subs.role_type = ?", constants.RoleTypeWorkspace)
+	userAssigned := r.db.Model(&model.UserWorkspaceRole{}).Select("1").
+		Where("mcmp_user_workspace_roles.role_id = mcmp_role_masters.id")
+	groupAssigned := r.db.Model(&model.GroupWorkspaceRole{}).Select("1").
+		Where("mcmp_group_workspace_roles.role_id = mcmp_role_masters.id")
+	err := r.db.Where("EXISTS (?) AND NOT EXISTS (?) AND NOT EXISTS (?)", workspaceSub, userAssigned, groupAssigned).
+		Order("id").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding workspace roles without members: %w", err)
+	}
+	return roles, nil
+}
+
+// FindIdpConfigsByHealthStatus 마지막 연결 상태 확인 결과가 주어진 상태인 IDP 설정 목록
+func (r *ReportRepository) FindIdpConfigsByHealthStatus(statuses []string) ([]model.CspIdpConfig, error) {
+	var configs []model.CspIdpConfig
+	err := r.db.Preload("CspAccount").Where("last_health_status IN ?", statuses).Order("id").Find(&configs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding idp configs by health status: %w", err)
+	}
+	return configs, nil
+}
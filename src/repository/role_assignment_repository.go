@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// ErrRoleAssignmentNotFound 기한부 처리 대상 할당이 없음
+var ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+
+// RoleAssignmentRepository 사용자/그룹 역할 할당의 유효 기간(시작/만료) 관리
+type RoleAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleAssignmentRepository RoleAssignmentRepository 생성
+func NewRoleAssignmentRepository(db *gorm.DB) *RoleAssignmentRepository {
+	return &RoleAssignmentRepository{db: db}
+}
+
+// timedAssignmentSelects 4개 할당 테이블을 공통 컬럼으로 조회하는 SELECT (할당 테이블 별칭은 x)
+var timedAssignmentSelects = []string{
+	`SELECT '` + model.RoleAssignmentUserPlatform + `' AS assignment_type, x.user_id, u.username, u.kc_id, 0 AS group_id, '' AS group_name, 0 AS workspace_id,
+		x.role_id, rm.name AS role_name, x.valid_from, x.expires_at, x.activation_pending
+		FROM mcmp_user_platform_roles x
+		JOIN mcmp_users u ON u.id = x.user_id
+		JOIN mcmp_role_masters rm ON rm.id = x.role_id`,
+	`SELECT '` + model.RoleAssignmentUserWorkspace + `' AS assignment_type, x.user_id, u.username, u.kc_id, 0 AS group_id, '' AS group_name, x.workspace_id,
+		x.role_id, rm.name AS role_name, x.valid_from, x.expires_at, x.activation_pending
+		FROM mcmp_user_workspace_roles x
+		JOIN mcmp_users u ON u.id = x.user_id
+		JOIN mcmp_role_masters rm ON rm.id = x.role_id`,
+	`SELECT '` + model.RoleAssignmentGroupPlatform + `' AS assignment_type, 0 AS user_id, '' AS username, '' AS kc_id, x.group_id, o.name AS group_name, 0 AS workspace_id,
+		x.role_id, rm.name AS role_name, x.valid_from, x.expires_at, x.activation_pending
+		FROM mcmp_group_platform_roles x
+		JOIN mcmp_organizations o ON o.id = x.group_id
+		JOIN mcmp_role_masters rm ON rm.id = x.role_id`,
+	`SELECT '` + model.RoleAssignmentGroupWorkspace + `' AS assignment_type, 0 AS user_id, '' AS username, '' AS kc_id, x.group_id, o.name AS group_name, x.workspace_id,
+		x.role_id, rm.name AS role_name, x.valid_from, x.expires_at, x.activation_pending
+		FROM mcmp_group_workspace_roles x
+		JOIN mcmp_organizations o ON o.id = x.group_id
+		JOIN mcmp_role_masters rm ON rm.id = x.role_id`,
+}
+
+// findTimed 4개 할당 테이블에서 조건(cond, 별칭 x 기준)에 맞는 할당 조회
+func (r *RoleAssignmentRepository) findTimed(cond string, args ...interface{}) ([]model.TimedRoleAssignment, error) {
+	parts := make([]string, 0, len(timedAssignmentSelects))
+	all := make([]interface{}, 0, len(args)*len(timedAssignmentSelects))
+	for _, sel := range timedAssignmentSelects {
+		parts = append(parts, sel+"\n\t\tWHERE "+cond)
+		all = append(all, args...)
+	}
+	var assignments []model.TimedRoleAssignment
+	if err := r.db.Raw(strings.Join(parts, "\n\t\tUNION ALL\n\t\t"), all...).Scan(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// FindPendingActivations 시작 시각이 지났지만 아직 활성화(Keycloak 부여)되지 않은 할당 (만료된 할당 제외)
+func (r *RoleAssignmentRepository) FindPendingActivations(now time.Time) ([]model.TimedRoleAssignment, error) {
+	assignments, err := r.findTimed("x.activation_pending = ? AND x.valid_from <= ? AND (x.expires_at IS NULL OR x.expires_at > ?)", true, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("error finding pending role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// FindExpiredAssignments 만료 시각이 지난 할당
+func (r *RoleAssignmentRepository) FindExpiredAssignments(now time.Time) ([]model.TimedRoleAssignment, error) {
+	assignments, err := r.findTimed("x.expires_at IS NOT NULL AND x.expires_at <= ?", now)
+	if err != nil {
+		return nil, fmt.Errorf("error finding expired role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// scope 할당 유형별 모델과 기본 키 조건
+func (r *RoleAssignmentRepository) scope(a *model.TimedRoleAssignment) (interface{}, *gorm.DB, error) {
+	switch a.Type {
+	case model.RoleAssignmentUserPlatform:
+		return &model.UserPlatformRole{}, r.db.Where("user_id = ? AND role_id = ?", a.UserID, a.RoleID), nil
+	case model.RoleAssignmentUserWorkspace:
+		return &model.UserWorkspaceRole{}, r.db.Where("user_id = ? AND workspace_id = ? AND role_id = ?", a.UserID, a.WorkspaceID, a.RoleID), nil
+	case model.RoleAssignmentGroupPlatform:
+		return &model.GroupPlatformRole{}, r.db.Where("group_id = ? AND role_id = ?", a.GroupID, a.RoleID), nil
+	case model.RoleAssignmentGroupWorkspace:
+		return &model.GroupWorkspaceRole{}, r.db.Where("group_id = ? AND workspace_id = ? AND role_id = ?", a.GroupID, a.WorkspaceID, a.RoleID), nil
+	}
+	return nil, nil, fmt.Errorf("unknown role assignment type: %s", a.Type)
+}
+
+// SetExpiry 기존 할당의 만료 시각 변경 (nil 이면 무기한)
+func (r *RoleAssignmentRepository) SetExpiry(a *model.TimedRoleAssignment, expiresAt *time.Time) error {
+	value, query, err := r.scope(a)
+	if err != nil {
+		return err
+	}
+	result := query.Model(value).Update("expires_at", expiresAt)
+	if result.Error != nil {
+		return fmt.Errorf("error updating %s expiry: %w", a.Type, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRoleAssignmentNotFound
+	}
+	return nil
+}
+
+// IsActivationPending 할당이 시작 전(Keycloak 미부여) 상태인지
+func (r *RoleAssignmentRepository) IsActivationPending(a *model.TimedRoleAssignment) (bool, error) {
+	value, query, err := r.scope(a)
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	if err := query.Model(value).Where("activation_pending = ?", true).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("error checking %s activation: %w", a.Type, err)
+	}
+	return count > 0, nil
+}
+
+// ClearActivationPending 시작 처리 완료 표시
+func (r *RoleAssignmentRepository) ClearActivationPending(a *model.TimedRoleAssignment) error {
+	value, query, err := r.scope(a)
+	if err != nil {
+		return err
+	}
+	if err := query.Model(value).Update("activation_pending", false).Error; err != nil {
+		return fmt.Errorf("error activating %s: %w", a.Type, err)
+	}
+	return nil
+}
+
+// Delete 할당 삭제
+func (r *RoleAssignmentRepository) Delete(a *model.TimedRoleAssignment) error {
+	value, query, err := r.scope(a)
+	if err != nil {
+		return err
+	}
+	if err := query.Delete(value).Error; err != nil {
+		return fmt.Errorf("error deleting %s: %w", a.Type, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -265,6 +266,11 @@ func (r *RoleRepository) UpdateRoleWithSubs(role model.RoleMaster, roleTypes []c
 func (r *RoleRepository) UpdateRoleWithSubsWithTx(tx *gorm.DB, role model.RoleMaster, roleTypes []constants.IAMRoleType) (*model.RoleMaster, error) {
 	var updatedRole *model.RoleMaster
 
+	// 0. 상위 역할 검증 (존재 여부, 순환 참조)
+	if err := validateRoleParent(tx, role.ID, role.ParentID); err != nil {
+		return nil, err
+	}
+
 	// 1. 역할 마스터 수정
 	if err := tx.Save(&role).Error; err != nil {
 		return nil, fmt.Errorf("역할 수정 실패: %w", err)
@@ -296,9 +302,17 @@ func (r *RoleRepository) UpdateRoleWithSubsWithTx(tx *gorm.DB, role model.RoleMa
 
 // AssignPlatformRole 플랫폼 역할 할당
 func (r *RoleRepository) AssignPlatformRole(userID, roleID uint) error {
+	return r.AssignPlatformRoleWithValidity(userID, roleID, model.AssignmentValidity{})
+}
+
+// AssignPlatformRoleWithValidity 유효 기간을 지정하여 플랫폼 역할 할당 (시작 전이면 activation_pending)
+func (r *RoleRepository) AssignPlatformRoleWithValidity(userID, roleID uint, validity model.AssignmentValidity) error {
 	userRole := model.UserPlatformRole{
-		UserID: userID,
-		RoleID: roleID,
+		UserID:            userID,
+		RoleID:            roleID,
+		ValidFrom:         validity.ValidFrom,
+		ExpiresAt:         validity.ExpiresAt,
+		ActivationPending: validity.PendingAt(time.Now()),
 	}
 	return r.db.Create(&userRole).Error
 }
@@ -311,10 +325,18 @@ func (r *RoleRepository) RemovePlatformRole(userID, roleID uint) error {
 
 // AssignWorkspaceRole 워크스페이스 역할 할당
 func (r *RoleRepository) AssignWorkspaceRole(userID, workspaceID, roleID uint) error {
+	return r.AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID, model.AssignmentValidity{})
+}
+
+// AssignWorkspaceRoleWithValidity 유효 기간을 지정하여 워크스페이스 역할 할당 (시작 전이면 activation_pending)
+func (r *RoleRepository) AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
 	userWorkspaceRole := model.UserWorkspaceRole{
-		UserID:      userID,
-		WorkspaceID: workspaceID,
-		RoleID:      roleID,
+		UserID:            userID,
+		WorkspaceID:       workspaceID,
+		RoleID:            roleID,
+		ValidFrom:         validity.ValidFrom,
+		ExpiresAt:         validity.ExpiresAt,
+		ActivationPending: validity.PendingAt(time.Now()),
 	}
 	return r.db.Create(&userWorkspaceRole).Error
 }
@@ -400,6 +422,7 @@ func (r *RoleRepository) CreateRoleCspRoleMapping(req *model.CreateRoleMasterCsp
 		AuthMethod:  req.AuthMethod,
 		CspRoleID:   cspRoleIDInt,
 		Description: req.Description,
+		Conditions:  req.Conditions,
 	}
 	return r.db.Create(mapping).Error
 }
@@ -737,6 +760,17 @@ func (r *RoleRepository) IsAssignedWorkspaceRole(userID uint, roleID uint) (bool
 	return count > 0, nil
 }
 
+// IsAssignedWorkspaceRoleInWorkspace 사용자에게 특정 워크스페이스의 역할이 할당되어 있는지 확인
+func (r *RoleRepository) IsAssignedWorkspaceRoleInWorkspace(userID, workspaceID, roleID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.UserWorkspaceRole{}).
+		Where("user_id = ? AND workspace_id = ? AND role_id = ?", userID, workspaceID, roleID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("워크스페이스 역할 할당 확인 중 오류 발생: %w", err)
+	}
+	return count > 0, nil
+}
+
 // GetWorkspaceRoles 워크스페이스의 모든 역할 목록 조회 : TODO : 목록조회면 ListWorkspaceRoleById 가 맞을 듯.
 func (r *RoleRepository) GetWorkspaceRoles(workspaceID uint) ([]model.RoleMaster, error) {
 	var roles []model.RoleMaster
@@ -848,6 +882,9 @@ func (r *RoleRepository) CreateRoleWithSubs(role *model.RoleMaster, roleSubs []m
 			role.ID = existingRole.ID
 		} else {
 			// 새로운 역할 생성
+			if err := validateRoleParent(tx, 0, role.ParentID); err != nil {
+				return err
+			}
 			if err := tx.Create(role).Error; err != nil {
 				return fmt.Errorf("역할 생성 실패: %w", err)
 			}
@@ -904,6 +941,9 @@ func (r *RoleRepository) CreateRoleWithSubsWithTx(tx *gorm.DB, role *model.RoleM
 		role.ID = existingRole.ID
 	} else {
 		// 새로운 역할 생성
+		if err := validateRoleParent(tx, 0, role.ParentID); err != nil {
+			return nil, err
+		}
 		if err := tx.Create(role).Error; err != nil {
 			return nil, fmt.Errorf("역할 생성 실패: %w", err)
 		}
@@ -1158,6 +1198,157 @@ func (r *RoleRepository) FindEffectiveWorkspaceRoles(userID uint) ([]model.Effec
 	return roles, nil
 }
 
+// FindRoleLineage 역할과 상위 역할 목록 조회 (자기 자신부터 최상위 순)
+// 저장된 계층에 순환이 있으면 순환 지점에서 멈춘다.
+func (r *RoleRepository) FindRoleLineage(roleID uint) ([]model.RoleMaster, error) {
+	roles, err := findRoleHierarchy(r.db)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := roles[roleID]; !ok {
+		return nil, ErrRoleMasterNotFound
+	}
+	return roleLineage(roles, roleID), nil
+}
+
+// FindRoleIDsWithAncestors 역할 ID 목록에 상위 역할 ID를 모두 더해 반환 (중복 제거, 입력 순서 유지)
+func (r *RoleRepository) FindRoleIDsWithAncestors(roleIDs []uint) ([]uint, error) {
+	if len(roleIDs) == 0 {
+		return roleIDs, nil
+	}
+	roles, err := findRoleHierarchy(r.db)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint]bool)
+	result := make([]uint, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		if _, ok := roles[roleID]; !ok {
+			if !seen[roleID] {
+				seen[roleID] = true
+				result = append(result, roleID)
+			}
+			continue
+		}
+		for _, role := range roleLineage(roles, roleID) {
+			if !seen[role.ID] {
+				seen[role.ID] = true
+				result = append(result, role.ID)
+			}
+		}
+	}
+	return result, nil
+}
+
+// FindRoleMciamPermissionMappings 역할 목록에 매핑된 MC-IAM 권한 조회 (역할 타입 무관)
+func (r *RoleRepository) FindRoleMciamPermissionMappings(roleIDs []uint) ([]model.MciamRoleMciamPermission, error) {
+	var mappings []model.MciamRoleMciamPermission
+	if len(roleIDs) == 0 {
+		return mappings, nil
+	}
+	if err := r.db.Where("role_id IN ?", roleIDs).Order("role_type, permission_id").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("역할 권한 매핑 조회 실패: %w", err)
+	}
+	return mappings, nil
+}
+
+// FindRoleMenuMappings 역할 목록에 매핑된 메뉴 조회
+func (r *RoleRepository) FindRoleMenuMappings(roleIDs []uint) ([]model.RoleMenuMapping, error) {
+	var mappings []model.RoleMenuMapping
+	if len(roleIDs) == 0 {
+		return mappings, nil
+	}
+	if err := r.db.Where("role_id IN ?", roleIDs).Order("menu_id").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("역할 메뉴 매핑 조회 실패: %w", err)
+	}
+	return mappings, nil
+}
+
+// FindRoleCspMappingsWithCspRole 역할 목록에 매핑된 CSP 역할 조회 (CSP 역할 이름/타입 포함, SourceRoleID 에 매핑 역할 ID)
+func (r *RoleRepository) FindRoleCspMappingsWithCspRole(roleIDs []uint) ([]model.ResolvedRoleCspMapping, error) {
+	var mappings []model.ResolvedRoleCspMapping
+	if len(roleIDs) == 0 {
+		return mappings, nil
+	}
+	err := r.db.Raw(`
+		SELECT m.role_id AS source_role_id, m.auth_method, m.csp_role_id, m.conditions, c.name AS csp_role_name, c.csp_type
+		FROM mcmp_role_csp_role_mappings m
+		JOIN mcmp_role_csp_roles c ON c.id = m.csp_role_id
+		WHERE m.role_id IN ?
+		ORDER BY c.csp_type, m.auth_method, m.csp_role_id
+	`, roleIDs).Scan(&mappings).Error
+	if err != nil {
+		return nil, fmt.Errorf("역할 CSP 매핑 조회 실패: %w", err)
+	}
+	return mappings, nil
+}
+
+// validateRoleParent 상위 역할 지정 검증
+// 상위 역할이 존재해야 하며, 자기 자신이나 하위 역할을 상위로 지정하면 ErrCircularReference 를 반환한다.
+// roleID 가 0 이면 신규 역할로 보고 존재 여부만 확인한다.
+func validateRoleParent(db *gorm.DB, roleID uint, parentID *uint) error {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+	if roleID != 0 && *parentID == roleID {
+		return fmt.Errorf("role %d cannot be its own parent: %w", roleID, ErrCircularReference)
+	}
+
+	roles, err := findRoleHierarchy(db)
+	if err != nil {
+		return err
+	}
+	if _, ok := roles[*parentID]; !ok {
+		return fmt.Errorf("parent role %d: %w", *parentID, ErrRoleMasterNotFound)
+	}
+	if roleID == 0 {
+		return nil
+	}
+	for _, ancestor := range roleLineage(roles, *parentID) {
+		if ancestor.ID == roleID {
+			return fmt.Errorf("role %d is an ancestor of parent role %d: %w", roleID, *parentID, ErrCircularReference)
+		}
+	}
+	return nil
+}
+
+// findRoleHierarchy 전체 역할의 id/name/parent_id 조회 (계층 탐색용)
+func findRoleHierarchy(db *gorm.DB) (map[uint]model.RoleMaster, error) {
+	var roles []model.RoleMaster
+	if err := db.Model(&model.RoleMaster{}).Select("id", "name", "parent_id").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("역할 계층 조회 실패: %w", err)
+	}
+	result := make(map[uint]model.RoleMaster, len(roles))
+	for _, role := range roles {
+		result[role.ID] = role
+	}
+	return result, nil
+}
+
+// roleLineage roleID부터 최상위까지 상위 역할을 따라간 목록 (순환 시 중단)
+func roleLineage(roles map[uint]model.RoleMaster, roleID uint) []model.RoleMaster {
+	var lineage []model.RoleMaster
+	visited := make(map[uint]bool)
+	id := roleID
+	for {
+		role, ok := roles[id]
+		if !ok {
+			break
+		}
+		if visited[id] {
+			log.Printf("role hierarchy cycle detected at role %d", id)
+			break
+		}
+		visited[id] = true
+		lineage = append(lineage, role)
+		if role.ParentID == nil {
+			break
+		}
+		id = *role.ParentID
+	}
+	return lineage
+}
+
 // containsRoleType roleTypes 슬라이스에 특정 roleType이 포함되어 있는지 확인
 func containsRoleType(roleTypes []constants.IAMRoleType, roleType constants.IAMRoleType) bool {
 	for _, rt := range roleTypes {
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSodConstraintNotFound = errors.New("sod constraint not found")
+	ErrSodOverrideNotFound   = errors.New("sod override not found")
+)
+
+// SodRepository 직무 분리(SoD) 제약/예외 저장소
+type SodRepository struct {
+	db *gorm.DB
+}
+
+// NewSodRepository SodRepository 생성
+func NewSodRepository(db *gorm.DB) *SodRepository {
+	return &SodRepository{db: db}
+}
+
+// ListConstraints 제약 목록 (enabledOnly 면 활성 제약만)
+func (r *SodRepository) ListConstraints(enabledOnly bool) ([]model.SodConstraint, error) {
+	query := r.db.Model(&model.SodConstraint{})
+	if enabledOnly {
+		query = query.Where("enabled = ?", true)
+	}
+	var constraints []model.SodConstraint
+	if err := query.Order("id").Find(&constraints).Error; err != nil {
+		return nil, fmt.Errorf("error listing sod constraints: %w", err)
+	}
+	return constraints, nil
+}
+
+// FindConstraintByID ID로 제약 조회
+func (r *SodRepository) FindConstraintByID(id uint) (*model.SodConstraint, error) {
+	var constraint model.SodConstraint
+	if err := r.db.First(&constraint, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSodConstraintNotFound
+		}
+		return nil, fmt.Errorf("error finding sod constraint %d: %w", id, err)
+	}
+	return &constraint, nil
+}
+
+// CreateConstraint 제약 생성
+func (r *SodRepository) CreateConstraint(constraint *model.SodConstraint) error {
+	if err := r.db.Create(constraint).Error; err != nil {
+		return fmt.Errorf("error creating sod constraint: %w", err)
+	}
+	return nil
+}
+
+// UpdateConstraint 제약 저장
+func (r *SodRepository) UpdateConstraint(constraint *model.SodConstraint) error {
+	if err := r.db.Save(constraint).Error; err != nil {
+		return fmt.Errorf("error updating sod constraint %d: %w", constraint.ID, err)
+	}
+	return nil
+}
+
+// DeleteConstraint 제약과 예외 삭제
+func (r *SodRepository) DeleteConstraint(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("constraint_id = ?", id).Delete(&model.SodOverride{}).Error; err != nil {
+			return fmt.Errorf("error deleting sod overrides: %w", err)
+		}
+		result := tx.Delete(&model.SodConstraint{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("error deleting sod constraint %d: %w", id, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrSodConstraintNotFound
+		}
+		return nil
+	})
+}
+
+// ListOverrides 예외 목록 (0 인 조건은 제한 없음, 최신순)
+func (r *SodRepository) ListOverrides(constraintID, userID uint) ([]model.SodOverride, error) {
+	query := r.db.Model(&model.SodOverride{})
+	if constraintID != 0 {
+		query = query.Where("constraint_id = ?", constraintID)
+	}
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	var overrides []model.SodOverride
+	if err := query.Order("id DESC").Find(&overrides).Error; err != nil {
+		return nil, fmt.Errorf("error listing sod overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// FindActiveOverrides 사용자들의 유효한 예외 (사용자 ID → 제약 ID → 예외)
+func (r *SodRepository) FindActiveOverrides(userIDs []uint, now time.Time) (map[uint]map[uint]model.SodOverride, error) {
+	var overrides []model.SodOverride
+	err := r.db.Where("user_id IN ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userIDs, now).
+		Order("id").Find(&overrides).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding active sod overrides: %w", err)
+	}
+	active := make(map[uint]map[uint]model.SodOverride)
+	for _, o := range overrides {
+		if active[o.UserID] == nil {
+			active[o.UserID] = make(map[uint]model.SodOverride)
+		}
+		active[o.UserID][o.ConstraintID] = o
+	}
+	return active, nil
+}
+
+// FindOverrideByID ID로 예외 조회
+func (r *SodRepository) FindOverrideByID(id uint) (*model.SodOverride, error) {
+	var override model.SodOverride
+	if err := r.db.First(&override, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSodOverrideNotFound
+		}
+		return nil, fmt.Errorf("error finding sod override %d: %w", id, err)
+	}
+	return &override, nil
+}
+
+// CreateOverride 예외 생성
+func (r *SodRepository) CreateOverride(override *model.SodOverride) error {
+	if err := r.db.Create(override).Error; err != nil {
+		return fmt.Errorf("error creating sod override: %w", err)
+	}
+	return nil
+}
+
+// RevokeOverride 예외 철회 (이미 철회된 예외는 변경하지 않음)
+func (r *SodRepository) RevokeOverride(id uint, revokedByKcID string, revokedAt time.Time) error {
+	result := r.db.Model(&model.SodOverride{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": revokedAt, "revoked_by_kc_id": revokedByKcID})
+	if result.Error != nil {
+		return fmt.Errorf("error revoking sod override %d: %w", id, result.Error)
+	}
+	return nil
+}
+
+// FindRoleNames 역할 ID → 이름
+func (r *SodRepository) FindRoleNames(roleIDs []uint) (map[uint]string, error) {
+	var roles []model.RoleMaster
+	if err := r.db.Select("id", "name").Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("error finding roles: %w", err)
+	}
+	names := make(map[uint]string, len(roles))
+	for _, role := range roles {
+		names[role.ID] = role.Name
+	}
+	return names, nil
+}
+
+// FindRoleIDsByNames 역할 이름 → ID
+func (r *SodRepository) FindRoleIDsByNames(names []string) (map[string]uint, error) {
+	var roles []model.RoleMaster
+	if err := r.db.Select("id", "name").Where("name IN ?", names).Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("error finding roles: %w", err)
+	}
+	ids := make(map[string]uint, len(roles))
+	for _, role := range roles {
+		ids[role.Name] = role.ID
+	}
+	return ids, nil
+}
+
+// FindUsersHoldingRoles 역할을 직접 또는 그룹을 통해 보유한 사용자 ID 목록
+func (r *SodRepository) FindUsersHoldingRoles(roleIDs []uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Raw(`
+		SELECT user_id FROM mcmp_user_platform_roles WHERE role_id IN ?
+		UNION
+		SELECT user_id FROM mcmp_user_workspace_roles WHERE role_id IN ?
+		UNION
+		SELECT uo.user_id FROM mcmp_user_organizations uo
+		JOIN mcmp_group_platform_roles gpr ON gpr.group_id = uo.organization_id
+		WHERE gpr.role_id IN ?
+		UNION
+		SELECT uo.user_id FROM mcmp_user_organizations uo
+		JOIN mcmp_group_workspace_roles gwr ON gwr.group_id = uo.organization_id
+		WHERE gwr.role_id IN ?
+		ORDER BY user_id
+	`, roleIDs, roleIDs, roleIDs, roleIDs).Scan(&userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding users holding roles: %w", err)
+	}
+	return userIDs, nil
+}
+
+// FindUsernames 사용자 ID → 사용자명
+func (r *SodRepository) FindUsernames(userIDs []uint) (map[uint]string, error) {
+	var users []model.User
+	if err := r.db.Select("id", "username").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error finding users: %w", err)
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+	return names, nil
+}
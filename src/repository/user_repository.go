@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	// "github.com/m-cmp/mc-iam-manager/config" // Removed Keycloak config dependency
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -167,6 +168,92 @@ func (r *UserRepository) UpdateStatus(id uint, status model.UserStatus) error {
 	return nil
 }
 
+// RecordActivity 활동 시각 기록 (종류별 시각과 마지막 활동 시각 갱신, 휴면 경고 초기화)
+func (r *UserRepository) RecordActivity(kcID, kind string, at time.Time) error {
+	column := ""
+	switch kind {
+	case model.UserActivityLogin:
+		column = "last_login_at"
+	case model.UserActivityRefresh:
+		column = "last_refresh_at"
+	case model.UserActivityTokenUse:
+		column = "last_token_use_at"
+	default:
+		return fmt.Errorf("unknown user activity %q", kind)
+	}
+	err := r.db.Model(&model.User{}).Where("kc_id = ?", kcID).Updates(map[string]interface{}{
+		column:               at,
+		"last_activity_at":   at,
+		"dormancy_warned_at": nil,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record %s activity (kc_id: %s): %w", kind, kcID, err)
+	}
+	return nil
+}
+
+// ResetDormancy 마지막 활동 시각을 at 으로 두고 휴면 경고 초기화 (재활성화 직후 다시 휴면 처리되지 않도록)
+func (r *UserRepository) ResetDormancy(id uint, at time.Time) error {
+	err := r.db.Model(&model.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_activity_at":   at,
+		"dormancy_warned_at": nil,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to reset dormancy (id: %d): %w", id, err)
+	}
+	return nil
+}
+
+// FindDormancyCandidates 마지막 활동(기록이 없으면 생성 시각)이 기준 시각 이전인 활성 사용자 중 예외가 아닌 사용자
+// exemptRoleName 이 있으면 그 플랫폼 역할을 직접 또는 그룹(직접 소속)을 통해 보유한 사용자도 제외한다.
+func (r *UserRepository) FindDormancyCandidates(before time.Time, exemptRoleName string) ([]model.User, error) {
+	var users []model.User
+	query := r.db.Where("status = ? AND COALESCE(dormancy_exemption, '') = '' AND COALESCE(last_activity_at, created_at) < ?",
+		model.UserStatusActive, before)
+	if exemptRoleName != "" {
+		query = query.Where(`id NOT IN (
+			SELECT upr.user_id FROM mcmp_user_platform_roles upr
+			JOIN mcmp_role_masters rm ON rm.id = upr.role_id WHERE rm.name = ?
+			UNION
+			SELECT uo.user_id FROM mcmp_user_organizations uo
+			JOIN mcmp_group_platform_roles gpr ON gpr.group_id = uo.organization_id
+			JOIN mcmp_role_masters rm ON rm.id = gpr.role_id WHERE rm.name = ?)`, exemptRoleName, exemptRoleName)
+	}
+	if err := query.Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to find dormancy candidates: %w", err)
+	}
+	return users, nil
+}
+
+// BaselineLastActivity 활동 기록이 없는 사용자의 마지막 활동 시각을 at 으로 설정 (휴면 정책 시작 시점을 기준으로 계산되도록)
+func (r *UserRepository) BaselineLastActivity(at time.Time) (int64, error) {
+	result := r.db.Model(&model.User{}).Where("last_activity_at IS NULL").Update("last_activity_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to baseline last activity: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkDormancyWarned 휴면 경고 발송 시각 기록
+func (r *UserRepository) MarkDormancyWarned(id uint, at time.Time) error {
+	if err := r.db.Model(&model.User{}).Where("id = ?", id).Update("dormancy_warned_at", at).Error; err != nil {
+		return fmt.Errorf("failed to mark dormancy warning (id: %d): %w", id, err)
+	}
+	return nil
+}
+
+// UpdateDormancyExemption 휴면 정책 예외 사유 변경 (빈 값이면 해제)
+func (r *UserRepository) UpdateDormancyExemption(id uint, exemption string) error {
+	result := r.db.Model(&model.User{}).Where("id = ?", id).Update("dormancy_exemption", exemption)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update dormancy exemption (id: %d): %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
 // DeleteAllRoleMappings removes all platform roles, workspace roles, and organization mappings for a user.
 func (r *UserRepository) DeleteAllRoleMappings(userID uint) error {
 	if err := r.db.Where("user_id = ?", userID).Delete(&model.UserPlatformRole{}).Error; err != nil {
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWorkflowRequestNotFound 워크플로 요청이 없음
+	ErrWorkflowRequestNotFound = errors.New("workflow request not found")
+	// ErrWorkflowDecisionExists 승인자가 이미 결정함
+	ErrWorkflowDecisionExists = errors.New("approver has already decided on the workflow request")
+)
+
+// WorkflowRepository 승인 워크플로 정책/요청/결정/의견 저장
+type WorkflowRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowRepository WorkflowRepository 생성
+func NewWorkflowRepository(db *gorm.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// FindPolicy 요청 유형의 정책 조회 (없으면 nil)
+func (r *WorkflowRepository) FindPolicy(requestType string) (*model.WorkflowPolicy, error) {
+	var policies []model.WorkflowPolicy
+	if err := r.db.Where("type = ?", requestType).Limit(1).Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("error finding workflow policy %s: %w", requestType, err)
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return &policies[0], nil
+}
+
+// SavePolicy 정책 저장 (유형별 1행, 있으면 덮어씀)
+func (r *WorkflowRepository) SavePolicy(policy *model.WorkflowPolicy) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "approver_rules", "required_approvals", "timeout_minutes", "escalation_rules", "updated_by", "updated_at"}),
+	}).Create(policy).Error
+	if err != nil {
+		return fmt.Errorf("error saving workflow policy %s: %w", policy.Type, err)
+	}
+	return nil
+}
+
+// Create 요청 저장
+func (r *WorkflowRepository) Create(req *model.WorkflowRequest) error {
+	if err := r.db.Create(req).Error; err != nil {
+		return fmt.Errorf("error creating workflow request: %w", err)
+	}
+	return nil
+}
+
+// FindByID ID로 요청 조회 (결정과 의견 포함)
+func (r *WorkflowRepository) FindByID(id uint) (*model.WorkflowRequest, error) {
+	var req model.WorkflowRequest
+	err := r.db.Preload("Decisions", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Comments", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&req, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkflowRequestNotFound
+		}
+		return nil, fmt.Errorf("error finding workflow request %d: %w", id, err)
+	}
+	return &req, nil
+}
+
+// List 요청 목록 (조건이 비어 있으면 제한 없음, 최신순)
+func (r *WorkflowRepository) List(filter model.WorkflowFilter) ([]model.WorkflowRequest, error) {
+	query := r.db.Model(&model.WorkflowRequest{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RequesterID != 0 {
+		query = query.Where("requester_id = ?", filter.RequesterID)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	var requests []model.WorkflowRequest
+	if err := query.Order("id DESC").Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("error listing workflow requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ExistsPending 같은 유형/대상의 대기 중 요청이 있는지
+func (r *WorkflowRepository) ExistsPending(requestType, key string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.WorkflowRequest{}).
+		Where("type = ? AND request_key = ? AND status = ?", requestType, key, model.WorkflowPending).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking pending workflow requests: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Transition 요청 상태를 from 에서 다른 상태로 변경 (이미 다른 상태면 false)
+func (r *WorkflowRepository) Transition(id uint, from string, updates map[string]interface{}) (bool, error) {
+	result := r.db.Model(&model.WorkflowRequest{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("error updating workflow request %d: %w", id, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// AddDecision 승인자 결정 저장 (같은 승인자의 두 번째 결정은 ErrWorkflowDecisionExists)
+func (r *WorkflowRepository) AddDecision(decision *model.WorkflowDecision) error {
+	var count int64
+	err := r.db.Model(&model.WorkflowDecision{}).
+		Where("request_id = ? AND approver_id = ?", decision.RequestID, decision.ApproverID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("error checking workflow decisions: %w", err)
+	}
+	if count > 0 {
+		return ErrWorkflowDecisionExists
+	}
+	if err := r.db.Create(decision).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrWorkflowDecisionExists
+		}
+		return fmt.Errorf("error creating workflow decision: %w", err)
+	}
+	return nil
+}
+
+// CountApprovals 요청의 승인 결정 수
+func (r *WorkflowRepository) CountApprovals(requestID uint) (int, error) {
+	var count int64
+	err := r.db.Model(&model.WorkflowDecision{}).
+		Where("request_id = ? AND decision = ?", requestID, model.WorkflowDecisionApprove).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("error counting workflow approvals: %w", err)
+	}
+	return int(count), nil
+}
+
+// AddComment 의견 저장
+func (r *WorkflowRepository) AddComment(comment *model.WorkflowComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return fmt.Errorf("error creating workflow comment: %w", err)
+	}
+	return nil
+}
+
+// ListDue 승인 기한이 지난 대기 중 요청
+func (r *WorkflowRepository) ListDue(now time.Time) ([]model.WorkflowRequest, error) {
+	var requests []model.WorkflowRequest
+	err := r.db.Where("status = ? AND due_at IS NOT NULL AND due_at <= ?", model.WorkflowPending, now).
+		Order("id").Find(&requests).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing due workflow requests: %w", err)
+	}
+	return requests, nil
+}
+
+// SharesOrganization 두 사용자가 같은 조직에 직접 소속되어 있는지
+func (r *WorkflowRepository) SharesOrganization(userID, otherUserID uint) (bool, error) {
+	var count int64
+	organizations := r.db.Model(&model.UserOrganization{}).Select("organization_id").Where("user_id = ?", otherUserID)
+	err := r.db.Model(&model.UserOrganization{}).
+		Where("user_id = ? AND organization_id IN (?)", userID, organizations).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error checking shared organization: %w", err)
+	}
+	return count > 0, nil
+}
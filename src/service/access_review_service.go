@@ -0,0 +1,621 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/report"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAccessReviewRequest = errors.New("invalid access review request")
+	ErrAccessReviewCampaignClosed = errors.New("access review campaign is closed")
+	ErrAccessReviewItemDecided    = errors.New("access review item is already decided")
+	ErrAccessReviewSelfReview     = errors.New("reviewers cannot decide on their own access")
+)
+
+// 접근 검토 권한
+const (
+	AccessReviewManagePermission = "mc-iam-manager:access-review:manage" // 캠페인 관리, 모든 항목 결정
+	AccessReviewReviewPermission = "mc-iam-manager:access-review:review" // 소속 워크스페이스/조직 항목 결정
+)
+
+const defaultAccessReviewCloseInterval = time.Minute
+
+// AccessReviewService 접근 검토(재인증) 캠페인 서비스
+// 검토자는 캠페인 관리자, 항목 워크스페이스에서 검토 권한을 가진 구성원, 또는 항목 조직에 소속되어 검토 권한을 가진 사용자이다.
+// 회수 결정은 즉시 역할 할당을 제거하며, 플랫폼 역할은 Keycloak realm role 도 함께 제거한다.
+type AccessReviewService struct {
+	db            *gorm.DB
+	reviewRepo    *repository.AccessReviewRepository
+	roleRepo      *repository.RoleRepository
+	groupRoleRepo *repository.GroupRoleRepository
+	userRepo      *repository.UserRepository
+	authzService  *AuthzService
+	auditService  *AuditService
+	kcService     KeycloakService
+}
+
+// NewAccessReviewService AccessReviewService 생성
+func NewAccessReviewService(db *gorm.DB) *AccessReviewService {
+	return &AccessReviewService{
+		db:            db,
+		reviewRepo:    repository.NewAccessReviewRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		groupRoleRepo: repository.NewGroupRoleRepository(db),
+		userRepo:      repository.NewUserRepository(db),
+		authzService:  NewAuthzService(db),
+		auditService:  NewAuditService(db),
+		kcService:     NewKeycloakService(),
+	}
+}
+
+// ResolveReviewer 요청자의 Keycloak ID 로 DB 사용자 조회
+func (s *AccessReviewService) ResolveReviewer(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// CreateCampaign 범위에 해당하는 현재 할당을 항목으로 고정하여 캠페인 생성
+func (s *AccessReviewService) CreateCampaign(req *model.CreateAccessReviewCampaignRequest, creator *model.User) (*model.AccessReviewCampaign, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAccessReviewRequest)
+	}
+	unreviewedAction := req.UnreviewedAction
+	switch unreviewedAction {
+	case "":
+		unreviewedAction = model.AccessReviewUnreviewedEscalate
+	case model.AccessReviewUnreviewedRevoke, model.AccessReviewUnreviewedEscalate:
+	default:
+		return nil, fmt.Errorf("%w: unreviewedAction must be revoke or escalate: %s", ErrInvalidAccessReviewRequest, unreviewedAction)
+	}
+	assignmentTypes := uniqueNonEmpty(req.AssignmentTypes)
+	for _, t := range assignmentTypes {
+		if !isAccessReviewAssignmentType(t) {
+			return nil, fmt.Errorf("%w: unknown assignment type: %s", ErrInvalidAccessReviewRequest, t)
+		}
+	}
+	if len(assignmentTypes) == 0 {
+		assignmentTypes = model.AccessReviewAssignmentTypes
+	}
+	if req.DueAt != nil && !req.DueAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: dueAt must be in the future", ErrInvalidAccessReviewRequest)
+	}
+
+	items, err := s.collectItems(req, assignmentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &model.AccessReviewCampaign{
+		Name:             name,
+		Description:      req.Description,
+		Status:           model.AccessReviewCampaignOpen,
+		WorkspaceIDs:     req.WorkspaceIDs,
+		RoleIDs:          req.RoleIDs,
+		OrganizationIDs:  req.OrganizationIDs,
+		AssignmentTypes:  assignmentTypes,
+		UnreviewedAction: unreviewedAction,
+		DueAt:            req.DueAt,
+	}
+	if creator != nil {
+		campaign.CreatedByKcID = creator.KcId
+		campaign.CreatedByUsername = creator.Username
+	}
+	if err := s.reviewRepo.CreateCampaign(campaign, items); err != nil {
+		return nil, err
+	}
+	return s.withSummary(campaign)
+}
+
+// collectItems 캠페인 범위의 할당 조회. 조직 범위는 사용자 할당이면 직접 소속 구성원, 그룹 할당이면 해당 조직(그룹)으로 제한한다.
+func (s *AccessReviewService) collectItems(req *model.CreateAccessReviewCampaignRequest, assignmentTypes []string) ([]model.AccessReviewItem, error) {
+	scope := repository.AccessReviewScope{
+		WorkspaceIDs: req.WorkspaceIDs,
+		RoleIDs:      req.RoleIDs,
+	}
+	var memberOrgs map[uint]uint
+	if len(req.OrganizationIDs) > 0 {
+		var err error
+		memberOrgs, err = s.reviewRepo.OrganizationMembers(req.OrganizationIDs)
+		if err != nil {
+			return nil, err
+		}
+		scope.UserIDs = make([]uint, 0, len(memberOrgs))
+		for userID := range memberOrgs {
+			scope.UserIDs = append(scope.UserIDs, userID)
+		}
+		scope.GroupIDs = req.OrganizationIDs
+	}
+
+	var items []model.AccessReviewItem
+	for _, assignmentType := range assignmentTypes {
+		var (
+			found []model.AccessReviewItem
+			err   error
+		)
+		switch assignmentType {
+		case model.AccessReviewUserPlatformRole:
+			found, err = s.reviewRepo.FindUserPlatformRoleAssignments(scope)
+		case model.AccessReviewUserWorkspaceRole:
+			found, err = s.reviewRepo.FindUserWorkspaceRoleAssignments(scope)
+		case model.AccessReviewGroupWorkspaceRole:
+			found, err = s.reviewRepo.FindGroupWorkspaceRoleAssignments(scope)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if memberOrgs != nil {
+			for i := range found {
+				var orgID uint
+				if found[i].UserID != nil {
+					orgID = memberOrgs[*found[i].UserID]
+				} else if found[i].GroupID != nil {
+					orgID = *found[i].GroupID
+				}
+				found[i].OrganizationID = &orgID
+			}
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+// ListCampaigns 캠페인 목록 (항목 상태별 건수 포함)
+func (s *AccessReviewService) ListCampaigns(status string) ([]model.AccessReviewCampaign, error) {
+	campaigns, err := s.reviewRepo.ListCampaigns(status)
+	if err != nil {
+		return nil, err
+	}
+	for i := range campaigns {
+		if _, err := s.withSummary(&campaigns[i]); err != nil {
+			return nil, err
+		}
+	}
+	return campaigns, nil
+}
+
+// GetCampaign 캠페인 조회 (항목 상태별 건수 포함)
+func (s *AccessReviewService) GetCampaign(id uint) (*model.AccessReviewCampaign, error) {
+	campaign, err := s.reviewRepo.FindCampaignByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withSummary(campaign)
+}
+
+// ListItems 캠페인 항목 조회
+func (s *AccessReviewService) ListItems(campaignID uint, filter model.AccessReviewItemFilter) ([]model.AccessReviewItem, error) {
+	if _, err := s.reviewRepo.FindCampaignByID(campaignID); err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.ListItems(campaignID, filter)
+}
+
+// ListReviewerItems 검토자가 결정할 수 있는 미결 항목 (진행 중 캠페인의 pending, 관리자는 escalated 포함)
+func (s *AccessReviewService) ListReviewerItems(ctx context.Context, reviewer *model.User) ([]model.AccessReviewItem, error) {
+	items, err := s.reviewRepo.ListOpenItems()
+	if err != nil {
+		return nil, err
+	}
+	reviewable := make([]model.AccessReviewItem, 0)
+	for i := range items {
+		if err := s.authorizeReviewer(ctx, reviewer, &items[i]); err != nil {
+			if errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrAccessReviewSelfReview) {
+				continue
+			}
+			return nil, err
+		}
+		reviewable = append(reviewable, items[i])
+	}
+	return reviewable, nil
+}
+
+// Decide 항목 검토 결정 (회수는 즉시 반영)
+// 진행 중 캠페인의 pending 항목은 검토자가, 종료 후 escalated 항목은 캠페인 관리자가 결정한다.
+func (s *AccessReviewService) Decide(ctx context.Context, itemID uint, req *model.AccessReviewDecisionRequest, reviewer *model.User) (*model.AccessReviewItem, error) {
+	if req.Decision != model.AccessReviewDecisionCertify && req.Decision != model.AccessReviewDecisionRevoke {
+		return nil, fmt.Errorf("%w: decision must be certify or revoke: %s", ErrInvalidAccessReviewRequest, req.Decision)
+	}
+	item, err := s.reviewRepo.FindItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	campaign, err := s.reviewRepo.FindCampaignByID(item.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	switch item.Status {
+	case model.AccessReviewItemPending:
+		if campaign.Status != model.AccessReviewCampaignOpen {
+			return nil, ErrAccessReviewCampaignClosed
+		}
+	case model.AccessReviewItemEscalated:
+	default:
+		return nil, ErrAccessReviewItemDecided
+	}
+	if err := s.authorizeReviewer(ctx, reviewer, item); err != nil {
+		return nil, err
+	}
+
+	if req.Decision == model.AccessReviewDecisionRevoke {
+		if err := s.revoke(ctx, item); err != nil {
+			return nil, err
+		}
+		item.Status = model.AccessReviewItemRevoked
+	} else {
+		item.Status = model.AccessReviewItemCertified
+	}
+	now := time.Now()
+	item.DecidedByKcID = reviewer.KcId
+	item.DecidedByUsername = reviewer.Username
+	item.DecidedAt = &now
+	item.Comment = req.Comment
+	item.RevocationError = ""
+	if err := s.reviewRepo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CloseCampaign 캠페인 종료. 검토되지 않은 항목은 캠페인 정책에 따라 회수하거나 escalated 로 넘긴다.
+// 회수에 실패한 항목은 escalated 로 남기고 실패 사유를 기록한다. actor 가 nil 이면 자동 종료이다.
+func (s *AccessReviewService) CloseCampaign(ctx context.Context, id uint, actor *model.User) (*model.AccessReviewCampaign, error) {
+	campaign, err := s.reviewRepo.FindCampaignByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != model.AccessReviewCampaignOpen {
+		return nil, ErrAccessReviewCampaignClosed
+	}
+	actorKcID := ""
+	if actor != nil {
+		actorKcID = actor.KcId
+	}
+	closed, err := s.reviewRepo.CloseCampaign(id, actorKcID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, ErrAccessReviewCampaignClosed
+	}
+
+	pending, err := s.reviewRepo.ListItems(id, model.AccessReviewItemFilter{Status: model.AccessReviewItemPending})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pending {
+		item := &pending[i]
+		now := time.Now()
+		item.DecidedAt = &now
+		item.Status = model.AccessReviewItemEscalated
+		if campaign.UnreviewedAction == model.AccessReviewUnreviewedRevoke {
+			if err := s.revoke(ctx, item); err != nil {
+				log.Printf("[ACCESS_REVIEW] campaign %d: failed to revoke unreviewed item %d, escalating: %v", id, item.ID, err)
+				item.RevocationError = err.Error()
+			} else {
+				item.Status = model.AccessReviewItemRevoked
+				item.Comment = "revoked automatically: not reviewed before campaign close"
+			}
+		}
+		if err := s.reviewRepo.UpdateItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetCampaign(id)
+}
+
+// CloseDueCampaigns 마감 시각이 지난 캠페인 자동 종료 (종료마다 감사 이벤트 기록)
+func (s *AccessReviewService) CloseDueCampaigns(ctx context.Context) ([]model.AccessReviewCampaign, error) {
+	due, err := s.reviewRepo.ListDueCampaigns(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var closed []model.AccessReviewCampaign
+	for _, campaign := range due {
+		result, err := s.CloseCampaign(ctx, campaign.ID, nil)
+		if err != nil {
+			if errors.Is(err, ErrAccessReviewCampaignClosed) {
+				continue
+			}
+			return closed, err
+		}
+		closed = append(closed, *result)
+		s.recordAutoClose(result)
+	}
+	return closed, nil
+}
+
+// recordAutoClose 자동 종료 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록)
+func (s *AccessReviewService) recordAutoClose(campaign *model.AccessReviewCampaign) {
+	if s.auditService == nil {
+		return
+	}
+	event := &model.AuditEvent{
+		ActorUsername: "system",
+		Method:        "SYSTEM",
+		Route:         "access-review.auto-close",
+		Path:          fmt.Sprintf("/api/access-reviews/%d", campaign.ID),
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:     model.AuditActionAccessReviewClose,
+		EntityType: model.AuditEntityAccessReview,
+		EntityID:   fmt.Sprint(campaign.ID),
+		After:      campaign,
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[ACCESS_REVIEW] failed to record audit event for campaign %d: %v", campaign.ID, err)
+	}
+}
+
+// StartAutoClose 주기적으로 마감된 캠페인 종료
+// 주기는 MC_IAM_MANAGER_ACCESS_REVIEW_CLOSE_INTERVAL (기본 1m)
+func (s *AccessReviewService) StartAutoClose(ctx context.Context) {
+	interval := defaultAccessReviewCloseInterval
+	if raw := os.Getenv("MC_IAM_MANAGER_ACCESS_REVIEW_CLOSE_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			interval = d
+		} else {
+			log.Printf("[ACCESS_REVIEW] invalid MC_IAM_MANAGER_ACCESS_REVIEW_CLOSE_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				closed, err := s.CloseDueCampaigns(ctx)
+				if err != nil {
+					log.Printf("[ACCESS_REVIEW] failed to close due campaigns: %v", err)
+				}
+				for _, campaign := range closed {
+					log.Printf("[ACCESS_REVIEW] campaign %d closed at due time: revoked=%d escalated=%d",
+						campaign.ID, campaign.Summary.Revoked, campaign.Summary.Escalated)
+				}
+			}
+		}
+	}()
+}
+
+// ExportCampaign 증적용 캠페인/항목 전체
+func (s *AccessReviewService) ExportCampaign(id uint) (*model.AccessReviewCampaignDetail, error) {
+	campaign, err := s.GetCampaign(id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.reviewRepo.ListItems(id, model.AccessReviewItemFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return &model.AccessReviewCampaignDetail{
+		Campaign:   *campaign,
+		Items:      items,
+		ExportedAt: time.Now().UTC(),
+	}, nil
+}
+
+// accessReviewCSVHeader 증적 CSV 열
+var accessReviewCSVHeader = []string{
+	"campaign_id", "campaign_name", "item_id", "assignment_type",
+	"user_id", "username", "group_id", "group_name", "workspace_id", "workspace_name",
+	"role_id", "role_name", "organization_id", "assigned_at",
+	"status", "decided_by", "decided_at", "comment", "revocation_error",
+}
+
+// EncodeAccessReviewCSV 증적을 CSV 로 변환 (항목당 한 행)
+func EncodeAccessReviewCSV(detail *model.AccessReviewCampaignDetail) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(accessReviewCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, item := range detail.Items {
+		decidedBy := item.DecidedByUsername
+		if decidedBy == "" {
+			decidedBy = item.DecidedByKcID
+		}
+		record := []string{
+			fmt.Sprint(detail.Campaign.ID), detail.Campaign.Name, fmt.Sprint(item.ID), item.AssignmentType,
+			optionalID(item.UserID), item.Username, optionalID(item.GroupID), item.GroupName,
+			optionalID(item.WorkspaceID), item.WorkspaceName,
+			fmt.Sprint(item.RoleID), item.RoleName, optionalID(item.OrganizationID), optionalTime(item.AssignedAt),
+			item.Status, decidedBy, optionalTime(item.DecidedAt), item.Comment, item.RevocationError,
+		}
+		if err := w.Write(report.EscapeCSVRecord(record)); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func optionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprint(*id)
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// authorizeReviewer 검토자가 항목을 결정할 수 있는지 확인
+// 자신의 할당(그룹 할당은 소속 그룹)은 결정할 수 없고, escalated 항목은 캠페인 관리자만 결정한다.
+func (s *AccessReviewService) authorizeReviewer(ctx context.Context, reviewer *model.User, item *model.AccessReviewItem) error {
+	if reviewer == nil {
+		return ErrPermissionDenied
+	}
+	if item.UserID != nil && *item.UserID == reviewer.ID {
+		return ErrAccessReviewSelfReview
+	}
+	if item.GroupID != nil {
+		member, err := s.reviewRepo.IsOrganizationMember(reviewer.ID, *item.GroupID)
+		if err != nil {
+			return err
+		}
+		if member {
+			return ErrAccessReviewSelfReview
+		}
+	}
+
+	// 캠페인 관리자 (platformAdmin 포함: HasPermission 이 모든 권한 보유로 판정)
+	manager, err := s.authzService.HasPermission(ctx, reviewer.ID, 0, AccessReviewManagePermission)
+	if err != nil {
+		return err
+	}
+	if manager {
+		return nil
+	}
+	if item.Status == model.AccessReviewItemEscalated {
+		return ErrPermissionDenied
+	}
+
+	if item.WorkspaceID != nil {
+		grants, err := s.authzService.GetWorkspaceRoleGrants(ctx, reviewer.ID, *item.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if len(grants) > 0 {
+			allowed, err := s.authzService.HasPermission(ctx, reviewer.ID, *item.WorkspaceID, AccessReviewReviewPermission)
+			if err != nil {
+				return err
+			}
+			if allowed {
+				return nil
+			}
+		}
+	}
+	if item.OrganizationID != nil && *item.OrganizationID != 0 {
+		member, err := s.reviewRepo.IsOrganizationMember(reviewer.ID, *item.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if member {
+			allowed, err := s.authzService.HasPermission(ctx, reviewer.ID, 0, AccessReviewReviewPermission)
+			if err != nil {
+				return err
+			}
+			if allowed {
+				return nil
+			}
+		}
+	}
+	return ErrPermissionDenied
+}
+
+// revoke 항목의 역할 할당 제거 (이미 제거된 할당은 성공으로 본다)
+func (s *AccessReviewService) revoke(ctx context.Context, item *model.AccessReviewItem) error {
+	switch item.AssignmentType {
+	case model.AccessReviewUserPlatformRole:
+		return s.revokeUserPlatformRole(ctx, item)
+	case model.AccessReviewUserWorkspaceRole:
+		if item.UserID == nil || item.WorkspaceID == nil {
+			return fmt.Errorf("%w: item %d has no user or workspace", ErrInvalidAccessReviewRequest, item.ID)
+		}
+		if err := s.roleRepo.RemoveWorkspaceRole(*item.UserID, *item.WorkspaceID, item.RoleID); err != nil {
+			return fmt.Errorf("failed to remove workspace role: %w", err)
+		}
+		return nil
+	case model.AccessReviewGroupWorkspaceRole:
+		if item.GroupID == nil || item.WorkspaceID == nil {
+			return fmt.Errorf("%w: item %d has no group or workspace", ErrInvalidAccessReviewRequest, item.ID)
+		}
+		// 검토 이후 다른 역할로 바뀐 매핑은 제거하지 않는다
+		roleID, err := s.reviewRepo.FindGroupWorkspaceRoleID(*item.GroupID, *item.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if roleID != item.RoleID {
+			return nil
+		}
+		err = s.groupRoleRepo.DeleteGroupWorkspaceRole(*item.GroupID, *item.WorkspaceID)
+		if err != nil && !errors.Is(err, repository.ErrGroupWorkspaceRoleNotFound) {
+			return fmt.Errorf("failed to remove group workspace role: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown assignment type: %s", ErrInvalidAccessReviewRequest, item.AssignmentType)
+}
+
+// revokeUserPlatformRole DB 할당과 Keycloak realm role 제거 (Keycloak 실패 시 DB 할당 복구)
+func (s *AccessReviewService) revokeUserPlatformRole(ctx context.Context, item *model.AccessReviewItem) error {
+	if item.UserID == nil {
+		return fmt.Errorf("%w: item %d has no user", ErrInvalidAccessReviewRequest, item.ID)
+	}
+	user, err := s.userRepo.FindUserByID(*item.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	assigned, err := s.roleRepo.IsAssignedPlatformRole(*item.UserID, item.RoleID)
+	if err != nil {
+		return err
+	}
+	if err := s.roleRepo.RemovePlatformRole(*item.UserID, item.RoleID); err != nil {
+		return fmt.Errorf("failed to remove platform role: %w", err)
+	}
+	if err := s.kcService.RemoveRealmRoleFromUser(ctx, user.KcId, item.RoleName); err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "User not found") {
+			log.Printf("[WARN] access review item %d: KC user not found (%s), skipping KC removal", item.ID, user.KcId)
+			return nil
+		}
+		if assigned {
+			if rollbackErr := s.roleRepo.AssignPlatformRole(*item.UserID, item.RoleID); rollbackErr != nil {
+				log.Printf("Failed to rollback platform role removal: %v", rollbackErr)
+			}
+		}
+		return fmt.Errorf("failed to remove keycloak realm role: %w", err)
+	}
+	return nil
+}
+
+// withSummary 캠페인에 항목 상태별 건수 채우기
+func (s *AccessReviewService) withSummary(campaign *model.AccessReviewCampaign) (*model.AccessReviewCampaign, error) {
+	counts, err := s.reviewRepo.CountItemsByStatus(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &model.AccessReviewSummary{
+		Pending:   counts[model.AccessReviewItemPending],
+		Certified: counts[model.AccessReviewItemCertified],
+		Revoked:   counts[model.AccessReviewItemRevoked],
+		Escalated: counts[model.AccessReviewItemEscalated],
+	}
+	for _, n := range counts {
+		summary.Total += n
+	}
+	campaign.Summary = summary
+	return campaign, nil
+}
+
+func isAccessReviewAssignmentType(t string) bool {
+	for _, known := range model.AccessReviewAssignmentTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,423 @@
+package service
+
+// access_review_service_test.go
+//
+// AccessReviewService 단위 테스트 (SQLite in-memory DB)
+// 캠페인 범위 고정, 검토자 권한, 즉시 회수(Keycloak realm role 포함), 종료 정책, 증적 CSV 를 검증한다.
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestAccessReviewService(t *testing.T) (*AccessReviewService, *gorm.DB, *recordingKeycloak) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.AccessReviewCampaign{},
+		&model.AccessReviewItem{},
+	))
+	kc := &recordingKeycloak{}
+	svc := &AccessReviewService{
+		db:            db,
+		reviewRepo:    repository.NewAccessReviewRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		groupRoleRepo: repository.NewGroupRoleRepository(db),
+		userRepo:      repository.NewUserRepository(db),
+		authzService:  newTestAuthz(db),
+		kcService:     kc,
+	}
+	return svc, db, kc
+}
+
+// accessReviewFixture 두 워크스페이스, 조직 하나, 검토자/관리자/대상 사용자
+type accessReviewFixture struct {
+	wsA, wsB   *model.Workspace
+	org        *model.Organization
+	role       *model.RoleMaster
+	reviewer   *model.User // wsA 에서 검토 권한
+	manager    *model.User // 캠페인 관리 권한
+	alice, bob *model.User // 검토 대상 (alice 는 org 소속)
+}
+
+func setupAccessReviewFixture(t *testing.T, db *gorm.DB) *accessReviewFixture {
+	t.Helper()
+	f := &accessReviewFixture{
+		wsA:      createGRTestWorkspace(t, db, "ar-ws-a"),
+		wsB:      createGRTestWorkspace(t, db, "ar-ws-b"),
+		org:      createGRTestOrg(t, db, "ar-org", "AR"),
+		role:     createGRTestRole(t, db, "ar-member"),
+		reviewer: createGRTestUser(t, db, "ar-reviewer", "kc-ar-reviewer"),
+		manager:  createGRTestUser(t, db, "ar-manager", "kc-ar-manager"),
+		alice:    createGRTestUser(t, db, "ar-alice", "kc-ar-alice"),
+		bob:      createGRTestUser(t, db, "ar-bob", "kc-ar-bob"),
+	}
+	assignAuthzTestWorkspaceRole(t, db, f.reviewer, f.wsA.ID, "ar-ws-admin", AccessReviewReviewPermission)
+	assignAuthzTestPlatformRole(t, db, f.manager, "ar-auditor", AccessReviewManagePermission)
+
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.alice.ID, OrganizationID: f.org.ID}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.alice.ID, WorkspaceID: f.wsA.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.bob.ID, WorkspaceID: f.wsB.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.alice.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: f.org.ID, WorkspaceID: f.wsB.ID, RoleID: f.role.ID}).Error)
+	return f
+}
+
+func findAccessReviewItem(t *testing.T, items []model.AccessReviewItem, assignmentType string, userID uint) model.AccessReviewItem {
+	t.Helper()
+	for _, item := range items {
+		if item.AssignmentType != assignmentType {
+			continue
+		}
+		if userID == 0 || (item.UserID != nil && *item.UserID == userID) {
+			return item
+		}
+	}
+	t.Fatalf("no %s item for user %d", assignmentType, userID)
+	return model.AccessReviewItem{}
+}
+
+// ── 캠페인 생성 ───────────────────────────────────────────────────────────────
+
+// TC-AR-CREATE-01: 워크스페이스 범위 → 해당 워크스페이스의 사용자/그룹 할당만, 플랫폼 역할 제외, 이름 고정
+func TestAccessReviewCreateCampaign_WorkspaceScope(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:         "Q3 ws-a",
+		WorkspaceIDs: []uint{f.wsA.ID},
+		RoleIDs:      []uint{f.role.ID},
+	}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewCampaignOpen, campaign.Status)
+	assert.Equal(t, model.AccessReviewUnreviewedEscalate, campaign.UnreviewedAction)
+	assert.Equal(t, "kc-ar-manager", campaign.CreatedByKcID)
+	assert.Equal(t, 1, campaign.Summary.Total)
+
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.AccessReviewUserWorkspaceRole, items[0].AssignmentType)
+	assert.Equal(t, "ar-alice", items[0].Username)
+	assert.Equal(t, "ar-ws-a", items[0].WorkspaceName)
+	assert.Equal(t, "ar-member", items[0].RoleName)
+	assert.Equal(t, model.AccessReviewItemPending, items[0].Status)
+}
+
+// TC-AR-CREATE-02: 조직 범위 → 직접 소속 구성원의 할당과 해당 조직(그룹)의 할당, 검토 조직 기록
+func TestAccessReviewCreateCampaign_OrganizationScope(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:            "org review",
+		RoleIDs:         []uint{f.role.ID},
+		OrganizationIDs: []uint{f.org.ID},
+	}, f.manager)
+	require.NoError(t, err)
+
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	require.Len(t, items, 3) // alice 플랫폼 + alice wsA + org 그룹 wsB (bob 제외)
+	for _, item := range items {
+		require.NotNil(t, item.OrganizationID)
+		assert.Equal(t, f.org.ID, *item.OrganizationID)
+		if item.UserID != nil {
+			assert.Equal(t, f.alice.ID, *item.UserID)
+		}
+	}
+	group := findAccessReviewItem(t, items, model.AccessReviewGroupWorkspaceRole, 0)
+	assert.Equal(t, "ar-org", group.GroupName)
+}
+
+// TC-AR-CREATE-03: 잘못된 요청 → ErrInvalidAccessReviewRequest
+func TestAccessReviewCreateCampaign_InvalidRequest(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	past := time.Now().Add(-time.Hour)
+
+	for _, req := range []model.CreateAccessReviewCampaignRequest{
+		{Name: " "},
+		{Name: "x", UnreviewedAction: "ignore"},
+		{Name: "x", AssignmentTypes: []string{"user-csp-role"}},
+		{Name: "x", DueAt: &past},
+	} {
+		_, err := svc.CreateCampaign(&req, f.manager)
+		assert.True(t, errors.Is(err, ErrInvalidAccessReviewRequest), "request %+v: %v", req, err)
+	}
+}
+
+// ── 검토 결정 ─────────────────────────────────────────────────────────────────
+
+// TC-AR-DECIDE-01: 워크스페이스 검토자는 해당 워크스페이스 항목만, 자기 할당은 결정 불가
+func TestAccessReviewDecide_ReviewerScope(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:            "ws review",
+		AssignmentTypes: []string{model.AccessReviewUserWorkspaceRole},
+	}, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	aliceItem := findAccessReviewItem(t, items, model.AccessReviewUserWorkspaceRole, f.alice.ID)
+	bobItem := findAccessReviewItem(t, items, model.AccessReviewUserWorkspaceRole, f.bob.ID)
+	ownItem := findAccessReviewItem(t, items, model.AccessReviewUserWorkspaceRole, f.reviewer.ID)
+
+	ctx := context.Background()
+	mine, err := svc.ListReviewerItems(ctx, f.reviewer)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, aliceItem.ID, mine[0].ID)
+
+	decided, err := svc.Decide(ctx, aliceItem.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify, Comment: "still needed"}, f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewItemCertified, decided.Status)
+	assert.Equal(t, "ar-reviewer", decided.DecidedByUsername)
+	assert.NotNil(t, decided.DecidedAt)
+
+	_, err = svc.Decide(ctx, aliceItem.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.reviewer)
+	assert.ErrorIs(t, err, ErrAccessReviewItemDecided)
+	_, err = svc.Decide(ctx, bobItem.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, f.reviewer)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = svc.Decide(ctx, ownItem.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, f.reviewer)
+	assert.ErrorIs(t, err, ErrAccessReviewSelfReview)
+	_, err = svc.Decide(ctx, ownItem.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, f.manager)
+	assert.NoError(t, err, "manager may review other users' assignments")
+}
+
+// TC-AR-DECIDE-02: 워크스페이스 역할 회수 → 할당 즉시 제거
+func TestAccessReviewDecide_RevokeWorkspaceRole(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:         "ws-a",
+		WorkspaceIDs: []uint{f.wsA.ID},
+		RoleIDs:      []uint{f.role.ID},
+	}, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+
+	decided, err := svc.Decide(context.Background(), items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewItemRevoked, decided.Status)
+
+	var count int64
+	require.NoError(t, db.Model(&model.UserWorkspaceRole{}).Where("user_id = ? AND workspace_id = ?", f.alice.ID, f.wsA.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+// TC-AR-DECIDE-03: 플랫폼 역할 회수 → DB 할당과 Keycloak realm role 제거, Keycloak 실패 시 DB 복구
+func TestAccessReviewDecide_RevokePlatformRole(t *testing.T) {
+	svc, db, kc := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:            "platform",
+		RoleIDs:         []uint{f.role.ID},
+		AssignmentTypes: []string{model.AccessReviewUserPlatformRole},
+	}, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	ctx := context.Background()
+
+	kc.removeErr = errors.New("keycloak unavailable")
+	_, err = svc.Decide(ctx, items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.manager)
+	require.Error(t, err)
+	assigned, err := svc.roleRepo.IsAssignedPlatformRole(f.alice.ID, f.role.ID)
+	require.NoError(t, err)
+	assert.True(t, assigned, "DB assignment restored after Keycloak failure")
+
+	kc.removeErr = nil
+	decided, err := svc.Decide(ctx, items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewItemRevoked, decided.Status)
+	assert.Equal(t, []string{"remove-user kc-ar-alice ar-member"}, kc.calls)
+	var count int64
+	require.NoError(t, db.Model(&model.UserPlatformRole{}).Where("user_id = ? AND role_id = ?", f.alice.ID, f.role.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+// ── 종료 ──────────────────────────────────────────────────────────────────────
+
+// TC-AR-CLOSE-01: revoke 정책 → 미검토 항목 회수, 결정된 항목 유지, 종료 후 결정 불가
+func TestAccessReviewClose_RevokePolicy(t *testing.T) {
+	svc, db, kc := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:             "revoke unreviewed",
+		RoleIDs:          []uint{f.role.ID},
+		UnreviewedAction: model.AccessReviewUnreviewedRevoke,
+	}, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	ctx := context.Background()
+	aliceWs := findAccessReviewItem(t, items, model.AccessReviewUserWorkspaceRole, f.alice.ID)
+	_, err = svc.Decide(ctx, aliceWs.ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, f.reviewer)
+	require.NoError(t, err)
+
+	closed, err := svc.CloseCampaign(ctx, campaign.ID, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewCampaignClosed, closed.Status)
+	assert.Equal(t, "kc-ar-manager", closed.ClosedByKcID)
+	assert.Equal(t, 1, closed.Summary.Certified)
+	assert.Equal(t, 3, closed.Summary.Revoked)
+	assert.Equal(t, []string{"remove-user kc-ar-alice ar-member"}, kc.calls)
+
+	var remaining int64
+	require.NoError(t, db.Model(&model.UserWorkspaceRole{}).Where("role_id = ?", f.role.ID).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining, "only the certified assignment remains")
+	require.NoError(t, db.Model(&model.GroupWorkspaceRole{}).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+
+	_, err = svc.CloseCampaign(ctx, campaign.ID, f.manager)
+	assert.ErrorIs(t, err, ErrAccessReviewCampaignClosed)
+}
+
+// TC-AR-CLOSE-02: escalate 정책 → 미검토 항목 escalated, 캠페인 관리자만 결정 가능
+func TestAccessReviewClose_EscalatePolicy(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:         "escalate",
+		WorkspaceIDs: []uint{f.wsA.ID},
+		RoleIDs:      []uint{f.role.ID},
+	}, f.manager)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	closed, err := svc.CloseCampaign(ctx, campaign.ID, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed.Summary.Escalated)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{Status: model.AccessReviewItemEscalated})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	_, err = svc.Decide(ctx, items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.reviewer)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	mine, err := svc.ListReviewerItems(ctx, f.manager)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	decided, err := svc.Decide(ctx, items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionRevoke}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewItemRevoked, decided.Status)
+}
+
+// TC-AR-CLOSE-04: platformAdmin(토큰 realm role)은 권한 매핑 없이도 escalated 항목을 결정
+func TestAccessReviewClose_PlatformAdminDecidesEscalated(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:         "escalate-admin",
+		WorkspaceIDs: []uint{f.wsA.ID},
+		RoleIDs:      []uint{f.role.ID},
+	}, f.manager)
+	require.NoError(t, err)
+	_, err = svc.CloseCampaign(context.Background(), campaign.ID, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{Status: model.AccessReviewItemEscalated})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	admin := createGRTestUser(t, db, "ar-platform-admin", "kc-ar-platform-admin")
+	_, err = svc.Decide(context.Background(), items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, admin)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	ctx := WithPlatformAdminSubject(context.Background(), admin.KcId)
+	mine, err := svc.ListReviewerItems(ctx, admin)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	decided, err := svc.Decide(ctx, items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify}, admin)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewItemCertified, decided.Status)
+}
+
+// TC-AR-CLOSE-03: 마감 시각이 지난 캠페인만 자동 종료, 회수 실패 항목은 사유와 함께 escalated
+func TestAccessReviewCloseDueCampaigns(t *testing.T) {
+	svc, db, kc := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	due := time.Now().Add(time.Hour)
+	overdue, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:             "overdue",
+		RoleIDs:          []uint{f.role.ID},
+		AssignmentTypes:  []string{model.AccessReviewUserPlatformRole},
+		UnreviewedAction: model.AccessReviewUnreviewedRevoke,
+		DueAt:            &due,
+	}, f.manager)
+	require.NoError(t, err)
+	open, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{Name: "open", DueAt: &due}, f.manager)
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&model.AccessReviewCampaign{}).Where("id = ?", overdue.ID).
+		Update("due_at", time.Now().Add(-time.Minute)).Error)
+
+	kc.removeErr = errors.New("keycloak unavailable")
+	closed, err := svc.CloseDueCampaigns(context.Background())
+	require.NoError(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, overdue.ID, closed[0].ID)
+	assert.Empty(t, closed[0].ClosedByKcID)
+	assert.Equal(t, 1, closed[0].Summary.Escalated)
+
+	items, err := svc.ListItems(overdue.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	assert.Contains(t, items[0].RevocationError, "keycloak unavailable")
+
+	still, err := svc.GetCampaign(open.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessReviewCampaignOpen, still.Status)
+}
+
+// ── 증적 ──────────────────────────────────────────────────────────────────────
+
+// TC-AR-EXPORT-01: CSV 증적 → 헤더와 항목별 결정/검토자 포함, 수식으로 시작하는 의견은 ' 로 무력화
+func TestAccessReviewExportCSV(t *testing.T) {
+	svc, db, _ := newTestAccessReviewService(t)
+	f := setupAccessReviewFixture(t, db)
+	campaign, err := svc.CreateCampaign(&model.CreateAccessReviewCampaignRequest{
+		Name:         "evidence, q3",
+		WorkspaceIDs: []uint{f.wsA.ID},
+		RoleIDs:      []uint{f.role.ID},
+	}, f.manager)
+	require.NoError(t, err)
+	items, err := svc.ListItems(campaign.ID, model.AccessReviewItemFilter{})
+	require.NoError(t, err)
+	_, err = svc.Decide(context.Background(), items[0].ID, &model.AccessReviewDecisionRequest{Decision: model.AccessReviewDecisionCertify, Comment: "=HYPERLINK(\"http://evil\")"}, f.reviewer)
+	require.NoError(t, err)
+
+	detail, err := svc.ExportCampaign(campaign.ID)
+	require.NoError(t, err)
+	body, err := EncodeAccessReviewCSV(detail)
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, accessReviewCSVHeader, rows[0])
+	row := make(map[string]string, len(rows[0]))
+	for i, col := range rows[0] {
+		row[col] = rows[1][i]
+	}
+	assert.Equal(t, "evidence, q3", row["campaign_name"])
+	assert.Equal(t, "ar-alice", row["username"])
+	assert.Equal(t, model.AccessReviewItemCertified, row["status"])
+	assert.Equal(t, "ar-reviewer", row["decided_by"])
+	assert.Equal(t, `'=HYPERLINK("http://evil")`, row["comment"])
+	assert.NotEmpty(t, row["decided_at"])
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidSimulationChange = errors.New("invalid simulation change")
+
+// AccessSimulationService 역할/그룹 변경의 가정(what-if) 시뮬레이션
+// 변경은 트랜잭션 안에서 RoleService/GroupRoleService 로 적용한 뒤 항상 롤백하며, Keycloak 은 호출하지 않는다.
+type AccessSimulationService struct {
+	db          *gorm.DB
+	menuService *MenuService
+}
+
+// NewAccessSimulationService AccessSimulationService 생성
+func NewAccessSimulationService(db *gorm.DB) *AccessSimulationService {
+	return &AccessSimulationService{
+		db:          db,
+		menuService: NewMenuService(db),
+	}
+}
+
+// accessSnapshot 사용자 한 명의 유효 접근 권한 (항목 문자열 집합)
+type accessSnapshot struct {
+	username        string
+	platformRoleIDs []uint
+	platformRoles   map[string]bool
+	workspaceRoles  map[string]bool
+	permissions     map[string]bool
+	cspRoles        map[string]bool
+	menus           map[string]bool
+}
+
+// Simulate 변경 적용 전후의 유효 플랫폼 역할, 워크스페이스 역할, 메뉴, MC-IAM 권한, CSP 역할 차이 계산
+// 조건부 매핑은 ctx 의 요청 속성(IP, 시각)으로 평가한다.
+func (s *AccessSimulationService) Simulate(ctx context.Context, req *model.SimulationRequest) (*model.SimulationResponse, error) {
+	if req == nil || len(req.Changes) == 0 {
+		return nil, fmt.Errorf("changes are required: %w", ErrInvalidSimulationChange)
+	}
+	for i, c := range req.Changes {
+		if err := validateSimulationChange(c); err != nil {
+			return nil, fmt.Errorf("change %d: %w", i+1, err)
+		}
+	}
+
+	userIDs, before, after, err := s.simulateInTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 메뉴 트리는 플랫폼 역할 ID 와 사용자(조건부 거부 매핑)로 결정되므로 롤백 후 조회한다.
+	for _, snaps := range []map[uint]*accessSnapshot{before, after} {
+		for userID, snap := range snaps {
+			if snap.menus, err = s.menuIDs(ctx, userID, snap.platformRoleIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp := &model.SimulationResponse{Changes: req.Changes, Users: make([]model.SimulationUserResult, 0, len(userIDs))}
+	for _, userID := range userIDs {
+		b, a := before[userID], after[userID]
+		result := model.SimulationUserResult{
+			UserID:         userID,
+			Username:       b.username,
+			PlatformRoles:  diffAccessSet(b.platformRoles, a.platformRoles),
+			WorkspaceRoles: diffAccessSet(b.workspaceRoles, a.workspaceRoles),
+			Menus:          diffAccessSet(b.menus, a.menus),
+			Permissions:    diffAccessSet(b.permissions, a.permissions),
+			CspRoles:       diffAccessSet(b.cspRoles, a.cspRoles),
+		}
+		result.Changed = !result.PlatformRoles.IsEmpty() || !result.WorkspaceRoles.IsEmpty() || !result.Menus.IsEmpty() ||
+			!result.Permissions.IsEmpty() || !result.CspRoles.IsEmpty()
+		resp.Users = append(resp.Users, result)
+	}
+	return resp, nil
+}
+
+// simulateInTx 트랜잭션 안에서 변경 전 스냅샷 → 변경 적용 → 변경 후 스냅샷을 수행하고 롤백
+func (s *AccessSimulationService) simulateInTx(ctx context.Context, req *model.SimulationRequest) ([]uint, map[uint]*accessSnapshot, map[uint]*accessSnapshot, error) {
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, nil, nil, fmt.Errorf("failed to begin simulation transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	userIDs, err := simulationUserIDs(tx, req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	before := make(map[uint]*accessSnapshot, len(userIDs))
+	for _, userID := range userIDs {
+		if before[userID], err = takeAccessSnapshot(ctx, tx, userID); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	for i, c := range req.Changes {
+		if err := applySimulationChange(ctx, tx, c); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: change %d (%s): %w", ErrInvalidSimulationChange, i+1, c.Action, err)
+		}
+	}
+
+	after := make(map[uint]*accessSnapshot, len(userIDs))
+	for _, userID := range userIDs {
+		if after[userID], err = takeAccessSnapshot(ctx, tx, userID); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return userIDs, before, after, nil
+}
+
+// validateSimulationChange 변경 유형별 필수 항목 확인
+func validateSimulationChange(c model.SimulationChange) error {
+	var missing string
+	switch c.Action {
+	case model.SimulationAssignPlatformRole, model.SimulationRemovePlatformRole:
+		if c.UserID == 0 || c.RoleID == 0 {
+			missing = "userId, roleId"
+		}
+	case model.SimulationAssignWorkspaceRole, model.SimulationRemoveWorkspaceRole:
+		if c.UserID == 0 || c.WorkspaceID == 0 || c.RoleID == 0 {
+			missing = "userId, workspaceId, roleId"
+		}
+	case model.SimulationAddUserToGroup, model.SimulationRemoveUserFromGroup:
+		if c.UserID == 0 || c.GroupID == 0 {
+			missing = "userId, groupId"
+		}
+	case model.SimulationMoveUserGroup:
+		if c.UserID == 0 || c.FromGroupID == 0 || c.GroupID == 0 {
+			missing = "userId, fromGroupId, groupId"
+		}
+	case model.SimulationAssignGroupPlatformRole, model.SimulationRemoveGroupPlatformRole:
+		if c.GroupID == 0 || c.RoleID == 0 {
+			missing = "groupId, roleId"
+		}
+	case model.SimulationAssignGroupWorkspaceRole:
+		if c.GroupID == 0 || c.WorkspaceID == 0 || c.RoleID == 0 {
+			missing = "groupId, workspaceId, roleId"
+		}
+	case model.SimulationRemoveGroupWorkspaceRole:
+		if c.GroupID == 0 || c.WorkspaceID == 0 {
+			missing = "groupId, workspaceId"
+		}
+	default:
+		return fmt.Errorf("unknown action %q: %w", c.Action, ErrInvalidSimulationChange)
+	}
+	if missing != "" {
+		return fmt.Errorf("%s requires %s: %w", c.Action, missing, ErrInvalidSimulationChange)
+	}
+	return nil
+}
+
+// simulationUserIDs 영향받는 사용자 목록 (지정 사용자 + 변경 대상 사용자 + 변경되는 그룹의 현재 구성원)
+func simulationUserIDs(tx *gorm.DB, req *model.SimulationRequest) ([]uint, error) {
+	seen := make(map[uint]bool)
+	for _, id := range req.UserIDs {
+		seen[id] = true
+	}
+	orgRepo := repository.NewOrganizationRepository(tx)
+	for _, c := range req.Changes {
+		if c.UserID != 0 {
+			seen[c.UserID] = true
+			continue
+		}
+		members, err := orgRepo.FindOrganizationUsers(c.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range members {
+			seen[u.ID] = true
+		}
+	}
+	return sortedKeys(seen), nil
+}
+
+// applySimulationChange 변경 한 건 적용 (DB 만 변경, Keycloak 동기화 없음)
+func applySimulationChange(ctx context.Context, tx *gorm.DB, c model.SimulationChange) error {
+	roleService := NewRoleService(tx)
+	groupRoleService := &GroupRoleService{
+		db:            tx,
+		groupRoleRepo: repository.NewGroupRoleRepository(tx),
+		orgRepo:       repository.NewOrganizationRepository(tx),
+		roleRepo:      repository.NewRoleRepository(tx),
+		sodService:    NewSodService(tx),
+	}
+
+	switch c.Action {
+	case model.SimulationAssignPlatformRole:
+		assigned, err := simulationRowExists(tx, &model.UserPlatformRole{}, "user_id = ? AND role_id = ?", c.UserID, c.RoleID)
+		if err != nil || assigned {
+			return err
+		}
+		return roleService.AssignPlatformRole(c.UserID, c.RoleID)
+	case model.SimulationRemovePlatformRole:
+		return roleService.RemovePlatformRole(c.UserID, c.RoleID)
+	case model.SimulationAssignWorkspaceRole:
+		assigned, err := simulationRowExists(tx, &model.UserWorkspaceRole{}, "user_id = ? AND workspace_id = ? AND role_id = ?", c.UserID, c.WorkspaceID, c.RoleID)
+		if err != nil || assigned {
+			return err
+		}
+		return roleService.AssignWorkspaceRole(c.UserID, c.WorkspaceID, c.RoleID)
+	case model.SimulationRemoveWorkspaceRole:
+		return roleService.RemoveWorkspaceRole(c.UserID, c.WorkspaceID, c.RoleID)
+	case model.SimulationAddUserToGroup:
+		return groupRoleService.AssignUserToGroups(ctx, c.UserID, []uint{c.GroupID}, "")
+	case model.SimulationRemoveUserFromGroup:
+		return groupRoleService.RemoveUserFromGroup(ctx, c.UserID, c.GroupID, "")
+	case model.SimulationMoveUserGroup:
+		if err := groupRoleService.RemoveUserFromGroup(ctx, c.UserID, c.FromGroupID, ""); err != nil {
+			return err
+		}
+		return groupRoleService.AssignUserToGroups(ctx, c.UserID, []uint{c.GroupID}, "")
+	case model.SimulationAssignGroupPlatformRole:
+		// AssignGroupPlatformRole 의 검증 + DB 단계 (Keycloak 그룹 역할 추가 제외)
+		if _, err := groupRoleService.orgRepo.FindByID(c.GroupID); err != nil {
+			return err
+		}
+		role, err := groupRoleService.roleRepo.FindRoleByRoleID(c.RoleID, constants.RoleTypePlatform)
+		if err != nil {
+			return fmt.Errorf("role not found: %w", err)
+		}
+		if role == nil {
+			return repository.ErrRoleMasterNotFound
+		}
+		assigned, err := simulationRowExists(tx, &model.GroupPlatformRole{}, "group_id = ? AND role_id = ?", c.GroupID, c.RoleID)
+		if err != nil || assigned {
+			return err
+		}
+		if err := groupRoleService.sodService.CheckGroupPlatformRoleAssignment(c.GroupID, c.RoleID); err != nil {
+			return err
+		}
+		return groupRoleService.groupRoleRepo.CreateGroupPlatformRole(c.GroupID, c.RoleID)
+	case model.SimulationRemoveGroupPlatformRole:
+		return groupRoleService.groupRoleRepo.DeleteGroupPlatformRole(c.GroupID, c.RoleID)
+	case model.SimulationAssignGroupWorkspaceRole:
+		assigned, err := simulationRowExists(tx, &model.GroupWorkspaceRole{}, "group_id = ? AND workspace_id = ?", c.GroupID, c.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if assigned {
+			return groupRoleService.UpdateGroupWorkspaceRole(c.GroupID, c.WorkspaceID, c.RoleID)
+		}
+		return groupRoleService.AssignGroupWorkspace(c.GroupID, c.WorkspaceID, c.RoleID)
+	case model.SimulationRemoveGroupWorkspaceRole:
+		return groupRoleService.RemoveGroupWorkspaceRole(c.GroupID, c.WorkspaceID)
+	}
+	return fmt.Errorf("unknown action %q: %w", c.Action, ErrInvalidSimulationChange)
+}
+
+// simulationRowExists 중복 INSERT 로 트랜잭션이 중단되지 않도록 기존 매핑 존재 여부를 먼저 확인
+func simulationRowExists(tx *gorm.DB, value interface{}, query string, args ...interface{}) (bool, error) {
+	var count int64
+	if err := tx.Model(value).Where(query, args...).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// takeAccessSnapshot 사용자의 유효 플랫폼 역할, 워크스페이스 역할, MC-IAM 권한, CSP 역할 조회
+// 워크스페이스별 CSP 역할은 GetTemporaryCredentials 와 같이 대표 워크스페이스 역할의 매핑을 따른다.
+func takeAccessSnapshot(ctx context.Context, tx *gorm.DB, userID uint) (*accessSnapshot, error) {
+	var user model.User
+	if err := tx.Select("id", "username").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %d: %w", userID, ErrUserNotFound)
+		}
+		return nil, err
+	}
+
+	authzService := NewAuthzService(tx)
+	authzService.cache = nil // 트랜잭션 안의 변경을 봐야 하므로 캐시를 거치지 않음
+	roleService := NewRoleService(tx)
+	snap := &accessSnapshot{
+		username:       user.Username,
+		platformRoles:  make(map[string]bool),
+		workspaceRoles: make(map[string]bool),
+		permissions:    make(map[string]bool),
+		cspRoles:       make(map[string]bool),
+	}
+
+	platformGrants, err := authzService.authzRepo.FindPlatformRoleGrants(userID)
+	if err != nil {
+		return nil, err
+	}
+	seenRoles := make(map[uint]bool)
+	for _, g := range platformGrants {
+		snap.platformRoles[g.RoleName] = true
+		if !seenRoles[g.RoleID] {
+			seenRoles[g.RoleID] = true
+			snap.platformRoleIDs = append(snap.platformRoleIDs, g.RoleID)
+		}
+	}
+
+	platformPerms, err := authzService.GetEffectivePermissions(ctx, userID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for p := range platformPerms {
+		snap.permissions[p] = true
+	}
+
+	wsGrants, err := authzService.authzRepo.FindAllWorkspaceRoleGrants(userID)
+	if err != nil {
+		return nil, err
+	}
+	byWorkspace := make(map[uint][]model.AuthzRoleGrant)
+	for _, g := range wsGrants {
+		byWorkspace[g.WorkspaceID] = append(byWorkspace[g.WorkspaceID], g)
+	}
+	for wsID, grants := range byWorkspace {
+		suffix := "@" + strconv.FormatUint(uint64(wsID), 10)
+		for _, g := range grants {
+			snap.workspaceRoles[g.RoleName+suffix] = true
+		}
+
+		wsPerms, err := authzService.GetEffectivePermissions(ctx, userID, wsID)
+		if err != nil {
+			return nil, err
+		}
+		for p := range wsPerms {
+			if _, ok := platformPerms[p]; !ok {
+				snap.permissions[p+suffix] = true
+			}
+		}
+
+		resolved, err := roleService.GetResolvedRolePermissions(PrimaryRoleGrant(grants).RoleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resolved.CspRoleMappings {
+			snap.cspRoles[m.CspType+":"+m.CspRoleName+suffix] = true
+		}
+	}
+	return snap, nil
+}
+
+// menuIDs 플랫폼 역할로 구성되는 메뉴 트리(BuildUserMenuTree)의 메뉴 ID 집합
+func (s *AccessSimulationService) menuIDs(ctx context.Context, userID uint, platformRoleIDs []uint) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	if len(platformRoleIDs) == 0 {
+		return ids, nil
+	}
+	tree, err := s.menuService.BuildUserMenuTree(ctx, userID, platformRoleIDs)
+	if err != nil {
+		return nil, err
+	}
+	var walk func(nodes []*model.MenuTreeNode)
+	walk = func(nodes []*model.MenuTreeNode) {
+		for _, n := range nodes {
+			ids[n.ID] = true
+			walk(n.Children)
+		}
+	}
+	walk(tree)
+	return ids, nil
+}
+
+// diffAccessSet 변경 전후 집합의 추가/제거 항목 (정렬)
+func diffAccessSet(before, after map[string]bool) model.SimulationDiff {
+	diff := model.SimulationDiff{Added: []string{}, Removed: []string{}}
+	for k := range after {
+		if !before[k] {
+			diff.Added = append(diff.Added, k)
+		}
+	}
+	for k := range before {
+		if !after[k] {
+			diff.Removed = append(diff.Removed, k)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
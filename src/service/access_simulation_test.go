@@ -0,0 +1,136 @@
+package service
+
+// access_simulation_test.go
+//
+// 역할/그룹 변경 가정 시뮬레이션 테스트 (SQLite in-memory DB)
+// 변경 전후 차이가 계산되고, 시뮬레이션 후 DB 에 아무것도 남지 않는지 검증한다.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestSimulationService(t *testing.T) (*AccessSimulationService, *gorm.DB) {
+	t.Helper()
+	db := setupRoleHierarchyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}))
+	return &AccessSimulationService{db: db, menuService: NewMenuService(db)}, db
+}
+
+// TC-SIM-01: 플랫폼 역할 할당 → 역할/권한/메뉴 추가, 실제 할당은 저장되지 않음
+func TestAccessSimulation_AssignPlatformRole(t *testing.T) {
+	svc, db := newTestSimulationService(t)
+	user := createGRTestUser(t, db, "sim-user-01", "kc-sim-01")
+	child, _, top := createRoleLadder(t, db, "sim01")
+	require.NoError(t, db.Create(&model.Menu{ID: "dashboard", DisplayName: "Dashboard", ResType: "menu", Priority: 1, MenuNumber: 1}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: top.ID, MenuID: "dashboard"}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:user:read")
+
+	resp, err := svc.Simulate(context.Background(), &model.SimulationRequest{Changes: []model.SimulationChange{
+		{Action: model.SimulationAssignPlatformRole, UserID: user.ID, RoleID: child.ID},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	result := resp.Users[0]
+	assert.True(t, result.Changed)
+	assert.Equal(t, []string{"sim01-viewer"}, result.PlatformRoles.Added)
+	assert.Equal(t, []string{"mc-iam-manager:user:read"}, result.Permissions.Added)
+	assert.Equal(t, []string{"dashboard"}, result.Menus.Added)
+	assert.Empty(t, result.PlatformRoles.Removed)
+
+	var count int64
+	require.NoError(t, db.Model(&model.UserPlatformRole{}).Where("user_id = ?", user.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+// TC-SIM-02: 그룹 간 이동 → 이전 그룹의 워크스페이스 역할/CSP 역할 제거, 새 그룹의 역할 추가
+func TestAccessSimulation_MoveUserGroup(t *testing.T) {
+	svc, db := newTestSimulationService(t)
+	user := createGRTestUser(t, db, "sim-user-02", "kc-sim-02")
+	ops := createGRTestOrg(t, db, "sim02-ops", "SM02O")
+	dev := createGRTestOrg(t, db, "sim02-dev", "SM02D")
+	operator := createGRTestRole(t, db, "sim02-operator")
+	viewer := createGRTestRole(t, db, "sim02-viewer")
+	ws := createGRTestWorkspace(t, db, "sim02-ws")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: ops.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: ops.ID, WorkspaceID: ws.ID, RoleID: operator.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: dev.ID, WorkspaceID: ws.ID, RoleID: viewer.ID}).Error)
+	awsCsp := &model.CspRole{Name: "mciam-sim02-operator", CspType: "aws"}
+	require.NoError(t, db.Create(awsCsp).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: operator.ID, CspRoleID: awsCsp.ID, AuthMethod: constants.AuthMethodOIDC}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, operator.ID, "mc-infra-manager:vm:create")
+
+	resp, err := svc.Simulate(context.Background(), &model.SimulationRequest{Changes: []model.SimulationChange{
+		{Action: model.SimulationMoveUserGroup, UserID: user.ID, FromGroupID: ops.ID, GroupID: dev.ID},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	result := resp.Users[0]
+	wsSuffix := "@" + strconv.Itoa(int(ws.ID))
+	assert.Equal(t, []string{"sim02-viewer" + wsSuffix}, result.WorkspaceRoles.Added)
+	assert.Equal(t, []string{"sim02-operator" + wsSuffix}, result.WorkspaceRoles.Removed)
+	assert.Equal(t, []string{"mc-infra-manager:vm:create" + wsSuffix}, result.Permissions.Removed)
+	assert.Equal(t, []string{"aws:mciam-sim02-operator" + wsSuffix}, result.CspRoles.Removed)
+
+	var orgIDs []uint
+	require.NoError(t, db.Model(&model.UserOrganization{}).Where("user_id = ?", user.ID).Pluck("organization_id", &orgIDs).Error)
+	assert.Equal(t, []uint{ops.ID}, orgIDs)
+}
+
+// TC-SIM-03: 그룹 워크스페이스 역할 제거 → 그룹 구성원 전체가 영향 대상, 변경 없는 지정 사용자도 포함
+func TestAccessSimulation_RemoveGroupWorkspaceRole(t *testing.T) {
+	svc, db := newTestSimulationService(t)
+	alice := createGRTestUser(t, db, "sim-user-03a", "kc-sim-03a")
+	bob := createGRTestUser(t, db, "sim-user-03b", "kc-sim-03b")
+	carol := createGRTestUser(t, db, "sim-user-03c", "kc-sim-03c")
+	team := createGRTestOrg(t, db, "sim03-team", "SM03")
+	role := createGRTestRole(t, db, "sim03-member")
+	ws := createGRTestWorkspace(t, db, "sim03-ws")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: alice.ID, OrganizationID: team.ID}).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: bob.ID, OrganizationID: team.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: team.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+
+	resp, err := svc.Simulate(context.Background(), &model.SimulationRequest{
+		UserIDs: []uint{carol.ID},
+		Changes: []model.SimulationChange{{Action: model.SimulationRemoveGroupWorkspaceRole, GroupID: team.ID, WorkspaceID: ws.ID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 3)
+	for _, u := range resp.Users {
+		if u.UserID == carol.ID {
+			assert.False(t, u.Changed)
+			continue
+		}
+		assert.True(t, u.Changed)
+		assert.Equal(t, []string{"sim03-member@" + strconv.Itoa(int(ws.ID))}, u.WorkspaceRoles.Removed)
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&model.GroupWorkspaceRole{}).Where("group_id = ?", team.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// TC-SIM-04: 필수 항목 누락, 알 수 없는 변경 유형, 적용할 수 없는 변경 → ErrInvalidSimulationChange
+func TestAccessSimulation_InvalidChanges(t *testing.T) {
+	svc, db := newTestSimulationService(t)
+	user := createGRTestUser(t, db, "sim-user-04", "kc-sim-04")
+	team := createGRTestOrg(t, db, "sim04-team", "SM04")
+
+	for _, c := range []model.SimulationChange{
+		{Action: model.SimulationAssignPlatformRole, UserID: user.ID},
+		{Action: "promoteEveryone"},
+		{Action: model.SimulationRemoveUserFromGroup, UserID: user.ID, GroupID: team.ID},
+		{Action: model.SimulationAssignGroupPlatformRole, GroupID: team.ID, RoleID: 99999},
+	} {
+		_, err := svc.Simulate(context.Background(), &model.SimulationRequest{Changes: []model.SimulationChange{c}})
+		assert.True(t, errors.Is(err, ErrInvalidSimulationChange), "action %s: %v", c.Action, err)
+	}
+}
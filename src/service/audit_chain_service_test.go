@@ -0,0 +1,265 @@
+package service
+
+// audit_chain_service_test.go
+//
+// 감사 기록 해시 체인/서명 체크포인트 테스트 (SQLite in-memory DB)
+// 체인 연결, 수정/삭제/해시 누락 탐지(처음 끊어진 연결 보고), 도입 전 기록 처리,
+// Ed25519 체크포인트 서명/검증과 체인 재계산·마지막 기록 삭제 탐지를 검증한다.
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupAuditChainTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.AuditEvent{}, &model.CredentialIssuance{}, &model.BreakGlassActivity{}, &model.AuditCheckpoint{}))
+	return db
+}
+
+// writeAuditTestKey Ed25519 키를 PEM 으로 저장하고 개인 키/공개 키 파일 경로 반환
+func writeAuditTestKey(t *testing.T) (string, string) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "audit-signing.pem")
+	pubPath := filepath.Join(dir, "audit-signing.pub.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+	return privPath, pubPath
+}
+
+// recordAuditChainEvents 감사 이벤트, 발급 기록, 비상 접근 활동 기록을 n 건씩 체인에 추가
+func recordAuditChainEvents(t *testing.T, db *gorm.DB, n int) {
+	t.Helper()
+	auditSvc := NewAuditService(db)
+	issuanceSvc := NewCredentialIssuanceService(db)
+	breakGlassSvc := &BreakGlassService{chainRepo: repository.NewAuditChainRepository(db)}
+	for i := 0; i < n; i++ {
+		require.NoError(t, auditSvc.Record(&model.AuditEvent{
+			Method: "POST", Route: "/api/roles/assign/workspace-role", StatusCode: 200, ActorKcID: "kc-admin",
+			ActorPlatformRoles: []string{"admin"},
+		}, &model.AuditDetail{
+			Action: model.AuditActionWorkspaceRoleAssign, EntityType: model.AuditEntityUser, EntityID: "7",
+			Before: map[string]interface{}{"workspaceRoles": []string{}}, After: map[string]interface{}{"workspaceRoles": []string{"viewer"}, "n": i},
+		}))
+		expiresAt := time.Now().Add(time.Hour)
+		issuanceSvc.Record(&model.CredentialIssuance{RequestedAt: time.Now(), KcUserID: "kc-alice", CspType: "aws", WorkspaceID: 1},
+			&model.CspCredentialResponse{Expiration: expiresAt}, nil)
+		require.NoError(t, breakGlassSvc.RecordActivity(&model.BreakGlassActivity{
+			SessionID: 1, ActorKcID: "kc-bg", ActorUsername: "bg", Method: "GET", Route: "/api/users", Path: "/api/users", StatusCode: 200,
+		}))
+	}
+}
+
+func newTestAuditChainService(db *gorm.DB) *AuditChainService {
+	signer, _ := loadAuditSignerFromEnv()
+	return &AuditChainService{chainRepo: repository.NewAuditChainRepository(db), signer: signer}
+}
+
+// TC-AUDITCHAIN-01: 기록이 직전 해시에 연결되고 전체 체인 검증 통과, jsonb 정규화에도 해시 유지
+func TestAuditChain_VerifyIntactChains(t *testing.T) {
+	db := setupAuditChainTestDB(t)
+	recordAuditChainEvents(t, db, 3)
+
+	var events []model.AuditEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	require.Len(t, events, 3)
+	assert.Equal(t, "", events[0].PrevHash)
+	assert.Equal(t, events[0].Hash, events[1].PrevHash)
+	assert.Len(t, events[2].Hash, 64)
+
+	resp, err := newTestAuditChainService(db).Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	require.Len(t, resp.Chains, 3)
+	for _, c := range resp.Chains {
+		assert.True(t, c.Valid, c.Chain)
+		assert.Equal(t, int64(3), c.Checked, c.Chain)
+		assert.Nil(t, c.BrokenLink)
+	}
+
+	// PostgreSQL jsonb 는 공백/키 순서를 바꿔 저장하므로 정규화 후 해시가 같아야 함
+	reformatted := events[1]
+	reformatted.After = datatypes.JSON(`{ "workspaceRoles" : ["viewer"], "n" : 1 }`)
+	assert.Equal(t, events[1].Hash, auditEventHash(events[1].PrevHash, &reformatted))
+
+	_, err = newTestAuditChainService(db).Verify(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, repository.ErrUnknownAuditChain))
+}
+
+// TC-AUDITCHAIN-02: 수정된 기록은 hash mismatch, 삭제된 기록은 다음 기록의 previous hash mismatch 로 보고
+func TestAuditChain_DetectsTampering(t *testing.T) {
+	db := setupAuditChainTestDB(t)
+	recordAuditChainEvents(t, db, 4)
+	svc := newTestAuditChainService(db)
+
+	var events []model.AuditEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	require.NoError(t, db.Model(&model.AuditEvent{}).Where("id = ?", events[1].ID).Update("actor_kc_id", "kc-someone-else").Error)
+	require.NoError(t, db.Model(&model.CredentialIssuance{}).Where("id = ?", 3).Update("source_ip", "198.51.100.1").Error)
+
+	resp, err := svc.Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	require.NotNil(t, resp.Chains[0].BrokenLink)
+	assert.Equal(t, events[1].ID, resp.Chains[0].BrokenLink.RecordID)
+	assert.Equal(t, "hash mismatch", resp.Chains[0].BrokenLink.Reason)
+	assert.Equal(t, int64(1), resp.Chains[0].Checked, "처음 끊어진 곳에서 멈춤")
+	require.NotNil(t, resp.Chains[1].BrokenLink)
+	assert.Equal(t, uint(3), resp.Chains[1].BrokenLink.RecordID)
+
+	// 수정 원복 후 중간 기록 삭제
+	require.NoError(t, db.Model(&model.AuditEvent{}).Where("id = ?", events[1].ID).Update("actor_kc_id", events[1].ActorKcID).Error)
+	require.NoError(t, db.Delete(&model.AuditEvent{}, events[2].ID).Error)
+	resp, err = svc.Verify(context.Background(), model.AuditChainEvents)
+	require.NoError(t, err)
+	require.Len(t, resp.Chains, 1)
+	require.NotNil(t, resp.Chains[0].BrokenLink)
+	assert.Equal(t, events[3].ID, resp.Chains[0].BrokenLink.RecordID)
+	assert.Equal(t, "previous hash mismatch", resp.Chains[0].BrokenLink.Reason)
+	assert.Equal(t, events[1].Hash, resp.Chains[0].BrokenLink.Expected)
+}
+
+// TC-AUDITCHAIN-03: 해시 체인 도입 전 기록은 검증 대상에서 제외, 체인 시작 후 해시가 지워진 기록은 missing hash
+func TestAuditChain_LegacyAndMissingHash(t *testing.T) {
+	db := setupAuditChainTestDB(t)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, db.Create(&model.AuditEvent{OccurredAt: time.Now(), Method: "POST", Route: "/api/legacy", Action: "POST /api/legacy", Result: model.AuditResultSuccess}).Error)
+	}
+	recordAuditChainEvents(t, db, 2)
+	svc := newTestAuditChainService(db)
+
+	resp, err := svc.Verify(context.Background(), model.AuditChainEvents)
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, int64(2), resp.Chains[0].Unchained)
+	assert.Equal(t, int64(2), resp.Chains[0].Checked)
+
+	require.NoError(t, db.Model(&model.AuditEvent{}).Where("id = ?", 4).Update("hash", "").Error)
+	resp, err = svc.Verify(context.Background(), model.AuditChainEvents)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Chains[0].BrokenLink)
+	assert.Equal(t, uint(4), resp.Chains[0].BrokenLink.RecordID)
+	assert.Equal(t, "missing hash", resp.Chains[0].BrokenLink.Reason)
+}
+
+// TC-AUDITCHAIN-04: 서명 체크포인트 생성/검증, 새 기록이 없으면 생성 생략, 서명 위조 탐지
+func TestAuditChain_SignedCheckpoints(t *testing.T) {
+	privPath, pubPath := writeAuditTestKey(t)
+	t.Setenv("MC_IAM_MANAGER_AUDIT_SIGNING_KEY_FILE", privPath)
+	db := setupAuditChainTestDB(t)
+	recordAuditChainEvents(t, db, 2)
+	svc := newTestAuditChainService(db)
+
+	created, err := svc.CreateCheckpoints()
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	assert.Equal(t, int64(2), created[0].RecordCount)
+	assert.Equal(t, "ed25519", created[0].Algorithm)
+
+	created, err = svc.CreateCheckpoints()
+	require.NoError(t, err)
+	assert.Empty(t, created, "새 기록 없음")
+
+	recordAuditChainEvents(t, db, 1)
+	created, err = svc.CreateCheckpoints()
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+
+	resp, err := svc.Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	for _, c := range resp.Chains {
+		require.Len(t, c.Checkpoints, 2)
+		for _, cp := range c.Checkpoints {
+			assert.True(t, cp.Valid)
+			assert.True(t, cp.SignatureVerified)
+		}
+	}
+
+	// 공개 키만으로 검증
+	t.Setenv("MC_IAM_MANAGER_AUDIT_SIGNING_KEY_FILE", "")
+	t.Setenv("MC_IAM_MANAGER_AUDIT_VERIFY_KEY_FILE", pubPath)
+	verifier := newTestAuditChainService(db)
+	resp, err = verifier.Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	_, err = verifier.CreateCheckpoints()
+	assert.True(t, errors.Is(err, ErrAuditSigningKeyNotConfigured))
+
+	// 기록 수를 바꾼 위조 체크포인트
+	require.NoError(t, db.Model(&model.AuditCheckpoint{}).Where("id = ?", created[0].ID).Update("record_count", 2).Error)
+	require.NoError(t, db.Model(&model.AuditCheckpoint{}).Where("id = ?", created[1].ID).Update("signature", created[0].Signature).Error)
+	resp, err = verifier.Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	assert.Contains(t, resp.Chains[0].Checkpoints[1].Reason, "record count")
+	assert.Equal(t, "invalid checkpoint signature", resp.Chains[1].Checkpoints[1].Reason)
+}
+
+// TC-AUDITCHAIN-05: 체인 전체 재계산(키 없이 가능한 변조)과 마지막 기록 삭제는 체크포인트로 탐지
+func TestAuditChain_CheckpointDetectsRehashAndTruncation(t *testing.T) {
+	privPath, _ := writeAuditTestKey(t)
+	t.Setenv("MC_IAM_MANAGER_AUDIT_SIGNING_KEY_FILE", privPath)
+	db := setupAuditChainTestDB(t)
+	recordAuditChainEvents(t, db, 3)
+	svc := newTestAuditChainService(db)
+	_, err := svc.CreateCheckpoints()
+	require.NoError(t, err)
+
+	// 감사 이벤트: 첫 기록을 수정하고 이후 체인을 모두 다시 계산
+	var events []model.AuditEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	prev := ""
+	for i := range events {
+		if i == 0 {
+			events[i].ActorKcID = "kc-attacker"
+		}
+		sealAuditEvent(&events[i], prev)
+		prev = events[i].Hash
+		require.NoError(t, db.Save(&events[i]).Error)
+	}
+	// 발급 기록: 마지막 기록 삭제
+	require.NoError(t, db.Delete(&model.CredentialIssuance{}, 3).Error)
+
+	resp, err := svc.Verify(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+
+	eventsChain, issuanceChain := resp.Chains[0], resp.Chains[1]
+	assert.Nil(t, eventsChain.BrokenLink, "재계산된 체인 자체는 연결됨")
+	assert.False(t, eventsChain.Valid)
+	assert.Equal(t, "checkpoint hash does not match record", eventsChain.Checkpoints[0].Reason)
+
+	assert.Nil(t, issuanceChain.BrokenLink)
+	assert.False(t, issuanceChain.Valid)
+	assert.Equal(t, "checkpointed record is missing", issuanceChain.Checkpoints[0].Reason)
+}
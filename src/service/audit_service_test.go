@@ -0,0 +1,136 @@
+package service
+
+// audit_service_test.go
+//
+// 감사 이벤트 기록/조회 테스트 (SQLite in-memory DB)
+// 의미 단위 상세 반영, 스냅샷 비밀값 가림, 조회 필터와 잘못된 필터 처리를 검증한다.
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestAuditService(t *testing.T) (*AuditService, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.AuditEvent{}))
+	return NewAuditService(db), db
+}
+
+// TC-AUDIT-01: 상세가 있으면 동작/대상/스냅샷 저장, 비밀값 필드는 가림
+func TestAuditService_RecordWithDetail(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	wsID := uint(7)
+	event := &model.AuditEvent{Method: "PUT", Route: "/api/csp-idp-configs/id/:configId", StatusCode: 200, ActorKcID: "kc-admin"}
+	detail := &model.AuditDetail{
+		Action:      model.AuditActionCspIdpConfigUpdate,
+		EntityType:  model.AuditEntityCspIdpConfig,
+		EntityID:    "3",
+		WorkspaceID: &wsID,
+		Before:      &model.CspIdpConfig{ID: 3, Name: "aws-sk", Config: map[string]string{"access_key_id": "AKIA", "secret_access_key": "s3cr3t", "region": "ap-northeast-2"}},
+		After:       map[string]interface{}{"nested": []interface{}{map[string]interface{}{"clientSecret": "x", "name": "ok"}}},
+	}
+	require.NoError(t, svc.Record(event, detail))
+
+	saved, err := svc.GetEvent(event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.AuditActionCspIdpConfigUpdate, saved.Action)
+	assert.Equal(t, model.AuditResultSuccess, saved.Result)
+	assert.Equal(t, &wsID, saved.WorkspaceID)
+	assert.False(t, saved.OccurredAt.IsZero())
+
+	var before model.CspIdpConfig
+	require.NoError(t, json.Unmarshal(saved.Before, &before))
+	assert.Equal(t, "***", before.Config["access_key_id"])
+	assert.Equal(t, "***", before.Config["secret_access_key"])
+	assert.Equal(t, "ap-northeast-2", before.Config["region"])
+	assert.JSONEq(t, `{"nested":[{"clientSecret":"***","name":"ok"}]}`, string(saved.After))
+}
+
+// TC-AUDIT-02: 상세 없는 요청은 "<METHOD> <route>" 동작, 4xx/5xx 는 실패로 기록
+func TestAuditService_RecordWithoutDetail(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	event := &model.AuditEvent{Method: "DELETE", Route: "/api/projects/id/:projectId", StatusCode: 403, ErrorMessage: "permission denied"}
+	require.NoError(t, svc.Record(event, nil))
+
+	saved, err := svc.GetEvent(event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE /api/projects/id/:projectId", saved.Action)
+	assert.Equal(t, model.AuditResultFailure, saved.Result)
+	assert.Nil(t, saved.Before)
+
+	_, err = svc.GetEvent(99999)
+	assert.True(t, errors.Is(err, repository.ErrAuditEventNotFound))
+}
+
+// TC-AUDIT-03: 행위자/대상/워크스페이스/시간 범위 필터, 최신순 정렬과 페이지
+func TestAuditService_ListEventsFilters(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ws1, ws2 := uint(1), uint(2)
+	for i, e := range []struct {
+		actor, username string
+		entityType      string
+		ws              *uint
+		status          int
+	}{
+		{"kc-alice", "alice", model.AuditEntityUser, &ws1, 200},
+		{"kc-alice", "alice", model.AuditEntityWorkspace, &ws2, 200},
+		{"kc-bob", "bob", model.AuditEntityUser, &ws1, 500},
+		{"kc-bob", "bob", model.AuditEntityGroup, nil, 200},
+	} {
+		require.NoError(t, svc.Record(&model.AuditEvent{
+			OccurredAt: base.Add(time.Duration(i) * time.Hour), Method: "POST", Route: "/api/test",
+			ActorKcID: e.actor, ActorUsername: e.username, StatusCode: e.status,
+		}, &model.AuditDetail{Action: "test.action", EntityType: e.entityType, WorkspaceID: e.ws}))
+	}
+
+	resp, err := svc.ListEvents(&model.AuditEventFilter{Actor: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Total)
+	assert.Equal(t, model.AuditEntityWorkspace, resp.Events[0].EntityType, "최신순")
+
+	resp, err = svc.ListEvents(&model.AuditEventFilter{Actor: "kc-bob", Result: model.AuditResultFailure})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+
+	resp, err = svc.ListEvents(&model.AuditEventFilter{EntityType: model.AuditEntityUser, WorkspaceID: ws1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Total)
+
+	resp, err = svc.ListEvents(&model.AuditEventFilter{From: "2026-03-01T10:00:00Z", To: "2026-03-01T12:00:00Z"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Total)
+
+	resp, err = svc.ListEvents(&model.AuditEventFilter{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Total)
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, "kc-bob", resp.Events[0].ActorKcID)
+}
+
+// TC-AUDIT-04: 잘못된 시간 형식/범위, 알 수 없는 결과 값 → ErrInvalidAuditFilter
+func TestAuditService_InvalidFilter(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	for _, f := range []model.AuditEventFilter{
+		{From: "yesterday"},
+		{From: "2026-03-02T00:00:00Z", To: "2026-03-01T00:00:00Z"},
+		{Result: "denied"},
+	} {
+		_, err := svc.ListEvents(&f)
+		assert.True(t, errors.Is(err, ErrInvalidAuditFilter), "%+v: %v", f, err)
+	}
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// AuthzCacheChannel 캐시 무효화를 다른 인스턴스에 전파하는 Postgres NOTIFY 채널
+const AuthzCacheChannel = "mciam_authz_cache"
+
+// 기본 캐시 유지 시간 (MC_IAM_MANAGER_AUTHZ_CACHE_TTL 로 변경, 0 이면 캐시 미사용)
+const defaultAuthzCacheTTL = 5 * time.Minute
+
+// 캐시 항목 종류
+const (
+	authzCachePlatformRoles  = "platformRoles"
+	authzCacheWorkspaceRoles = "workspaceRoles"
+	authzCachePermissions    = "permissions"
+	authzCacheMenuTree       = "menuTree"
+)
+
+// authzCacheTables 쓰기가 발생하면 캐시를 무효화하는 테이블
+// 역할 할당, 그룹 소속/그룹 역할, 역할-메뉴 매핑, 역할-권한 매핑, 역할 계층/메뉴 트리 변경이 해당된다.
+var authzCacheTables = []string{
+	"mcmp_user_platform_roles",
+	"mcmp_user_workspace_roles",
+	"mcmp_user_organizations",
+	"mcmp_group_platform_roles",
+	"mcmp_group_workspace_roles",
+	"mcmp_role_menu_mappings",
+	"mcmp_mciam_role_permissions",
+	"mcmp_role_masters",
+	"mcmp_role_subs",
+	"mcmp_menus",
+	"mcmp_organizations",
+}
+
+// AuthzCache 사용자별 유효 역할/권한/메뉴 트리 캐시 (프로세스 내)
+// 관련 테이블에 쓰기가 발생하면 전체를 비우고, Postgres NOTIFY 로 다른 인스턴스에도 알린다.
+// 무효화 콜백이 등록(RegisterInvalidation)되기 전이나 nil 캐시는 항상 미적중으로 동작한다.
+type AuthzCache struct {
+	ttl    time.Duration
+	active atomic.Bool
+
+	mu          sync.RWMutex
+	generation  uint64
+	entries     map[string]authzCacheEntry
+	invalidated time.Time
+
+	counters            map[string]*authzCacheCounter
+	invalidations       atomic.Uint64
+	remoteInvalidations atomic.Uint64
+	listenerConnected   atomic.Bool
+}
+
+type authzCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type authzCacheCounter struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewAuthzCache AuthzCache 생성 (ttl 이 0 이하면 캐시하지 않고 지표만 기록)
+func NewAuthzCache(ttl time.Duration) *AuthzCache {
+	c := &AuthzCache{
+		ttl:      ttl,
+		entries:  make(map[string]authzCacheEntry),
+		counters: make(map[string]*authzCacheCounter),
+	}
+	for _, kind := range []string{authzCachePlatformRoles, authzCacheWorkspaceRoles, authzCachePermissions, authzCacheMenuTree} {
+		c.counters[kind] = &authzCacheCounter{}
+	}
+	return c
+}
+
+var (
+	defaultAuthzCache     *AuthzCache
+	defaultAuthzCacheOnce sync.Once
+)
+
+// DefaultAuthzCache 서비스들이 공유하는 캐시 (.env 로드 후 첫 호출 시 생성)
+func DefaultAuthzCache() *AuthzCache {
+	defaultAuthzCacheOnce.Do(func() {
+		ttl := defaultAuthzCacheTTL
+		if v := os.Getenv("MC_IAM_MANAGER_AUTHZ_CACHE_TTL"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				log.Printf("[AUTHZ_CACHE] invalid MC_IAM_MANAGER_AUTHZ_CACHE_TTL %q, using %s: %v", v, ttl, err)
+			} else {
+				ttl = d
+			}
+		}
+		defaultAuthzCache = NewAuthzCache(ttl)
+	})
+	return defaultAuthzCache
+}
+
+// lookup 캐시 조회. 미적중이면 조회 시작 시점의 세대(generation)를 함께 반환한다.
+func (c *AuthzCache) lookup(kind, key string) (interface{}, uint64, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	counter := c.counters[kind]
+	if !c.enabled() {
+		counter.misses.Add(1)
+		return nil, 0, false
+	}
+	c.mu.RLock()
+	entry, ok := c.entries[kind+":"+key]
+	gen := c.generation
+	c.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		counter.hits.Add(1)
+		return entry.value, gen, true
+	}
+	counter.misses.Add(1)
+	return nil, gen, false
+}
+
+// store 캐시 저장. 조회 시작 후 무효화가 있었으면(세대가 바뀌었으면) 이전 데이터일 수 있으므로 저장하지 않는다.
+func (c *AuthzCache) store(kind, key string, gen uint64, value interface{}) {
+	if c == nil || !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.generation {
+		return
+	}
+	c.entries[kind+":"+key] = authzCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+func (c *AuthzCache) enabled() bool {
+	return c.ttl > 0 && c.active.Load()
+}
+
+// Invalidate 캐시 전체 비우기
+func (c *AuthzCache) Invalidate(reason string) {
+	if c == nil {
+		return
+	}
+	c.invalidations.Add(1)
+	c.flush(reason)
+}
+
+func (c *AuthzCache) flush(reason string) {
+	c.mu.Lock()
+	c.generation++
+	c.entries = make(map[string]authzCacheEntry)
+	c.invalidated = time.Now()
+	c.mu.Unlock()
+	log.Printf("[AUTHZ_CACHE] invalidated: %s", reason)
+}
+
+// Stats 캐시 지표 조회
+func (c *AuthzCache) Stats() model.AuthzCacheStats {
+	if c == nil {
+		return model.AuthzCacheStats{ByKind: map[string]model.AuthzCacheCounter{}}
+	}
+	stats := model.AuthzCacheStats{
+		Enabled:             c.enabled(),
+		TTLSeconds:          int64(c.ttl / time.Second),
+		ByKind:              make(map[string]model.AuthzCacheCounter, len(c.counters)),
+		Invalidations:       c.invalidations.Load(),
+		RemoteInvalidations: c.remoteInvalidations.Load(),
+		ListenerConnected:   c.listenerConnected.Load(),
+	}
+	for kind, counter := range c.counters {
+		kc := model.AuthzCacheCounter{Hits: counter.hits.Load(), Misses: counter.misses.Load()}
+		stats.ByKind[kind] = kc
+		stats.Hits += kc.Hits
+		stats.Misses += kc.Misses
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	c.mu.RLock()
+	stats.Entries = len(c.entries)
+	if !c.invalidated.IsZero() {
+		at := c.invalidated
+		stats.LastInvalidatedAt = &at
+	}
+	c.mu.RUnlock()
+	return stats
+}
+
+// RegisterInvalidation 관련 테이블 쓰기 시 캐시를 무효화하는 GORM 콜백 등록
+// 이 인스턴스의 캐시는 즉시 비우고, Postgres 에서는 같은 연결(트랜잭션)로 pg_notify 를 보낸다.
+// NOTIFY 는 커밋 시점에 전달되므로 커밋 전 다시 채워진 이전 데이터도 수신 시 다시 비워진다.
+func (c *AuthzCache) RegisterInvalidation(db *gorm.DB) error {
+	callback := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			return
+		}
+		table := authzCacheWriteTable(tx.Statement)
+		if table == "" {
+			return
+		}
+		c.Invalidate("write to " + table)
+		if tx.Dialector.Name() != "postgres" {
+			return
+		}
+		notify := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+		if err := notify.Exec("SELECT pg_notify(?, ?)", AuthzCacheChannel, table).Error; err != nil {
+			log.Printf("[AUTHZ_CACHE] failed to notify invalidation (%s): %v", table, err)
+		}
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("mciam:authz_cache_create", callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("mciam:authz_cache_update", callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("mciam:authz_cache_delete", callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("mciam:authz_cache_raw", callback); err != nil {
+		return err
+	}
+	c.active.Store(true)
+	return nil
+}
+
+// authzCacheWriteTable 캐시 대상 테이블에 대한 쓰기이면 테이블 이름 반환
+// Raw(Exec) 문은 SQL 에 대상 테이블 이름과 쓰기 구문이 함께 있는 경우로 판단한다.
+func authzCacheWriteTable(stmt *gorm.Statement) string {
+	if stmt.Table != "" {
+		for _, t := range authzCacheTables {
+			if stmt.Table == t {
+				return t
+			}
+		}
+		return ""
+	}
+	sql := strings.ToLower(stmt.SQL.String())
+	if !strings.Contains(sql, "insert") && !strings.Contains(sql, "update") && !strings.Contains(sql, "delete") {
+		return ""
+	}
+	for _, t := range authzCacheTables {
+		if strings.Contains(sql, t) {
+			return t
+		}
+	}
+	return ""
+}
+
+// Listen 다른 인스턴스의 무효화 알림 수신 (ctx 가 끝나면 중단)
+// 재연결 시에는 놓친 알림이 있을 수 있으므로 캐시를 비운다.
+func (c *AuthzCache) Listen(ctx context.Context, dsn string) error {
+	listener := pq.NewListener(dsn, 5*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventConnected:
+			c.listenerConnected.Store(true)
+		case pq.ListenerEventDisconnected:
+			c.listenerConnected.Store(false)
+			log.Printf("[AUTHZ_CACHE] listener disconnected: %v", err)
+		case pq.ListenerEventReconnected:
+			c.listenerConnected.Store(true)
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Printf("[AUTHZ_CACHE] listener connection attempt failed: %v", err)
+		}
+	})
+	if err := listener.Listen(AuthzCacheChannel); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to listen on %s: %w", AuthzCacheChannel, err)
+	}
+
+	go func() {
+		defer listener.Close()
+		ping := time.NewTicker(90 * time.Second)
+		defer ping.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				c.remoteInvalidations.Add(1)
+				if n == nil {
+					c.flush("listener reconnected")
+					continue
+				}
+				c.flush("notify: write to " + n.Extra)
+			case <-ping.C:
+				go listener.Ping()
+			}
+		}
+	}()
+	return nil
+}
+
+// authzCacheKey 사용자/워크스페이스 단위 캐시 키
+func authzCacheKey(userID, workspaceID uint) string {
+	return strconv.FormatUint(uint64(userID), 10) + ":" + strconv.FormatUint(uint64(workspaceID), 10)
+}
+
+// menuTreeCacheKey 플랫폼 역할 조합 단위 메뉴 트리 캐시 키
+func menuTreeCacheKey(platformRoleIDs []uint) string {
+	ids := append([]uint(nil), platformRoleIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// cloneMenuTree 캐시된 메뉴 트리를 호출자가 수정할 수 있도록 복사
+func cloneMenuTree(nodes []*model.MenuTreeNode) []*model.MenuTreeNode {
+	if nodes == nil {
+		return nil
+	}
+	out := make([]*model.MenuTreeNode, len(nodes))
+	for i, n := range nodes {
+		clone := *n
+		clone.Children = cloneMenuTree(n.Children)
+		out[i] = &clone
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package service
+
+// authz_cache_test.go
+//
+// 유효 역할/권한/메뉴 트리 캐시 테스트 (SQLite in-memory DB)
+// 적중/미적중 지표, 쓰기 감지 무효화, 조건부 매핑 미캐시, 무효화 중 채우기 방지를 검증한다.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestCachedAuthzService(t *testing.T) (*AuthzService, *gorm.DB) {
+	t.Helper()
+	svc, db := newTestAuthzService(t)
+	svc.cache = NewAuthzCache(time.Minute)
+	require.NoError(t, svc.cache.RegisterInvalidation(db))
+	return svc, db
+}
+
+// TC-CACHE-01: 같은 사용자 권한 재확인 → 역할/권한 모두 캐시 적중
+func TestAuthzCache_HitsAndMisses(t *testing.T) {
+	svc, db := newTestCachedAuthzService(t)
+	user := createGRTestUser(t, db, "cache-user-01", "kc-cache-01")
+	role := createGRTestRole(t, db, "cache01-admin")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:user:read")
+
+	for i := 0; i < 2; i++ {
+		allowed, err := svc.HasPermission(context.Background(), user.ID, 0, "mc-iam-manager:user:read")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	stats := svc.CacheStats()
+	assert.True(t, stats.Enabled)
+	assert.Equal(t, model.AuthzCacheCounter{Hits: 1, Misses: 1}, stats.ByKind[authzCachePermissions])
+	assert.Equal(t, model.AuthzCacheCounter{Hits: 0, Misses: 1}, stats.ByKind[authzCachePlatformRoles])
+	assert.Equal(t, 2, stats.Entries)
+	assert.InDelta(t, 1.0/3.0, stats.HitRatio, 0.001)
+}
+
+// TC-CACHE-02: 역할 할당/권한 매핑 쓰기 → 캐시 무효화 후 새 권한 반영
+func TestAuthzCache_InvalidatedOnWrites(t *testing.T) {
+	svc, db := newTestCachedAuthzService(t)
+	user := createGRTestUser(t, db, "cache-user-02", "kc-cache-02")
+	role := createGRTestRole(t, db, "cache02-operator")
+
+	allowed, err := svc.HasPermission(context.Background(), user.ID, 0, "mc-iam-manager:user:write")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	before := svc.CacheStats().Invalidations
+
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:user:write")
+	allowed, err = svc.HasPermission(context.Background(), user.ID, 0, "mc-iam-manager:user:write")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// 리포지토리 경유 삭제도 감지
+	require.NoError(t, repository.NewRoleRepository(db).RemovePlatformRole(user.ID, role.ID))
+	allowed, err = svc.HasPermission(context.Background(), user.ID, 0, "mc-iam-manager:user:write")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, before+3, svc.CacheStats().Invalidations)
+
+	// 캐시 대상이 아닌 테이블 쓰기는 무효화하지 않음
+	createGRTestWorkspace(t, db, "cache02-ws")
+	assert.Equal(t, before+3, svc.CacheStats().Invalidations)
+}
+
+// TC-CACHE-03: 조건부 매핑이 평가된 권한 집합은 요청 속성에 따라 달라지므로 캐시하지 않음
+func TestAuthzCache_ConditionalNotCached(t *testing.T) {
+	svc, db := newTestCachedAuthzService(t)
+	user := createGRTestUser(t, db, "cache-user-03", "kc-cache-03")
+	role := createGRTestRole(t, db, "cache03-operator")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantConditionalTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:user:write", model.PermissionEffectAllow, &model.GrantConditions{SourceCIDRs: []string{"10.0.0.0/8"}})
+
+	inside := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{SourceIP: "10.1.2.3"})
+	outside := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{SourceIP: "192.168.0.1"})
+
+	allowed, err := svc.HasPermission(inside, user.ID, 0, "mc-iam-manager:user:write")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = svc.HasPermission(outside, user.ID, 0, "mc-iam-manager:user:write")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Zero(t, svc.CacheStats().ByKind[authzCachePermissions].Hits)
+}
+
+// TC-CACHE-04: 메뉴 트리는 복사본으로 반환되고, 역할-메뉴 매핑 변경 시 무효화
+func TestAuthzCache_MenuTree(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}))
+	menuService := NewMenuService(db)
+	menuService.cache = NewAuthzCache(time.Minute)
+	require.NoError(t, menuService.cache.RegisterInvalidation(db))
+	role := createGRTestRole(t, db, "cache04-viewer")
+	for _, m := range []*model.Menu{
+		{ID: "cache04-home", DisplayName: "Home", ResType: "menu", Priority: 1, MenuNumber: 1},
+		{ID: "cache04-logs", DisplayName: "Logs", ResType: "menu", Priority: 1, MenuNumber: 2},
+	} {
+		require.NoError(t, db.Create(m).Error)
+	}
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: role.ID, MenuID: "cache04-home"}).Error)
+
+	tree, err := menuService.BuildUserMenuTree(context.Background(), 0, []uint{role.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	tree[0].DisplayName = "changed by caller"
+
+	tree, err = menuService.BuildUserMenuTree(context.Background(), 0, []uint{role.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, "Home", tree[0].DisplayName)
+	assert.Equal(t, uint64(1), menuService.cache.Stats().ByKind[authzCacheMenuTree].Hits)
+
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: role.ID, MenuID: "cache04-logs"}).Error)
+	tree, err = menuService.BuildUserMenuTree(context.Background(), 0, []uint{role.ID})
+	require.NoError(t, err)
+	assert.Len(t, tree, 2)
+}
+
+// TC-CACHE-05: 조회 중 무효화가 있었으면 조회 결과를 저장하지 않음, TTL 0 이거나 무효화 콜백 미등록이면 캐시 미사용
+func TestAuthzCache_StaleFillAndDisabled(t *testing.T) {
+	svc, _ := newTestCachedAuthzService(t)
+	cache := svc.cache
+	_, gen, ok := cache.lookup(authzCachePlatformRoles, "1:0")
+	assert.False(t, ok)
+	cache.Invalidate("test")
+	cache.store(authzCachePlatformRoles, "1:0", gen, []model.AuthzRoleGrant{})
+	_, _, ok = cache.lookup(authzCachePlatformRoles, "1:0")
+	assert.False(t, ok)
+
+	unregistered := NewAuthzCache(time.Minute)
+	noTTL := NewAuthzCache(0)
+	require.NoError(t, noTTL.RegisterInvalidation(setupAuthzTestDB(t)))
+	for _, disabled := range []*AuthzCache{unregistered, noTTL} {
+		disabled.store(authzCachePlatformRoles, "1:0", 0, []model.AuthzRoleGrant{})
+		_, _, ok = disabled.lookup(authzCachePlatformRoles, "1:0")
+		assert.False(t, ok)
+		assert.False(t, disabled.Stats().Enabled)
+	}
+
+	var nilCache *AuthzCache
+	_, _, ok = nilCache.lookup(authzCachePlatformRoles, "1:0")
+	assert.False(t, ok)
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidExplainTarget = errors.New("exactly one of permission, menuId or cspRoleId is required")
+
+// Explain 사용자가 권한/메뉴/CSP 역할에 접근할 수 있는(없는) 모든 경로 조회
+// 직접 할당, 그룹(조직) 소속, 역할 상속을 따라 허용 경로와 차단 경로를 각각 기록한다.
+func (s *AuthzService) Explain(ctx context.Context, req *model.AuthzExplainRequest) (*model.AuthzExplainResponse, error) {
+	targetType, target, err := explainTarget(req)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, err := parseAuthzWorkspaceID(req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if targetType == model.AuthzExplainTargetCspRole && workspaceID == 0 {
+		return nil, fmt.Errorf("cspRoleId requires workspaceId: %w", ErrInvalidWorkspaceID)
+	}
+	ctx, err = withConditionOverrides(ctx, req.SourceIP, req.At, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.ResolveSubject(ctx, req.UserID, req.KcUserID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.GetRoleGrants(ctx, user.ID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgIDs []uint
+	for _, g := range grants {
+		if g.GroupID != 0 {
+			orgIDs = append(orgIDs, g.GroupID)
+		}
+	}
+	orgPaths, err := s.authzRepo.FindOrganizationPaths(orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &accessExplanation{
+		orgPaths: orgPaths,
+		resp: &model.AuthzExplainResponse{
+			UserID:      user.ID,
+			KcUserID:    user.KcId,
+			Username:    user.Username,
+			WorkspaceID: req.WorkspaceID,
+			TargetType:  targetType,
+			Target:      target,
+			Grants:      []model.AuthzAccessPath{},
+			Blocks:      []model.AuthzAccessPath{},
+		},
+	}
+	e.resp.Roles = e.paths(grants, "", "")
+
+	switch targetType {
+	case model.AuthzExplainTargetPermission:
+		err = s.explainPermission(ctx, e, user.ID, workspaceID, grants, target)
+	case model.AuthzExplainTargetMenu:
+		err = s.explainMenu(ctx, e, grants, target)
+	case model.AuthzExplainTargetCspRole:
+		err = s.explainCspRole(ctx, e, user.ID, workspaceID, grants, target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.resp.Allowed = e.platformAdmin || (len(e.resp.Grants) > 0 && len(e.resp.Blocks) == 0)
+	return e.resp, nil
+}
+
+// explainTarget 요청에서 설명 대상 하나를 선택
+func explainTarget(req *model.AuthzExplainRequest) (string, string, error) {
+	var targetType, target string
+	count := 0
+	for _, t := range []struct{ typ, id string }{
+		{model.AuthzExplainTargetPermission, req.Permission},
+		{model.AuthzExplainTargetMenu, req.MenuID},
+		{model.AuthzExplainTargetCspRole, req.CspRoleID},
+	} {
+		if t.id != "" {
+			targetType, target = t.typ, t.id
+			count++
+		}
+	}
+	if count != 1 {
+		return "", "", ErrInvalidExplainTarget
+	}
+	if targetType == model.AuthzExplainTargetPermission && !isValidPermissionID(target) {
+		return "", "", fmt.Errorf("%q: %w", target, ErrInvalidPermissionID)
+	}
+	return targetType, target, nil
+}
+
+// accessExplanation 설명 응답 작성 도우미 (그룹 경로에 조직 트리 위치를 붙인다)
+type accessExplanation struct {
+	orgPaths      map[uint]string
+	resp          *model.AuthzExplainResponse
+	platformAdmin bool // 권한 대상에서 platformAdmin 으로 허용 (차단 경로보다 우선)
+}
+
+func (e *accessExplanation) paths(grants []model.AuthzRoleGrant, via, detail string) []model.AuthzAccessPath {
+	paths := make([]model.AuthzAccessPath, 0, len(grants))
+	for _, g := range grants {
+		paths = append(paths, model.AuthzAccessPath{
+			AuthzRoleGrant: g,
+			GroupPath:      e.orgPaths[g.GroupID],
+			Via:            via,
+			Detail:         detail,
+		})
+	}
+	return paths
+}
+
+// explainPermission 권한 판정과 같은 규칙(거부 우선, 조건부 매핑 평가)으로 경로 기록
+func (s *AuthzService) explainPermission(ctx context.Context, e *accessExplanation, userID, workspaceID uint, grants []model.AuthzRoleGrant, permissionID string) error {
+	evaluator := newGrantConditionEvaluator(s.authzRepo, userID, workspaceID, authzConditionContextFrom(ctx))
+	perms, err := collectRolePermissions(s.authzRepo, grants, evaluator)
+	if err != nil {
+		return err
+	}
+	d := perms.decide(permissionID)
+	// HasPermission 과 같이 platformAdmin(플랫폼 역할 또는 요청 토큰)은 거부 매핑과 관계없이 허용
+	var admins []model.AuthzRoleGrant
+	for _, g := range grants {
+		if g.RoleName == platformAdminRoleName && g.RoleType != constants.RoleTypeWorkspace {
+			admins = append(admins, g)
+		}
+	}
+	if len(admins) == 0 {
+		tokenAdmin, err := s.isPlatformAdminSubject(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if tokenAdmin {
+			admins = append(admins, model.AuthzRoleGrant{RoleName: platformAdminRoleName, RoleType: constants.RoleTypePlatform, Source: "token"})
+		}
+	}
+	if len(admins) > 0 {
+		e.platformAdmin = true
+		e.resp.Grants = append(e.resp.Grants, e.paths(admins, "platformAdmin", "platformAdmin holds every permission")...)
+	}
+	e.resp.Grants = append(e.resp.Grants, e.paths(d.GrantedBy, "permission", "allow "+permissionID)...)
+	e.resp.Blocks = append(e.resp.Blocks, e.paths(d.DeniedBy, "permission", "deny "+permissionID)...)
+	e.resp.ConditionUnmet = append(e.resp.ConditionUnmet, e.paths(d.ConditionUnmet, "permission", permissionID)...)
+	return nil
+}
+
+// explainMenu 메뉴 트리 구성(BuildUserMenuTree)과 같은 규칙으로 경로 기록
+// 플랫폼 역할(상위 역할 포함)에 메뉴 또는 하위 메뉴가 매핑되어 있으면 허용, 메뉴나 상위 메뉴에 대한 거부 매핑이 있으면 차단한다.
+func (s *AuthzService) explainMenu(ctx context.Context, e *accessExplanation, grants []model.AuthzRoleGrant, menuID string) error {
+	parents, err := s.authzRepo.FindMenuParents()
+	if err != nil {
+		return err
+	}
+	if _, ok := parents[menuID]; !ok {
+		return fmt.Errorf("%q: %w", menuID, repository.ErrMenuNotFound)
+	}
+	menuIDs := []string{menuID}
+	for id, parentID := range parents {
+		if parentID == menuID {
+			menuIDs = append(menuIDs, id)
+		}
+	}
+
+	var platformGrants []model.AuthzRoleGrant
+	var roleIDs []uint
+	for _, g := range grants {
+		if g.RoleType == constants.RoleTypePlatform {
+			platformGrants = append(platformGrants, g)
+			roleIDs = append(roleIDs, g.RoleID)
+		}
+	}
+	lineages, err := s.authzRepo.FindRoleLineages(roleIDs)
+	if err != nil {
+		return err
+	}
+	var sourceIDs []uint
+	for _, lineage := range lineages {
+		for _, role := range lineage {
+			sourceIDs = append(sourceIDs, role.ID)
+		}
+	}
+	mappings, err := s.authzRepo.FindRoleMenuMappings(sourceIDs, menuIDs)
+	if err != nil {
+		return err
+	}
+	mappedMenus := make(map[uint][]string)
+	for _, m := range mappings {
+		mappedMenus[m.RoleID] = append(mappedMenus[m.RoleID], m.MenuID)
+	}
+
+	for _, g := range platformGrants {
+		for _, source := range lineages[g.RoleID] {
+			for _, mapped := range mappedMenus[source.ID] {
+				path := inheritedGrant(g, source)
+				if mapped == menuID {
+					e.resp.Grants = append(e.resp.Grants, e.paths([]model.AuthzRoleGrant{path}, "menu", "menu "+menuID+" is mapped")...)
+				} else {
+					e.resp.Grants = append(e.resp.Grants, e.paths([]model.AuthzRoleGrant{path}, "childMenu", "child menu "+mapped+" is mapped")...)
+				}
+			}
+		}
+	}
+
+	// 메뉴 트리 필터와 같이 사용자 정보 없이 요청 속성(IP, 시각)만으로 조건을 평가한다.
+	evaluator := newGrantConditionEvaluator(s.authzRepo, 0, 0, authzConditionContextFrom(ctx))
+	perms, err := collectRolePermissions(s.authzRepo, platformGrants, evaluator)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for id := menuID; id != "" && !seen[id]; id = parents[id] {
+		seen[id] = true
+		d := perms.decide(model.MenuPermissionID(id))
+		detail := "deny " + d.Permission
+		if id != menuID {
+			detail = "ancestor menu " + id + ": " + detail
+		}
+		e.resp.Blocks = append(e.resp.Blocks, e.paths(d.DeniedBy, "menuDeny", detail)...)
+		e.resp.ConditionUnmet = append(e.resp.ConditionUnmet, e.paths(d.ConditionUnmet, "menuDeny", d.Permission)...)
+	}
+	return nil
+}
+
+// explainCspRole CSP 임시 자격 증명 발급(GetTemporaryCredentialsForRole)과 같은 규칙으로 경로 기록
+// 워크스페이스 역할의 상속 경로 중 cspType/authMethod 별로 가장 가까운 역할의 매핑만 유효하며,
+// 더 가까운 역할의 다른 매핑에 가려진 경우 차단 경로(cspRoleShadowed)로 기록한다.
+func (s *AuthzService) explainCspRole(ctx context.Context, e *accessExplanation, userID, workspaceID uint, grants []model.AuthzRoleGrant, cspRoleIDStr string) error {
+	id, err := strconv.ParseUint(cspRoleIDStr, 10, 32)
+	if err != nil {
+		return fmt.Errorf("cspRoleId %q: %w", cspRoleIDStr, ErrInvalidExplainTarget)
+	}
+	cspRoleID := uint(id)
+	cspRole, err := s.roleRepo.FindCspRoleById(cspRoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("csp role %d: %w", cspRoleID, ErrCspRoleNotFound)
+		}
+		return err
+	}
+
+	var workspaceGrants []model.AuthzRoleGrant
+	var roleIDs []uint
+	for _, g := range grants {
+		if g.RoleType == constants.RoleTypeWorkspace {
+			workspaceGrants = append(workspaceGrants, g)
+			roleIDs = append(roleIDs, g.RoleID)
+		}
+	}
+	lineages, err := s.authzRepo.FindRoleLineages(roleIDs)
+	if err != nil {
+		return err
+	}
+	var sourceIDs []uint
+	for _, lineage := range lineages {
+		for _, role := range lineage {
+			sourceIDs = append(sourceIDs, role.ID)
+		}
+	}
+	mappings, err := s.roleRepo.FindRoleCspMappingsWithCspRole(sourceIDs)
+	if err != nil {
+		return err
+	}
+	mappingsByRole := make(map[uint][]model.ResolvedRoleCspMapping)
+	for _, m := range mappings {
+		if m.CspType == cspRole.CspType {
+			mappingsByRole[m.SourceRoleID] = append(mappingsByRole[m.SourceRoleID], m)
+		}
+	}
+
+	evaluator := newGrantConditionEvaluator(s.authzRepo, userID, workspaceID, authzConditionContextFrom(ctx))
+	for _, g := range workspaceGrants {
+		lineage := lineages[g.RoleID]
+		// authMethod 별로 매핑을 가진 가장 가까운 역할
+		nearest := make(map[constants.AuthMethod]model.ResolvedRoleCspMapping)
+		for _, source := range lineage {
+			for _, m := range mappingsByRole[source.ID] {
+				if _, ok := nearest[m.AuthMethod]; !ok {
+					nearest[m.AuthMethod] = m
+				}
+			}
+		}
+		for _, source := range lineage {
+			for _, m := range mappingsByRole[source.ID] {
+				if m.CspRoleID != cspRoleID {
+					continue
+				}
+				path := inheritedGrant(g, source)
+				first := nearest[m.AuthMethod]
+				if first.SourceRoleID != source.ID {
+					detail := fmt.Sprintf("authMethod %s: role %d maps csp role %s first", m.AuthMethod, first.SourceRoleID, first.CspRoleName)
+					e.resp.Blocks = append(e.resp.Blocks, e.paths([]model.AuthzRoleGrant{path}, "cspRoleShadowed", detail)...)
+					continue
+				}
+				results, ok, err := evaluator.evaluate(m.Conditions)
+				if err != nil {
+					return err
+				}
+				path.Conditions = results
+				detail := fmt.Sprintf("authMethod %s", m.AuthMethod)
+				if !ok {
+					e.resp.ConditionUnmet = append(e.resp.ConditionUnmet, e.paths([]model.AuthzRoleGrant{path}, "cspRoleMapping", detail)...)
+					continue
+				}
+				e.resp.Grants = append(e.resp.Grants, e.paths([]model.AuthzRoleGrant{path}, "cspRoleMapping", detail)...)
+			}
+		}
+	}
+	return nil
+}
+
+// inheritedGrant 매핑을 가진 역할(source)이 부여 역할과 다르면 상속 정보를 기록한 복사본
+func inheritedGrant(g model.AuthzRoleGrant, source model.RoleMaster) model.AuthzRoleGrant {
+	if source.ID != g.RoleID {
+		g.InheritedFromRoleID = source.ID
+		g.InheritedFromRoleName = source.Name
+	}
+	return g
+}
@@ -0,0 +1,186 @@
+package service
+
+// authz_explain_test.go
+//
+// 접근 경로 설명(Explain) 테스트 (SQLite in-memory DB)
+// 권한/메뉴/CSP 역할 대상별로 직접 할당, 그룹(조직 트리), 역할 상속 경로와 차단 경로가 모두 기록되는지 검증한다.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestExplainService(t *testing.T) (*AuthzService, *gorm.DB) {
+	t.Helper()
+	db := setupRoleHierarchyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}))
+	svc := &AuthzService{
+		db:        db,
+		authzRepo: repository.NewAuthzRepository(db),
+		userRepo:  repository.NewUserRepository(db),
+		roleRepo:  repository.NewRoleRepository(db),
+	}
+	return svc, db
+}
+
+// TC-EXP-01: 권한 — 직접/그룹(조직 트리 경로)/상속 허용 경로와 그룹 거부 경로를 모두 기록
+func TestAuthzExplain_PermissionPaths(t *testing.T) {
+	svc, db := newTestExplainService(t)
+	user := createGRTestUser(t, db, "exp-user-01", "kc-exp-01")
+	child, _, top := createRoleLadder(t, db, "exp01")
+	auditor := createGRTestRole(t, db, "exp01-auditor")
+	restricted := createGRTestRole(t, db, "exp01-restricted")
+	hq := createGRTestOrg(t, db, "exp01-hq", "EX01")
+	team := &model.Organization{Name: "exp01-dev", OrganizationCode: "EX01D", ParentID: &hq.ID}
+	require.NoError(t, db.Create(team).Error)
+
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: child.ID}).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: team.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: team.ID, RoleID: auditor.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: team.ID, RoleID: restricted.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:user:delete")
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, auditor.ID, "mc-iam-manager:user:delete")
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, restricted.ID, "mc-iam-manager:user:delete")
+
+	resp, err := svc.Explain(context.Background(), &model.AuthzExplainRequest{UserID: strconv.Itoa(int(user.ID)), Permission: "mc-iam-manager:user:delete"})
+	require.NoError(t, err)
+	assert.Equal(t, model.AuthzExplainTargetPermission, resp.TargetType)
+	assert.False(t, resp.Allowed)
+	require.Len(t, resp.Grants, 2)
+	require.Len(t, resp.Blocks, 1)
+	assert.Len(t, resp.Roles, 3)
+
+	var inherited, group bool
+	for _, g := range resp.Grants {
+		if g.RoleID == child.ID {
+			inherited = g.InheritedFromRoleID == top.ID && g.Source == "direct"
+		}
+		if g.RoleID == auditor.ID {
+			group = g.GroupID == team.ID && g.GroupPath == "/exp01-hq/exp01-dev"
+		}
+	}
+	assert.True(t, inherited)
+	assert.True(t, group)
+	assert.Equal(t, restricted.ID, resp.Blocks[0].RoleID)
+	assert.Equal(t, "/exp01-hq/exp01-dev", resp.Blocks[0].GroupPath)
+}
+
+// TC-EXP-02: 메뉴 — 상속 역할의 메뉴 매핑, 하위 메뉴 매핑, 상위 메뉴 거부를 기록
+func TestAuthzExplain_MenuPaths(t *testing.T) {
+	svc, db := newTestExplainService(t)
+	user := createGRTestUser(t, db, "exp-user-02", "kc-exp-02")
+	child, middle, _ := createRoleLadder(t, db, "exp02")
+	restricted := createGRTestRole(t, db, "exp02-restricted")
+	for _, m := range []*model.Menu{
+		{ID: "settings", DisplayName: "Settings", ResType: "menu", Priority: 1, MenuNumber: 1},
+		{ID: "accounts", ParentID: "settings", DisplayName: "Accounts", ResType: "menu", Priority: 1, MenuNumber: 2},
+		{ID: "users", ParentID: "accounts", DisplayName: "Users", ResType: "menu", Priority: 1, MenuNumber: 3},
+	} {
+		require.NoError(t, db.Create(m).Error)
+	}
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: child.ID}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: middle.ID, MenuID: "accounts"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: child.ID, MenuID: "users"}).Error)
+
+	resp, err := svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, MenuID: "accounts"})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Grants, 2)
+	vias := map[string]model.AuthzAccessPath{}
+	for _, g := range resp.Grants {
+		vias[g.Via] = g
+	}
+	assert.Equal(t, middle.ID, vias["menu"].InheritedFromRoleID)
+	assert.Equal(t, "child menu users is mapped", vias["childMenu"].Detail)
+
+	// 상위 메뉴(settings) 거부 → 차단
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: restricted.ID}).Error)
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, restricted.ID, model.MenuPermissionID("settings"))
+	resp, err = svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, MenuID: "accounts"})
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	require.Len(t, resp.Blocks, 1)
+	assert.Equal(t, "menuDeny", resp.Blocks[0].Via)
+	assert.Equal(t, restricted.ID, resp.Blocks[0].RoleID)
+
+	_, err = svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, MenuID: "missing"})
+	assert.True(t, errors.Is(err, repository.ErrMenuNotFound))
+}
+
+// TC-EXP-03: CSP 역할 — 가장 가까운 역할의 매핑은 허용, 더 가까운 매핑에 가려진 상속 매핑은 차단으로 기록
+func TestAuthzExplain_CspRolePaths(t *testing.T) {
+	svc, db := newTestExplainService(t)
+	user := createGRTestUser(t, db, "exp-user-03", "kc-exp-03")
+	child, middle, top := createRoleLadder(t, db, "exp03")
+	ws := createGRTestWorkspace(t, db, "exp03-ws")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: child.ID}).Error)
+	operatorCsp := &model.CspRole{Name: "mciam-exp03-operator", CspType: "aws"}
+	adminCsp := &model.CspRole{Name: "mciam-exp03-admin", CspType: "aws"}
+	require.NoError(t, db.Create(operatorCsp).Error)
+	require.NoError(t, db.Create(adminCsp).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: middle.ID, CspRoleID: operatorCsp.ID, AuthMethod: constants.AuthMethodOIDC}).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: top.ID, CspRoleID: adminCsp.ID, AuthMethod: constants.AuthMethodOIDC}).Error)
+	wsID := strconv.Itoa(int(ws.ID))
+
+	resp, err := svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, WorkspaceID: wsID, CspRoleID: strconv.Itoa(int(operatorCsp.ID))})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Grants, 1)
+	assert.Equal(t, middle.ID, resp.Grants[0].InheritedFromRoleID)
+
+	resp, err = svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, WorkspaceID: wsID, CspRoleID: strconv.Itoa(int(adminCsp.ID))})
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Empty(t, resp.Grants)
+	require.Len(t, resp.Blocks, 1)
+	assert.Equal(t, "cspRoleShadowed", resp.Blocks[0].Via)
+	assert.Equal(t, top.ID, resp.Blocks[0].InheritedFromRoleID)
+
+	_, err = svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, CspRoleID: strconv.Itoa(int(adminCsp.ID))})
+	assert.True(t, errors.Is(err, ErrInvalidWorkspaceID))
+}
+
+// TC-EXP-04: 대상은 정확히 하나만 지정
+func TestAuthzExplain_TargetRequired(t *testing.T) {
+	svc, db := newTestExplainService(t)
+	user := createGRTestUser(t, db, "exp-user-04", "kc-exp-04")
+
+	_, err := svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId})
+	assert.True(t, errors.Is(err, ErrInvalidExplainTarget))
+	_, err = svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: user.KcId, Permission: "a:b:c", MenuID: "m"})
+	assert.True(t, errors.Is(err, ErrInvalidExplainTarget))
+}
+
+// TC-EXP-05: 권한 — platformAdmin 은 거부 경로가 있어도 허용, 요청 토큰의 platformAdmin 은 source token 경로로 기록
+func TestAuthzExplain_PlatformAdmin(t *testing.T) {
+	svc, db := newTestExplainService(t)
+	admin := createGRTestUser(t, db, "exp-user-05", "kc-exp-05")
+	adminRole := createGRTestRole(t, db, platformAdminRoleName)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: admin.ID, RoleID: adminRole.ID}).Error)
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, adminRole.ID, "mc-iam-manager:user:delete")
+
+	resp, err := svc.Explain(context.Background(), &model.AuthzExplainRequest{KcUserID: admin.KcId, Permission: "mc-iam-manager:user:delete"})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Grants, 1)
+	assert.Equal(t, "platformAdmin", resp.Grants[0].Via)
+	assert.Equal(t, adminRole.ID, resp.Grants[0].RoleID)
+	assert.Len(t, resp.Blocks, 1)
+
+	tokenAdmin := createGRTestUser(t, db, "exp-user-05b", "kc-exp-05b")
+	ctx := WithPlatformAdminSubject(context.Background(), tokenAdmin.KcId)
+	resp, err = svc.Explain(ctx, &model.AuthzExplainRequest{KcUserID: tokenAdmin.KcId, Permission: "mc-iam-manager:user:delete"})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Grants, 1)
+	assert.Equal(t, "token", resp.Grants[0].Source)
+}
@@ -0,0 +1,286 @@
+package service
+
+// authz_service_test.go
+//
+// AuthzService 단위 테스트 (SQLite in-memory DB)
+// 직접 할당/그룹 상속 역할과 MciamRoleMciamPermission 매핑으로 권한을 판정하는지 검증한다.
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ── DB 헬퍼 ───────────────────────────────────────────────────────────────────
+
+func setupAuthzTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(
+		&model.Organization{},
+		&model.UserOrganization{},
+		&model.User{},
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.Workspace{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
+		&model.GroupPlatformRole{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&model.SodConstraint{},
+		&model.SodOverride{},
+	))
+	return db
+}
+
+func newTestAuthzService(t *testing.T) (*AuthzService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	return newTestAuthz(db), db
+}
+
+// newTestAuthz 캐시 없이 db 로 권한을 판정하는 AuthzService (다른 서비스 테스트의 권한 확인용)
+func newTestAuthz(db *gorm.DB) *AuthzService {
+	return &AuthzService{
+		db:                db,
+		authzRepo:         repository.NewAuthzRepository(db),
+		userRepo:          repository.NewUserRepository(db),
+		roleRepo:          repository.NewRoleRepository(db),
+		actionMappingRepo: repository.NewMcmpApiPermissionActionMappingRepository(db),
+	}
+}
+
+func grantAuthzTestPermission(t *testing.T, db *gorm.DB, roleType constants.IAMRoleType, roleID uint, permissionID string) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType:     roleType,
+		RoleID:       roleID,
+		PermissionID: permissionID,
+	}).Error)
+}
+
+// assignAuthzTestPlatformRole permissionIDs 를 부여한 플랫폼 역할 roleName 을 만들어 user 에게 할당
+func assignAuthzTestPlatformRole(t *testing.T, db *gorm.DB, user *model.User, roleName string, permissionIDs ...string) *model.RoleMaster {
+	t.Helper()
+	role := createGRTestRole(t, db, roleName)
+	for _, id := range permissionIDs {
+		grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, id)
+	}
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	return role
+}
+
+// assignAuthzTestWorkspaceRole permissionIDs 를 부여한 워크스페이스 역할 roleName 을 만들어 user 에게 workspaceID 에서 할당
+func assignAuthzTestWorkspaceRole(t *testing.T, db *gorm.DB, user *model.User, workspaceID uint, roleName string, permissionIDs ...string) *model.RoleMaster {
+	t.Helper()
+	role := createGRTestRole(t, db, roleName)
+	for _, id := range permissionIDs {
+		grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, role.ID, id)
+	}
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: workspaceID, RoleID: role.ID}).Error)
+	return role
+}
+
+// ── Check ─────────────────────────────────────────────────────────────────────
+
+// TC-AZ-CHK-01: 직접 할당된 플랫폼 역할의 권한 → 허용
+func TestAuthzCheck_DirectPlatformRole(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "authz-user-01", "kc-authz-01")
+	role := createGRTestRole(t, db, "authz-role-01")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:workspace:read")
+
+	resp, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID:    "kc-authz-01",
+		Permissions: []string{"mc-iam-manager:workspace:read", "mc-iam-manager:workspace:delete"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, resp.UserID)
+	assert.False(t, resp.Allowed)
+	require.Len(t, resp.Decisions, 2)
+	assert.True(t, resp.Decisions[0].Allowed)
+	require.Len(t, resp.Decisions[0].GrantedBy, 1)
+	assert.Equal(t, "direct", resp.Decisions[0].GrantedBy[0].Source)
+	assert.False(t, resp.Decisions[1].Allowed)
+}
+
+// TC-AZ-CHK-02: 그룹(조직)에서 상속된 플랫폼 역할의 권한 → 허용, 출처는 group:{name}
+func TestAuthzCheck_GroupPlatformRole(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "authz-user-02", "kc-authz-02")
+	role := createGRTestRole(t, db, "authz-role-02")
+	org := createGRTestOrg(t, db, "authz-group-02", "AZ02")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: org.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:user:read")
+
+	resp, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		UserID:      strconv.Itoa(int(user.ID)),
+		Permissions: []string{"mc-iam-manager:user:read"},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Decisions[0].GrantedBy, 1)
+	assert.Equal(t, "group:authz-group-02", resp.Decisions[0].GrantedBy[0].Source)
+}
+
+// TC-AZ-CHK-03: 워크스페이스 역할 권한은 해당 워크스페이스를 지정한 경우에만 허용
+func TestAuthzCheck_WorkspaceRoleScopedToWorkspace(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "authz-user-03", "kc-authz-03")
+	role := createGRTestRole(t, db, "authz-role-03")
+	ws := createGRTestWorkspace(t, db, "authz-ws-03")
+	other := createGRTestWorkspace(t, db, "authz-ws-03-other")
+	org := createGRTestOrg(t, db, "authz-group-03", "AZ03")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, role.ID, "mc-infra-manager:mci:create")
+
+	perm := []string{"mc-infra-manager:mci:create"}
+	inWs, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: "kc-authz-03", WorkspaceID: strconv.Itoa(int(ws.ID)), Permissions: perm})
+	require.NoError(t, err)
+	assert.True(t, inWs.Allowed)
+
+	otherWs, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: "kc-authz-03", WorkspaceID: strconv.Itoa(int(other.ID)), Permissions: perm})
+	require.NoError(t, err)
+	assert.False(t, otherWs.Allowed)
+
+	noWs, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: "kc-authz-03", Permissions: perm})
+	require.NoError(t, err)
+	assert.False(t, noWs.Allowed)
+}
+
+// TC-AZ-CHK-04: 입력 검증 실패 케이스
+func TestAuthzCheck_InvalidRequest(t *testing.T) {
+	svc, _ := newTestAuthzService(t)
+	ctx := context.Background()
+
+	_, err := svc.Check(ctx, &model.AuthzCheckRequest{Permissions: []string{"a:b:c"}})
+	assert.ErrorIs(t, err, ErrAuthzSubjectRequired)
+
+	_, err = svc.Check(ctx, &model.AuthzCheckRequest{KcUserID: "kc", Permissions: []string{"a:b"}})
+	assert.ErrorIs(t, err, ErrInvalidPermissionID)
+
+	_, err = svc.Check(ctx, &model.AuthzCheckRequest{KcUserID: "kc", WorkspaceID: "abc", Permissions: []string{"a:b:c"}})
+	assert.ErrorIs(t, err, ErrInvalidWorkspaceID)
+
+	_, err = svc.Check(ctx, &model.AuthzCheckRequest{KcUserID: "kc-missing", Permissions: []string{"a:b:c"}})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	_, err = svc.Check(ctx, &model.AuthzCheckRequest{UserID: "abc", Permissions: []string{"a:b:c"}})
+	assert.ErrorIs(t, err, ErrInvalidUserID)
+	assert.NotErrorIs(t, err, ErrUserNotFound)
+}
+
+// TC-AZ-CHK-05: platformAdmin(플랫폼 역할 또는 요청 토큰)은 라우트 권한 검사와 같이 매핑·거부와 관계없이 허용
+func TestAuthzCheck_PlatformAdmin(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	ctx := context.Background()
+	admin := createGRTestUser(t, db, "authz-admin", "kc-authz-admin")
+	role := assignAuthzTestPlatformRole(t, db, admin, platformAdminRoleName)
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:role:write")
+
+	resp, err := svc.Check(ctx, &model.AuthzCheckRequest{
+		KcUserID:    admin.KcId,
+		Permissions: []string{"mc-iam-manager:user:delete", "mc-iam-manager:role:write"},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	for _, d := range resp.Decisions {
+		assert.True(t, d.Allowed, d.Permission)
+		assert.True(t, d.PlatformAdmin, d.Permission)
+	}
+
+	// 토큰 realm role 은 요청자 본인을 판정할 때만 적용
+	tokenAdmin := createGRTestUser(t, db, "authz-token-admin", "kc-authz-token-admin")
+	other := createGRTestUser(t, db, "authz-other", "kc-authz-other")
+	tokenCtx := WithPlatformAdminSubject(ctx, tokenAdmin.KcId)
+	batch := svc.CheckBatch(tokenCtx, &model.AuthzBatchCheckRequest{Checks: []model.AuthzCheckRequest{
+		{KcUserID: tokenAdmin.KcId, Permissions: []string{"mc-iam-manager:user:delete"}},
+		{KcUserID: other.KcId, Permissions: []string{"mc-iam-manager:user:delete"}},
+	}})
+	require.Len(t, batch.Results, 2)
+	assert.True(t, batch.Results[0].Allowed)
+	assert.False(t, batch.Results[1].Allowed)
+}
+
+// TC-AZ-BAT-01: 일괄 확인 — 개별 오류는 결과에 기록되고 나머지는 계속 판정
+func TestAuthzCheckBatch_PartialError(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "authz-user-04", "kc-authz-04")
+	role := createGRTestRole(t, db, "authz-role-04")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:menu:read")
+
+	resp := svc.CheckBatch(context.Background(), &model.AuthzBatchCheckRequest{Checks: []model.AuthzCheckRequest{
+		{KcUserID: "kc-authz-04", Permissions: []string{"mc-iam-manager:menu:read"}},
+		{KcUserID: "kc-missing", Permissions: []string{"mc-iam-manager:menu:read"}},
+	}})
+
+	require.Len(t, resp.Results, 2)
+	assert.True(t, resp.Results[0].Allowed)
+	assert.Empty(t, resp.Results[0].Error)
+	assert.False(t, resp.Results[1].Allowed)
+	assert.NotEmpty(t, resp.Results[1].Error)
+}
+
+// TC-AZ-MP-01: MciamPermissionService.CheckPermission — 권한 없음 → ErrPermissionDenied
+func TestMciamPermissionCheckPermission(t *testing.T) {
+	_, db := newTestAuthzService(t)
+	permSvc := NewMciamPermissionService(db)
+	user := createGRTestUser(t, db, "authz-user-05", "kc-authz-05")
+	role := createGRTestRole(t, db, "authz-role-05")
+	ws := createGRTestWorkspace(t, db, "authz-ws-05")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, role.ID, "mc-iam-manager:project:read")
+
+	wsID := strconv.Itoa(int(ws.ID))
+	assert.NoError(t, permSvc.CheckPermission(context.Background(), user.ID, wsID, "mc-iam-manager:project:read"))
+	assert.ErrorIs(t, permSvc.CheckPermission(context.Background(), user.ID, wsID, "mc-iam-manager:project:delete"), ErrPermissionDenied)
+}
+
+// TC-AZ-WS-01: 워크스페이스 구성원 확인 — 그룹 상속 역할도 구성원으로 인정, 대표 역할은 직접 할당 우선
+func TestAuthzGetWorkspaceRoleGrants_Membership(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "authz-user-06", "kc-authz-06")
+	groupRole := createGRTestRole(t, db, "authz-role-06-group")
+	directRole := createGRTestRole(t, db, "authz-role-06-direct")
+	ws := createGRTestWorkspace(t, db, "authz-ws-06")
+	other := createGRTestWorkspace(t, db, "authz-ws-06-other")
+	org := createGRTestOrg(t, db, "authz-group-06", "AZ06")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: ws.ID, RoleID: groupRole.ID}).Error)
+
+	grants, err := svc.GetWorkspaceRoleGrants(context.Background(), user.ID, ws.ID)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, groupRole.ID, PrimaryRoleGrant(grants).RoleID)
+
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: directRole.ID}).Error)
+	grants, err = svc.GetWorkspaceRoleGrants(context.Background(), user.ID, ws.ID)
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, directRole.ID, PrimaryRoleGrant(grants).RoleID)
+
+	grants, err = svc.GetWorkspaceRoleGrants(context.Background(), user.ID, other.ID)
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+	assert.Nil(t, PrimaryRoleGrant(grants))
+}
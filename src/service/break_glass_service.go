@@ -0,0 +1,579 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/eventsink"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidBreakGlassRequest  = errors.New("invalid break-glass request")
+	ErrBreakGlassNotEligible     = errors.New("user is not registered for break-glass access")
+	ErrBreakGlassAccountExists   = errors.New("user is already registered for break-glass access")
+	ErrBreakGlassAlreadyActive   = errors.New("break-glass access is already active")
+	ErrBreakGlassAlreadyAdmin    = errors.New("user already holds the platformAdmin role")
+	ErrBreakGlassNotActive       = errors.New("break-glass session is not active")
+	ErrBreakGlassRoleUnavailable = errors.New("platformAdmin role is not registered")
+)
+
+// BreakGlassManagePermission 비상 접근 계정 등록/해제, 세션 조회·종료, 활동 기록 조회
+const BreakGlassManagePermission = "mc-iam-manager:break-glass:manage"
+
+const (
+	defaultBreakGlassMinutes        = 30
+	defaultBreakGlassMaxMinutes     = 120
+	defaultBreakGlassExpiryInterval = 30 * time.Second
+	breakGlassNotifyTimeout         = 10 * time.Second
+	breakGlassSystemActor           = "system"
+)
+
+// BreakGlassNotifier 비상 접근 활성화/종료 알림 전달
+type BreakGlassNotifier interface {
+	Notify(ctx context.Context, notice *model.BreakGlassNotice) error
+}
+
+// BreakGlassService 비상 접근(break-glass) 계정 등록, 활성화/종료, 활동 기록
+// 활성화하면 만료 시각이 지정된 platformAdmin 할당과 Keycloak realm role 을 부여하고 다른 플랫폼 관리자에게 알린다.
+// 유효한 동안의 모든 요청은 BreakGlassMiddleware 가 별도 해시 체인(break_glass_activities)에 기록하고, 만료되면 자동으로 회수한다.
+type BreakGlassService struct {
+	breakGlassRepo *repository.BreakGlassRepository
+	chainRepo      *repository.AuditChainRepository
+	roleRepo       *repository.RoleRepository
+	userRepo       *repository.UserRepository
+	authzService   *AuthzService
+	kcService      KeycloakService
+	auditService   *AuditService      // nil 이면 활성화/종료 감사 기록하지 않음
+	notifier       BreakGlassNotifier // nil 이면 감사/보안 이벤트 외 알림 없음
+	policy         model.BreakGlassPolicy
+}
+
+// NewBreakGlassService BreakGlassService 생성 (기간 정책과 알림 웹훅은 환경 변수에서 읽음)
+func NewBreakGlassService(db *gorm.DB) *BreakGlassService {
+	s := &BreakGlassService{
+		breakGlassRepo: repository.NewBreakGlassRepository(db),
+		chainRepo:      repository.NewAuditChainRepository(db),
+		roleRepo:       repository.NewRoleRepository(db),
+		userRepo:       repository.NewUserRepository(db),
+		authzService:   NewAuthzService(db),
+		kcService:      NewKeycloakService(),
+		auditService:   NewAuditService(db),
+		policy:         loadBreakGlassPolicy(),
+	}
+	if url := os.Getenv("MC_IAM_MANAGER_BREAK_GLASS_WEBHOOK_URL"); url != "" {
+		s.notifier = &webhookBreakGlassNotifier{url: url, client: &http.Client{Timeout: breakGlassNotifyTimeout}}
+	}
+	return s
+}
+
+// Policy 적용 중인 비상 접근 기간 정책
+func (s *BreakGlassService) Policy() model.BreakGlassPolicy {
+	return s.policy
+}
+
+// ResolveUser 요청자의 Keycloak ID 로 DB 사용자 조회
+func (s *BreakGlassService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// ── 계정 등록 ────────────────────────────────────────────────────────────────
+
+// RegisterAccount 비상 접근 계정 등록
+// emergency 계정은 평소 쓰지 않으므로 휴면 정책 예외(BREAK_GLASS)로 지정한다.
+func (s *BreakGlassService) RegisterAccount(req *model.RegisterBreakGlassAccountRequest, actor *model.User) (*model.BreakGlassAccount, error) {
+	kind := req.Kind
+	if kind == "" {
+		kind = model.BreakGlassKindElevation
+	}
+	if kind != model.BreakGlassKindEmergency && kind != model.BreakGlassKindElevation {
+		return nil, fmt.Errorf("%w: kind must be emergency or elevation: %s", ErrInvalidBreakGlassRequest, kind)
+	}
+	if req.UserID == 0 {
+		return nil, fmt.Errorf("%w: userId is required", ErrInvalidBreakGlassRequest)
+	}
+	user, err := s.userRepo.FindUserByID(req.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: user %d not found", ErrInvalidBreakGlassRequest, req.UserID)
+		}
+		return nil, err
+	}
+	existing, err := s.breakGlassRepo.FindAccountByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrBreakGlassAccountExists
+	}
+
+	account := &model.BreakGlassAccount{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Kind:        kind,
+		Description: strings.TrimSpace(req.Description),
+		Enabled:     true,
+	}
+	if actor != nil {
+		account.RegisteredBy = actor.Username
+	}
+	if err := s.breakGlassRepo.CreateAccount(account); err != nil {
+		return nil, err
+	}
+	if kind == model.BreakGlassKindEmergency && user.DormancyExemption == "" {
+		if err := s.userRepo.UpdateDormancyExemption(user.ID, model.DormancyExemptionBreakGlass); err != nil {
+			log.Printf("[BREAK_GLASS] failed to exempt emergency account %s from dormancy policy: %v", user.Username, err)
+		}
+	}
+	return account, nil
+}
+
+// ListAccounts 비상 접근 계정 목록
+func (s *BreakGlassService) ListAccounts() ([]model.BreakGlassAccount, error) {
+	return s.breakGlassRepo.ListAccounts()
+}
+
+// RemoveAccount 비상 접근 계정 등록 해제 (유효한 세션이 있으면 먼저 종료해야 함)
+func (s *BreakGlassService) RemoveAccount(userID uint) (*model.BreakGlassAccount, error) {
+	account, err := s.breakGlassRepo.FindAccountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, repository.ErrBreakGlassAccountNotFound
+	}
+	active, err := s.breakGlassRepo.FindActiveSession(userID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, ErrBreakGlassAlreadyActive
+	}
+	if err := s.breakGlassRepo.DeleteAccount(userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ── 활성화/종료 ──────────────────────────────────────────────────────────────
+
+// Activate 비상 접근 활성화 (사유 필수, 기간은 정책 범위 안)
+// 등록된 계정만 활성화할 수 있고, 이미 platformAdmin 을 가진 사용자는 대상이 아니다.
+// 직무 분리 제약은 비상 접근을 막지 않도록 적용하지 않으며, 대신 모든 활동을 별도 체인에 기록한다.
+func (s *BreakGlassService) Activate(ctx context.Context, req *model.ActivateBreakGlassRequest, user *model.User, sourceIP string) (*model.BreakGlassSession, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidBreakGlassRequest)
+	}
+	duration := req.DurationMinutes
+	if duration == 0 {
+		duration = s.policy.DefaultMinutes
+	}
+	if duration < 0 || duration > s.policy.MaxMinutes {
+		return nil, fmt.Errorf("%w: durationMinutes must be between 1 and %d", ErrInvalidBreakGlassRequest, s.policy.MaxMinutes)
+	}
+
+	account, err := s.breakGlassRepo.FindAccountByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.Enabled {
+		return nil, ErrBreakGlassNotEligible
+	}
+	active, err := s.breakGlassRepo.FindActiveSession(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, ErrBreakGlassAlreadyActive
+	}
+	role, err := s.roleRepo.FindRoleByRoleName(model.BreakGlassRoleName, constants.RoleTypePlatform)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrBreakGlassRoleUnavailable
+	}
+	held, err := s.breakGlassRepo.HasPlatformRole(user.ID, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	if held {
+		return nil, ErrBreakGlassAlreadyAdmin
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(duration) * time.Minute)
+	if err := s.roleRepo.AssignPlatformRoleWithValidity(user.ID, role.ID, model.AssignmentValidity{ExpiresAt: &expiresAt}); err != nil {
+		return nil, fmt.Errorf("failed to assign break-glass platform role: %w", err)
+	}
+	if err := s.grantRealmRole(ctx, user.KcId); err != nil {
+		if delErr := s.breakGlassRepo.DeleteTimedPlatformRole(user.ID, role.ID); delErr != nil {
+			log.Printf("[BREAK_GLASS] user %s: failed to roll back platform role: %v", user.Username, delErr)
+		}
+		return nil, err
+	}
+
+	session := &model.BreakGlassSession{
+		AccountID:       account.ID,
+		UserID:          user.ID,
+		KcUserID:        user.KcId,
+		Username:        user.Username,
+		RoleID:          role.ID,
+		Reason:          reason,
+		DurationMinutes: duration,
+		Status:          model.BreakGlassActive,
+		SourceIP:        sourceIP,
+		ActivatedAt:     now,
+		ExpiresAt:       expiresAt,
+	}
+	if err := s.breakGlassRepo.CreateSession(session); err != nil {
+		s.revokeGrant(ctx, user.ID, user.KcId, role.ID)
+		return nil, err
+	}
+
+	recipients, notifyErr := s.notifyAdmins(ctx, model.BreakGlassNoticeActivated, session, 0)
+	session.NotifiedAdmins = recipients
+	updates := map[string]interface{}{"notified_admins": session.NotifiedAdmins}
+	if notifyErr != nil {
+		log.Printf("[BREAK_GLASS] session %d: failed to notify platform admins: %v", session.ID, notifyErr)
+		session.NotificationError = notifyErr.Error()
+		updates["notification_error"] = session.NotificationError
+	}
+	if err := s.breakGlassRepo.UpdateSession(session.ID, updates); err != nil {
+		log.Printf("[BREAK_GLASS] session %d: %v", session.ID, err)
+	}
+	return session, nil
+}
+
+// EndMine 본인의 유효한 비상 접근 반납
+func (s *BreakGlassService) EndMine(ctx context.Context, req *model.EndBreakGlassRequest, user *model.User) (*model.BreakGlassSession, error) {
+	session, err := s.breakGlassRepo.FindActiveSession(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrBreakGlassNotActive
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "relinquished by holder"
+	}
+	return s.end(ctx, session, model.BreakGlassEnded, user.Username, reason, time.Now())
+}
+
+// End 유효한 비상 접근을 만료 전에 종료 (관리자)
+func (s *BreakGlassService) End(ctx context.Context, id uint, req *model.EndBreakGlassRequest, actor *model.User) (*model.BreakGlassSession, error) {
+	session, err := s.breakGlassRepo.FindSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != model.BreakGlassActive {
+		return nil, ErrBreakGlassNotActive
+	}
+	return s.end(ctx, session, model.BreakGlassEnded, actor.Username, strings.TrimSpace(req.Reason), time.Now())
+}
+
+// end Keycloak realm role 과 기한부 할당을 회수하고 세션을 status(ended/expired)로 닫은 뒤 종료 알림
+func (s *BreakGlassService) end(ctx context.Context, session *model.BreakGlassSession, status, actorUsername, reason string, now time.Time) (*model.BreakGlassSession, error) {
+	if err := s.removeRealmRole(ctx, session.KcUserID); err != nil {
+		return nil, err
+	}
+	if err := s.breakGlassRepo.DeleteTimedPlatformRole(session.UserID, session.RoleID); err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{
+		"status":            status,
+		"ended_at":          now,
+		"ended_by_username": actorUsername,
+		"end_reason":        reason,
+	}
+	ok, err := s.breakGlassRepo.Transition(session.ID, model.BreakGlassActive, updates)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrBreakGlassNotActive
+	}
+	ended, err := s.breakGlassRepo.FindSessionByID(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := s.breakGlassRepo.CountActivities(session.ID)
+	if err != nil {
+		log.Printf("[BREAK_GLASS] session %d: %v", session.ID, err)
+	}
+	if _, err := s.notifyAdmins(ctx, model.BreakGlassNoticeEnded, ended, activities); err != nil {
+		log.Printf("[BREAK_GLASS] session %d: failed to notify platform admins of end: %v", session.ID, err)
+	}
+	return ended, nil
+}
+
+// ExpireDue 만료 시각이 지난 비상 접근 회수 (회수마다 감사 이벤트 기록)
+// 기한부 역할 할당 만료 처리가 할당을 먼저 삭제했을 수 있으므로 할당 삭제는 없어도 성공으로 본다.
+func (s *BreakGlassService) ExpireDue(ctx context.Context, now time.Time) ([]model.BreakGlassSession, error) {
+	due, err := s.breakGlassRepo.ListDueExpirations(now)
+	if err != nil {
+		return nil, err
+	}
+	var expired []model.BreakGlassSession
+	for i := range due {
+		result, err := s.end(ctx, &due[i], model.BreakGlassExpired, breakGlassSystemActor, "duration elapsed", now)
+		if err != nil {
+			if errors.Is(err, ErrBreakGlassNotActive) {
+				continue
+			}
+			log.Printf("[BREAK_GLASS] session %d: failed to expire: %v", due[i].ID, err)
+			continue
+		}
+		expired = append(expired, *result)
+		s.recordExpiry(result)
+	}
+	return expired, nil
+}
+
+// recordExpiry 자동 만료 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록)
+func (s *BreakGlassService) recordExpiry(session *model.BreakGlassSession) {
+	if s.auditService == nil {
+		return
+	}
+	event := &model.AuditEvent{
+		ActorUsername: breakGlassSystemActor,
+		Method:        "SYSTEM",
+		Route:         "break-glass.expiry",
+		Path:          fmt.Sprintf("/api/break-glass/sessions/%d", session.ID),
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:     model.AuditActionBreakGlassExpire,
+		EntityType: model.AuditEntityBreakGlass,
+		EntityID:   fmt.Sprint(session.ID),
+		After:      session,
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[BREAK_GLASS] failed to record audit event for session %d: %v", session.ID, err)
+	}
+}
+
+// StartExpiry 주기적으로 만료된 비상 접근 회수
+// 주기는 MC_IAM_MANAGER_BREAK_GLASS_EXPIRY_INTERVAL (기본 30s)
+func (s *BreakGlassService) StartExpiry(ctx context.Context) {
+	interval := dormancyEnvDuration("MC_IAM_MANAGER_BREAK_GLASS_EXPIRY_INTERVAL", defaultBreakGlassExpiryInterval)
+	log.Printf("[BREAK_GLASS] duration default=%dm max=%dm, checking expirations every %s",
+		s.policy.DefaultMinutes, s.policy.MaxMinutes, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := s.ExpireDue(ctx, time.Now())
+				if err != nil {
+					log.Printf("[BREAK_GLASS] failed to expire sessions: %v", err)
+				}
+				for _, session := range expired {
+					log.Printf("[BREAK_GLASS] session %d expired: %s", session.ID, session.Username)
+				}
+			}
+		}
+	}()
+}
+
+// ── 조회 ─────────────────────────────────────────────────────────────────────
+
+// ListSessions 비상 접근 세션 목록 (관리자 조회)
+func (s *BreakGlassService) ListSessions(filter model.BreakGlassSessionFilter) ([]model.BreakGlassSession, error) {
+	return s.breakGlassRepo.ListSessions(filter)
+}
+
+// GetSession 비상 접근 세션 조회
+func (s *BreakGlassService) GetSession(id uint) (*model.BreakGlassSession, error) {
+	return s.breakGlassRepo.FindSessionByID(id)
+}
+
+// GetMine 본인의 유효한 비상 접근 세션 (없으면 nil)
+func (s *BreakGlassService) GetMine(user *model.User) (*model.BreakGlassSession, error) {
+	return s.breakGlassRepo.FindActiveSession(user.ID)
+}
+
+// ListActivities 세션 중 기록된 요청 (오래된 순)
+func (s *BreakGlassService) ListActivities(id uint) ([]model.BreakGlassActivity, error) {
+	if _, err := s.breakGlassRepo.FindSessionByID(id); err != nil {
+		return nil, err
+	}
+	return s.breakGlassRepo.ListActivities(id)
+}
+
+// ── 요청 처리 (BreakGlassMiddleware) ─────────────────────────────────────────
+
+// SessionForToken 토큰 발급 시각 이후에 영향을 주는 세션 (유효하거나 토큰 발급 후 종료된 세션, 없으면 nil)
+// 종료된 세션이 반환되면 그 토큰에 실린 platformAdmin 은 비상 접근으로 받은 것이므로 무시해야 한다.
+func (s *BreakGlassService) SessionForToken(kcUserID string, issuedAt time.Time) (*model.BreakGlassSession, error) {
+	return s.breakGlassRepo.FindLatestSessionSince(kcUserID, issuedAt)
+}
+
+// RecordActivity 비상 접근 중 요청을 별도 해시 체인에 추가하고 보안 이벤트 싱크로 전달
+func (s *BreakGlassService) RecordActivity(activity *model.BreakGlassActivity) error {
+	if activity.OccurredAt.IsZero() {
+		activity.OccurredAt = time.Now()
+	}
+	err := s.chainRepo.Append(model.AuditChainBreakGlass, activity, func(prevHash string) {
+		sealBreakGlassActivity(activity, prevHash)
+	})
+	if err != nil {
+		return err
+	}
+	eventsink.Publish(securityEventFromBreakGlassActivity(activity))
+	return nil
+}
+
+// ── Keycloak / 알림 ──────────────────────────────────────────────────────────
+
+// grantRealmRole Keycloak platformAdmin realm role 부여 (다음 토큰부터 반영, 그 전까지는 미들웨어가 보완)
+func (s *BreakGlassService) grantRealmRole(ctx context.Context, kcUserID string) error {
+	exists, err := s.kcService.CheckRealmRoleExists(ctx, model.BreakGlassRoleName)
+	if err != nil {
+		return fmt.Errorf("failed to check keycloak realm role: %w", err)
+	}
+	if !exists {
+		if err := s.kcService.CreateRealmRoleAndWait(ctx, model.BreakGlassRoleName); err != nil {
+			return fmt.Errorf("failed to create keycloak realm role: %w", err)
+		}
+	}
+	if err := s.kcService.AssignRealmRoleToUser(ctx, kcUserID, model.BreakGlassRoleName); err != nil {
+		return fmt.Errorf("failed to assign keycloak realm role: %w", err)
+	}
+	return nil
+}
+
+// removeRealmRole Keycloak platformAdmin realm role 회수 (사용자가 없으면 성공으로 봄)
+func (s *BreakGlassService) removeRealmRole(ctx context.Context, kcUserID string) error {
+	if err := s.kcService.RemoveRealmRoleFromUser(ctx, kcUserID, model.BreakGlassRoleName); err != nil {
+		if !strings.Contains(err.Error(), "404") && !strings.Contains(err.Error(), "User not found") {
+			return fmt.Errorf("failed to remove keycloak realm role: %w", err)
+		}
+		log.Printf("[BREAK_GLASS] KC user not found (%s), skipping KC removal", kcUserID)
+	}
+	return nil
+}
+
+// revokeGrant 활성화 도중 실패 시 부여한 역할 되돌리기
+func (s *BreakGlassService) revokeGrant(ctx context.Context, userID uint, kcUserID string, roleID uint) {
+	if err := s.removeRealmRole(ctx, kcUserID); err != nil {
+		log.Printf("[BREAK_GLASS] user %d: failed to roll back keycloak realm role: %v", userID, err)
+	}
+	if err := s.breakGlassRepo.DeleteTimedPlatformRole(userID, roleID); err != nil {
+		log.Printf("[BREAK_GLASS] user %d: failed to roll back platform role: %v", userID, err)
+	}
+}
+
+// notifyAdmins 세션 보유자를 제외한 플랫폼 관리자에게 높은 우선순위 알림, 알림 대상 사용자 이름 반환
+// 웹훅이 설정되지 않았어도 대상은 계산해 세션에 남긴다 (감사/보안 이벤트는 별도로 전달됨).
+func (s *BreakGlassService) notifyAdmins(ctx context.Context, noticeType string, session *model.BreakGlassSession, activities int64) ([]string, error) {
+	holders, err := s.breakGlassRepo.FindPlatformRoleHolders(session.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	notice := &model.BreakGlassNotice{
+		Type:        noticeType,
+		Priority:    model.BreakGlassNoticePriority,
+		SessionID:   session.ID,
+		Username:    session.Username,
+		Reason:      session.Reason,
+		SourceIP:    session.SourceIP,
+		ActivatedAt: session.ActivatedAt,
+		ExpiresAt:   session.ExpiresAt,
+		EndedAt:     session.EndedAt,
+		EndReason:   session.EndReason,
+		Activities:  activities,
+		Recipients:  []model.BreakGlassRecipient{},
+	}
+	names := []string{}
+	for _, holder := range holders {
+		if holder.ID == session.UserID {
+			continue
+		}
+		recipient := model.BreakGlassRecipient{UserID: holder.ID, Username: holder.Username}
+		if s.notifier != nil {
+			if kcUser, err := s.kcService.GetUser(ctx, holder.KcId); err == nil && kcUser != nil {
+				recipient.Email = ptrStr(kcUser.Email)
+			}
+		}
+		notice.Recipients = append(notice.Recipients, recipient)
+		names = append(names, holder.Username)
+	}
+	if s.notifier == nil {
+		return names, nil
+	}
+	return names, s.notifier.Notify(ctx, notice)
+}
+
+// webhookBreakGlassNotifier 알림을 JSON 으로 웹훅에 POST (메일/메신저/호출 연동은 수신 측에서 처리)
+type webhookBreakGlassNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify 웹훅 호출 (2xx 가 아니면 오류)
+func (n *webhookBreakGlassNotifier) Notify(ctx context.Context, notice *model.BreakGlassNotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Priority", notice.Priority)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("break-glass webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("break-glass webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// loadBreakGlassPolicy 비상 접근 기간 정책 환경 변수 (MC_IAM_MANAGER_BREAK_GLASS_DEFAULT_MINUTES 기본 30, MC_IAM_MANAGER_BREAK_GLASS_MAX_MINUTES 기본 120)
+func loadBreakGlassPolicy() model.BreakGlassPolicy {
+	policy := model.BreakGlassPolicy{
+		DefaultMinutes: breakGlassEnvMinutes("MC_IAM_MANAGER_BREAK_GLASS_DEFAULT_MINUTES", defaultBreakGlassMinutes),
+		MaxMinutes:     breakGlassEnvMinutes("MC_IAM_MANAGER_BREAK_GLASS_MAX_MINUTES", defaultBreakGlassMaxMinutes),
+	}
+	if policy.DefaultMinutes > policy.MaxMinutes {
+		log.Printf("[BREAK_GLASS] MC_IAM_MANAGER_BREAK_GLASS_DEFAULT_MINUTES (%d) exceeds MC_IAM_MANAGER_BREAK_GLASS_MAX_MINUTES (%d), using the maximum",
+			policy.DefaultMinutes, policy.MaxMinutes)
+		policy.DefaultMinutes = policy.MaxMinutes
+	}
+	return policy
+}
+
+func breakGlassEnvMinutes(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	minutes, err := strconv.Atoi(raw)
+	if err != nil || minutes <= 0 {
+		log.Printf("[BREAK_GLASS] invalid %s %q, using %d", key, raw, fallback)
+		return fallback
+	}
+	return minutes
+}
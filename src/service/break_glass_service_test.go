@@ -0,0 +1,312 @@
+package service
+
+// break_glass_service_test.go
+//
+// BreakGlassService 단위 테스트 (SQLite in-memory DB)
+// 계정 등록, 활성화 조건(사유/등록/중복/기존 관리자), platformAdmin 기한부 부여와 Keycloak 부여,
+// 다른 플랫폼 관리자 알림, 반납/자동 만료 회수, 토큰별 세션 판정, 활동 기록 체인을 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+// fakeBreakGlassNotifier 알림 내용을 기록
+type fakeBreakGlassNotifier struct {
+	notices []model.BreakGlassNotice
+	err     error
+}
+
+func (n *fakeBreakGlassNotifier) Notify(ctx context.Context, notice *model.BreakGlassNotice) error {
+	n.notices = append(n.notices, *notice)
+	return n.err
+}
+
+func newTestBreakGlassService(t *testing.T) (*BreakGlassService, *gorm.DB, *recordingKeycloak, *fakeBreakGlassNotifier) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.BreakGlassAccount{},
+		&model.BreakGlassSession{},
+		&model.BreakGlassActivity{},
+	))
+	kc := &recordingKeycloak{}
+	notifier := &fakeBreakGlassNotifier{}
+	svc := &BreakGlassService{
+		breakGlassRepo: repository.NewBreakGlassRepository(db),
+		chainRepo:      repository.NewAuditChainRepository(db),
+		roleRepo:       repository.NewRoleRepository(db),
+		userRepo:       repository.NewUserRepository(db),
+		kcService:      kc,
+		notifier:       notifier,
+		policy:         model.BreakGlassPolicy{DefaultMinutes: 30, MaxMinutes: 60},
+	}
+	return svc, db, kc, notifier
+}
+
+// breakGlassFixture platformAdmin 역할, 비상 계정 사용자, 기존 플랫폼 관리자 두 명
+type breakGlassFixture struct {
+	adminRole *model.RoleMaster
+	operator  *model.User
+	admins    []*model.User
+}
+
+func setupBreakGlassFixture(t *testing.T, svc *BreakGlassService, db *gorm.DB) *breakGlassFixture {
+	t.Helper()
+	f := &breakGlassFixture{
+		adminRole: createGRTestRole(t, db, model.BreakGlassRoleName),
+		operator:  createGRTestUser(t, db, "bg-oncall", "kc-bg-oncall"),
+	}
+	for _, name := range []string{"bg-admin-1", "bg-admin-2"} {
+		admin := createGRTestUser(t, db, name, "kc-"+name)
+		require.NoError(t, db.Create(&model.UserPlatformRole{UserID: admin.ID, RoleID: f.adminRole.ID}).Error)
+		f.admins = append(f.admins, admin)
+	}
+	_, err := svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: f.operator.ID}, f.admins[0])
+	require.NoError(t, err)
+	return f
+}
+
+func activateTestBreakGlass(t *testing.T, svc *BreakGlassService, user *model.User) *model.BreakGlassSession {
+	t.Helper()
+	session, err := svc.Activate(context.Background(), &model.ActivateBreakGlassRequest{Reason: "INC-42 keycloak outage"}, user, "203.0.113.7")
+	require.NoError(t, err)
+	return session
+}
+
+func findBreakGlassGrant(t *testing.T, db *gorm.DB, userID, roleID uint) *model.UserPlatformRole {
+	t.Helper()
+	var rows []model.UserPlatformRole
+	require.NoError(t, db.Where("user_id = ? AND role_id = ?", userID, roleID).Find(&rows).Error)
+	if len(rows) == 0 {
+		return nil
+	}
+	return &rows[0]
+}
+
+// ── 계정 등록 ─────────────────────────────────────────────────────────────────
+
+// TC-BG-ACCOUNT-01: 기본 종류 elevation, emergency 는 휴면 예외 지정, 중복 등록 → ErrBreakGlassAccountExists
+func TestBreakGlassRegisterAccount(t *testing.T) {
+	svc, db, _, _ := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+
+	accounts, err := svc.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, model.BreakGlassKindElevation, accounts[0].Kind)
+	assert.Equal(t, "bg-admin-1", accounts[0].RegisteredBy)
+
+	_, err = svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: f.operator.ID}, f.admins[0])
+	assert.True(t, errors.Is(err, ErrBreakGlassAccountExists))
+
+	emergency := createGRTestUser(t, db, "bg-emergency", "kc-bg-emergency")
+	account, err := svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: emergency.ID, Kind: model.BreakGlassKindEmergency}, f.admins[0])
+	require.NoError(t, err)
+	assert.Equal(t, model.BreakGlassKindEmergency, account.Kind)
+	reloaded, err := repository.NewUserRepository(db).FindUserByID(emergency.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DormancyExemptionBreakGlass, reloaded.DormancyExemption)
+
+	_, err = svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: emergency.ID, Kind: "root"}, f.admins[0])
+	assert.True(t, errors.Is(err, ErrInvalidBreakGlassRequest))
+	_, err = svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: 9999}, f.admins[0])
+	assert.True(t, errors.Is(err, ErrInvalidBreakGlassRequest))
+}
+
+// ── 활성화 ────────────────────────────────────────────────────────────────────
+
+// TC-BG-ACTIVATE-01: 사유 필수, 미등록 계정 거부, 기간 상한, 이미 platformAdmin 이면 거부
+func TestBreakGlassActivate_Validation(t *testing.T) {
+	svc, db, _, _ := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+	ctx := context.Background()
+
+	_, err := svc.Activate(ctx, &model.ActivateBreakGlassRequest{Reason: "  "}, f.operator, "")
+	assert.True(t, errors.Is(err, ErrInvalidBreakGlassRequest))
+	_, err = svc.Activate(ctx, &model.ActivateBreakGlassRequest{Reason: "outage", DurationMinutes: 61}, f.operator, "")
+	assert.True(t, errors.Is(err, ErrInvalidBreakGlassRequest))
+
+	outsider := createGRTestUser(t, db, "bg-outsider", "kc-bg-outsider")
+	_, err = svc.Activate(ctx, &model.ActivateBreakGlassRequest{Reason: "outage"}, outsider, "")
+	assert.True(t, errors.Is(err, ErrBreakGlassNotEligible))
+
+	_, err = svc.RegisterAccount(&model.RegisterBreakGlassAccountRequest{UserID: f.admins[1].ID}, f.admins[0])
+	require.NoError(t, err)
+	_, err = svc.Activate(ctx, &model.ActivateBreakGlassRequest{Reason: "outage"}, f.admins[1], "")
+	assert.True(t, errors.Is(err, ErrBreakGlassAlreadyAdmin))
+
+	require.NoError(t, db.Model(&model.BreakGlassAccount{}).Where("user_id = ?", f.operator.ID).Update("enabled", false).Error)
+	_, err = svc.Activate(ctx, &model.ActivateBreakGlassRequest{Reason: "outage"}, f.operator, "")
+	assert.True(t, errors.Is(err, ErrBreakGlassNotEligible), "비활성 계정")
+}
+
+// TC-BG-ACTIVATE-02: 활성화 → 기한부 platformAdmin + Keycloak 부여, 다른 관리자에게 high 알림, 중복 활성화 거부
+func TestBreakGlassActivate_GrantsAndNotifies(t *testing.T) {
+	svc, db, kc, notifier := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+
+	before := time.Now()
+	session := activateTestBreakGlass(t, svc, f.operator)
+	assert.Equal(t, model.BreakGlassActive, session.Status)
+	assert.Equal(t, 30, session.DurationMinutes, "정책 기본값")
+	assert.Equal(t, "203.0.113.7", session.SourceIP)
+	assert.WithinDuration(t, before.Add(30*time.Minute), session.ExpiresAt, 5*time.Second)
+
+	grant := findBreakGlassGrant(t, db, f.operator.ID, f.adminRole.ID)
+	require.NotNil(t, grant)
+	require.NotNil(t, grant.ExpiresAt)
+	assert.WithinDuration(t, session.ExpiresAt, *grant.ExpiresAt, time.Second)
+	assert.Equal(t, []string{"assign-user kc-bg-oncall platformAdmin"}, kc.calls)
+
+	require.Len(t, notifier.notices, 1)
+	notice := notifier.notices[0]
+	assert.Equal(t, model.BreakGlassNoticeActivated, notice.Type)
+	assert.Equal(t, model.BreakGlassNoticePriority, notice.Priority)
+	assert.Equal(t, "INC-42 keycloak outage", notice.Reason)
+	require.Len(t, notice.Recipients, 2)
+	assert.Equal(t, "bg-admin-1", notice.Recipients[0].Username)
+
+	stored, err := svc.GetSession(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bg-admin-1", "bg-admin-2"}, []string(stored.NotifiedAdmins))
+	assert.Empty(t, stored.NotificationError)
+
+	_, err = svc.Activate(context.Background(), &model.ActivateBreakGlassRequest{Reason: "again"}, f.operator, "")
+	assert.True(t, errors.Is(err, ErrBreakGlassAlreadyActive))
+
+	_, err = svc.RemoveAccount(f.operator.ID)
+	assert.True(t, errors.Is(err, ErrBreakGlassAlreadyActive), "유효한 세션이 있으면 등록 해제 불가")
+}
+
+// TC-BG-ACTIVATE-03: 알림 실패는 활성화를 막지 않고 세션에 기록
+func TestBreakGlassActivate_NotificationFailureRecorded(t *testing.T) {
+	svc, db, _, notifier := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+	notifier.err = errors.New("webhook returned 503")
+
+	session := activateTestBreakGlass(t, svc, f.operator)
+	assert.Equal(t, "webhook returned 503", session.NotificationError)
+	stored, err := svc.GetSession(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "webhook returned 503", stored.NotificationError)
+	assert.NotNil(t, findBreakGlassGrant(t, db, f.operator.ID, f.adminRole.ID))
+}
+
+// ── 종료/만료 ─────────────────────────────────────────────────────────────────
+
+// TC-BG-END-01: 반납 → 할당/Keycloak 회수, 종료 알림에 활동 수, 다시 반납하면 ErrBreakGlassNotActive
+func TestBreakGlassEndMine(t *testing.T) {
+	svc, db, kc, notifier := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+	session := activateTestBreakGlass(t, svc, f.operator)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, svc.RecordActivity(&model.BreakGlassActivity{
+			SessionID: session.ID, ActorKcID: f.operator.KcId, Method: "PUT", Route: "/api/users/id/:userId", StatusCode: 200,
+		}))
+	}
+
+	ended, err := svc.EndMine(context.Background(), &model.EndBreakGlassRequest{}, f.operator)
+	require.NoError(t, err)
+	assert.Equal(t, model.BreakGlassEnded, ended.Status)
+	assert.Equal(t, "bg-oncall", ended.EndedByUsername)
+	assert.Equal(t, "relinquished by holder", ended.EndReason)
+	require.NotNil(t, ended.EndedAt)
+	assert.Nil(t, findBreakGlassGrant(t, db, f.operator.ID, f.adminRole.ID))
+	assert.Equal(t, "remove-user kc-bg-oncall platformAdmin", kc.calls[len(kc.calls)-1])
+
+	require.Len(t, notifier.notices, 2)
+	assert.Equal(t, model.BreakGlassNoticeEnded, notifier.notices[1].Type)
+	assert.Equal(t, int64(2), notifier.notices[1].Activities)
+
+	_, err = svc.EndMine(context.Background(), &model.EndBreakGlassRequest{}, f.operator)
+	assert.True(t, errors.Is(err, ErrBreakGlassNotActive))
+	_, err = svc.End(context.Background(), session.ID, &model.EndBreakGlassRequest{}, f.admins[0])
+	assert.True(t, errors.Is(err, ErrBreakGlassNotActive))
+}
+
+// TC-BG-EXPIRE-01: 만료 시각이 지난 세션만 expired 로 회수, 기한부 할당이 먼저 삭제되어 있어도 성공
+func TestBreakGlassExpireDue(t *testing.T) {
+	svc, db, kc, _ := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+	session := activateTestBreakGlass(t, svc, f.operator)
+
+	expired, err := svc.ExpireDue(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, expired, "아직 유효")
+
+	require.NoError(t, db.Where("user_id = ?", f.operator.ID).Delete(&model.UserPlatformRole{}).Error)
+	expired, err = svc.ExpireDue(context.Background(), session.ExpiresAt.Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, model.BreakGlassExpired, expired[0].Status)
+	assert.Equal(t, breakGlassSystemActor, expired[0].EndedByUsername)
+	assert.Equal(t, "remove-user kc-bg-oncall platformAdmin", kc.calls[len(kc.calls)-1])
+
+	for _, admin := range f.admins {
+		assert.NotNil(t, findBreakGlassGrant(t, db, admin.ID, f.adminRole.ID), "상시 platformAdmin 은 유지")
+	}
+}
+
+// ── 요청 처리 ─────────────────────────────────────────────────────────────────
+
+// TC-BG-TOKEN-01: 유효한 세션은 모든 토큰에, 종료된 세션은 종료 전에 발급된 토큰에만 적용
+func TestBreakGlassSessionForToken(t *testing.T) {
+	svc, db, _, _ := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+
+	session, err := svc.SessionForToken(f.operator.KcId, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	active := activateTestBreakGlass(t, svc, f.operator)
+	session, err = svc.SessionForToken(f.operator.KcId, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, active.ID, session.ID)
+
+	issuedDuringSession := time.Now()
+	_, err = svc.End(context.Background(), active.ID, &model.EndBreakGlassRequest{Reason: "resolved"}, f.admins[0])
+	require.NoError(t, err)
+	session, err = svc.SessionForToken(f.operator.KcId, issuedDuringSession)
+	require.NoError(t, err)
+	require.NotNil(t, session, "종료 전 발급된 토큰의 platformAdmin 은 무시해야 함")
+	assert.Equal(t, model.BreakGlassEnded, session.Status)
+
+	session, err = svc.SessionForToken(f.operator.KcId, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Nil(t, session, "종료 후 발급된 토큰")
+}
+
+// TC-BG-ACTIVITY-01: 활동 기록은 세션별로 조회되고 해시 체인으로 연결
+func TestBreakGlassRecordActivity(t *testing.T) {
+	svc, db, _, _ := newTestBreakGlassService(t)
+	f := setupBreakGlassFixture(t, svc, db)
+	session := activateTestBreakGlass(t, svc, f.operator)
+
+	for _, method := range []string{"GET", "DELETE"} {
+		require.NoError(t, svc.RecordActivity(&model.BreakGlassActivity{
+			SessionID: session.ID, ActorKcID: f.operator.KcId, Method: method, Route: "/api/users/id/:userId", StatusCode: 200,
+		}))
+	}
+	activities, err := svc.ListActivities(session.ID)
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	assert.Equal(t, "", activities[0].PrevHash)
+	assert.Equal(t, activities[0].Hash, activities[1].PrevHash)
+	assert.Equal(t, activities[1].Hash, breakGlassActivityHash(activities[1].PrevHash, &activities[1]))
+
+	_, err = svc.ListActivities(9999)
+	assert.True(t, errors.Is(err, repository.ErrBreakGlassSessionNotFound))
+}
@@ -0,0 +1,671 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// bulkAssignmentGroupPermission 그룹 열이 있는 행을 적용하는 데 필요한 권한 (role:write 만으로는 그룹 가입 불가)
+const bulkAssignmentGroupPermission = "mc-iam-manager:organization:write"
+
+var (
+	ErrInvalidBulkAssignment = errors.New("invalid bulk assignment input")
+	ErrBulkAssignmentInvalid = errors.New("bulk assignment has invalid rows")
+	ErrBulkAssignmentFailed  = errors.New("bulk assignment failed and was rolled back")
+)
+
+// bulkAssignmentColumns CSV 헤더 별칭 → 행 필드
+var bulkAssignmentColumns = map[string]string{
+	"user":         "user",
+	"username":     "user",
+	"email":        "user",
+	"workspace":    "workspace",
+	"workspaceid":  "workspace",
+	"role":         "role",
+	"rolename":     "role",
+	"expiresat":    "expiresAt",
+	"expires":      "expiresAt",
+	"expiry":       "expiresAt",
+	"group":        "group",
+	"organization": "group",
+}
+
+// ParseBulkAssignments CSV(헤더 필수) 또는 YAML(assignments: 목록) 일괄 할당 입력 파싱
+// 파일 구조 오류만 에러로 반환하고, 행 단위 검증은 Plan/Apply 에서 수행한다.
+func ParseBulkAssignments(format string, data []byte) ([]model.BulkAssignmentRow, error) {
+	var rows []model.BulkAssignmentRow
+	switch strings.ToLower(format) {
+	case model.BulkAssignmentFormatCSV:
+		parsed, err := parseBulkAssignmentCSV(data)
+		if err != nil {
+			return nil, err
+		}
+		rows = parsed
+	case model.BulkAssignmentFormatYAML, "yml":
+		var file model.BulkAssignmentFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse YAML: %v", ErrInvalidBulkAssignment, err)
+		}
+		for i := range file.Assignments {
+			file.Assignments[i].Line = i + 1
+		}
+		rows = file.Assignments
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q (csv or yaml)", ErrInvalidBulkAssignment, format)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidBulkAssignment)
+	}
+	return rows, nil
+}
+
+func parseBulkAssignmentCSV(data []byte) ([]model.BulkAssignmentRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidBulkAssignment, err)
+	}
+	fields := make([]string, len(header))
+	hasUser := false
+	for i, name := range header {
+		key := strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(name)))
+		if i == 0 {
+			key = strings.TrimPrefix(key, "\ufeff")
+		}
+		field, ok := bulkAssignmentColumns[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", ErrInvalidBulkAssignment, name)
+		}
+		fields[i] = field
+		hasUser = hasUser || field == "user"
+	}
+	if !hasUser {
+		return nil, fmt.Errorf("%w: CSV header must contain a user, username or email column", ErrInvalidBulkAssignment)
+	}
+
+	var rows []model.BulkAssignmentRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBulkAssignment, err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := model.BulkAssignmentRow{Line: line}
+		for i, value := range record {
+			if i >= len(fields) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch fields[i] {
+			case "user":
+				row.User = value
+			case "workspace":
+				row.Workspace = value
+			case "role":
+				row.Role = value
+			case "expiresAt":
+				row.ExpiresAt = value
+			case "group":
+				row.Group = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseBulkExpiry RFC3339 또는 YYYY-MM-DD(UTC 자정) 만료 시각 파싱
+func parseBulkExpiry(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("expiresAt %q must be RFC3339 or YYYY-MM-DD", value)
+	}
+	return &t, nil
+}
+
+// BulkAssignmentService CSV/YAML 행 단위 역할·그룹 일괄 할당
+// 모든 행을 먼저 검증하고, 하나라도 잘못되면 아무것도 적용하지 않는다.
+// 적용은 하나의 DB 트랜잭션에서 수행하며, 실패하면 이미 반영한 Keycloak realm role/그룹 변경을 역순으로 되돌린다.
+type BulkAssignmentService struct {
+	db            *gorm.DB
+	userRepo      *repository.UserRepository
+	workspaceRepo *repository.WorkspaceRepository
+	roleRepo      *repository.RoleRepository
+	orgRepo       *repository.OrganizationRepository
+	sodService    *SodService
+	authzService  *AuthzService
+	kcService     KeycloakService
+}
+
+// NewBulkAssignmentService BulkAssignmentService 생성자
+func NewBulkAssignmentService(db *gorm.DB) *BulkAssignmentService {
+	return &BulkAssignmentService{
+		db:            db,
+		userRepo:      repository.NewUserRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		sodService:    NewSodService(db),
+		authzService:  NewAuthzService(db),
+		kcService:     NewKeycloakService(),
+	}
+}
+
+// bulkRowPlan 검증을 통과한 행의 해석 결과
+type bulkRowPlan struct {
+	result      *model.BulkAssignmentRowResult
+	user        *model.User
+	workspaceID uint
+	role        *model.RoleMaster
+	validity    model.AssignmentValidity
+	org         *model.Organization
+	executed    bool
+}
+
+// bulkResolver 한 번의 요청 안에서 이름 조회 결과를 재사용
+type bulkResolver struct {
+	svc        *BulkAssignmentService
+	ctx        context.Context
+	emails     map[string]string // 소문자 이메일 → Keycloak ID (첫 이메일 행에서 한 번 조회)
+	workspaces map[string]*model.Workspace
+	roles      map[string]*model.RoleMaster
+	groups     map[string]*model.Organization
+}
+
+// Plan 모든 행을 검증하고 행별 계획 반환 (dry-run, 변경 없음)
+func (s *BulkAssignmentService) Plan(ctx context.Context, rows []model.BulkAssignmentRow) *model.BulkAssignmentReport {
+	report, _ := s.plan(ctx, rows)
+	report.DryRun = true
+	return report
+}
+
+// ResolveUser Keycloak ID 로 요청자 DB 사용자 조회
+func (s *BulkAssignmentService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// authorizeGroupRows 그룹 열이 있는 행이 있으면 요청자에게 organization:write 가 있는지 확인
+func (s *BulkAssignmentService) authorizeGroupRows(ctx context.Context, actorID uint, rows []model.BulkAssignmentRow) error {
+	for _, row := range rows {
+		if row.Group == "" {
+			continue
+		}
+		allowed, err := s.authzService.HasPermission(ctx, actorID, 0, bulkAssignmentGroupPermission)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s is required for rows with a group (line %d)", ErrPermissionDenied, bulkAssignmentGroupPermission, row.Line)
+		}
+		return nil
+	}
+	return nil
+}
+
+// ApplyAs 요청자 권한 확인 후 Apply
+// 그룹 열이 있는 행이 있으면 요청자에게 organization:write 가 필요하다(없으면 ErrPermissionDenied, 보고서 없음).
+func (s *BulkAssignmentService) ApplyAs(ctx context.Context, actorID uint, rows []model.BulkAssignmentRow) (*model.BulkAssignmentReport, error) {
+	if err := s.authorizeGroupRows(ctx, actorID, rows); err != nil {
+		return nil, err
+	}
+	return s.Apply(ctx, rows)
+}
+
+// Apply 모든 행 검증 후 하나의 트랜잭션으로 적용 (요청자 권한은 확인하지 않음, API 는 ApplyAs 사용)
+// 검증 실패 시 ErrBulkAssignmentInvalid, 적용 실패(전체 롤백) 시 ErrBulkAssignmentFailed 와 함께 행별 보고서를 반환한다.
+func (s *BulkAssignmentService) Apply(ctx context.Context, rows []model.BulkAssignmentRow) (*model.BulkAssignmentReport, error) {
+	report, plans := s.plan(ctx, rows)
+	if report.Invalid > 0 {
+		for _, p := range plans {
+			p.result.Status = model.BulkRowNotApplied
+		}
+		return report, ErrBulkAssignmentInvalid
+	}
+
+	var undo []bulkCompensation
+	var failed *bulkRowPlan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txRoleService := NewRoleService(tx)
+		txSodService := NewSodService(tx)
+		txOrgRepo := repository.NewOrganizationRepository(tx)
+		for _, p := range plans {
+			p.executed = true
+			if err := s.applyRow(ctx, p, txRoleService, txSodService, txOrgRepo, &undo); err != nil {
+				failed = p
+				p.result.Errors = append(p.result.Errors, err.Error())
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.compensate(ctx, undo)
+		for _, p := range plans {
+			switch {
+			case p == failed:
+				p.result.Status = model.BulkRowFailed
+			case p.executed:
+				p.result.Status = model.BulkRowRolledBack
+			default:
+				p.result.Status = model.BulkRowNotApplied
+			}
+		}
+		report.Error = err.Error()
+		return report, fmt.Errorf("%w: %v", ErrBulkAssignmentFailed, err)
+	}
+
+	for _, p := range plans {
+		if p.result.Status == model.BulkRowPlanned {
+			p.result.Status = model.BulkRowApplied
+		}
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// plan 행별 해석·검증 (검증을 통과한 행만 plans 에 포함)
+func (s *BulkAssignmentService) plan(ctx context.Context, rows []model.BulkAssignmentRow) (*model.BulkAssignmentReport, []*bulkRowPlan) {
+	report := &model.BulkAssignmentReport{Total: len(rows), Rows: make([]model.BulkAssignmentRowResult, len(rows))}
+	resolver := &bulkResolver{
+		svc:        s,
+		ctx:        ctx,
+		workspaces: make(map[string]*model.Workspace),
+		roles:      make(map[string]*model.RoleMaster),
+		groups:     make(map[string]*model.Organization),
+	}
+	seen := make(map[string]int)
+	var plans []*bulkRowPlan
+
+	for i, row := range rows {
+		result := &report.Rows[i]
+		*result = model.BulkAssignmentRowResult{
+			Line:      row.Line,
+			User:      row.User,
+			Workspace: row.Workspace,
+			Role:      row.Role,
+			Group:     row.Group,
+		}
+		p := resolver.resolve(row, result)
+		if p != nil {
+			for _, key := range bulkStepKeys(p) {
+				if line, dup := seen[key]; dup {
+					result.Errors = append(result.Errors, fmt.Sprintf("duplicates line %d", line))
+					continue
+				}
+				seen[key] = row.Line
+			}
+		}
+		if len(result.Errors) > 0 {
+			result.Status = model.BulkRowInvalid
+			report.Invalid++
+			continue
+		}
+		result.Status = model.BulkRowUnchanged
+		for _, step := range result.Steps {
+			if !step.Exists {
+				result.Status = model.BulkRowPlanned
+			}
+		}
+		report.Valid++
+		plans = append(plans, p)
+	}
+	return report, plans
+}
+
+func bulkStepKeys(p *bulkRowPlan) []string {
+	var keys []string
+	if p.role != nil {
+		keys = append(keys, fmt.Sprintf("role:%d:%d:%d", p.user.ID, p.workspaceID, p.role.ID))
+	}
+	if p.org != nil {
+		keys = append(keys, fmt.Sprintf("group:%d:%d", p.user.ID, p.org.ID))
+	}
+	return keys
+}
+
+// resolve 한 행의 사용자/워크스페이스/역할/그룹을 해석하고 현재 할당 상태와 직무 분리 제약 확인
+// 오류는 result.Errors 에 누적하며, 사용자를 찾지 못하면 nil 을 반환한다.
+func (r *bulkResolver) resolve(row model.BulkAssignmentRow, result *model.BulkAssignmentRowResult) *bulkRowPlan {
+	p := &bulkRowPlan{result: result}
+	addErr := func(format string, args ...interface{}) {
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if row.User == "" {
+		addErr("user is required")
+	}
+	if row.Role == "" && row.Group == "" {
+		addErr("role or group is required")
+	}
+	if row.Workspace != "" && row.Role == "" {
+		addErr("workspace requires a role")
+	}
+	expiresAt, err := parseBulkExpiry(row.ExpiresAt)
+	if err != nil {
+		addErr("%v", err)
+	} else if expiresAt != nil && row.Role == "" {
+		addErr("expiresAt applies to a role; group membership does not expire")
+	}
+	p.validity = model.AssignmentValidity{ExpiresAt: expiresAt}
+	if err := ValidateAssignmentValidity(p.validity, time.Now()); err != nil {
+		addErr("%v", err)
+	}
+
+	if row.User != "" {
+		user, err := r.user(row.User)
+		if err != nil {
+			addErr("%v", err)
+		} else {
+			p.user = user
+			result.UserID = user.ID
+		}
+	}
+
+	roleType := constants.RoleTypePlatform
+	if row.Workspace != "" {
+		roleType = constants.RoleTypeWorkspace
+		ws, err := r.workspace(row.Workspace)
+		if err != nil {
+			addErr("%v", err)
+		} else {
+			p.workspaceID = ws.ID
+			result.Workspace = ws.Name
+		}
+	}
+	if row.Role != "" {
+		role, err := r.role(row.Role, roleType)
+		if err != nil {
+			addErr("%v", err)
+		} else {
+			p.role = role
+		}
+	}
+	if row.Group != "" {
+		org, err := r.group(row.Group)
+		if err != nil {
+			addErr("%v", err)
+		} else {
+			p.org = org
+			result.Group = org.Name
+		}
+	}
+
+	if p.user == nil || len(result.Errors) > 0 {
+		return nil
+	}
+	if p.role != nil {
+		step, err := r.svc.planRoleStep(p)
+		if err != nil {
+			addErr("%v", err)
+		}
+		result.Steps = append(result.Steps, step)
+	}
+	if p.org != nil {
+		step, err := r.svc.planGroupStep(p)
+		if err != nil {
+			addErr("%v", err)
+		}
+		result.Steps = append(result.Steps, step)
+	}
+	return p
+}
+
+func (r *bulkResolver) user(value string) (*model.User, error) {
+	if !strings.Contains(value, "@") {
+		user, err := r.svc.userRepo.FindByUsername(value)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return nil, fmt.Errorf("user %q not found", value)
+			}
+			return nil, err
+		}
+		return user, nil
+	}
+
+	// 이메일은 DB 에 없으므로 Keycloak 사용자 목록에서 찾는다
+	if r.emails == nil {
+		kcUsers, err := r.svc.kcService.GetUsers(r.ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up users by email: %w", err)
+		}
+		r.emails = make(map[string]string, len(kcUsers))
+		for _, u := range kcUsers {
+			if u.Email != nil && u.ID != nil {
+				r.emails[strings.ToLower(*u.Email)] = *u.ID
+			}
+		}
+	}
+	kcID, ok := r.emails[strings.ToLower(value)]
+	if !ok {
+		return nil, fmt.Errorf("user with email %q not found", value)
+	}
+	user, err := r.svc.userRepo.FindByKcID(kcID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user with email %q has not been synchronized", value)
+	}
+	return user, nil
+}
+
+func (r *bulkResolver) workspace(value string) (*model.Workspace, error) {
+	if ws, ok := r.workspaces[value]; ok {
+		return ws, nil
+	}
+	var ws *model.Workspace
+	var err error
+	if id, convErr := strconv.ParseUint(value, 10, 64); convErr == nil {
+		ws, err = r.svc.workspaceRepo.FindWorkspaceByID(uint(id))
+		if err == nil && ws == nil {
+			err = repository.ErrWorkspaceNotFound
+		}
+	} else {
+		ws, err = r.svc.workspaceRepo.FindWorkspaceByName(value)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			return nil, fmt.Errorf("workspace %q not found", value)
+		}
+		return nil, err
+	}
+	r.workspaces[value] = ws
+	return ws, nil
+}
+
+func (r *bulkResolver) role(name string, roleType constants.IAMRoleType) (*model.RoleMaster, error) {
+	key := string(roleType) + ":" + name
+	if role, ok := r.roles[key]; ok {
+		return role, nil
+	}
+	role, err := r.svc.roleRepo.FindRoleByRoleName(name, roleType)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("%s role %q not found", roleType, name)
+	}
+	r.roles[key] = role
+	return role, nil
+}
+
+func (r *bulkResolver) group(value string) (*model.Organization, error) {
+	if org, ok := r.groups[value]; ok {
+		return org, nil
+	}
+	org, err := r.svc.orgRepo.FindByCode(value)
+	if errors.Is(err, repository.ErrOrganizationNotFound) {
+		org, err = r.svc.orgRepo.FindByName(value)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, fmt.Errorf("group %q not found", value)
+		}
+		return nil, err
+	}
+	r.groups[value] = org
+	return org, nil
+}
+
+// planRoleStep 역할 할당 단계 (이미 할당되어 있으면 Exists, 아니면 직무 분리 제약 확인)
+func (s *BulkAssignmentService) planRoleStep(p *bulkRowPlan) (model.BulkAssignmentStep, error) {
+	step := model.BulkAssignmentStep{Target: p.role.Name, ExpiresAt: p.validity.ExpiresAt}
+	var err error
+	if p.workspaceID != 0 {
+		step.Kind = model.BulkStepWorkspaceRole
+		step.Workspace = p.result.Workspace
+		step.Exists, err = s.roleRepo.IsAssignedWorkspaceRoleInWorkspace(p.user.ID, p.workspaceID, p.role.ID)
+		if err == nil && !step.Exists {
+			err = s.sodService.CheckWorkspaceRoleAssignment(p.user.ID, p.workspaceID, p.role.ID)
+		}
+		return step, err
+	}
+	step.Kind = model.BulkStepPlatformRole
+	step.Exists, err = s.roleRepo.IsAssignedPlatformRole(p.user.ID, p.role.ID)
+	if err == nil && !step.Exists {
+		step.Keycloak = p.user.KcId != ""
+		err = s.sodService.CheckPlatformRoleAssignment(p.user.ID, p.role.ID)
+	}
+	return step, err
+}
+
+// planGroupStep 그룹 가입 단계 (그룹의 realm role 을 Keycloak 그룹 멤버십으로 상속)
+func (s *BulkAssignmentService) planGroupStep(p *bulkRowPlan) (model.BulkAssignmentStep, error) {
+	step := model.BulkAssignmentStep{Kind: model.BulkStepGroup, Target: p.org.Name}
+	member, err := s.orgRepo.IsUserInOrganization(p.user.ID, p.org.ID)
+	if err != nil || member {
+		step.Exists = member
+		return step, err
+	}
+	step.Keycloak = p.user.KcId != ""
+	return step, s.sodService.CheckGroupMembership(p.user.ID, []uint{p.org.ID}, false)
+}
+
+// bulkCompensation 적용 실패 시 되돌릴 Keycloak 변경
+type bulkCompensation struct {
+	desc string
+	undo func(ctx context.Context) error
+}
+
+// applyRow 트랜잭션 안에서 한 행 적용 (직무 분리 제약은 앞선 행까지 반영된 상태로 다시 확인)
+func (s *BulkAssignmentService) applyRow(ctx context.Context, p *bulkRowPlan, roleService *RoleService, sodService *SodService, orgRepo *repository.OrganizationRepository, undo *[]bulkCompensation) error {
+	for _, step := range p.result.Steps {
+		if step.Exists {
+			continue
+		}
+		switch step.Kind {
+		case model.BulkStepWorkspaceRole:
+			if err := roleService.AssignWorkspaceRoleWithValidity(p.user.ID, p.workspaceID, p.role.ID, p.validity); err != nil {
+				return fmt.Errorf("workspace role %s: %w", p.role.Name, err)
+			}
+		case model.BulkStepPlatformRole:
+			if err := roleService.AssignPlatformRoleWithValidity(p.user.ID, p.role.ID, p.validity); err != nil {
+				return fmt.Errorf("platform role %s: %w", p.role.Name, err)
+			}
+			// 시작 전이면 Keycloak 부여는 만료 처리기가 시작 시각에 수행
+			if p.user.KcId == "" || p.validity.PendingAt(time.Now()) {
+				continue
+			}
+			if err := s.grantRealmRole(ctx, p.user.KcId, p.role.Name, undo); err != nil {
+				return fmt.Errorf("platform role %s: %w", p.role.Name, err)
+			}
+		case model.BulkStepGroup:
+			if err := sodService.CheckGroupMembership(p.user.ID, []uint{p.org.ID}, false); err != nil {
+				return fmt.Errorf("group %s: %w", p.org.Name, err)
+			}
+			if err := orgRepo.AssignUserToOrganizations(p.user.ID, []uint{p.org.ID}); err != nil {
+				return fmt.Errorf("group %s: %w", p.org.Name, err)
+			}
+			if p.user.KcId == "" {
+				continue
+			}
+			if err := s.joinGroup(ctx, p.user.KcId, p.org.Name, undo); err != nil {
+				return fmt.Errorf("group %s: %w", p.org.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// joinGroup Keycloak 그룹 가입 (이미 가입되어 있던 그룹은 보상 대상에서 제외)
+func (s *BulkAssignmentService) joinGroup(ctx context.Context, kcUserID, groupName string, undo *[]bulkCompensation) error {
+	member, err := s.kcService.IsUserInGroup(ctx, kcUserID, groupName)
+	if err != nil {
+		return fmt.Errorf("failed to check keycloak group membership: %w", err)
+	}
+	if member {
+		return nil
+	}
+	if err := s.kcService.EnsureGroupExistsAndAssignUser(ctx, kcUserID, groupName); err != nil {
+		return fmt.Errorf("failed to assign keycloak group: %w", err)
+	}
+	*undo = append(*undo, bulkCompensation{
+		desc: fmt.Sprintf("remove %s from keycloak group %s", kcUserID, groupName),
+		undo: func(ctx context.Context) error { return s.kcService.RemoveUserFromGroup(ctx, kcUserID, groupName) },
+	})
+	return nil
+}
+
+// grantRealmRole Keycloak realm role 부여 (이미 부여되어 있던 역할은 보상 대상에서 제외)
+func (s *BulkAssignmentService) grantRealmRole(ctx context.Context, kcUserID, roleName string, undo *[]bulkCompensation) error {
+	exists, err := s.kcService.CheckRealmRoleExists(ctx, roleName)
+	if err != nil {
+		return fmt.Errorf("failed to check keycloak realm role: %w", err)
+	}
+	if !exists {
+		if err := s.kcService.CreateRealmRoleAndWait(ctx, roleName); err != nil {
+			return fmt.Errorf("failed to create keycloak realm role: %w", err)
+		}
+	}
+	assigned, err := s.kcService.IsRealmRoleAssignedToUser(ctx, kcUserID, roleName)
+	if err != nil {
+		return fmt.Errorf("failed to check keycloak realm role assignment: %w", err)
+	}
+	if assigned {
+		return nil
+	}
+	if err := s.kcService.AssignRealmRoleToUser(ctx, kcUserID, roleName); err != nil {
+		return fmt.Errorf("failed to assign keycloak realm role: %w", err)
+	}
+	*undo = append(*undo, bulkCompensation{
+		desc: fmt.Sprintf("remove realm role %s from %s", roleName, kcUserID),
+		undo: func(ctx context.Context) error { return s.kcService.RemoveRealmRoleFromUser(ctx, kcUserID, roleName) },
+	})
+	return nil
+}
+
+// compensate 반영된 Keycloak 변경을 역순으로 되돌림 (실패는 로그로 남기고 계속 진행)
+func (s *BulkAssignmentService) compensate(ctx context.Context, undo []bulkCompensation) {
+	for i := len(undo) - 1; i >= 0; i-- {
+		if err := undo[i].undo(ctx); err != nil {
+			log.Printf("[BULK_ASSIGN] compensation failed (%s): %v", undo[i].desc, err)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+// bulk_assignment_service_test.go
+//
+// BulkAssignmentService 단위 테스트 (SQLite in-memory DB)
+// CSV/YAML 파싱, 행별 검증과 dry-run 계획, 트랜잭션 적용, 실패 시 DB 롤백과 Keycloak 보상을 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestBulkAssignmentService(t *testing.T) (*BulkAssignmentService, *gorm.DB, *recordingKeycloak) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	kc := &recordingKeycloak{}
+	return &BulkAssignmentService{
+		db:            db,
+		userRepo:      repository.NewUserRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		sodService:    NewSodService(db),
+		authzService:  newTestAuthz(db),
+		kcService:     kc,
+	}, db, kc
+}
+
+// bulkFixture 워크스페이스 하나, 역할 둘(플랫폼/워크스페이스 겸용), 그룹 하나, 사용자 둘
+type bulkFixture struct {
+	ws       *model.Workspace
+	viewer   *model.RoleMaster
+	operator *model.RoleMaster
+	team     *model.Organization
+	alice    *model.User
+	bob      *model.User
+}
+
+func setupBulkFixture(t *testing.T, db *gorm.DB, kc *recordingKeycloak) *bulkFixture {
+	t.Helper()
+	f := &bulkFixture{
+		ws:       createGRTestWorkspace(t, db, "onboarding"),
+		viewer:   createGRTestRole(t, db, "bulk-viewer"),
+		operator: createGRTestRole(t, db, "bulk-operator"),
+		team:     createGRTestOrg(t, db, "platform-team", "BULK-01"),
+		alice:    createGRTestUser(t, db, "bulk-alice", "kc-bulk-alice"),
+		bob:      createGRTestUser(t, db, "bulk-bob", "kc-bulk-bob"),
+	}
+	kc.users = []*gocloak.User{{ID: gocloak.StringP("kc-bulk-bob"), Email: gocloak.StringP("Bob@example.com")}}
+	return f
+}
+
+// ── 파싱 ──────────────────────────────────────────────────────────────────────
+
+// TC-BULK-PARSE-01: CSV 헤더 별칭/줄 번호, YAML assignments 목록, 알 수 없는 열·형식 거부
+func TestParseBulkAssignments(t *testing.T) {
+	rows, err := ParseBulkAssignments("csv", []byte("Username,Workspace,Role,expires_at,Group\n"+
+		"alice, onboarding ,viewer,2030-01-01,\n"+
+		"\n"+
+		"bob@example.com,,operator,,BULK-01\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, model.BulkAssignmentRow{Line: 2, User: "alice", Workspace: "onboarding", Role: "viewer", ExpiresAt: "2030-01-01"}, rows[0])
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, "BULK-01", rows[1].Group)
+
+	rows, err = ParseBulkAssignments("yaml", []byte("assignments:\n  - user: alice\n    workspace: onboarding\n    role: viewer\n  - user: bob\n    group: BULK-01\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[1].Line)
+	assert.Equal(t, "BULK-01", rows[1].Group)
+
+	_, err = ParseBulkAssignments("csv", []byte("user,team\nalice,x\n"))
+	assert.ErrorIs(t, err, ErrInvalidBulkAssignment)
+	_, err = ParseBulkAssignments("csv", []byte("role\nviewer\n"))
+	assert.ErrorIs(t, err, ErrInvalidBulkAssignment)
+	_, err = ParseBulkAssignments("json", []byte("[]"))
+	assert.ErrorIs(t, err, ErrInvalidBulkAssignment)
+	_, err = ParseBulkAssignments("yaml", []byte("assignments: []\n"))
+	assert.ErrorIs(t, err, ErrInvalidBulkAssignment)
+}
+
+// ── 계획 ──────────────────────────────────────────────────────────────────────
+
+// TC-BULK-PLAN-01: dry-run 은 행별 단계와 기존 할당을 보고하고 아무것도 변경하지 않음
+func TestBulkAssignmentPlan_ReportsStepsWithoutChanges(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	f := setupBulkFixture(t, db, kc)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.alice.ID, WorkspaceID: f.ws.ID, RoleID: f.viewer.ID}).Error)
+
+	report := svc.Plan(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer"},
+		{Line: 3, User: "bob@example.com", Workspace: "onboarding", Role: "bulk-operator", ExpiresAt: "2099-01-01", Group: "platform-team"},
+		{Line: 4, User: "bulk-bob", Role: "bulk-operator"},
+	})
+
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Applied)
+	assert.Equal(t, 3, report.Valid)
+	assert.Equal(t, model.BulkRowUnchanged, report.Rows[0].Status)
+	assert.True(t, report.Rows[0].Steps[0].Exists)
+
+	bobRow := report.Rows[1]
+	assert.Equal(t, model.BulkRowPlanned, bobRow.Status)
+	assert.Equal(t, f.bob.ID, bobRow.UserID)
+	require.Len(t, bobRow.Steps, 2)
+	assert.Equal(t, model.BulkStepWorkspaceRole, bobRow.Steps[0].Kind)
+	require.NotNil(t, bobRow.Steps[0].ExpiresAt)
+	assert.Equal(t, model.BulkStepGroup, bobRow.Steps[1].Kind)
+	assert.True(t, bobRow.Steps[1].Keycloak)
+
+	assert.Equal(t, model.BulkStepPlatformRole, report.Rows[2].Steps[0].Kind)
+	assert.True(t, report.Rows[2].Steps[0].Keycloak)
+
+	assert.Equal(t, int64(1), countRows(t, db, &model.UserWorkspaceRole{}))
+	assert.Zero(t, countRows(t, db, &model.UserOrganization{}))
+	assert.Empty(t, kc.calls)
+}
+
+// TC-BULK-PLAN-02: 모든 행의 오류를 모아 보고 (없는 사용자/워크스페이스/역할, 잘못된 만료, 중복, 직무 분리)
+func TestBulkAssignmentPlan_ValidatesEveryRow(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	f := setupBulkFixture(t, db, kc)
+	createSodTestConstraint(t, svc.sodService, "bulk-sod", f.viewer, f.operator)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.bob.ID, WorkspaceID: f.ws.ID, RoleID: f.viewer.ID}).Error)
+
+	report := svc.Plan(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "nobody", Workspace: "onboarding", Role: "bulk-viewer"},
+		{Line: 3, User: "bulk-alice", Workspace: "missing", Role: "bulk-viewer"},
+		{Line: 4, User: "bulk-alice", Workspace: "onboarding", Role: "missing"},
+		{Line: 5, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer", ExpiresAt: "next week"},
+		{Line: 6, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer", ExpiresAt: "2000-01-01"},
+		{Line: 7, User: "bulk-alice", Group: "platform-team", ExpiresAt: "2099-01-01"},
+		{Line: 8, User: "bulk-alice"},
+		{Line: 9, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-operator"},
+		{Line: 10, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-operator"},
+		{Line: 11, User: "bulk-bob", Workspace: "onboarding", Role: "bulk-operator"},
+		{Line: 12, User: "nobody@example.com", Group: "BULK-01"},
+	})
+
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, 10, report.Invalid)
+	for i, row := range report.Rows {
+		if row.Line == 9 {
+			assert.Equal(t, model.BulkRowPlanned, row.Status)
+			continue
+		}
+		assert.Equal(t, model.BulkRowInvalid, row.Status, "line %d", row.Line)
+		assert.NotEmpty(t, report.Rows[i].Errors, "line %d", row.Line)
+	}
+	assert.Contains(t, report.Rows[8].Errors, "duplicates line 9")
+	assert.Contains(t, report.Rows[9].Errors[0], ErrSodViolation.Error())
+}
+
+// ── 적용 ──────────────────────────────────────────────────────────────────────
+
+// TC-BULK-APPLY-01: 워크스페이스 역할(만료 포함), 플랫폼 역할 realm role 부여, 그룹 가입을 한 번에 적용
+func TestBulkAssignmentApply_Success(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	f := setupBulkFixture(t, db, kc)
+
+	report, err := svc.Apply(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer", ExpiresAt: "2099-01-01"},
+		{Line: 3, User: "bob@example.com", Role: "bulk-operator", Group: "BULK-01"},
+	})
+	require.NoError(t, err)
+	assert.True(t, report.Applied)
+	assert.Equal(t, model.BulkRowApplied, report.Rows[0].Status)
+	assert.Equal(t, model.BulkRowApplied, report.Rows[1].Status)
+
+	var wsRole model.UserWorkspaceRole
+	require.NoError(t, db.Where("user_id = ? AND workspace_id = ? AND role_id = ?", f.alice.ID, f.ws.ID, f.viewer.ID).First(&wsRole).Error)
+	require.NotNil(t, wsRole.ExpiresAt)
+	assert.Equal(t, 2099, wsRole.ExpiresAt.Year())
+	assert.Equal(t, int64(1), countRows(t, db, &model.UserPlatformRole{}))
+	assert.Equal(t, int64(1), countRows(t, db, &model.UserOrganization{}))
+	assert.Equal(t, []string{"assign-user kc-bulk-bob bulk-operator", "join-group kc-bulk-bob platform-team"}, kc.calls)
+}
+
+// TC-BULK-APPLY-02: 잘못된 행이 하나라도 있으면 아무것도 적용하지 않음
+func TestBulkAssignmentApply_InvalidRowsApplyNothing(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	setupBulkFixture(t, db, kc)
+
+	report, err := svc.Apply(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer"},
+		{Line: 3, User: "nobody", Workspace: "onboarding", Role: "bulk-viewer"},
+	})
+	assert.ErrorIs(t, err, ErrBulkAssignmentInvalid)
+	assert.False(t, report.Applied)
+	assert.Equal(t, model.BulkRowNotApplied, report.Rows[0].Status)
+	assert.Equal(t, model.BulkRowInvalid, report.Rows[1].Status)
+	assert.Zero(t, countRows(t, db, &model.UserWorkspaceRole{}))
+	assert.Empty(t, kc.calls)
+}
+
+// TC-BULK-APPLY-03: 적용 중 실패 → DB 트랜잭션 롤백, 이미 부여한 realm role 회수, 행별 상태 보고
+func TestBulkAssignmentApply_FailureRollsBackAndCompensates(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	setupBulkFixture(t, db, kc)
+	kc.groupErr = errors.New("keycloak unavailable")
+
+	report, err := svc.Apply(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-alice", Role: "bulk-operator"},
+		{Line: 3, User: "bulk-bob", Workspace: "onboarding", Role: "bulk-viewer", Group: "BULK-01"},
+		{Line: 4, User: "bulk-bob", Workspace: "onboarding", Role: "bulk-operator"},
+	})
+	assert.ErrorIs(t, err, ErrBulkAssignmentFailed)
+	assert.False(t, report.Applied)
+	assert.Contains(t, report.Error, "keycloak unavailable")
+	assert.Equal(t, model.BulkRowRolledBack, report.Rows[0].Status)
+	assert.Equal(t, model.BulkRowFailed, report.Rows[1].Status)
+	assert.Equal(t, model.BulkRowNotApplied, report.Rows[2].Status)
+
+	assert.Zero(t, countRows(t, db, &model.UserPlatformRole{}))
+	assert.Zero(t, countRows(t, db, &model.UserWorkspaceRole{}))
+	assert.Zero(t, countRows(t, db, &model.UserOrganization{}))
+	assert.Equal(t, []string{"assign-user kc-bulk-alice bulk-operator", "remove-user kc-bulk-alice bulk-operator"}, kc.calls)
+}
+
+// TC-BULK-APPLY-04: 그룹 열이 있는 행은 요청자에게 organization:write 가 있어야 적용 (역할만 있는 행은 role:write 로 충분)
+func TestBulkAssignmentApplyAs_GroupRowsRequireOrganizationWrite(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	f := setupBulkFixture(t, db, kc)
+	roleWriter := createGRTestUser(t, db, "bulk-role-writer", "kc-bulk-role-writer")
+	assignAuthzTestPlatformRole(t, db, roleWriter, "bulk-role-admin", "mc-iam-manager:role:write")
+	orgWriter := createGRTestUser(t, db, "bulk-org-writer", "kc-bulk-org-writer")
+	assignAuthzTestPlatformRole(t, db, orgWriter, "bulk-org-admin", "mc-iam-manager:role:write", bulkAssignmentGroupPermission)
+	ctx := context.Background()
+	groupRows := []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-alice", Workspace: "onboarding", Role: "bulk-viewer"},
+		{Line: 3, User: "bulk-bob", Group: "BULK-01"},
+	}
+
+	report, err := svc.ApplyAs(ctx, roleWriter.ID, groupRows)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Contains(t, err.Error(), "line 3")
+	assert.Nil(t, report)
+	assert.Zero(t, countRows(t, db, &model.UserWorkspaceRole{}))
+	assert.Zero(t, countRows(t, db, &model.UserOrganization{}))
+	assert.Empty(t, kc.calls)
+
+	report, err = svc.ApplyAs(ctx, roleWriter.ID, groupRows[:1])
+	require.NoError(t, err)
+	assert.True(t, report.Applied)
+
+	report, err = svc.ApplyAs(ctx, orgWriter.ID, groupRows[1:])
+	require.NoError(t, err)
+	assert.True(t, report.Applied)
+	member, err := repository.NewOrganizationRepository(db).IsUserInOrganization(f.bob.ID, f.team.ID)
+	require.NoError(t, err)
+	assert.True(t, member)
+}
+
+// TC-BULK-APPLY-05: 이미 Keycloak 그룹에 속한 사용자는 가입 호출도, 실패 시 탈퇴 보상도 하지 않음
+func TestBulkAssignmentApply_ExistingKeycloakGroupMemberNotCompensated(t *testing.T) {
+	svc, db, kc := newTestBulkAssignmentService(t)
+	setupBulkFixture(t, db, kc)
+	kc.groups = map[string]bool{"kc-bulk-bob platform-team": true}
+	kc.groupErr = errors.New("keycloak unavailable")
+
+	report, err := svc.Apply(context.Background(), []model.BulkAssignmentRow{
+		{Line: 2, User: "bulk-bob", Group: "BULK-01"},
+		{Line: 3, User: "bulk-alice", Group: "BULK-01"},
+	})
+	assert.ErrorIs(t, err, ErrBulkAssignmentFailed)
+	assert.Equal(t, model.BulkRowRolledBack, report.Rows[0].Status)
+	assert.Equal(t, model.BulkRowFailed, report.Rows[1].Status)
+	assert.Zero(t, countRows(t, db, &model.UserOrganization{}))
+	assert.Empty(t, kc.calls, "bob was already in the keycloak group and must not be removed")
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/eventsink"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCredentialIssuanceFilter = errors.New("invalid credential issuance filter")
+
+// 집계 기준 기본값
+var defaultCredentialIssuanceGroupBy = []string{"workspace", "csp"}
+
+// CredentialIssuanceService CSP 임시 자격 증명 발급 원장 기록/조회
+type CredentialIssuanceService struct {
+	issuanceRepo *repository.CredentialIssuanceRepository
+	chainRepo    *repository.AuditChainRepository
+	userRepo     *repository.UserRepository
+	roleRepo     *repository.RoleRepository
+}
+
+// NewCredentialIssuanceService CredentialIssuanceService 생성
+func NewCredentialIssuanceService(db *gorm.DB) *CredentialIssuanceService {
+	return &CredentialIssuanceService{
+		issuanceRepo: repository.NewCredentialIssuanceRepository(db),
+		chainRepo:    repository.NewAuditChainRepository(db),
+		userRepo:     repository.NewUserRepository(db),
+		roleRepo:     repository.NewRoleRepository(db),
+	}
+}
+
+// newCredentialIssuance 발급 요청 시점의 원장 기록 생성 (요청 IP 는 AuthzConditionMiddleware 가 남긴 값)
+func newCredentialIssuance(ctx context.Context, userID uint, kcUserId string, req *model.CspCredentialRequest) *model.CredentialIssuance {
+	cc := authzConditionContextFrom(ctx)
+	issuance := &model.CredentialIssuance{
+		RequestedAt: cc.At,
+		UserID:      userID,
+		KcUserID:    kcUserId,
+		CspType:     req.CspType,
+		AuthMethod:  req.AuthMethod,
+		Region:      req.Region,
+		SourceIP:    cc.SourceIP,
+	}
+	if id, err := parseAuthzWorkspaceID(req.WorkspaceID); err == nil {
+		issuance.WorkspaceID = id
+	}
+	if req.DurationSeconds > 0 {
+		issuance.RequestedDurationSeconds = req.DurationSeconds
+		requestedExpiresAt := issuance.RequestedAt.Add(time.Duration(req.DurationSeconds) * time.Second)
+		issuance.RequestedExpiresAt = &requestedExpiresAt
+	}
+	return issuance
+}
+
+// Record 발급 결과를 원장 해시 체인에 추가하고 보안 이벤트 싱크로 전달 (자격 증명 자체는 만료 시각 외에 저장하지 않음)
+// 사용자명/워크스페이스 역할명이 비어 있으면 DB 에서 채운다. 기록 실패는 발급 결과에 영향을 주지 않는다.
+func (s *CredentialIssuanceService) Record(issuance *model.CredentialIssuance, cred *model.CspCredentialResponse, issueErr error) {
+	if issueErr != nil {
+		issuance.Result = model.AuditResultFailure
+		issuance.FailureReason = issueErr.Error()
+	} else {
+		issuance.Result = model.AuditResultSuccess
+		if cred != nil && !cred.Expiration.IsZero() {
+			expiresAt := cred.Expiration
+			issuance.ExpiresAt = &expiresAt
+		}
+	}
+
+	if issuance.KcUserID != "" && (issuance.UserID == 0 || issuance.Username == "") {
+		if user, err := s.userRepo.FindByKcID(issuance.KcUserID); err == nil && user != nil {
+			issuance.UserID = user.ID
+			issuance.Username = user.Username
+		}
+	}
+	if issuance.WorkspaceRoleID != 0 && issuance.WorkspaceRoleName == "" {
+		if role, err := s.roleRepo.FindRoleByRoleID(issuance.WorkspaceRoleID, ""); err == nil && role != nil {
+			issuance.WorkspaceRoleName = role.Name
+		}
+	}
+
+	err := s.chainRepo.Append(model.AuditChainCredentialIssuances, issuance, func(prevHash string) {
+		sealCredentialIssuance(issuance, prevHash)
+	})
+	if err != nil {
+		log.Printf("[CSP_CREDENTIAL] failed to record credential issuance for user %s: %v", issuance.KcUserID, err)
+	}
+	eventsink.Publish(securityEventFromIssuance(issuance))
+}
+
+// ListIssuances 조건에 맞는 발급 기록 조회 (최신순)
+func (s *CredentialIssuanceService) ListIssuances(filter *model.CredentialIssuanceFilter) (*model.CredentialIssuanceListResponse, error) {
+	q, err := credentialIssuanceQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	issuances, total, err := s.issuanceRepo.List(q)
+	if err != nil {
+		return nil, err
+	}
+	if issuances == nil {
+		issuances = []model.CredentialIssuance{}
+	}
+	return &model.CredentialIssuanceListResponse{Total: total, Issuances: issuances}, nil
+}
+
+// GetIssuance ID로 발급 기록 조회
+func (s *CredentialIssuanceService) GetIssuance(id uint) (*model.CredentialIssuance, error) {
+	return s.issuanceRepo.FindByID(id)
+}
+
+// AggregateIssuances 조건에 맞는 발급 기록을 워크스페이스/CSP 등 기준으로 집계
+func (s *CredentialIssuanceService) AggregateIssuances(filter *model.CredentialIssuanceFilter) ([]model.CredentialIssuanceAggregate, error) {
+	q, err := credentialIssuanceQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	q.GroupBy = defaultCredentialIssuanceGroupBy
+	if strings.TrimSpace(filter.GroupBy) != "" {
+		q.GroupBy = nil
+		seen := make(map[string]bool)
+		for _, g := range strings.Split(filter.GroupBy, ",") {
+			g = strings.TrimSpace(g)
+			switch g {
+			case "workspace", "csp", "cspRole", "user":
+			default:
+				return nil, fmt.Errorf("%w: groupBy must be a combination of workspace, csp, cspRole, user: %q", ErrInvalidCredentialIssuanceFilter, g)
+			}
+			if !seen[g] {
+				seen[g] = true
+				q.GroupBy = append(q.GroupBy, g)
+			}
+		}
+	}
+	return s.issuanceRepo.Aggregate(q)
+}
+
+// credentialIssuanceQuery 조회 조건 검증 및 변환 (감사 이벤트 조회와 같은 규칙)
+func credentialIssuanceQuery(filter *model.CredentialIssuanceFilter) (repository.CredentialIssuanceQuery, error) {
+	q := repository.CredentialIssuanceQuery{
+		User:        strings.TrimSpace(filter.User),
+		WorkspaceID: filter.WorkspaceID,
+		CspType:     filter.CspType,
+		CspRole:     strings.TrimSpace(filter.CspRole),
+		AuthMethod:  filter.AuthMethod,
+		Result:      filter.Result,
+		SourceIP:    filter.SourceIP,
+		Limit:       filter.Limit,
+		Offset:      filter.Offset,
+	}
+	if q.Result != "" && q.Result != model.AuditResultSuccess && q.Result != model.AuditResultFailure {
+		return q, fmt.Errorf("%w: result must be %s or %s", ErrInvalidCredentialIssuanceFilter, model.AuditResultSuccess, model.AuditResultFailure)
+	}
+	for _, bound := range []struct {
+		raw  string
+		dest **time.Time
+	}{{filter.From, &q.From}, {filter.To, &q.To}} {
+		if bound.raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.raw)
+		if err != nil {
+			return q, fmt.Errorf("%w: time must be RFC3339: %q", ErrInvalidCredentialIssuanceFilter, bound.raw)
+		}
+		*bound.dest = &t
+	}
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return q, fmt.Errorf("%w: from must be before to", ErrInvalidCredentialIssuanceFilter)
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultAuditListLimit
+	}
+	if q.Limit > maxAuditListLimit {
+		q.Limit = maxAuditListLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return q, nil
+}
@@ -0,0 +1,184 @@
+package service
+
+// credential_issuance_service_test.go
+//
+// CSP 임시 자격 증명 발급 원장 테스트 (SQLite in-memory DB + 발급 mock)
+// 성공/실패 발급 기록, 비밀값 미저장, 조회 필터와 워크스페이스/CSP 집계를 검증한다.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestCredentialIssuanceService(t *testing.T) (*CredentialIssuanceService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.CredentialIssuance{}))
+	return NewCredentialIssuanceService(db), db
+}
+
+// TC-CREDLEDGER-01: 성공 발급 — 사용자/워크스페이스 역할/CSP 역할/인증 방식/요청 IP/만료 기록, 비밀값 미저장
+func TestCredentialIssuance_RecordsSuccessWithoutSecrets(t *testing.T) {
+	issuanceSvc, db := newTestCredentialIssuanceService(t)
+	user := createGRTestUser(t, db, "alice", "kc-alice")
+	role := createGRTestRole(t, db, "ws-admin")
+
+	expiration := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	awsCred := *awsOidcCred
+	awsCred.Expiration = expiration
+	mapping := buildMapping(constants.AuthMethodOIDC, idpArn, roleArn, model.AuthMethodOIDC, nil)
+	mapping.CspRoles[0].ID = 11
+	mapping.CspRoles[0].Name = "mciam-admin"
+	svc := newCredServiceWithMocks(credServiceDeps{
+		aws:      &mockAwsCredService{oidcResult: &awsCred},
+		kc:       oidcKC(),
+		userRepo: &mockUserRepoForCred{role: &model.UserWorkspaceRole{RoleID: role.ID}},
+		mapRepo:  &mockCspMappingRepo{mapping: mapping},
+	})
+	svc.issuanceService = issuanceSvc
+
+	requestedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ctx := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{SourceIP: "203.0.113.9", At: requestedAt})
+	r := req("aws", "")
+	r.DurationSeconds = 7200
+	_, err := svc.GetTemporaryCredentials(ctx, user.ID, "kc-alice", r)
+	require.NoError(t, err)
+
+	resp, err := issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.Total)
+	got := resp.Issuances[0]
+	assert.Equal(t, model.AuditResultSuccess, got.Result)
+	assert.Equal(t, "alice", got.Username)
+	assert.Equal(t, uint(1), got.WorkspaceID)
+	assert.Equal(t, role.ID, got.WorkspaceRoleID)
+	assert.Equal(t, "ws-admin", got.WorkspaceRoleName)
+	require.NotNil(t, got.CspRoleID)
+	assert.Equal(t, uint(11), *got.CspRoleID)
+	assert.Equal(t, "mciam-admin", got.CspRoleName)
+	assert.Equal(t, roleArn, got.CspRoleIdentifier)
+	assert.Equal(t, string(model.AuthMethodOIDC), got.AuthMethod, "매핑에서 결정된 인증 방식")
+	assert.Equal(t, "203.0.113.9", got.SourceIP)
+	require.NotNil(t, got.RequestedExpiresAt)
+	assert.True(t, got.RequestedExpiresAt.Equal(requestedAt.Add(2*time.Hour)))
+	require.NotNil(t, got.ExpiresAt)
+	assert.True(t, got.ExpiresAt.Equal(expiration))
+
+	raw, err := json.Marshal(got)
+	require.NoError(t, err)
+	for _, secret := range []string{awsCred.AccessKeyId, awsCred.SecretAccessKey, awsCred.SessionToken} {
+		assert.NotContains(t, string(raw), secret)
+	}
+}
+
+// TC-CREDLEDGER-02: 실패 발급 — 실패 사유 기록 (역할 없음, STS 실패), 역할 지정 발급 경로도 기록
+func TestCredentialIssuance_RecordsFailures(t *testing.T) {
+	issuanceSvc, db := newTestCredentialIssuanceService(t)
+	createGRTestUser(t, db, "bob", "kc-bob")
+
+	noRole := newCredServiceWithMocks(credServiceDeps{
+		kc:       &mockKeycloakForCred{},
+		userRepo: &mockUserRepoForCred{role: nil},
+		mapRepo:  &mockCspMappingRepo{},
+	})
+	noRole.issuanceService = issuanceSvc
+	_, err := noRole.GetTemporaryCredentials(context.Background(), 0, "kc-bob", req("aws", "OIDC"))
+	require.Error(t, err)
+
+	stsFail := newCredServiceWithMocks(credServiceDeps{
+		aws:     &mockAwsCredService{oidcErr: errStsFail},
+		kc:      oidcKC(),
+		mapRepo: &mockCspMappingRepo{mapping: buildMapping(constants.AuthMethodOIDC, idpArn, roleArn, model.AuthMethodOIDC, nil)},
+	})
+	stsFail.issuanceService = issuanceSvc
+	_, err = stsFail.GetTemporaryCredentialsForRole(context.Background(), "kc-bob", 1, req("aws", "OIDC"))
+	require.Error(t, err)
+
+	resp, err := issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{User: "bob", Result: model.AuditResultFailure})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Total)
+	assert.Contains(t, resp.Issuances[0].FailureReason, errStsFail.Error())
+	assert.Equal(t, roleArn, resp.Issuances[0].CspRoleIdentifier)
+	assert.Nil(t, resp.Issuances[0].ExpiresAt)
+	assert.Contains(t, resp.Issuances[1].FailureReason, "no role assigned")
+	for _, issuance := range resp.Issuances {
+		assert.Equal(t, "bob", issuance.Username, "kcUserId 로 사용자명 보완")
+	}
+}
+
+// TC-CREDLEDGER-03: 조회 필터 — CSP 역할 부분 일치, 시간 범위, 요청 IP
+func TestCredentialIssuance_ListFilters(t *testing.T) {
+	issuanceSvc, db := newTestCredentialIssuanceService(t)
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for i, row := range []model.CredentialIssuance{
+		{KcUserID: "kc-a", CspType: "aws", CspRoleName: "mciam-Admin", WorkspaceID: 1, SourceIP: "10.0.0.1", Result: model.AuditResultSuccess},
+		{KcUserID: "kc-b", CspType: "aws", CspRoleIdentifier: "arn:aws:iam::1:role/viewer", WorkspaceID: 1, SourceIP: "10.0.0.2", Result: model.AuditResultSuccess},
+		{KcUserID: "kc-a", CspType: "gcp", CspRoleName: "admin", WorkspaceID: 2, SourceIP: "10.0.0.1", Result: model.AuditResultFailure},
+	} {
+		row.RequestedAt = base.Add(time.Duration(i) * 24 * time.Hour)
+		require.NoError(t, db.Create(&row).Error)
+	}
+
+	resp, err := issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{CspType: "aws", CspRole: "admin", Result: model.AuditResultSuccess})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.Total)
+	assert.Equal(t, "10.0.0.1", resp.Issuances[0].SourceIP)
+
+	resp, err = issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{CspRole: "role/viewer"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+
+	resp, err = issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{From: "2026-03-03T00:00:00Z", SourceIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.Total)
+	assert.Equal(t, "gcp", resp.Issuances[0].CspType)
+
+	_, err = issuanceSvc.ListIssuances(&model.CredentialIssuanceFilter{To: "last week"})
+	assert.True(t, errors.Is(err, ErrInvalidCredentialIssuanceFilter))
+}
+
+// TC-CREDLEDGER-04: 워크스페이스/CSP 집계 — 건수, 성공/실패, 사용자 수, 최근 요청 시각
+func TestCredentialIssuance_Aggregate(t *testing.T) {
+	issuanceSvc, db := newTestCredentialIssuanceService(t)
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for i, row := range []model.CredentialIssuance{
+		{KcUserID: "kc-a", CspType: "aws", WorkspaceID: 1, Result: model.AuditResultSuccess},
+		{KcUserID: "kc-b", CspType: "aws", WorkspaceID: 1, Result: model.AuditResultFailure},
+		{KcUserID: "kc-a", CspType: "aws", WorkspaceID: 1, Result: model.AuditResultSuccess},
+		{KcUserID: "kc-a", CspType: "gcp", WorkspaceID: 2, Result: model.AuditResultSuccess},
+	} {
+		row.RequestedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(&row).Error)
+	}
+
+	rows, err := issuanceSvc.AggregateIssuances(&model.CredentialIssuanceFilter{})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	top := rows[0]
+	require.NotNil(t, top.WorkspaceID)
+	assert.Equal(t, uint(1), *top.WorkspaceID)
+	assert.Equal(t, "aws", top.CspType)
+	assert.Equal(t, int64(3), top.Total)
+	assert.Equal(t, int64(2), top.Succeeded)
+	assert.Equal(t, int64(1), top.Failed)
+	assert.Equal(t, int64(2), top.DistinctUsers)
+	require.NotNil(t, top.LastRequestedAt)
+	assert.True(t, top.LastRequestedAt.Equal(base.Add(2*time.Hour)))
+
+	rows, err = issuanceSvc.AggregateIssuances(&model.CredentialIssuanceFilter{GroupBy: "csp"})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Nil(t, rows[0].WorkspaceID)
+
+	_, err = issuanceSvc.AggregateIssuances(&model.CredentialIssuanceFilter{GroupBy: "region"})
+	assert.True(t, errors.Is(err, ErrInvalidCredentialIssuanceFilter))
+}
@@ -46,6 +46,7 @@ type CspCredentialService struct {
 	tencentCredService  TencentCredentialService
 	ibmCredService      IbmCredentialService
 	keycloakService     KeycloakService
+	issuanceService     *CredentialIssuanceService // 발급 원장 (nil이면 기록하지 않음)
 }
 
 // NewCspCredentialService 새 CspCredentialService 인스턴스 생성
@@ -70,6 +71,7 @@ func NewCspCredentialService(db *gorm.DB) *CspCredentialService {
 		tencentCredService: tencentCredService,
 		ibmCredService:     ibmCredService,
 		keycloakService:    keycloakService,
+		issuanceService:    NewCredentialIssuanceService(db),
 	}
 }
 
@@ -90,7 +92,15 @@ func (s *CspCredentialService) resolveMappingRepo() credMappingRepo {
 }
 
 // GetTemporaryCredentials 사용자의 워크스페이스 역할에 기반하여 CSP 임시 자격 증명 발급
+// 성공/실패 모두 발급 원장에 기록한다.
 func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, userID uint, kcUserId string, req *model.CspCredentialRequest) (*model.CspCredentialResponse, error) {
+	issuance := newCredentialIssuance(ctx, userID, kcUserId, req)
+	cred, err := s.getTemporaryCredentials(ctx, userID, kcUserId, req, issuance)
+	s.recordIssuance(issuance, cred, err)
+	return cred, err
+}
+
+func (s *CspCredentialService) getTemporaryCredentials(ctx context.Context, userID uint, kcUserId string, req *model.CspCredentialRequest, issuance *model.CredentialIssuance) (*model.CspCredentialResponse, error) {
 	log.Printf("[CSP_CREDENTIAL] Starting GetTemporaryCredentials - UserID: %d, WorkspaceID: %s, CspType: %s", userID, req.WorkspaceID, req.CspType)
 
 	workspaceIDInt, err := util.StringToUint(req.WorkspaceID)
@@ -126,31 +136,104 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 	}
 	log.Printf("[CSP_CREDENTIAL] Found user workspace role - RoleID: %d", userWorkspaceRole.RoleID)
 
+	issuance.WorkspaceRoleID = userWorkspaceRole.RoleID
+	issuance.WorkspaceRoleName = userWorkspaceRole.RoleName
+	return s.getTemporaryCredentialsForRole(ctx, kcUserId, userWorkspaceRole.RoleID, req, issuance)
+}
+
+// recordIssuance 발급 원장 기록 (원장 미구성 시 생략)
+func (s *CspCredentialService) recordIssuance(issuance *model.CredentialIssuance, cred *model.CspCredentialResponse, err error) {
+	if s.issuanceService == nil {
+		return
+	}
+	s.issuanceService.Record(issuance, cred, err)
+}
+
+// checkMappingConditions 역할-CSP 역할 매핑 조건 평가 (만족하지 않으면 ErrGrantConditionsNotMet)
+func (s *CspCredentialService) checkMappingConditions(ctx context.Context, kcUserId, workspaceID string, conditions *model.GrantConditions) error {
+	var wsID uint
+	if workspaceID != "" {
+		id, err := util.StringToUint(workspaceID)
+		if err != nil {
+			return fmt.Errorf("invalid workspace ID: %w", err)
+		}
+		wsID = id
+	}
+	authzService := NewAuthzService(s.db)
+	user, err := authzService.ResolveSubject(ctx, "", kcUserId)
+	if err != nil {
+		return err
+	}
+	results, ok, err := authzService.EvaluateGrantConditions(ctx, user.ID, wsID, conditions)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		var unmet []string
+		for _, r := range results {
+			if !r.Satisfied {
+				unmet = append(unmet, r.Condition)
+			}
+		}
+		return fmt.Errorf("%s: %w", strings.Join(unmet, ", "), ErrGrantConditionsNotMet)
+	}
+	return nil
+}
+
+// GetTemporaryCredentialsForRole 이미 확인된 워크스페이스 역할(roleID)로 CSP 임시 자격 증명 발급
+// 워크스페이스 구성원 확인은 호출자(WorkspaceRoleMiddleware 등)가 수행한다. 성공/실패 모두 발급 원장에 기록한다.
+func (s *CspCredentialService) GetTemporaryCredentialsForRole(ctx context.Context, kcUserId string, roleID uint, req *model.CspCredentialRequest) (*model.CspCredentialResponse, error) {
+	issuance := newCredentialIssuance(ctx, 0, kcUserId, req)
+	issuance.WorkspaceRoleID = roleID
+	cred, err := s.getTemporaryCredentialsForRole(ctx, kcUserId, roleID, req, issuance)
+	s.recordIssuance(issuance, cred, err)
+	return cred, err
+}
+
+func (s *CspCredentialService) getTemporaryCredentialsForRole(ctx context.Context, kcUserId string, roleID uint, req *model.CspCredentialRequest, issuance *model.CredentialIssuance) (*model.CspCredentialResponse, error) {
+	cspType := req.CspType
+	region := req.Region
+	if cspType == "" {
+		return nil, fmt.Errorf("csp type is required")
+	}
+
 	// 2. Find the first matching CSP role mapping (authMethod 지정 시 해당 방식 매핑만 조회)
-	log.Printf("[CSP_CREDENTIAL] Finding CSP role mappings for role %d, csp type %s, authMethod %s", userWorkspaceRole.RoleID, cspType, req.AuthMethod)
-	targetMapping, err := s.resolveMappingRepo().FindCspRoleMappingsByRoleIDAndCspType(userWorkspaceRole.RoleID, cspType, req.AuthMethod)
+	log.Printf("[CSP_CREDENTIAL] Finding CSP role mappings for role %d, csp type %s, authMethod %s", roleID, cspType, req.AuthMethod)
+	targetMapping, err := s.resolveMappingRepo().FindCspRoleMappingsByRoleIDAndCspType(roleID, cspType, req.AuthMethod)
 	if err != nil {
-		log.Printf("[CSP_CREDENTIAL] Error finding CSP role mapping for role %d: %v", userWorkspaceRole.RoleID, err)
+		log.Printf("[CSP_CREDENTIAL] Error finding CSP role mapping for role %d: %v", roleID, err)
 	}
 
 	if targetMapping == nil {
-		log.Printf("[CSP_CREDENTIAL] Error: No CSP role mappings found for role %d and csp type %s", userWorkspaceRole.RoleID, cspType)
+		log.Printf("[CSP_CREDENTIAL] Error: No CSP role mappings found for role %d and csp type %s", roleID, cspType)
 		return nil, ErrNoCspRoleMappingFound
 	}
 
+	// 조건부 매핑이면 요청 속성(IP, 시각, 조직, 워크스페이스 라벨)으로 평가
+	if !targetMapping.Conditions.IsEmpty() {
+		if err := s.checkMappingConditions(ctx, kcUserId, req.WorkspaceID, targetMapping.Conditions); err != nil {
+			log.Printf("[CSP_CREDENTIAL] CSP role mapping conditions for role %d: %v", roleID, err)
+			return nil, err
+		}
+	}
+
 	// CspRoles 배열에서 첫 번째 요소를 사용
 	if len(targetMapping.CspRoles) == 0 {
 		log.Printf("[CSP_CREDENTIAL] Error: No CSP roles found in mapping")
 		return nil, fmt.Errorf("CSP 역할 정보가 없습니다")
 	}
 	targetCspRole := targetMapping.CspRoles[0]
-	log.Printf("[CSP_CREDENTIAL] Found CSP role mapping - RoleID: %d, CspRoleID: %d", targetMapping.RoleID, targetCspRole.ID)
 
 	// 3. IDP ARN 가져오기
 	if targetCspRole == nil {
 		log.Printf("[CSP_CREDENTIAL] Error: CSP role information is nil")
 		return nil, fmt.Errorf("CSP 역할 정보가 없습니다")
 	}
+	log.Printf("[CSP_CREDENTIAL] Found CSP role mapping - RoleID: %d, CspRoleID: %d", targetMapping.RoleID, targetCspRole.ID)
+	cspRoleID := targetCspRole.ID
+	issuance.CspRoleID = &cspRoleID
+	issuance.CspRoleName = targetCspRole.Name
+	issuance.CspRoleIdentifier = targetCspRole.IamIdentifier
 	idpArn := targetCspRole.IdpIdentifier
 	if idpArn == "" {
 		log.Printf("[CSP_CREDENTIAL] Error: IDP ARN is empty")
@@ -178,6 +261,7 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 		}
 	}
 	log.Printf("[CSP_CREDENTIAL] Auth method resolved: cspType=%s, authMethod=%s", cspType, authMethod)
+	issuance.AuthMethod = string(authMethod)
 
 	// 6. Dispatch by (cspType, authMethod)
 	switch cspType {
@@ -172,10 +172,18 @@ func (s *CspIdpConfigService) TestConnection(ctx context.Context, id uint) error
 	// CSP 계정 정보 조회
 	account, err := s.cspAccountRepo.GetByID(idpConfig.CspAccountID)
 	if err != nil {
-		return fmt.Errorf("failed to get CSP account: %w", err)
+		err = fmt.Errorf("failed to get CSP account: %w", err)
+	} else {
+		err = s.testConnection(ctx, idpConfig, account)
 	}
 
-	// 인증 방식에 따른 연결 테스트
+	// 결과를 마지막 연결 상태로 저장 (보고서에서 실패 설정 조회에 사용)
+	s.recordHealthCheck(ctx, id, err)
+	return err
+}
+
+// testConnection 인증 방식에 따른 연결 테스트
+func (s *CspIdpConfigService) testConnection(ctx context.Context, idpConfig *model.CspIdpConfig, account *model.CspAccount) error {
 	switch idpConfig.AuthMethod {
 	case model.AuthMethodOIDC:
 		return s.testOidcConnection(ctx, idpConfig, account)
@@ -188,6 +196,29 @@ func (s *CspIdpConfigService) TestConnection(ctx context.Context, id uint) error
 	}
 }
 
+// healthStatus 연결 테스트 결과를 상태값으로 변환
+func healthStatus(ctx context.Context, err error) string {
+	switch {
+	case err == nil:
+		return model.HealthStatusConnected
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return model.HealthStatusTimeout
+	default:
+		return model.HealthStatusFailed
+	}
+}
+
+// recordHealthCheck 연결 테스트 결과 저장 (저장 실패는 로그만 남김)
+func (s *CspIdpConfigService) recordHealthCheck(ctx context.Context, id uint, testErr error) {
+	errMsg := ""
+	if testErr != nil {
+		errMsg = testErr.Error()
+	}
+	if err := s.cspIdpConfigRepo.UpdateHealthCheck(id, healthStatus(ctx, testErr), errMsg, time.Now()); err != nil {
+		log.Printf("Failed to record health check for IDP config %d: %v", id, err)
+	}
+}
+
 // testOidcConnection OIDC 연결 테스트
 func (s *CspIdpConfigService) testOidcConnection(ctx context.Context, idpConfig *model.CspIdpConfig, account *model.CspAccount) error {
 	switch account.CspType {
@@ -563,16 +594,12 @@ func (s *CspIdpConfigService) BulkHealthCheck(filter *model.BulkHealthCheckReque
 
 			err := s.TestConnection(ctx, cfg.ID)
 			if err != nil {
-				status := "FAILED"
-				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-					status = "TIMEOUT"
-				}
 				results[i] = model.HealthCheckResult{
 					ConfigID:   cfg.ID,
 					ConfigName: cfg.Name,
 					CspType:    cspType,
 					AuthMethod: string(cfg.AuthMethod),
-					Status:     status,
+					Status:     healthStatus(ctx, err),
 					ErrorMsg:   err.Error(),
 					CheckedAt:  checkedAt,
 				}
@@ -584,7 +611,7 @@ func (s *CspIdpConfigService) BulkHealthCheck(filter *model.BulkHealthCheckReque
 				ConfigName: cfg.Name,
 				CspType:    cspType,
 				AuthMethod: string(cfg.AuthMethod),
-				Status:     "CONNECTED",
+				Status:     model.HealthStatusConnected,
 				CheckedAt:  checkedAt,
 			}
 		}(i, cfg)
@@ -594,7 +621,7 @@ func (s *CspIdpConfigService) BulkHealthCheck(filter *model.BulkHealthCheckReque
 	connectedCount := 0
 	failedCount := 0
 	for _, r := range results {
-		if r.Status == "CONNECTED" {
+		if r.Status == model.HealthStatusConnected {
 			connectedCount++
 		} else {
 			failedCount++
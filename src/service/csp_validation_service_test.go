@@ -49,6 +49,9 @@ func (m *mockValKcService) GetSamlAssertionByServiceAccount(ctx context.Context,
 func (m *mockValKcService) CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error) {
 	return "", nil
 }
+func (m *mockValKcService) GetLastLoginTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	return nil, nil
+}
 
 type mockValAwsService struct {
 	oidcResult *model.CspCredentialResponse
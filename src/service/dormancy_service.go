@@ -0,0 +1,357 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidDormancyExemption = errors.New("invalid dormancy exemption")
+)
+
+const (
+	defaultDormancyCheckInterval  = time.Hour
+	defaultTokenUseRecordInterval = 5 * time.Minute
+	dormancyNotifyTimeout         = 10 * time.Second
+	dormancyDay                   = 24 * time.Hour
+	dormancySystemActor           = "system"
+)
+
+// userDeactivator 휴면 계정 비활성화 (UserService.DeactivateUser: Keycloak 비활성화 + 상태 INACTIVE)
+type userDeactivator interface {
+	DeactivateUser(ctx context.Context, userID uint, requestorKcID string) error
+}
+
+// DormancyNotifier 휴면 경고/비활성화 알림 전달
+type DormancyNotifier interface {
+	Notify(ctx context.Context, notice *model.DormancyNotice) error
+}
+
+// DormancyService 사용자 활동 시각 기록과 휴면 계정 정책 (경고 → 비활성화)
+type DormancyService struct {
+	userRepo     *repository.UserRepository
+	deactivator  userDeactivator
+	kcService    KeycloakService
+	auditService *AuditService    // nil 이면 감사 기록하지 않음
+	notifier     DormancyNotifier // nil 이면 감사/보안 이벤트 외 알림 없음
+	policy       model.DormancyPolicy
+
+	tokenUseInterval time.Duration
+	tokenUseSeen     sync.Map // kcUserID → 마지막으로 토큰 사용을 기록한 시각
+}
+
+// NewDormancyService DormancyService 생성 (정책은 환경 변수에서 읽음)
+func NewDormancyService(db *gorm.DB) *DormancyService {
+	s := &DormancyService{
+		userRepo:         repository.NewUserRepository(db),
+		deactivator:      NewUserService(db),
+		kcService:        NewKeycloakService(),
+		auditService:     NewAuditService(db),
+		policy:           loadDormancyPolicy(),
+		tokenUseInterval: dormancyEnvDuration("MC_IAM_MANAGER_TOKEN_USE_RECORD_INTERVAL", defaultTokenUseRecordInterval),
+	}
+	if url := os.Getenv("MC_IAM_MANAGER_DORMANT_WEBHOOK_URL"); url != "" {
+		s.notifier = &webhookDormancyNotifier{url: url, client: &http.Client{Timeout: dormancyNotifyTimeout}}
+	}
+	return s
+}
+
+// Policy 적용 중인 휴면 정책
+func (s *DormancyService) Policy() model.DormancyPolicy {
+	return s.policy
+}
+
+// RecordActivity 로그인/토큰 갱신 시각 기록 (기록 실패는 로그만 남김)
+func (s *DormancyService) RecordActivity(kcUserID, kind string) {
+	if err := s.userRepo.RecordActivity(kcUserID, kind, time.Now()); err != nil {
+		log.Printf("[DORMANCY] %v", err)
+	}
+}
+
+// RecordTokenUse 토큰 사용 시각 기록 (사용자별로 tokenUseInterval 에 한 번만 DB 에 쓴다)
+func (s *DormancyService) RecordTokenUse(kcUserID string) {
+	now := time.Now()
+	if last, ok := s.tokenUseSeen.Load(kcUserID); ok && now.Sub(last.(time.Time)) < s.tokenUseInterval {
+		return
+	}
+	s.tokenUseSeen.Store(kcUserID, now)
+	if err := s.userRepo.RecordActivity(kcUserID, model.UserActivityTokenUse, now); err != nil {
+		log.Printf("[DORMANCY] %v", err)
+	}
+}
+
+// SetExemption 휴면 정책 예외 설정 (SERVICE_ACCOUNT, BREAK_GLASS, 빈 값이면 해제), 변경 전 예외 사유를 함께 반환
+func (s *DormancyService) SetExemption(userID uint, exemption string) (*model.User, string, error) {
+	switch exemption {
+	case "", model.DormancyExemptionServiceAccount, model.DormancyExemptionBreakGlass:
+	default:
+		return nil, "", fmt.Errorf("%w: %q (allowed: %s, %s or empty)", ErrInvalidDormancyExemption,
+			exemption, model.DormancyExemptionServiceAccount, model.DormancyExemptionBreakGlass)
+	}
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	previous := user.DormancyExemption
+	if err := s.userRepo.UpdateDormancyExemption(userID, exemption); err != nil {
+		return nil, "", err
+	}
+	user.DormancyExemption = exemption
+	return user, previous, nil
+}
+
+// RunDormancyCheck 휴면 정책 1회 적용
+// 마지막 활동 후 DeactivateDays 가 지나면 비활성화하고, WarnDays 가 지났고 아직 경고하지 않았으면 경고한다.
+// DB 에 기록되지 않은 로그인(다른 클라이언트로 Keycloak 에 직접 로그인)은 Keycloak LOGIN 이벤트로 보완한다.
+func (s *DormancyService) RunDormancyCheck(ctx context.Context, now time.Time) (*model.DormancyRunResult, error) {
+	result := &model.DormancyRunResult{Warned: []string{}, Deactivated: []string{}}
+	threshold := s.policy.WarnDays
+	if threshold <= 0 || (s.policy.DeactivateDays > 0 && s.policy.DeactivateDays < threshold) {
+		threshold = s.policy.DeactivateDays
+	}
+	if threshold <= 0 {
+		return result, nil
+	}
+	since := now.AddDate(0, 0, -threshold)
+	exemptRole := ""
+	if s.policy.ExemptPlatformAdmins {
+		exemptRole = platformAdminRoleName
+	}
+	candidates, err := s.userRepo.FindDormancyCandidates(since, exemptRole)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+	kcLogins, err := s.kcService.GetLastLoginTimes(ctx, since)
+	if err != nil {
+		log.Printf("[DORMANCY] failed to read Keycloak login events, using recorded activity only: %v", err)
+	}
+
+	for i := range candidates {
+		user := &candidates[i]
+		last := user.CreatedAt
+		if user.LastActivityAt != nil {
+			last = *user.LastActivityAt
+		}
+		if login, ok := kcLogins[user.KcId]; ok && login.After(last) {
+			if err := s.userRepo.RecordActivity(user.KcId, model.UserActivityLogin, login); err != nil {
+				log.Printf("[DORMANCY] %v", err)
+			}
+			continue
+		}
+		inactiveDays := int(now.Sub(last) / dormancyDay)
+
+		if s.policy.DeactivateDays > 0 && inactiveDays >= s.policy.DeactivateDays {
+			if err := s.deactivator.DeactivateUser(ctx, user.ID, ""); err != nil {
+				s.recordFailure(result, user, err)
+				continue
+			}
+			notice := s.notice(ctx, model.DormancyNoticeDeactivated, user, inactiveDays)
+			s.recordAudit(model.AuditActionDormancyDeactivate, user, notice)
+			if err := s.notify(ctx, notice); err != nil {
+				log.Printf("[DORMANCY] failed to notify deactivation of %s: %v", user.Username, err)
+			}
+			result.Deactivated = append(result.Deactivated, user.Username)
+			continue
+		}
+
+		if s.policy.WarnDays > 0 && inactiveDays >= s.policy.WarnDays && user.DormancyWarnedAt == nil {
+			notice := s.notice(ctx, model.DormancyNoticeWarning, user, inactiveDays)
+			if s.policy.DeactivateDays > 0 {
+				deactivateAt := last.AddDate(0, 0, s.policy.DeactivateDays)
+				notice.DeactivateAt = &deactivateAt
+			}
+			// 알림 실패 시 경고 시각을 남기지 않아 다음 주기에 다시 시도
+			if err := s.notify(ctx, notice); err != nil {
+				s.recordFailure(result, user, err)
+				continue
+			}
+			if err := s.userRepo.MarkDormancyWarned(user.ID, now); err != nil {
+				s.recordFailure(result, user, err)
+				continue
+			}
+			s.recordAudit(model.AuditActionDormancyWarn, user, notice)
+			result.Warned = append(result.Warned, user.Username)
+		}
+	}
+	return result, nil
+}
+
+// StartDormancyCheck 주기적으로 휴면 정책 적용
+// 주기는 MC_IAM_MANAGER_DORMANT_CHECK_INTERVAL (기본 1h), 경고/비활성화 기간이 모두 0 이면 시작하지 않음
+// 시작 전에 활동 기록이 없는 사용자(정책 도입 전 계정)의 마지막 활동을 현재 시각으로 두어, 도입 직후 일괄 비활성화되지 않게 한다.
+func (s *DormancyService) StartDormancyCheck(ctx context.Context) {
+	if s.policy.WarnDays <= 0 && s.policy.DeactivateDays <= 0 {
+		return
+	}
+	baselined, err := s.userRepo.BaselineLastActivity(time.Now())
+	if err != nil {
+		log.Printf("[DORMANCY] policy not started: %v", err)
+		return
+	}
+	if baselined > 0 {
+		log.Printf("[DORMANCY] %d users without recorded activity start counting from now", baselined)
+	}
+	interval := dormancyEnvDuration("MC_IAM_MANAGER_DORMANT_CHECK_INTERVAL", defaultDormancyCheckInterval)
+	log.Printf("[DORMANCY] policy enabled: warn after %d days, deactivate after %d days, every %s",
+		s.policy.WarnDays, s.policy.DeactivateDays, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.RunDormancyCheck(ctx, time.Now())
+				if err != nil {
+					log.Printf("[DORMANCY] failed to apply dormancy policy: %v", err)
+					continue
+				}
+				if len(result.Warned) > 0 || len(result.Deactivated) > 0 || len(result.Failed) > 0 {
+					log.Printf("[DORMANCY] warned=%d deactivated=%d failed=%d",
+						len(result.Warned), len(result.Deactivated), len(result.Failed))
+				}
+			}
+		}
+	}()
+}
+
+// notice 알림 내용 (알림 대상이 있으면 Keycloak 에서 이메일 조회)
+func (s *DormancyService) notice(ctx context.Context, noticeType string, user *model.User, inactiveDays int) *model.DormancyNotice {
+	notice := &model.DormancyNotice{
+		Type:           noticeType,
+		UserID:         user.ID,
+		Username:       user.Username,
+		LastActivityAt: user.LastActivityAt,
+		InactiveDays:   inactiveDays,
+	}
+	if s.notifier != nil {
+		if kcUser, err := s.kcService.GetUser(ctx, user.KcId); err == nil && kcUser != nil {
+			notice.Email = ptrStr(kcUser.Email)
+		}
+	}
+	return notice
+}
+
+func (s *DormancyService) notify(ctx context.Context, notice *model.DormancyNotice) error {
+	if s.notifier == nil {
+		return nil
+	}
+	return s.notifier.Notify(ctx, notice)
+}
+
+func (s *DormancyService) recordFailure(result *model.DormancyRunResult, user *model.User, err error) {
+	log.Printf("[DORMANCY] user %s (id=%d): %v", user.Username, user.ID, err)
+	if result.Failed == nil {
+		result.Failed = map[string]string{}
+	}
+	result.Failed[user.Username] = err.Error()
+}
+
+// recordAudit 경고/비활성화 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록, 보안 이벤트 싱크로도 전달됨)
+func (s *DormancyService) recordAudit(action string, user *model.User, notice *model.DormancyNotice) {
+	if s.auditService == nil {
+		return
+	}
+	event := &model.AuditEvent{
+		ActorUsername: dormancySystemActor,
+		Method:        "SYSTEM",
+		Route:         "user.dormancy",
+		Path:          fmt.Sprintf("/api/users/id/%d", user.ID),
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:     action,
+		EntityType: model.AuditEntityUser,
+		EntityID:   fmt.Sprint(user.ID),
+		After:      notice,
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[DORMANCY] failed to record audit event for user %d: %v", user.ID, err)
+	}
+}
+
+// webhookDormancyNotifier 알림을 JSON 으로 웹훅에 POST (메일/메신저 연동은 수신 측에서 처리)
+type webhookDormancyNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify 웹훅 호출 (2xx 가 아니면 오류)
+func (n *webhookDormancyNotifier) Notify(ctx context.Context, notice *model.DormancyNotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("dormancy webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("dormancy webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// loadDormancyPolicy 휴면 정책 환경 변수 (MC_IAM_MANAGER_DORMANT_WARN_DAYS, MC_IAM_MANAGER_DORMANT_DEACTIVATE_DAYS, 기본 0 = 사용 안 함)
+func loadDormancyPolicy() model.DormancyPolicy {
+	policy := model.DormancyPolicy{
+		WarnDays:       dormancyEnvDays("MC_IAM_MANAGER_DORMANT_WARN_DAYS"),
+		DeactivateDays: dormancyEnvDays("MC_IAM_MANAGER_DORMANT_DEACTIVATE_DAYS"),
+		// platformAdmin 은 초기 관리자·비상 복구 경로이므로 명시적으로 끄지 않으면 제외
+		ExemptPlatformAdmins: os.Getenv("MC_IAM_MANAGER_DORMANT_EXEMPT_PLATFORM_ADMIN") != "false",
+	}
+	if policy.DeactivateDays > 0 && policy.WarnDays >= policy.DeactivateDays {
+		log.Printf("[DORMANCY] MC_IAM_MANAGER_DORMANT_WARN_DAYS (%d) must be less than MC_IAM_MANAGER_DORMANT_DEACTIVATE_DAYS (%d), warnings disabled",
+			policy.WarnDays, policy.DeactivateDays)
+		policy.WarnDays = 0
+	}
+	return policy
+}
+
+func dormancyEnvDays(key string) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		log.Printf("[DORMANCY] invalid %s %q, disabled", key, raw)
+		return 0
+	}
+	return days
+}
+
+func dormancyEnvDuration(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+		return d
+	}
+	log.Printf("[DORMANCY] invalid %s %q, using %s", key, raw, fallback)
+	return fallback
+}
@@ -0,0 +1,294 @@
+package service
+
+// dormancy_service_test.go
+//
+// DormancyService 단위 테스트 (SQLite in-memory DB)
+// 로그인/토큰 갱신/토큰 사용 시각 기록, 휴면 경고(1회) → 비활성화 단계, 예외 계정 제외,
+// Keycloak LOGIN 이벤트 보완, 알림 실패 시 재시도를 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+// fakeDeactivator 비활성화 요청을 기록하고 DB 상태만 INACTIVE 로 변경 (Keycloak 호출 없음)
+type fakeDeactivator struct {
+	db  *gorm.DB
+	ids []uint
+	err error
+}
+
+func (f *fakeDeactivator) DeactivateUser(ctx context.Context, userID uint, requestorKcID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.ids = append(f.ids, userID)
+	return f.db.Model(&model.User{}).Where("id = ?", userID).Update("status", model.UserStatusInactive).Error
+}
+
+// fakeDormancyNotifier 알림 내용을 기록
+type fakeDormancyNotifier struct {
+	notices []model.DormancyNotice
+	err     error
+}
+
+func (n *fakeDormancyNotifier) Notify(ctx context.Context, notice *model.DormancyNotice) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.notices = append(n.notices, *notice)
+	return nil
+}
+
+func newTestDormancyService(t *testing.T, policy model.DormancyPolicy) (*DormancyService, *gorm.DB, *fakeDeactivator, *fakeDormancyNotifier, *recordingKeycloak) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	deactivator := &fakeDeactivator{db: db}
+	notifier := &fakeDormancyNotifier{}
+	kc := &recordingKeycloak{}
+	return &DormancyService{
+		userRepo:         repository.NewUserRepository(db),
+		deactivator:      deactivator,
+		kcService:        kc,
+		notifier:         notifier,
+		policy:           policy,
+		tokenUseInterval: time.Minute,
+	}, db, deactivator, notifier, kc
+}
+
+// createDormancyTestUser 마지막 활동이 daysAgo 일 전인 활성 사용자 (daysAgo < 0 이면 활동 기록 없음, 생성 60일 전)
+func createDormancyTestUser(t *testing.T, db *gorm.DB, username string, daysAgo int, exemption string) *model.User {
+	t.Helper()
+	u := &model.User{
+		Username:          username,
+		KcId:              "kc-" + username,
+		Status:            model.UserStatusActive,
+		CreatedAt:         time.Now().AddDate(0, 0, -60),
+		DormancyExemption: exemption,
+	}
+	if daysAgo >= 0 {
+		last := time.Now().AddDate(0, 0, -daysAgo)
+		u.LastActivityAt = &last
+	}
+	require.NoError(t, db.Create(u).Error)
+	return u
+}
+
+func reloadDormancyTestUser(t *testing.T, db *gorm.DB, id uint) *model.User {
+	t.Helper()
+	var u model.User
+	require.NoError(t, db.First(&u, id).Error)
+	return &u
+}
+
+// ── 활동 기록 ─────────────────────────────────────────────────────────────────
+
+// TC-DORM-ACT-01: 로그인/토큰 갱신 시 종류별 시각과 마지막 활동 시각 기록, 휴면 경고 초기화
+func TestDormancyRecordActivity(t *testing.T) {
+	svc, db, _, _, _ := newTestDormancyService(t, model.DormancyPolicy{})
+	u := createDormancyTestUser(t, db, "alice", 40, "")
+	require.NoError(t, db.Model(u).Update("dormancy_warned_at", time.Now()).Error)
+
+	svc.RecordActivity(u.KcId, model.UserActivityLogin)
+	got := reloadDormancyTestUser(t, db, u.ID)
+	require.NotNil(t, got.LastLoginAt)
+	require.NotNil(t, got.LastActivityAt)
+	assert.WithinDuration(t, time.Now(), *got.LastLoginAt, time.Minute)
+	assert.Equal(t, got.LastLoginAt.Unix(), got.LastActivityAt.Unix())
+	assert.Nil(t, got.DormancyWarnedAt)
+	assert.Nil(t, got.LastRefreshAt)
+
+	svc.RecordActivity(u.KcId, model.UserActivityRefresh)
+	got = reloadDormancyTestUser(t, db, u.ID)
+	assert.NotNil(t, got.LastRefreshAt)
+
+	assert.Error(t, svc.userRepo.RecordActivity(u.KcId, "unknown", time.Now()))
+}
+
+// TC-DORM-ACT-02: 토큰 사용은 사용자별 기록 간격 안에서 한 번만 기록
+func TestDormancyRecordTokenUseThrottled(t *testing.T) {
+	svc, db, _, _, _ := newTestDormancyService(t, model.DormancyPolicy{})
+	u := createDormancyTestUser(t, db, "alice", -1, "")
+
+	svc.RecordTokenUse(u.KcId)
+	first := reloadDormancyTestUser(t, db, u.ID).LastTokenUseAt
+	require.NotNil(t, first)
+
+	require.NoError(t, db.Model(u).Update("last_token_use_at", nil).Error)
+	svc.RecordTokenUse(u.KcId)
+	assert.Nil(t, reloadDormancyTestUser(t, db, u.ID).LastTokenUseAt, "second use within interval must not be written")
+
+	svc.tokenUseSeen.Store(u.KcId, time.Now().Add(-2*time.Minute))
+	svc.RecordTokenUse(u.KcId)
+	assert.NotNil(t, reloadDormancyTestUser(t, db, u.ID).LastTokenUseAt)
+}
+
+// ── 정책 적용 ─────────────────────────────────────────────────────────────────
+
+// TC-DORM-RUN-01: 경고 기간 경과 → 1회 경고, 비활성화 기간 경과 → 비활성화, 예외/최근 활동 사용자는 제외
+func TestDormancyRunWarnsAndDeactivates(t *testing.T) {
+	svc, db, deactivator, notifier, _ := newTestDormancyService(t, model.DormancyPolicy{WarnDays: 30, DeactivateDays: 45})
+	recent := createDormancyTestUser(t, db, "recent", 5, "")
+	warn := createDormancyTestUser(t, db, "warn", 35, "")
+	never := createDormancyTestUser(t, db, "never", -1, "")
+	deactivate := createDormancyTestUser(t, db, "deactivate", 50, "")
+	createDormancyTestUser(t, db, "robot", 100, model.DormancyExemptionServiceAccount)
+	createDormancyTestUser(t, db, "breakglass", 100, model.DormancyExemptionBreakGlass)
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"warn"}, result.Warned)
+	assert.ElementsMatch(t, []string{"never", "deactivate"}, result.Deactivated)
+	assert.ElementsMatch(t, []uint{never.ID, deactivate.ID}, deactivator.ids)
+	assert.Empty(t, result.Failed)
+
+	require.Len(t, notifier.notices, 3)
+	var warning *model.DormancyNotice
+	for i := range notifier.notices {
+		if notifier.notices[i].Type == model.DormancyNoticeWarning {
+			warning = &notifier.notices[i]
+		}
+	}
+	require.NotNil(t, warning)
+	assert.Equal(t, warn.ID, warning.UserID)
+	assert.Equal(t, 35, warning.InactiveDays)
+	require.NotNil(t, warning.DeactivateAt)
+	assert.WithinDuration(t, warn.LastActivityAt.AddDate(0, 0, 45), *warning.DeactivateAt, time.Second)
+	assert.NotNil(t, reloadDormancyTestUser(t, db, warn.ID).DormancyWarnedAt)
+	assert.Nil(t, reloadDormancyTestUser(t, db, recent.ID).DormancyWarnedAt)
+
+	// 다시 실행해도 이미 경고한 사용자는 다시 경고하지 않음
+	result, err = svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Warned)
+	assert.Empty(t, result.Deactivated)
+	assert.Len(t, notifier.notices, 3)
+}
+
+// TC-DORM-RUN-02: Keycloak 에 기간 내 LOGIN 이벤트가 있으면 로그인으로 기록하고 제외
+func TestDormancyRunUsesKeycloakLogins(t *testing.T) {
+	svc, db, deactivator, _, kc := newTestDormancyService(t, model.DormancyPolicy{DeactivateDays: 30})
+	u := createDormancyTestUser(t, db, "sso", 40, "")
+	login := time.Now().AddDate(0, 0, -2).Truncate(time.Second)
+	kc.lastLogin = map[string]time.Time{u.KcId: login}
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Deactivated)
+	assert.Empty(t, deactivator.ids)
+	got := reloadDormancyTestUser(t, db, u.ID)
+	require.NotNil(t, got.LastLoginAt)
+	assert.True(t, got.LastLoginAt.Equal(login))
+}
+
+// TC-DORM-RUN-03: 알림 실패 시 경고 시각을 남기지 않아 다음 실행에서 재시도, 비활성화 실패는 결과에 기록
+func TestDormancyRunFailures(t *testing.T) {
+	svc, db, deactivator, notifier, _ := newTestDormancyService(t, model.DormancyPolicy{WarnDays: 30, DeactivateDays: 45})
+	warn := createDormancyTestUser(t, db, "warn", 35, "")
+	createDormancyTestUser(t, db, "deactivate", 50, "")
+	notifier.err = errors.New("webhook down")
+	deactivator.err = errors.New("keycloak down")
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Warned)
+	assert.Empty(t, result.Deactivated)
+	assert.Equal(t, map[string]string{"warn": "webhook down", "deactivate": "keycloak down"}, result.Failed)
+	assert.Nil(t, reloadDormancyTestUser(t, db, warn.ID).DormancyWarnedAt)
+
+	notifier.err = nil
+	result, err = svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"warn"}, result.Warned)
+}
+
+// TC-DORM-RUN-04: 경고/비활성화 기간이 모두 0 이면 아무것도 하지 않음
+func TestDormancyRunDisabled(t *testing.T) {
+	svc, db, deactivator, notifier, _ := newTestDormancyService(t, model.DormancyPolicy{})
+	createDormancyTestUser(t, db, "old", 500, "")
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Warned)
+	assert.Empty(t, result.Deactivated)
+	assert.Empty(t, deactivator.ids)
+	assert.Empty(t, notifier.notices)
+}
+
+// TC-DORM-RUN-05: platformAdmin 보유자(직접/그룹)는 기본 제외, 정책을 끄면 대상
+func TestDormancyRunExemptsPlatformAdmins(t *testing.T) {
+	policy := model.DormancyPolicy{DeactivateDays: 45, ExemptPlatformAdmins: true}
+	svc, db, deactivator, _, _ := newTestDormancyService(t, policy)
+	admin := createDormancyTestUser(t, db, "admin", 100, "")
+	grouped := createDormancyTestUser(t, db, "grouped", 100, "")
+	plain := createDormancyTestUser(t, db, "plain", 100, "")
+	role := createGRTestRole(t, db, platformAdminRoleName)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: admin.ID, RoleID: role.ID}).Error)
+	org := createGRTestOrg(t, db, "admins", "ADMINS")
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: org.ID, RoleID: role.ID}).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: grouped.ID, OrganizationID: org.ID}).Error)
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plain"}, result.Deactivated)
+	assert.Equal(t, []uint{plain.ID}, deactivator.ids)
+
+	svc.policy.ExemptPlatformAdmins = false
+	result, err = svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"admin", "grouped"}, result.Deactivated)
+}
+
+// TC-DORM-RUN-06: 정책 시작 시 활동 기록이 없는 기존 사용자는 시작 시각부터 계산 (생성 시각 기준으로 즉시 비활성화하지 않음)
+func TestDormancyStartBaselinesUsersWithoutActivity(t *testing.T) {
+	svc, db, deactivator, _, _ := newTestDormancyService(t, model.DormancyPolicy{DeactivateDays: 45})
+	never := createDormancyTestUser(t, db, "never", -1, "")
+	old := createDormancyTestUser(t, db, "old", 50, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc.StartDormancyCheck(ctx)
+	got := reloadDormancyTestUser(t, db, never.ID)
+	require.NotNil(t, got.LastActivityAt)
+	assert.WithinDuration(t, time.Now(), *got.LastActivityAt, time.Minute)
+	assert.WithinDuration(t, *old.LastActivityAt, *reloadDormancyTestUser(t, db, old.ID).LastActivityAt, time.Second)
+
+	result, err := svc.RunDormancyCheck(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old"}, result.Deactivated)
+	assert.Equal(t, []uint{old.ID}, deactivator.ids)
+}
+
+// ── 예외 설정 ─────────────────────────────────────────────────────────────────
+
+// TC-DORM-EXM-01: 허용된 예외 사유만 설정 가능, 빈 값이면 해제, 변경 전 값 반환
+func TestDormancySetExemption(t *testing.T) {
+	svc, db, _, _, _ := newTestDormancyService(t, model.DormancyPolicy{})
+	u := createDormancyTestUser(t, db, "robot", -1, "")
+
+	_, _, err := svc.SetExemption(u.ID, "VIP")
+	assert.True(t, errors.Is(err, ErrInvalidDormancyExemption))
+
+	got, previous, err := svc.SetExemption(u.ID, model.DormancyExemptionServiceAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "", previous)
+	assert.Equal(t, model.DormancyExemptionServiceAccount, got.DormancyExemption)
+
+	_, previous, err = svc.SetExemption(u.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.DormancyExemptionServiceAccount, previous)
+	assert.Equal(t, "", reloadDormancyTestUser(t, db, u.ID).DormancyExemption)
+
+	_, _, err = svc.SetExemption(9999, "")
+	assert.True(t, errors.Is(err, repository.ErrUserNotFound))
+}
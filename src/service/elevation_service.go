@@ -0,0 +1,522 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidElevationRequest  = errors.New("invalid elevation request")
+	ErrElevationAlreadyOpen     = errors.New("an elevation request for this role is already pending or active")
+	ErrElevationRoleAlreadyHeld = errors.New("role is already assigned in the workspace")
+	ErrElevationNotPending      = errors.New("elevation request is not pending")
+	ErrElevationNotActive       = errors.New("elevation is not active")
+	ErrElevationSelfApproval    = errors.New("approvers cannot decide on their own elevation request")
+)
+
+// 권한 승격 권한
+const (
+	ElevationManagePermission  = "mc-iam-manager:elevation:manage"  // 모든 요청 결정, 전체 조회, 회수
+	ElevationApprovePermission = "mc-iam-manager:elevation:approve" // 소속 워크스페이스 요청 결정
+)
+
+const (
+	defaultElevationMinutes        = 60
+	defaultElevationMaxMinutes     = 480
+	defaultElevationExpiryInterval = time.Minute
+	elevationSystemActor           = "system"
+)
+
+// ElevationService 기한부 권한 승격(JIT) 요청/승인/자동 회수
+// 승인되면 만료 시각이 지정된 워크스페이스 역할 할당을 만들고, 만료되면 할당을 삭제하고 요청을 expired 로 닫는다.
+// csp-role 유형은 해당 CSP 역할이 매핑된 워크스페이스 역할을 승격하며, 유효한 동안 그 CSP 타입의 임시 자격 증명은 승격 역할로 발급된다.
+type ElevationService struct {
+	elevationRepo *repository.ElevationRepository
+	roleRepo      *repository.RoleRepository
+	workspaceRepo *repository.WorkspaceRepository
+	roleService   *RoleService
+	authzService  *AuthzService
+	auditService  *AuditService // nil 이면 자동 만료 감사 기록하지 않음
+	policy        model.ElevationPolicy
+}
+
+// NewElevationService ElevationService 생성 (기간 정책은 환경 변수에서 읽음)
+func NewElevationService(db *gorm.DB) *ElevationService {
+	return &ElevationService{
+		elevationRepo: repository.NewElevationRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+		roleService:   NewRoleService(db),
+		authzService:  NewAuthzService(db),
+		auditService:  NewAuditService(db),
+		policy:        loadElevationPolicy(),
+	}
+}
+
+// Policy 적용 중인 승격 기간 정책
+func (s *ElevationService) Policy() model.ElevationPolicy {
+	return s.policy
+}
+
+// ResolveUser 요청자의 Keycloak ID 로 DB 사용자 조회
+func (s *ElevationService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// Create 승격 요청 생성 (대상 워크스페이스/역할 확인, 기간은 정책 범위 안)
+func (s *ElevationService) Create(req *model.CreateElevationRequest, requester *model.User) (*model.ElevationRequest, error) {
+	justification := strings.TrimSpace(req.Justification)
+	if justification == "" {
+		return nil, fmt.Errorf("%w: justification is required", ErrInvalidElevationRequest)
+	}
+	elevationType := req.Type
+	if elevationType == "" {
+		elevationType = model.ElevationTypeWorkspaceRole
+		if req.CspRoleID != nil {
+			elevationType = model.ElevationTypeCspRole
+		}
+	}
+	if elevationType != model.ElevationTypeWorkspaceRole && elevationType != model.ElevationTypeCspRole {
+		return nil, fmt.Errorf("%w: type must be workspace-role or csp-role: %s", ErrInvalidElevationRequest, elevationType)
+	}
+	duration := req.DurationMinutes
+	if duration == 0 {
+		duration = s.policy.DefaultMinutes
+	}
+	if duration < 0 || duration > s.policy.MaxMinutes {
+		return nil, fmt.Errorf("%w: durationMinutes must be between 1 and %d", ErrInvalidElevationRequest, s.policy.MaxMinutes)
+	}
+
+	if req.WorkspaceID == 0 {
+		return nil, fmt.Errorf("%w: workspaceId is required", ErrInvalidElevationRequest)
+	}
+	workspace, err := s.workspaceRepo.FindWorkspaceByID(req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, fmt.Errorf("%w: workspace %d not found", ErrInvalidElevationRequest, req.WorkspaceID)
+	}
+
+	elevation := &model.ElevationRequest{
+		Type:              elevationType,
+		RequesterID:       requester.ID,
+		RequesterKcID:     requester.KcId,
+		RequesterUsername: requester.Username,
+		WorkspaceID:       workspace.ID,
+		WorkspaceName:     workspace.Name,
+		RoleID:            req.RoleID,
+		DurationMinutes:   duration,
+		Justification:     justification,
+		Status:            model.ElevationPending,
+	}
+	if elevationType == model.ElevationTypeCspRole {
+		if err := s.resolveCspRole(elevation, req.CspRoleID); err != nil {
+			return nil, err
+		}
+	}
+	if elevation.RoleID == 0 {
+		return nil, fmt.Errorf("%w: roleId is required", ErrInvalidElevationRequest)
+	}
+	role, err := s.roleRepo.FindRoleByRoleID(elevation.RoleID, constants.RoleTypeWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("%w: role %d is not a workspace role", ErrInvalidElevationRequest, elevation.RoleID)
+	}
+	elevation.RoleName = role.Name
+
+	if err := s.checkRoleNotHeld(elevation); err != nil {
+		return nil, err
+	}
+	open, err := s.elevationRepo.ExistsOpen(requester.ID, workspace.ID, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, ErrElevationAlreadyOpen
+	}
+	if err := s.elevationRepo.Create(elevation); err != nil {
+		return nil, err
+	}
+	return elevation, nil
+}
+
+// resolveCspRole csp-role 요청의 CSP 역할 확인, 역할이 지정되지 않으면 매핑된 워크스페이스 역할(하나뿐일 때)로 채움
+func (s *ElevationService) resolveCspRole(elevation *model.ElevationRequest, cspRoleID *uint) error {
+	if cspRoleID == nil || *cspRoleID == 0 {
+		return fmt.Errorf("%w: cspRoleId is required for csp-role elevation", ErrInvalidElevationRequest)
+	}
+	cspRole, err := s.roleRepo.FindCspRoleById(*cspRoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: csp role %d not found", ErrInvalidElevationRequest, *cspRoleID)
+		}
+		return err
+	}
+	elevation.CspRoleID = &cspRole.ID
+	elevation.CspRoleName = cspRole.Name
+	elevation.CspType = cspRole.CspType
+
+	if elevation.RoleID != 0 {
+		mapped, err := s.elevationRepo.IsRoleMappedToCspRole(elevation.RoleID, cspRole.ID)
+		if err != nil {
+			return err
+		}
+		if !mapped {
+			return fmt.Errorf("%w: role %d is not mapped to csp role %s", ErrInvalidElevationRequest, elevation.RoleID, cspRole.Name)
+		}
+		return nil
+	}
+
+	roleIDs, err := s.elevationRepo.FindRoleIDsMappedToCspRole(cspRole.ID)
+	if err != nil {
+		return err
+	}
+	var candidates []uint
+	for _, roleID := range roleIDs {
+		role, err := s.roleRepo.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
+		if err != nil {
+			return err
+		}
+		if role != nil {
+			candidates = append(candidates, role.ID)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return fmt.Errorf("%w: no workspace role is mapped to csp role %s", ErrInvalidElevationRequest, cspRole.Name)
+	case 1:
+		elevation.RoleID = candidates[0]
+		return nil
+	}
+	return fmt.Errorf("%w: several workspace roles are mapped to csp role %s, roleId is required", ErrInvalidElevationRequest, cspRole.Name)
+}
+
+// checkRoleNotHeld 요청자가 워크스페이스에서 이미 역할을 직접 할당받았으면 ErrElevationRoleAlreadyHeld
+func (s *ElevationService) checkRoleNotHeld(elevation *model.ElevationRequest) error {
+	assigned, err := s.roleRepo.FindUserWorkspaceRoles(elevation.RequesterID, elevation.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, a := range assigned {
+		if a.RoleID == elevation.RoleID {
+			return ErrElevationRoleAlreadyHeld
+		}
+	}
+	return nil
+}
+
+// List 승격 요청 목록 (관리자 조회)
+func (s *ElevationService) List(filter model.ElevationFilter) ([]model.ElevationRequest, error) {
+	return s.elevationRepo.List(filter)
+}
+
+// ListMine 요청자 본인의 승격 요청 (status 가 비어 있으면 전체)
+func (s *ElevationService) ListMine(requester *model.User, status string) ([]model.ElevationRequest, error) {
+	return s.elevationRepo.List(model.ElevationFilter{RequesterID: requester.ID, Status: status})
+}
+
+// GetMine 요청자 본인의 승격 요청 조회 (다른 사용자의 요청은 없는 것으로 본다)
+func (s *ElevationService) GetMine(id uint, requester *model.User) (*model.ElevationRequest, error) {
+	elevation, err := s.elevationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if elevation.RequesterID != requester.ID {
+		return nil, repository.ErrElevationRequestNotFound
+	}
+	return elevation, nil
+}
+
+// ListApprovable 승인자가 결정할 수 있는 대기 중 요청 (본인 요청 제외)
+func (s *ElevationService) ListApprovable(ctx context.Context, approver *model.User) ([]model.ElevationRequest, error) {
+	pending, err := s.elevationRepo.List(model.ElevationFilter{Status: model.ElevationPending})
+	if err != nil {
+		return nil, err
+	}
+	approvable := make([]model.ElevationRequest, 0)
+	for i := range pending {
+		if err := s.authorizeApprover(ctx, approver, &pending[i]); err != nil {
+			if errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrElevationSelfApproval) {
+				continue
+			}
+			return nil, err
+		}
+		approvable = append(approvable, pending[i])
+	}
+	return approvable, nil
+}
+
+// Decide 승인/거절. 승인하면 지금부터 요청 기간 동안 유효한 워크스페이스 역할을 할당한다 (직무 분리 제약 적용).
+func (s *ElevationService) Decide(ctx context.Context, id uint, req *model.ElevationDecisionRequest, approver *model.User) (*model.ElevationRequest, error) {
+	if req.Decision != model.ElevationDecisionApprove && req.Decision != model.ElevationDecisionDeny {
+		return nil, fmt.Errorf("%w: decision must be approve or deny: %s", ErrInvalidElevationRequest, req.Decision)
+	}
+	elevation, err := s.elevationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if elevation.Status != model.ElevationPending {
+		return nil, ErrElevationNotPending
+	}
+	if err := s.authorizeApprover(ctx, approver, elevation); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"decided_by_kc_id":    approver.KcId,
+		"decided_by_username": approver.Username,
+		"decision_comment":    req.Comment,
+		"decided_at":          now,
+	}
+	if req.Decision == model.ElevationDecisionDeny {
+		updates["status"] = model.ElevationDenied
+		if err := s.transition(id, model.ElevationPending, updates, ErrElevationNotPending); err != nil {
+			return nil, err
+		}
+		return s.elevationRepo.FindByID(id)
+	}
+
+	if err := s.checkRoleNotHeld(elevation); err != nil {
+		return nil, err
+	}
+	expiresAt := now.Add(time.Duration(elevation.DurationMinutes) * time.Minute)
+	validity := model.AssignmentValidity{ExpiresAt: &expiresAt}
+	if err := s.roleService.AssignWorkspaceRoleWithValidity(elevation.RequesterID, elevation.WorkspaceID, elevation.RoleID, validity); err != nil {
+		return nil, err
+	}
+	updates["status"] = model.ElevationApproved
+	updates["expires_at"] = expiresAt
+	if err := s.transition(id, model.ElevationPending, updates, ErrElevationNotPending); err != nil {
+		// 다른 승인자가 먼저 결정했으면 방금 만든 할당을 되돌린다
+		if delErr := s.elevationRepo.DeleteTimedWorkspaceRole(elevation.RequesterID, elevation.WorkspaceID, elevation.RoleID); delErr != nil {
+			log.Printf("[ELEVATION] request %d: failed to roll back elevated role: %v", id, delErr)
+		}
+		return nil, err
+	}
+	return s.elevationRepo.FindByID(id)
+}
+
+// Cancel 요청자 본인의 취소. 대기 중이면 cancelled, 승인되어 유효하면 할당을 즉시 반납하고 revoked 로 닫는다.
+func (s *ElevationService) Cancel(id uint, requester *model.User) (*model.ElevationRequest, error) {
+	elevation, err := s.GetMine(id, requester)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	switch elevation.Status {
+	case model.ElevationPending:
+		updates := map[string]interface{}{
+			"status":            model.ElevationCancelled,
+			"ended_at":          now,
+			"ended_by_kc_id":    requester.KcId,
+			"ended_by_username": requester.Username,
+		}
+		if err := s.transition(id, model.ElevationPending, updates, ErrElevationNotPending); err != nil {
+			return nil, err
+		}
+		return s.elevationRepo.FindByID(id)
+	case model.ElevationApproved:
+		return s.end(elevation, model.ElevationRevoked, requester.KcId, requester.Username, "relinquished by requester", now)
+	}
+	return nil, ErrElevationNotActive
+}
+
+// Revoke 유효한 승격을 만료 전에 회수 (관리자)
+func (s *ElevationService) Revoke(id uint, req *model.ElevationRevokeRequest, actor *model.User) (*model.ElevationRequest, error) {
+	elevation, err := s.elevationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if elevation.Status != model.ElevationApproved {
+		return nil, ErrElevationNotActive
+	}
+	return s.end(elevation, model.ElevationRevoked, actor.KcId, actor.Username, strings.TrimSpace(req.Reason), time.Now())
+}
+
+// end 승인된 승격의 할당을 삭제하고 요청을 status(revoked/expired)로 닫음
+func (s *ElevationService) end(elevation *model.ElevationRequest, status, actorKcID, actorUsername, reason string, now time.Time) (*model.ElevationRequest, error) {
+	if err := s.elevationRepo.DeleteTimedWorkspaceRole(elevation.RequesterID, elevation.WorkspaceID, elevation.RoleID); err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{
+		"status":            status,
+		"end_reason":        reason,
+		"ended_at":          now,
+		"ended_by_kc_id":    actorKcID,
+		"ended_by_username": actorUsername,
+	}
+	if err := s.transition(elevation.ID, model.ElevationApproved, updates, ErrElevationNotActive); err != nil {
+		return nil, err
+	}
+	return s.elevationRepo.FindByID(elevation.ID)
+}
+
+// transition 상태 전이, 이미 다른 상태로 바뀌었으면 conflict
+func (s *ElevationService) transition(id uint, from string, updates map[string]interface{}, conflict error) error {
+	ok, err := s.elevationRepo.Transition(id, from, updates)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return conflict
+	}
+	return nil
+}
+
+// ExpireDue 만료 시각이 지난 승격 회수 (회수마다 감사 이벤트 기록)
+// 기한부 역할 할당 만료 처리가 할당을 먼저 삭제했을 수 있으므로 할당 삭제는 없어도 성공으로 본다.
+func (s *ElevationService) ExpireDue(now time.Time) ([]model.ElevationRequest, error) {
+	due, err := s.elevationRepo.ListDueExpirations(now)
+	if err != nil {
+		return nil, err
+	}
+	var expired []model.ElevationRequest
+	for i := range due {
+		result, err := s.end(&due[i], model.ElevationExpired, "", elevationSystemActor, "duration elapsed", now)
+		if err != nil {
+			if errors.Is(err, ErrElevationNotActive) {
+				continue
+			}
+			log.Printf("[ELEVATION] request %d: failed to expire: %v", due[i].ID, err)
+			continue
+		}
+		expired = append(expired, *result)
+		s.recordExpiry(result)
+	}
+	return expired, nil
+}
+
+// recordExpiry 자동 만료 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록)
+func (s *ElevationService) recordExpiry(elevation *model.ElevationRequest) {
+	if s.auditService == nil {
+		return
+	}
+	workspaceID := elevation.WorkspaceID
+	event := &model.AuditEvent{
+		ActorUsername: elevationSystemActor,
+		Method:        "SYSTEM",
+		Route:         "elevation.expiry",
+		Path:          fmt.Sprintf("/api/elevations/%d", elevation.ID),
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:      model.AuditActionElevationExpire,
+		EntityType:  model.AuditEntityElevation,
+		EntityID:    fmt.Sprint(elevation.ID),
+		WorkspaceID: &workspaceID,
+		After:       elevation,
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[ELEVATION] failed to record audit event for request %d: %v", elevation.ID, err)
+	}
+}
+
+// StartExpiry 주기적으로 만료된 승격 회수
+// 주기는 MC_IAM_MANAGER_ELEVATION_EXPIRY_INTERVAL (기본 1m)
+func (s *ElevationService) StartExpiry(ctx context.Context) {
+	interval := dormancyEnvDuration("MC_IAM_MANAGER_ELEVATION_EXPIRY_INTERVAL", defaultElevationExpiryInterval)
+	log.Printf("[ELEVATION] duration default=%dm max=%dm, checking expirations every %s",
+		s.policy.DefaultMinutes, s.policy.MaxMinutes, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := s.ExpireDue(time.Now())
+				if err != nil {
+					log.Printf("[ELEVATION] failed to expire elevations: %v", err)
+				}
+				for _, elevation := range expired {
+					log.Printf("[ELEVATION] request %d expired: %s/ws%d/%s",
+						elevation.ID, elevation.RequesterUsername, elevation.WorkspaceID, elevation.RoleName)
+				}
+			}
+		}
+	}()
+}
+
+// ActiveCspElevationRoleID 사용자가 워크스페이스에서 cspType 에 대해 유효한 CSP 역할 승격이 있으면 승격 역할 ID (없으면 0)
+func (s *ElevationService) ActiveCspElevationRoleID(userID, workspaceID uint, cspType string) (uint, error) {
+	elevation, err := s.elevationRepo.FindActiveCspElevation(userID, workspaceID, cspType, time.Now())
+	if err != nil || elevation == nil {
+		return 0, err
+	}
+	return elevation.RoleID, nil
+}
+
+// authorizeApprover 승인자가 요청을 결정할 수 있는지 확인
+// 본인 요청은 결정할 수 없고, 승격 관리자이거나 대상 워크스페이스에서 승인 권한을 가진 구성원이어야 한다.
+func (s *ElevationService) authorizeApprover(ctx context.Context, approver *model.User, elevation *model.ElevationRequest) error {
+	if approver == nil {
+		return ErrPermissionDenied
+	}
+	if elevation.RequesterID == approver.ID {
+		return ErrElevationSelfApproval
+	}
+	manager, err := s.authzService.HasPermission(ctx, approver.ID, 0, ElevationManagePermission)
+	if err != nil {
+		return err
+	}
+	if manager {
+		return nil
+	}
+	grants, err := s.authzService.GetWorkspaceRoleGrants(ctx, approver.ID, elevation.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if len(grants) > 0 {
+		allowed, err := s.authzService.HasPermission(ctx, approver.ID, elevation.WorkspaceID, ElevationApprovePermission)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return ErrPermissionDenied
+}
+
+// loadElevationPolicy 승격 기간 정책 환경 변수 (MC_IAM_MANAGER_ELEVATION_DEFAULT_MINUTES 기본 60, MC_IAM_MANAGER_ELEVATION_MAX_MINUTES 기본 480)
+func loadElevationPolicy() model.ElevationPolicy {
+	policy := model.ElevationPolicy{
+		DefaultMinutes: elevationEnvMinutes("MC_IAM_MANAGER_ELEVATION_DEFAULT_MINUTES", defaultElevationMinutes),
+		MaxMinutes:     elevationEnvMinutes("MC_IAM_MANAGER_ELEVATION_MAX_MINUTES", defaultElevationMaxMinutes),
+	}
+	if policy.DefaultMinutes > policy.MaxMinutes {
+		log.Printf("[ELEVATION] MC_IAM_MANAGER_ELEVATION_DEFAULT_MINUTES (%d) exceeds MC_IAM_MANAGER_ELEVATION_MAX_MINUTES (%d), using the maximum",
+			policy.DefaultMinutes, policy.MaxMinutes)
+		policy.DefaultMinutes = policy.MaxMinutes
+	}
+	return policy
+}
+
+func elevationEnvMinutes(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	minutes, err := strconv.Atoi(raw)
+	if err != nil || minutes <= 0 {
+		log.Printf("[ELEVATION] invalid %s %q, using %d", key, raw, fallback)
+		return fallback
+	}
+	return minutes
+}
@@ -0,0 +1,334 @@
+package service
+
+// elevation_service_test.go
+//
+// ElevationService 단위 테스트 (SQLite in-memory DB)
+// 요청 검증, 승인자 범위, 승인 시 기한부 할당 생성, 취소/반납/회수, 자동 만료, CSP 역할 승격을 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestElevationService(t *testing.T) (*ElevationService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+		&model.ElevationRequest{},
+	))
+	svc := &ElevationService{
+		elevationRepo: repository.NewElevationRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+		roleService:   NewRoleService(db),
+		authzService:  newTestAuthz(db),
+		policy:        model.ElevationPolicy{DefaultMinutes: 60, MaxMinutes: 240},
+	}
+	return svc, db
+}
+
+// elevationFixture 워크스페이스 하나, 승격 대상 역할, 요청자/승인자/관리자/외부인
+type elevationFixture struct {
+	ws        *model.Workspace
+	member    *model.RoleMaster // 요청자의 상시 역할
+	operator  *model.RoleMaster // 승격 대상 역할
+	requester *model.User
+	approver  *model.User // ws 에서 승인 권한
+	manager   *model.User // 승격 관리 권한
+	outsider  *model.User // ws 구성원이지만 승인 권한 없음
+}
+
+func setupElevationFixture(t *testing.T, db *gorm.DB) *elevationFixture {
+	t.Helper()
+	f := &elevationFixture{
+		ws:        createGRTestWorkspace(t, db, "elv-ws"),
+		member:    createGRTestRole(t, db, "elv-member"),
+		operator:  createGRTestRole(t, db, "elv-operator"),
+		requester: createGRTestUser(t, db, "elv-alice", "kc-elv-alice"),
+		approver:  createGRTestUser(t, db, "elv-approver", "kc-elv-approver"),
+		manager:   createGRTestUser(t, db, "elv-manager", "kc-elv-manager"),
+		outsider:  createGRTestUser(t, db, "elv-bob", "kc-elv-bob"),
+	}
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.requester.ID, WorkspaceID: f.ws.ID, RoleID: f.member.ID}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.outsider.ID, WorkspaceID: f.ws.ID, RoleID: f.member.ID}).Error)
+
+	assignAuthzTestWorkspaceRole(t, db, f.approver, f.ws.ID, "elv-lead", ElevationApprovePermission)
+	assignAuthzTestPlatformRole(t, db, f.manager, "elv-admin", ElevationManagePermission)
+	return f
+}
+
+func createTestElevation(t *testing.T, svc *ElevationService, f *elevationFixture, minutes int) *model.ElevationRequest {
+	t.Helper()
+	elevation, err := svc.Create(&model.CreateElevationRequest{
+		WorkspaceID:     f.ws.ID,
+		RoleID:          f.operator.ID,
+		DurationMinutes: minutes,
+		Justification:   "INC-1234 production hotfix",
+	}, f.requester)
+	require.NoError(t, err)
+	return elevation
+}
+
+func findElevatedAssignment(t *testing.T, db *gorm.DB, f *elevationFixture) *model.UserWorkspaceRole {
+	t.Helper()
+	var rows []model.UserWorkspaceRole
+	require.NoError(t, db.Where("user_id = ? AND workspace_id = ? AND role_id = ?", f.requester.ID, f.ws.ID, f.operator.ID).Find(&rows).Error)
+	if len(rows) == 0 {
+		return nil
+	}
+	return &rows[0]
+}
+
+// ── 요청 ──────────────────────────────────────────────────────────────────────
+
+// TC-ELV-CREATE-01: 기간 미지정 → 정책 기본값, 이름 고정, 같은 역할 중복 요청 → ErrElevationAlreadyOpen
+func TestElevationCreate_DefaultsAndDuplicate(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+
+	elevation := createTestElevation(t, svc, f, 0)
+	assert.Equal(t, model.ElevationTypeWorkspaceRole, elevation.Type)
+	assert.Equal(t, model.ElevationPending, elevation.Status)
+	assert.Equal(t, 60, elevation.DurationMinutes)
+	assert.Equal(t, "elv-ws", elevation.WorkspaceName)
+	assert.Equal(t, "elv-operator", elevation.RoleName)
+	assert.Equal(t, "kc-elv-alice", elevation.RequesterKcID)
+	assert.Nil(t, findElevatedAssignment(t, db, f), "요청만으로는 할당되지 않아야 함")
+
+	_, err := svc.Create(&model.CreateElevationRequest{
+		WorkspaceID: f.ws.ID, RoleID: f.operator.ID, Justification: "again",
+	}, f.requester)
+	assert.True(t, errors.Is(err, ErrElevationAlreadyOpen))
+}
+
+// TC-ELV-CREATE-02: 잘못된 요청 → ErrInvalidElevationRequest, 이미 가진 역할 → ErrElevationRoleAlreadyHeld
+func TestElevationCreate_Invalid(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+
+	for _, req := range []model.CreateElevationRequest{
+		{WorkspaceID: f.ws.ID, RoleID: f.operator.ID, Justification: " "},
+		{WorkspaceID: f.ws.ID, RoleID: f.operator.ID, Justification: "x", DurationMinutes: 241},
+		{WorkspaceID: f.ws.ID, RoleID: f.operator.ID, Justification: "x", DurationMinutes: -5},
+		{WorkspaceID: 9999, RoleID: f.operator.ID, Justification: "x"},
+		{WorkspaceID: f.ws.ID, Justification: "x"},
+		{WorkspaceID: f.ws.ID, RoleID: f.operator.ID, Justification: "x", Type: "platform-role"},
+		{WorkspaceID: f.ws.ID, Justification: "x", Type: model.ElevationTypeCspRole},
+	} {
+		_, err := svc.Create(&req, f.requester)
+		assert.True(t, errors.Is(err, ErrInvalidElevationRequest), "%+v: %v", req, err)
+	}
+
+	_, err := svc.Create(&model.CreateElevationRequest{
+		WorkspaceID: f.ws.ID, RoleID: f.member.ID, Justification: "x",
+	}, f.requester)
+	assert.True(t, errors.Is(err, ErrElevationRoleAlreadyHeld))
+}
+
+// ── 승인/거절 ─────────────────────────────────────────────────────────────────
+
+// TC-ELV-DECIDE-01: 본인/권한 없는 구성원은 결정 불가, 승인자 승인 → 기간 만료 시각이 지정된 할당 생성
+func TestElevationDecide_Approve(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	elevation := createTestElevation(t, svc, f, 30)
+	ctx := context.Background()
+	approve := &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove, Comment: "ok"}
+
+	_, err := svc.Decide(ctx, elevation.ID, approve, f.requester)
+	assert.True(t, errors.Is(err, ErrElevationSelfApproval))
+	_, err = svc.Decide(ctx, elevation.ID, approve, f.outsider)
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+
+	mine, err := svc.ListApprovable(ctx, f.requester)
+	require.NoError(t, err)
+	assert.Empty(t, mine)
+	approvable, err := svc.ListApprovable(ctx, f.approver)
+	require.NoError(t, err)
+	require.Len(t, approvable, 1)
+	assert.Equal(t, elevation.ID, approvable[0].ID)
+
+	before := time.Now()
+	decided, err := svc.Decide(ctx, elevation.ID, approve, f.approver)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationApproved, decided.Status)
+	assert.Equal(t, "elv-approver", decided.DecidedByUsername)
+	assert.Equal(t, "ok", decided.DecisionComment)
+	require.NotNil(t, decided.ExpiresAt)
+	assert.WithinDuration(t, before.Add(30*time.Minute), *decided.ExpiresAt, 5*time.Second)
+
+	assignment := findElevatedAssignment(t, db, f)
+	require.NotNil(t, assignment)
+	require.NotNil(t, assignment.ExpiresAt)
+	assert.WithinDuration(t, *decided.ExpiresAt, *assignment.ExpiresAt, time.Second)
+
+	grants, err := svc.authzService.GetWorkspaceRoleGrants(ctx, f.requester.ID, f.ws.ID)
+	require.NoError(t, err)
+	assert.Len(t, grants, 2, "상시 역할 + 승격 역할")
+}
+
+// TC-ELV-DECIDE-02: 거절 → 할당 없음, 결정된 요청 재결정 → ErrElevationNotPending, 관리자는 워크스페이스 밖에서도 결정
+func TestElevationDecide_DenyAndManager(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	elevation := createTestElevation(t, svc, f, 30)
+	ctx := context.Background()
+
+	decided, err := svc.Decide(ctx, elevation.ID, &model.ElevationDecisionRequest{Decision: model.ElevationDecisionDeny}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationDenied, decided.Status)
+	assert.Nil(t, decided.ExpiresAt)
+	assert.Nil(t, findElevatedAssignment(t, db, f))
+
+	_, err = svc.Decide(ctx, elevation.ID, &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove}, f.approver)
+	assert.True(t, errors.Is(err, ErrElevationNotPending))
+	_, err = svc.Decide(ctx, elevation.ID, &model.ElevationDecisionRequest{Decision: "maybe"}, f.approver)
+	assert.True(t, errors.Is(err, ErrInvalidElevationRequest))
+}
+
+// TC-ELV-DECIDE-03: 직무 분리 제약에 걸리는 승격 승인 → ErrSodViolation, 요청은 대기 상태 유지
+func TestElevationDecide_SodViolation(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	elevation := createTestElevation(t, svc, f, 30)
+	_, err := svc.roleService.sodService.CreateConstraint(&model.SodConstraintRequest{
+		Name: "member-operator", RoleIDs: []uint{f.member.ID, f.operator.ID},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Decide(context.Background(), elevation.ID, &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove}, f.approver)
+	assert.True(t, errors.Is(err, ErrSodViolation))
+	got, err := svc.GetMine(elevation.ID, f.requester)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationPending, got.Status)
+	assert.Nil(t, findElevatedAssignment(t, db, f))
+}
+
+// ── 취소/반납/회수/만료 ───────────────────────────────────────────────────────
+
+// TC-ELV-END-01: 대기 중 취소 → cancelled, 승인 후 반납 → revoked 와 승격 할당만 삭제, 다른 사용자의 요청은 보이지 않음
+func TestElevationCancel(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	ctx := context.Background()
+
+	first := createTestElevation(t, svc, f, 30)
+	_, err := svc.Cancel(first.ID, f.outsider)
+	assert.True(t, errors.Is(err, repository.ErrElevationRequestNotFound))
+	cancelled, err := svc.Cancel(first.ID, f.requester)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationCancelled, cancelled.Status)
+	assert.Equal(t, "elv-alice", cancelled.EndedByUsername)
+
+	second := createTestElevation(t, svc, f, 30)
+	_, err = svc.Decide(ctx, second.ID, &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove}, f.approver)
+	require.NoError(t, err)
+	require.NotNil(t, findElevatedAssignment(t, db, f))
+
+	relinquished, err := svc.Cancel(second.ID, f.requester)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationRevoked, relinquished.Status)
+	assert.NotNil(t, relinquished.EndedAt)
+	assert.Nil(t, findElevatedAssignment(t, db, f))
+	assert.Equal(t, int64(1), countRows(t, db.Where("user_id = ?", f.requester.ID), &model.UserWorkspaceRole{}), "상시 역할은 유지")
+
+	_, err = svc.Cancel(second.ID, f.requester)
+	assert.True(t, errors.Is(err, ErrElevationNotActive))
+
+	mine, err := svc.ListMine(f.requester, "")
+	require.NoError(t, err)
+	require.Len(t, mine, 2)
+	assert.Equal(t, second.ID, mine[0].ID, "최신순")
+}
+
+// TC-ELV-END-02: 관리자 회수 → revoked 와 사유 기록, 만료 처리 → expired (system), 상시 할당은 건드리지 않음
+func TestElevationRevokeAndExpire(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	ctx := context.Background()
+	approve := &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove}
+
+	revokedReq := createTestElevation(t, svc, f, 30)
+	_, err := svc.Decide(ctx, revokedReq.ID, approve, f.approver)
+	require.NoError(t, err)
+	revoked, err := svc.Revoke(revokedReq.ID, &model.ElevationRevokeRequest{Reason: "incident closed"}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.ElevationRevoked, revoked.Status)
+	assert.Equal(t, "incident closed", revoked.EndReason)
+	assert.Equal(t, "kc-elv-manager", revoked.EndedByKcID)
+
+	expiring := createTestElevation(t, svc, f, 30)
+	_, err = svc.Decide(ctx, expiring.ID, approve, f.approver)
+	require.NoError(t, err)
+
+	expired, err := svc.ExpireDue(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, expired, "만료 전")
+
+	expired, err = svc.ExpireDue(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, model.ElevationExpired, expired[0].Status)
+	assert.Equal(t, elevationSystemActor, expired[0].EndedByUsername)
+	assert.Nil(t, findElevatedAssignment(t, db, f))
+
+	var base int64
+	require.NoError(t, db.Model(&model.UserWorkspaceRole{}).
+		Where("user_id = ? AND role_id = ?", f.requester.ID, f.member.ID).Count(&base).Error)
+	assert.Equal(t, int64(1), base)
+
+	_, err = svc.Revoke(expiring.ID, &model.ElevationRevokeRequest{}, f.manager)
+	assert.True(t, errors.Is(err, ErrElevationNotActive))
+}
+
+// ── CSP 역할 승격 ─────────────────────────────────────────────────────────────
+
+// TC-ELV-CSP-01: CSP 역할만 지정 → 매핑된 워크스페이스 역할로 요청, 승인 후 해당 CSP 타입 발급 역할로 사용
+func TestElevationCspRole(t *testing.T) {
+	svc, db := newTestElevationService(t)
+	f := setupElevationFixture(t, db)
+	cspRole := &model.CspRole{Name: "mciam-aws-poweruser", CspType: "aws"}
+	require.NoError(t, db.Create(cspRole).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{
+		RoleID: f.operator.ID, CspRoleID: cspRole.ID, AuthMethod: constants.AuthMethodOIDC,
+	}).Error)
+
+	elevation, err := svc.Create(&model.CreateElevationRequest{
+		Type: model.ElevationTypeCspRole, WorkspaceID: f.ws.ID, CspRoleID: &cspRole.ID, Justification: "cost report",
+	}, f.requester)
+	require.NoError(t, err)
+	assert.Equal(t, f.operator.ID, elevation.RoleID)
+	assert.Equal(t, "mciam-aws-poweruser", elevation.CspRoleName)
+	assert.Equal(t, "aws", elevation.CspType)
+
+	_, err = svc.Create(&model.CreateElevationRequest{
+		Type: model.ElevationTypeCspRole, WorkspaceID: f.ws.ID, RoleID: f.member.ID, CspRoleID: &cspRole.ID, Justification: "x",
+	}, f.requester)
+	assert.True(t, errors.Is(err, ErrInvalidElevationRequest), "매핑되지 않은 역할")
+
+	roleID, err := svc.ActiveCspElevationRoleID(f.requester.ID, f.ws.ID, "aws")
+	require.NoError(t, err)
+	assert.Zero(t, roleID, "승인 전")
+
+	_, err = svc.Decide(context.Background(), elevation.ID, &model.ElevationDecisionRequest{Decision: model.ElevationDecisionApprove}, f.approver)
+	require.NoError(t, err)
+	roleID, err = svc.ActiveCspElevationRoleID(f.requester.ID, f.ws.ID, "aws")
+	require.NoError(t, err)
+	assert.Equal(t, f.operator.ID, roleID)
+	roleID, err = svc.ActiveCspElevationRoleID(f.requester.ID, f.ws.ID, "gcp")
+	require.NoError(t, err)
+	assert.Zero(t, roleID)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+)
+
+var (
+	ErrInvalidGrantConditions = errors.New("invalid grant conditions")
+	ErrGrantConditionsNotMet  = errors.New("grant conditions not met")
+	ErrInvalidConditionInput  = errors.New("invalid condition attribute")
+)
+
+type authzConditionContextKey struct{}
+
+// WithAuthzConditionContext 조건부 매핑 평가에 쓰이는 요청 속성을 context 에 저장
+func WithAuthzConditionContext(ctx context.Context, cc model.AuthzConditionContext) context.Context {
+	return context.WithValue(ctx, authzConditionContextKey{}, cc)
+}
+
+// authzConditionContextFrom context 에 저장된 요청 속성 조회 (평가 시각 미지정 시 현재 시각)
+func authzConditionContextFrom(ctx context.Context) model.AuthzConditionContext {
+	cc, _ := ctx.Value(authzConditionContextKey{}).(model.AuthzConditionContext)
+	if cc.At.IsZero() {
+		cc.At = time.Now()
+	}
+	return cc
+}
+
+// withConditionOverrides 요청 본문에 지정된 평가 속성(IP, 시각, 프로젝트)으로 context 의 요청 속성을 덮어씀
+func withConditionOverrides(ctx context.Context, sourceIP, at, projectID string) (context.Context, error) {
+	if sourceIP == "" && at == "" && projectID == "" {
+		return ctx, nil
+	}
+	cc, _ := ctx.Value(authzConditionContextKey{}).(model.AuthzConditionContext)
+	if sourceIP != "" {
+		if net.ParseIP(sourceIP) == nil {
+			return nil, fmt.Errorf("sourceIp %q: %w", sourceIP, ErrInvalidConditionInput)
+		}
+		cc.SourceIP = sourceIP
+	}
+	if at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return nil, fmt.Errorf("at %q: %w", at, ErrInvalidConditionInput)
+		}
+		cc.At = t
+	}
+	if projectID != "" {
+		id, err := strconv.ParseUint(projectID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("projectId %q: %w", projectID, ErrInvalidConditionInput)
+		}
+		cc.ProjectID = uint(id)
+	}
+	return WithAuthzConditionContext(ctx, cc), nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ValidateGrantConditions 조건 형식 검증 (CIDR, HH:MM, 요일, 타임존)
+func ValidateGrantConditions(c *model.GrantConditions) error {
+	if c == nil {
+		return nil
+	}
+	for _, cidr := range c.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("sourceCidrs %q: %w", cidr, ErrInvalidGrantConditions)
+		}
+	}
+	for _, w := range c.TimeWindows {
+		if _, err := parseClock(w.Start); err != nil {
+			return fmt.Errorf("timeWindows start %q: %w", w.Start, ErrInvalidGrantConditions)
+		}
+		if _, err := parseClock(w.End); err != nil {
+			return fmt.Errorf("timeWindows end %q: %w", w.End, ErrInvalidGrantConditions)
+		}
+		for _, d := range w.Days {
+			if _, ok := weekdayNames[strings.ToLower(d)]; !ok {
+				return fmt.Errorf("timeWindows day %q: %w", d, ErrInvalidGrantConditions)
+			}
+		}
+		if w.Timezone != "" {
+			if _, err := time.LoadLocation(w.Timezone); err != nil {
+				return fmt.Errorf("timeWindows timezone %q: %w", w.Timezone, ErrInvalidGrantConditions)
+			}
+		}
+	}
+	return nil
+}
+
+// grantConditionEvaluator 사용자/워크스페이스 단위 조건 평가기
+// 소속 조직과 라벨은 조건부 매핑을 만났을 때 한 번만 조회한다.
+type grantConditionEvaluator struct {
+	authzRepo   *repository.AuthzRepository
+	userID      uint
+	workspaceID uint
+	env         model.AuthzConditionContext
+
+	loaded          bool
+	orgIDs          map[uint]bool
+	workspaceLabels model.Labels
+	projectLabels   model.Labels
+}
+
+func newGrantConditionEvaluator(authzRepo *repository.AuthzRepository, userID, workspaceID uint, env model.AuthzConditionContext) *grantConditionEvaluator {
+	return &grantConditionEvaluator{authzRepo: authzRepo, userID: userID, workspaceID: workspaceID, env: env}
+}
+
+// evaluate 조건 평가. 조건이 없으면 (nil, true) 를 반환한다.
+func (e *grantConditionEvaluator) evaluate(c *model.GrantConditions) ([]model.AuthzConditionResult, bool, error) {
+	if c.IsEmpty() {
+		return nil, true, nil
+	}
+	if err := e.load(); err != nil {
+		return nil, false, err
+	}
+
+	var results []model.AuthzConditionResult
+	add := func(name string, ok bool, detail string) {
+		results = append(results, model.AuthzConditionResult{Condition: name, Satisfied: ok, Detail: detail})
+	}
+
+	if len(c.SourceCIDRs) > 0 {
+		ok := ipInCIDRs(e.env.SourceIP, c.SourceCIDRs)
+		add("sourceCidrs", ok, fmt.Sprintf("sourceIp=%s, allowed=%s", e.env.SourceIP, strings.Join(c.SourceCIDRs, ",")))
+	}
+	if len(c.TimeWindows) > 0 {
+		ok := false
+		for _, w := range c.TimeWindows {
+			if inTimeWindow(w, e.env.At) {
+				ok = true
+				break
+			}
+		}
+		add("timeWindows", ok, "at="+e.env.At.Format(time.RFC3339))
+	}
+	if len(c.OrganizationIDs) > 0 {
+		ok := false
+		for _, id := range c.OrganizationIDs {
+			if e.orgIDs[id] {
+				ok = true
+				break
+			}
+		}
+		add("organizationIds", ok, fmt.Sprintf("userOrganizations=%v", sortedKeys(e.orgIDs)))
+	}
+	if len(c.WorkspaceLabels) > 0 {
+		add("workspaceLabels", labelsMatch(e.workspaceLabels, c.WorkspaceLabels), fmt.Sprintf("workspaceId=%d", e.workspaceID))
+	}
+	if len(c.ProjectLabels) > 0 {
+		add("projectLabels", labelsMatch(e.projectLabels, c.ProjectLabels), fmt.Sprintf("projectId=%d", e.env.ProjectID))
+	}
+
+	satisfied := true
+	for _, r := range results {
+		if !r.Satisfied {
+			satisfied = false
+		}
+	}
+	return results, satisfied, nil
+}
+
+// load 평가에 필요한 소속 조직/라벨 조회 (최초 1회)
+func (e *grantConditionEvaluator) load() error {
+	if e.loaded {
+		return nil
+	}
+	e.loaded = true
+	e.orgIDs = make(map[uint]bool)
+	if e.userID != 0 {
+		ids, err := e.authzRepo.FindUserOrganizationIDs(e.userID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			e.orgIDs[id] = true
+		}
+	}
+	if e.workspaceID != 0 {
+		labels, err := e.authzRepo.FindWorkspaceLabels(e.workspaceID)
+		if err != nil {
+			return err
+		}
+		e.workspaceLabels = labels
+	}
+	if e.env.ProjectID != 0 {
+		labels, err := e.authzRepo.FindProjectLabels(e.env.ProjectID)
+		if err != nil {
+			return err
+		}
+		e.projectLabels = labels
+	}
+	return nil
+}
+
+// ipInCIDRs IP 가 CIDR 목록 중 하나에 속하는지 확인
+func ipInCIDRs(ip string, cidrs []string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// inTimeWindow 시각이 허용 시간대에 속하는지 확인
+// 자정을 넘는 구간(예: 22:00-06:00)의 새벽 부분은 시작한 날의 요일로 판단한다.
+func inTimeWindow(w model.GrantTimeWindow, at time.Time) bool {
+	loc := time.Local
+	if w.Timezone != "" {
+		l, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return false
+		}
+		loc = l
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+
+	t := at.In(loc)
+	now := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	switch {
+	case start <= end:
+		if now < start || now >= end {
+			return false
+		}
+	case now >= start:
+	case now < end:
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdayNames[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock HH:MM 을 자정 기준 분으로 변환
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// labelsMatch required 의 모든 라벨이 labels 에 같은 값으로 있는지 확인
+func labelsMatch(labels, required model.Labels) bool {
+	for k, v := range required {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[uint]bool) []uint {
+	keys := make([]uint, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
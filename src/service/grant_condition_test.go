@@ -0,0 +1,240 @@
+package service
+
+// grant_condition_test.go
+//
+// 조건부 역할-권한 매핑 테스트 (SQLite in-memory DB)
+// IP 대역, 시간대, 조직, 워크스페이스/프로젝트 라벨 조건의 평가와 권한 판정 반영(ConditionUnmet)을 검증한다.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func grantConditionalTestPermission(t *testing.T, db *gorm.DB, roleType constants.IAMRoleType, roleID uint, permissionID string, effect model.PermissionEffect, conditions *model.GrantConditions) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType:     roleType,
+		RoleID:       roleID,
+		PermissionID: permissionID,
+		Effect:       effect,
+		Conditions:   conditions,
+	}).Error)
+}
+
+// TC-COND-01: 조건 형식 검증 (CIDR, HH:MM, 요일, 타임존)
+func TestValidateGrantConditions(t *testing.T) {
+	valid := &model.GrantConditions{
+		SourceCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+		TimeWindows: []model.GrantTimeWindow{{Days: []string{"mon", "FRI"}, Start: "09:00", End: "18:00", Timezone: "UTC"}},
+	}
+	assert.NoError(t, ValidateGrantConditions(valid))
+	assert.NoError(t, ValidateGrantConditions(nil))
+
+	invalid := []*model.GrantConditions{
+		{SourceCIDRs: []string{"10.0.0.1"}},
+		{TimeWindows: []model.GrantTimeWindow{{Start: "9am", End: "18:00"}}},
+		{TimeWindows: []model.GrantTimeWindow{{Days: []string{"monday"}, Start: "09:00", End: "18:00"}}},
+		{TimeWindows: []model.GrantTimeWindow{{Start: "09:00", End: "18:00", Timezone: "Mars/Olympus"}}},
+	}
+	for _, c := range invalid {
+		assert.True(t, errors.Is(ValidateGrantConditions(c), ErrInvalidGrantConditions), "%+v", c)
+	}
+}
+
+// TC-COND-02: 시간대 평가 — 요일 지정, 자정을 넘는 구간은 시작한 날의 요일로 판단
+func TestInTimeWindow(t *testing.T) {
+	business := model.GrantTimeWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "18:00", Timezone: "UTC"}
+	assert.True(t, inTimeWindow(business, time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)))   // 월 09:00
+	assert.False(t, inTimeWindow(business, time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC))) // 월 18:00 (종료 시각 제외)
+	assert.False(t, inTimeWindow(business, time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC))) // 토
+
+	night := model.GrantTimeWindow{Days: []string{"fri"}, Start: "22:00", End: "06:00", Timezone: "UTC"}
+	assert.True(t, inTimeWindow(night, time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)))  // 금 23:00
+	assert.True(t, inTimeWindow(night, time.Date(2026, 10, 17, 5, 59, 0, 0, time.UTC)))  // 토 05:59 (금요일 구간)
+	assert.False(t, inTimeWindow(night, time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC)))  // 금 05:00 (목요일 구간)
+	assert.False(t, inTimeWindow(night, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))) // 토 12:00
+
+	seoul := model.GrantTimeWindow{Start: "09:00", End: "18:00", Timezone: "Asia/Seoul"}
+	assert.True(t, inTimeWindow(seoul, time.Date(2026, 10, 16, 1, 0, 0, 0, time.UTC))) // KST 10:00
+}
+
+// TC-COND-03: 조건부 허용 — IP 대역을 벗어나면 적용되지 않고 ConditionUnmet 에 조건별 결과와 함께 기록
+func TestGrantCondition_SourceCIDRAllow(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "cond-user-03", "kc-cond-03")
+	role := createGRTestRole(t, db, "cond-role-03")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantConditionalTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:user:delete", model.PermissionEffectAllow,
+		&model.GrantConditions{SourceCIDRs: []string{"10.0.0.0/8"}})
+
+	inside := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{SourceIP: "10.1.2.3"})
+	decisions, err := svc.GetPermissionDecisions(inside, user.ID, 0, []string{"mc-iam-manager:user:delete"})
+	require.NoError(t, err)
+	assert.True(t, decisions[0].Allowed)
+	require.Len(t, decisions[0].GrantedBy, 1)
+	require.Len(t, decisions[0].GrantedBy[0].Conditions, 1)
+	assert.True(t, decisions[0].GrantedBy[0].Conditions[0].Satisfied)
+
+	outside := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{SourceIP: "192.168.0.10"})
+	decisions, err = svc.GetPermissionDecisions(outside, user.ID, 0, []string{"mc-iam-manager:user:delete"})
+	require.NoError(t, err)
+	assert.False(t, decisions[0].Allowed)
+	assert.Empty(t, decisions[0].GrantedBy)
+	require.Len(t, decisions[0].ConditionUnmet, 1)
+	assert.Equal(t, role.ID, decisions[0].ConditionUnmet[0].RoleID)
+	require.Len(t, decisions[0].ConditionUnmet[0].Conditions, 1)
+	assert.Equal(t, "sourceCidrs", decisions[0].ConditionUnmet[0].Conditions[0].Condition)
+	assert.False(t, decisions[0].ConditionUnmet[0].Conditions[0].Satisfied)
+}
+
+// TC-COND-04: 조건부 거부 — 조직 조건을 만족하는 사용자에게만 거부가 적용됨
+func TestGrantCondition_OrganizationDeny(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	member := createGRTestUser(t, db, "cond-user-04a", "kc-cond-04a")
+	other := createGRTestUser(t, db, "cond-user-04b", "kc-cond-04b")
+	operator := createGRTestRole(t, db, "cond-operator-04")
+	restricted := createGRTestRole(t, db, "cond-restricted-04")
+	org := createGRTestOrg(t, db, "cond-org-04", "CD04")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: member.ID, OrganizationID: org.ID}).Error)
+	for _, u := range []*model.User{member, other} {
+		require.NoError(t, db.Create(&model.UserPlatformRole{UserID: u.ID, RoleID: operator.ID}).Error)
+		require.NoError(t, db.Create(&model.UserPlatformRole{UserID: u.ID, RoleID: restricted.ID}).Error)
+	}
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, operator.ID, "mc-iam-manager:workspace:delete")
+	grantConditionalTestPermission(t, db, constants.RoleTypePlatform, restricted.ID, "mc-iam-manager:workspace:delete", model.PermissionEffectDeny,
+		&model.GrantConditions{OrganizationIDs: []uint{org.ID}})
+
+	ctx := context.Background()
+	decisions, err := svc.GetPermissionDecisions(ctx, member.ID, 0, []string{"mc-iam-manager:workspace:delete"})
+	require.NoError(t, err)
+	assert.False(t, decisions[0].Allowed)
+	require.Len(t, decisions[0].DeniedBy, 1)
+	assert.Equal(t, restricted.ID, decisions[0].DeniedBy[0].RoleID)
+
+	decisions, err = svc.GetPermissionDecisions(ctx, other.ID, 0, []string{"mc-iam-manager:workspace:delete"})
+	require.NoError(t, err)
+	assert.True(t, decisions[0].Allowed)
+	assert.Empty(t, decisions[0].DeniedBy)
+	require.Len(t, decisions[0].ConditionUnmet, 1)
+	assert.Equal(t, restricted.ID, decisions[0].ConditionUnmet[0].RoleID)
+}
+
+// TC-COND-05: 워크스페이스/프로젝트 라벨 조건 — 라벨이 모두 일치할 때만 허용
+func TestGrantCondition_Labels(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	require.NoError(t, db.AutoMigrate(&model.Project{}))
+	user := createGRTestUser(t, db, "cond-user-05", "kc-cond-05")
+	role := createGRTestRole(t, db, "cond-role-05")
+	prod := &model.Workspace{Name: "cond-ws-05-prod", Labels: model.Labels{"env": "prod", "team": "infra"}}
+	dev := &model.Workspace{Name: "cond-ws-05-dev", Labels: model.Labels{"env": "dev"}}
+	require.NoError(t, db.Create(prod).Error)
+	require.NoError(t, db.Create(dev).Error)
+	project := &model.Project{Name: "cond-prj-05", Labels: model.Labels{"tier": "gold"}}
+	require.NoError(t, db.Create(project).Error)
+	for _, ws := range []*model.Workspace{prod, dev} {
+		require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	}
+	grantConditionalTestPermission(t, db, constants.RoleTypeWorkspace, role.ID, "mc-infra-manager:vm:delete", model.PermissionEffectAllow,
+		&model.GrantConditions{WorkspaceLabels: model.Labels{"env": "prod"}, ProjectLabels: model.Labels{"tier": "gold"}})
+
+	ctx := WithAuthzConditionContext(context.Background(), model.AuthzConditionContext{ProjectID: project.ID})
+	allowed, err := svc.HasPermission(ctx, user.ID, prod.ID, "mc-infra-manager:vm:delete")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = svc.HasPermission(ctx, user.ID, dev.ID, "mc-infra-manager:vm:delete")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// 프로젝트 미지정 → 프로젝트 라벨 조건 미충족
+	allowed, err = svc.HasPermission(context.Background(), user.ID, prod.ID, "mc-infra-manager:vm:delete")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+// TC-COND-06: Check 요청의 평가 속성(sourceIp, at) 지정 — 잘못된 값은 ErrInvalidConditionInput
+func TestAuthzCheck_ConditionOverrides(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "cond-user-06", "kc-cond-06")
+	role := createGRTestRole(t, db, "cond-role-06")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID}).Error)
+	grantConditionalTestPermission(t, db, constants.RoleTypePlatform, role.ID, "mc-iam-manager:role:update", model.PermissionEffectAllow,
+		&model.GrantConditions{
+			SourceCIDRs: []string{"10.0.0.0/8"},
+			TimeWindows: []model.GrantTimeWindow{{Start: "09:00", End: "18:00", Timezone: "UTC"}},
+		})
+
+	resp, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: user.KcId, Permissions: []string{"mc-iam-manager:role:update"},
+		SourceIP: "10.0.0.5", At: "2026-10-16T10:00:00Z",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+
+	resp, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: user.KcId, Permissions: []string{"mc-iam-manager:role:update"},
+		SourceIP: "10.0.0.5", At: "2026-10-16T20:00:00Z",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	require.Len(t, resp.Decisions[0].ConditionUnmet, 1)
+
+	_, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: user.KcId, Permissions: []string{"mc-iam-manager:role:update"}, At: "yesterday",
+	})
+	assert.True(t, errors.Is(err, ErrInvalidConditionInput))
+}
+
+// TC-COND-07: 조직 조건부 메뉴 거부 — 메뉴 트리·메뉴 목록에서 조직 구성원에게만 적용됨
+func TestGrantCondition_OrganizationMenuDeny(t *testing.T) {
+	_, db := newTestAuthzService(t)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}, &model.RoleMenuMapping{}))
+	svc := NewMenuService(db)
+	member := createGRTestUser(t, db, "cond-user-07a", "kc-cond-07a")
+	other := createGRTestUser(t, db, "cond-user-07b", "kc-cond-07b")
+	viewer := createGRTestRole(t, db, "cond-viewer-07")
+	org := createGRTestOrg(t, db, "cond-org-07", "CD07")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: member.ID, OrganizationID: org.ID}).Error)
+	for _, m := range []model.Menu{
+		{ID: "cond-settings", DisplayName: "Settings", ResType: "menu", Priority: 1, MenuNumber: 1},
+		{ID: "cond-billing", DisplayName: "Billing", ResType: "menu", Priority: 2, MenuNumber: 2},
+	} {
+		menu := m
+		require.NoError(t, db.Create(&menu).Error)
+		require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: viewer.ID, MenuID: menu.ID}).Error)
+	}
+	grantConditionalTestPermission(t, db, constants.RoleTypePlatform, viewer.ID, model.MenuPermissionID("cond-billing"), model.PermissionEffectDeny,
+		&model.GrantConditions{OrganizationIDs: []uint{org.ID}})
+
+	ctx := context.Background()
+	memberID, err := svc.MenuUserID(member.KcId)
+	require.NoError(t, err)
+	assert.Equal(t, member.ID, memberID)
+	tree, err := svc.BuildUserMenuTree(ctx, memberID, []uint{viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, "cond-settings", tree[0].ID)
+
+	tree, err = svc.BuildUserMenuTree(ctx, other.ID, []uint{viewer.ID})
+	require.NoError(t, err)
+	assert.Len(t, tree, 2)
+
+	roleIDs := []string{strconv.FormatUint(uint64(viewer.ID), 10)}
+	menus, err := svc.MenuList(ctx, member.ID, &model.MenuMappingFilterRequest{RoleIDs: roleIDs})
+	require.NoError(t, err)
+	require.Len(t, menus, 1)
+	assert.Equal(t, "cond-settings", menus[0].ID)
+
+	unknownID, err := svc.MenuUserID("kc-cond-unknown")
+	require.NoError(t, err)
+	assert.Zero(t, unknownID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -18,6 +19,7 @@ type GroupRoleService struct {
 	orgRepo       *repository.OrganizationRepository
 	roleRepo      *repository.RoleRepository
 	kcService     KeycloakService
+	sodService    *SodService // nil 이면 직무 분리 검사를 하지 않음
 }
 
 // NewGroupRoleService GroupRoleService 생성자
@@ -28,6 +30,7 @@ func NewGroupRoleService(db *gorm.DB) *GroupRoleService {
 		orgRepo:       repository.NewOrganizationRepository(db),
 		roleRepo:      repository.NewRoleRepository(db),
 		kcService:     NewKeycloakService(),
+		sodService:    NewSodService(db),
 	}
 }
 
@@ -35,6 +38,17 @@ func NewGroupRoleService(db *gorm.DB) *GroupRoleService {
 
 // AssignGroupPlatformRole 그룹에 platform role 할당 (DB + Keycloak)
 func (s *GroupRoleService) AssignGroupPlatformRole(ctx context.Context, groupID, roleID uint) error {
+	return s.AssignGroupPlatformRoleWithValidity(ctx, groupID, roleID, model.AssignmentValidity{})
+}
+
+// AssignGroupPlatformRoleWithValidity 유효 기간을 지정하여 그룹에 platform role 할당
+// 시작 전이면 DB 에만 저장하고 Keycloak 그룹 realm role 은 만료 처리기가 시작 시각에 부여한다.
+func (s *GroupRoleService) AssignGroupPlatformRoleWithValidity(ctx context.Context, groupID, roleID uint, validity model.AssignmentValidity) error {
+	now := time.Now()
+	if err := ValidateAssignmentValidity(validity, now); err != nil {
+		return err
+	}
+
 	// 1. 그룹 조회 (KC 그룹 이름으로 사용)
 	org, err := s.orgRepo.FindByID(groupID)
 	if err != nil {
@@ -50,10 +64,18 @@ func (s *GroupRoleService) AssignGroupPlatformRole(ctx context.Context, groupID,
 		return repository.ErrRoleMasterNotFound
 	}
 
-	// 3. DB에 저장
-	if err := s.groupRoleRepo.CreateGroupPlatformRole(groupID, roleID); err != nil {
+	// 3. 그룹 구성원의 직무 분리 제약 확인
+	if err := s.sodService.CheckGroupPlatformRoleAssignment(groupID, roleID); err != nil {
+		return err
+	}
+
+	// 4. DB에 저장
+	if err := s.groupRoleRepo.CreateGroupPlatformRoleWithValidity(groupID, roleID, validity); err != nil {
 		return err
 	}
+	if validity.PendingAt(now) {
+		return nil
+	}
 
 	// 4. Keycloak: 그룹에 realm role 추가
 	if err := s.kcService.AddRealmRoleToGroup(ctx, org.Name, role.Name); err != nil {
@@ -125,6 +147,14 @@ func (s *GroupRoleService) RemoveGroupPlatformRole(ctx context.Context, groupID,
 // AssignGroupWorkspace 그룹-워크스페이스 매핑 생성 (DB 전용)
 // workspace_id, role_id 존재 여부 pre-validation 포함
 func (s *GroupRoleService) AssignGroupWorkspace(groupID, workspaceID, roleID uint) error {
+	return s.AssignGroupWorkspaceWithValidity(groupID, workspaceID, roleID, model.AssignmentValidity{})
+}
+
+// AssignGroupWorkspaceWithValidity 유효 기간을 지정하여 그룹-워크스페이스 매핑 생성
+func (s *GroupRoleService) AssignGroupWorkspaceWithValidity(groupID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
+	if err := ValidateAssignmentValidity(validity, time.Now()); err != nil {
+		return err
+	}
 	// workspace 존재 여부 확인
 	var workspace model.Workspace
 	if err := s.db.First(&workspace, workspaceID).Error; err != nil {
@@ -141,7 +171,10 @@ func (s *GroupRoleService) AssignGroupWorkspace(groupID, workspaceID, roleID uin
 	if role == nil {
 		return repository.ErrRoleMasterNotFound
 	}
-	return s.groupRoleRepo.CreateGroupWorkspaceRole(groupID, workspaceID, roleID)
+	if err := s.sodService.CheckGroupWorkspaceRoleAssignment(groupID, workspaceID, roleID); err != nil {
+		return err
+	}
+	return s.groupRoleRepo.CreateGroupWorkspaceRoleWithValidity(groupID, workspaceID, roleID, validity)
 }
 
 // GetGroupWorkspaces 그룹의 워크스페이스 매핑 목록 조회
@@ -159,6 +192,9 @@ func (s *GroupRoleService) UpdateGroupWorkspaceRole(groupID, workspaceID, roleID
 	if role == nil {
 		return repository.ErrRoleMasterNotFound
 	}
+	if err := s.sodService.CheckGroupWorkspaceRoleAssignment(groupID, workspaceID, roleID); err != nil {
+		return err
+	}
 	return s.groupRoleRepo.UpdateGroupWorkspaceRole(groupID, workspaceID, roleID)
 }
 
@@ -171,6 +207,11 @@ func (s *GroupRoleService) RemoveGroupWorkspaceRole(groupID, workspaceID uint) e
 
 // AssignUserToGroups 사용자를 그룹에 할당 (DB + Keycloak 동기화)
 func (s *GroupRoleService) AssignUserToGroups(ctx context.Context, userID uint, groupIDs []uint, kcUserID string) error {
+	// 그룹 상속 역할의 직무 분리 제약 확인 (일부 그룹만 반영되지 않도록 먼저 검사)
+	if err := s.sodService.CheckGroupMembership(userID, groupIDs, false); err != nil {
+		return err
+	}
+
 	for _, groupID := range groupIDs {
 		// 그룹 조회
 		org, err := s.orgRepo.FindByID(groupID)
@@ -201,6 +242,13 @@ func (s *GroupRoleService) AssignUsersToGroup(ctx context.Context, groupID uint,
 		return err
 	}
 
+	// 그룹 상속 역할의 직무 분리 제약 확인 (일부 사용자만 반영되지 않도록 먼저 검사)
+	for _, userID := range userIDs {
+		if err := s.sodService.CheckGroupMembership(userID, []uint{groupID}, false); err != nil {
+			return err
+		}
+	}
+
 	for _, userID := range userIDs {
 		// 사용자 KC ID 조회
 		var user model.User
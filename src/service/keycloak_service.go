@@ -48,6 +48,7 @@ type KeycloakService interface {
 	// Methods for group synchronization
 	EnsureGroupExistsAndAssignUser(ctx context.Context, kcUserId, groupName string) error
 	RemoveUserFromGroup(ctx context.Context, kcUserId, groupName string) error
+	IsUserInGroup(ctx context.Context, kcUserId, groupName string) (bool, error)
 	// Method for UMA RPT Token
 	GetRequestingPartyToken(ctx context.Context, accessToken string, options gocloak.RequestingPartyTokenOptions) (*gocloak.JWT, error)
 	// Method to validate token and get claims
@@ -96,6 +97,8 @@ type KeycloakService interface {
 	DeleteGroup(ctx context.Context, groupName string) error
 	// CheckSAMLClientConfig Keycloak SAML 클라이언트 존재 및 protocol mapper 구성 확인
 	CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error)
+	// GetLastLoginTimes since 이후 LOGIN 이벤트로 사용자(kcId)별 마지막 로그인 시각 조회 (realm 이벤트 저장이 켜져 있어야 함)
+	GetLastLoginTimes(ctx context.Context, since time.Time) (map[string]time.Time, error)
 }
 
 // keycloakService is now stateless, methods directly use config.KC
@@ -849,6 +852,32 @@ func (s *keycloakService) RemoveUserFromGroup(ctx context.Context, kcUserId, gro
 	return nil
 }
 
+// IsUserInGroup checks whether a user is a direct member of the named group.
+func (s *keycloakService) IsUserInGroup(ctx context.Context, kcUserId, groupName string) (bool, error) {
+	if config.KC == nil || config.KC.Client == nil {
+		return false, fmt.Errorf("keycloak configuration not initialized")
+	}
+
+	token, err := config.KC.GetAdminToken(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	groups, err := config.KC.Client.GetUserGroups(ctx, token.AccessToken, config.KC.Realm, kcUserId, gocloak.GetGroupsParams{
+		Search: &groupName,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get groups for user %s: %w", kcUserId, err)
+	}
+
+	for _, group := range groups {
+		if group.Name != nil && *group.Name == groupName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // SetupInitialAdmin creates the initial platform admin user and sets up necessary permissions
 // 초기 관리자 생성 및 권한 설정
 // 1. KC 관리자 로그인 -> token 발급
@@ -2007,3 +2036,49 @@ func kcAdminGetRequest(ctx context.Context, url, token string) ([]byte, error) {
 	}
 	return io.ReadAll(resp.Body)
 }
+
+// keycloakEventPageSize 이벤트 조회 한 번에 가져오는 개수
+const keycloakEventPageSize = 1000
+
+// GetLastLoginTimes since 이후 LOGIN 이벤트로 사용자(kcId)별 마지막 로그인 시각 조회
+// Keycloak 이벤트 보존 기간이 지난 로그인은 조회되지 않는다.
+func (s *keycloakService) GetLastLoginTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	if config.KC == nil || config.KC.Client == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KC.LoginAdmin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	dateFrom := since.UTC().Format("2006-01-02")
+	max := int32(keycloakEventPageSize)
+	lastLogin := make(map[string]time.Time)
+	for first := int32(0); ; first += max {
+		page := first
+		events, err := config.KC.Client.GetEvents(ctx, token.AccessToken, config.KC.Realm, gocloak.GetEventsParams{
+			Type:     []string{"LOGIN"},
+			DateFrom: &dateFrom,
+			First:    &page,
+			Max:      &max,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get login events from keycloak: %w", err)
+		}
+		for _, e := range events {
+			if e.UserID == nil {
+				continue
+			}
+			at := time.UnixMilli(e.Time)
+			if at.Before(since) {
+				continue
+			}
+			if prev, ok := lastLogin[*e.UserID]; !ok || at.After(prev) {
+				lastLogin[*e.UserID] = at
+			}
+		}
+		if len(events) < keycloakEventPageSize {
+			return lastLogin, nil
+		}
+	}
+}
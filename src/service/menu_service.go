@@ -89,6 +89,8 @@ type MenuService struct {
 	permissionRepo  *repository.MciamPermissionRepository // Use renamed repository type
 	menuMappingRepo *repository.MenuMappingRepository
 	roleRepo        *repository.RoleRepository
+	authzRepo       *repository.AuthzRepository
+	cache           *AuthzCache // nil 이면 캐시하지 않음
 }
 
 // NewMenuService 새 MenuService 인스턴스 생성
@@ -100,6 +102,8 @@ func NewMenuService(db *gorm.DB) *MenuService {
 		permissionRepo:  repository.NewMciamPermissionRepository(db),
 		menuMappingRepo: repository.NewMenuMappingRepository(db),
 		roleRepo:        repository.NewRoleRepository(db),
+		authzRepo:       repository.NewAuthzRepository(db),
+		cache:           DefaultAuthzCache(),
 	}
 }
 
@@ -128,15 +132,57 @@ func (s *MenuService) GetAllMenusTree(req *model.MenuFilterRequest) ([]*model.Me
 	return tree, nil
 }
 
+// MenuUserID 메뉴를 요청한 사용자의 DB ID (조건부 거부 매핑의 조직 조건 평가용)
+// DB 에 없는 사용자(예: Keycloak 전용 관리자)는 소속 조직이 없으므로 0 을 반환한다.
+func (s *MenuService) MenuUserID(kcUserID string) (uint, error) {
+	if kcUserID == "" {
+		return 0, nil
+	}
+	user, err := s.userRepo.FindByKcID(kcUserID)
+	if err != nil || user == nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
 // BuildUserMenuTree 사용자의 플랫폼 역할에 따른 메뉴 트리 구성
-func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []uint) ([]*model.MenuTreeNode, error) {
+// 플랫폼 역할 조합 단위로 캐시하며, 조건부 거부 매핑이 평가된 트리는 사용자와 요청 속성에 따라 달라지므로 캐시하지 않는다.
+func (s *MenuService) BuildUserMenuTree(ctx context.Context, userID uint, platformRoleIDs []uint) ([]*model.MenuTreeNode, error) {
+	key := menuTreeCacheKey(platformRoleIDs)
+	cached, gen, ok := s.cache.lookup(authzCacheMenuTree, key)
+	if ok {
+		return cloneMenuTree(cached.([]*model.MenuTreeNode)), nil
+	}
+	menuTree, conditional, err := s.buildUserMenuTree(ctx, userID, platformRoleIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !conditional {
+		s.cache.store(authzCacheMenuTree, key, gen, cloneMenuTree(menuTree))
+	}
+	return menuTree, nil
+}
+
+// buildUserMenuTree 메뉴 트리 구성 (조건부 거부 매핑 평가 여부 포함)
+func (s *MenuService) buildUserMenuTree(ctx context.Context, userID uint, platformRoleIDs []uint) ([]*model.MenuTreeNode, bool, error) {
 	req := &model.MenuMappingFilterRequest{}
 	var allMenus []*model.Menu
 
+	// 0. 상위 역할(ParentID)의 메뉴도 상속
+	if len(platformRoleIDs) > 0 {
+		roleIDs, err := s.roleRepo.FindRoleIDsWithAncestors(platformRoleIDs)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, roleID := range roleIDs {
+			req.RoleIDs = append(req.RoleIDs, strconv.FormatUint(uint64(roleID), 10))
+		}
+	}
+
 	// 1. 각 플랫폼 역할에 매핑된 메뉴 ID들을 조회
 	menuIDs, err := s.menuMappingRepo.FindMappedMenuIDs(req)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// 2. 매핑된 메뉴 ID들의 상위 메뉴 ID들을 수집
@@ -146,19 +192,19 @@ func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []u
 	}
 	menus, err := s.menuRepo.GetMenus(menuFilterRequest)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	allMenus = append(allMenus, menus...)
 
 	parentIDs, err := s.menuRepo.FindParentIDs(menuIDs)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// for _, menuID := range menuIDs {
 	// 	menu, err := s.menuRepo.FindMenuByID(menuID)
 	// 	if err != nil {
-	// 		return nil, err
+	// 		return nil, false, err
 	// 	}
 	// 	// 상위 메뉴 ID가 있으면 수집
 	// 	if menu.ParentID != "" {
@@ -175,10 +221,16 @@ func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []u
 	}
 	parentMenus, err := s.menuRepo.GetMenus(parentMenuFilterRequest)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	allMenus = append(allMenus, parentMenus...)
 
+	// 4-1. 거부(deny) 매핑된 메뉴와 그 하위 메뉴 제외
+	allMenus, conditional, err := s.filterDeniedMenus(ctx, userID, platformRoleIDs, allMenus)
+	if err != nil {
+		return nil, false, err
+	}
+
 	// for _, platformMenuID := range parentIDs {
 	// 	menu, err := s.menuRepo.FindMenuByID(platformMenuID)
 	// 	if err != nil {
@@ -193,11 +245,32 @@ func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []u
 	// 6. 정렬
 	sortMenuTree(menuTree)
 
-	return menuTree, nil
+	return menuTree, conditional, nil
 }
 
 // Role에 따른 메뉴 목록록
-func (s *MenuService) MenuList(req *model.MenuMappingFilterRequest) ([]*model.Menu, error) {
+func (s *MenuService) MenuList(ctx context.Context, userID uint, req *model.MenuMappingFilterRequest) ([]*model.Menu, error) {
+	// 0. 상위 역할(ParentID)의 메뉴도 상속
+	var roleIDs []uint
+	if len(req.RoleIDs) > 0 {
+		roleIDs = make([]uint, 0, len(req.RoleIDs))
+		for _, roleID := range req.RoleIDs {
+			id, err := util.StringToUint(roleID)
+			if err != nil {
+				return nil, err
+			}
+			roleIDs = append(roleIDs, id)
+		}
+		expanded, err := s.roleRepo.FindRoleIDsWithAncestors(roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		req.RoleIDs = make([]string, 0, len(expanded))
+		for _, roleID := range expanded {
+			req.RoleIDs = append(req.RoleIDs, strconv.FormatUint(uint64(roleID), 10))
+		}
+	}
+
 	// 1. 각 플랫폼 역할에 매핑된 메뉴 ID들을 조회
 	menuIDMap := make(map[string]bool)
 	menuIDs, err := s.menuMappingRepo.FindMappedMenuIDs(req)
@@ -251,7 +324,53 @@ func (s *MenuService) MenuList(req *model.MenuMappingFilterRequest) ([]*model.Me
 		result = append(result, menu)
 	}
 
-	return result, nil
+	// 거부(deny) 매핑된 메뉴와 그 하위 메뉴 제외
+	filtered, _, err := s.filterDeniedMenus(ctx, userID, roleIDs, result)
+	return filtered, err
+}
+
+// filterDeniedMenus 역할에 메뉴 권한(mc-web-console:menu:<menuId>) 거부 매핑이 있는 메뉴와 그 하위 메뉴를 제외
+// 권한 판정(AuthzService)과 같은 규칙으로 상위 역할의 거부 매핑도 적용되며, 다른 역할의 메뉴 매핑보다 우선한다.
+// 조건부 거부 매핑은 userID 의 소속 조직과 ctx 의 요청 속성(IP, 시각, 프로젝트)으로 평가한다.
+// 두 번째 반환값은 조건부 매핑을 평가했는지 여부다.
+func (s *MenuService) filterDeniedMenus(ctx context.Context, userID uint, platformRoleIDs []uint, menus []*model.Menu) ([]*model.Menu, bool, error) {
+	if len(platformRoleIDs) == 0 || len(menus) == 0 {
+		return menus, false, nil
+	}
+	grants := make([]model.AuthzRoleGrant, 0, len(platformRoleIDs))
+	for _, roleID := range platformRoleIDs {
+		grants = append(grants, model.AuthzRoleGrant{RoleID: roleID, RoleType: constants.RoleTypePlatform, Source: "direct"})
+	}
+	evaluator := newGrantConditionEvaluator(s.authzRepo, userID, 0, authzConditionContextFrom(ctx))
+	perms, err := collectRolePermissions(s.authzRepo, grants, evaluator)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(perms.deny) == 0 {
+		return menus, evaluator.loaded, nil
+	}
+
+	parents := make(map[string]string, len(menus))
+	for _, menu := range menus {
+		parents[menu.ID] = menu.ParentID
+	}
+	denied := func(menuID string) bool {
+		for seen := make(map[string]bool); menuID != "" && !seen[menuID]; menuID = parents[menuID] {
+			seen[menuID] = true
+			if perms.denied(model.MenuPermissionID(menuID)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	filtered := make([]*model.Menu, 0, len(menus))
+	for _, menu := range menus {
+		if !denied(menu.ID) {
+			filtered = append(filtered, menu)
+		}
+	}
+	return filtered, evaluator.loaded, nil
 }
 
 // sortMenuTree 메뉴 트리를 정렬하는 헬퍼 함수
@@ -661,11 +780,12 @@ func (s *MenuService) ListMappedMenusByRole(req *model.MenuMappingFilterRequest)
 }
 
 // rolePermissionEntry permission.yaml 역할 단위 권한 정의
-// permissions → role → menus | operations | csps
+// permissions → role → menus | operations | denies | csps
 type rolePermissionEntry struct {
 	Role       string   `yaml:"role"`
 	Menus      []string `yaml:"menus"`
 	Operations []string `yaml:"operations"`
+	Denies     []string `yaml:"denies"`
 	Csps       []string `yaml:"csps"`
 }
 
@@ -808,34 +928,46 @@ func (s *MenuService) resolvePermissionSeedPath(
 
 // loadAndApplyMenuPermissionsFromYAML 역할 중심 permission.yaml을 적용합니다.
 func (s *MenuService) loadAndApplyMenuPermissionsFromYAML(filePath string) error {
+	roleMenus, roleOperations, roleDenies, err := parseRolePermissionSeedFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyRoleMenuPermissionSeed(roleMenus); err != nil {
+		return err
+	}
+	if err := s.applyRoleOperationPermissionSeed(roleOperations, model.PermissionEffectAllow); err != nil {
+		return err
+	}
+	return s.applyRoleOperationPermissionSeed(roleDenies, model.PermissionEffectDeny)
+}
+
+// parseRolePermissionSeedFile permission.yaml을 역할별 menus / operations / denies 맵으로 읽습니다.
+func parseRolePermissionSeedFile(filePath string) (map[string][]string, map[string][]string, map[string][]string, error) {
 	body, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read permission YAML: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to read permission YAML: %w", err)
 	}
 
 	var data rolePermissionFile
 	if err := yaml.Unmarshal(body, &data); err != nil {
-		return fmt.Errorf("failed to parse permission YAML: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to parse permission YAML: %w", err)
 	}
 	if len(data.Permissions) == 0 {
-		return fmt.Errorf("no permissions entries found in %s", filePath)
+		return nil, nil, nil, fmt.Errorf("no permissions entries found in %s", filePath)
 	}
 
 	roleMenus := make(map[string][]string)
+	roleOperations := make(map[string][]string)
+	roleDenies := make(map[string][]string)
 	for _, entry := range data.Permissions {
 		roleName := strings.TrimSpace(entry.Role)
 		if roleName == "" {
-			return fmt.Errorf("permission entry missing role in %s", filePath)
-		}
-		if len(entry.Operations) > 0 {
-			fmt.Printf(
-				"Note: role %s operations(%d) reserved — menu seed only in this pass\n",
-				roleName, len(entry.Operations),
-			)
+			return nil, nil, nil, fmt.Errorf("permission entry missing role in %s", filePath)
 		}
 		if len(entry.Csps) > 0 {
 			fmt.Printf(
-				"Note: role %s csps(%d) reserved — menu seed only in this pass\n",
+				"Note: role %s csps(%d) reserved — menu/operation seed only in this pass\n",
 				roleName, len(entry.Csps),
 			)
 		}
@@ -847,9 +979,100 @@ func (s *MenuService) loadAndApplyMenuPermissionsFromYAML(filePath string) error
 			}
 		}
 		roleMenus[roleName] = menus
+		if ops := uniqueNonEmpty(entry.Operations); len(ops) > 0 {
+			roleOperations[roleName] = ops
+		}
+		if denies := uniqueNonEmpty(entry.Denies); len(denies) > 0 {
+			roleDenies[roleName] = denies
+		}
 	}
+	return roleMenus, roleOperations, roleDenies, nil
+}
 
-	return s.applyRoleMenuPermissionSeed(roleMenus)
+// applyRoleOperationPermissionSeed 역할→MC-IAM 권한(operations/denies) 목록을 플랫폼 역할 권한으로 upsert(존재 시 skip)합니다.
+func (s *MenuService) applyRoleOperationPermissionSeed(roleOperations map[string][]string, effect model.PermissionEffect) error {
+	for roleName, operations := range roleOperations {
+		role, err := s.roleRepo.FindRoleByRoleName(roleName, constants.RoleTypePlatform)
+		if err != nil {
+			return fmt.Errorf("failed to find role %s: %w", roleName, err)
+		}
+		if role == nil {
+			return fmt.Errorf("role not found: %s", roleName)
+		}
+		if _, err := s.addMissingRoleOperationPermissions(role.ID, operations, effect); err != nil {
+			return fmt.Errorf("failed to seed %s operations for role %s: %w", effect, roleName, err)
+		}
+	}
+	return nil
+}
+
+// SyncRoleOperationPermissionsFromYAML permission.yaml의 operations / denies 중 아직 반영하지 않은 항목만 플랫폼 역할에 추가합니다.
+// 서버 기동 시 호출되어 기존 배포에도 새로 추가된 라우트 권한이 반영되도록 합니다.
+// 반영한 항목은 mcmp_role_operation_seeds에 기록하여, 이후 관리자가 역할에서 제거해도 다시 추가하지 않습니다.
+// 메뉴 매핑은 건드리지 않으며, 아직 생성되지 않은 역할(초기 설정 전)은 기록하지 않고 건너뜁니다.
+// 추가된 권한 수를 반환합니다.
+func (s *MenuService) SyncRoleOperationPermissionsFromYAML(filePath string) (int, error) {
+	effectiveFilePath, cleanup, err := s.resolvePermissionSeedPath(
+		filePath, "permission.yaml", ".yaml",
+	)
+	if err != nil {
+		return 0, err
+	}
+	if cleanup != "" {
+		defer os.Remove(cleanup)
+	}
+	_, roleOperations, roleDenies, err := parseRolePermissionSeedFile(effectiveFilePath)
+	if err != nil {
+		return 0, err
+	}
+	applied, err := s.permissionRepo.FindAppliedRoleOperationSeeds()
+	if err != nil {
+		return 0, err
+	}
+	appliedSet := make(map[model.RoleOperationSeed]bool, len(applied))
+	for _, seed := range applied {
+		appliedSet[model.RoleOperationSeed{RoleName: seed.RoleName, PermissionID: seed.PermissionID, Effect: seed.Effect}] = true
+	}
+
+	added := 0
+	for _, seed := range []struct {
+		roles  map[string][]string
+		effect model.PermissionEffect
+	}{
+		{roleOperations, model.PermissionEffectAllow},
+		{roleDenies, model.PermissionEffectDeny},
+	} {
+		for roleName, operations := range seed.roles {
+			var pending []string
+			var records []model.RoleOperationSeed
+			for _, id := range operations {
+				record := model.RoleOperationSeed{RoleName: roleName, PermissionID: id, Effect: seed.effect}
+				if !appliedSet[record] {
+					pending = append(pending, id)
+					records = append(records, record)
+				}
+			}
+			if len(pending) == 0 {
+				continue
+			}
+			role, err := s.roleRepo.FindRoleByRoleName(roleName, constants.RoleTypePlatform)
+			if err != nil {
+				return added, fmt.Errorf("failed to find role %s: %w", roleName, err)
+			}
+			if role == nil {
+				continue
+			}
+			n, err := s.addMissingRoleOperationPermissions(role.ID, pending, seed.effect)
+			added += n
+			if err != nil {
+				return added, fmt.Errorf("failed to sync %s operations for role %s: %w", seed.effect, roleName, err)
+			}
+			if err := s.permissionRepo.RecordRoleOperationSeeds(records); err != nil {
+				return added, err
+			}
+		}
+	}
+	return added, nil
 }
 
 // initializeMenuPermissionsFromCSVFile 기존 CSV 매트릭스를 적용합니다.
@@ -1021,11 +1244,25 @@ func (s *MenuService) BackupRolePermissions(
 			sort.Strings(menuIDs)
 			entry.Menus = menuIDs
 		}
-		if containsSection(sections, rolePermissionSectionOps) ||
-			containsSection(sections, rolePermissionSectionCsps) {
-			// reserved: 스키마 자리만 유지 (1단계는 menus)
+		if containsSection(sections, rolePermissionSectionOps) {
+			operations, err := s.permissionRepo.GetRoleMciamPermissions(constants.RoleTypePlatform, role.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list operations for role %s: %w", role.Name, err)
+			}
+			sort.Strings(operations)
+			entry.Operations = operations
+
+			denies, err := s.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, role.ID, model.PermissionEffectDeny)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list denied operations for role %s: %w", role.Name, err)
+			}
+			sort.Strings(denies)
+			entry.Denies = denies
+		}
+		if containsSection(sections, rolePermissionSectionCsps) {
+			// reserved: 스키마 자리만 유지
 			fmt.Printf(
-				"Note: backup role %s — operations/csps sections reserved (empty)\n",
+				"Note: backup role %s — csps section reserved (empty)\n",
 				role.Name,
 			)
 		}
@@ -1118,26 +1355,47 @@ func (s *MenuService) RestoreRolePermissions(
 		}
 		result.RolesProcessed++
 
-		if !containsSection(sections, rolePermissionSectionMenus) {
-			continue
-		}
-
-		desired := uniqueNonEmpty(entry.Menus)
-		if mode == rolePermissionRestoreReplace {
-			removed, err := s.replaceRoleMenuMappings(role.ID, desired)
-			if err != nil {
-				return nil, fmt.Errorf("replace-role failed for %s: %w", roleName, err)
+		if containsSection(sections, rolePermissionSectionMenus) {
+			desired := uniqueNonEmpty(entry.Menus)
+			if mode == rolePermissionRestoreReplace {
+				removed, err := s.replaceRoleMenuMappings(role.ID, desired)
+				if err != nil {
+					return nil, fmt.Errorf("replace-role failed for %s: %w", roleName, err)
+				}
+				result.MenusRemoved += removed
+				result.MenusAdded += len(desired)
+			} else {
+				added, err := s.addMissingRoleMenuMappings(role.ID, desired)
+				if err != nil {
+					return nil, fmt.Errorf("additive restore failed for %s: %w", roleName, err)
+				}
+				result.MenusAdded += added
 			}
-			result.MenusRemoved += removed
-			result.MenusAdded += len(desired)
-			continue
 		}
 
-		added, err := s.addMissingRoleMenuMappings(role.ID, desired)
-		if err != nil {
-			return nil, fmt.Errorf("additive restore failed for %s: %w", roleName, err)
+		if containsSection(sections, rolePermissionSectionOps) {
+			// operations(allow) 를 먼저, denies 를 나중에 적용해 같은 권한이 양쪽에 있으면 deny 로 남는다.
+			for _, ops := range []struct {
+				effect  model.PermissionEffect
+				desired []string
+			}{
+				{model.PermissionEffectAllow, uniqueNonEmpty(entry.Operations)},
+				{model.PermissionEffectDeny, uniqueNonEmpty(entry.Denies)},
+			} {
+				if mode == rolePermissionRestoreReplace {
+					removed, err := s.removeRoleOperationPermissionsExcept(role.ID, ops.desired, ops.effect)
+					if err != nil {
+						return nil, fmt.Errorf("replace-role %s operations failed for %s: %w", ops.effect, roleName, err)
+					}
+					result.OperationsRemoved += removed
+				}
+				added, err := s.addMissingRoleOperationPermissions(role.ID, ops.desired, ops.effect)
+				if err != nil {
+					return nil, fmt.Errorf("restore %s operations failed for %s: %w", ops.effect, roleName, err)
+				}
+				result.OperationsAdded += added
+			}
 		}
-		result.MenusAdded += added
 	}
 
 	return result, nil
@@ -1239,6 +1497,70 @@ func (s *MenuService) replaceRoleMenuMappings(roleID uint, menuIDs []string) (in
 	return len(existing), nil
 }
 
+// addMissingRoleOperationPermissions 플랫폼 역할에 없는 MC-IAM 권한만 추가합니다 (권한 미등록 시 등록).
+// allow 는 이미 deny 로 매핑된 권한을 덮어쓰지 않고, deny 는 기존 allow 매핑을 deny 로 바꿉니다.
+func (s *MenuService) addMissingRoleOperationPermissions(roleID uint, permissionIDs []string, effect model.PermissionEffect) (int, error) {
+	if len(permissionIDs) == 0 {
+		return 0, nil
+	}
+	permissions, err := newMciamPermissionsFromIDs(permissionIDs)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.permissionRepo.EnsurePermissions(permissions); err != nil {
+		return 0, err
+	}
+	skipEffects := []model.PermissionEffect{effect}
+	if effect == model.PermissionEffectAllow {
+		skipEffects = append(skipEffects, model.PermissionEffectDeny)
+	}
+	have := make(map[string]bool)
+	for _, e := range skipEffects {
+		existing, err := s.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, roleID, e)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range existing {
+			have[id] = true
+		}
+	}
+	added := 0
+	for _, id := range permissionIDs {
+		if have[id] {
+			continue
+		}
+		if err := s.permissionRepo.AssignMciamPermissionToRole(constants.RoleTypePlatform, roleID, id, effect, nil); err != nil {
+			return added, err
+		}
+		added++
+		have[id] = true
+	}
+	return added, nil
+}
+
+// removeRoleOperationPermissionsExcept 플랫폼 역할의 MC-IAM 권한(effect 별) 중 keep 에 없는 것을 제거합니다.
+func (s *MenuService) removeRoleOperationPermissionsExcept(roleID uint, keep []string, effect model.PermissionEffect) (int, error) {
+	existing, err := s.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, roleID, effect)
+	if err != nil {
+		return 0, err
+	}
+	keepSet := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		keepSet[id] = true
+	}
+	removed := 0
+	for _, id := range existing {
+		if keepSet[id] {
+			continue
+		}
+		if err := s.permissionRepo.RemoveMciamPermissionFromRole(constants.RoleTypePlatform, roleID, id); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
 func normalizeRolePermissionSections(sections []string) []string {
 	if len(sections) == 0 {
 		return []string{rolePermissionSectionMenus}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/Nerzal/gocloak/v13"
 	"github.com/golang-jwt/jwt/v5"
@@ -63,6 +64,9 @@ func (m *mockKeycloakService) EnsureGroupExistsAndAssignUser(ctx context.Context
 func (m *mockKeycloakService) RemoveUserFromGroup(ctx context.Context, kcUserId, groupName string) error {
 	return nil
 }
+func (m *mockKeycloakService) IsUserInGroup(ctx context.Context, kcUserId, groupName string) (bool, error) {
+	return false, nil
+}
 func (m *mockKeycloakService) GetRequestingPartyToken(ctx context.Context, accessToken string, options gocloak.RequestingPartyTokenOptions) (*gocloak.JWT, error) {
 	return nil, nil
 }
@@ -131,3 +135,57 @@ func (m *mockKeycloakService) DeleteGroup(ctx context.Context, groupName string)
 func (m *mockKeycloakService) CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error) {
 	return "", nil
 }
+func (m *mockKeycloakService) GetLastLoginTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	return nil, nil
+}
+
+// recordingKeycloak realm role·그룹 변경 호출을 기록하는 KeycloakService 스텁
+// realm role 은 항상 존재하는 것으로 보고, 성공한 호출만 calls 에 "assign-user <kcUserId> <role>" 형식으로 남긴다.
+type recordingKeycloak struct {
+	mockKeycloakService
+	calls     []string
+	removeErr error                // realm role 회수(사용자/그룹) 실패
+	groupErr  error                // 그룹 가입 실패
+	groups    map[string]bool      // 이미 가입된 Keycloak 그룹 ("<kcUserId> <group>", IsUserInGroup)
+	users     []*gocloak.User      // GetUsers 결과
+	lastLogin map[string]time.Time // GetLastLoginTimes 결과
+	since     time.Time            // 마지막 GetLastLoginTimes 조회 시작 시각
+}
+
+func (m *recordingKeycloak) record(call string, err error) error {
+	if err == nil {
+		m.calls = append(m.calls, call)
+	}
+	return err
+}
+func (m *recordingKeycloak) CheckRealmRoleExists(ctx context.Context, roleName string) (bool, error) {
+	return true, nil
+}
+func (m *recordingKeycloak) AssignRealmRoleToUser(ctx context.Context, kcUserId, roleName string) error {
+	return m.record("assign-user "+kcUserId+" "+roleName, nil)
+}
+func (m *recordingKeycloak) RemoveRealmRoleFromUser(ctx context.Context, kcUserId, roleName string) error {
+	return m.record("remove-user "+kcUserId+" "+roleName, m.removeErr)
+}
+func (m *recordingKeycloak) AddRealmRoleToGroup(ctx context.Context, groupName, roleName string) error {
+	return m.record("assign-group "+groupName+" "+roleName, nil)
+}
+func (m *recordingKeycloak) RemoveRealmRoleFromGroup(ctx context.Context, groupName, roleName string) error {
+	return m.record("remove-group "+groupName+" "+roleName, m.removeErr)
+}
+func (m *recordingKeycloak) EnsureGroupExistsAndAssignUser(ctx context.Context, kcUserId, groupName string) error {
+	return m.record("join-group "+kcUserId+" "+groupName, m.groupErr)
+}
+func (m *recordingKeycloak) RemoveUserFromGroup(ctx context.Context, kcUserId, groupName string) error {
+	return m.record("leave-group "+kcUserId+" "+groupName, nil)
+}
+func (m *recordingKeycloak) IsUserInGroup(ctx context.Context, kcUserId, groupName string) (bool, error) {
+	return m.groups[kcUserId+" "+groupName], nil
+}
+func (m *recordingKeycloak) GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error) {
+	return m.users, nil
+}
+func (m *recordingKeycloak) GetLastLoginTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	m.since = since
+	return m.lastLogin, nil
+}
@@ -20,17 +20,19 @@ var ErrMaxDepthExceeded = errors.New("organization tree depth would exceed maxim
 
 // OrganizationService 조직 비즈니스 로직
 type OrganizationService struct {
-	db        *gorm.DB
-	orgRepo   *repository.OrganizationRepository
-	kcService KeycloakService
+	db         *gorm.DB
+	orgRepo    *repository.OrganizationRepository
+	kcService  KeycloakService
+	sodService *SodService // nil 이면 직무 분리 검사를 하지 않음
 }
 
 // NewOrganizationService OrganizationService 생성자
 func NewOrganizationService(db *gorm.DB) *OrganizationService {
 	return &OrganizationService{
-		db:        db,
-		orgRepo:   repository.NewOrganizationRepository(db),
-		kcService: NewKeycloakService(),
+		db:         db,
+		orgRepo:    repository.NewOrganizationRepository(db),
+		kcService:  NewKeycloakService(),
+		sodService: NewSodService(db),
 	}
 }
 
@@ -432,6 +434,9 @@ func (s *OrganizationService) AssignUserToOrganizations(userID uint, orgIDs []ui
 			return fmt.Errorf("organization not found: %d", orgID)
 		}
 	}
+	if err := s.sodService.CheckGroupMembership(userID, orgIDs, false); err != nil {
+		return err
+	}
 	return s.orgRepo.AssignUserToOrganizations(userID, orgIDs)
 }
 
@@ -494,6 +499,11 @@ func (s *OrganizationService) ReplaceUserGroups(userID uint, groupIDs []uint) er
 		}
 	}
 
+	// 변경 후 그룹 상속 역할의 직무 분리 제약 확인
+	if err := s.sodService.CheckGroupMembership(userID, groupIDs, true); err != nil {
+		return err
+	}
+
 	// 기존 그룹 조회
 	currentOrgs, err := s.orgRepo.FindUserOrganizations(userID)
 	if err != nil {
@@ -0,0 +1,163 @@
+package service
+
+// permission_deny_test.go
+//
+// 역할-권한 거부(deny) 매핑 테스트 (SQLite in-memory DB)
+// 거부가 역할/그룹/상속 경로와 관계없이 허용보다 우선하는지, 메뉴 트리와 MCMP API 호출에도 같은 규칙이 적용되는지 검증한다.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func denyAuthzTestPermission(t *testing.T, db *gorm.DB, roleType constants.IAMRoleType, roleID uint, permissionID string) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType:     roleType,
+		RoleID:       roleID,
+		PermissionID: permissionID,
+		Effect:       model.PermissionEffectDeny,
+	}).Error)
+}
+
+// TC-DENY-01: 그룹 역할의 거부가 직접 할당 역할의 허용보다 우선 — 판정 결과에 허용/거부 경로 모두 기록
+func TestPermissionDeny_GroupDenyOverridesDirectAllow(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	user := createGRTestUser(t, db, "deny-user-01", "kc-deny-01")
+	operator := createGRTestRole(t, db, "deny-operator-01")
+	restricted := createGRTestRole(t, db, "deny-restricted-01")
+	ws := createGRTestWorkspace(t, db, "deny-ws-01")
+	org := createGRTestOrg(t, db, "deny-group-01", "DN01")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: operator.ID}).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: ws.ID, RoleID: restricted.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, operator.ID, "mc-infra-manager:vm:read")
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, operator.ID, "mc-infra-manager:vm:delete")
+	denyAuthzTestPermission(t, db, constants.RoleTypeWorkspace, restricted.ID, "mc-infra-manager:vm:delete")
+
+	ctx := context.Background()
+	decisions, err := svc.GetPermissionDecisions(ctx, user.ID, ws.ID, []string{"mc-infra-manager:vm:read", "mc-infra-manager:vm:delete"})
+	require.NoError(t, err)
+	require.Len(t, decisions, 2)
+	assert.True(t, decisions[0].Allowed)
+	assert.Empty(t, decisions[0].DeniedBy)
+	assert.False(t, decisions[1].Allowed)
+	require.Len(t, decisions[1].GrantedBy, 1)
+	assert.Equal(t, operator.ID, decisions[1].GrantedBy[0].RoleID)
+	require.Len(t, decisions[1].DeniedBy, 1)
+	assert.Equal(t, "group:deny-group-01", decisions[1].DeniedBy[0].Source)
+
+	perms, err := svc.GetEffectivePermissions(ctx, user.ID, ws.ID)
+	require.NoError(t, err)
+	assert.Contains(t, perms, "mc-infra-manager:vm:read")
+	assert.NotContains(t, perms, "mc-infra-manager:vm:delete")
+
+	allowed, err := svc.HasPermission(ctx, user.ID, ws.ID, "mc-infra-manager:vm:delete")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+// TC-DENY-02: 상위 역할의 거부는 하위 역할의 허용보다 우선하며, 해석된 권한에도 deny 로 표시됨
+func TestPermissionDeny_InheritedDenyOverridesAllow(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := &AuthzService{db: db, authzRepo: repository.NewAuthzRepository(db), userRepo: repository.NewUserRepository(db)}
+	user := createGRTestUser(t, db, "deny-user-02", "kc-deny-02")
+	child, _, top := createRoleLadder(t, db, "deny02")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: child.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, child.ID, "mc-iam-manager:user:write")
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:user:write")
+
+	resp, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: "kc-deny-02", Permissions: []string{"mc-iam-manager:user:write"}})
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	require.Len(t, resp.Decisions[0].DeniedBy, 1)
+	assert.Equal(t, top.ID, resp.Decisions[0].DeniedBy[0].InheritedFromRoleID)
+
+	roleSvc := NewRoleService(db)
+	resolved, err := roleSvc.GetResolvedRolePermissions(child.ID)
+	require.NoError(t, err)
+	require.Len(t, resolved.Permissions, 1)
+	assert.Equal(t, model.PermissionEffectDeny, resolved.Permissions[0].Effect)
+	assert.Equal(t, top.ID, resolved.Permissions[0].SourceRoleID)
+	assert.True(t, resolved.Permissions[0].Inherited)
+}
+
+// TC-DENY-03: 메뉴 권한 거부 — 역할 매핑으로 허용된 메뉴라도 거부되면 하위 메뉴까지 메뉴 트리에서 제외
+func TestPermissionDeny_MenuTree(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Menu{}))
+	svc := NewMenuService(db)
+	viewer := createGRTestRole(t, db, "deny-menu-viewer")
+	restricted := createGRTestRole(t, db, "deny-menu-restricted")
+	for _, m := range []model.Menu{
+		{ID: "operations", DisplayName: "Operations", ResType: "menu", Priority: 1, MenuNumber: 1},
+		{ID: "manage", ParentID: "operations", DisplayName: "Manage", ResType: "menu", Priority: 1, MenuNumber: 2},
+		{ID: "workspaces", ParentID: "manage", DisplayName: "Workspaces", ResType: "menu", Priority: 1, MenuNumber: 3},
+		{ID: "analytics", ParentID: "operations", DisplayName: "Analytics", ResType: "menu", Priority: 2, MenuNumber: 4},
+	} {
+		menu := m
+		require.NoError(t, db.Create(&menu).Error)
+		require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: viewer.ID, MenuID: menu.ID}).Error)
+	}
+	denyAuthzTestPermission(t, db, constants.RoleTypePlatform, restricted.ID, model.MenuPermissionID("manage"))
+
+	tree, err := svc.BuildUserMenuTree(context.Background(), 0, []uint{viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	require.Len(t, tree[0].Children, 2)
+
+	tree, err = svc.BuildUserMenuTree(context.Background(), 0, []uint{viewer.ID, restricted.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "analytics", tree[0].Children[0].ID)
+}
+
+// TC-DENY-04: MCMP API 액션에 매핑된 권한이 거부되면 거부 판정을 반환 (워크스페이스 생략 시 역할을 가진 모든 워크스페이스 포함)
+func TestPermissionDeny_McmpApiAction(t *testing.T) {
+	svc, db := newTestAuthzService(t)
+	require.NoError(t, db.AutoMigrate(&mcmpapi.McmpApiAction{}, &mcmpapi.McmpApiPermissionActionMapping{}))
+	user := createGRTestUser(t, db, "deny-user-04", "kc-deny-04")
+	role := createGRTestRole(t, db, "deny-role-04")
+	ws := createGRTestWorkspace(t, db, "deny-ws-04")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	denyAuthzTestPermission(t, db, constants.RoleTypeWorkspace, role.ID, "mc-infra-manager:vm:delete")
+
+	action := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "DelMciVm", Method: "DELETE"}
+	require.NoError(t, db.Create(action).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{PermissionID: "mc-infra-manager:vm:delete", ActionID: action.ID, ActionName: action.ActionName}).Error)
+
+	ctx := context.Background()
+	denials, err := svc.GetMcmpApiActionDenials(ctx, user.ID, ws.ID, "mc-infra-manager", "DelMciVm")
+	require.NoError(t, err)
+	require.Len(t, denials, 1)
+	assert.Equal(t, "mc-infra-manager:vm:delete", denials[0].Permission)
+
+	// 워크스페이스를 생략하거나 구성원이 아닌 워크스페이스를 지정해도 역할을 가진 워크스페이스의 거부는 적용
+	denials, err = svc.GetMcmpApiActionDenials(ctx, user.ID, 0, "mc-infra-manager", "DelMciVm")
+	require.NoError(t, err)
+	require.Len(t, denials, 1)
+	other := createGRTestWorkspace(t, db, "deny-ws-04-other")
+	denials, err = svc.GetMcmpApiActionDenials(ctx, user.ID, other.ID, "mc-infra-manager", "DelMciVm")
+	require.NoError(t, err)
+	require.Len(t, denials, 1)
+
+	// 거부 매핑이 없는 워크스페이스의 구성원으로 지정하면 그 워크스페이스 역할만 평가
+	member := createGRTestRole(t, db, "deny-role-04-member")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: other.ID, RoleID: member.ID}).Error)
+	denials, err = svc.GetMcmpApiActionDenials(ctx, user.ID, other.ID, "mc-infra-manager", "DelMciVm")
+	require.NoError(t, err)
+	assert.Empty(t, denials)
+
+	denials, err = svc.GetMcmpApiActionDenials(ctx, user.ID, ws.ID, "mc-infra-manager", "GetMciVm")
+	require.NoError(t, err)
+	assert.Empty(t, denials)
+}
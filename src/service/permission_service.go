@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
+	"strconv"
+	"strings"
 
 	// "errors" // Removed unused import
 
@@ -59,8 +60,43 @@ func (s *MciamPermissionService) DeleteMcIamPermission(ctx context.Context, id s
 	return s.permissionRepo.DeleteMcIamPermission(id)
 }
 
+// RegisterMcIamPermissions 권한 ID 목록을 MC-IAM 권한으로 등록 (이미 존재하면 skip)
+func (s *MciamPermissionService) RegisterMcIamPermissions(ctx context.Context, permissionIDs []string) error {
+	permissions, err := newMciamPermissionsFromIDs(permissionIDs)
+	if err != nil {
+		return err
+	}
+	return s.permissionRepo.EnsurePermissions(permissions)
+}
+
+// newMciamPermissionsFromIDs <framework>:<resourceType>:<action> 형식의 ID 로 권한 모델 생성
+func newMciamPermissionsFromIDs(permissionIDs []string) ([]model.MciamPermission, error) {
+	permissions := make([]model.MciamPermission, 0, len(permissionIDs))
+	for _, id := range permissionIDs {
+		if !isValidPermissionID(id) {
+			return nil, fmt.Errorf("%q: %w", id, ErrInvalidPermissionID)
+		}
+		parts := strings.Split(id, ":")
+		permissions = append(permissions, model.MciamPermission{
+			ID:             id,
+			FrameworkID:    parts[0],
+			ResourceTypeID: parts[1],
+			Action:         parts[2],
+			Name:           fmt.Sprintf("%s %s", parts[1], parts[2]),
+			Description:    fmt.Sprintf("Permission to %s %s in %s", parts[2], parts[1], parts[0]),
+		})
+	}
+	return permissions, nil
+}
+
 // AssignMciamPermissionToRole 역할에 MC-IAM 권한 할당 - Renamed
-func (s *MciamPermissionService) AssignMciamPermissionToRole(ctx context.Context, roleType constants.IAMRoleType, roleID uint, permissionID string) error {
+// effect 가 deny 이면 거부 매핑으로 등록되며, 다른 역할/그룹 경로의 허용보다 우선한다.
+// conditions 가 있으면 조건을 모두 만족할 때만 매핑이 적용된다.
+func (s *MciamPermissionService) AssignMciamPermissionToRole(ctx context.Context, roleType constants.IAMRoleType, roleID uint, permissionID string, effect model.PermissionEffect, conditions *model.GrantConditions) error {
+	if err := ValidateGrantConditions(conditions); err != nil {
+		return err
+	}
+
 	// 권한 존재 여부 확인
 	_, err := s.permissionRepo.GetByID(permissionID)
 	if err != nil {
@@ -78,7 +114,7 @@ func (s *MciamPermissionService) AssignMciamPermissionToRole(ctx context.Context
 	//  return errors.New("invalid role type")
 	// }
 
-	return s.permissionRepo.AssignMciamPermissionToRole(roleType, roleID, permissionID) // Use renamed repo method
+	return s.permissionRepo.AssignMciamPermissionToRole(roleType, roleID, permissionID, effect, conditions) // Use renamed repo method
 }
 
 // RemoveMciamPermissionFromRole 역할에서 MC-IAM 권한 제거 - Renamed
@@ -87,48 +123,44 @@ func (s *MciamPermissionService) RemoveMciamPermissionFromRole(ctx context.Conte
 	return s.permissionRepo.RemoveMciamPermissionFromRole(roleType, roleID, permissionID) // Use renamed repo method
 }
 
-// GetRoleMciamPermissions 역할의 MC-IAM 권한 ID 목록 조회 - Renamed
-func (s *MciamPermissionService) GetRoleMciamPermissions(ctx context.Context, roleType constants.IAMRoleType, roleID uint) ([]string, error) { // Return []string
+// GetRoleMciamPermissions 역할의 MC-IAM 권한 ID 목록 조회 (effect 별) - Renamed
+func (s *MciamPermissionService) GetRoleMciamPermissions(ctx context.Context, roleType constants.IAMRoleType, roleID uint, effect model.PermissionEffect) ([]string, error) { // Return []string
 	// TODO: 역할 존재 여부 확인 (Platform or Workspace)
-	return s.permissionRepo.GetRoleMciamPermissions(roleType, roleID) // Use renamed repo method
+	return s.permissionRepo.GetRoleMciamPermissionsByEffect(roleType, roleID, effect) // Use renamed repo method
 }
 
-// Note: Need similar service for CSP permissions and role-csp mappings later.
-
-func (s *MciamPermissionService) checkPermission(ctx context.Context, userID uint, workspaceID string, requiredPermission string) error {
-	// 1. 사용자의 워크스페이스 역할 조회
-	var roles []string
-	query := s.db.Table("mcmp_user_workspace_roles").
-		Joins("JOIN mcmp_workspace_roles ON mcmp_user_workspace_roles.workspace_role_id = mcmp_workspace_roles.id").
-		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_user_workspace_roles.workspace_id = ?", userID, workspaceID).
-		Pluck("mcmp_workspace_roles.name", &roles)
-
-	if err := query.Error; err != nil {
-		// 에러 발생 시에만 쿼리 로깅
-		sql := query.Statement.SQL.String()
-		args := query.Statement.Vars
-		log.Printf("Permission Check SQL Query (ERROR): %s", sql)
-		log.Printf("Permission Check SQL Args (ERROR): %v", args)
-		return fmt.Errorf("failed to get user workspace roles: %w", err)
+// ParsePermissionEffect 문자열을 권한 매핑 효과로 변환 (빈 값은 allow)
+func ParsePermissionEffect(v string) (model.PermissionEffect, error) {
+	switch effect := model.PermissionEffect(strings.ToLower(strings.TrimSpace(v))); effect {
+	case "":
+		return model.PermissionEffectAllow, nil
+	case model.PermissionEffectAllow, model.PermissionEffectDeny:
+		return effect, nil
+	default:
+		return "", fmt.Errorf("%q: %w", v, ErrInvalidPermissionEffect)
 	}
+}
 
-	// 2. 역할별 권한 매핑
-	rolePermissions := map[string][]string{
-		"admin":    {"read", "write", "delete", "manage"},
-		"operator": {"read", "write"},
-		"viewer":   {"read"},
-	}
+// Note: Need similar service for CSP permissions and role-csp mappings later.
 
-	// 3. 사용자의 권한 확인
-	for _, role := range roles {
-		if permissions, exists := rolePermissions[role]; exists {
-			for _, permission := range permissions {
-				if permission == requiredPermission {
-					return nil
-				}
-			}
+// CheckPermission 사용자가 MC-IAM 권한을 보유하는지 확인 (직접 할당 + 그룹 상속 역할 기준)
+// workspaceID 가 비어 있으면 플랫폼 역할만 평가한다. 권한이 없으면 ErrPermissionDenied 를 반환한다.
+func (s *MciamPermissionService) CheckPermission(ctx context.Context, userID uint, workspaceID string, requiredPermission string) error {
+	var wsID uint
+	if workspaceID != "" {
+		id, err := strconv.ParseUint(workspaceID, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%q: %w", workspaceID, ErrInvalidWorkspaceID)
 		}
+		wsID = uint(id)
 	}
 
-	return fmt.Errorf("permission denied: user does not have %s permission in workspace %s", requiredPermission, workspaceID)
+	allowed, err := NewAuthzService(s.db).HasPermission(ctx, userID, wsID, requiredPermission)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("user %d does not have %s permission in workspace %q: %w", userID, requiredPermission, workspaceID, ErrPermissionDenied)
+	}
+	return nil
 }
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/report"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReportNotFound      = errors.New("report not found")
+	ErrInvalidReportOption = errors.New("invalid report option")
+)
+
+const (
+	// privilegedRoleName 특권 사용자 보고서 대상 플랫폼 역할
+	privilegedRoleName = "platformAdmin"
+
+	defaultReportDormantDays = 90
+	defaultReportInterval    = 24 * time.Hour
+	reportTimeFormat         = time.RFC3339
+)
+
+// reportDefinitions 제공하는 보고서 (목록 조회 순서)
+var reportDefinitions = []model.ReportDefinition{
+	{Name: model.ReportPrivilegedUsers, Description: "Users holding platformAdmin directly or through a group"},
+	{Name: model.ReportDormantAccounts, Description: "Active users with no login, token refresh or token use for the given number of days"},
+	{Name: model.ReportUnmappedCspRoles, Description: "CSP roles not mapped to any role"},
+	{Name: model.ReportEmptyWorkspaceRoles, Description: "Workspace roles assigned to no user and no group"},
+	{Name: model.ReportFailedIdpConfigs, Description: "CSP IDP configs whose last health check failed or timed out"},
+}
+
+// ReportService 규정 준수 보고서 생성 (CSV/XLSX) 과 정기 생성
+type ReportService struct {
+	reportRepo *repository.ReportRepository
+	kcService  KeycloakService
+}
+
+// NewReportService ReportService 생성
+func NewReportService(db *gorm.DB) *ReportService {
+	return &ReportService{
+		reportRepo: repository.NewReportRepository(db),
+		kcService:  NewKeycloakService(),
+	}
+}
+
+// ListReports 제공하는 보고서 목록
+func (s *ReportService) ListReports() []model.ReportDefinition {
+	defs := make([]model.ReportDefinition, len(reportDefinitions))
+	for i, d := range reportDefinitions {
+		d.Formats = []string{report.FormatCSV, report.FormatXLSX}
+		defs[i] = d
+	}
+	return defs
+}
+
+// Generate 보고서 생성
+func (s *ReportService) Generate(ctx context.Context, name string, opts model.ReportOptions) (*report.Table, error) {
+	switch name {
+	case model.ReportPrivilegedUsers:
+		return s.privilegedUsers()
+	case model.ReportDormantAccounts:
+		return s.dormantAccounts(ctx, opts.DormantDays, time.Now())
+	case model.ReportUnmappedCspRoles:
+		return s.unmappedCspRoles()
+	case model.ReportEmptyWorkspaceRoles:
+		return s.emptyWorkspaceRoles()
+	case model.ReportFailedIdpConfigs:
+		return s.failedIdpConfigs()
+	}
+	return nil, fmt.Errorf("%w: %s", ErrReportNotFound, name)
+}
+
+func (s *ReportService) privilegedUsers() (*report.Table, error) {
+	holders, err := s.reportRepo.FindPlatformRoleHolders(privilegedRoleName)
+	if err != nil {
+		return nil, err
+	}
+	table := &report.Table{
+		Name:    model.ReportPrivilegedUsers,
+		Columns: []string{"user_id", "username", "kc_id", "status", "role", "source"},
+	}
+	for _, h := range holders {
+		source := "direct"
+		if h.GroupName != "" {
+			source = "group:" + h.GroupName
+		}
+		table.Rows = append(table.Rows, []string{
+			formatUint(h.UserID), h.Username, h.KcID, string(h.Status), h.RoleName, source,
+		})
+	}
+	return table, nil
+}
+
+// dormantAccounts 마지막 활동(로그인/토큰 갱신/토큰 사용, 기록이 없으면 생성 시각)이 days 일 전보다 오래된 활성 사용자
+// DB 에 기록되지 않은 Keycloak 직접 로그인(LOGIN 이벤트)이 기간 내에 있으면 제외한다.
+func (s *ReportService) dormantAccounts(ctx context.Context, days int, now time.Time) (*report.Table, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("%w: days must not be negative", ErrInvalidReportOption)
+	}
+	if days == 0 {
+		days = reportDormantDays()
+	}
+	since := now.AddDate(0, 0, -days)
+	users, err := s.reportRepo.FindActiveUsersInactiveSince(since)
+	if err != nil {
+		return nil, err
+	}
+	lastLogin, err := s.kcService.GetLastLoginTimes(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	table := &report.Table{
+		Name: model.ReportDormantAccounts,
+		Columns: []string{
+			"user_id", "username", "kc_id", "status", "created_at",
+			"last_login_at", "last_activity_at", "dormancy_exemption", "no_activity_since",
+		},
+	}
+	for _, u := range users {
+		if _, ok := lastLogin[u.KcId]; ok {
+			continue
+		}
+		table.Rows = append(table.Rows, []string{
+			formatUint(u.ID), u.Username, u.KcId, string(u.Status),
+			u.CreatedAt.UTC().Format(reportTimeFormat),
+			formatReportTime(u.LastLoginAt), formatReportTime(u.LastActivityAt), u.DormancyExemption,
+			since.UTC().Format(reportTimeFormat),
+		})
+	}
+	return table, nil
+}
+
+func (s *ReportService) unmappedCspRoles() (*report.Table, error) {
+	roles, err := s.reportRepo.FindUnmappedCspRoles()
+	if err != nil {
+		return nil, err
+	}
+	table := &report.Table{
+		Name:    model.ReportUnmappedCspRoles,
+		Columns: []string{"csp_role_id", "name", "csp_type", "csp_account", "idp_identifier", "iam_identifier", "created_at"},
+	}
+	for _, r := range roles {
+		account := ""
+		if r.CspAccount != nil {
+			account = r.CspAccount.Name
+		}
+		table.Rows = append(table.Rows, []string{
+			formatUint(r.ID), r.Name, r.CspType, account, r.IdpIdentifier, r.IamIdentifier,
+			r.CreatedAt.UTC().Format(reportTimeFormat),
+		})
+	}
+	return table, nil
+}
+
+func (s *ReportService) emptyWorkspaceRoles() (*report.Table, error) {
+	roles, err := s.reportRepo.FindEmptyWorkspaceRoles()
+	if err != nil {
+		return nil, err
+	}
+	table := &report.Table{
+		Name:    model.ReportEmptyWorkspaceRoles,
+		Columns: []string{"role_id", "name", "description", "predefined", "created_at"},
+	}
+	for _, r := range roles {
+		table.Rows = append(table.Rows, []string{
+			formatUint(r.ID), r.Name, r.Description, strconv.FormatBool(r.Predefined),
+			r.CreatedAt.UTC().Format(reportTimeFormat),
+		})
+	}
+	return table, nil
+}
+
+func (s *ReportService) failedIdpConfigs() (*report.Table, error) {
+	configs, err := s.reportRepo.FindIdpConfigsByHealthStatus([]string{model.HealthStatusFailed, model.HealthStatusTimeout})
+	if err != nil {
+		return nil, err
+	}
+	table := &report.Table{
+		Name: model.ReportFailedIdpConfigs,
+		Columns: []string{"config_id", "name", "csp_account", "csp_type", "auth_method", "is_active",
+			"health_status", "health_error", "checked_at"},
+	}
+	for _, c := range configs {
+		account, cspType := "", ""
+		if c.CspAccount != nil {
+			account, cspType = c.CspAccount.Name, c.CspAccount.CspType
+		}
+		table.Rows = append(table.Rows, []string{
+			formatUint(c.ID), c.Name, account, cspType, string(c.AuthMethod), strconv.FormatBool(c.IsActive),
+			c.LastHealthStatus, c.LastHealthError, formatReportTime(c.LastHealthCheckedAt),
+		})
+	}
+	return table, nil
+}
+
+// WriteReports 보고서들을 dir 에 <이름>-<UTC 시각>.<형식> 파일로 저장하고 경로 목록 반환
+// 파일은 임시 이름으로 쓴 뒤 이름을 바꿔, 수집기가 쓰는 중인 파일을 읽지 않게 한다.
+func (s *ReportService) WriteReports(ctx context.Context, dir, format string, names []string, now time.Time) ([]string, error) {
+	if !report.ValidFormat(format) {
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidReportOption, format)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	var paths []string
+	var errs []error
+	for _, name := range names {
+		table, err := s.Generate(ctx, name, model.ReportOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", name, now.UTC().Format("20060102T150405Z"), format))
+		if err := writeReportFile(path, table, format); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, errors.Join(errs...)
+}
+
+func writeReportFile(path string, table *report.Table, format string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := table.Write(tmp, format); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// StartScheduledReports MC_IAM_MANAGER_REPORT_DIR 가 설정되어 있으면 주기적으로 보고서 파일 생성
+// 주기 MC_IAM_MANAGER_REPORT_INTERVAL (기본 24h), 형식 MC_IAM_MANAGER_REPORT_FORMAT (csv|xlsx, 기본 csv),
+// 대상 MC_IAM_MANAGER_REPORT_NAMES (쉼표 구분, 기본 전체)
+func (s *ReportService) StartScheduledReports(ctx context.Context) {
+	dir := os.Getenv("MC_IAM_MANAGER_REPORT_DIR")
+	if dir == "" {
+		return
+	}
+	interval := defaultReportInterval
+	if raw := os.Getenv("MC_IAM_MANAGER_REPORT_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			interval = d
+		} else {
+			log.Printf("[REPORT] invalid MC_IAM_MANAGER_REPORT_INTERVAL %q, using %s", raw, interval)
+		}
+	}
+	format := report.FormatCSV
+	if raw := os.Getenv("MC_IAM_MANAGER_REPORT_FORMAT"); raw != "" {
+		if report.ValidFormat(raw) {
+			format = raw
+		} else {
+			log.Printf("[REPORT] invalid MC_IAM_MANAGER_REPORT_FORMAT %q, using %s", raw, format)
+		}
+	}
+	names, err := scheduledReportNames(os.Getenv("MC_IAM_MANAGER_REPORT_NAMES"))
+	if err != nil {
+		log.Printf("[REPORT] scheduled reports disabled: %v", err)
+		return
+	}
+	log.Printf("[REPORT] writing %s reports %v to %s every %s", format, names, dir, interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				paths, err := s.WriteReports(ctx, dir, format, names, now)
+				if err != nil {
+					log.Printf("[REPORT] failed to write scheduled reports: %v", err)
+				}
+				for _, p := range paths {
+					log.Printf("[REPORT] wrote %s", p)
+				}
+			}
+		}
+	}()
+}
+
+// scheduledReportNames 쉼표 구분 보고서 이름 (비어 있으면 전체)
+func scheduledReportNames(raw string) ([]string, error) {
+	known := make(map[string]bool, len(reportDefinitions))
+	all := make([]string, 0, len(reportDefinitions))
+	for _, d := range reportDefinitions {
+		known[d.Name] = true
+		all = append(all, d.Name)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return all, nil
+	}
+	names := uniqueNonEmpty(strings.Split(raw, ","))
+	for _, name := range names {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: %s", ErrReportNotFound, name)
+		}
+	}
+	return names, nil
+}
+
+// reportDormantDays 휴면 계정 보고서 기본 기간 (MC_IAM_MANAGER_REPORT_DORMANT_DAYS, 기본 90)
+func reportDormantDays() int {
+	if raw := os.Getenv("MC_IAM_MANAGER_REPORT_DORMANT_DAYS"); raw != "" {
+		if days, err := strconv.Atoi(raw); err == nil && days > 0 {
+			return days
+		}
+		log.Printf("[REPORT] invalid MC_IAM_MANAGER_REPORT_DORMANT_DAYS %q, using %d", raw, defaultReportDormantDays)
+	}
+	return defaultReportDormantDays
+}
+
+func formatUint(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// formatReportTime 보고서 시각 (nil 이면 빈 값)
+func formatReportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(reportTimeFormat)
+}
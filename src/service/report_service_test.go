@@ -0,0 +1,220 @@
+package service
+
+// report_service_test.go
+//
+// ReportService 단위 테스트 (SQLite in-memory DB)
+// 특권 사용자(직접/그룹), 휴면 계정(Keycloak LOGIN 이벤트), 미매핑 CSP 역할, 구성원 없는 워크스페이스 역할,
+// 연결 상태 확인 실패 IDP 설정 보고서와 정기 생성 파일 저장을 검증한다.
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/report"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestReportService(t *testing.T) (*ReportService, *gorm.DB, *recordingKeycloak) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.CspAccount{},
+		&model.CspIdpConfig{},
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+	))
+	kc := &recordingKeycloak{}
+	return &ReportService{
+		reportRepo: repository.NewReportRepository(db),
+		kcService:  kc,
+	}, db, kc
+}
+
+// reportColumn 보고서에서 지정한 열 값 목록
+func reportColumn(t *testing.T, table *report.Table, column string) []string {
+	t.Helper()
+	idx := -1
+	for i, c := range table.Columns {
+		if c == column {
+			idx = i
+		}
+	}
+	require.GreaterOrEqual(t, idx, 0, "column %s", column)
+	values := make([]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		values = append(values, row[idx])
+	}
+	return values
+}
+
+// ── 보고서 ────────────────────────────────────────────────────────────────────
+
+// TC-RPT-PRIV-01: platformAdmin 직접 보유자와 그룹 상속 보유자를 경로와 함께 보고
+func TestReportPrivilegedUsers(t *testing.T) {
+	svc, db, _ := newTestReportService(t)
+	admin := createGRTestRole(t, db, "platformAdmin")
+	viewer := createGRTestRole(t, db, "viewer")
+	group := createGRTestOrg(t, db, "ops", "OPS")
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: group.ID, RoleID: admin.ID}).Error)
+
+	alice := createGRTestUser(t, db, "alice", "kc-alice")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: alice.ID, RoleID: admin.ID}).Error)
+	bob := createGRTestUser(t, db, "bob", "kc-bob")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: bob.ID, OrganizationID: group.ID}).Error)
+	carol := createGRTestUser(t, db, "carol", "kc-carol")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: carol.ID, RoleID: viewer.ID}).Error)
+
+	table, err := svc.Generate(context.Background(), model.ReportPrivilegedUsers, model.ReportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, reportColumn(t, table, "username"))
+	assert.Equal(t, []string{"direct", "group:ops"}, reportColumn(t, table, "source"))
+}
+
+// TC-RPT-DORM-01: 기간 내 기록된 활동도 Keycloak LOGIN 이벤트도 없는 활성 사용자만 보고 (기록이 없으면 생성 시각 기준)
+func TestReportDormantAccounts(t *testing.T) {
+	svc, db, kc := newTestReportService(t)
+	old := time.Now().AddDate(0, 0, -60)
+	recent := time.Now().AddDate(0, 0, -1)
+	stale := time.Now().AddDate(0, 0, -45)
+	for _, u := range []*model.User{
+		{Username: "token-user", KcId: "kc-5", Status: model.UserStatusActive, CreatedAt: old, LastActivityAt: &recent},
+		{Username: "stale-login", KcId: "kc-6", Status: model.UserStatusActive, CreatedAt: old, LastLoginAt: &stale, LastActivityAt: &stale},
+		{Username: "active-login", KcId: "kc-1", Status: model.UserStatusActive, CreatedAt: old},
+		{Username: "dormant", KcId: "kc-2", Status: model.UserStatusActive, CreatedAt: old},
+		{Username: "inactive", KcId: "kc-3", Status: model.UserStatusInactive, CreatedAt: old},
+		{Username: "new", KcId: "kc-4", Status: model.UserStatusActive},
+	} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	kc.lastLogin = map[string]time.Time{"kc-1": time.Now().AddDate(0, 0, -3)}
+
+	table, err := svc.Generate(context.Background(), model.ReportDormantAccounts, model.ReportOptions{DormantDays: 30})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stale-login", "dormant"}, reportColumn(t, table, "username"))
+	assert.Equal(t, []string{stale.UTC().Format(time.RFC3339), ""}, reportColumn(t, table, "last_login_at"))
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), kc.since, time.Minute)
+
+	_, err = svc.Generate(context.Background(), model.ReportDormantAccounts, model.ReportOptions{DormantDays: -1})
+	assert.True(t, errors.Is(err, ErrInvalidReportOption))
+}
+
+// TC-RPT-ORPH-01: 매핑 없는 CSP 역할, 사용자/그룹 할당이 없는 워크스페이스 역할 보고
+func TestReportOrphanedMappings(t *testing.T) {
+	svc, db, _ := newTestReportService(t)
+	account := &model.CspAccount{Name: "aws-main", CspType: "aws"}
+	require.NoError(t, db.Create(account).Error)
+	mappedCsp := &model.CspRole{Name: "mapped", CspType: "aws", CspAccountID: &account.ID}
+	unmappedCsp := &model.CspRole{Name: "unmapped", CspType: "aws", CspAccountID: &account.ID}
+	require.NoError(t, db.Create(mappedCsp).Error)
+	require.NoError(t, db.Create(unmappedCsp).Error)
+
+	userRole := createGRTestRole(t, db, "user-role")
+	groupRole := createGRTestRole(t, db, "group-role")
+	emptyRole := createGRTestRole(t, db, "empty-role")
+	platformOnly := &model.RoleMaster{Name: "platform-only"}
+	require.NoError(t, db.Create(platformOnly).Error)
+	require.NoError(t, db.Create(&model.RoleSub{RoleID: platformOnly.ID, RoleType: constants.RoleTypePlatform}).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: emptyRole.ID, AuthMethod: constants.AuthMethodOIDC, CspRoleID: mappedCsp.ID}).Error)
+
+	ws := createGRTestWorkspace(t, db, "ws-1")
+	user := createGRTestUser(t, db, "dave", "kc-dave")
+	group := createGRTestOrg(t, db, "devs", "DEV")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: userRole.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: group.ID, WorkspaceID: ws.ID, RoleID: groupRole.ID}).Error)
+
+	table, err := svc.Generate(context.Background(), model.ReportUnmappedCspRoles, model.ReportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unmapped"}, reportColumn(t, table, "name"))
+	assert.Equal(t, []string{"aws-main"}, reportColumn(t, table, "csp_account"))
+
+	table, err = svc.Generate(context.Background(), model.ReportEmptyWorkspaceRoles, model.ReportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"empty-role"}, reportColumn(t, table, "name"))
+}
+
+// TC-RPT-IDP-01: 마지막 연결 상태 확인이 FAILED/TIMEOUT 인 IDP 설정만 보고
+func TestReportFailedIdpConfigs(t *testing.T) {
+	svc, db, _ := newTestReportService(t)
+	account := &model.CspAccount{Name: "aws-main", CspType: "aws"}
+	require.NoError(t, db.Create(account).Error)
+	repo := repository.NewCspIdpConfigRepository(db)
+	statuses := map[string]string{
+		"connected": model.HealthStatusConnected,
+		"failed":    model.HealthStatusFailed,
+		"timeout":   model.HealthStatusTimeout,
+		"unchecked": "",
+	}
+	for _, name := range []string{"connected", "failed", "timeout", "unchecked"} {
+		cfg := &model.CspIdpConfig{Name: name, CspAccountID: account.ID, AuthMethod: model.AuthMethodOIDC, IsActive: true}
+		require.NoError(t, db.Create(cfg).Error)
+		if statuses[name] != "" {
+			require.NoError(t, repo.UpdateHealthCheck(cfg.ID, statuses[name], "boom: "+name, time.Now()))
+		}
+	}
+
+	table, err := svc.Generate(context.Background(), model.ReportFailedIdpConfigs, model.ReportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"failed", "timeout"}, reportColumn(t, table, "name"))
+	assert.Equal(t, []string{"FAILED", "TIMEOUT"}, reportColumn(t, table, "health_status"))
+	assert.Equal(t, []string{"aws", "aws"}, reportColumn(t, table, "csp_type"))
+}
+
+// TC-RPT-GEN-01: 알 수 없는 보고서 → ErrReportNotFound
+func TestReportUnknown(t *testing.T) {
+	svc, _, _ := newTestReportService(t)
+	_, err := svc.Generate(context.Background(), "nope", model.ReportOptions{})
+	assert.True(t, errors.Is(err, ErrReportNotFound))
+
+	_, err = scheduledReportNames("privileged-users, nope")
+	assert.True(t, errors.Is(err, ErrReportNotFound))
+	names, err := scheduledReportNames("")
+	require.NoError(t, err)
+	assert.Len(t, names, len(reportDefinitions))
+}
+
+// ── 정기 생성 ─────────────────────────────────────────────────────────────────
+
+// TC-RPT-SCHED-01: 보고서별 시각이 붙은 파일 저장, 임시 파일은 남지 않음
+func TestReportWriteReports(t *testing.T) {
+	svc, db, _ := newTestReportService(t)
+	admin := createGRTestRole(t, db, "platformAdmin")
+	alice := createGRTestUser(t, db, "alice", "kc-alice")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: alice.ID, RoleID: admin.ID}).Error)
+
+	dir := filepath.Join(t.TempDir(), "reports")
+	now := time.Date(2026, 5, 1, 2, 3, 4, 0, time.UTC)
+	paths, err := svc.WriteReports(context.Background(), dir, report.FormatCSV,
+		[]string{model.ReportPrivilegedUsers, model.ReportEmptyWorkspaceRoles}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "privileged-users-20260501T020304Z.csv"),
+		filepath.Join(dir, "empty-workspace-roles-20260501T020304Z.csv"),
+	}, paths)
+
+	f, err := os.Open(paths[0])
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "alice", records[1][1])
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	_, err = svc.WriteReports(context.Background(), dir, "pdf", []string{model.ReportPrivilegedUsers}, now)
+	assert.True(t, errors.Is(err, ErrInvalidReportOption))
+}
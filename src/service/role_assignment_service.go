@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidAssignmentValidity 역할 할당 유효 기간이 올바르지 않음
+var ErrInvalidAssignmentValidity = errors.New("invalid role assignment validity")
+
+const (
+	defaultRoleExpiryInterval = time.Minute
+	roleExpirySystemActor     = "system"
+)
+
+// roleAssignmentAuditActions 할당 유형별 시작/만료 감사 동작
+var roleAssignmentAuditActions = map[string][2]string{
+	model.RoleAssignmentUserPlatform:   {model.AuditActionPlatformRoleActivate, model.AuditActionPlatformRoleExpire},
+	model.RoleAssignmentUserWorkspace:  {model.AuditActionWorkspaceRoleActivate, model.AuditActionWorkspaceRoleExpire},
+	model.RoleAssignmentGroupPlatform:  {model.AuditActionGroupPlatformActivate, model.AuditActionGroupPlatformExpire},
+	model.RoleAssignmentGroupWorkspace: {model.AuditActionGroupWorkspaceActivate, model.AuditActionGroupWorkspaceExpire},
+}
+
+// ValidateAssignmentValidity 유효 기간 검사 (만료 시각은 now 이후이고 시작 시각보다 뒤여야 함)
+func ValidateAssignmentValidity(validity model.AssignmentValidity, now time.Time) error {
+	if validity.ExpiresAt == nil {
+		return nil
+	}
+	if !validity.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAssignmentValidity)
+	}
+	if validity.ValidFrom != nil && !validity.ExpiresAt.After(*validity.ValidFrom) {
+		return fmt.Errorf("%w: expires_at must be after valid_from", ErrInvalidAssignmentValidity)
+	}
+	return nil
+}
+
+// RoleAssignmentService 기한부 역할 할당 처리 (시작 시 Keycloak 부여, 만료 시 DB/Keycloak 회수)
+type RoleAssignmentService struct {
+	assignmentRepo *repository.RoleAssignmentRepository
+	kcService      KeycloakService
+	auditService   *AuditService // nil 이면 감사 기록하지 않음
+}
+
+// NewRoleAssignmentService RoleAssignmentService 생성
+func NewRoleAssignmentService(db *gorm.DB) *RoleAssignmentService {
+	return &RoleAssignmentService{
+		assignmentRepo: repository.NewRoleAssignmentRepository(db),
+		kcService:      NewKeycloakService(),
+		auditService:   NewAuditService(db),
+	}
+}
+
+// RunExpiry 시작 시각이 된 할당을 활성화하고 만료된 할당을 회수 (1회)
+// Keycloak 처리에 실패한 할당은 DB 에 그대로 두어 다음 실행에서 다시 시도한다.
+func (s *RoleAssignmentService) RunExpiry(ctx context.Context, now time.Time) (*model.RoleAssignmentRunResult, error) {
+	result := &model.RoleAssignmentRunResult{}
+
+	pending, err := s.assignmentRepo.FindPendingActivations(now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pending {
+		a := &pending[i]
+		if err := s.activate(ctx, a); err != nil {
+			s.recordFailure(result, a, err)
+			continue
+		}
+		result.Activated = append(result.Activated, *a)
+		s.recordAudit(roleAssignmentAuditActions[a.Type][0], a)
+	}
+
+	expired, err := s.assignmentRepo.FindExpiredAssignments(now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range expired {
+		a := &expired[i]
+		if err := s.expire(ctx, a); err != nil {
+			s.recordFailure(result, a, err)
+			continue
+		}
+		result.Expired = append(result.Expired, *a)
+		s.recordAudit(roleAssignmentAuditActions[a.Type][1], a)
+	}
+	return result, nil
+}
+
+// StartExpiryReaper 주기적으로 RunExpiry 실행 (ctx 가 끝나면 중지)
+func (s *RoleAssignmentService) StartExpiryReaper(ctx context.Context) {
+	interval := dormancyEnvDuration("MC_IAM_MANAGER_ROLE_EXPIRY_INTERVAL", defaultRoleExpiryInterval)
+	log.Printf("[ROLE_EXPIRY] checking time-bound role assignments every %s", interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.RunExpiry(ctx, time.Now())
+				if err != nil {
+					log.Printf("[ROLE_EXPIRY] failed to process role assignments: %v", err)
+					continue
+				}
+				if len(result.Activated) > 0 || len(result.Expired) > 0 || len(result.Failed) > 0 {
+					log.Printf("[ROLE_EXPIRY] activated=%d expired=%d failed=%d",
+						len(result.Activated), len(result.Expired), len(result.Failed))
+				}
+			}
+		}
+	}()
+}
+
+// activate 시작 시각이 된 할당 활성화 (플랫폼 역할은 Keycloak realm role 부여)
+func (s *RoleAssignmentService) activate(ctx context.Context, a *model.TimedRoleAssignment) error {
+	switch a.Type {
+	case model.RoleAssignmentUserPlatform:
+		exists, err := s.kcService.CheckRealmRoleExists(ctx, a.RoleName)
+		if err != nil {
+			return fmt.Errorf("failed to check keycloak realm role: %w", err)
+		}
+		if !exists {
+			if err := s.kcService.CreateRealmRoleAndWait(ctx, a.RoleName); err != nil {
+				return fmt.Errorf("failed to create keycloak realm role: %w", err)
+			}
+		}
+		if err := s.kcService.AssignRealmRoleToUser(ctx, a.KcUserID, a.RoleName); err != nil {
+			return fmt.Errorf("failed to assign keycloak realm role: %w", err)
+		}
+	case model.RoleAssignmentGroupPlatform:
+		if err := s.kcService.AddRealmRoleToGroup(ctx, a.GroupName, a.RoleName); err != nil {
+			return fmt.Errorf("failed to assign role to keycloak group: %w", err)
+		}
+	}
+	return s.assignmentRepo.ClearActivationPending(a)
+}
+
+// expire 만료된 할당 회수 (Keycloak 먼저 제거, 성공하면 DB 삭제)
+// 시작 전(pending) 상태로 만료된 할당은 Keycloak 에 부여된 적이 없으므로 DB 만 삭제한다.
+func (s *RoleAssignmentService) expire(ctx context.Context, a *model.TimedRoleAssignment) error {
+	if !a.Pending {
+		switch a.Type {
+		case model.RoleAssignmentUserPlatform:
+			if err := s.kcService.RemoveRealmRoleFromUser(ctx, a.KcUserID, a.RoleName); err != nil {
+				if !strings.Contains(err.Error(), "404") && !strings.Contains(err.Error(), "User not found") {
+					return fmt.Errorf("failed to remove keycloak realm role: %w", err)
+				}
+				log.Printf("[ROLE_EXPIRY] KC user not found (%s), skipping KC removal", a.KcUserID)
+			}
+		case model.RoleAssignmentGroupPlatform:
+			if err := s.kcService.RemoveRealmRoleFromGroup(ctx, a.GroupName, a.RoleName); err != nil {
+				return fmt.Errorf("failed to remove role from keycloak group: %w", err)
+			}
+		}
+	}
+	return s.assignmentRepo.Delete(a)
+}
+
+func (s *RoleAssignmentService) recordFailure(result *model.RoleAssignmentRunResult, a *model.TimedRoleAssignment, err error) {
+	key := describeTimedAssignment(a)
+	log.Printf("[ROLE_EXPIRY] %s: %v", key, err)
+	if result.Failed == nil {
+		result.Failed = map[string]string{}
+	}
+	result.Failed[key] = err.Error()
+}
+
+// recordAudit 시작/만료 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록, 보안 이벤트 싱크로도 전달됨)
+func (s *RoleAssignmentService) recordAudit(action string, a *model.TimedRoleAssignment) {
+	if s.auditService == nil {
+		return
+	}
+	entityType, entityID, path := model.AuditEntityUser, a.UserID, fmt.Sprintf("/api/users/id/%d", a.UserID)
+	if a.GroupID != 0 {
+		entityType, entityID, path = model.AuditEntityGroup, a.GroupID, fmt.Sprintf("/api/groups/id/%d", a.GroupID)
+	}
+	event := &model.AuditEvent{
+		ActorUsername: roleExpirySystemActor,
+		Method:        "SYSTEM",
+		Route:         "role.expiry",
+		Path:          path,
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+		After:      a,
+	}
+	if a.WorkspaceID != 0 {
+		workspaceID := a.WorkspaceID
+		detail.WorkspaceID = &workspaceID
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[ROLE_EXPIRY] failed to record audit event for %s: %v", describeTimedAssignment(a), err)
+	}
+}
+
+// describeTimedAssignment 로그/결과용 할당 설명 (예: user-platform-role alice/admin)
+func describeTimedAssignment(a *model.TimedRoleAssignment) string {
+	holder := a.Username
+	if a.GroupID != 0 {
+		holder = a.GroupName
+	}
+	if a.WorkspaceID != 0 {
+		return fmt.Sprintf("%s %s/ws%d/%s", a.Type, holder, a.WorkspaceID, a.RoleName)
+	}
+	return fmt.Sprintf("%s %s/%s", a.Type, holder, a.RoleName)
+}
@@ -0,0 +1,208 @@
+package service
+
+// role_assignment_service_test.go
+//
+// 기한부 역할 할당 (valid_from/expires_at) 단위 테스트 (SQLite in-memory DB)
+// 유효 기간 검사, 권한 판정 시 기간 밖 할당 제외, 만료 처리기의 Keycloak 부여/회수와
+// 실패 시 재시도(DB 유지)를 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestRoleAssignmentService(t *testing.T) (*RoleAssignmentService, *gorm.DB, *recordingKeycloak) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	kc := &recordingKeycloak{}
+	return &RoleAssignmentService{
+		assignmentRepo: repository.NewRoleAssignmentRepository(db),
+		kcService:      kc,
+	}, db, kc
+}
+
+func timeAt(d time.Duration) *time.Time {
+	t := time.Now().Add(d)
+	return &t
+}
+
+func countRows(t *testing.T, db *gorm.DB, value interface{}) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Model(value).Count(&n).Error)
+	return n
+}
+
+// ── 유효 기간 검사 ────────────────────────────────────────────────────────────
+
+// TC-RAS-VAL-01: 만료 시각은 현재 이후이고 시작 시각보다 뒤여야 함, 시작 시각만 지정은 허용
+func TestValidateAssignmentValidity(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, ValidateAssignmentValidity(model.AssignmentValidity{}, now))
+	assert.NoError(t, ValidateAssignmentValidity(model.AssignmentValidity{ValidFrom: timeAt(time.Hour)}, now))
+	assert.NoError(t, ValidateAssignmentValidity(model.AssignmentValidity{ValidFrom: timeAt(-time.Hour), ExpiresAt: timeAt(time.Hour)}, now))
+
+	err := ValidateAssignmentValidity(model.AssignmentValidity{ExpiresAt: timeAt(-time.Minute)}, now)
+	assert.True(t, errors.Is(err, ErrInvalidAssignmentValidity))
+	err = ValidateAssignmentValidity(model.AssignmentValidity{ValidFrom: timeAt(2 * time.Hour), ExpiresAt: timeAt(time.Hour)}, now)
+	assert.True(t, errors.Is(err, ErrInvalidAssignmentValidity))
+}
+
+// ── 권한 판정 ─────────────────────────────────────────────────────────────────
+
+// TC-RAS-AUTHZ-01: 시작 전/만료된 할당은 권한 판정에서 제외, SoD 용 조회는 시작 전 할당 포함
+func TestAuthzGrantsRespectValidity(t *testing.T) {
+	db := setupAuthzTestDB(t)
+	repo := repository.NewAuthzRepository(db)
+	user := createGRTestUser(t, db, "contractor", "kc-contractor")
+	active := createGRTestRole(t, db, "active")
+	future := createGRTestRole(t, db, "future")
+	expired := createGRTestRole(t, db, "expired")
+	ws := createGRTestWorkspace(t, db, "ws")
+
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: active.ID, ExpiresAt: timeAt(time.Hour)}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: future.ID, ValidFrom: timeAt(time.Hour), ActivationPending: true}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: expired.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: expired.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+
+	grants, err := repo.FindPlatformRoleGrants(user.ID)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "active", grants[0].RoleName)
+
+	assigned, err := repo.FindAssignedPlatformRoleGrants(user.ID)
+	require.NoError(t, err)
+	assert.Len(t, assigned, 2)
+
+	wsGrants, err := repo.FindWorkspaceRoleGrants(user.ID, ws.ID)
+	require.NoError(t, err)
+	assert.Empty(t, wsGrants)
+}
+
+// ── 할당 ──────────────────────────────────────────────────────────────────────
+
+// TC-RAS-ASN-01: 시작 전 그룹 플랫폼 역할은 DB 에만 저장(pending)하고 Keycloak 은 호출하지 않음
+func TestAssignGroupPlatformRoleWithValidityDefersKeycloak(t *testing.T) {
+	svc, db := newTestGroupRoleService(t)
+	kc := &recordingKeycloak{}
+	svc.kcService = kc
+	org := createGRTestOrg(t, db, "contractors", "CONTRACTORS")
+	role := createGRTestRole(t, db, "operator")
+
+	err := svc.AssignGroupPlatformRoleWithValidity(context.Background(), org.ID, role.ID,
+		model.AssignmentValidity{ValidFrom: timeAt(time.Hour), ExpiresAt: timeAt(48 * time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, kc.calls)
+
+	var gpr model.GroupPlatformRole
+	require.NoError(t, db.First(&gpr, "group_id = ? AND role_id = ?", org.ID, role.ID).Error)
+	assert.True(t, gpr.ActivationPending)
+	require.NotNil(t, gpr.ExpiresAt)
+
+	err = svc.AssignGroupWorkspaceWithValidity(org.ID, 1, role.ID, model.AssignmentValidity{ExpiresAt: timeAt(-time.Hour)})
+	assert.True(t, errors.Is(err, ErrInvalidAssignmentValidity))
+}
+
+// ── 만료 처리 ─────────────────────────────────────────────────────────────────
+
+// TC-RAS-RUN-01: 만료된 할당은 Keycloak 에서 회수 후 DB 삭제, 유효 기간 내 할당은 유지
+func TestRunExpiryRevokesExpiredAssignments(t *testing.T) {
+	svc, db, kc := newTestRoleAssignmentService(t)
+	user := createGRTestUser(t, db, "alice", "kc-alice")
+	org := createGRTestOrg(t, db, "temp", "TEMP")
+	role := createGRTestRole(t, db, "operator")
+	keep := createGRTestRole(t, db, "viewer")
+	ws := createGRTestWorkspace(t, db, "ws")
+
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: keep.ID, ExpiresAt: timeAt(time.Hour)}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: org.ID, RoleID: role.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: ws.ID, RoleID: role.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+
+	result, err := svc.RunExpiry(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Len(t, result.Expired, 4)
+	assert.Empty(t, result.Failed)
+	assert.ElementsMatch(t, []string{"remove-user kc-alice operator", "remove-group temp operator"}, kc.calls)
+
+	assert.Equal(t, int64(1), countRows(t, db, &model.UserPlatformRole{}))
+	assert.Equal(t, int64(0), countRows(t, db, &model.UserWorkspaceRole{}))
+	assert.Equal(t, int64(0), countRows(t, db, &model.GroupPlatformRole{}))
+	assert.Equal(t, int64(0), countRows(t, db, &model.GroupWorkspaceRole{}))
+}
+
+// TC-RAS-RUN-02: 시작 시각이 된 플랫폼 역할은 Keycloak 에 부여하고 pending 해제
+func TestRunExpiryActivatesPendingAssignments(t *testing.T) {
+	svc, db, kc := newTestRoleAssignmentService(t)
+	user := createGRTestUser(t, db, "bob", "kc-bob")
+	org := createGRTestOrg(t, db, "project", "PROJECT")
+	role := createGRTestRole(t, db, "operator")
+	later := createGRTestRole(t, db, "later")
+
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID, ValidFrom: timeAt(-time.Minute), ActivationPending: true}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: later.ID, ValidFrom: timeAt(time.Hour), ActivationPending: true}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: org.ID, RoleID: role.ID, ValidFrom: timeAt(-time.Minute), ActivationPending: true}).Error)
+
+	result, err := svc.RunExpiry(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Len(t, result.Activated, 2)
+	assert.ElementsMatch(t, []string{"assign-user kc-bob operator", "assign-group project operator"}, kc.calls)
+
+	var pending int64
+	require.NoError(t, db.Model(&model.UserPlatformRole{}).Where("activation_pending = ?", true).Count(&pending).Error)
+	assert.Equal(t, int64(1), pending, "assignment starting later must stay pending")
+
+	grants, err := repository.NewAuthzRepository(db).FindPlatformRoleGrants(user.ID)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "operator", grants[0].RoleName)
+}
+
+// TC-RAS-RUN-03: Keycloak 회수 실패 시 DB 할당 유지(다음 실행에서 재시도), 사용자 404 는 무시
+func TestRunExpiryKeycloakFailures(t *testing.T) {
+	svc, db, kc := newTestRoleAssignmentService(t)
+	user := createGRTestUser(t, db, "carol", "kc-carol")
+	role := createGRTestRole(t, db, "operator")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: role.ID, ExpiresAt: timeAt(-time.Minute)}).Error)
+
+	kc.removeErr = errors.New("connection refused")
+	result, err := svc.RunExpiry(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Expired)
+	assert.Len(t, result.Failed, 1)
+	assert.Equal(t, int64(1), countRows(t, db, &model.UserPlatformRole{}))
+
+	kc.removeErr = errors.New("404 Not Found: User not found")
+	result, err = svc.RunExpiry(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Len(t, result.Expired, 1)
+	assert.Equal(t, int64(0), countRows(t, db, &model.UserPlatformRole{}))
+}
+
+// TC-RAS-RUN-04: 시작 전 상태로 만료된 할당은 Keycloak 호출 없이 DB 만 삭제
+func TestRunExpiryPendingExpiredSkipsKeycloak(t *testing.T) {
+	svc, db, kc := newTestRoleAssignmentService(t)
+	user := createGRTestUser(t, db, "dave", "kc-dave")
+	role := createGRTestRole(t, db, "operator")
+	require.NoError(t, db.Create(&model.UserPlatformRole{
+		UserID: user.ID, RoleID: role.ID, ValidFrom: timeAt(-2 * time.Hour), ExpiresAt: timeAt(-time.Hour), ActivationPending: true,
+	}).Error)
+
+	result, err := svc.RunExpiry(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Activated)
+	assert.Len(t, result.Expired, 1)
+	assert.Empty(t, kc.calls)
+	assert.Equal(t, int64(0), countRows(t, db, &model.UserPlatformRole{}))
+}
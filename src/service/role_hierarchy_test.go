@@ -0,0 +1,132 @@
+package service
+
+// role_hierarchy_test.go
+//
+// 역할 계층(RoleMaster.ParentID) 상속 테스트 (SQLite in-memory DB)
+// 권한/메뉴/CSP 매핑 상속, 순환 참조 검증, 해석된 권한 조회를 검증한다.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupRoleHierarchyTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.RoleMenuMapping{},
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+	))
+	return db
+}
+
+// createRoleLadder viewer < operator < admin 형태의 역할 계층 생성 (자식부터 반환)
+func createRoleLadder(t *testing.T, db *gorm.DB, prefix string) (child, middle, top *model.RoleMaster) {
+	t.Helper()
+	top = createGRTestRole(t, db, prefix+"-admin")
+	middle = createGRTestRole(t, db, prefix+"-operator")
+	child = createGRTestRole(t, db, prefix+"-viewer")
+	require.NoError(t, db.Model(middle).Update("parent_id", top.ID).Error)
+	require.NoError(t, db.Model(child).Update("parent_id", middle.ID).Error)
+	return child, middle, top
+}
+
+// TC-RH-01: 하위 역할 사용자는 상위 역할의 권한을 상속받고, 부여 경로에 상속 역할이 기록됨
+func TestRoleHierarchy_InheritsPermissions(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := &AuthzService{db: db, authzRepo: repository.NewAuthzRepository(db), userRepo: repository.NewUserRepository(db)}
+	user := createGRTestUser(t, db, "rh-user-01", "kc-rh-01")
+	child, _, top := createRoleLadder(t, db, "rh01")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: child.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, child.ID, "mc-iam-manager:user:read")
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:user:write")
+
+	perms, err := svc.GetEffectivePermissions(context.Background(), user.ID, 0)
+	require.NoError(t, err)
+
+	require.Len(t, perms["mc-iam-manager:user:read"], 1)
+	assert.Zero(t, perms["mc-iam-manager:user:read"][0].InheritedFromRoleID)
+	require.Len(t, perms["mc-iam-manager:user:write"], 1)
+	assert.Equal(t, child.ID, perms["mc-iam-manager:user:write"][0].RoleID)
+	assert.Equal(t, top.ID, perms["mc-iam-manager:user:write"][0].InheritedFromRoleID)
+	assert.Equal(t, top.Name, perms["mc-iam-manager:user:write"][0].InheritedFromRoleName)
+}
+
+// TC-RH-02: 자기 자신/하위 역할을 상위로 지정 → ErrCircularReference, 없는 상위 역할 → ErrRoleMasterNotFound
+func TestRoleHierarchy_CycleDetection(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewRoleService(db)
+	child, middle, top := createRoleLadder(t, db, "rh02")
+
+	_, err := svc.UpdateRoleWithSubs(model.RoleMaster{ID: top.ID, Name: top.Name, ParentID: &child.ID}, nil)
+	assert.ErrorIs(t, err, repository.ErrCircularReference)
+
+	_, err = svc.UpdateRoleWithSubs(model.RoleMaster{ID: middle.ID, Name: middle.Name, ParentID: &middle.ID}, nil)
+	assert.ErrorIs(t, err, repository.ErrCircularReference)
+
+	missing := uint(9999)
+	_, err = svc.UpdateRoleWithSubs(model.RoleMaster{ID: child.ID, Name: child.Name, ParentID: &missing}, nil)
+	assert.ErrorIs(t, err, repository.ErrRoleMasterNotFound)
+
+	// 정상적인 상위 역할 변경은 허용
+	_, err = svc.UpdateRoleWithSubs(model.RoleMaster{ID: child.ID, Name: child.Name, ParentID: &top.ID}, nil)
+	assert.NoError(t, err)
+}
+
+// TC-RH-03: 해석된 권한 조회 — 가까운 역할 우선, 상속 여부와 출처 표시, CSP 매핑은 가장 가까운 역할 것만 유효
+func TestRoleHierarchy_GetResolvedRolePermissions(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewRoleService(db)
+	child, middle, top := createRoleLadder(t, db, "rh03")
+
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, child.ID, "mc-iam-manager:menu:read")
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:menu:read")
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, top.ID, "mc-iam-manager:menu:write")
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: middle.ID, MenuID: "workspaces"}).Error)
+
+	middleCsp := &model.CspRole{Name: "mciam-rh03-operator", CspType: "aws"}
+	topCsp := &model.CspRole{Name: "mciam-rh03-admin", CspType: "aws"}
+	require.NoError(t, db.Create(middleCsp).Error)
+	require.NoError(t, db.Create(topCsp).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: middle.ID, CspRoleID: middleCsp.ID, AuthMethod: constants.AuthMethodOIDC}).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{RoleID: top.ID, CspRoleID: topCsp.ID, AuthMethod: constants.AuthMethodOIDC}).Error)
+
+	resolved, err := svc.GetResolvedRolePermissions(child.ID)
+	require.NoError(t, err)
+
+	require.Len(t, resolved.Lineage, 3)
+	assert.Equal(t, top.ID, resolved.Lineage[2].RoleID)
+
+	require.Len(t, resolved.Permissions, 2)
+	byID := map[string]model.ResolvedRolePermission{}
+	for _, p := range resolved.Permissions {
+		byID[p.PermissionID] = p
+	}
+	assert.Equal(t, child.ID, byID["mc-iam-manager:menu:read"].SourceRoleID)
+	assert.False(t, byID["mc-iam-manager:menu:read"].Inherited)
+	assert.Equal(t, top.ID, byID["mc-iam-manager:menu:write"].SourceRoleID)
+	assert.True(t, byID["mc-iam-manager:menu:write"].Inherited)
+
+	require.Len(t, resolved.Menus, 1)
+	assert.Equal(t, middle.Name, resolved.Menus[0].SourceRoleName)
+
+	require.Len(t, resolved.CspRoleMappings, 1)
+	assert.Equal(t, middleCsp.ID, resolved.CspRoleMappings[0].CspRoleID)
+
+	// 자격 증명 발급용 매핑 조회도 가장 가까운 상위 역할의 매핑을 사용
+	mapping, err := repository.NewCspMappingRepository(db).FindCspRoleMappingsByRoleIDAndCspType(child.ID, "aws", "")
+	require.NoError(t, err)
+	require.NotNil(t, mapping)
+	assert.Equal(t, middle.ID, mapping.RoleID)
+
+	_, err = svc.GetResolvedRolePermissions(9999)
+	assert.ErrorIs(t, err, repository.ErrRoleMasterNotFound)
+}
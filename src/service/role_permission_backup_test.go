@@ -1,6 +1,8 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -122,6 +124,47 @@ func TestRestoreRolePermissions_ReplaceRole(t *testing.T) {
 	require.ElementsMatch(t, []string{"operations", "observability"}, ids)
 }
 
+// createMciamPermissionTables sqlite 에서는 default:now() 마이그레이션이 실패하므로 직접 생성
+func createMciamPermissionTables(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Exec(`CREATE TABLE IF NOT EXISTS mcmp_resource_types (
+		framework_id varchar(100) NOT NULL, id varchar(100) NOT NULL, name varchar(255) NOT NULL,
+		description varchar(1000), created_at datetime, updated_at datetime, PRIMARY KEY (framework_id, id))`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE IF NOT EXISTS mcmp_mciam_permissions (
+		id varchar(255) PRIMARY KEY, framework_id varchar(100) NOT NULL, resource_type_id varchar(100) NOT NULL,
+		action varchar(100) NOT NULL, name varchar(100) NOT NULL, description varchar(1000),
+		created_at datetime, updated_at datetime)`).Error)
+	require.NoError(t, db.AutoMigrate(&model.MciamRoleMciamPermission{}))
+}
+
+func TestBackupAndRestoreRolePermissions_Operations(t *testing.T) {
+	db := setupRolePermissionBackupTestDB(t)
+	createMciamPermissionTables(t, db)
+	svc := NewMenuService(db)
+
+	admin := seedPlatformRole(t, db, "admin")
+
+	added, err := svc.addMissingRoleOperationPermissions(admin.ID, []string{
+		"mc-iam-manager:user:read", "mc-iam-manager:user:write",
+	}, model.PermissionEffectAllow)
+	require.NoError(t, err)
+	require.Equal(t, 2, added)
+
+	backup, err := svc.BackupRolePermissions([]string{"admin"}, []string{"menus", "operations"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"mc-iam-manager:user:read", "mc-iam-manager:user:write"}, backup.Permissions[0].Operations)
+
+	backup.Permissions[0].Operations = []string{"mc-iam-manager:user:read", "mc-iam-manager:role:read"}
+	result, err := svc.RestoreRolePermissions(backup, "replace-role", []string{"operations"})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.OperationsRemoved)
+	require.Equal(t, 1, result.OperationsAdded)
+
+	ops, err := svc.permissionRepo.GetRoleMciamPermissions(constants.RoleTypePlatform, admin.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"mc-iam-manager:user:read", "mc-iam-manager:role:read"}, ops)
+}
+
 func TestParseRolePermissionBackupYAML(t *testing.T) {
 	raw := []byte(`
 kind: role-permission-backup
@@ -139,3 +182,67 @@ permissions:
 	require.Equal(t, "viewer", backup.Permissions[0].Role)
 	require.Equal(t, []string{"operations"}, backup.Permissions[0].Menus)
 }
+
+// 기동 시 동기화는 반영하지 않은 operations/denies 만 추가하고 기록, 메뉴와 없는 역할은 건드리지 않음
+// 관리자가 제거한 항목은 재기동 시 다시 추가하지 않고, 나중에 생성된 역할과 시드에 새로 추가된 항목은 반영
+func TestSyncRoleOperationPermissionsFromYAML_AppliesEachEntryOnce(t *testing.T) {
+	db := setupRolePermissionBackupTestDB(t)
+	createMciamPermissionTables(t, db)
+	require.NoError(t, db.AutoMigrate(&model.RoleOperationSeed{}))
+	svc := NewMenuService(db)
+
+	admin := seedPlatformRole(t, db, "admin")
+	seedMenu(t, db, "operations", "Operations")
+	_, err := svc.addMissingRoleOperationPermissions(admin.ID, []string{"mc-iam-manager:user:read"}, model.PermissionEffectAllow)
+	require.NoError(t, err)
+
+	seed := filepath.Join(t.TempDir(), "permission.yaml")
+	writeSeed := func(adminOperations string) {
+		require.NoError(t, os.WriteFile(seed, []byte(`
+permissions:
+  - role: admin
+    menus: [operations]
+    operations: [`+adminOperations+`]
+    denies: [mc-iam-manager:role:write]
+  - role: operator
+    operations: [mc-iam-manager:user:read]
+`), 0o644))
+	}
+	writeSeed("mc-iam-manager:user:read, mc-iam-manager:user:write")
+
+	added, err := svc.SyncRoleOperationPermissionsFromYAML(seed)
+	require.NoError(t, err)
+	require.Equal(t, 2, added)
+
+	allowed, err := svc.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, admin.ID, model.PermissionEffectAllow)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"mc-iam-manager:user:read", "mc-iam-manager:user:write"}, allowed)
+	denied, err := svc.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, admin.ID, model.PermissionEffectDeny)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mc-iam-manager:role:write"}, denied)
+	menus, err := svc.menuMappingRepo.GetMappedMenuIDs(admin.ID)
+	require.NoError(t, err)
+	require.Empty(t, menus)
+
+	// 관리자가 역할에서 제거한 권한은 다시 추가하지 않음
+	require.NoError(t, svc.permissionRepo.RemoveMciamPermissionFromRole(constants.RoleTypePlatform, admin.ID, "mc-iam-manager:user:write"))
+	added, err = svc.SyncRoleOperationPermissionsFromYAML(seed)
+	require.NoError(t, err)
+	require.Zero(t, added)
+	allowed, err = svc.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, admin.ID, model.PermissionEffectAllow)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mc-iam-manager:user:read"}, allowed)
+
+	// 새로 생성된 역할과 시드에 새로 추가된 권한만 반영
+	operator := seedPlatformRole(t, db, "operator")
+	writeSeed("mc-iam-manager:user:read, mc-iam-manager:user:write, mc-iam-manager:role:read")
+	added, err = svc.SyncRoleOperationPermissionsFromYAML(seed)
+	require.NoError(t, err)
+	require.Equal(t, 2, added)
+	allowed, err = svc.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, admin.ID, model.PermissionEffectAllow)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"mc-iam-manager:user:read", "mc-iam-manager:role:read"}, allowed)
+	allowed, err = svc.permissionRepo.GetRoleMciamPermissionsByEffect(constants.RoleTypePlatform, operator.ID, model.PermissionEffectAllow)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mc-iam-manager:user:read"}, allowed)
+}
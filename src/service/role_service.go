@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -14,6 +15,8 @@ import (
 type RoleService struct {
 	db             *gorm.DB
 	roleRepository *repository.RoleRepository
+	assignmentRepo *repository.RoleAssignmentRepository
+	sodService     *SodService // nil 이면 직무 분리 검사를 하지 않음
 }
 
 // NewRoleService 새 RoleService 인스턴스 생성
@@ -21,6 +24,8 @@ func NewRoleService(db *gorm.DB) *RoleService {
 	return &RoleService{
 		db:             db,
 		roleRepository: repository.NewRoleRepository(db),
+		assignmentRepo: repository.NewRoleAssignmentRepository(db),
+		sodService:     NewSodService(db),
 	}
 }
 
@@ -187,6 +192,16 @@ func (s *RoleService) DeleteRoleWithSubsAndMappings(roleID uint) error {
 
 // AssignPlatformRole 플랫폼 역할 할당
 func (s *RoleService) AssignPlatformRole(userID, roleID uint) error {
+	return s.AssignPlatformRoleWithValidity(userID, roleID, model.AssignmentValidity{})
+}
+
+// AssignPlatformRoleWithValidity 유효 기간을 지정하여 플랫폼 역할 할당 (DB 만, Keycloak 부여는 호출자 몫)
+// 시작 전이면 activation_pending 으로 저장되고, 만료 처리기가 시작 시각에 Keycloak 에 부여한다.
+func (s *RoleService) AssignPlatformRoleWithValidity(userID, roleID uint, validity model.AssignmentValidity) error {
+	if err := ValidateAssignmentValidity(validity, time.Now()); err != nil {
+		return err
+	}
+
 	// 1. 역할이 존재하는지 확인
 	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypePlatform)
 	if err != nil {
@@ -208,12 +223,26 @@ func (s *RoleService) AssignPlatformRole(userID, roleID uint) error {
 		return fmt.Errorf("플랫폼 역할이 아닙니다")
 	}
 
-	// 3. 역할 할당
-	return s.roleRepository.AssignPlatformRole(userID, roleID)
+	// 3. 직무 분리 제약 확인
+	if err := s.sodService.CheckPlatformRoleAssignment(userID, roleID); err != nil {
+		return err
+	}
+
+	// 4. 역할 할당
+	return s.roleRepository.AssignPlatformRoleWithValidity(userID, roleID, validity)
 }
 
 // AssignWorkspaceRole 워크스페이스 역할 할당
 func (s *RoleService) AssignWorkspaceRole(userID, workspaceID, roleID uint) error {
+	return s.AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID, model.AssignmentValidity{})
+}
+
+// AssignWorkspaceRoleWithValidity 유효 기간을 지정하여 워크스페이스 역할 할당
+func (s *RoleService) AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
+	if err := ValidateAssignmentValidity(validity, time.Now()); err != nil {
+		return err
+	}
+
 	// 1. 역할이 존재하는지 확인
 	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
 	if err != nil {
@@ -235,8 +264,30 @@ func (s *RoleService) AssignWorkspaceRole(userID, workspaceID, roleID uint) erro
 		return fmt.Errorf("워크스페이스 역할이 아닙니다")
 	}
 
-	// 3. 역할 할당
-	return s.roleRepository.AssignWorkspaceRole(userID, workspaceID, roleID)
+	// 3. 직무 분리 제약 확인
+	if err := s.sodService.CheckWorkspaceRoleAssignment(userID, workspaceID, roleID); err != nil {
+		return err
+	}
+
+	// 4. 역할 할당
+	return s.roleRepository.AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID, validity)
+}
+
+// IsPlatformRoleActivationPending 플랫폼 역할 할당이 시작 전(Keycloak 미부여) 상태인지
+func (s *RoleService) IsPlatformRoleActivationPending(userID, roleID uint) (bool, error) {
+	return s.assignmentRepo.IsActivationPending(&model.TimedRoleAssignment{
+		Type: model.RoleAssignmentUserPlatform, UserID: userID, RoleID: roleID,
+	})
+}
+
+// UpdatePlatformRoleExpiry 이미 할당된 플랫폼 역할의 만료 시각 변경 (nil 이면 무기한)
+func (s *RoleService) UpdatePlatformRoleExpiry(userID, roleID uint, expiresAt *time.Time) error {
+	if err := ValidateAssignmentValidity(model.AssignmentValidity{ExpiresAt: expiresAt}, time.Now()); err != nil {
+		return err
+	}
+	return s.assignmentRepo.SetExpiry(&model.TimedRoleAssignment{
+		Type: model.RoleAssignmentUserPlatform, UserID: userID, RoleID: roleID,
+	}, expiresAt)
 }
 
 // AssignRole 역할 할당 (플랫폼/워크스페이스)
@@ -267,8 +318,14 @@ func (s *RoleService) AssignRole(userID, workspaceID, roleID uint) error {
 		if workspaceID == 0 {
 			return fmt.Errorf("워크스페이스 역할 할당을 위해 워크스페이스 ID가 필요합니다")
 		}
+		if err := s.sodService.CheckWorkspaceRoleAssignment(userID, workspaceID, roleID); err != nil {
+			return err
+		}
 		return s.roleRepository.AssignWorkspaceRole(userID, workspaceID, roleID)
 	} else if isPlatformRole {
+		if err := s.sodService.CheckPlatformRoleAssignment(userID, roleID); err != nil {
+			return err
+		}
 		return s.roleRepository.AssignPlatformRole(userID, roleID)
 	} else {
 		return fmt.Errorf("지원하지 않는 역할 타입입니다")
@@ -307,6 +364,9 @@ func (s *RoleService) GetEffectiveWorkspaceRoles(userID uint) ([]model.Effective
 
 // 있으면 update, 없으면 insert
 func (s *RoleService) CreateRoleCspRoleMapping(req *model.CreateRoleMasterCspRoleMappingRequest) error {
+	if err := ValidateGrantConditions(req.Conditions); err != nil {
+		return err
+	}
 
 	// 매핑 생성
 	err := s.roleRepository.CreateRoleCspRoleMapping(req)
@@ -448,6 +508,9 @@ func (s *RoleService) AddRoleSub(roleID uint, roleSub *model.RoleSub) error {
 }
 
 func (s *RoleService) AddCspRolesMapping(req *model.CreateRoleMasterCspRoleMappingRequest) error {
+	if err := ValidateGrantConditions(req.Conditions); err != nil {
+		return err
+	}
 	return s.roleRepository.CreateRoleCspRoleMapping(req)
 }
 
@@ -469,3 +532,106 @@ func (s *RoleService) GetRoleMasterMappings(req *model.FilterRoleMasterMappingRe
 
 	return mappings[0], nil
 }
+
+// GetResolvedRolePermissions 역할 계층(ParentID)을 따라 상속된 권한/메뉴/CSP 매핑을 출처와 함께 조회
+// 같은 항목이 여러 단계에 있으면 가장 가까운 역할을 출처로 한다. 단, 권한은 어느 단계든 deny 가 있으면 deny 로 해석한다.
+func (s *RoleService) GetResolvedRolePermissions(roleID uint) (*model.ResolvedRolePermissions, error) {
+	lineage, err := s.roleRepository.FindRoleLineage(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ResolvedRolePermissions{
+		RoleID:          lineage[0].ID,
+		RoleName:        lineage[0].Name,
+		Lineage:         make([]model.RoleLineageEntry, 0, len(lineage)),
+		Permissions:     []model.ResolvedRolePermission{},
+		Menus:           []model.ResolvedRoleMenu{},
+		CspRoleMappings: []model.ResolvedRoleCspMapping{},
+	}
+	roleIDs := make([]uint, 0, len(lineage))
+	depth := make(map[uint]int, len(lineage))
+	for i, role := range lineage {
+		result.Lineage = append(result.Lineage, model.RoleLineageEntry{RoleID: role.ID, RoleName: role.Name, Depth: i})
+		roleIDs = append(roleIDs, role.ID)
+		depth[role.ID] = i
+	}
+	source := func(sourceRoleID uint) model.ResolvedRoleSource {
+		return model.ResolvedRoleSource{
+			SourceRoleID:   sourceRoleID,
+			SourceRoleName: lineage[depth[sourceRoleID]].Name,
+			Inherited:      depth[sourceRoleID] > 0,
+		}
+	}
+
+	// 1. MC-IAM 권한 (roleType + permissionID 단위)
+	permMappings, err := s.roleRepository.FindRoleMciamPermissionMappings(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	permIndex := make(map[string]int)
+	for _, m := range permMappings {
+		key := string(m.RoleType) + "|" + m.PermissionID
+		effect := model.PermissionEffectAllow
+		if m.IsDeny() {
+			effect = model.PermissionEffectDeny
+		}
+		if i, ok := permIndex[key]; ok {
+			p := &result.Permissions[i]
+			denyOverrides := effect == model.PermissionEffectDeny && p.Effect != model.PermissionEffectDeny
+			if denyOverrides || (effect == p.Effect && depth[m.RoleID] < depth[p.SourceRoleID]) {
+				p.Effect = effect
+				p.Conditions = m.Conditions
+				p.ResolvedRoleSource = source(m.RoleID)
+			}
+			continue
+		}
+		permIndex[key] = len(result.Permissions)
+		result.Permissions = append(result.Permissions, model.ResolvedRolePermission{
+			PermissionID:       m.PermissionID,
+			RoleType:           m.RoleType,
+			Effect:             effect,
+			Conditions:         m.Conditions,
+			ResolvedRoleSource: source(m.RoleID),
+		})
+	}
+
+	// 2. 메뉴 (menuID 단위)
+	menuMappings, err := s.roleRepository.FindRoleMenuMappings(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	menuIndex := make(map[string]int)
+	for _, m := range menuMappings {
+		if i, ok := menuIndex[m.MenuID]; ok {
+			if depth[m.RoleID] < depth[result.Menus[i].SourceRoleID] {
+				result.Menus[i].ResolvedRoleSource = source(m.RoleID)
+			}
+			continue
+		}
+		menuIndex[m.MenuID] = len(result.Menus)
+		result.Menus = append(result.Menus, model.ResolvedRoleMenu{MenuID: m.MenuID, ResolvedRoleSource: source(m.RoleID)})
+	}
+
+	// 3. CSP 역할 매핑 (cspType + authMethod 단위로 가장 가까운 역할의 매핑만 유효)
+	cspMappings, err := s.roleRepository.FindRoleCspMappingsWithCspRole(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	nearest := make(map[string]int)
+	for _, m := range cspMappings {
+		key := m.CspType + "|" + string(m.AuthMethod)
+		if d, ok := nearest[key]; !ok || depth[m.SourceRoleID] < d {
+			nearest[key] = depth[m.SourceRoleID]
+		}
+	}
+	for _, m := range cspMappings {
+		if depth[m.SourceRoleID] != nearest[m.CspType+"|"+string(m.AuthMethod)] {
+			continue
+		}
+		m.ResolvedRoleSource = source(m.SourceRoleID)
+		result.CspRoleMappings = append(result.CspRoleMappings, m)
+	}
+
+	return result, nil
+}
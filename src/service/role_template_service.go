@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// roleCspMappingPermission CSP 역할 매핑을 복사·생성할 때 필요한 권한 (/roles/csp-roles 와 동일)
+const roleCspMappingPermission = "mc-iam-manager:role:manage"
+
+var (
+	ErrInvalidRoleClone     = errors.New("invalid role clone request")
+	ErrRoleNameTaken        = errors.New("role name already exists")
+	ErrRoleTemplateNotFound = errors.New("role template not found")
+	ErrInvalidRoleTemplate  = errors.New("invalid role template")
+	// ErrCspMappingApprovalRequired CSP 역할 매핑 승인 워크플로가 켜져 있어 복제·템플릿으로 매핑을 만들 수 없음
+	ErrCspMappingApprovalRequired = errors.New("csp role mappings require approval")
+)
+
+// RoleTemplateService 역할 복제와 YAML 역할 템플릿 인스턴스화
+// 복제·인스턴스화는 하나의 트랜잭션에서 RoleMaster 와 RoleSub, 메뉴·MC-IAM 권한·CSP 역할 매핑을 함께 만든다.
+// CSP 역할 매핑이 포함되면 요청자에게 roleCspMappingPermission 이 있어야 하고, CSP 역할 매핑 승인 워크플로가 꺼져 있어야 한다.
+type RoleTemplateService struct {
+	db              *gorm.DB
+	roleRepo        *repository.RoleRepository
+	authzService    *AuthzService
+	workflowService *WorkflowService // nil 이면 CSP 역할 매핑 승인 워크플로를 확인하지 않음
+	templateDir     string
+}
+
+// NewRoleTemplateService RoleTemplateService 생성 (템플릿 디렉터리: asset/role-templates)
+func NewRoleTemplateService(db *gorm.DB) *RoleTemplateService {
+	return &RoleTemplateService{
+		db:              db,
+		roleRepo:        repository.NewRoleRepository(db),
+		authzService:    NewAuthzService(db),
+		workflowService: NewWorkflowService(db),
+		templateDir:     filepath.Join(util.GetAssetPath(), "role-templates"),
+	}
+}
+
+// normalizeRoleCloneSections 복제 항목 검증 (비어 있으면 전체)
+func normalizeRoleCloneSections(sections []string) (map[string]bool, error) {
+	all := []string{
+		model.RoleCloneSectionRoleTypes,
+		model.RoleCloneSectionMenus,
+		model.RoleCloneSectionOperations,
+		model.RoleCloneSectionCsps,
+	}
+	selected := map[string]bool{}
+	if len(sections) == 0 {
+		for _, s := range all {
+			selected[s] = true
+		}
+		return selected, nil
+	}
+	for _, s := range sections {
+		s = strings.TrimSpace(s)
+		known := false
+		for _, a := range all {
+			if strings.EqualFold(s, a) {
+				selected[a] = true
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown section %q (roleTypes, menus, operations, csps)", ErrInvalidRoleClone, s)
+		}
+	}
+	return selected, nil
+}
+
+// ResolveUser Keycloak ID 로 요청자 DB 사용자 조회
+func (s *RoleTemplateService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// authorizeCspMappings CSP 역할 매핑을 만들 권한 확인 (role:write 만으로는 CSP 자격 증명 매핑을 얻을 수 없도록)
+// CSP 역할 매핑 승인 워크플로가 켜져 있으면 승인 없이 매핑이 생기지 않도록 거부한다 (/roles/csp-roles 로 승인 요청).
+func (s *RoleTemplateService) authorizeCspMappings(ctx context.Context, actorID uint) error {
+	if s.workflowService != nil {
+		required, err := s.workflowService.Required(model.WorkflowTypeCspRoleMapping)
+		if err != nil {
+			return err
+		}
+		if required {
+			return fmt.Errorf("%w: exclude csps and request the mappings through /api/roles/csp-roles", ErrCspMappingApprovalRequired)
+		}
+	}
+	allowed, err := s.authzService.HasPermission(ctx, actorID, 0, roleCspMappingPermission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s is required to map CSP roles", ErrPermissionDenied, roleCspMappingPermission)
+	}
+	return nil
+}
+
+// requireUnusedRoleName 새 역할 이름 중복 확인
+func (s *RoleTemplateService) requireUnusedRoleName(name string) error {
+	existing, err := s.roleRepo.FindRoleByRoleName(name, "")
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: %s", ErrRoleNameTaken, name)
+	}
+	return nil
+}
+
+// CloneRole 역할을 선택한 항목(RoleSub 타입, 메뉴, MC-IAM 권한, CSP 역할 매핑)과 함께 새 이름으로 복제
+// 상위 역할은 원본을 따르고, 복제본은 predefined·delegable 이 아니다. csps 항목을 선택하면 roleCspMappingPermission 이 필요하고
+// CSP 역할 매핑 승인 워크플로가 켜져 있으면 거부된다.
+func (s *RoleTemplateService) CloneRole(ctx context.Context, actorID, sourceRoleID uint, req *model.CloneRoleRequest) (*model.RoleMaster, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidRoleClone)
+	}
+	sections, err := normalizeRoleCloneSections(req.Sections)
+	if err != nil {
+		return nil, err
+	}
+	if sections[model.RoleCloneSectionCsps] {
+		if err := s.authorizeCspMappings(ctx, actorID); err != nil {
+			return nil, err
+		}
+	}
+	source, err := s.roleRepo.FindRoleByRoleID(sourceRoleID, "")
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, repository.ErrRoleMasterNotFound
+	}
+	if err := s.requireUnusedRoleName(name); err != nil {
+		return nil, err
+	}
+
+	description := source.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+	role := &model.RoleMaster{
+		Name:        name,
+		Description: description,
+		ParentID:    source.ParentID,
+	}
+	roleSubs := make([]model.RoleSub, 0, len(source.RoleSubs))
+	if sections[model.RoleCloneSectionRoleTypes] {
+		for _, sub := range source.RoleSubs {
+			roleSubs = append(roleSubs, model.RoleSub{RoleType: sub.RoleType})
+		}
+	}
+
+	var created *model.RoleMaster
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		// 역할 이름 조회도 같은 트랜잭션에서 수행
+		created, err = repository.NewRoleRepository(tx).CreateRoleWithSubsWithTx(tx, role, roleSubs)
+		if err != nil {
+			return err
+		}
+
+		if sections[model.RoleCloneSectionMenus] {
+			var menus []model.RoleMenuMapping
+			if err := tx.Where("role_id = ?", source.ID).Find(&menus).Error; err != nil {
+				return fmt.Errorf("메뉴 매핑 조회 실패: %w", err)
+			}
+			for _, m := range menus {
+				if err := tx.Create(&model.RoleMenuMapping{RoleID: created.ID, MenuID: m.MenuID}).Error; err != nil {
+					return fmt.Errorf("메뉴 매핑 생성 실패: %w", err)
+				}
+			}
+		}
+
+		if sections[model.RoleCloneSectionOperations] {
+			var perms []model.MciamRoleMciamPermission
+			if err := tx.Where("role_id = ?", source.ID).Find(&perms).Error; err != nil {
+				return fmt.Errorf("MC-IAM 권한 매핑 조회 실패: %w", err)
+			}
+			for _, p := range perms {
+				if err := tx.Create(&model.MciamRoleMciamPermission{
+					RoleType:     p.RoleType,
+					RoleID:       created.ID,
+					PermissionID: p.PermissionID,
+					Effect:       p.Effect,
+					Conditions:   p.Conditions,
+				}).Error; err != nil {
+					return fmt.Errorf("MC-IAM 권한 매핑 생성 실패: %w", err)
+				}
+			}
+		}
+
+		if sections[model.RoleCloneSectionCsps] {
+			var mappings []model.RoleMasterCspRoleMapping
+			if err := tx.Where("role_id = ?", source.ID).Find(&mappings).Error; err != nil {
+				return fmt.Errorf("CSP 역할 매핑 조회 실패: %w", err)
+			}
+			for _, m := range mappings {
+				if err := tx.Create(&model.RoleMasterCspRoleMapping{
+					RoleID:      created.ID,
+					AuthMethod:  m.AuthMethod,
+					CspRoleID:   m.CspRoleID,
+					Description: m.Description,
+					Conditions:  m.Conditions,
+				}).Error; err != nil {
+					return fmt.Errorf("CSP 역할 매핑 생성 실패: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListTemplates 템플릿 디렉터리의 YAML 파일(*.yaml, *.yml)에서 역할 템플릿 목록 조회 (이름 순)
+// 디렉터리가 없으면 빈 목록, 이름이 중복되면 오류를 반환한다.
+func (s *RoleTemplateService) ListTemplates() ([]model.RoleTemplate, error) {
+	entries, err := os.ReadDir(s.templateDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []model.RoleTemplate{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role template directory: %w", err)
+	}
+
+	templates := make([]model.RoleTemplate, 0)
+	sources := map[string]string{}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.templateDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read role template file %s: %w", entry.Name(), err)
+		}
+		var file model.RoleTemplateFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRoleTemplate, entry.Name(), err)
+		}
+		for _, t := range file.Templates {
+			t.Name = strings.TrimSpace(t.Name)
+			if t.Name == "" {
+				return nil, fmt.Errorf("%w: %s: template without name", ErrInvalidRoleTemplate, entry.Name())
+			}
+			if prev, ok := sources[t.Name]; ok {
+				return nil, fmt.Errorf("%w: template %q is defined in both %s and %s", ErrInvalidRoleTemplate, t.Name, prev, entry.Name())
+			}
+			sources[t.Name] = entry.Name()
+			t.Source = entry.Name()
+			templates = append(templates, t)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// GetTemplate 이름으로 역할 템플릿 조회
+func (s *RoleTemplateService) GetTemplate(name string) (*model.RoleTemplate, error) {
+	templates, err := s.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrRoleTemplateNotFound, name)
+}
+
+// validateRoleTemplate 역할 타입과 권한 ID 형식 확인
+func validateRoleTemplate(t *model.RoleTemplate) error {
+	if len(t.RoleTypes) == 0 {
+		return fmt.Errorf("%w: %s: roleTypes is required", ErrInvalidRoleTemplate, t.Name)
+	}
+	for _, rt := range t.RoleTypes {
+		switch rt {
+		case constants.RoleTypePlatform, constants.RoleTypeWorkspace, constants.RoleTypeCSP:
+		default:
+			return fmt.Errorf("%w: %s: unknown role type %q", ErrInvalidRoleTemplate, t.Name, rt)
+		}
+	}
+	for _, ids := range [][]string{t.Operations, t.Denies, t.WorkspaceOperations, t.WorkspaceDenies} {
+		for _, id := range ids {
+			if !isValidPermissionID(id) {
+				return fmt.Errorf("%w: %s: %q: %v", ErrInvalidRoleTemplate, t.Name, id, ErrInvalidPermissionID)
+			}
+		}
+	}
+	for _, c := range t.Csps {
+		if c.CspType == "" || c.Name == "" {
+			return fmt.Errorf("%w: %s: csps entries need cspType and name", ErrInvalidRoleTemplate, t.Name)
+		}
+	}
+	return nil
+}
+
+// InstantiateTemplate 템플릿으로 새 역할 생성 (메뉴와 CSP 역할은 이미 존재해야 함)
+// 템플릿에 csps 가 있으면 roleCspMappingPermission 이 필요하고, CSP 역할 매핑 승인 워크플로가 켜져 있으면 거부된다.
+func (s *RoleTemplateService) InstantiateTemplate(ctx context.Context, actorID uint, templateName string, req *model.InstantiateRoleTemplateRequest) (*model.RoleMaster, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidRoleTemplate)
+	}
+	t, err := s.GetTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRoleTemplate(t); err != nil {
+		return nil, err
+	}
+	if len(t.Csps) > 0 {
+		if err := s.authorizeCspMappings(ctx, actorID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.requireUnusedRoleName(name); err != nil {
+		return nil, err
+	}
+
+	description := t.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+	role := &model.RoleMaster{
+		Name:        name,
+		Description: description,
+		ParentID:    req.ParentID,
+		Delegable:   t.Delegable,
+	}
+	roleSubs := make([]model.RoleSub, 0, len(t.RoleTypes))
+	for _, rt := range t.RoleTypes {
+		roleSubs = append(roleSubs, model.RoleSub{RoleType: rt})
+	}
+
+	var created *model.RoleMaster
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		// 역할 이름 조회도 같은 트랜잭션에서 수행
+		created, err = repository.NewRoleRepository(tx).CreateRoleWithSubsWithTx(tx, role, roleSubs)
+		if err != nil {
+			return err
+		}
+
+		for _, menuID := range uniqueNonEmpty(t.Menus) {
+			var count int64
+			if err := tx.Model(&model.Menu{}).Where("id = ?", menuID).Count(&count).Error; err != nil {
+				return fmt.Errorf("메뉴 조회 실패: %w", err)
+			}
+			if count == 0 {
+				return fmt.Errorf("%w: %s: menu %q not found", ErrInvalidRoleTemplate, t.Name, menuID)
+			}
+			if err := tx.Create(&model.RoleMenuMapping{RoleID: created.ID, MenuID: menuID}).Error; err != nil {
+				return fmt.Errorf("메뉴 매핑 생성 실패: %w", err)
+			}
+		}
+
+		grants := []struct {
+			roleType constants.IAMRoleType
+			effect   model.PermissionEffect
+			ids      []string
+		}{
+			{constants.RoleTypePlatform, model.PermissionEffectAllow, t.Operations},
+			{constants.RoleTypePlatform, model.PermissionEffectDeny, t.Denies},
+			{constants.RoleTypeWorkspace, model.PermissionEffectAllow, t.WorkspaceOperations},
+			{constants.RoleTypeWorkspace, model.PermissionEffectDeny, t.WorkspaceDenies},
+		}
+		for _, g := range grants {
+			for _, id := range uniqueNonEmpty(g.ids) {
+				if err := tx.Create(&model.MciamRoleMciamPermission{
+					RoleType:     g.roleType,
+					RoleID:       created.ID,
+					PermissionID: id,
+					Effect:       g.effect,
+				}).Error; err != nil {
+					return fmt.Errorf("MC-IAM 권한 매핑 생성 실패 (%s): %w", id, err)
+				}
+			}
+		}
+
+		for _, c := range t.Csps {
+			var cspRole model.CspRole
+			if err := tx.Where("csp_type = ? AND name = ?", c.CspType, c.Name).First(&cspRole).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("%w: %s: csp role %s/%s not found", ErrInvalidRoleTemplate, t.Name, c.CspType, c.Name)
+				}
+				return fmt.Errorf("CSP 역할 조회 실패: %w", err)
+			}
+			authMethod := constants.AuthMethodOIDC
+			if c.AuthMethod != "" {
+				authMethod = c.AuthMethod
+			}
+			if err := tx.Create(&model.RoleMasterCspRoleMapping{
+				RoleID:      created.ID,
+				AuthMethod:  authMethod,
+				CspRoleID:   cspRole.ID,
+				Description: description,
+			}).Error; err != nil {
+				return fmt.Errorf("CSP 역할 매핑 생성 실패: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+// role_template_service_test.go
+//
+// RoleTemplateService 단위 테스트 (SQLite in-memory DB)
+// 역할 복제가 선택한 항목만 새 역할로 복사하고, YAML 템플릿으로 역할을 만드는지 검증한다.
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestRoleTemplateService(t *testing.T, templates string) (*RoleTemplateService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.Menu{},
+		&model.RoleMenuMapping{},
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+	))
+	dir := t.TempDir()
+	if templates != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "team.yaml"), []byte(templates), 0o644))
+	}
+	return &RoleTemplateService{db: db, roleRepo: repository.NewRoleRepository(db), authzService: newTestAuthz(db), templateDir: dir}, db
+}
+
+// createRoleTemplateActor 플랫폼 역할에 permissionIDs 를 부여한 요청자
+func createRoleTemplateActor(t *testing.T, db *gorm.DB, name string, permissionIDs ...string) *model.User {
+	t.Helper()
+	user := createGRTestUser(t, db, name, "kc-"+name)
+	assignAuthzTestPlatformRole(t, db, user, name+"-role", permissionIDs...)
+	return user
+}
+
+// seedCloneSourceRole 메뉴·허용/거부 권한·CSP 역할 매핑을 가진 원본 역할
+func seedCloneSourceRole(t *testing.T, db *gorm.DB) (*model.RoleMaster, *model.CspRole) {
+	t.Helper()
+	source := createGRTestRole(t, db, "rt-source")
+	require.NoError(t, db.Model(source).Updates(map[string]interface{}{"description": "source role", "delegable": true}).Error)
+	require.NoError(t, db.Create(&model.Menu{ID: "workloads", DisplayName: "Workloads", ResType: "menu"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: source.ID, MenuID: "workloads"}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, source.ID, "mc-iam-manager:user:read")
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: source.ID,
+		PermissionID: "mc-iam-manager:workspace:write", Effect: model.PermissionEffectDeny,
+	}).Error)
+	cspRole := &model.CspRole{Name: "rt-aws-role", CspType: "aws"}
+	require.NoError(t, db.Create(cspRole).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{
+		RoleID: source.ID, AuthMethod: constants.AuthMethodOIDC, CspRoleID: cspRole.ID, Description: "aws",
+	}).Error)
+	return source, cspRole
+}
+
+func countRoleTemplateRows(t *testing.T, db *gorm.DB, m interface{}, roleID uint) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, db.Model(m).Where("role_id = ?", roleID).Count(&count).Error)
+	return count
+}
+
+// TC-RT-01: 전체 복제는 역할 타입, 메뉴, 허용·거부 권한, CSP 매핑을 모두 복사
+func TestRoleTemplate_CloneCopiesAllSections(t *testing.T) {
+	svc, db := newTestRoleTemplateService(t, "")
+	source, cspRole := seedCloneSourceRole(t, db)
+	manager := createRoleTemplateActor(t, db, "rt-manager", roleCspMappingPermission)
+
+	clone, err := svc.CloneRole(context.Background(), manager.ID, source.ID, &model.CloneRoleRequest{Name: "rt-team-a"})
+	require.NoError(t, err)
+	assert.NotEqual(t, source.ID, clone.ID)
+	assert.Equal(t, "source role", clone.Description)
+	assert.False(t, clone.Delegable, "delegable is not copied")
+	assert.False(t, clone.Predefined)
+	assert.Len(t, clone.RoleSubs, 2)
+
+	assert.Equal(t, int64(1), countRoleTemplateRows(t, db, &model.RoleMenuMapping{}, clone.ID))
+	var deny model.MciamRoleMciamPermission
+	require.NoError(t, db.Where("role_id = ? AND role_type = ?", clone.ID, constants.RoleTypeWorkspace).First(&deny).Error)
+	assert.Equal(t, model.PermissionEffectDeny, deny.Effect)
+	assert.Equal(t, int64(2), countRoleTemplateRows(t, db, &model.MciamRoleMciamPermission{}, clone.ID))
+	var mapping model.RoleMasterCspRoleMapping
+	require.NoError(t, db.Where("role_id = ?", clone.ID).First(&mapping).Error)
+	assert.Equal(t, cspRole.ID, mapping.CspRoleID)
+
+	// 원본은 그대로
+	assert.Equal(t, int64(2), countRoleTemplateRows(t, db, &model.MciamRoleMciamPermission{}, source.ID))
+}
+
+// TC-RT-02: 선택 항목만 복제, 이름 중복·알 수 없는 항목·없는 원본은 거부, csps 는 role:manage 필요
+func TestRoleTemplate_CloneSelectedSectionsAndErrors(t *testing.T) {
+	svc, db := newTestRoleTemplateService(t, "")
+	source, _ := seedCloneSourceRole(t, db)
+	writer := createRoleTemplateActor(t, db, "rt-writer", "mc-iam-manager:role:write")
+	ctx := context.Background()
+	description := "team b"
+
+	_, err := svc.CloneRole(ctx, writer.ID, source.ID, &model.CloneRoleRequest{Name: "rt-team-b"})
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+
+	clone, err := svc.CloneRole(ctx, writer.ID, source.ID, &model.CloneRoleRequest{
+		Name: "rt-team-b", Description: &description, Sections: []string{"roleTypes", "operations"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "team b", clone.Description)
+	assert.Len(t, clone.RoleSubs, 2)
+	assert.Equal(t, int64(2), countRoleTemplateRows(t, db, &model.MciamRoleMciamPermission{}, clone.ID))
+	assert.Zero(t, countRoleTemplateRows(t, db, &model.RoleMenuMapping{}, clone.ID))
+	assert.Zero(t, countRoleTemplateRows(t, db, &model.RoleMasterCspRoleMapping{}, clone.ID))
+
+	_, err = svc.CloneRole(ctx, writer.ID, source.ID, &model.CloneRoleRequest{Name: "rt-team-b", Sections: []string{"menus"}})
+	assert.True(t, errors.Is(err, ErrRoleNameTaken), "got %v", err)
+	_, err = svc.CloneRole(ctx, writer.ID, source.ID, &model.CloneRoleRequest{Name: "rt-team-c", Sections: []string{"groups"}})
+	assert.True(t, errors.Is(err, ErrInvalidRoleClone), "got %v", err)
+	_, err = svc.CloneRole(ctx, writer.ID, 9999, &model.CloneRoleRequest{Name: "rt-team-d", Sections: []string{"menus"}})
+	assert.True(t, errors.Is(err, repository.ErrRoleMasterNotFound), "got %v", err)
+}
+
+const testRoleTemplates = `
+templates:
+  - name: team-operator
+    description: Team operator
+    roleTypes: [platform, workspace]
+    delegable: true
+    menus: [workloads]
+    operations: [mc-iam-manager:user:read]
+    workspaceDenies: [mc-iam-manager:workspace:write]
+    csps:
+      - cspType: aws
+        name: rt-aws-role
+  - name: team-viewer
+    roleTypes: [workspace]
+    menus: [workloads]
+  - name: broken-permission
+    roleTypes: [platform]
+    operations: [user-read]
+  - name: missing-csp
+    roleTypes: [platform]
+    csps:
+      - cspType: gcp
+        name: nope
+`
+
+// TC-RT-03: 템플릿으로 역할 생성, 잘못된 권한 ID·없는 CSP 역할은 아무것도 만들지 않음, csps 는 role:manage 필요
+func TestRoleTemplate_InstantiateTemplate(t *testing.T) {
+	svc, db := newTestRoleTemplateService(t, testRoleTemplates)
+	_, _ = seedCloneSourceRole(t, db)
+	manager := createRoleTemplateActor(t, db, "rt-manager", roleCspMappingPermission)
+	writer := createRoleTemplateActor(t, db, "rt-writer", "mc-iam-manager:role:write")
+	ctx := context.Background()
+
+	templates, err := svc.ListTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 4)
+	assert.Equal(t, "broken-permission", templates[0].Name)
+	assert.Equal(t, "team.yaml", templates[0].Source)
+
+	_, err = svc.InstantiateTemplate(ctx, writer.ID, "team-operator", &model.InstantiateRoleTemplateRequest{Name: "rt-ops-team"})
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+	viewer, err := svc.InstantiateTemplate(ctx, writer.ID, "team-viewer", &model.InstantiateRoleTemplateRequest{Name: "rt-viewer-team"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), countRoleTemplateRows(t, db, &model.RoleMenuMapping{}, viewer.ID))
+
+	role, err := svc.InstantiateTemplate(ctx, manager.ID, "team-operator", &model.InstantiateRoleTemplateRequest{Name: "rt-ops-team"})
+	require.NoError(t, err)
+	assert.Equal(t, "Team operator", role.Description)
+	assert.True(t, role.Delegable)
+	assert.Len(t, role.RoleSubs, 2)
+	assert.Equal(t, int64(1), countRoleTemplateRows(t, db, &model.RoleMenuMapping{}, role.ID))
+	assert.Equal(t, int64(2), countRoleTemplateRows(t, db, &model.MciamRoleMciamPermission{}, role.ID))
+	assert.Equal(t, int64(1), countRoleTemplateRows(t, db, &model.RoleMasterCspRoleMapping{}, role.ID))
+
+	_, err = svc.InstantiateTemplate(ctx, manager.ID, "broken-permission", &model.InstantiateRoleTemplateRequest{Name: "rt-broken"})
+	assert.True(t, errors.Is(err, ErrInvalidRoleTemplate), "got %v", err)
+	_, err = svc.InstantiateTemplate(ctx, manager.ID, "missing-csp", &model.InstantiateRoleTemplateRequest{Name: "rt-missing"})
+	assert.True(t, errors.Is(err, ErrInvalidRoleTemplate), "got %v", err)
+	_, err = svc.InstantiateTemplate(ctx, manager.ID, "unknown", &model.InstantiateRoleTemplateRequest{Name: "rt-unknown"})
+	assert.True(t, errors.Is(err, ErrRoleTemplateNotFound), "got %v", err)
+
+	var count int64
+	db.Model(&model.RoleMaster{}).Where("name IN ?", []string{"rt-broken", "rt-missing"}).Count(&count)
+	assert.Zero(t, count)
+}
+
+// TC-RT-04: CSP 역할 매핑 승인 워크플로가 켜져 있으면 csps 를 포함한 복제·템플릿 생성은 거부, csps 없이 만들기는 허용
+func TestRoleTemplate_CspMappingsRequireApproval(t *testing.T) {
+	svc, db := newTestRoleTemplateService(t, testRoleTemplates)
+	require.NoError(t, db.AutoMigrate(&model.WorkflowPolicy{}, &model.WorkflowRequest{}))
+	svc.workflowService = &WorkflowService{workflowRepo: repository.NewWorkflowRepository(db), authzService: newTestAuthz(db)}
+	svc.workflowService.RegisterAction(model.WorkflowTypeCspRoleMapping, &cspRoleMappingWorkflowAction{roleService: NewRoleService(db)})
+	enableTestWorkflow(t, svc.workflowService, model.WorkflowTypeCspRoleMapping, model.WorkflowPolicy{ApproverRules: []model.WorkflowApproverRule{reviewerRule}})
+	source, _ := seedCloneSourceRole(t, db)
+	manager := createRoleTemplateActor(t, db, "rt-manager", roleCspMappingPermission)
+	ctx := context.Background()
+
+	_, err := svc.CloneRole(ctx, manager.ID, source.ID, &model.CloneRoleRequest{Name: "rt-team-a"})
+	assert.True(t, errors.Is(err, ErrCspMappingApprovalRequired), "got %v", err)
+	_, err = svc.InstantiateTemplate(ctx, manager.ID, "team-operator", &model.InstantiateRoleTemplateRequest{Name: "rt-ops-team"})
+	assert.True(t, errors.Is(err, ErrCspMappingApprovalRequired), "got %v", err)
+	var count int64
+	require.NoError(t, db.Model(&model.RoleMaster{}).Where("name IN ?", []string{"rt-team-a", "rt-ops-team"}).Count(&count).Error)
+	assert.Zero(t, count)
+
+	clone, err := svc.CloneRole(ctx, manager.ID, source.ID, &model.CloneRoleRequest{
+		Name: "rt-team-a", Sections: []string{"roleTypes", "menus", "operations"},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, countRoleTemplateRows(t, db, &model.RoleMasterCspRoleMapping{}, clone.ID))
+	viewer, err := svc.InstantiateTemplate(ctx, manager.ID, "team-viewer", &model.InstantiateRoleTemplateRequest{Name: "rt-viewer-team"})
+	require.NoError(t, err)
+	assert.Zero(t, countRoleTemplateRows(t, db, &model.RoleMasterCspRoleMapping{}, viewer.ID))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/eventsink"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// 보안 이벤트 심각도 (CEF 0-10)
+const (
+	securitySeverityLow      = 3
+	securitySeverityMedium   = 5
+	securitySeverityHigh     = 7
+	securitySeverityCritical = 9
+)
+
+// PublishLoginEvent 로그인 결과를 보안 이벤트 싱크로 전달 (kcUserID 는 인증 성공 시에만 알 수 있음)
+func PublishLoginEvent(loginID, kcUserID, sourceIP, userAgent string, loginErr error) {
+	event := &model.SecurityEvent{
+		ID:            "login:" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		Time:          time.Now(),
+		Type:          model.SecurityEventLogin,
+		Category:      model.SecurityCategoryAuthentication,
+		Severity:      securitySeverityLow,
+		Outcome:       model.AuditResultSuccess,
+		ActorKcID:     kcUserID,
+		ActorUsername: loginID,
+		SourceIP:      sourceIP,
+		UserAgent:     userAgent,
+		Message:       "user login",
+	}
+	if loginErr != nil {
+		event.Severity = securitySeverityMedium
+		event.Outcome = model.AuditResultFailure
+		event.Reason = loginErr.Error()
+		event.Message = "user login failed"
+	}
+	eventsink.Publish(event)
+}
+
+// securityEventFromAudit 해시 체인에 추가된 감사 이벤트로 보안 이벤트 생성
+func securityEventFromAudit(event *model.AuditEvent) *model.SecurityEvent {
+	se := &model.SecurityEvent{
+		ID:            fmt.Sprintf("audit:%d", event.ID),
+		Time:          event.OccurredAt,
+		Type:          event.Action,
+		Category:      model.SecurityCategoryAudit,
+		Severity:      securitySeverityLow,
+		Outcome:       event.Result,
+		ActorKcID:     event.ActorKcID,
+		ActorUsername: event.ActorUsername,
+		SourceIP:      event.SourceIP,
+		UserAgent:     event.UserAgent,
+		TargetType:    event.EntityType,
+		TargetID:      event.EntityID,
+		WorkspaceID:   event.WorkspaceID,
+		Message:       event.Method + " " + event.Route,
+		Reason:        event.ErrorMessage,
+		RecordID:      event.ID,
+		RecordHash:    event.Hash,
+		Attributes:    map[string]string{"statusCode": strconv.Itoa(event.StatusCode)},
+	}
+	switch {
+	case strings.HasPrefix(event.Action, "role."), strings.HasPrefix(event.Action, "group.platform-role."),
+		strings.HasPrefix(event.Action, "group.workspace-role."):
+		se.Category = model.SecurityCategoryAuthorization
+		se.Severity = securitySeverityHigh
+	case strings.HasPrefix(event.Action, "access-review."):
+		se.Category = model.SecurityCategoryAuthorization
+		se.Severity = securitySeverityMedium
+	case strings.HasPrefix(event.Action, "sod."), strings.HasPrefix(event.Action, "elevation."),
+		strings.HasPrefix(event.Action, "workflow."):
+		se.Category = model.SecurityCategoryAuthorization
+		se.Severity = securitySeverityHigh
+	case strings.HasPrefix(event.Action, "break-glass."):
+		se.Category = model.SecurityCategoryAuthorization
+		se.Severity = securitySeverityCritical
+	case strings.HasPrefix(event.Action, "user.dormancy."):
+		se.Category = model.SecurityCategoryAccount
+		se.Severity = securitySeverityMedium
+	case event.Action == model.AuditActionInvitationApprove,
+		event.Action == model.AuditActionWithdrawalRequest,
+		event.Action == model.AuditActionWithdrawalProcess:
+		se.Category = model.SecurityCategoryAccount
+		se.Severity = securitySeverityMedium
+	}
+	if event.Result == model.AuditResultFailure && se.Severity < securitySeverityMedium {
+		se.Severity = securitySeverityMedium
+	}
+	return se
+}
+
+// securityEventFromIssuance 발급 원장 기록으로 보안 이벤트 생성
+func securityEventFromIssuance(issuance *model.CredentialIssuance) *model.SecurityEvent {
+	se := &model.SecurityEvent{
+		ID:            fmt.Sprintf("credential-issuance:%d", issuance.ID),
+		Time:          issuance.RequestedAt,
+		Type:          model.SecurityEventCredentialIssue,
+		Category:      model.SecurityCategoryCredential,
+		Severity:      securitySeverityMedium,
+		Outcome:       issuance.Result,
+		ActorKcID:     issuance.KcUserID,
+		ActorUsername: issuance.Username,
+		SourceIP:      issuance.SourceIP,
+		TargetType:    "csp-role",
+		TargetID:      issuance.CspRoleIdentifier,
+		Message:       "temporary " + issuance.CspType + " credential issued",
+		Reason:        issuance.FailureReason,
+		RecordID:      issuance.ID,
+		RecordHash:    issuance.Hash,
+		Attributes: map[string]string{
+			"cspType":    issuance.CspType,
+			"authMethod": issuance.AuthMethod,
+		},
+	}
+	if issuance.WorkspaceID != 0 {
+		workspaceID := issuance.WorkspaceID
+		se.WorkspaceID = &workspaceID
+	}
+	if issuance.CspRoleName != "" {
+		se.Attributes["cspRole"] = issuance.CspRoleName
+	}
+	if issuance.WorkspaceRoleName != "" {
+		se.Attributes["workspaceRole"] = issuance.WorkspaceRoleName
+	}
+	if issuance.ExpiresAt != nil {
+		se.Attributes["expiresAt"] = issuance.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if issuance.Result == model.AuditResultFailure {
+		se.Message = "temporary " + issuance.CspType + " credential issuance failed"
+	}
+	return se
+}
+
+// securityEventFromBreakGlassActivity 비상 접근 중 요청 기록으로 보안 이벤트 생성
+func securityEventFromBreakGlassActivity(activity *model.BreakGlassActivity) *model.SecurityEvent {
+	se := &model.SecurityEvent{
+		ID:            fmt.Sprintf("break-glass-activity:%d", activity.ID),
+		Time:          activity.OccurredAt,
+		Type:          model.SecurityEventBreakGlassActivity,
+		Category:      model.SecurityCategoryAuthorization,
+		Severity:      securitySeverityHigh,
+		Outcome:       model.AuditResultSuccess,
+		ActorKcID:     activity.ActorKcID,
+		ActorUsername: activity.ActorUsername,
+		SourceIP:      activity.SourceIP,
+		UserAgent:     activity.UserAgent,
+		TargetType:    model.AuditEntityBreakGlass,
+		TargetID:      fmt.Sprint(activity.SessionID),
+		Message:       activity.Method + " " + activity.Route,
+		Reason:        activity.ErrorMessage,
+		RecordID:      activity.ID,
+		RecordHash:    activity.Hash,
+		Attributes:    map[string]string{"statusCode": strconv.Itoa(activity.StatusCode)},
+	}
+	if activity.StatusCode >= 400 {
+		se.Outcome = model.AuditResultFailure
+	}
+	return se
+}
@@ -0,0 +1,603 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSodViolation        = errors.New("segregation of duties violation")
+	ErrInvalidSodRequest   = errors.New("invalid segregation of duties request")
+	ErrSodOverrideInactive = errors.New("sod override is already revoked or expired")
+)
+
+// sodSourceProposed 검사 중인 변경으로 생기는 역할 보유 경로의 접두어
+const sodSourceProposed = "proposed"
+
+// SodService 직무 분리(SoD) 제약 관리와 역할 할당 시 위반 검사
+// 보유 역할은 플랫폼 역할과 워크스페이스별 역할을 직접 할당 + 그룹 상속으로 모으며,
+// 플랫폼 역할끼리 또는 플랫폼 역할 + 한 워크스페이스의 역할 사이에서 제약을 평가한다.
+// 검사 메서드는 nil 수신자에서 아무 것도 하지 않는다.
+type SodService struct {
+	sodRepo       *repository.SodRepository
+	authzRepo     *repository.AuthzRepository
+	orgRepo       *repository.OrganizationRepository
+	groupRoleRepo *repository.GroupRoleRepository
+}
+
+// NewSodService SodService 생성
+func NewSodService(db *gorm.DB) *SodService {
+	return &SodService{
+		sodRepo:       repository.NewSodRepository(db),
+		authzRepo:     repository.NewAuthzRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		groupRoleRepo: repository.NewGroupRoleRepository(db),
+	}
+}
+
+// --- 제약 관리 ---
+
+// ListConstraints 제약 목록 (역할 이름 포함)
+func (s *SodService) ListConstraints() ([]model.SodConstraint, error) {
+	constraints, err := s.sodRepo.ListConstraints(false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillRoleNames(constraints); err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
+
+// GetConstraint 제약 조회 (역할 이름 포함)
+func (s *SodService) GetConstraint(id uint) (*model.SodConstraint, error) {
+	constraint, err := s.sodRepo.FindConstraintByID(id)
+	if err != nil {
+		return nil, err
+	}
+	constraints := []model.SodConstraint{*constraint}
+	if err := s.fillRoleNames(constraints); err != nil {
+		return nil, err
+	}
+	return &constraints[0], nil
+}
+
+// CreateConstraint 제약 생성 (서로 다른 역할 두 개 이상 필요)
+func (s *SodService) CreateConstraint(req *model.SodConstraintRequest) (*model.SodConstraint, error) {
+	constraint := &model.SodConstraint{}
+	if err := s.applyConstraintRequest(constraint, req); err != nil {
+		return nil, err
+	}
+	if err := s.sodRepo.CreateConstraint(constraint); err != nil {
+		return nil, err
+	}
+	return s.GetConstraint(constraint.ID)
+}
+
+// UpdateConstraint 제약 수정
+func (s *SodService) UpdateConstraint(id uint, req *model.SodConstraintRequest) (*model.SodConstraint, error) {
+	constraint, err := s.sodRepo.FindConstraintByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyConstraintRequest(constraint, req); err != nil {
+		return nil, err
+	}
+	if err := s.sodRepo.UpdateConstraint(constraint); err != nil {
+		return nil, err
+	}
+	return s.GetConstraint(id)
+}
+
+// DeleteConstraint 제약과 예외 삭제
+func (s *SodService) DeleteConstraint(id uint) error {
+	return s.sodRepo.DeleteConstraint(id)
+}
+
+func (s *SodService) applyConstraintRequest(constraint *model.SodConstraint, req *model.SodConstraintRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSodRequest)
+	}
+	seen := make(map[uint]bool)
+	roleIDs := make([]uint, 0, len(req.RoleIDs)+len(req.RoleNames))
+	for _, id := range req.RoleIDs {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	if names := uniqueNonEmpty(req.RoleNames); len(names) > 0 {
+		ids, err := s.sodRepo.FindRoleIDsByNames(names)
+		if err != nil {
+			return err
+		}
+		for _, n := range names {
+			id, ok := ids[n]
+			if !ok {
+				return fmt.Errorf("%w: role not found: %s", ErrInvalidSodRequest, n)
+			}
+			if !seen[id] {
+				seen[id] = true
+				roleIDs = append(roleIDs, id)
+			}
+		}
+	}
+	if len(roleIDs) < 2 {
+		return fmt.Errorf("%w: at least two distinct roles are required", ErrInvalidSodRequest)
+	}
+	names, err := s.sodRepo.FindRoleNames(roleIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range roleIDs {
+		if _, ok := names[id]; !ok {
+			return fmt.Errorf("%w: role not found: %d", ErrInvalidSodRequest, id)
+		}
+	}
+
+	constraint.Name = name
+	constraint.Description = req.Description
+	constraint.RoleIDs = roleIDs
+	constraint.Enabled = req.Enabled == nil || *req.Enabled
+	return nil
+}
+
+func (s *SodService) fillRoleNames(constraints []model.SodConstraint) error {
+	var roleIDs []uint
+	for _, c := range constraints {
+		roleIDs = append(roleIDs, c.RoleIDs...)
+	}
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	names, err := s.sodRepo.FindRoleNames(roleIDs)
+	if err != nil {
+		return err
+	}
+	for i := range constraints {
+		constraints[i].RoleNames = make([]string, 0, len(constraints[i].RoleIDs))
+		for _, id := range constraints[i].RoleIDs {
+			constraints[i].RoleNames = append(constraints[i].RoleNames, names[id])
+		}
+	}
+	return nil
+}
+
+// --- 예외 ---
+
+// ListOverrides 예외 목록 (0 인 조건은 제한 없음)
+func (s *SodService) ListOverrides(constraintID, userID uint) ([]model.SodOverride, error) {
+	return s.sodRepo.ListOverrides(constraintID, userID)
+}
+
+// CreateOverride 사용자에게 제약 예외 승인 (사유 필수)
+func (s *SodService) CreateOverride(req *model.CreateSodOverrideRequest, approver *model.User) (*model.SodOverride, error) {
+	justification := strings.TrimSpace(req.Justification)
+	if justification == "" {
+		return nil, fmt.Errorf("%w: justification is required", ErrInvalidSodRequest)
+	}
+	if req.UserID == 0 {
+		return nil, fmt.Errorf("%w: userId is required", ErrInvalidSodRequest)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidSodRequest)
+	}
+	if _, err := s.sodRepo.FindConstraintByID(req.ConstraintID); err != nil {
+		return nil, err
+	}
+	usernames, err := s.sodRepo.FindUsernames([]uint{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := usernames[req.UserID]; !ok {
+		return nil, fmt.Errorf("%w: user %d", ErrUserNotFound, req.UserID)
+	}
+
+	override := &model.SodOverride{
+		ConstraintID:  req.ConstraintID,
+		UserID:        req.UserID,
+		Justification: justification,
+		ExpiresAt:     req.ExpiresAt,
+	}
+	if approver != nil {
+		override.ApprovedByKcID = approver.KcId
+		override.ApprovedByUsername = approver.Username
+	}
+	if err := s.sodRepo.CreateOverride(override); err != nil {
+		return nil, err
+	}
+	return override, nil
+}
+
+// RevokeOverride 예외 철회 (이후 해당 제약 위반 할당은 다시 차단된다. 이미 보유한 역할은 위반 보고서에 나타난다)
+func (s *SodService) RevokeOverride(id uint, actor *model.User) (*model.SodOverride, error) {
+	override, err := s.sodRepo.FindOverrideByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if override.RevokedAt != nil {
+		return nil, ErrSodOverrideInactive
+	}
+	actorKcID := ""
+	if actor != nil {
+		actorKcID = actor.KcId
+	}
+	if err := s.sodRepo.RevokeOverride(id, actorKcID, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.sodRepo.FindOverrideByID(id)
+}
+
+// --- 위반 검사 ---
+
+// FindViolations 현재 할당의 제약 위반 보고서 (constraintID 가 0 이면 활성 제약 전체, 예외 승인된 위반 포함)
+func (s *SodService) FindViolations(constraintID uint) ([]model.SodViolation, error) {
+	var constraints []model.SodConstraint
+	if constraintID != 0 {
+		constraint, err := s.sodRepo.FindConstraintByID(constraintID)
+		if err != nil {
+			return nil, err
+		}
+		constraints = []model.SodConstraint{*constraint}
+	} else {
+		var err error
+		if constraints, err = s.sodRepo.ListConstraints(true); err != nil {
+			return nil, err
+		}
+	}
+	violations := make([]model.SodViolation, 0)
+	var roleIDs []uint
+	for _, c := range constraints {
+		roleIDs = append(roleIDs, c.RoleIDs...)
+	}
+	if len(roleIDs) == 0 {
+		return violations, nil
+	}
+	userIDs, err := s.sodRepo.FindUsersHoldingRoles(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return violations, nil
+	}
+	overrides, err := s.sodRepo.FindActiveOverrides(userIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	usernames, err := s.sodRepo.FindUsernames(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		holdings, err := s.loadHoldings(userID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range evaluateSod(constraints, holdings) {
+			v.UserID = userID
+			v.Username = usernames[userID]
+			if o, ok := overrides[userID][v.ConstraintID]; ok {
+				id := o.ID
+				v.OverrideID = &id
+			}
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
+
+// CheckPlatformRoleAssignment 사용자 플랫폼 역할 할당이 제약을 위반하는지 검사
+func (s *SodService) CheckPlatformRoleAssignment(userID, roleID uint) error {
+	if s == nil {
+		return nil
+	}
+	return s.check([]uint{userID}, &sodProposal{
+		platform: []model.SodRoleHolding{{RoleID: roleID, Source: sodSourceProposed}},
+	})
+}
+
+// CheckWorkspaceRoleAssignment 사용자 워크스페이스 역할 할당이 제약을 위반하는지 검사
+func (s *SodService) CheckWorkspaceRoleAssignment(userID, workspaceID, roleID uint) error {
+	if s == nil {
+		return nil
+	}
+	return s.check([]uint{userID}, &sodProposal{
+		workspaces: map[uint][]model.SodRoleHolding{workspaceID: {{RoleID: roleID, Source: sodSourceProposed}}},
+	})
+}
+
+// CheckGroupPlatformRoleAssignment 그룹 플랫폼 역할 할당이 그룹 구성원에게 제약 위반을 만드는지 검사
+func (s *SodService) CheckGroupPlatformRoleAssignment(groupID, roleID uint) error {
+	if s == nil {
+		return nil
+	}
+	userIDs, err := s.groupMemberIDs(groupID)
+	if err != nil || len(userIDs) == 0 {
+		return err
+	}
+	return s.check(userIDs, &sodProposal{
+		platform: []model.SodRoleHolding{{RoleID: roleID, Source: sodSourceProposed}},
+	})
+}
+
+// CheckGroupWorkspaceRoleAssignment 그룹 워크스페이스 역할 할당/변경이 그룹 구성원에게 제약 위반을 만드는지 검사
+// 그룹은 워크스페이스마다 역할 하나를 가지므로 같은 워크스페이스의 기존 그룹 역할은 대체되는 것으로 본다.
+func (s *SodService) CheckGroupWorkspaceRoleAssignment(groupID, workspaceID, roleID uint) error {
+	if s == nil {
+		return nil
+	}
+	userIDs, err := s.groupMemberIDs(groupID)
+	if err != nil || len(userIDs) == 0 {
+		return err
+	}
+	return s.check(userIDs, &sodProposal{
+		workspaces: map[uint][]model.SodRoleHolding{workspaceID: {{RoleID: roleID, Source: sodSourceProposed}}},
+		drop: func(g model.AuthzRoleGrant) bool {
+			return g.GroupID == groupID && g.WorkspaceID == workspaceID
+		},
+	})
+}
+
+// CheckGroupMembership 사용자의 그룹 가입이 그룹 상속 역할로 제약 위반을 만드는지 검사
+// replace 면 groupIDs 가 새 소속 전체이며, 빠지는 그룹의 상속 역할은 제외하고 평가한다.
+func (s *SodService) CheckGroupMembership(userID uint, groupIDs []uint, replace bool) error {
+	if s == nil {
+		return nil
+	}
+	current, err := s.authzRepo.FindUserOrganizationIDs(userID)
+	if err != nil {
+		return err
+	}
+	isMember := make(map[uint]bool, len(current))
+	for _, id := range current {
+		isMember[id] = true
+	}
+	keep := make(map[uint]bool, len(groupIDs))
+	proposal := &sodProposal{workspaces: make(map[uint][]model.SodRoleHolding)}
+	for _, groupID := range groupIDs {
+		keep[groupID] = true
+		if isMember[groupID] {
+			continue
+		}
+		platformRoles, err := s.groupRoleRepo.FindGroupPlatformRoles(groupID)
+		if err != nil {
+			return err
+		}
+		for _, r := range platformRoles {
+			proposal.platform = append(proposal.platform, model.SodRoleHolding{RoleID: r.RoleID, RoleName: r.RoleName, Source: sodSourceProposed + " group:" + r.GroupName})
+		}
+		workspaceRoles, err := s.groupRoleRepo.FindGroupWorkspaceRoles(groupID)
+		if err != nil {
+			return err
+		}
+		for _, r := range workspaceRoles {
+			proposal.workspaces[r.WorkspaceID] = append(proposal.workspaces[r.WorkspaceID],
+				model.SodRoleHolding{RoleID: r.RoleID, RoleName: r.RoleName, Source: sodSourceProposed + " group:" + r.GroupName})
+		}
+	}
+	if replace {
+		proposal.drop = func(g model.AuthzRoleGrant) bool {
+			return g.GroupID != 0 && !keep[g.GroupID]
+		}
+	}
+	return s.check([]uint{userID}, proposal)
+}
+
+// sodProposal 검사할 변경 (기존 보유 역할에서 drop 을 빼고 platform/workspaces 를 더해 평가)
+type sodProposal struct {
+	platform   []model.SodRoleHolding
+	workspaces map[uint][]model.SodRoleHolding
+	drop       func(model.AuthzRoleGrant) bool
+}
+
+func (p *sodProposal) roleIDs() []uint {
+	var ids []uint
+	for _, h := range p.platform {
+		ids = append(ids, h.RoleID)
+	}
+	for _, hs := range p.workspaces {
+		for _, h := range hs {
+			ids = append(ids, h.RoleID)
+		}
+	}
+	return ids
+}
+
+// sodHoldings 사용자의 보유 역할 (플랫폼, 워크스페이스별)
+type sodHoldings struct {
+	platform   []model.SodRoleHolding
+	workspaces map[uint][]model.SodRoleHolding
+}
+
+// check 변경 후 보유 역할이 변경된 역할을 포함해 제약을 위반하면 ErrSodViolation (유효한 예외가 있는 제약은 제외)
+func (s *SodService) check(userIDs []uint, proposal *sodProposal) error {
+	proposedRoles := proposal.roleIDs()
+	if len(proposedRoles) == 0 {
+		return nil
+	}
+	constraints, err := s.sodRepo.ListConstraints(true)
+	if err != nil {
+		return err
+	}
+	constraints = constraintsWithAnyRole(constraints, proposedRoles)
+	if len(constraints) == 0 {
+		return nil
+	}
+	overrides, err := s.sodRepo.FindActiveOverrides(userIDs, time.Now())
+	if err != nil {
+		return err
+	}
+	roleNames, err := s.sodRepo.FindRoleNames(proposedRoles)
+	if err != nil {
+		return err
+	}
+
+	var messages []string
+	for _, userID := range userIDs {
+		holdings, err := s.loadHoldings(userID, proposal.drop)
+		if err != nil {
+			return err
+		}
+		for _, h := range proposal.platform {
+			h.RoleName = roleNames[h.RoleID]
+			holdings.platform = append(holdings.platform, h)
+		}
+		for wsID, hs := range proposal.workspaces {
+			for _, h := range hs {
+				h.RoleName = roleNames[h.RoleID]
+				holdings.workspaces[wsID] = append(holdings.workspaces[wsID], h)
+			}
+		}
+		for _, v := range evaluateSod(constraints, holdings) {
+			if _, ok := overrides[userID][v.ConstraintID]; ok || !involvesProposed(v) {
+				continue
+			}
+			messages = append(messages, describeSodViolation(userID, v))
+		}
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("%w: %s", ErrSodViolation, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// loadHoldings 직접 할당 + 그룹 상속 역할, 시작 전 예약 할당 포함 (drop 이 true 인 경로는 제외)
+func (s *SodService) loadHoldings(userID uint, drop func(model.AuthzRoleGrant) bool) (*sodHoldings, error) {
+	platform, err := s.authzRepo.FindAssignedPlatformRoleGrants(userID)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.authzRepo.FindAssignedWorkspaceRoleGrants(userID)
+	if err != nil {
+		return nil, err
+	}
+	holdings := &sodHoldings{workspaces: make(map[uint][]model.SodRoleHolding)}
+	for _, g := range platform {
+		if drop == nil || !drop(g) {
+			holdings.platform = append(holdings.platform, model.SodRoleHolding{RoleID: g.RoleID, RoleName: g.RoleName, Source: g.Source})
+		}
+	}
+	for _, g := range workspace {
+		if drop == nil || !drop(g) {
+			holdings.workspaces[g.WorkspaceID] = append(holdings.workspaces[g.WorkspaceID],
+				model.SodRoleHolding{RoleID: g.RoleID, RoleName: g.RoleName, Source: g.Source})
+		}
+	}
+	return holdings, nil
+}
+
+func (s *SodService) groupMemberIDs(groupID uint) ([]uint, error) {
+	members, err := s.orgRepo.FindOrganizationUsers(groupID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+// evaluateSod 제약별 위반. 플랫폼 역할만으로 위반하면 워크스페이스별로 다시 보고하지 않는다.
+func evaluateSod(constraints []model.SodConstraint, holdings *sodHoldings) []model.SodViolation {
+	workspaceIDs := make([]uint, 0, len(holdings.workspaces))
+	for id := range holdings.workspaces {
+		workspaceIDs = append(workspaceIDs, id)
+	}
+	sort.Slice(workspaceIDs, func(i, j int) bool { return workspaceIDs[i] < workspaceIDs[j] })
+
+	var violations []model.SodViolation
+	for _, c := range constraints {
+		inConstraint := make(map[uint]bool, len(c.RoleIDs))
+		for _, id := range c.RoleIDs {
+			inConstraint[id] = true
+		}
+		platformHeld := filterSodHoldings(holdings.platform, inConstraint)
+		if distinctSodRoles(platformHeld) >= 2 {
+			violations = append(violations, model.SodViolation{ConstraintID: c.ID, ConstraintName: c.Name, Roles: platformHeld})
+			continue
+		}
+		for _, wsID := range workspaceIDs {
+			wsHeld := filterSodHoldings(holdings.workspaces[wsID], inConstraint)
+			if len(wsHeld) == 0 {
+				continue
+			}
+			held := append(append([]model.SodRoleHolding{}, platformHeld...), wsHeld...)
+			if distinctSodRoles(held) >= 2 {
+				id := wsID
+				violations = append(violations, model.SodViolation{ConstraintID: c.ID, ConstraintName: c.Name, WorkspaceID: &id, Roles: held})
+			}
+		}
+	}
+	return violations
+}
+
+func filterSodHoldings(holdings []model.SodRoleHolding, roleIDs map[uint]bool) []model.SodRoleHolding {
+	var out []model.SodRoleHolding
+	for _, h := range holdings {
+		if roleIDs[h.RoleID] {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+func distinctSodRoles(holdings []model.SodRoleHolding) int {
+	seen := make(map[uint]bool, len(holdings))
+	for _, h := range holdings {
+		seen[h.RoleID] = true
+	}
+	return len(seen)
+}
+
+func involvesProposed(v model.SodViolation) bool {
+	for _, h := range v.Roles {
+		if strings.HasPrefix(h.Source, sodSourceProposed) {
+			return true
+		}
+	}
+	return false
+}
+
+func constraintsWithAnyRole(constraints []model.SodConstraint, roleIDs []uint) []model.SodConstraint {
+	want := make(map[uint]bool, len(roleIDs))
+	for _, id := range roleIDs {
+		want[id] = true
+	}
+	var out []model.SodConstraint
+	for _, c := range constraints {
+		for _, id := range c.RoleIDs {
+			if want[id] {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// describeSodViolation 오류 메시지용 위반 설명
+func describeSodViolation(userID uint, v model.SodViolation) string {
+	roles := make([]string, 0, len(v.Roles))
+	seen := make(map[string]bool)
+	for _, h := range v.Roles {
+		label := h.RoleName + " (" + h.Source + ")"
+		if !seen[label] {
+			seen[label] = true
+			roles = append(roles, label)
+		}
+	}
+	scope := "platform"
+	if v.WorkspaceID != nil {
+		scope = fmt.Sprintf("workspace %d", *v.WorkspaceID)
+	}
+	return fmt.Sprintf("constraint %q: user %d would hold %s in %s", v.ConstraintName, userID, strings.Join(roles, ", "), scope)
+}
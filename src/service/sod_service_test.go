@@ -0,0 +1,320 @@
+package service
+
+// sod_service_test.go
+//
+// SodService 단위 테스트 (SQLite in-memory DB)
+// 직접/그룹 상속 역할의 직무 분리 제약 검사, 할당 경로별 차단, 예외 승인/철회, 위반 보고서를 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+func newTestSodService(t *testing.T) (*SodService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	return NewSodService(db), db
+}
+
+func createSodTestConstraint(t *testing.T, svc *SodService, name string, roles ...*model.RoleMaster) *model.SodConstraint {
+	t.Helper()
+	req := &model.SodConstraintRequest{Name: name}
+	for _, r := range roles {
+		req.RoleIDs = append(req.RoleIDs, r.ID)
+	}
+	constraint, err := svc.CreateConstraint(req)
+	require.NoError(t, err)
+	return constraint
+}
+
+func addSodTestMember(t *testing.T, db *gorm.DB, userID, groupID uint) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: userID, OrganizationID: groupID}).Error)
+}
+
+// ── 제약 관리 ─────────────────────────────────────────────────────────────────
+
+// TC-SOD-CON-01: 역할 이름으로 제약 생성, 역할이 하나뿐이거나 없는 역할이면 거부
+func TestSodCreateConstraint_Validation(t *testing.T) {
+	svc, db := newTestSodService(t)
+	requester := createGRTestRole(t, db, "payment-requester")
+	createGRTestRole(t, db, "payment-approver")
+
+	constraint, err := svc.CreateConstraint(&model.SodConstraintRequest{
+		Name:      "payment",
+		RoleIDs:   []uint{requester.ID},
+		RoleNames: []string{"payment-approver", "payment-requester"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, constraint.RoleIDs, 2)
+	assert.ElementsMatch(t, []string{"payment-requester", "payment-approver"}, constraint.RoleNames)
+	assert.True(t, constraint.Enabled)
+
+	_, err = svc.CreateConstraint(&model.SodConstraintRequest{Name: "single", RoleIDs: []uint{requester.ID, requester.ID}})
+	assert.True(t, errors.Is(err, ErrInvalidSodRequest))
+
+	_, err = svc.CreateConstraint(&model.SodConstraintRequest{Name: "unknown", RoleNames: []string{"payment-requester", "nope"}})
+	assert.True(t, errors.Is(err, ErrInvalidSodRequest))
+}
+
+// ── 할당 경로별 차단 ───────────────────────────────────────────────────────────
+
+// TC-SOD-ROLE-01: 직접 보유한 플랫폼 역할과 충돌하는 플랫폼 역할 할당 → ErrSodViolation, 저장되지 않음
+func TestSodAssignPlatformRole_DirectConflictBlocked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "requester")
+	roleB := createGRTestRole(t, db, "approver")
+	createSodTestConstraint(t, svc, "request-approve", roleA, roleB)
+	user := createGRTestUser(t, db, "alice", "kc-alice")
+	roleService := NewRoleService(db)
+	require.NoError(t, roleService.AssignPlatformRole(user.ID, roleA.ID))
+
+	err := roleService.AssignPlatformRole(user.ID, roleB.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSodViolation))
+	assert.Contains(t, err.Error(), "request-approve")
+
+	var count int64
+	db.Model(&model.UserPlatformRole{}).Where("user_id = ? AND role_id = ?", user.ID, roleB.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+// TC-SOD-ROLE-02: 그룹에서 상속한 플랫폼 역할과 충돌하는 워크스페이스 역할 할당 → 차단
+func TestSodAssignWorkspaceRole_GroupInheritedConflictBlocked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "auditor")
+	roleB := createGRTestRole(t, db, "operator")
+	createSodTestConstraint(t, svc, "audit-operate", roleA, roleB)
+	ws := createGRTestWorkspace(t, db, "ws-1")
+	group := createGRTestOrg(t, db, "auditors", "AUD")
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: group.ID, RoleID: roleA.ID}).Error)
+	user := createGRTestUser(t, db, "bob", "kc-bob")
+	addSodTestMember(t, db, user.ID, group.ID)
+
+	err := NewRoleService(db).AssignWorkspaceRole(user.ID, ws.ID, roleB.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSodViolation))
+	assert.Contains(t, err.Error(), "group:auditors")
+}
+
+// TC-SOD-ROLE-03: 서로 다른 워크스페이스의 역할, 제약과 무관한 역할, 비활성 제약 → 허용
+func TestSodAssignWorkspaceRole_UnrelatedAllowed(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "developer")
+	roleB := createGRTestRole(t, db, "deployer")
+	roleC := createGRTestRole(t, db, "viewer")
+	createSodTestConstraint(t, svc, "dev-deploy", roleA, roleB)
+	disabled := false
+	_, err := svc.CreateConstraint(&model.SodConstraintRequest{Name: "disabled", RoleIDs: []uint{roleA.ID, roleC.ID}, Enabled: &disabled})
+	require.NoError(t, err)
+	ws1 := createGRTestWorkspace(t, db, "ws-1")
+	ws2 := createGRTestWorkspace(t, db, "ws-2")
+	user := createGRTestUser(t, db, "carol", "kc-carol")
+	roleService := NewRoleService(db)
+	require.NoError(t, roleService.AssignWorkspaceRole(user.ID, ws1.ID, roleA.ID))
+
+	assert.NoError(t, roleService.AssignWorkspaceRole(user.ID, ws2.ID, roleB.ID))
+	assert.NoError(t, roleService.AssignWorkspaceRole(user.ID, ws1.ID, roleC.ID))
+}
+
+// TC-SOD-ROLE-04: 초대 수락·승인, 워크스페이스 사용자 추가도 충돌 역할이면 차단, 멤버로 등록되지 않음
+func TestSodWorkspaceMembership_ConflictBlocked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	require.NoError(t, db.AutoMigrate(&model.WorkspaceInvitation{}))
+	auditor := createGRTestRole(t, db, "auditor")
+	member := createGRTestRole(t, db, "workspace_user")
+	createSodTestConstraint(t, svc, "audit-member", auditor, member)
+	ws := createGRTestWorkspace(t, db, "ws-1")
+	inviter := createGRTestUser(t, db, "owner", "kc-owner")
+	dave := createGRTestUser(t, db, "dave", "kc-dave")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: dave.ID, RoleID: auditor.ID}).Error)
+
+	invitationService := NewWorkspaceInvitationService(db)
+	invitation, err := invitationService.SendInvitation(ws.ID, inviter.ID, dave.ID, &member.ID)
+	require.NoError(t, err)
+	err = invitationService.AcceptInvitation(invitation.ID, dave.ID)
+	assert.True(t, errors.Is(err, ErrSodViolation), "got %v", err)
+
+	_, err = invitationService.RequestApproval(invitation.ID, dave.ID)
+	require.NoError(t, err)
+	err = invitationService.ApproveInvitation(invitation.ID)
+	assert.True(t, errors.Is(err, ErrSodViolation), "got %v", err)
+
+	err = NewWorkspaceService(db).AddUserToWorkspace(ws.ID, dave.ID)
+	assert.True(t, errors.Is(err, ErrSodViolation), "got %v", err)
+
+	var count int64
+	require.NoError(t, db.Model(&model.UserWorkspaceRole{}).Where("user_id = ?", dave.ID).Count(&count).Error)
+	assert.Zero(t, count)
+	var stored model.WorkspaceInvitation
+	require.NoError(t, db.First(&stored, invitation.ID).Error)
+	assert.Equal(t, model.InvitationStatusPendingApproval, stored.Status)
+}
+
+// TC-SOD-GROUP-01: 구성원이 충돌 역할을 가진 그룹에 플랫폼 역할 할당 → Keycloak 호출 전에 차단
+func TestSodAssignGroupPlatformRole_MemberConflictBlocked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "requester")
+	roleB := createGRTestRole(t, db, "approver")
+	createSodTestConstraint(t, svc, "request-approve", roleA, roleB)
+	group := createGRTestOrg(t, db, "approvers", "APR")
+	user := createGRTestUser(t, db, "dave", "kc-dave")
+	addSodTestMember(t, db, user.ID, group.ID)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: roleA.ID}).Error)
+	groupRoleService := &GroupRoleService{
+		db:            db,
+		groupRoleRepo: repository.NewGroupRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		kcService:     &mockKeycloakService{},
+		sodService:    svc,
+	}
+
+	err := groupRoleService.AssignGroupPlatformRole(context.Background(), group.ID, roleB.ID)
+	assert.True(t, errors.Is(err, ErrSodViolation))
+	var count int64
+	db.Model(&model.GroupPlatformRole{}).Where("group_id = ?", group.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+// TC-SOD-GROUP-02: 충돌 워크스페이스 역할을 가진 그룹 가입 → 차단, 소속이 추가되지 않음
+func TestSodAssignUserToGroups_MembershipConflictBlocked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "developer")
+	roleB := createGRTestRole(t, db, "deployer")
+	createSodTestConstraint(t, svc, "dev-deploy", roleA, roleB)
+	ws := createGRTestWorkspace(t, db, "ws-1")
+	group := createGRTestOrg(t, db, "deployers", "DEP")
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: group.ID, WorkspaceID: ws.ID, RoleID: roleB.ID}).Error)
+	user := createGRTestUser(t, db, "erin", "kc-erin")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: roleA.ID}).Error)
+	groupRoleService := &GroupRoleService{
+		db:            db,
+		groupRoleRepo: repository.NewGroupRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		kcService:     &mockKeycloakService{},
+		sodService:    svc,
+	}
+
+	err := groupRoleService.AssignUserToGroups(context.Background(), user.ID, []uint{group.ID}, "")
+	assert.True(t, errors.Is(err, ErrSodViolation))
+	var count int64
+	db.Model(&model.UserOrganization{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+// TC-SOD-GROUP-03: 그룹 교체 시 빠지는 그룹의 상속 역할은 제외하고 평가
+func TestSodCheckGroupMembership_ReplaceDropsOldGroups(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "requester")
+	roleB := createGRTestRole(t, db, "approver")
+	createSodTestConstraint(t, svc, "request-approve", roleA, roleB)
+	requesters := createGRTestOrg(t, db, "requesters", "REQ")
+	approvers := createGRTestOrg(t, db, "approvers", "APR")
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: requesters.ID, RoleID: roleA.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupPlatformRole{GroupID: approvers.ID, RoleID: roleB.ID}).Error)
+	user := createGRTestUser(t, db, "frank", "kc-frank")
+	addSodTestMember(t, db, user.ID, requesters.ID)
+
+	assert.True(t, errors.Is(svc.CheckGroupMembership(user.ID, []uint{approvers.ID}, false), ErrSodViolation))
+	assert.True(t, errors.Is(svc.CheckGroupMembership(user.ID, []uint{requesters.ID, approvers.ID}, true), ErrSodViolation))
+	assert.NoError(t, svc.CheckGroupMembership(user.ID, []uint{approvers.ID}, true))
+}
+
+// ── 예외 승인 ─────────────────────────────────────────────────────────────────
+
+// TC-SOD-OVR-01: 예외 승인 시 사유 필수, 승인 후 할당 허용, 철회 후 다시 차단
+func TestSodOverride_AllowsUntilRevoked(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "requester")
+	roleB := createGRTestRole(t, db, "approver")
+	roleC := createGRTestRole(t, db, "controller")
+	constraint := createSodTestConstraint(t, svc, "request-approve-control", roleA, roleB, roleC)
+	user := createGRTestUser(t, db, "grace", "kc-grace")
+	approver := createGRTestUser(t, db, "admin", "kc-admin")
+	roleService := NewRoleService(db)
+	require.NoError(t, roleService.AssignPlatformRole(user.ID, roleA.ID))
+
+	_, err := svc.CreateOverride(&model.CreateSodOverrideRequest{ConstraintID: constraint.ID, UserID: user.ID, Justification: "  "}, approver)
+	assert.True(t, errors.Is(err, ErrInvalidSodRequest))
+
+	past := time.Now().Add(-time.Hour)
+	_, err = svc.CreateOverride(&model.CreateSodOverrideRequest{ConstraintID: constraint.ID, UserID: user.ID, Justification: "x", ExpiresAt: &past}, approver)
+	assert.True(t, errors.Is(err, ErrInvalidSodRequest))
+
+	override, err := svc.CreateOverride(&model.CreateSodOverrideRequest{
+		ConstraintID: constraint.ID, UserID: user.ID, Justification: "small team, compensating monthly review",
+	}, approver)
+	require.NoError(t, err)
+	assert.Equal(t, "kc-admin", override.ApprovedByKcID)
+	require.NoError(t, roleService.AssignPlatformRole(user.ID, roleB.ID))
+
+	revoked, err := svc.RevokeOverride(override.ID, approver)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	assert.Equal(t, "kc-admin", revoked.RevokedByKcID)
+	_, err = svc.RevokeOverride(override.ID, approver)
+	assert.True(t, errors.Is(err, ErrSodOverrideInactive))
+
+	assert.True(t, errors.Is(roleService.AssignPlatformRole(user.ID, roleC.ID), ErrSodViolation))
+}
+
+// ── 위반 보고서 ───────────────────────────────────────────────────────────────
+
+// TC-SOD-RPT-01: 기존 할당의 위반을 보유 경로와 함께 보고, 예외 승인된 위반은 overrideId 표시
+func TestSodFindViolations(t *testing.T) {
+	svc, db := newTestSodService(t)
+	roleA := createGRTestRole(t, db, "developer")
+	roleB := createGRTestRole(t, db, "deployer")
+	ws := createGRTestWorkspace(t, db, "ws-1")
+	group := createGRTestOrg(t, db, "deployers", "DEP")
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: group.ID, WorkspaceID: ws.ID, RoleID: roleB.ID}).Error)
+
+	henry := createGRTestUser(t, db, "henry", "kc-henry")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: henry.ID, WorkspaceID: ws.ID, RoleID: roleA.ID}).Error)
+	addSodTestMember(t, db, henry.ID, group.ID)
+	ivy := createGRTestUser(t, db, "ivy", "kc-ivy")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: ivy.ID, RoleID: roleA.ID}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: ivy.ID, RoleID: roleB.ID}).Error)
+	jack := createGRTestUser(t, db, "jack", "kc-jack")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: jack.ID, WorkspaceID: ws.ID, RoleID: roleA.ID}).Error)
+
+	// 제약은 할당 이후에 추가 (기존 할당은 보고서로만 드러남)
+	constraint := createSodTestConstraint(t, svc, "dev-deploy", roleA, roleB)
+	override, err := svc.CreateOverride(&model.CreateSodOverrideRequest{ConstraintID: constraint.ID, UserID: ivy.ID, Justification: "on-call"}, nil)
+	require.NoError(t, err)
+
+	violations, err := svc.FindViolations(0)
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+
+	byUser := make(map[string]model.SodViolation)
+	for _, v := range violations {
+		byUser[v.Username] = v
+	}
+	h := byUser["henry"]
+	require.NotNil(t, h.WorkspaceID)
+	assert.Equal(t, ws.ID, *h.WorkspaceID)
+	assert.Nil(t, h.OverrideID)
+	sources := make(map[string]string)
+	for _, r := range h.Roles {
+		sources[r.RoleName] = r.Source
+	}
+	assert.Equal(t, map[string]string{"developer": "direct", "deployer": "group:deployers"}, sources)
+
+	i := byUser["ivy"]
+	assert.Nil(t, i.WorkspaceID)
+	require.NotNil(t, i.OverrideID)
+	assert.Equal(t, override.ID, *i.OverrideID)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	// Add strings import for error checking
 	// "github.com/Nerzal/gocloak/v13" // No longer needed directly
@@ -555,6 +556,10 @@ func (s *UserService) ActivateUser(ctx context.Context, userID uint) error {
 	if err := s.userRepo.UpdateStatus(userID, model.UserStatusActive); err != nil {
 		return fmt.Errorf("failed to update user status in db: %w", err)
 	}
+	// 휴면 정책으로 곧바로 다시 비활성화되지 않도록 재활성화 시점을 마지막 활동으로 기록
+	if err := s.userRepo.ResetDormancy(userID, time.Now()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/util"
+)
+
+// invitationWorkflowAction 초대 수락 후 워크스페이스 가입 (PENDING_APPROVAL 초대 승인/거절)
+type invitationWorkflowAction struct {
+	invitationService *WorkspaceInvitationService
+}
+
+func (a *invitationWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowInvitationPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	return a.invitationService.ApproveInvitation(payload.InvitationID)
+}
+
+func (a *invitationWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowInvitationPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	return a.invitationService.RejectInvitationByAdmin(payload.InvitationID)
+}
+
+// signupWorkflowAction 가입 승인 (Keycloak 사용자 활성화, 거절되면 비활성 상태 유지)
+type signupWorkflowAction struct {
+	userService *UserService
+}
+
+func (a *signupWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowUserPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	return a.userService.ApproveUser(ctx, payload.KcUserID)
+}
+
+func (a *signupWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	return nil
+}
+
+// withdrawalWorkflowAction 탈퇴 처리 (승인 없이 닫히면 탈퇴 신청 전 ACTIVE 로 복구)
+type withdrawalWorkflowAction struct {
+	userService *UserService
+	userRepo    *repository.UserRepository
+}
+
+func (a *withdrawalWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowUserPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	return a.userService.ProcessWithdrawal(ctx, payload.UserID)
+}
+
+func (a *withdrawalWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowUserPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	user, err := a.userRepo.FindUserByID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Status != model.UserStatusWithdrawalRequested {
+		return nil
+	}
+	return a.userRepo.UpdateStatus(user.ID, model.UserStatusActive)
+}
+
+// roleAssignmentWorkflowAction 플랫폼/워크스페이스 역할 할당 (플랫폼 역할은 Keycloak realm role 까지 부여)
+// 그룹 역할 할당과 그룹 구성원 추가(그룹 상속 역할)도 처리
+type roleAssignmentWorkflowAction struct {
+	roleService      *RoleService
+	groupRoleService *GroupRoleService
+	userRepo         *repository.UserRepository
+	kcService        KeycloakService
+}
+
+func (a *roleAssignmentWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowRoleAssignmentPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	validity := model.AssignmentValidity{ValidFrom: payload.ValidFrom, ExpiresAt: payload.ExpiresAt}
+	switch payload.RoleType {
+	case model.WorkflowRoleWorkspace:
+		return a.roleService.AssignWorkspaceRoleWithValidity(payload.UserID, payload.WorkspaceID, payload.RoleID, validity)
+	case model.WorkflowRolePlatform:
+		return a.assignPlatformRole(ctx, &payload, validity)
+	case model.WorkflowRoleGroupPlatform:
+		return a.groupRoleService.AssignGroupPlatformRoleWithValidity(ctx, payload.GroupID, payload.RoleID, validity)
+	case model.WorkflowRoleGroupWorkspace:
+		return a.groupRoleService.AssignGroupWorkspaceWithValidity(payload.GroupID, payload.WorkspaceID, payload.RoleID, validity)
+	case model.WorkflowRoleGroupMember:
+		return a.assignGroupMembers(ctx, &payload)
+	}
+	return fmt.Errorf("%w: unknown role type: %s", ErrInvalidWorkflowRequest, payload.RoleType)
+}
+
+// assignPlatformRole DB 할당 후 Keycloak realm role 부여 (Keycloak 실패 시 DB 할당 되돌림)
+func (a *roleAssignmentWorkflowAction) assignPlatformRole(ctx context.Context, payload *model.WorkflowRoleAssignmentPayload, validity model.AssignmentValidity) error {
+	assigned, err := a.roleService.IsAssignedPlatformRole(payload.UserID, payload.RoleID)
+	if err != nil {
+		return err
+	}
+	if assigned {
+		return fmt.Errorf("platform role %s is already assigned to user %d", payload.RoleName, payload.UserID)
+	}
+	user, err := a.userRepo.FindUserByID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := a.roleService.AssignPlatformRoleWithValidity(payload.UserID, payload.RoleID, validity); err != nil {
+		return err
+	}
+	// 시작 전이면 Keycloak 부여는 만료 처리기가 시작 시각에 수행
+	if validity.PendingAt(time.Now()) {
+		return nil
+	}
+	if err := a.grantRealmRole(ctx, user.KcId, payload.RoleName); err != nil {
+		if rollbackErr := a.roleService.RemovePlatformRole(payload.UserID, payload.RoleID); rollbackErr != nil {
+			log.Printf("[WORKFLOW] failed to roll back platform role assignment: %v", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// assignGroupMembers 사용자 한 명을 여러 그룹에, 또는 한 그룹에 여러 사용자를 추가 (Keycloak 그룹 동기화 포함)
+func (a *roleAssignmentWorkflowAction) assignGroupMembers(ctx context.Context, payload *model.WorkflowRoleAssignmentPayload) error {
+	if len(payload.GroupIDs) == 0 {
+		return a.groupRoleService.AssignUsersToGroup(ctx, payload.GroupID, payload.UserIDs)
+	}
+	user, err := a.userRepo.FindUserByID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	return a.groupRoleService.AssignUserToGroups(ctx, payload.UserID, payload.GroupIDs, user.KcId)
+}
+
+func (a *roleAssignmentWorkflowAction) grantRealmRole(ctx context.Context, kcUserID, roleName string) error {
+	exists, err := a.kcService.CheckRealmRoleExists(ctx, roleName)
+	if err != nil {
+		return fmt.Errorf("failed to check keycloak realm role: %w", err)
+	}
+	if !exists {
+		if err := a.kcService.CreateRealmRoleAndWait(ctx, roleName); err != nil {
+			return fmt.Errorf("failed to create keycloak realm role: %w", err)
+		}
+	}
+	if err := a.kcService.AssignRealmRoleToUser(ctx, kcUserID, roleName); err != nil {
+		return fmt.Errorf("failed to assign keycloak realm role: %w", err)
+	}
+	return nil
+}
+
+func (a *roleAssignmentWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	return nil
+}
+
+// cspRoleMappingWorkflowAction 역할-CSP 역할 매핑 추가/삭제
+type cspRoleMappingWorkflowAction struct {
+	roleService *RoleService
+}
+
+func (a *cspRoleMappingWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	var payload model.WorkflowCspRoleMappingPayload
+	if err := decodeWorkflowPayload(req, &payload); err != nil {
+		return err
+	}
+	roleID, err := util.StringToUint(payload.Mapping.RoleID)
+	if err != nil {
+		return fmt.Errorf("%w: roleId: %v", ErrInvalidWorkflowRequest, err)
+	}
+	cspRoleID, err := util.StringToUint(payload.Mapping.CspRoleID)
+	if err != nil {
+		return fmt.Errorf("%w: cspRoleId: %v", ErrInvalidWorkflowRequest, err)
+	}
+
+	switch payload.Operation {
+	case model.WorkflowCspMappingRemove:
+		return a.roleService.DeleteRoleCspRoleMapping(roleID, cspRoleID, payload.Mapping.AuthMethod)
+	case model.WorkflowCspMappingAdd:
+		cspRole, err := a.roleService.GetCspRoleByID(cspRoleID)
+		if err != nil {
+			return err
+		}
+		if cspRole == nil {
+			return fmt.Errorf("csp role %d not found", cspRoleID)
+		}
+		assigned, err := a.roleService.IsAssignedRole(0, roleID, constants.RoleTypeCSP)
+		if err != nil {
+			return err
+		}
+		if !assigned {
+			if err := a.roleService.AddRoleSub(roleID, &model.RoleSub{RoleID: roleID, RoleType: constants.RoleTypeCSP}); err != nil {
+				return err
+			}
+		}
+		return a.roleService.AddCspRolesMapping(&payload.Mapping)
+	}
+	return fmt.Errorf("%w: unknown csp role mapping operation: %s", ErrInvalidWorkflowRequest, payload.Operation)
+}
+
+func (a *cspRoleMappingWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	return nil
+}
@@ -0,0 +1,631 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidWorkflowRequest  = errors.New("invalid workflow request")
+	ErrInvalidWorkflowPolicy   = errors.New("invalid workflow policy")
+	ErrWorkflowUnsupportedType = errors.New("unsupported workflow request type")
+	ErrWorkflowAlreadyOpen     = errors.New("a workflow request for this subject is already pending")
+	ErrWorkflowNotPending      = errors.New("workflow request is not pending")
+	ErrWorkflowSelfApproval    = errors.New("approvers cannot decide on requests they made or that concern them")
+)
+
+// 승인 워크플로 권한
+const (
+	WorkflowManagePermission  = "mc-iam-manager:workflow:manage"  // 정책 관리, 모든 요청 결정/조회
+	WorkflowApprovePermission = "mc-iam-manager:workflow:approve" // workspace-admin, org-manager 승인자 규칙의 권한
+)
+
+const (
+	defaultWorkflowTimeoutInterval = time.Minute
+	workflowSystemActor            = "system"
+)
+
+// WorkflowAction 요청 유형별 실제 작업 (승인 시 적용, 승인 없이 닫히면 요청 전 상태로 되돌림)
+type WorkflowAction interface {
+	// Apply 필요한 승인을 모두 받았을 때 실행
+	Apply(ctx context.Context, req *model.WorkflowRequest) error
+	// Discard 거절/취소/기한 만료로 닫혔을 때 실행 (되돌릴 것이 없으면 nil)
+	Discard(ctx context.Context, req *model.WorkflowRequest) error
+}
+
+// WorkflowSubmission 워크플로 요청 생성 내용
+type WorkflowSubmission struct {
+	Type            string
+	Key             string // 같은 유형에서 대상을 구분하는 값 (대기 중 요청이 있으면 ErrWorkflowAlreadyOpen)
+	Summary         string
+	Payload         interface{}
+	Requester       *model.User
+	SubjectUserID   uint // 0 이면 대상 사용자 없음
+	SubjectUsername string
+	WorkspaceID     uint // 0 이면 워크스페이스 범위 아님
+}
+
+// WorkflowService 승인 워크플로 (정책, 요청, N-of-M 승인, 의견, 기한 상향/만료, 승인 시 작업 실행)
+// 유형별 정책이 켜진 경우에만 각 기능이 작업을 바로 실행하지 않고 요청을 만든다.
+type WorkflowService struct {
+	workflowRepo *repository.WorkflowRepository
+	authzService *AuthzService
+	auditService *AuditService // nil 이면 기한 상향/만료 감사 기록하지 않음
+	actions      map[string]WorkflowAction
+}
+
+// NewWorkflowService WorkflowService 생성 (기본 유형의 작업 등록)
+func NewWorkflowService(db *gorm.DB) *WorkflowService {
+	s := &WorkflowService{
+		workflowRepo: repository.NewWorkflowRepository(db),
+		authzService: NewAuthzService(db),
+		auditService: NewAuditService(db),
+	}
+	s.RegisterAction(model.WorkflowTypeWorkspaceInvitation, &invitationWorkflowAction{invitationService: NewWorkspaceInvitationService(db)})
+	s.RegisterAction(model.WorkflowTypeUserSignup, &signupWorkflowAction{userService: NewUserService(db)})
+	s.RegisterAction(model.WorkflowTypeUserWithdrawal, &withdrawalWorkflowAction{
+		userService: NewUserService(db),
+		userRepo:    repository.NewUserRepository(db),
+	})
+	s.RegisterAction(model.WorkflowTypeRoleAssignment, &roleAssignmentWorkflowAction{
+		roleService:      NewRoleService(db),
+		groupRoleService: NewGroupRoleService(db),
+		userRepo:         repository.NewUserRepository(db),
+		kcService:        NewKeycloakService(),
+	})
+	s.RegisterAction(model.WorkflowTypeCspRoleMapping, &cspRoleMappingWorkflowAction{roleService: NewRoleService(db)})
+	return s
+}
+
+// RegisterAction 요청 유형의 작업 등록 (같은 유형은 교체)
+func (s *WorkflowService) RegisterAction(requestType string, action WorkflowAction) {
+	if s.actions == nil {
+		s.actions = make(map[string]WorkflowAction)
+	}
+	s.actions[requestType] = action
+}
+
+// ResolveUser 요청자의 Keycloak ID 로 DB 사용자 조회
+func (s *WorkflowService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
+
+// Policy 요청 유형의 정책 (저장된 정책이 없으면 비활성 기본값)
+func (s *WorkflowService) Policy(requestType string) (*model.WorkflowPolicy, error) {
+	if _, ok := s.actions[requestType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWorkflowUnsupportedType, requestType)
+	}
+	policy, err := s.workflowRepo.FindPolicy(requestType)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &model.WorkflowPolicy{Type: requestType, RequiredApprovals: 1}
+	}
+	return policy, nil
+}
+
+// ListPolicies 지원하는 모든 유형의 정책
+func (s *WorkflowService) ListPolicies() ([]model.WorkflowPolicy, error) {
+	policies := make([]model.WorkflowPolicy, 0, len(model.WorkflowTypes))
+	for _, requestType := range model.WorkflowTypes {
+		if _, ok := s.actions[requestType]; !ok {
+			continue
+		}
+		policy, err := s.Policy(requestType)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+	return policies, nil
+}
+
+// UpdatePolicy 요청 유형의 정책 저장
+// 이미 대기 중인 요청의 필요 승인 수와 기한은 바뀌지 않고, 승인자 규칙은 바로 적용된다.
+func (s *WorkflowService) UpdatePolicy(requestType string, req *model.WorkflowPolicy, actor *model.User) (*model.WorkflowPolicy, error) {
+	if _, ok := s.actions[requestType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWorkflowUnsupportedType, requestType)
+	}
+	if req.RequiredApprovals == 0 {
+		req.RequiredApprovals = 1
+	}
+	if req.RequiredApprovals < 0 {
+		return nil, fmt.Errorf("%w: requiredApprovals must be at least 1", ErrInvalidWorkflowPolicy)
+	}
+	if req.TimeoutMinutes < 0 {
+		return nil, fmt.Errorf("%w: timeoutMinutes must not be negative", ErrInvalidWorkflowPolicy)
+	}
+	if err := validateWorkflowApproverRules(req.ApproverRules); err != nil {
+		return nil, err
+	}
+	if err := validateWorkflowApproverRules(req.EscalationRules); err != nil {
+		return nil, err
+	}
+	if len(req.EscalationRules) > 0 && req.TimeoutMinutes == 0 {
+		return nil, fmt.Errorf("%w: escalationRules require timeoutMinutes", ErrInvalidWorkflowPolicy)
+	}
+	req.Type = requestType
+	if actor != nil {
+		req.UpdatedBy = actor.Username
+	}
+	if err := s.workflowRepo.SavePolicy(req); err != nil {
+		return nil, err
+	}
+	return s.Policy(requestType)
+}
+
+// validateWorkflowApproverRules 승인자 규칙 종류 확인 (platform-role 은 역할 이름 필수)
+func validateWorkflowApproverRules(rules []model.WorkflowApproverRule) error {
+	for _, rule := range rules {
+		switch rule.Kind {
+		case model.WorkflowApproverPlatformRole:
+			if strings.TrimSpace(rule.RoleName) == "" {
+				return fmt.Errorf("%w: roleName is required for platform-role approvers", ErrInvalidWorkflowPolicy)
+			}
+		case model.WorkflowApproverWorkspaceAdmin, model.WorkflowApproverOrgManager:
+		default:
+			return fmt.Errorf("%w: unknown approver kind: %s", ErrInvalidWorkflowPolicy, rule.Kind)
+		}
+	}
+	return nil
+}
+
+// Required 요청 유형에 승인 절차가 켜져 있는지
+func (s *WorkflowService) Required(requestType string) (bool, error) {
+	if _, ok := s.actions[requestType]; !ok {
+		return false, nil
+	}
+	policy, err := s.workflowRepo.FindPolicy(requestType)
+	if err != nil {
+		return false, err
+	}
+	return policy != nil && policy.Enabled, nil
+}
+
+// Submit 승인 요청 생성 (필요 승인 수와 기한은 현재 정책 값으로 고정)
+func (s *WorkflowService) Submit(sub *WorkflowSubmission) (*model.WorkflowRequest, error) {
+	if sub.Requester == nil {
+		return nil, fmt.Errorf("%w: requester is required", ErrInvalidWorkflowRequest)
+	}
+	if sub.Key == "" {
+		return nil, fmt.Errorf("%w: key is required", ErrInvalidWorkflowRequest)
+	}
+	policy, err := s.Policy(sub.Type)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.workflowRepo.ExistsPending(sub.Type, sub.Key)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, ErrWorkflowAlreadyOpen
+	}
+	payload, err := json.Marshal(sub.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidWorkflowRequest, err)
+	}
+
+	request := &model.WorkflowRequest{
+		Type:              sub.Type,
+		Key:               sub.Key,
+		Status:            model.WorkflowPending,
+		Summary:           sub.Summary,
+		Payload:           payload,
+		RequesterID:       sub.Requester.ID,
+		RequesterUsername: sub.Requester.Username,
+		SubjectUsername:   sub.SubjectUsername,
+		RequiredApprovals: policy.RequiredApprovals,
+	}
+	if sub.SubjectUserID != 0 {
+		subjectUserID := sub.SubjectUserID
+		request.SubjectUserID = &subjectUserID
+	}
+	if sub.WorkspaceID != 0 {
+		workspaceID := sub.WorkspaceID
+		request.WorkspaceID = &workspaceID
+	}
+	if policy.TimeoutMinutes > 0 {
+		dueAt := time.Now().Add(time.Duration(policy.TimeoutMinutes) * time.Minute)
+		request.DueAt = &dueAt
+	}
+	if err := s.workflowRepo.Create(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// List 요청 목록 (관리자 조회)
+func (s *WorkflowService) List(filter model.WorkflowFilter) ([]model.WorkflowRequest, error) {
+	return s.workflowRepo.List(filter)
+}
+
+// ListMine 요청자 본인의 요청 (status 가 비어 있으면 전체)
+func (s *WorkflowService) ListMine(requester *model.User, status string) ([]model.WorkflowRequest, error) {
+	return s.workflowRepo.List(model.WorkflowFilter{RequesterID: requester.ID, Status: status})
+}
+
+// Get 요청 조회 (요청자, 대상 사용자, 승인자, 워크플로 관리자만 조회 가능)
+func (s *WorkflowService) Get(ctx context.Context, id uint, viewer *model.User) (*model.WorkflowRequest, error) {
+	request, err := s.workflowRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeViewer(ctx, viewer, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ListApprovable 승인자가 결정할 수 있는 대기 중 요청 (본인 관련 요청, 이미 결정한 요청 제외)
+func (s *WorkflowService) ListApprovable(ctx context.Context, approver *model.User) ([]model.WorkflowRequest, error) {
+	pending, err := s.workflowRepo.List(model.WorkflowFilter{Status: model.WorkflowPending})
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]*model.WorkflowPolicy)
+	approvable := make([]model.WorkflowRequest, 0)
+	for i := range pending {
+		policy, ok := policies[pending[i].Type]
+		if !ok {
+			if policy, err = s.Policy(pending[i].Type); err != nil {
+				return nil, err
+			}
+			policies[pending[i].Type] = policy
+		}
+		if err := s.authorizeApprover(ctx, approver, &pending[i], policy); err != nil {
+			if errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrWorkflowSelfApproval) {
+				continue
+			}
+			return nil, err
+		}
+		request, err := s.workflowRepo.FindByID(pending[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if !workflowDecidedBy(request, approver.ID) {
+			approvable = append(approvable, *request)
+		}
+	}
+	return approvable, nil
+}
+
+func workflowDecidedBy(request *model.WorkflowRequest, userID uint) bool {
+	for _, decision := range request.Decisions {
+		if decision.ApproverID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Decide 승인/거절. 거절은 즉시 요청을 닫고, 승인은 필요한 승인 수를 채우면 유형별 작업을 실행한다.
+// 작업 실행이 실패하면 요청은 failed 로 남고 resultError 에 원인을 기록한다.
+func (s *WorkflowService) Decide(ctx context.Context, id uint, req *model.WorkflowDecisionRequest, approver *model.User) (*model.WorkflowRequest, error) {
+	if req.Decision != model.WorkflowDecisionApprove && req.Decision != model.WorkflowDecisionReject {
+		return nil, fmt.Errorf("%w: decision must be approve or reject: %s", ErrInvalidWorkflowRequest, req.Decision)
+	}
+	request, err := s.workflowRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != model.WorkflowPending {
+		return nil, ErrWorkflowNotPending
+	}
+	policy, err := s.Policy(request.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeApprover(ctx, approver, request, policy); err != nil {
+		return nil, err
+	}
+	decision := &model.WorkflowDecision{
+		RequestID:        request.ID,
+		ApproverID:       approver.ID,
+		ApproverUsername: approver.Username,
+		Decision:         req.Decision,
+		Comment:          strings.TrimSpace(req.Comment),
+	}
+	if err := s.workflowRepo.AddDecision(decision); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.Decision == model.WorkflowDecisionReject {
+		if err := s.close(ctx, request, model.WorkflowRejected, now); err != nil {
+			return nil, err
+		}
+		return s.workflowRepo.FindByID(id)
+	}
+
+	approvals, err := s.workflowRepo.CountApprovals(request.ID)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"approvals": approvals}
+	if approvals < request.RequiredApprovals {
+		if err := s.transition(id, model.WorkflowPending, updates); err != nil {
+			return nil, err
+		}
+		return s.workflowRepo.FindByID(id)
+	}
+
+	updates["status"] = model.WorkflowApproved
+	updates["decided_at"] = now
+	if err := s.transition(id, model.WorkflowPending, updates); err != nil {
+		return nil, err
+	}
+	if err := s.actions[request.Type].Apply(ctx, request); err != nil {
+		log.Printf("[WORKFLOW] request %d (%s): failed to apply: %v", id, request.Type, err)
+		if _, updErr := s.workflowRepo.Transition(id, model.WorkflowApproved, map[string]interface{}{
+			"status":       model.WorkflowFailed,
+			"result_error": err.Error(),
+		}); updErr != nil {
+			return nil, updErr
+		}
+	}
+	return s.workflowRepo.FindByID(id)
+}
+
+// Cancel 요청자 본인의 대기 중 요청 취소
+func (s *WorkflowService) Cancel(ctx context.Context, id uint, requester *model.User) (*model.WorkflowRequest, error) {
+	request, err := s.workflowRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequesterID != requester.ID {
+		return nil, repository.ErrWorkflowRequestNotFound
+	}
+	if request.Status != model.WorkflowPending {
+		return nil, ErrWorkflowNotPending
+	}
+	if err := s.close(ctx, request, model.WorkflowCancelled, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.workflowRepo.FindByID(id)
+}
+
+// AddComment 요청에 의견 작성 (요청을 조회할 수 있는 사용자)
+func (s *WorkflowService) AddComment(ctx context.Context, id uint, req *model.WorkflowCommentRequest, author *model.User) (*model.WorkflowRequest, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidWorkflowRequest)
+	}
+	request, err := s.Get(ctx, id, author)
+	if err != nil {
+		return nil, err
+	}
+	comment := &model.WorkflowComment{
+		RequestID:      request.ID,
+		AuthorID:       author.ID,
+		AuthorUsername: author.Username,
+		Body:           body,
+	}
+	if err := s.workflowRepo.AddComment(comment); err != nil {
+		return nil, err
+	}
+	return s.workflowRepo.FindByID(id)
+}
+
+// close 대기 중 요청을 status(rejected/cancelled/expired)로 닫고 유형별 되돌림 실행
+// 되돌림이 실패해도 요청은 닫힌 상태로 두고 resultError 에 원인을 기록한다.
+func (s *WorkflowService) close(ctx context.Context, request *model.WorkflowRequest, status string, now time.Time) error {
+	if err := s.transition(request.ID, model.WorkflowPending, map[string]interface{}{
+		"status":     status,
+		"decided_at": now,
+	}); err != nil {
+		return err
+	}
+	if err := s.actions[request.Type].Discard(ctx, request); err != nil {
+		log.Printf("[WORKFLOW] request %d (%s): failed to discard: %v", request.ID, request.Type, err)
+		if _, updErr := s.workflowRepo.Transition(request.ID, status, map[string]interface{}{"result_error": err.Error()}); updErr != nil {
+			return updErr
+		}
+	}
+	return nil
+}
+
+// transition 상태 전이, 이미 다른 상태로 바뀌었으면 ErrWorkflowNotPending
+func (s *WorkflowService) transition(id uint, from string, updates map[string]interface{}) error {
+	ok, err := s.workflowRepo.Transition(id, from, updates)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWorkflowNotPending
+	}
+	return nil
+}
+
+// ProcessTimeouts 승인 기한이 지난 요청 처리
+// 상향 승인자가 정의되어 있고 아직 상향 전이면 상향 승인자를 추가하고 기한을 다시 설정하며, 그 외에는 expired 로 닫는다.
+func (s *WorkflowService) ProcessTimeouts(ctx context.Context, now time.Time) ([]model.WorkflowRequest, error) {
+	due, err := s.workflowRepo.ListDue(now)
+	if err != nil {
+		return nil, err
+	}
+	var handled []model.WorkflowRequest
+	for i := range due {
+		request := &due[i]
+		policy, err := s.Policy(request.Type)
+		if err != nil {
+			log.Printf("[WORKFLOW] request %d: %v", request.ID, err)
+			continue
+		}
+		action := model.AuditActionWorkflowExpire
+		if request.EscalationLevel == 0 && len(policy.EscalationRules) > 0 && policy.TimeoutMinutes > 0 {
+			action = model.AuditActionWorkflowEscalate
+			err = s.transition(request.ID, model.WorkflowPending, map[string]interface{}{
+				"escalation_level": request.EscalationLevel + 1,
+				"due_at":           now.Add(time.Duration(policy.TimeoutMinutes) * time.Minute),
+			})
+		} else {
+			err = s.close(ctx, request, model.WorkflowExpired, now)
+		}
+		if err != nil {
+			if !errors.Is(err, ErrWorkflowNotPending) {
+				log.Printf("[WORKFLOW] request %d: failed to process timeout: %v", request.ID, err)
+			}
+			continue
+		}
+		result, err := s.workflowRepo.FindByID(request.ID)
+		if err != nil {
+			log.Printf("[WORKFLOW] request %d: %v", request.ID, err)
+			continue
+		}
+		handled = append(handled, *result)
+		s.recordTimeout(action, result)
+	}
+	return handled, nil
+}
+
+// recordTimeout 기한 상향/만료 감사 이벤트 (요청이 없으므로 시스템 행위자로 기록)
+func (s *WorkflowService) recordTimeout(action string, request *model.WorkflowRequest) {
+	if s.auditService == nil {
+		return
+	}
+	event := &model.AuditEvent{
+		ActorUsername: workflowSystemActor,
+		Method:        "SYSTEM",
+		Route:         "workflow.timeout",
+		Path:          fmt.Sprintf("/api/workflows/%d", request.ID),
+		StatusCode:    200,
+	}
+	detail := &model.AuditDetail{
+		Action:      action,
+		EntityType:  model.AuditEntityWorkflow,
+		EntityID:    fmt.Sprint(request.ID),
+		WorkspaceID: request.WorkspaceID,
+		After:       request,
+	}
+	if err := s.auditService.Record(event, detail); err != nil {
+		log.Printf("[WORKFLOW] failed to record audit event for request %d: %v", request.ID, err)
+	}
+}
+
+// StartTimeouts 주기적으로 승인 기한이 지난 요청 처리
+// 주기는 MC_IAM_MANAGER_WORKFLOW_TIMEOUT_INTERVAL (기본 1m)
+func (s *WorkflowService) StartTimeouts(ctx context.Context) {
+	interval := dormancyEnvDuration("MC_IAM_MANAGER_WORKFLOW_TIMEOUT_INTERVAL", defaultWorkflowTimeoutInterval)
+	log.Printf("[WORKFLOW] checking approval timeouts every %s", interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handled, err := s.ProcessTimeouts(ctx, time.Now())
+				if err != nil {
+					log.Printf("[WORKFLOW] failed to process timeouts: %v", err)
+				}
+				for _, request := range handled {
+					log.Printf("[WORKFLOW] request %d (%s) timed out: status=%s escalationLevel=%d",
+						request.ID, request.Type, request.Status, request.EscalationLevel)
+				}
+			}
+		}
+	}()
+}
+
+// authorizeViewer 요청자, 대상 사용자, 승인자, 워크플로 관리자만 요청을 볼 수 있음
+func (s *WorkflowService) authorizeViewer(ctx context.Context, viewer *model.User, request *model.WorkflowRequest) error {
+	if viewer == nil {
+		return ErrPermissionDenied
+	}
+	if request.RequesterID == viewer.ID || (request.SubjectUserID != nil && *request.SubjectUserID == viewer.ID) {
+		return nil
+	}
+	policy, err := s.Policy(request.Type)
+	if err != nil {
+		return err
+	}
+	return s.authorizeApprover(ctx, viewer, request, policy)
+}
+
+// authorizeApprover 승인자가 요청을 결정할 수 있는지 확인
+// 요청자와 대상 사용자는 결정할 수 없고, 워크플로 관리자이거나 정책의 승인자 규칙(상향 후에는 상향 규칙 포함) 중 하나를 만족해야 한다.
+func (s *WorkflowService) authorizeApprover(ctx context.Context, approver *model.User, request *model.WorkflowRequest, policy *model.WorkflowPolicy) error {
+	if approver == nil {
+		return ErrPermissionDenied
+	}
+	if request.RequesterID == approver.ID || (request.SubjectUserID != nil && *request.SubjectUserID == approver.ID) {
+		return ErrWorkflowSelfApproval
+	}
+	manager, err := s.authzService.HasPermission(ctx, approver.ID, 0, WorkflowManagePermission)
+	if err != nil {
+		return err
+	}
+	if manager {
+		return nil
+	}
+	rules := append([]model.WorkflowApproverRule{}, policy.ApproverRules...)
+	if request.EscalationLevel > 0 {
+		rules = append(rules, policy.EscalationRules...)
+	}
+	for _, rule := range rules {
+		ok, err := s.matchesApproverRule(ctx, approver, request, rule)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return ErrPermissionDenied
+}
+
+// matchesApproverRule 승인자가 규칙 하나를 만족하는지
+func (s *WorkflowService) matchesApproverRule(ctx context.Context, approver *model.User, request *model.WorkflowRequest, rule model.WorkflowApproverRule) (bool, error) {
+	switch rule.Kind {
+	case model.WorkflowApproverPlatformRole:
+		grants, err := s.authzService.GetRoleGrants(ctx, approver.ID, 0)
+		if err != nil {
+			return false, err
+		}
+		for _, grant := range grants {
+			if grant.RoleType == constants.RoleTypePlatform && strings.EqualFold(grant.RoleName, rule.RoleName) {
+				return true, nil
+			}
+		}
+	case model.WorkflowApproverWorkspaceAdmin:
+		if request.WorkspaceID == nil {
+			return false, nil
+		}
+		grants, err := s.authzService.GetWorkspaceRoleGrants(ctx, approver.ID, *request.WorkspaceID)
+		if err != nil || len(grants) == 0 {
+			return false, err
+		}
+		return s.authzService.HasPermission(ctx, approver.ID, *request.WorkspaceID, WorkflowApprovePermission)
+	case model.WorkflowApproverOrgManager:
+		if request.SubjectUserID == nil {
+			return false, nil
+		}
+		shared, err := s.workflowRepo.SharesOrganization(approver.ID, *request.SubjectUserID)
+		if err != nil || !shared {
+			return false, err
+		}
+		return s.authzService.HasPermission(ctx, approver.ID, 0, WorkflowApprovePermission)
+	}
+	return false, nil
+}
+
+// decodeWorkflowPayload 요청 내용을 유형별 구조체로 변환
+func decodeWorkflowPayload(request *model.WorkflowRequest, payload interface{}) error {
+	if err := json.Unmarshal(request.Payload, payload); err != nil {
+		return fmt.Errorf("%w: payload of request %d: %v", ErrInvalidWorkflowRequest, request.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,486 @@
+package service
+
+// workflow_service_test.go
+//
+// WorkflowService 단위 테스트 (SQLite in-memory DB)
+// 정책 검증, N-of-M 승인, 승인자 규칙, 자기 승인 차단, 거절/취소 시 되돌림, 기한 상향/만료, 초대·역할(그룹 역할 포함) 할당 작업 실행을 검증한다.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ── 헬퍼 ──────────────────────────────────────────────────────────────────────
+
+const testWorkflowType = model.WorkflowTypeUserSignup
+
+// fakeWorkflowAction 실행 횟수만 기록하는 작업
+type fakeWorkflowAction struct {
+	applied   int
+	discarded int
+	applyErr  error
+}
+
+func (a *fakeWorkflowAction) Apply(ctx context.Context, req *model.WorkflowRequest) error {
+	a.applied++
+	return a.applyErr
+}
+
+func (a *fakeWorkflowAction) Discard(ctx context.Context, req *model.WorkflowRequest) error {
+	a.discarded++
+	return nil
+}
+
+func newTestWorkflowService(t *testing.T) (*WorkflowService, *gorm.DB, *fakeWorkflowAction) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.WorkspaceInvitation{},
+		&model.WorkflowPolicy{},
+		&model.WorkflowRequest{},
+		&model.WorkflowDecision{},
+		&model.WorkflowComment{},
+	))
+	svc := &WorkflowService{
+		workflowRepo: repository.NewWorkflowRepository(db),
+		authzService: newTestAuthz(db),
+	}
+	action := &fakeWorkflowAction{}
+	svc.RegisterAction(testWorkflowType, action)
+	return svc, db, action
+}
+
+// workflowFixture 요청자(관리자), 대상 사용자, 플랫폼 역할 승인자 2명, 워크플로 관리자, 외부인
+type workflowFixture struct {
+	ws        *model.Workspace
+	requester *model.User
+	subject   *model.User
+	approver1 *model.User // wf-reviewer 플랫폼 역할
+	approver2 *model.User // wf-reviewer 플랫폼 역할
+	manager   *model.User // 워크플로 관리 권한
+	outsider  *model.User
+}
+
+func setupWorkflowFixture(t *testing.T, db *gorm.DB) *workflowFixture {
+	t.Helper()
+	f := &workflowFixture{
+		ws:        createGRTestWorkspace(t, db, "wf-ws"),
+		requester: createGRTestUser(t, db, "wf-admin", "kc-wf-admin"),
+		subject:   createGRTestUser(t, db, "wf-alice", "kc-wf-alice"),
+		approver1: createGRTestUser(t, db, "wf-approver1", "kc-wf-approver1"),
+		approver2: createGRTestUser(t, db, "wf-approver2", "kc-wf-approver2"),
+		manager:   createGRTestUser(t, db, "wf-manager", "kc-wf-manager"),
+		outsider:  createGRTestUser(t, db, "wf-bob", "kc-wf-bob"),
+	}
+	reviewer := createGRTestRole(t, db, "wf-reviewer")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.approver1.ID, RoleID: reviewer.ID}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.approver2.ID, RoleID: reviewer.ID}).Error)
+
+	assignAuthzTestPlatformRole(t, db, f.manager, "wf-manager-role", WorkflowManagePermission)
+	return f
+}
+
+func enableTestWorkflow(t *testing.T, svc *WorkflowService, requestType string, policy model.WorkflowPolicy) {
+	t.Helper()
+	policy.Enabled = true
+	_, err := svc.UpdatePolicy(requestType, &policy, nil)
+	require.NoError(t, err)
+}
+
+func submitTestWorkflow(t *testing.T, svc *WorkflowService, f *workflowFixture) *model.WorkflowRequest {
+	t.Helper()
+	request, err := svc.Submit(&WorkflowSubmission{
+		Type:            testWorkflowType,
+		Key:             "user:1",
+		Summary:         "approve signup of wf-alice",
+		Payload:         model.WorkflowUserPayload{UserID: f.subject.ID, KcUserID: f.subject.KcId},
+		Requester:       f.requester,
+		SubjectUserID:   f.subject.ID,
+		SubjectUsername: f.subject.Username,
+	})
+	require.NoError(t, err)
+	return request
+}
+
+var reviewerRule = model.WorkflowApproverRule{Kind: model.WorkflowApproverPlatformRole, RoleName: "wf-reviewer"}
+
+// ── 정책 ──────────────────────────────────────────────────────────────────────
+
+// TC-WF-POLICY-01: 저장된 정책이 없으면 비활성, 잘못된 규칙/기한 거부, 저장 후 Required
+func TestWorkflowPolicy_DefaultsAndValidation(t *testing.T) {
+	svc, _, _ := newTestWorkflowService(t)
+
+	policy, err := svc.Policy(testWorkflowType)
+	require.NoError(t, err)
+	assert.False(t, policy.Enabled)
+	assert.Equal(t, 1, policy.RequiredApprovals)
+	required, err := svc.Required(testWorkflowType)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	_, err = svc.Policy("unknown-type")
+	assert.True(t, errors.Is(err, ErrWorkflowUnsupportedType))
+
+	invalid := []model.WorkflowPolicy{
+		{ApproverRules: []model.WorkflowApproverRule{{Kind: "team-lead"}}},
+		{ApproverRules: []model.WorkflowApproverRule{{Kind: model.WorkflowApproverPlatformRole}}},
+		{RequiredApprovals: -1},
+		{EscalationRules: []model.WorkflowApproverRule{reviewerRule}},
+	}
+	for i := range invalid {
+		_, err := svc.UpdatePolicy(testWorkflowType, &invalid[i], nil)
+		assert.True(t, errors.Is(err, ErrInvalidWorkflowPolicy), "case %d: %v", i, err)
+	}
+
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{ApproverRules: []model.WorkflowApproverRule{reviewerRule}})
+	required, err = svc.Required(testWorkflowType)
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	policies, err := svc.ListPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 1, "등록된 작업이 있는 유형만")
+	assert.Equal(t, 1, policies[0].RequiredApprovals)
+}
+
+// ── 결정 ──────────────────────────────────────────────────────────────────────
+
+// TC-WF-DECIDE-01: 2-of-M → 첫 승인은 대기 유지, 같은 승인자 재결정 거부, 두 번째 승인에 작업 1회 실행
+func TestWorkflowDecide_NOfM(t *testing.T) {
+	svc, db, action := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{
+		ApproverRules:     []model.WorkflowApproverRule{reviewerRule},
+		RequiredApprovals: 2,
+	})
+	request := submitTestWorkflow(t, svc, f)
+	assert.Equal(t, 2, request.RequiredApprovals)
+
+	_, err := svc.Submit(&WorkflowSubmission{Type: testWorkflowType, Key: "user:1", Requester: f.requester})
+	assert.True(t, errors.Is(err, ErrWorkflowAlreadyOpen))
+
+	ctx := context.Background()
+	approve := &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove, Comment: "verified employee id"}
+	result, err := svc.Decide(ctx, request.ID, approve, f.approver1)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowPending, result.Status)
+	assert.Equal(t, 1, result.Approvals)
+	assert.Equal(t, 0, action.applied)
+
+	_, err = svc.Decide(ctx, request.ID, approve, f.approver1)
+	assert.True(t, errors.Is(err, repository.ErrWorkflowDecisionExists))
+
+	approvable, err := svc.ListApprovable(ctx, f.approver1)
+	require.NoError(t, err)
+	assert.Empty(t, approvable, "이미 결정한 요청은 제외")
+	approvable, err = svc.ListApprovable(ctx, f.approver2)
+	require.NoError(t, err)
+	require.Len(t, approvable, 1)
+
+	result, err = svc.Decide(ctx, request.ID, approve, f.approver2)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowApproved, result.Status)
+	assert.Equal(t, 2, result.Approvals)
+	assert.NotNil(t, result.DecidedAt)
+	require.Len(t, result.Decisions, 2)
+	assert.Equal(t, "verified employee id", result.Decisions[0].Comment)
+	assert.Equal(t, 1, action.applied)
+
+	_, err = svc.Decide(ctx, request.ID, approve, f.manager)
+	assert.True(t, errors.Is(err, ErrWorkflowNotPending))
+}
+
+// TC-WF-DECIDE-02: 요청자/대상자 결정 불가, 규칙 밖 사용자 거부, 거절 시 되돌림 실행
+func TestWorkflowDecide_ApproverScopeAndReject(t *testing.T) {
+	svc, db, action := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{ApproverRules: []model.WorkflowApproverRule{reviewerRule}})
+	request := submitTestWorkflow(t, svc, f)
+
+	ctx := context.Background()
+	approve := &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove}
+	_, err := svc.Decide(ctx, request.ID, approve, f.requester)
+	assert.True(t, errors.Is(err, ErrWorkflowSelfApproval))
+	_, err = svc.Decide(ctx, request.ID, approve, f.subject)
+	assert.True(t, errors.Is(err, ErrWorkflowSelfApproval))
+	_, err = svc.Decide(ctx, request.ID, approve, f.outsider)
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+	_, err = svc.Get(ctx, request.ID, f.outsider)
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+
+	visible, err := svc.Get(ctx, request.ID, f.subject)
+	require.NoError(t, err)
+	assert.Equal(t, request.ID, visible.ID)
+
+	result, err := svc.Decide(ctx, request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionReject, Comment: "unknown applicant"}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowRejected, result.Status)
+	assert.Equal(t, 0, action.applied)
+	assert.Equal(t, 1, action.discarded)
+}
+
+// TC-WF-DECIDE-03: 작업 실행 실패 → failed, resultError 기록
+func TestWorkflowDecide_ApplyFailure(t *testing.T) {
+	svc, db, action := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{})
+	action.applyErr = errors.New("keycloak unavailable")
+	request := submitTestWorkflow(t, svc, f)
+
+	result, err := svc.Decide(context.Background(), request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowFailed, result.Status)
+	assert.Equal(t, "keycloak unavailable", result.ResultError)
+}
+
+// TC-WF-RULE-01: workspace-admin 은 요청 워크스페이스의 승인 권한 구성원, org-manager 는 대상자와 같은 조직의 승인 권한 보유자
+func TestWorkflowApproverRules_WorkspaceAdminAndOrgManager(t *testing.T) {
+	svc, db, _ := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{ApproverRules: []model.WorkflowApproverRule{
+		{Kind: model.WorkflowApproverWorkspaceAdmin},
+		{Kind: model.WorkflowApproverOrgManager},
+	}})
+
+	wsAdmin := createGRTestUser(t, db, "wf-ws-admin", "kc-wf-ws-admin")
+	lead := createGRTestRole(t, db, "wf-lead")
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: wsAdmin.ID, WorkspaceID: f.ws.ID, RoleID: lead.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypeWorkspace, lead.ID, WorkflowApprovePermission)
+
+	orgManager := createGRTestUser(t, db, "wf-org-manager", "kc-wf-org-manager")
+	org := createGRTestOrg(t, db, "wf-org", "WFORG")
+	otherOrg := createGRTestOrg(t, db, "wf-other-org", "WFOTHER")
+	managerRole := createGRTestRole(t, db, "wf-org-approver")
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: orgManager.ID, RoleID: managerRole.ID}).Error)
+	grantAuthzTestPermission(t, db, constants.RoleTypePlatform, managerRole.ID, WorkflowApprovePermission)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: orgManager.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.subject.ID, OrganizationID: otherOrg.ID}).Error)
+
+	// 워크스페이스 범위가 없는 요청: workspace-admin 규칙은 해당 없음
+	request := submitTestWorkflow(t, svc, f)
+	ctx := context.Background()
+	policy, err := svc.Policy(testWorkflowType)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(svc.authorizeApprover(ctx, wsAdmin, request, policy), ErrPermissionDenied))
+	assert.True(t, errors.Is(svc.authorizeApprover(ctx, orgManager, request, policy), ErrPermissionDenied), "다른 조직")
+
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.subject.ID, OrganizationID: org.ID}).Error)
+	assert.NoError(t, svc.authorizeApprover(ctx, orgManager, request, policy))
+
+	wsID := f.ws.ID
+	request.WorkspaceID = &wsID
+	assert.NoError(t, svc.authorizeApprover(ctx, wsAdmin, request, policy))
+}
+
+// ── 취소/기한 ─────────────────────────────────────────────────────────────────
+
+// TC-WF-CANCEL-01: 다른 사용자의 요청은 없는 것으로, 요청자 취소 시 되돌림 실행
+func TestWorkflowCancel(t *testing.T) {
+	svc, db, action := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{})
+	request := submitTestWorkflow(t, svc, f)
+
+	ctx := context.Background()
+	_, err := svc.Cancel(ctx, request.ID, f.outsider)
+	assert.True(t, errors.Is(err, repository.ErrWorkflowRequestNotFound))
+
+	commented, err := svc.AddComment(ctx, request.ID, &model.WorkflowCommentRequest{Body: "duplicate of #12"}, f.requester)
+	require.NoError(t, err)
+	require.Len(t, commented.Comments, 1)
+	assert.Equal(t, "wf-admin", commented.Comments[0].AuthorUsername)
+
+	result, err := svc.Cancel(ctx, request.ID, f.requester)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowCancelled, result.Status)
+	assert.Equal(t, 1, action.discarded)
+
+	_, err = svc.Cancel(ctx, request.ID, f.requester)
+	assert.True(t, errors.Is(err, ErrWorkflowNotPending))
+}
+
+// TC-WF-TIMEOUT-01: 기한 경과 → 상향 승인자 추가와 기한 재설정, 다시 경과 → expired 와 되돌림
+func TestWorkflowTimeouts_EscalateThenExpire(t *testing.T) {
+	svc, db, action := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	enableTestWorkflow(t, svc, testWorkflowType, model.WorkflowPolicy{
+		ApproverRules:   []model.WorkflowApproverRule{{Kind: model.WorkflowApproverOrgManager}},
+		TimeoutMinutes:  30,
+		EscalationRules: []model.WorkflowApproverRule{reviewerRule},
+	})
+	request := submitTestWorkflow(t, svc, f)
+	require.NotNil(t, request.DueAt)
+
+	ctx := context.Background()
+	policy, err := svc.Policy(testWorkflowType)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(svc.authorizeApprover(ctx, f.approver1, request, policy), ErrPermissionDenied), "상향 전")
+
+	handled, err := svc.ProcessTimeouts(ctx, time.Now().Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, handled, "기한 전")
+
+	now := time.Now().Add(31 * time.Minute)
+	handled, err = svc.ProcessTimeouts(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, handled, 1)
+	assert.Equal(t, model.WorkflowPending, handled[0].Status)
+	assert.Equal(t, 1, handled[0].EscalationLevel)
+	assert.True(t, handled[0].DueAt.After(now))
+	assert.NoError(t, svc.authorizeApprover(ctx, f.approver1, &handled[0], policy), "상향 후")
+
+	handled, err = svc.ProcessTimeouts(ctx, now.Add(31*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, handled, 1)
+	assert.Equal(t, model.WorkflowExpired, handled[0].Status)
+	assert.Equal(t, 1, action.discarded)
+}
+
+// ── 유형별 작업 ───────────────────────────────────────────────────────────────
+
+// TC-WF-INV-01: 초대 수락 승인 대기 → 승인 시 멤버 등록, 다른 초대는 거절 시 REJECTED
+func TestWorkflowInvitationAction(t *testing.T) {
+	svc, db, _ := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	invitationService := &WorkspaceInvitationService{
+		db:                db,
+		invitationRepo:    repository.NewWorkspaceInvitationRepository(db),
+		workspaceRepo:     repository.NewWorkspaceRepository(db),
+		userRepo:          repository.NewUserRepository(db),
+		workspaceRoleRepo: repository.NewWorkspaceRoleRepository(db),
+	}
+	svc.RegisterAction(model.WorkflowTypeWorkspaceInvitation, &invitationWorkflowAction{invitationService: invitationService})
+	enableTestWorkflow(t, svc, model.WorkflowTypeWorkspaceInvitation, model.WorkflowPolicy{})
+
+	member := createGRTestRole(t, db, "wf-member")
+	submit := func(invitee *model.User) (*model.WorkspaceInvitation, *model.WorkflowRequest) {
+		invitation, err := invitationService.SendInvitation(f.ws.ID, f.requester.ID, invitee.ID, &member.ID)
+		require.NoError(t, err)
+		invitation, err = invitationService.RequestApproval(invitation.ID, invitee.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.InvitationStatusPendingApproval, invitation.Status)
+		request, err := svc.Submit(&WorkflowSubmission{
+			Type:          model.WorkflowTypeWorkspaceInvitation,
+			Key:           "invitation:" + invitee.Username,
+			Payload:       model.WorkflowInvitationPayload{InvitationID: invitation.ID},
+			Requester:     invitee,
+			SubjectUserID: invitee.ID,
+			WorkspaceID:   f.ws.ID,
+		})
+		require.NoError(t, err)
+		return invitation, request
+	}
+
+	ctx := context.Background()
+	invitation, request := submit(f.subject)
+	result, err := svc.Decide(ctx, request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowApproved, result.Status, result.ResultError)
+	stored, err := repository.NewWorkspaceInvitationRepository(db).FindByID(invitation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.InvitationStatusAccepted, stored.Status)
+	assert.Equal(t, int64(1), countRows(t, db.Where("user_id = ? AND workspace_id = ?", f.subject.ID, f.ws.ID), &model.UserWorkspaceRole{}))
+
+	invitation, request = submit(f.outsider)
+	result, err = svc.Decide(ctx, request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionReject}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowRejected, result.Status)
+	stored, err = repository.NewWorkspaceInvitationRepository(db).FindByID(invitation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.InvitationStatusRejected, stored.Status)
+	assert.Equal(t, int64(0), countRows(t, db.Where("user_id = ?", f.outsider.ID), &model.UserWorkspaceRole{}))
+}
+
+// TC-WF-ROLE-01: 워크스페이스 역할 할당 요청 → 승인 시 할당 생성
+func TestWorkflowRoleAssignmentAction_WorkspaceRole(t *testing.T) {
+	svc, db, _ := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	svc.RegisterAction(model.WorkflowTypeRoleAssignment, &roleAssignmentWorkflowAction{
+		roleService: NewRoleService(db),
+		userRepo:    repository.NewUserRepository(db),
+		kcService:   &mockKeycloakService{},
+	})
+	enableTestWorkflow(t, svc, model.WorkflowTypeRoleAssignment, model.WorkflowPolicy{
+		ApproverRules: []model.WorkflowApproverRule{{Kind: model.WorkflowApproverWorkspaceAdmin}},
+	})
+	operator := createGRTestRole(t, db, "wf-operator")
+
+	request, err := svc.Submit(&WorkflowSubmission{
+		Type: model.WorkflowTypeRoleAssignment,
+		Key:  "workspace-role",
+		Payload: model.WorkflowRoleAssignmentPayload{
+			RoleType:    model.WorkflowRoleWorkspace,
+			UserID:      f.subject.ID,
+			RoleID:      operator.ID,
+			WorkspaceID: f.ws.ID,
+		},
+		Requester:     f.requester,
+		SubjectUserID: f.subject.ID,
+		WorkspaceID:   f.ws.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), countRows(t, db.Where("user_id = ?", f.subject.ID), &model.UserWorkspaceRole{}), "승인 전 미할당")
+
+	result, err := svc.Decide(context.Background(), request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove}, f.manager)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkflowApproved, result.Status, result.ResultError)
+	assert.Equal(t, int64(1), countRows(t, db.Where("user_id = ? AND workspace_id = ? AND role_id = ?", f.subject.ID, f.ws.ID, operator.ID), &model.UserWorkspaceRole{}))
+}
+
+// TC-WF-ROLE-02: 그룹 플랫폼 역할·그룹-워크스페이스 매핑·그룹 구성원 추가 요청 → 승인 시 반영
+func TestWorkflowRoleAssignmentAction_GroupRoles(t *testing.T) {
+	svc, db, _ := newTestWorkflowService(t)
+	f := setupWorkflowFixture(t, db)
+	svc.RegisterAction(model.WorkflowTypeRoleAssignment, &roleAssignmentWorkflowAction{
+		roleService: NewRoleService(db),
+		groupRoleService: &GroupRoleService{
+			db:            db,
+			groupRoleRepo: repository.NewGroupRoleRepository(db),
+			orgRepo:       repository.NewOrganizationRepository(db),
+			roleRepo:      repository.NewRoleRepository(db),
+			kcService:     &mockKeycloakService{},
+		},
+		userRepo:  repository.NewUserRepository(db),
+		kcService: &mockKeycloakService{},
+	})
+	enableTestWorkflow(t, svc, model.WorkflowTypeRoleAssignment, model.WorkflowPolicy{ApproverRules: []model.WorkflowApproverRule{reviewerRule}})
+	operator := createGRTestRole(t, db, "wf-group-operator")
+	group := createGRTestOrg(t, db, "wf-group", "WFG")
+	other := createGRTestOrg(t, db, "wf-group-2", "WFG2")
+
+	payloads := []model.WorkflowRoleAssignmentPayload{
+		{RoleType: model.WorkflowRoleGroupPlatform, GroupID: group.ID, RoleID: operator.ID},
+		{RoleType: model.WorkflowRoleGroupWorkspace, GroupID: group.ID, WorkspaceID: f.ws.ID, RoleID: operator.ID},
+		{RoleType: model.WorkflowRoleGroupMember, GroupID: group.ID, UserIDs: []uint{f.subject.ID}},
+		{RoleType: model.WorkflowRoleGroupMember, UserID: f.outsider.ID, GroupIDs: []uint{group.ID, other.ID}},
+	}
+	for i, payload := range payloads {
+		request, err := svc.Submit(&WorkflowSubmission{
+			Type:          model.WorkflowTypeRoleAssignment,
+			Key:           "group-" + strconv.Itoa(i),
+			Payload:       payload,
+			Requester:     f.requester,
+			SubjectUserID: payload.UserID,
+			WorkspaceID:   payload.WorkspaceID,
+		})
+		require.NoError(t, err)
+		result, err := svc.Decide(context.Background(), request.ID, &model.WorkflowDecisionRequest{Decision: model.WorkflowDecisionApprove}, f.approver1)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkflowApproved, result.Status, "%s: %s", payload.RoleType, result.ResultError)
+	}
+
+	assert.Equal(t, int64(1), countRows(t, db.Where("group_id = ? AND role_id = ?", group.ID, operator.ID), &model.GroupPlatformRole{}))
+	assert.Equal(t, int64(1), countRows(t, db.Where("group_id = ? AND workspace_id = ? AND role_id = ?", group.ID, f.ws.ID, operator.ID), &model.GroupWorkspaceRole{}))
+	assert.Equal(t, int64(1), countRows(t, db.Where("user_id = ? AND organization_id = ?", f.subject.ID, group.ID), &model.UserOrganization{}))
+	assert.Equal(t, int64(2), countRows(t, db.Where("user_id = ?", f.outsider.ID), &model.UserOrganization{}))
+}
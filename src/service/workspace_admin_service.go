@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// WorkspaceAdminPermission 워크스페이스 역할에 부여하면 그 워크스페이스의 구성원·초대·그룹 매핑을 위임 관리
+const WorkspaceAdminPermission = "mc-iam-manager:workspace:admin"
+
+var ErrRoleNotDelegable = errors.New("role is not delegable to workspace admins")
+
+// 위임 대상 작업별 플랫폼 권한 (보유하면 워크스페이스·역할 제한 없이 허용)
+const (
+	workspaceMemberPlatformPermission     = "mc-iam-manager:workspace:write"
+	workspaceRolePlatformPermission       = "mc-iam-manager:role:write"
+	workspaceInvitePlatformPermission     = "mc-iam-manager:invitation:write"
+	workspaceInviteReadPlatformPermission = "mc-iam-manager:invitation:read"
+	workspaceGroupPlatformPermission      = "mc-iam-manager:organization:write"
+)
+
+// WorkspaceAdminService 워크스페이스 구성원·역할·초대·그룹 매핑 관리의 권한 확인
+// 플랫폼 권한 보유자는 모든 워크스페이스를, 워크스페이스 관리자(WorkspaceAdminPermission)는 자신의 워크스페이스만 관리하며
+// 위임 관리자는 delegable 로 표시된 워크스페이스 역할만 부여·회수할 수 있다.
+type WorkspaceAdminService struct {
+	db                *gorm.DB
+	authzService      *AuthzService
+	roleRepo          *repository.RoleRepository
+	workspaceService  *WorkspaceService
+	roleService       *RoleService
+	invitationService *WorkspaceInvitationService
+	groupRoleService  *GroupRoleService
+}
+
+// NewWorkspaceAdminService WorkspaceAdminService 생성
+func NewWorkspaceAdminService(db *gorm.DB) *WorkspaceAdminService {
+	return &WorkspaceAdminService{
+		db:                db,
+		authzService:      NewAuthzService(db),
+		roleRepo:          repository.NewRoleRepository(db),
+		workspaceService:  NewWorkspaceService(db),
+		roleService:       NewRoleService(db),
+		invitationService: NewWorkspaceInvitationService(db),
+		groupRoleService:  NewGroupRoleService(db),
+	}
+}
+
+// authorize 플랫폼 권한이 있으면 제한 없이, 해당 워크스페이스의 관리자이면 위임 범위로 허용 (delegated=true)
+func (s *WorkspaceAdminService) authorize(ctx context.Context, actorID, workspaceID uint, platformPermission string) (bool, error) {
+	if actorID == 0 || workspaceID == 0 {
+		return false, ErrPermissionDenied
+	}
+	allowed, err := s.authzService.HasPermission(ctx, actorID, 0, platformPermission)
+	if err != nil {
+		return false, err
+	}
+	if allowed {
+		return false, nil
+	}
+	grants, err := s.authzService.GetWorkspaceRoleGrants(ctx, actorID, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	if len(grants) > 0 {
+		admin, err := s.authzService.HasPermission(ctx, actorID, workspaceID, WorkspaceAdminPermission)
+		if err != nil {
+			return false, err
+		}
+		if admin {
+			return true, nil
+		}
+	}
+	return false, ErrPermissionDenied
+}
+
+// requireDelegable 위임 관리자가 다룰 수 있는 역할인지 확인
+func (s *WorkspaceAdminService) requireDelegable(roleIDs ...uint) error {
+	for _, roleID := range roleIDs {
+		role, err := s.roleRepo.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			return repository.ErrRoleMasterNotFound
+		}
+		if !role.Delegable {
+			return fmt.Errorf("%w: %s", ErrRoleNotDelegable, role.Name)
+		}
+	}
+	return nil
+}
+
+// authorizeRoles 작업 권한 확인 후, 위임 관리자이면 관련 역할이 모두 delegable 인지 확인
+func (s *WorkspaceAdminService) authorizeRoles(ctx context.Context, actorID, workspaceID uint, platformPermission string, roleIDs ...uint) error {
+	delegated, err := s.authorize(ctx, actorID, workspaceID, platformPermission)
+	if err != nil {
+		return err
+	}
+	if !delegated {
+		return nil
+	}
+	return s.requireDelegable(roleIDs...)
+}
+
+// AddMember 워크스페이스에 사용자를 기본 워크스페이스 역할로 추가
+func (s *WorkspaceAdminService) AddMember(ctx context.Context, actorID, workspaceID, userID uint) error {
+	delegated, err := s.authorize(ctx, actorID, workspaceID, workspaceMemberPlatformPermission)
+	if err != nil {
+		return err
+	}
+	if delegated {
+		defaultRole, err := s.roleRepo.FindDefaultWorkspaceRole()
+		if err != nil {
+			return err
+		}
+		if err := s.requireDelegable(defaultRole.ID); err != nil {
+			return err
+		}
+	}
+	return s.workspaceService.AddUserToWorkspace(workspaceID, userID)
+}
+
+// RemoveMember 워크스페이스에서 사용자 제거 (위임 관리자는 사용자의 역할이 모두 delegable 일 때만)
+func (s *WorkspaceAdminService) RemoveMember(ctx context.Context, actorID, workspaceID, userID uint) error {
+	var roleIDs []uint
+	if err := s.db.Model(&model.UserWorkspaceRole{}).
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Pluck("role_id", &roleIDs).Error; err != nil {
+		return err
+	}
+	if err := s.authorizeRoles(ctx, actorID, workspaceID, workspaceMemberPlatformPermission, roleIDs...); err != nil {
+		return err
+	}
+	return s.workspaceService.RemoveUserFromWorkspace(workspaceID, userID)
+}
+
+// AuthorizeRoleAssignment 워크스페이스 역할 부여·회수 권한 확인 (승인 워크플로 요청 전 확인용)
+func (s *WorkspaceAdminService) AuthorizeRoleAssignment(ctx context.Context, actorID, workspaceID, roleID uint) error {
+	return s.authorizeRoles(ctx, actorID, workspaceID, workspaceRolePlatformPermission, roleID)
+}
+
+// AssignRole 워크스페이스 역할 부여
+func (s *WorkspaceAdminService) AssignRole(ctx context.Context, actorID, userID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
+	if err := s.AuthorizeRoleAssignment(ctx, actorID, workspaceID, roleID); err != nil {
+		return err
+	}
+	return s.roleService.AssignWorkspaceRoleWithValidity(userID, workspaceID, roleID, validity)
+}
+
+// RemoveRole 워크스페이스 역할 회수
+func (s *WorkspaceAdminService) RemoveRole(ctx context.Context, actorID, userID, workspaceID, roleID uint) error {
+	if err := s.AuthorizeRoleAssignment(ctx, actorID, workspaceID, roleID); err != nil {
+		return err
+	}
+	return s.roleService.RemoveWorkspaceRole(userID, workspaceID, roleID)
+}
+
+// SendInvitation 워크스페이스 초대 발송 (위임 관리자는 delegable 역할로만 초대)
+func (s *WorkspaceAdminService) SendInvitation(ctx context.Context, actorID, workspaceID, inviteeUserID uint, roleID *uint) (*model.WorkspaceInvitation, error) {
+	var roleIDs []uint
+	if roleID != nil {
+		roleIDs = append(roleIDs, *roleID)
+	}
+	if err := s.authorizeRoles(ctx, actorID, workspaceID, workspaceInvitePlatformPermission, roleIDs...); err != nil {
+		return nil, err
+	}
+	return s.invitationService.SendInvitation(workspaceID, actorID, inviteeUserID, roleID)
+}
+
+// ListInvitations 워크스페이스 초대 목록 조회
+func (s *WorkspaceAdminService) ListInvitations(ctx context.Context, actorID, workspaceID uint, status string) ([]model.WorkspaceInvitation, error) {
+	if _, err := s.authorize(ctx, actorID, workspaceID, workspaceInviteReadPlatformPermission); err != nil {
+		return nil, err
+	}
+	return s.invitationService.ListWorkspaceInvitations(workspaceID, status)
+}
+
+// groupWorkspaceRoleID 그룹-워크스페이스 매핑의 현재 역할 ID
+func (s *WorkspaceAdminService) groupWorkspaceRoleID(groupID, workspaceID uint) (uint, error) {
+	var binding model.GroupWorkspaceRole
+	err := s.db.Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).First(&binding).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, repository.ErrGroupWorkspaceRoleNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binding.RoleID, nil
+}
+
+// AuthorizeGroupBinding 그룹-워크스페이스 매핑 권한 확인 (플랫폼 권한 또는 워크스페이스 위임 관리자의 delegable 역할)
+func (s *WorkspaceAdminService) AuthorizeGroupBinding(ctx context.Context, actorID, workspaceID, roleID uint) error {
+	return s.authorizeRoles(ctx, actorID, workspaceID, workspaceGroupPlatformPermission, roleID)
+}
+
+// BindGroup 그룹을 워크스페이스에 매핑
+func (s *WorkspaceAdminService) BindGroup(ctx context.Context, actorID, groupID, workspaceID, roleID uint, validity model.AssignmentValidity) error {
+	if err := s.AuthorizeGroupBinding(ctx, actorID, workspaceID, roleID); err != nil {
+		return err
+	}
+	return s.groupRoleService.AssignGroupWorkspaceWithValidity(groupID, workspaceID, roleID, validity)
+}
+
+// UpdateGroupRole 그룹-워크스페이스 매핑의 역할 변경 (위임 관리자는 현재·변경 역할 모두 delegable 이어야 함)
+func (s *WorkspaceAdminService) UpdateGroupRole(ctx context.Context, actorID, groupID, workspaceID, roleID uint) error {
+	delegated, err := s.authorize(ctx, actorID, workspaceID, workspaceGroupPlatformPermission)
+	if err != nil {
+		return err
+	}
+	if delegated {
+		currentRoleID, err := s.groupWorkspaceRoleID(groupID, workspaceID)
+		if err != nil {
+			return err
+		}
+		if err := s.requireDelegable(currentRoleID, roleID); err != nil {
+			return err
+		}
+	}
+	return s.groupRoleService.UpdateGroupWorkspaceRole(groupID, workspaceID, roleID)
+}
+
+// UnbindGroup 그룹-워크스페이스 매핑 제거
+func (s *WorkspaceAdminService) UnbindGroup(ctx context.Context, actorID, groupID, workspaceID uint) error {
+	delegated, err := s.authorize(ctx, actorID, workspaceID, workspaceGroupPlatformPermission)
+	if err != nil {
+		return err
+	}
+	if delegated {
+		currentRoleID, err := s.groupWorkspaceRoleID(groupID, workspaceID)
+		if err != nil {
+			return err
+		}
+		if err := s.requireDelegable(currentRoleID); err != nil {
+			return err
+		}
+	}
+	return s.groupRoleService.RemoveGroupWorkspaceRole(groupID, workspaceID)
+}
+
+// ResolveUser Keycloak ID 로 요청자 DB 사용자 조회
+func (s *WorkspaceAdminService) ResolveUser(ctx context.Context, kcUserID string) (*model.User, error) {
+	return s.authzService.ResolveSubject(ctx, "", kcUserID)
+}
@@ -0,0 +1,182 @@
+package service
+
+// workspace_admin_service_test.go
+//
+// WorkspaceAdminService 단위 테스트 (SQLite in-memory DB)
+// 워크스페이스 관리자는 자신의 워크스페이스에서 delegable 역할만 다루고, 플랫폼 권한 보유자는 제한이 없는지 검증한다.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestWorkspaceAdminService(t *testing.T) (*WorkspaceAdminService, *gorm.DB) {
+	t.Helper()
+	db := setupAuthzTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.WorkspaceInvitation{}))
+	svc := &WorkspaceAdminService{
+		db:                db,
+		authzService:      newTestAuthz(db),
+		roleRepo:          repository.NewRoleRepository(db),
+		workspaceService:  NewWorkspaceService(db),
+		roleService:       NewRoleService(db),
+		invitationService: NewWorkspaceInvitationService(db),
+		groupRoleService: &GroupRoleService{
+			db:            db,
+			groupRoleRepo: repository.NewGroupRoleRepository(db),
+			orgRepo:       repository.NewOrganizationRepository(db),
+			roleRepo:      repository.NewRoleRepository(db),
+			sodService:    NewSodService(db),
+		},
+	}
+	return svc, db
+}
+
+// workspaceAdminFixture 워크스페이스 두 개, 위임 가능/불가 역할, 관리자·플랫폼 관리자·일반 구성원·대상자
+type workspaceAdminFixture struct {
+	ws        *model.Workspace
+	otherWs   *model.Workspace
+	delegable *model.RoleMaster // delegable 워크스페이스 역할
+	owner     *model.RoleMaster // 위임 불가 워크스페이스 역할
+	admin     *model.User       // ws 의 워크스페이스 관리자 (otherWs 에는 일반 구성원)
+	platform  *model.User       // 플랫폼 role:write, organization:write 보유
+	member    *model.User       // ws 구성원, 관리 권한 없음
+	target    *model.User
+}
+
+func setupWorkspaceAdminFixture(t *testing.T, db *gorm.DB) *workspaceAdminFixture {
+	t.Helper()
+	f := &workspaceAdminFixture{
+		ws:        createGRTestWorkspace(t, db, "wsa-ws"),
+		otherWs:   createGRTestWorkspace(t, db, "wsa-other"),
+		delegable: createGRTestRole(t, db, "wsa-developer"),
+		owner:     createGRTestRole(t, db, "wsa-owner"),
+		admin:     createGRTestUser(t, db, "wsa-admin", "kc-wsa-admin"),
+		platform:  createGRTestUser(t, db, "wsa-platform", "kc-wsa-platform"),
+		member:    createGRTestUser(t, db, "wsa-member", "kc-wsa-member"),
+		target:    createGRTestUser(t, db, "wsa-target", "kc-wsa-target"),
+	}
+	require.NoError(t, db.Model(f.delegable).Update("delegable", true).Error)
+
+	assignAuthzTestWorkspaceRole(t, db, f.admin, f.ws.ID, "wsa-workspace-admin", WorkspaceAdminPermission)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.admin.ID, WorkspaceID: f.otherWs.ID, RoleID: f.delegable.ID}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.member.ID, WorkspaceID: f.ws.ID, RoleID: f.delegable.ID}).Error)
+
+	assignAuthzTestPlatformRole(t, db, f.platform, "wsa-platform-admin", workspaceRolePlatformPermission, workspaceGroupPlatformPermission)
+	return f
+}
+
+// TC-WSA-01: 워크스페이스 관리자는 자신의 워크스페이스에서 delegable 역할을 부여·회수
+func TestWorkspaceAdmin_DelegatedAssignsDelegableRole(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, svc.AssignRole(ctx, f.admin.ID, f.target.ID, f.ws.ID, f.delegable.ID, model.AssignmentValidity{}))
+	var count int64
+	db.Model(&model.UserWorkspaceRole{}).Where("user_id = ? AND workspace_id = ? AND role_id = ?", f.target.ID, f.ws.ID, f.delegable.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, svc.RemoveRole(ctx, f.admin.ID, f.target.ID, f.ws.ID, f.delegable.ID))
+	db.Model(&model.UserWorkspaceRole{}).Where("user_id = ? AND workspace_id = ?", f.target.ID, f.ws.ID).Count(&count)
+	assert.Zero(t, count)
+}
+
+// TC-WSA-02: 위임 불가 역할, 다른 워크스페이스, 관리 권한 없는 구성원은 거부
+func TestWorkspaceAdmin_DelegatedScopeIsEnforced(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+	ctx := context.Background()
+
+	err := svc.AssignRole(ctx, f.admin.ID, f.target.ID, f.ws.ID, f.owner.ID, model.AssignmentValidity{})
+	assert.True(t, errors.Is(err, ErrRoleNotDelegable), "got %v", err)
+
+	err = svc.AssignRole(ctx, f.admin.ID, f.target.ID, f.otherWs.ID, f.delegable.ID, model.AssignmentValidity{})
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+
+	err = svc.AssignRole(ctx, f.member.ID, f.target.ID, f.ws.ID, f.delegable.ID, model.AssignmentValidity{})
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+
+	var count int64
+	db.Model(&model.UserWorkspaceRole{}).Where("user_id = ?", f.target.ID).Count(&count)
+	assert.Zero(t, count)
+}
+
+// TC-WSA-03: 플랫폼 권한 보유자는 워크스페이스·역할 제한 없이 부여
+func TestWorkspaceAdmin_PlatformPermissionIsUnrestricted(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+
+	require.NoError(t, svc.AssignRole(context.Background(), f.platform.ID, f.target.ID, f.otherWs.ID, f.owner.ID, model.AssignmentValidity{}))
+}
+
+// TC-WSA-04: 위임 불가 역할을 가진 구성원은 워크스페이스 관리자가 제거할 수 없음
+func TestWorkspaceAdmin_RemoveMemberRequiresDelegableRoles(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+	ctx := context.Background()
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.target.ID, WorkspaceID: f.ws.ID, RoleID: f.owner.ID}).Error)
+
+	err := svc.RemoveMember(ctx, f.admin.ID, f.ws.ID, f.target.ID)
+	assert.True(t, errors.Is(err, ErrRoleNotDelegable), "got %v", err)
+
+	require.NoError(t, svc.RemoveMember(ctx, f.admin.ID, f.ws.ID, f.member.ID))
+	var count int64
+	db.Model(&model.UserWorkspaceRole{}).Where("user_id = ? AND workspace_id = ?", f.member.ID, f.ws.ID).Count(&count)
+	assert.Zero(t, count)
+}
+
+// TC-WSA-05: 초대는 delegable 역할로만, 그룹 매핑 변경·제거는 현재 역할도 delegable 이어야 함
+func TestWorkspaceAdmin_InvitationsAndGroupBindings(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+	ctx := context.Background()
+
+	_, err := svc.SendInvitation(ctx, f.admin.ID, f.ws.ID, f.target.ID, &f.owner.ID)
+	assert.True(t, errors.Is(err, ErrRoleNotDelegable), "got %v", err)
+	invitation, err := svc.SendInvitation(ctx, f.admin.ID, f.ws.ID, f.target.ID, &f.delegable.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.admin.ID, invitation.InviterUserID)
+	_, err = svc.ListInvitations(ctx, f.member.ID, f.ws.ID, "")
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+
+	group := createGRTestOrg(t, db, "wsa-group", "WSA")
+	require.NoError(t, svc.BindGroup(ctx, f.platform.ID, group.ID, f.ws.ID, f.owner.ID, model.AssignmentValidity{}))
+	err = svc.UpdateGroupRole(ctx, f.admin.ID, group.ID, f.ws.ID, f.delegable.ID)
+	assert.True(t, errors.Is(err, ErrRoleNotDelegable), "got %v", err)
+	err = svc.UnbindGroup(ctx, f.admin.ID, group.ID, f.ws.ID)
+	assert.True(t, errors.Is(err, ErrRoleNotDelegable), "got %v", err)
+
+	require.NoError(t, svc.UpdateGroupRole(ctx, f.platform.ID, group.ID, f.ws.ID, f.delegable.ID))
+	require.NoError(t, svc.UnbindGroup(ctx, f.admin.ID, group.ID, f.ws.ID))
+}
+
+// TC-WSA-06: platformAdmin(플랫폼 역할 할당 또는 토큰 realm role)은 권한 매핑 없이도 제한 없이 관리
+func TestWorkspaceAdmin_PlatformAdminIsUnrestricted(t *testing.T) {
+	svc, db := newTestWorkspaceAdminService(t)
+	f := setupWorkspaceAdminFixture(t, db)
+
+	// 비상 접근처럼 DB 에 platformAdmin 플랫폼 역할이 할당된 경우
+	breakGlass := createGRTestUser(t, db, "wsa-break-glass", "kc-wsa-break-glass")
+	platformAdmin := createGRTestRole(t, db, platformAdminRoleName)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: breakGlass.ID, RoleID: platformAdmin.ID}).Error)
+	require.NoError(t, svc.AssignRole(context.Background(), breakGlass.ID, f.target.ID, f.otherWs.ID, f.owner.ID, model.AssignmentValidity{}))
+
+	// DB 할당 없이 토큰에만 platformAdmin 이 있는 초기 관리자
+	bootstrap := createGRTestUser(t, db, "wsa-bootstrap", "kc-wsa-bootstrap")
+	ctx := WithPlatformAdminSubject(context.Background(), bootstrap.KcId)
+	_, err := svc.ListInvitations(ctx, bootstrap.ID, f.otherWs.ID, "")
+	require.NoError(t, err)
+	require.NoError(t, svc.RemoveRole(ctx, bootstrap.ID, f.target.ID, f.otherWs.ID, f.owner.ID))
+
+	// 다른 사용자의 권한 확인에는 요청자의 platformAdmin 이 적용되지 않음
+	err = svc.AssignRole(ctx, f.member.ID, f.target.ID, f.ws.ID, f.delegable.ID, model.AssignmentValidity{})
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "got %v", err)
+}
@@ -98,8 +98,11 @@ func (s *WorkspaceInvitationService) AcceptInvitation(invitationID, userID uint)
 	}
 
 	return s.db.Transaction(func(tx *gorm.DB) error {
-		// 워크스페이스 멤버로 등록
+		// 워크스페이스 멤버로 등록 (직무 분리 제약 확인 후)
 		if invitation.RoleID != nil {
+			if err := NewSodService(tx).CheckWorkspaceRoleAssignment(userID, invitation.WorkspaceID, *invitation.RoleID); err != nil {
+				return err
+			}
 			userWsRole := model.UserWorkspaceRole{
 				UserID:      userID,
 				WorkspaceID: invitation.WorkspaceID,
@@ -116,6 +119,31 @@ func (s *WorkspaceInvitationService) AcceptInvitation(invitationID, userID uint)
 	})
 }
 
+// RequestApproval 초대 수락을 관리자 승인 대기로 전환 (초대받은 사용자, PENDING → PENDING_APPROVAL)
+// 가입 승인 워크플로가 켜져 있을 때 AcceptInvitation 대신 사용하며, 승인되면 ApproveInvitation 으로 멤버가 된다.
+func (s *WorkspaceInvitationService) RequestApproval(invitationID, userID uint) (*model.WorkspaceInvitation, error) {
+	invitation, err := s.invitationRepo.FindByID(invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("invitation not found: %w", err)
+	}
+	if invitation.InviteeUserID != userID {
+		return nil, errors.New("forbidden: not your invitation")
+	}
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, fmt.Errorf("invitation is not in PENDING state (current: %s)", invitation.Status)
+	}
+	if err := s.invitationRepo.UpdateStatus(invitationID, model.InvitationStatusPendingApproval); err != nil {
+		return nil, err
+	}
+	invitation.Status = model.InvitationStatusPendingApproval
+	return invitation, nil
+}
+
+// ReopenInvitation 승인 대기 초대를 다시 PENDING 으로 되돌림 (승인 요청 생성 실패 시)
+func (s *WorkspaceInvitationService) ReopenInvitation(invitationID uint) error {
+	return s.invitationRepo.UpdateStatus(invitationID, model.InvitationStatusPending)
+}
+
 // RejectInvitation 초대 거절 (초대받은 사용자)
 func (s *WorkspaceInvitationService) RejectInvitation(invitationID, userID uint) error {
 	invitation, err := s.invitationRepo.FindByID(invitationID)
@@ -148,6 +176,9 @@ func (s *WorkspaceInvitationService) ApproveInvitation(invitationID uint) error
 
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		if invitation.RoleID != nil {
+			if err := NewSodService(tx).CheckWorkspaceRoleAssignment(invitation.InviteeUserID, invitation.WorkspaceID, *invitation.RoleID); err != nil {
+				return err
+			}
 			userWsRole := model.UserWorkspaceRole{
 				UserID:      invitation.InviteeUserID,
 				WorkspaceID: invitation.WorkspaceID,
@@ -20,6 +20,7 @@ type WorkspaceService struct {
 	userRepo          *repository.UserRepository
 	workspaceRoleRepo *repository.WorkspaceRoleRepository
 	projectRepo       *repository.ProjectRepository
+	sodService        *SodService // nil 이면 직무 분리 검사를 하지 않음
 }
 
 // NewWorkspaceService 새 WorkspaceService 인스턴스 생성
@@ -37,6 +38,7 @@ func NewWorkspaceService(db *gorm.DB) *WorkspaceService {
 		roleRepo:          roleRepo,
 		userRepo:          userRepo,
 		workspaceRoleRepo: workspaceRoleRepo,
+		sodService:        NewSodService(db),
 	}
 }
 
@@ -67,6 +69,9 @@ func (s *WorkspaceService) UpdateWorkspace(workspace *model.Workspace) error {
 	if workspace.Description != "" {
 		updates["description"] = workspace.Description
 	}
+	if workspace.Labels != nil {
+		updates["labels"] = workspace.Labels // 조건부 권한 매핑의 workspaceLabels 평가 대상
+	}
 
 	return s.workspaceRepo.UpdateWorkspace(workspace.ID, updates)
 }
@@ -226,6 +231,11 @@ func (s *WorkspaceService) AssignWorkspaceRole(userID, workspaceID, roleID uint)
 		return fmt.Errorf("워크스페이스 역할이 아닙니다")
 	}
 
+	// 직무 분리 제약 확인
+	if err := s.sodService.CheckWorkspaceRoleAssignment(userID, workspaceID, roleID); err != nil {
+		return err
+	}
+
 	// 역할 할당
 	return s.roleRepo.AssignWorkspaceRole(userID, workspaceID, roleID)
 }
@@ -262,17 +272,18 @@ func (s *WorkspaceService) AddUserToWorkspace(workspaceID, userID uint) error {
 		return err
 	}
 
-	// 사용자를 워크스페이스에 추가
-	uwr := model.UserWorkspaceRole{
-		UserID:      userID,
-		WorkspaceID: workspaceID,
-		RoleID:      defaultRole.ID,
-	}
-	if err := s.userRepo.CreateUserWorkspaceRole(&uwr); err != nil {
-		return err
-	}
-
-	return nil
+	// 직무 분리 제약 확인 후 사용자를 워크스페이스에 추가
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewSodService(tx).CheckWorkspaceRoleAssignment(userID, workspaceID, defaultRole.ID); err != nil {
+			return err
+		}
+		uwr := model.UserWorkspaceRole{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			RoleID:      defaultRole.ID,
+		}
+		return repository.NewUserRepository(tx).CreateUserWorkspaceRole(&uwr)
+	})
 }
 
 // RemoveUserFromWorkspace 워크스페이스에서 사용자를 제거합니다.
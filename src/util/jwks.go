@@ -0,0 +1,274 @@
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/config"
+)
+
+// ErrUnknownKid JWKS 에 토큰 kid 에 해당하는 서명 키가 없음
+var ErrUnknownKid = errors.New("signing key not found for kid")
+
+const (
+	defaultJWKSRefreshInterval = 10 * time.Minute
+	// 알 수 없는 kid 로 인한 재조회 최소 간격 (위조 kid 로 Keycloak 을 반복 호출하지 않도록)
+	jwksMinRefreshGap = 10 * time.Second
+)
+
+// JWKSFetcher realm JWKS 조회 함수
+type JWKSFetcher func(ctx context.Context) (*gocloak.CertResponse, error)
+
+// jwksKey JWKS 의 서명 키 하나
+type jwksKey struct {
+	alg string // JWKS 에 명시된 알고리즘 (없으면 빈 문자열)
+	key crypto.PublicKey
+}
+
+// JWKSCache realm 서명 키를 kid 별로 보관하는 캐시
+// 주기적으로 갱신하고, 모르는 kid 가 들어오면 즉시 재조회하여 Keycloak 키 순환을 재시작 없이 반영한다.
+type JWKSCache struct {
+	fetch           JWKSFetcher
+	refreshInterval time.Duration
+	minRefreshGap   time.Duration
+
+	refreshMu sync.Mutex // 동시 재조회 방지
+
+	mu          sync.RWMutex
+	keys        map[string]jwksKey
+	lastAttempt time.Time
+}
+
+// NewJWKSCache JWKSCache 생성 (refreshInterval <= 0 이면 백그라운드 갱신 안 함)
+func NewJWKSCache(fetch JWKSFetcher, refreshInterval time.Duration) *JWKSCache {
+	return &JWKSCache{
+		fetch:           fetch,
+		refreshInterval: refreshInterval,
+		minRefreshGap:   jwksMinRefreshGap,
+		keys:            make(map[string]jwksKey),
+	}
+}
+
+var (
+	defaultJWKSOnce  sync.Once
+	defaultJWKSCache *JWKSCache
+)
+
+// DefaultJWKSCache config.KC realm 의 JWKS 캐시 (프로세스 공용)
+func DefaultJWKSCache() *JWKSCache {
+	defaultJWKSOnce.Do(func() {
+		interval := defaultJWKSRefreshInterval
+		if raw := os.Getenv("MC_IAM_MANAGER_JWKS_REFRESH_INTERVAL"); raw != "" {
+			if d, err := time.ParseDuration(raw); err == nil {
+				interval = d
+			} else {
+				log.Printf("[WARN] invalid MC_IAM_MANAGER_JWKS_REFRESH_INTERVAL=%q, using default %s", raw, defaultJWKSRefreshInterval)
+			}
+		}
+		defaultJWKSCache = NewJWKSCache(fetchKeycloakJWKS, interval)
+	})
+	return defaultJWKSCache
+}
+
+// fetchKeycloakJWKS Keycloak certs 엔드포인트 직접 조회
+// gocloak GetCerts 는 자체 캐시(10분)를 거치므로 키 순환 직후 새 kid 를 받지 못한다.
+func fetchKeycloakJWKS(ctx context.Context) (*gocloak.CertResponse, error) {
+	if config.KC == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.KC.CertsURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+	var certs gocloak.CertResponse
+	if err := json.NewDecoder(resp.Body).Decode(&certs); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+	return &certs, nil
+}
+
+// Start 최초 조회 후 refreshInterval 마다 백그라운드 갱신 (ctx 취소 시 종료)
+// 최초 조회 실패는 반환하지만 갱신 루프는 계속 동작한다.
+func (c *JWKSCache) Start(ctx context.Context) error {
+	err := c.Refresh(ctx)
+	if c.refreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(c.refreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := c.Refresh(ctx); err != nil {
+						log.Printf("[WARN] JWKS refresh failed, keeping %d cached keys: %v", c.Len(), err)
+					}
+				}
+			}
+		}()
+	}
+	return err
+}
+
+// Refresh JWKS 재조회 후 키 목록 교체 (조회 실패 시 기존 키 유지)
+func (c *JWKSCache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+func (c *JWKSCache) refreshLocked(ctx context.Context) error {
+	c.mu.Lock()
+	c.lastAttempt = time.Now()
+	c.mu.Unlock()
+
+	certs, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]jwksKey)
+	if certs != nil && certs.Keys != nil {
+		for _, k := range *certs.Keys {
+			if k.Kid == nil || *k.Kid == "" {
+				continue
+			}
+			if k.Use != nil && *k.Use != "" && *k.Use != "sig" {
+				continue
+			}
+			key, err := parseJWK(k)
+			if err != nil {
+				log.Printf("[WARN] skipping JWKS key %s: %v", *k.Kid, err)
+				continue
+			}
+			keys[*k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no usable signing keys in JWKS")
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+	return nil
+}
+
+// Key kid 에 해당하는 공개키 조회, 없으면 최소 간격을 지켜 JWKS 를 재조회한 뒤 다시 찾는다.
+func (c *JWKSCache) Key(ctx context.Context, kid string) (crypto.PublicKey, string, error) {
+	if key, ok := c.lookup(kid); ok {
+		return key.key, key.alg, nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	// 대기 중 다른 요청이 이미 갱신했을 수 있음
+	if key, ok := c.lookup(kid); ok {
+		return key.key, key.alg, nil
+	}
+	c.mu.RLock()
+	recent := time.Since(c.lastAttempt) < c.minRefreshGap
+	c.mu.RUnlock()
+	if !recent {
+		if err := c.refreshLocked(ctx); err != nil {
+			log.Printf("[WARN] JWKS refresh for unknown kid %s failed: %v", kid, err)
+		} else if key, ok := c.lookup(kid); ok {
+			return key.key, key.alg, nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: %s", ErrUnknownKid, kid)
+}
+
+// Len 캐시된 키 개수
+func (c *JWKSCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.keys)
+}
+
+func (c *JWKSCache) lookup(kid string) (jwksKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+// parseJWK JWKS 키를 공개키로 변환 (RSA, EC P-256 지원)
+func parseJWK(k gocloak.CertResponseKey) (jwksKey, error) {
+	key := jwksKey{}
+	if k.Alg != nil {
+		key.alg = *k.Alg
+	}
+	kty := ""
+	if k.Kty != nil {
+		kty = *k.Kty
+	}
+	switch kty {
+	case "RSA":
+		if k.N == nil || k.E == nil {
+			return key, fmt.Errorf("missing modulus or exponent")
+		}
+		n, err := base64.RawURLEncoding.DecodeString(*k.N)
+		if err != nil {
+			return key, fmt.Errorf("failed to decode modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(*k.E)
+		if err != nil {
+			return key, fmt.Errorf("failed to decode exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return key, fmt.Errorf("invalid exponent")
+		}
+		key.key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	case "EC":
+		if k.Crv == nil || *k.Crv != "P-256" {
+			return key, fmt.Errorf("unsupported curve")
+		}
+		if k.X == nil || k.Y == nil {
+			return key, fmt.Errorf("missing curve point")
+		}
+		x, err := base64.RawURLEncoding.DecodeString(*k.X)
+		if err != nil {
+			return key, fmt.Errorf("failed to decode x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(*k.Y)
+		if err != nil {
+			return key, fmt.Errorf("failed to decode y: %w", err)
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return key, fmt.Errorf("invalid P-256 coordinate length")
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return key, fmt.Errorf("invalid P-256 point: %w", err)
+		}
+		key.key = pub
+	default:
+		return key, fmt.Errorf("unsupported key type %q", kty)
+	}
+	return key, nil
+}
@@ -1,50 +1,86 @@
 package util
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"fmt"
 	"log"
-
-	"crypto/rsa"
+	"slices"
+	"sync"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/m-cmp/mc-iam-manager/config"
 )
 
+// tokenSigningMethods 허용하는 서명 알고리즘
+var tokenSigningMethods = []string{
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodPS256.Alg(),
+	jwt.SigningMethodES256.Alg(),
+}
+
+// TokenValidator JWKS 서명 키와 realm/클라이언트 설정으로 액세스 토큰을 검증
+type TokenValidator struct {
+	jwks      *JWKSCache
+	issuers   []string
+	audiences []string
+}
+
+// NewTokenValidator TokenValidator 생성
+// iss 는 issuers 중 하나여야 하고, aud 또는 azp 중 하나가 audiences 에 포함되어야 한다.
+func NewTokenValidator(jwks *JWKSCache, issuers, audiences []string) *TokenValidator {
+	return &TokenValidator{jwks: jwks, issuers: issuers, audiences: audiences}
+}
+
+var (
+	defaultValidatorOnce sync.Once
+	defaultValidator     *TokenValidator
+)
+
 // ValidateToken은 JWT 토큰을 검증하고 claims를 반환합니다.
 func ValidateToken(tokenString string) (*jwt.MapClaims, error) {
-	// 토큰 파싱
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// 서명 알고리즘 확인
-		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
+	if config.KC == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	defaultValidatorOnce.Do(func() {
+		defaultValidator = NewTokenValidator(DefaultJWKSCache(), config.KC.Issuers(), config.KC.Audiences())
+	})
+	return defaultValidator.Validate(context.Background(), tokenString)
+}
 
-		// 토큰의 kid 확인
+// Validate 서명(kid 별 키), 만료, iss, aud/azp 를 검증하고 claims 를 반환
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		kid, ok := token.Header["kid"].(string)
-		if !ok {
+		if !ok || kid == "" {
 			return nil, fmt.Errorf("kid header not found")
 		}
-		log.Printf("[DEBUG] Token KID: %s", kid)
-
-		// Keycloak 공개키 가져오기
-		publicKey, err := config.KC.GetPublicKey()
+		key, keyAlg, err := v.jwks.Key(ctx, kid)
 		if err != nil {
-			log.Printf("[DEBUG] Failed to get public key: %v", err)
-			return nil, fmt.Errorf("failed to get public key: %v", err)
+			return nil, err
 		}
-
-		// 공개키 정보 로깅
-		log.Printf("[DEBUG] Public Key Type: %T", publicKey)
-		if rsaKey, ok := publicKey.(*rsa.PublicKey); ok {
-			log.Printf("[DEBUG] RSA Key Size: %d bits", rsaKey.Size()*8)
+		// 키에 명시된 알고리즘과 다르거나 키 유형이 맞지 않는 알고리즘은 거부
+		alg := token.Method.Alg()
+		if keyAlg != "" && keyAlg != alg {
+			return nil, fmt.Errorf("token alg %s does not match key %s alg %s", alg, kid, keyAlg)
 		}
-
-		return publicKey, nil
-	})
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if alg != jwt.SigningMethodRS256.Alg() && alg != jwt.SigningMethodPS256.Alg() {
+				return nil, fmt.Errorf("token alg %s cannot use RSA key %s", alg, kid)
+			}
+		case *ecdsa.PublicKey:
+			if alg != jwt.SigningMethodES256.Alg() {
+				return nil, fmt.Errorf("token alg %s cannot use EC key %s", alg, kid)
+			}
+		}
+		return key, nil
+	}, jwt.WithValidMethods(tokenSigningMethods))
 
 	if err != nil {
 		log.Printf("[DEBUG] Token validation error: %v", err)
-		return nil, fmt.Errorf("failed to parse token: %v", err)
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	if !token.Valid {
@@ -57,10 +93,26 @@ func ValidateToken(tokenString string) (*jwt.MapClaims, error) {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
-	// 토큰 정보 로깅
-	log.Printf("[DEBUG] Token Claims - Issuer: %v", claims["iss"])
-	log.Printf("[DEBUG] Token Claims - Audience: %v", claims["aud"])
-	log.Printf("[DEBUG] Token Claims - Subject: %v", claims["sub"])
+	// 만료 시각이 없는 토큰은 거부 (exp 가 있으면 Parse 에서 이미 검증됨)
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return nil, fmt.Errorf("token has no expiration")
+	}
+
+	iss, _ := claims.GetIssuer()
+	if !slices.Contains(v.issuers, iss) {
+		return nil, fmt.Errorf("unexpected token issuer: %s", iss)
+	}
+
+	// Keycloak 액세스 토큰의 aud 에는 요청 클라이언트가 없을 수 있으므로 azp 도 함께 확인
+	aud, _ := claims.GetAudience()
+	azp, _ := claims["azp"].(string)
+	if !slices.ContainsFunc(aud, v.trustedAudience) && !v.trustedAudience(azp) {
+		return nil, fmt.Errorf("token not issued for this client: aud=%v azp=%s", aud, azp)
+	}
 
 	return &claims, nil
 }
+
+func (v *TokenValidator) trustedAudience(client string) bool {
+	return client != "" && slices.Contains(v.audiences, client)
+}
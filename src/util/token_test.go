@@ -0,0 +1,209 @@
+package util
+
+// token_test.go
+//
+// JWKS 기반 액세스 토큰 검증 테스트
+// kid 별 키 선택, 키 순환 시 재조회, 알고리즘/iss/aud/azp 검증을 확인한다.
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer = "http://keycloak:8080/auth/realms/mciam"
+	testClient = "mciamClient"
+)
+
+// fakeJWKS 테스트용 JWKS 엔드포인트 (키 목록 교체, 조회 횟수 기록)
+type fakeJWKS struct {
+	mu      sync.Mutex
+	keys    []gocloak.CertResponseKey
+	fetches int
+}
+
+func (f *fakeJWKS) set(keys ...gocloak.CertResponseKey) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = keys
+}
+
+func (f *fakeJWKS) fetch(ctx context.Context) (*gocloak.CertResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetches++
+	keys := append([]gocloak.CertResponseKey(nil), f.keys...)
+	return &gocloak.CertResponse{Keys: &keys}, nil
+}
+
+func rsaJWK(t *testing.T, kid, alg string) (gocloak.CertResponseKey, crypto.Signer) {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return gocloak.CertResponseKey{
+		Kid: gocloak.StringP(kid),
+		Kty: gocloak.StringP("RSA"),
+		Alg: gocloak.StringP(alg),
+		Use: gocloak.StringP("sig"),
+		N:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(priv.N.Bytes())),
+		E:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.E)).Bytes())),
+	}, priv
+}
+
+func ecJWK(t *testing.T, kid string) (gocloak.CertResponseKey, crypto.Signer) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	point, err := priv.PublicKey.Bytes()
+	require.NoError(t, err)
+	return gocloak.CertResponseKey{
+		Kid: gocloak.StringP(kid),
+		Kty: gocloak.StringP("EC"),
+		Alg: gocloak.StringP("ES256"),
+		Use: gocloak.StringP("sig"),
+		Crv: gocloak.StringP("P-256"),
+		X:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(point[1:33])),
+		Y:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(point[33:])),
+	}, priv
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key crypto.Signer, claims jwt.MapClaims) string {
+	t.Helper()
+	base := jwt.MapClaims{
+		"iss": testIssuer,
+		"sub": "kc-user-01",
+		"azp": testClient,
+		"aud": "account",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(base, k)
+			continue
+		}
+		base[k] = v
+	}
+	token := jwt.NewWithClaims(method, base)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestTokenValidator(jwks *fakeJWKS) *TokenValidator {
+	return NewTokenValidator(NewJWKSCache(jwks.fetch, 0), []string{testIssuer}, []string{testClient})
+}
+
+// TC-TOKEN-01: RS256/PS256/ES256 토큰을 kid 에 맞는 키로 검증
+func TestTokenValidator_SupportedAlgorithms(t *testing.T) {
+	rsJWK, rsKey := rsaJWK(t, "rs-1", "RS256")
+	psJWK, psKey := rsaJWK(t, "ps-1", "PS256")
+	esJWK, esKey := ecJWK(t, "es-1")
+	jwks := &fakeJWKS{}
+	jwks.set(rsJWK, psJWK, esJWK)
+	v := newTestTokenValidator(jwks)
+
+	for _, tc := range []struct {
+		method jwt.SigningMethod
+		kid    string
+		key    crypto.Signer
+	}{
+		{jwt.SigningMethodRS256, "rs-1", rsKey},
+		{jwt.SigningMethodPS256, "ps-1", psKey},
+		{jwt.SigningMethodES256, "es-1", esKey},
+	} {
+		claims, err := v.Validate(context.Background(), signTestToken(t, tc.method, tc.kid, tc.key, nil))
+		require.NoError(t, err, tc.method.Alg())
+		sub, _ := claims.GetSubject()
+		assert.Equal(t, "kc-user-01", sub)
+	}
+	assert.Equal(t, 1, jwks.fetches)
+}
+
+// TC-TOKEN-02: 키 순환 → 새 kid 토큰이 들어오면 즉시 재조회, 최소 간격 내 반복 재조회는 하지 않음
+func TestTokenValidator_KeyRotation(t *testing.T) {
+	oldJWK, oldKey := rsaJWK(t, "old", "RS256")
+	newJWK, newKey := rsaJWK(t, "new", "RS256")
+	jwks := &fakeJWKS{}
+	jwks.set(oldJWK)
+	v := newTestTokenValidator(jwks)
+	require.NoError(t, v.jwks.Refresh(context.Background()))
+	v.jwks.minRefreshGap = 0
+
+	jwks.set(oldJWK, newJWK)
+	_, err := v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS256, "new", newKey, nil))
+	require.NoError(t, err)
+	_, err = v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS256, "old", oldKey, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 2, jwks.fetches)
+
+	// 알 수 없는 kid 반복 → 최소 간격 내에는 재조회하지 않음
+	v.jwks.minRefreshGap = time.Hour
+	for i := 0; i < 3; i++ {
+		_, err = v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS256, "forged", newKey, nil))
+		assert.True(t, errors.Is(err, ErrUnknownKid), "%v", err)
+	}
+	assert.Equal(t, 2, jwks.fetches)
+}
+
+// TC-TOKEN-03: 허용하지 않는 알고리즘, 키와 맞지 않는 알고리즘 → 거부
+func TestTokenValidator_RejectsAlgorithmMismatch(t *testing.T) {
+	rsJWK, rsKey := rsaJWK(t, "rs-1", "RS256")
+	jwks := &fakeJWKS{}
+	jwks.set(rsJWK)
+	v := newTestTokenValidator(jwks)
+
+	// RS256 로 공개된 키를 PS256 서명에 사용
+	_, err := v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodPS256, "rs-1", rsKey, nil))
+	assert.Error(t, err)
+
+	// RS512 는 허용 목록에 없음
+	_, err = v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS512, "rs-1", rsKey, nil))
+	assert.Error(t, err)
+
+	// HS256 (공개키를 비밀키로 쓰는 알고리즘 혼동 공격)
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": testIssuer, "azp": testClient, "exp": time.Now().Add(time.Minute).Unix()})
+	hs.Header["kid"] = "rs-1"
+	signed, err := hs.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = v.Validate(context.Background(), signed)
+	assert.Error(t, err)
+}
+
+// TC-TOKEN-04: iss, aud/azp, exp 검증
+func TestTokenValidator_Claims(t *testing.T) {
+	rsJWK, rsKey := rsaJWK(t, "rs-1", "RS256")
+	jwks := &fakeJWKS{}
+	jwks.set(rsJWK)
+	v := newTestTokenValidator(jwks)
+
+	// aud 에 클라이언트가 있으면 azp 가 달라도 허용
+	_, err := v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS256, "rs-1", rsKey, jwt.MapClaims{"azp": "other-app", "aud": []string{"account", testClient}}))
+	assert.NoError(t, err)
+
+	for name, claims := range map[string]jwt.MapClaims{
+		"other realm":  {"iss": "http://keycloak:8080/auth/realms/other"},
+		"other client": {"azp": "other-app"},
+		"no azp":       {"azp": nil},
+		"expired":      {"exp": time.Now().Add(-time.Minute).Unix()},
+		"no exp":       {"exp": nil},
+	} {
+		_, err := v.Validate(context.Background(), signTestToken(t, jwt.SigningMethodRS256, "rs-1", rsKey, claims))
+		assert.Error(t, err, name)
+	}
+}